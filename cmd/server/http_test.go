@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/crawl"
+	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+const testToken = "secret-token"
+
+type stubConfig struct{}
+
+func (stubConfig) Validate() error    { return nil }
+func (stubConfig) RateLimit() float64 { return 0 }
+
+// stubPlatform 返回 limit 篇固定论文
+type stubPlatform struct{}
+
+func (stubPlatform) Name() string               { return "stub" }
+func (stubPlatform) GetConfig() platform.Config { return stubConfig{} }
+func (stubPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	papers := make([]*models.Paper, q.Limit)
+	for i := range papers {
+		papers[i] = &models.Paper{
+			Source:   "stub",
+			SourceID: fmt.Sprintf("stub-%d", i),
+			URL:      fmt.Sprintf("https://example.com/stub-%d", i),
+			Title:    fmt.Sprintf("Graph Paper %d", i),
+			Abstract: "Message passing on graphs.",
+		}
+	}
+	return platform.Result{Total: len(papers), Papers: papers}, nil
+}
+
+func init() {
+	core.MustRegister(core.Provider{
+		Name:          "stub",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return stubPlatform{}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func newTestServer(t *testing.T) (*server, http.Handler) {
+	t.Helper()
+	app, err := core.NewApp(filepath.Join(t.TempDir(), "test.db"), emb.EmbedderConfig{}, map[string]platform.Config{}, core.ZoteroConfig{}, core.FeiShuConfig{}, core.NotionConfig{})
+	if err != nil {
+		t.Fatalf("NewApp() error: %v", err)
+	}
+	t.Cleanup(func() { app.Close() })
+	s := newServer(app, testToken, t.TempDir(), t.TempDir())
+	return s, s.routes()
+}
+
+func do(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.NewDecoder(rec.Body).Decode(v); err != nil {
+		t.Fatalf("decode response error: %v", err)
+	}
+}
+
+// crawlStub 通过 API 爬取 n 篇论文并等待任务完成
+func crawlStub(t *testing.T, h http.Handler, n int) *crawl.Task {
+	t.Helper()
+	rec := do(t, h, "POST", "/api/crawl", fmt.Sprintf(`{"platform":"stub","params":{"keywords":["graph"],"limit":%d}}`, n))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("POST /api/crawl status = %d, body %s", rec.Code, rec.Body)
+	}
+	var started map[string]string
+	decode(t, rec, &started)
+	if started["taskId"] == "" {
+		t.Fatal("Expected taskId in response")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		rec := do(t, h, "GET", "/api/crawl/"+started["taskId"], "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /api/crawl status = %d", rec.Code)
+		}
+		task := &crawl.Task{}
+		decode(t, rec, task)
+		if task.Status == "completed" || task.Status == "failed" {
+			return task
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("crawl task did not finish in time")
+	return nil
+}
+
+func TestAuth(t *testing.T) {
+	_, h := newTestServer(t)
+	for _, header := range []string{"", "Bearer wrong", testToken} {
+		req := httptest.NewRequest("GET", "/api/papers", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: status = %d, want 401", header, rec.Code)
+		}
+	}
+	if rec := do(t, h, "GET", "/api/papers", ""); rec.Code != http.StatusOK {
+		t.Errorf("Valid token: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestAuthDisabled(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.token = ""
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, httptest.NewRequest("GET", "/api/papers", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 without auth_token", rec.Code)
+	}
+}
+
+func TestCrawl(t *testing.T) {
+	_, h := newTestServer(t)
+	task := crawlStub(t, h, 3)
+	if task.Status != "completed" || task.TotalCount != 3 || task.Platform != "stub" {
+		t.Errorf("Unexpected task: %+v", task)
+	}
+
+	if rec := do(t, h, "GET", "/api/crawl/missing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("Missing task: status = %d, want 404", rec.Code)
+	}
+	if rec := do(t, h, "POST", "/api/crawl", `{"platform":"nope"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Unknown platform: status = %d, want 400", rec.Code)
+	}
+	if rec := do(t, h, "POST", "/api/crawl", `not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Invalid body: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestListPapers(t *testing.T) {
+	_, h := newTestServer(t)
+	crawlStub(t, h, 5)
+
+	rec := do(t, h, "GET", "/api/papers?page=1&pageSize=2&source=stub", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var resp PaperListResponse
+	decode(t, rec, &resp)
+	if resp.Total != 5 || len(resp.Papers) != 2 {
+		t.Errorf("Expected 2 of 5 papers, got %d of %d", len(resp.Papers), resp.Total)
+	}
+
+	rec = do(t, h, "GET", "/api/papers?search=Paper%203", "")
+	decode(t, rec, &resp)
+	if resp.Total != 1 || resp.Papers[0].SourceID != "stub-3" {
+		t.Errorf("Expected only stub-3 for search, got %d papers", resp.Total)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	_, h := newTestServer(t)
+	crawlStub(t, h, 3)
+
+	rec := do(t, h, "POST", "/api/search", `{"query":"Graph","limit":10}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var results []models.SimilarPaper
+	decode(t, rec, &results)
+	if len(results) != 3 {
+		t.Errorf("Expected 3 keyword results, got %d", len(results))
+	}
+
+	if rec := do(t, h, "POST", "/api/search", `{"query":"Graph","from":"yesterday"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Invalid date: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestExport(t *testing.T) {
+	s, h := newTestServer(t)
+	crawlStub(t, h, 2)
+
+	rec := do(t, h, "POST", "/api/export", `{"format":"json","output":"daily/papers.json","source":"stub"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var resp map[string]string
+	decode(t, rec, &resp)
+	output := filepath.Join(s.exportDir, "daily", "papers.json")
+	if resp["output"] != output {
+		t.Errorf("output = %q, want %q", resp["output"], output)
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Errorf("Expected export file: %v", err)
+	}
+
+	outside := filepath.Join(t.TempDir(), "papers.json")
+	for _, bad := range []string{outside, "../papers.json", "daily/../../papers.json", "."} {
+		rec := do(t, h, "POST", "/api/export", fmt.Sprintf(`{"format":"json","output":%q}`, bad))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Output %q: status = %d, want 400", bad, rec.Code)
+		}
+	}
+	if _, err := os.Stat(outside); !os.IsNotExist(err) {
+		t.Errorf("Expected no file outside the export directory, got %v", err)
+	}
+
+	if rec := do(t, h, "POST", "/api/export", `{"format":"csv"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Missing output: status = %d, want 400", rec.Code)
+	}
+	if rec := do(t, h, "POST", "/api/export", `{"format":"pdf"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Unsupported format: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	cases := map[string]bool{
+		"127.0.0.1:8080": true,
+		"localhost:8080": true,
+		"[::1]:8080":     true,
+		":8080":          false,
+		"0.0.0.0:8080":   false,
+		"10.0.0.5:8080":  false,
+		"8080":           false,
+	}
+	for addr, want := range cases {
+		if got := isLoopbackAddr(addr); got != want {
+			t.Errorf("isLoopbackAddr(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestDeletePapers(t *testing.T) {
+	_, h := newTestServer(t)
+	crawlStub(t, h, 3)
+
+	rec := do(t, h, "DELETE", "/api/papers", `{"source":"stub","ids":["stub-0","stub-1"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var resp map[string]int
+	decode(t, rec, &resp)
+	if resp["deleted"] != 2 {
+		t.Errorf("deleted = %d, want 2", resp["deleted"])
+	}
+
+	var list PaperListResponse
+	decode(t, do(t, h, "GET", "/api/papers", ""), &list)
+	if list.Total != 1 {
+		t.Errorf("Expected 1 remaining paper, got %d", list.Total)
+	}
+
+	if rec := do(t, h, "DELETE", "/api/papers", `{"source":"stub","ids":[]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Empty ids: status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,124 @@
+// server 以无界面方式运行 PaperHunter，通过 REST API 提供爬取、搜索、导出和论文管理，
+// 配置文件与桌面端相同（~/.quicksearch/config/config.yaml），也可用 -config 指定
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"PaperHunter/config"
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/ir"
+	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/httplimit"
+	"PaperHunter/pkg/logger"
+
+	// 平台适配器在 init() 中注册到 core，新增平台只需在此匿名导入
+	_ "PaperHunter/internal/platform/acl"
+	_ "PaperHunter/internal/platform/arxiv"
+	_ "PaperHunter/internal/platform/dblp"
+	_ "PaperHunter/internal/platform/openreview"
+	_ "PaperHunter/internal/platform/ssrn"
+)
+
+func main() {
+	configPath := flag.String("config", "", "配置文件路径或所在目录，默认使用标准配置路径")
+	addr := flag.String("addr", "", "监听地址，覆盖配置中的 server.addr")
+	flag.Parse()
+
+	logger.Init("INFO", true)
+
+	cfg, err := config.Init(*configPath)
+	if err != nil {
+		logger.Fatal("加载配置失败: %v", err)
+	}
+	if *addr != "" {
+		cfg.Server.Addr = *addr
+	}
+
+	httplimit.SetMaxConcurrent(cfg.HTTP.MaxConcurrent)
+
+	app, err := core.NewApp(cfg.Database.Path, cfg.Embedder,
+		map[string]platform.Config{
+			"arxiv":      &cfg.Arxiv,
+			"openreview": &cfg.OpenReview,
+			"acl":        &cfg.ACL,
+			"ssrn":       &cfg.SSRN,
+			"dblp":       &cfg.DBLP,
+		}, cfg.Zotero, cfg.FeiShu, cfg.Notion)
+	if err != nil {
+		logger.Fatal("初始化核心模块失败: %v", err)
+	}
+	defer app.Close()
+	if cfg.Database.MergeCrossSource {
+		app.EnableCrossSourceMerge()
+	}
+	app.EnableCrossRef(cfg.CrossRef)
+	app.EnableUnpaywall(cfg.Unpaywall)
+	app.EnableLastCrawlTracking(core.DefaultStatusDir())
+	if err := app.ConfigureTokenizer(cfg.IR.Tokenizer()); err != nil {
+		logger.Fatal("IR 分词配置无效: %v", err)
+	}
+	if cfg.IR.PersistIndex {
+		app.EnableIRIndexPersistence(ir.DefaultIndexPath())
+	}
+
+	if cfg.Server.AuthToken == "" {
+		if !isLoopbackAddr(cfg.Server.Addr) {
+			logger.Fatal("监听非回环地址 %s 时必须配置 server.auth_token", cfg.Server.Addr)
+		}
+		logger.Warn("未配置 server.auth_token，API 不做身份校验，仅允许本机访问")
+	}
+
+	dataDir := filepath.Dir(cfg.Database.Path)
+	exportDir := cfg.Server.ExportDir
+	if exportDir == "" {
+		exportDir = filepath.Join(dataDir, "exports")
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           newServer(app, cfg.Server.AuthToken, dataDir, exportDir).routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logger.Info("REST API 服务已启动: %s", cfg.Server.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("REST API 服务异常退出: %v", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Warn("关闭 REST API 服务失败: %v", err)
+	}
+	logger.Info("REST API 服务已退出")
+}
+
+// isLoopbackAddr 判断监听地址是否只绑定回环接口，主机为空（监听所有接口）时返回 false
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,451 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/core"
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/crawl"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+
+	"github.com/gorilla/websocket"
+)
+
+type crawlRequest struct {
+	Platform string                 `json:"platform"`
+	Params   map[string]interface{} `json:"params"`
+}
+
+// SearchExample 与桌面端 SearchExample 一致
+type SearchExample struct {
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+}
+
+// SearchOptions 与桌面端 SearchOptions 一致
+type SearchOptions struct {
+	Query        string          `json:"query"`
+	Examples     []SearchExample `json:"examples"`
+	Semantic     bool            `json:"semantic"`
+	TopK         int             `json:"topK"`
+	Limit        int             `json:"limit"`
+	Source       string          `json:"source"`
+	From         string          `json:"from"`  // YYYY-MM-DD
+	Until        string          `json:"until"` // YYYY-MM-DD
+	ComputeEmbed bool            `json:"computeEmbed"`
+	EmbedBatch   int             `json:"embedBatch"`
+	FTS          bool            `json:"fts"`
+	IR           bool            `json:"ir"`
+	IRAlgorithm  string          `json:"irAlgorithm"`
+	Hybrid       bool            `json:"hybrid"`
+	HybridAlpha  float64         `json:"hybridAlpha"`
+	SortBy       string          `json:"sortBy"`
+	SortDesc     bool            `json:"sortDesc"`
+	Status       string          `json:"status"`
+	Starred      bool            `json:"starred"`
+}
+
+// ExportOptions 与桌面端 ExportOptions 一致
+type ExportOptions struct {
+	Format     string   `json:"format"`     // csv|json|ris|bibtex|markdown|obsidian|xlsx|zotero|feishu|notion
+	Output     string   `json:"output"`     // 文件类格式必填，相对于服务端导出目录的路径
+	SplitFiles bool     `json:"splitFiles"` // markdown: 每篇论文一个文件，output 作为目录
+	Query      string   `json:"query"`
+	Keywords   []string `json:"keywords"`
+	Categories []string `json:"categories"`
+	Source     string   `json:"source"`
+	Collection string   `json:"collection"` // zotero
+	FeishuName string   `json:"feishuName"` // feishu: 作为文件与文件夹名
+	// feishu: 已有多维表格的 app_token/table_id，同时设置时追加到该表，否则使用配置或新建
+	FeishuAppToken string `json:"feishuAppToken"`
+	FeishuTableID  string `json:"feishuTableId"`
+	NotionName     string `json:"notionName"` // notion: 目标数据库 ID，留空使用配置
+	Status         string `json:"status"`     // 阅读状态过滤，为空表示不限
+	Starred        bool   `json:"starred"`    // 只导出已收藏的论文
+	Limit          int    `json:"limit"`
+}
+
+type deleteRequest struct {
+	Source string   `json:"source"`
+	IDs    []string `json:"ids"`
+}
+
+// PaperListResponse 与桌面端 GetPapers 返回一致
+type PaperListResponse struct {
+	Papers []*models.Paper `json:"papers"`
+	Total  int             `json:"total"`
+}
+
+type server struct {
+	app       *core.App
+	token     string
+	exportDir string
+	crawls    *crawl.Service
+}
+
+// newServer dataDir 为爬取任务文件所在目录，为空时使用 ~/.quicksearch/data；
+// exportDir 为文件类导出的根目录，请求中的 output 只能指向该目录内
+func newServer(app *core.App, token, dataDir, exportDir string) *server {
+	crawls := crawl.NewService(func() *core.App { return app }, func() string { return dataDir })
+	return &server{app: app, token: token, exportDir: exportDir, crawls: crawls}
+}
+
+// routes 注册 REST 接口，所有 /api 路由都经过 Bearer Token 校验
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/crawl", s.handleStartCrawl)
+	mux.HandleFunc("GET /api/crawl/{taskID}", s.handleGetCrawl)
+	mux.HandleFunc("GET /api/crawl/{taskID}/stream", s.handleStreamCrawl)
+	mux.HandleFunc("POST /api/search", s.handleSearch)
+	mux.HandleFunc("POST /api/export", s.handleExport)
+	mux.HandleFunc("GET /api/papers", s.handleListPapers)
+	mux.HandleFunc("DELETE /api/papers", s.handleDeletePapers)
+	return s.auth(mux)
+}
+
+// auth 校验 Authorization: Bearer <token>，未配置 token 时不校验
+func (s *server) auth(next http.Handler) http.Handler {
+	if s.token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok && websocket.IsWebSocketUpgrade(r) {
+			// 浏览器的 WebSocket 无法设置请求头，允许通过 ?token= 传递
+			got, ok = r.URL.Query().Get("token"), true
+		}
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) handleStartCrawl(w http.ResponseWriter, r *http.Request) {
+	var req crawlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Platform == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("platform is required"))
+		return
+	}
+	if _, ok := core.Get(req.Platform); !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown platform: %s", req.Platform))
+		return
+	}
+
+	taskID, err := s.crawls.StartCrawl(req.Platform, req.Params, false)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{"taskId": taskID})
+}
+
+func (s *server) handleGetCrawl(w http.ResponseWriter, r *http.Request) {
+	task, err := s.crawls.GetTask(r.PathValue("taskID"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var opts SearchOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	ctx := r.Context()
+
+	if opts.ComputeEmbed {
+		batch := opts.EmbedBatch
+		if batch <= 0 {
+			batch = 100
+		}
+		if _, err := s.app.ComputeMissingEmbeddings(ctx, batch, 0); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("compute embeddings failed: %w", err))
+			return
+		}
+	}
+
+	cond := models.SearchCondition{Limit: opts.Limit}
+	if opts.Source != "" {
+		cond.Sources = []string{opts.Source}
+	}
+	if opts.From != "" {
+		t, err := time.Parse("2006-01-02", opts.From)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid from date: %w", err))
+			return
+		}
+		cond.DateFrom = &t
+	}
+	if opts.Until != "" {
+		t, err := time.Parse("2006-01-02", opts.Until)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid until date: %w", err))
+			return
+		}
+		cond.DateTo = &t
+	}
+	if opts.Status != "" && !models.ValidPaperStatus(opts.Status) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid status: %s", opts.Status))
+		return
+	}
+	cond.Status = opts.Status
+	cond.Starred = opts.Starred
+
+	var examples []*models.Paper
+	for _, e := range opts.Examples {
+		if e.Title != "" || e.Abstract != "" {
+			examples = append(examples, &models.Paper{Title: e.Title, Abstract: e.Abstract})
+		}
+	}
+
+	results, err := s.app.Search(ctx, core.SearchOptions{
+		Query:       opts.Query,
+		Examples:    examples,
+		Condition:   cond,
+		TopK:        opts.TopK,
+		Semantic:    opts.Semantic,
+		FTS:         opts.FTS,
+		IR:          opts.IR,
+		IRAlgorithm: opts.IRAlgorithm,
+		Hybrid:      opts.Hybrid,
+		HybridAlpha: opts.HybridAlpha,
+		SortBy:      opts.SortBy,
+		SortDesc:    opts.SortDesc,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if results == nil {
+		results = []*models.SimilarPaper{}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *server) handleExport(w http.ResponseWriter, r *http.Request) {
+	var opts ExportOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	f, ok := exporter.Lookup(opts.Format)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported format: %s", opts.Format))
+		return
+	}
+	format := f.Name
+	if f.NeedsOutput && strings.TrimSpace(opts.Output) == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("output is required for %s", strings.Join(exporter.OutputFormatNames(), "/")))
+		return
+	}
+	if format == "feishu" && strings.TrimSpace(opts.FeishuName) == "" && !feishuTarget(opts).IsSet() {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("feishuName is required for feishu export"))
+		return
+	}
+	if f.NeedsOutput {
+		path, err := s.exportPath(opts.Output)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		opts.Output = path
+	}
+
+	conditions, params, err := exportConditions(opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	ctx := r.Context()
+
+	var output string
+	switch format {
+	case "markdown":
+		output, err = opts.Output, s.app.ExportMarkdown(ctx, opts.Output, conditions, params, opts.Limit, opts.SplitFiles)
+	case "zotero":
+		err = s.app.ExportToZotero(ctx, opts.Collection, conditions, params, opts.Limit)
+	case "notion":
+		err = s.app.ExportToNotion(ctx, strings.TrimSpace(opts.NotionName), conditions, params, opts.Limit)
+	case "feishu":
+		name := strings.TrimSpace(opts.FeishuName)
+		result, err := s.app.ExportToFeiShuBitableWithURL(ctx, name, name, feishuTarget(opts), conditions, params, opts.Limit)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"output": result.URL, "inserted": result.Inserted, "skipped": result.Skipped})
+		return
+	default:
+		// 其余为文件类格式
+		output, err = opts.Output, s.app.ExportPapers(ctx, format, opts.Output, conditions, params, opts.Limit)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"output": output})
+}
+
+// exportPath 将请求中的 output 解析到导出目录内，拒绝绝对路径和跳出导出目录的相对路径
+func (s *server) exportPath(output string) (string, error) {
+	output = strings.TrimSpace(output)
+	if filepath.IsAbs(output) || filepath.VolumeName(output) != "" {
+		return "", fmt.Errorf("output must be a path relative to the export directory")
+	}
+	root, err := filepath.Abs(s.exportDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid export directory: %w", err)
+	}
+	path := filepath.Join(root, output)
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("output must stay inside the export directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	return path, nil
+}
+
+func (s *server) handleListPapers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(q.Get("pageSize"))
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	var conditions []string
+	var params []interface{}
+	if source := q.Get("source"); source != "" && source != "all" {
+		conditions = append(conditions, "source = ?")
+		params = append(params, source)
+	}
+	if search := q.Get("search"); search != "" {
+		pattern := "%" + search + "%"
+		conditions = append(conditions, "(title LIKE ? OR abstract LIKE ? OR authors LIKE ?)")
+		params = append(params, pattern, pattern, pattern)
+	}
+
+	papers, total, err := s.app.GetPapers(r.Context(), page, pageSize, conditions, params, "first_announced_at DESC")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if papers == nil {
+		papers = []*models.Paper{}
+	}
+	writeJSON(w, http.StatusOK, PaperListResponse{Papers: papers, Total: total})
+}
+
+func (s *server) handleDeletePapers(w http.ResponseWriter, r *http.Request) {
+	var req deleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if len(req.IDs) == 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("no papers selected"))
+		return
+	}
+
+	conditions, params := selectionConditions(req.Source, req.IDs)
+	deleted, err := s.app.DeletePapers(r.Context(), conditions, params)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"deleted": deleted})
+}
+
+// exportConditions 按导出选项生成查询条件，与桌面端 ExportWithOptions 一致
+// feishuTarget 请求中指定的已有飞书数据表，未指定时由配置决定
+func feishuTarget(opts ExportOptions) core.FeiShuTable {
+	return core.FeiShuTable{AppToken: strings.TrimSpace(opts.FeishuAppToken), TableID: strings.TrimSpace(opts.FeishuTableID)}
+}
+
+func exportConditions(opts ExportOptions) ([]string, []interface{}, error) {
+	var conditions []string
+	var params []interface{}
+	if opts.Source != "" {
+		conditions = append(conditions, "source = ?")
+		params = append(params, opts.Source)
+	}
+	if opts.Query != "" {
+		pattern := "%" + opts.Query + "%"
+		conditions = append(conditions, "(title LIKE ? OR abstract LIKE ?)")
+		params = append(params, pattern, pattern)
+	}
+	if len(opts.Keywords) > 0 {
+		ks := make([]string, 0, len(opts.Keywords))
+		for _, k := range opts.Keywords {
+			ks = append(ks, "(title LIKE ? OR abstract LIKE ?)")
+			p := "%" + k + "%"
+			params = append(params, p, p)
+		}
+		conditions = append(conditions, "("+strings.Join(ks, " AND ")+")")
+	}
+	if len(opts.Categories) > 0 {
+		cs := make([]string, 0, len(opts.Categories))
+		for _, c := range opts.Categories {
+			cs = append(cs, "categories LIKE ?")
+			params = append(params, "%"+c+"%")
+		}
+		conditions = append(conditions, "("+strings.Join(cs, " OR ")+")")
+	}
+	statusConds, statusParams, err := core.StatusConditions(opts.Status, opts.Starred)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(conditions, statusConds...), append(params, statusParams...), nil
+}
+
+// selectionConditions 按 source + source_id 列表生成查询条件
+func selectionConditions(source string, ids []string) ([]string, []interface{}) {
+	placeholders := make([]string, 0, len(ids))
+	params := make([]interface{}, 0, len(ids)+1)
+	var conditions []string
+	if source != "" {
+		conditions = append(conditions, "source = ?")
+		params = append(params, source)
+	}
+	for _, id := range ids {
+		placeholders = append(placeholders, "?")
+		params = append(params, id)
+	}
+	conditions = append(conditions, fmt.Sprintf("source_id IN (%s)", strings.Join(placeholders, ",")))
+	return conditions, params
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Warn("写入响应失败: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"PaperHunter/pkg/logger"
+
+	"github.com/gorilla/websocket"
+)
+
+// streamWriteTimeout 单条日志写入 WebSocket 的超时
+const streamWriteTimeout = 10 * time.Second
+
+// upgrader 配置了 Bearer Token 时访问已受控，不限制 Origin 以便其他域名的前端接入；
+// 未配置时使用同源检查（CheckOrigin 为 nil），避免任意网页通过浏览器读取本机任务日志
+func (s *server) upgrader() *websocket.Upgrader {
+	if s.token == "" {
+		return &websocket.Upgrader{}
+	}
+	return &websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+}
+
+// handleStreamCrawl 通过 WebSocket 推送任务日志（crawl.LogEntry JSON），任务结束或客户端断开时关闭连接；
+// 客户端消费过慢时以 1013 (Try Again Later) 关闭，可重新连接获取完整日志
+func (s *server) handleStreamCrawl(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("taskID")
+	logs, unsubscribe, err := s.crawls.Subscribe(taskID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := s.upgrader().Upgrade(w, r, nil)
+	if err != nil {
+		logger.Warn("WebSocket 升级失败: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// 客户端只接收不发送，读循环用于处理控制帧并感知断开
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case entry, ok := <-logs:
+			if !ok {
+				// 任务未结束时通道被关闭，说明客户端消费过慢，通知其重新订阅
+				code, reason := websocket.CloseNormalClosure, "task finished"
+				if task, err := s.crawls.GetTask(taskID); err == nil {
+					select {
+					case <-task.Done():
+					default:
+						code, reason = websocket.CloseTryAgainLater, "log stream fell behind"
+					}
+				}
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(code, reason),
+					time.Now().Add(streamWriteTimeout))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(entry); err != nil {
+				logger.Debug("WebSocket 写入失败: %v", err)
+				return
+			}
+		case <-disconnected:
+			return
+		}
+	}
+}
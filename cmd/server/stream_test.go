@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/crawl"
+	"PaperHunter/internal/platform"
+
+	"github.com/gorilla/websocket"
+)
+
+// gate 控制 stub-gated 平台何时返回结果，便于在爬取开始前建立 WebSocket 连接；
+// 每个测试在 StartCrawl 前重新创建
+var gate chan struct{}
+
+type gatedPlatform struct{ stubPlatform }
+
+func (gatedPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	select {
+	case <-gate:
+	case <-ctx.Done():
+		return platform.Result{}, ctx.Err()
+	}
+	return stubPlatform{}.Search(ctx, q)
+}
+
+func init() {
+	core.MustRegister(core.Provider{
+		Name:          "stub-gated",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return gatedPlatform{}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func dialStream(t *testing.T, srv *httptest.Server, taskID string, header http.Header, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/crawl/" + taskID + "/stream" + query
+	conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		t.Fatalf("Dial() error: %v (status %d)", err, status)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readAll 读取日志直到服务端正常关闭连接
+func readAll(t *testing.T, conn *websocket.Conn) []crawl.LogEntry {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var entries []crawl.LogEntry
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				t.Fatalf("ReadMessage() error: %v", err)
+			}
+			return entries
+		}
+		var entry crawl.LogEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			t.Fatalf("invalid log entry %s: %v", data, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func TestStreamCrawl(t *testing.T) {
+	s, h := newTestServer(t)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	gate = make(chan struct{})
+	taskID, err := s.crawls.StartCrawl("stub-gated", map[string]interface{}{"limit": float64(3)}, false)
+	if err != nil {
+		t.Fatalf("StartCrawl() error: %v", err)
+	}
+	conn := dialStream(t, srv, taskID, http.Header{"Authorization": {"Bearer " + testToken}}, "")
+	close(gate)
+
+	entries := readAll(t, conn)
+	task, err := s.crawls.GetTask(taskID)
+	if err != nil {
+		t.Fatalf("GetTask() error: %v", err)
+	}
+	task = task.Snapshot()
+	if task.Status != "completed" {
+		t.Fatalf("Expected completed task, got %s", task.Status)
+	}
+	// 开始 + 3 条进度 + 完成
+	if len(entries) != 5 || len(entries) != len(task.Logs) {
+		t.Fatalf("Expected 5 streamed entries matching task logs, got %d (task has %d)", len(entries), len(task.Logs))
+	}
+	for i, e := range entries {
+		if e.ID != task.Logs[i].ID || e.TaskID != taskID {
+			t.Errorf("entry %d = %+v, want %+v", i, e, task.Logs[i])
+		}
+	}
+	if entries[0].Level != "info" || entries[len(entries)-1].Level != "success" || entries[len(entries)-1].Count != 3 {
+		t.Errorf("Unexpected first/last entries: %+v / %+v", entries[0], entries[len(entries)-1])
+	}
+
+	// 任务结束后连接仍能拿到完整日志，并可用 ?token= 认证
+	replay := readAll(t, dialStream(t, srv, taskID, nil, "?token="+testToken))
+	if len(replay) != len(entries) {
+		t.Errorf("Expected %d replayed entries, got %d", len(entries), len(replay))
+	}
+}
+
+func TestStreamCrawl_Errors(t *testing.T) {
+	_, h := newTestServer(t)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/crawl/missing/stream"
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Authorization": {"Bearer " + testToken}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown task, got %v", err)
+	}
+	_, resp, err = websocket.DefaultDialer.Dial(url+"?token=wrong", nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for wrong token, got %v", err)
+	}
+}
+
+func TestStreamCrawl_CheckOrigin(t *testing.T) {
+	for _, token := range []string{"", testToken} {
+		s, _ := newTestServer(t)
+		s.token = token
+		srv := httptest.NewServer(s.routes())
+		defer srv.Close()
+
+		taskID, err := s.crawls.StartCrawl("stub", map[string]interface{}{"limit": 1}, false)
+		if err != nil {
+			t.Fatalf("StartCrawl() error: %v", err)
+		}
+		url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/crawl/" + taskID + "/stream"
+		header := http.Header{"Origin": {"http://evil.example"}}
+		if token != "" {
+			header.Set("Authorization", "Bearer "+token)
+		}
+		conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+		if conn != nil {
+			conn.Close()
+		}
+		if token == "" && (err == nil || resp == nil || resp.StatusCode != http.StatusForbidden) {
+			t.Errorf("Expected 403 for cross-origin request without token, got %v", err)
+		}
+		if token != "" && err != nil {
+			t.Errorf("Expected cross-origin request with token to succeed, got %v", err)
+		}
+
+		// 等待任务结束，避免临时目录清理时任务仍在写入
+		if logs, unsubscribe, err := s.crawls.Subscribe(taskID); err == nil {
+			for range logs {
+			}
+			unsubscribe()
+		}
+	}
+}
+
+func TestSubscribe_Unsubscribe(t *testing.T) {
+	s, _ := newTestServer(t)
+	taskID, err := s.crawls.StartCrawl("stub", map[string]interface{}{}, false)
+	if err != nil {
+		t.Fatalf("StartCrawl() error: %v", err)
+	}
+	logs, unsubscribe, err := s.crawls.Subscribe(taskID)
+	if err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	unsubscribe()
+	unsubscribe() // 重复取消订阅不应 panic
+	for range logs {
+	}
+	if _, _, err := s.crawls.Subscribe("missing"); err == nil {
+		t.Error("Expected error for unknown task")
+	}
+}
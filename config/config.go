@@ -6,21 +6,66 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 
 	"PaperHunter/internal/core"
 	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/ir"
 	"PaperHunter/internal/platform/acl"
 	"PaperHunter/internal/platform/arxiv"
+	"PaperHunter/internal/platform/dblp"
 	"PaperHunter/internal/platform/openreview"
 	"PaperHunter/internal/platform/ssrn"
+	"PaperHunter/pkg/enrich/crossref"
+	"PaperHunter/pkg/httplimit"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/metrics"
 )
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
 	Path string `mapstructure:"path" yaml:"path"` // 数据库文件路径
+	// MergeCrossSource 入库时将不同平台收录的同一篇论文（DOI 或标题+第一作者相同）合并为一条记录
+	MergeCrossSource bool `mapstructure:"merge_cross_source" yaml:"merge_cross_source"`
+}
+
+// HTTPConfig 出站请求配置
+type HTTPConfig struct {
+	MaxConcurrent int `mapstructure:"max_concurrent" yaml:"max_concurrent"` // 全局同时进行的请求数上限
+}
+
+// IRConfig 本地 IR 检索（TF-IDF/BM25）配置
+type IRConfig struct {
+	StopwordLang    string   `mapstructure:"stopword_lang" yaml:"stopword_lang"`       // 停用词语言：en 或 zh（汉字逐字切分）
+	CustomStopwords []string `mapstructure:"custom_stopwords" yaml:"custom_stopwords"` // 追加的停用词，如领域内过于常见的词
+	Stemming        bool     `mapstructure:"stemming" yaml:"stemming"`                 // 英文词干提取，使 networks 与 network 互相匹配
+	MinTokenLength  int      `mapstructure:"min_token_length" yaml:"min_token_length"` // 最短词长，更短的词不参与检索
+	PersistIndex    bool     `mapstructure:"persist_index" yaml:"persist_index"`       // 将 IR 索引保存到 ~/.quicksearch/index/ir.gob，重启后加载而不是重建
+}
+
+// Tokenizer 转换为 IR 分词配置
+func (c IRConfig) Tokenizer() ir.TokenizerConfig {
+	return ir.TokenizerConfig{
+		StopwordLang:    c.StopwordLang,
+		CustomStopwords: c.CustomStopwords,
+		Stem:            c.Stemming,
+		MinTokenLength:  c.MinTokenLength,
+	}
+}
+
+// MetricsConfig Prometheus 指标配置
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"` // 是否启动 /metrics 服务
+	Port    int  `mapstructure:"port" yaml:"port"`       // 监听端口
+}
+
+// ServerConfig REST API 服务配置（cmd/server）
+type ServerConfig struct {
+	Addr      string `mapstructure:"addr" yaml:"addr"`             // 监听地址
+	AuthToken string `mapstructure:"auth_token" yaml:"auth_token"` // Bearer Token，为空时不校验，此时只允许监听回环地址
+	ExportDir string `mapstructure:"export_dir" yaml:"export_dir"` // 导出文件目录，为空时使用数据库同目录下的 exports
 }
 
 // LLMConfig LLM 配置（用于 Agent）
@@ -28,20 +73,35 @@ type LLMConfig struct {
 	BaseURL   string `mapstructure:"base_url" yaml:"base_url"` // API 地址，支持 OpenAI 兼容的 API
 	ModelName string `mapstructure:"model" yaml:"model"`       // 模型名称
 	APIKey    string `mapstructure:"api_key" yaml:"api_key"`   // API Key
+
+	TranslationModel string `mapstructure:"translation_model" yaml:"translation_model"` // 翻译标题/摘要使用的模型，为空时使用 model
+
+	HyDECache       bool          `mapstructure:"hyde_cache" yaml:"hyde_cache"`             // 是否缓存 HyDE 生成结果（~/.quicksearch/cache/hyde），相同查询不再调用 LLM
+	HyDECacheTTL    time.Duration `mapstructure:"hyde_cache_ttl" yaml:"hyde_cache_ttl"`     // HyDE 缓存有效期，如 168h
+	HyDECandidates  int           `mapstructure:"hyde_candidates" yaml:"hyde_candidates"`   // HyDE 每次生成的候选数（1-8），越多越好但调用次数越多
+	HyDETemperature float32       `mapstructure:"hyde_temperature" yaml:"hyde_temperature"` // HyDE 生成温度（0-2）
 }
 
 // AppConfig 应用总配置(全局 + 平台)
 type AppConfig struct {
-	Env        string             `mapstructure:"env" yaml:"env"`               // 运行环境:dev/prod
-	Embedder   emb.EmbedderConfig `mapstructure:"embedder" yaml:"embedder"`     // Embedder 配置
-	Database   DatabaseConfig     `mapstructure:"database" yaml:"database"`     // 数据库配置
-	Zotero     core.ZoteroConfig  `mapstructure:"zotero" yaml:"zotero"`         // Zotero 配置
-	FeiShu     core.FeiShuConfig  `mapstructure:"feishu" yaml:"feishu"`         // 飞书配置
-	Arxiv      arxiv.Config       `mapstructure:"arxiv" yaml:"arxiv"`           // arXiv 平台配置
-	OpenReview openreview.Config  `mapstructure:"openreview" yaml:"openreview"` // OpenReview 平台配置
-	ACL        acl.Config         `mapstructure:"acl" yaml:"acl"`               // ACL Anthology 平台配置
-	SSRN       ssrn.Config        `mapstructure:"ssrn" yaml:"ssrn"`             // SSRN 平台配置
-	LLM        LLMConfig          `mapstructure:"agent" yaml:"agent"`           // LLM 配置（用于 Agent，兼容 yaml 中的 agent 键）
+	Env        string               `mapstructure:"env" yaml:"env"`               // 运行环境:dev/prod
+	Embedder   emb.EmbedderConfig   `mapstructure:"embedder" yaml:"embedder"`     // Embedder 配置
+	Database   DatabaseConfig       `mapstructure:"database" yaml:"database"`     // 数据库配置
+	HTTP       HTTPConfig           `mapstructure:"http" yaml:"http"`             // 出站请求配置
+	IR         IRConfig             `mapstructure:"ir" yaml:"ir"`                 // 本地 IR 检索配置
+	Metrics    MetricsConfig        `mapstructure:"metrics" yaml:"metrics"`       // Prometheus 指标配置
+	Server     ServerConfig         `mapstructure:"server" yaml:"server"`         // REST API 服务配置
+	Zotero     core.ZoteroConfig    `mapstructure:"zotero" yaml:"zotero"`         // Zotero 配置
+	FeiShu     core.FeiShuConfig    `mapstructure:"feishu" yaml:"feishu"`         // 飞书配置
+	Notion     core.NotionConfig    `mapstructure:"notion" yaml:"notion"`         // Notion 配置
+	CrossRef   core.CrossRefConfig  `mapstructure:"crossref" yaml:"crossref"`     // CrossRef 元数据补全配置
+	Unpaywall  core.UnpaywallConfig `mapstructure:"unpaywall" yaml:"unpaywall"`   // Unpaywall 开放获取链接配置
+	Arxiv      arxiv.Config         `mapstructure:"arxiv" yaml:"arxiv"`           // arXiv 平台配置
+	OpenReview openreview.Config    `mapstructure:"openreview" yaml:"openreview"` // OpenReview 平台配置
+	ACL        acl.Config           `mapstructure:"acl" yaml:"acl"`               // ACL Anthology 平台配置
+	SSRN       ssrn.Config          `mapstructure:"ssrn" yaml:"ssrn"`             // SSRN 平台配置
+	DBLP       dblp.Config          `mapstructure:"dblp" yaml:"dblp"`             // DBLP 平台配置
+	LLM        LLMConfig            `mapstructure:"agent" yaml:"agent"`           // LLM 配置（用于 Agent，兼容 yaml 中的 agent 键）
 }
 
 var (
@@ -57,6 +117,17 @@ func setDefaults(v *viper.Viper) {
 	dataBasePath := filepath.Join(homedir, ".quicksearch", "data", "quicksearch.db")
 	v.SetDefault("env", "prod")
 	v.SetDefault("database.path", dataBasePath)
+	v.SetDefault("database.merge_cross_source", false)
+	v.SetDefault("http.max_concurrent", httplimit.DefaultMaxConcurrent)
+	v.SetDefault("ir.stopword_lang", "en")
+	v.SetDefault("ir.stemming", false)
+	v.SetDefault("ir.min_token_length", ir.DefaultMinTokenLength)
+	v.SetDefault("ir.persist_index", true)
+	v.SetDefault("metrics.enabled", false)
+	v.SetDefault("metrics.port", metrics.DefaultPort)
+	v.SetDefault("server.addr", "127.0.0.1:8080")
+	v.SetDefault("server.auth_token", "")
+	v.SetDefault("server.export_dir", "")
 
 	v.SetDefault("arxiv.use_api", false)
 	v.SetDefault("arxiv.proxy", "")
@@ -64,10 +135,20 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("arxiv.timeout", 30)
 	v.SetDefault("arxiv.api_base", "https://export.arxiv.org/api/query")
 	v.SetDefault("arxiv.web_base", "https://arxiv.org/search/advanced")
+	v.SetDefault("arxiv.new_submissions_categories", arxiv.DefaultNewSubmissionCategories())
+	v.SetDefault("arxiv.holidays", []string{})
+	v.SetDefault("arxiv.fetch_citations", false)
+	v.SetDefault("arxiv.citation_api", "https://api.semanticscholar.org/graph/v1/paper/batch")
+	v.SetDefault("arxiv.rate_limit_rps", 1.0)
+	v.SetDefault("arxiv.insecure_skip_verify", false)
+	v.SetDefault("arxiv.dial_timeout", "30s")
 
 	v.SetDefault("openreview.api_base", "https://api2.openreview.net")
 	v.SetDefault("openreview.proxy", "")
 	v.SetDefault("openreview.timeout", 30)
+	v.SetDefault("openreview.rate_limit_rps", 2.0)
+	v.SetDefault("openreview.insecure_skip_verify", false)
+	v.SetDefault("openreview.dial_timeout", "30s")
 
 	v.SetDefault("acl.base_url", "https://aclanthology.org")
 	v.SetDefault("acl.timeout", "30s")
@@ -75,6 +156,9 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("acl.step", 100)
 	v.SetDefault("acl.use_rss", true)
 	v.SetDefault("acl.use_bibtex", false)
+	v.SetDefault("acl.rate_limit_rps", 2.0)
+	v.SetDefault("acl.insecure_skip_verify", false)
+	v.SetDefault("acl.dial_timeout", "30s")
 
 	// SSRN 默认值
 	v.SetDefault("ssrn.base_url", "https://papers.ssrn.com")
@@ -84,24 +168,57 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("ssrn.max_pages", 3)
 	v.SetDefault("ssrn.rate_limit_per_second", 1.0)
 	v.SetDefault("ssrn.sort", "AB_Date_D")
+	v.SetDefault("ssrn.fetch_abstracts", false)
+	v.SetDefault("ssrn.insecure_skip_verify", false)
+	v.SetDefault("ssrn.dial_timeout", "30s")
+	v.SetDefault("ssrn.checkpoint_path", "")
+	v.SetDefault("ssrn.force_full_crawl", false)
+
+	v.SetDefault("dblp.api_base", "https://dblp.org/search/publ/api")
+	v.SetDefault("dblp.proxy", "")
+	v.SetDefault("dblp.timeout", 30)
+	v.SetDefault("dblp.page_size", 100)
+	v.SetDefault("dblp.rate_limit_rps", 1.0)
+	v.SetDefault("dblp.insecure_skip_verify", false)
+	v.SetDefault("dblp.dial_timeout", "30s")
 	// Embedder 默认值
 	v.SetDefault("embedder.baseurl", "")
 	v.SetDefault("embedder.apikey", "")
 	v.SetDefault("embedder.model", "Qwen/Qwen3-Embedding-4B")
 	v.SetDefault("embedder.dim", 2560)
+	v.SetDefault("embedder.use_vector_index", true)
+	v.SetDefault("embedder.cache_size", 5000)
+	v.SetDefault("embedder.quantize", false)
 
 	// Zotero 默认值
 	v.SetDefault("zotero.user_id", "")
 	v.SetDefault("zotero.api_key", "")
+	v.SetDefault("zotero.group_id", "")
+	v.SetDefault("zotero.invalid_collection", "warn")
 
 	// 飞书默认值
 	v.SetDefault("feishu.app_id", "")
 	v.SetDefault("feishu.app_secret", "")
+	v.SetDefault("feishu.app_token", "")
+	v.SetDefault("feishu.table_id", "")
+	v.SetDefault("feishu.dedup_columns", []string{"URL"})
+	v.SetDefault("notion.integration_token", "")
+	v.SetDefault("notion.database_id", "")
+	v.SetDefault("crossref.enabled", false)
+	v.SetDefault("crossref.mailto", "")
+	v.SetDefault("crossref.rate_limit_per_second", crossref.DefaultRateLimit)
+	v.SetDefault("crossref.min_similarity", crossref.DefaultMinSimilarity)
+	v.SetDefault("unpaywall.email", "")
 
 	// LLM 默认值（使用 agent 作为键名以兼容现有配置）
 	v.SetDefault("agent.base_url", "https://openrouter.ai/api/v1")
 	v.SetDefault("agent.model", "deepseek/deepseek-v3")
 	v.SetDefault("agent.api_key", "")
+	v.SetDefault("agent.translation_model", "")
+	v.SetDefault("agent.hyde_cache", true)
+	v.SetDefault("agent.hyde_cache_ttl", "168h")
+	v.SetDefault("agent.hyde_candidates", 3)
+	v.SetDefault("agent.hyde_temperature", 0.3)
 }
 
 // 可额外传入目录或具体文件路径
@@ -177,6 +294,44 @@ func Init(configPaths ...string) (*AppConfig, error) {
 			return
 		}
 
+		// 旧配置没有 library_type，按个人库处理
+		if cfg.Zotero.UserID != "" && !v.InConfig("zotero.library_type") {
+			logger.Warn("配置中缺少 zotero.library_type，默认使用个人库（user）；如需同步到群组库请设置为 group 并填写 zotero.group_id")
+		}
+		switch cfg.Zotero.LibraryType {
+		case "", "user":
+		case "group":
+			if cfg.Zotero.GroupID == "" {
+				globalErr = fmt.Errorf("zotero.library_type 为 group 时需要设置 zotero.group_id")
+				return
+			}
+		default:
+			globalErr = fmt.Errorf("zotero.library_type 仅支持 user/group，当前为 %q", cfg.Zotero.LibraryType)
+			return
+		}
+
+		// 验证 zotero collection 处理方式
+		switch cfg.Zotero.InvalidCollection {
+		case "", "fail", "warn", "create":
+		default:
+			globalErr = fmt.Errorf("zotero.invalid_collection 仅支持 fail/warn/create，当前为 %q", cfg.Zotero.InvalidCollection)
+			return
+		}
+
+		if s := cfg.CrossRef.MinSimilarity; s < 0 || s > 1 {
+			globalErr = fmt.Errorf("crossref.min_similarity 需在 0 到 1 之间，当前为 %v", s)
+			return
+		}
+
+		if n := cfg.LLM.HyDECandidates; n < 1 || n > 8 {
+			globalErr = fmt.Errorf("agent.hyde_candidates 需在 1 到 8 之间，当前为 %d", n)
+			return
+		}
+		if t := cfg.LLM.HyDETemperature; t < 0 || t > 2 {
+			globalErr = fmt.Errorf("agent.hyde_temperature 需在 0 到 2 之间，当前为 %v", t)
+			return
+		}
+
 		global = cfg
 	})
 	return global, globalErr
@@ -253,43 +408,104 @@ embedder:
   apikey: "your-api-key-here"               # 请替换为你的 API Key
   model: "Qwen/Qwen3-Embedding-4B"          # 或使用 OpenAI: "text-embedding-3-small"
   dim: 2560                                 # 向量维度
+  use_vector_index: true                    # 语义检索使用内存 HNSW 索引，索引构建中或过滤条件过严时回退到全表扫描
+  cache_size: 5000                          # 语义检索缓存的向量数（LRU），0 表示不缓存
+  quantize: false                           # 以 int8 量化存储向量，数据库体积约为原来的 1/4
 
 # 数据库配置
 database:
   path: ""  #可以配置后重新初始化，从而指定你的数据库保存位置
+  merge_cross_source: false  #将不同平台收录的同一篇论文（DOI 或标题+第一作者相同）合并为一条记录
+
+# 出站请求配置
+http:
+  max_concurrent: 16  # 爬取、向量生成、导出等所有请求同时进行的总数上限
+
+# Prometheus 指标（可选）
+metrics:
+  enabled: false  # 开启后在 http://localhost:<port>/metrics 暴露指标
+  port: 9090
+
+# REST API 服务（cmd/server，无界面运行时使用）
+server:
+  addr: "127.0.0.1:8080"  # 监听非回环地址时必须设置 auth_token
+  auth_token: ""  # 请求需携带 Authorization: Bearer <auth_token>，留空则不校验
+  export_dir: ""  # 文件类导出只能写入该目录，留空使用数据库同目录下的 exports
 
 # Zotero 配置（可选）
 zotero:
   user_id: ""     # 你的 Zotero 用户 ID
   api_key: ""     # 你的 Zotero API Key
+  library_type: "user"  # user 个人库 / group 群组库
+  group_id: ""    # 群组库 ID（library_type 为 group 时必填），可在应用中列出所属群组查询
+  invalid_collection: "warn"  # collection 不存在时: fail 报错 / warn 添加到默认位置 / create 按名称创建
 
 # 飞书配置（可选）
 feishu:
   app_id: ""      # 飞书应用 ID
   app_secret: ""  # 飞书应用密钥
+  app_token: ""   # 可选：已有多维表格的 app_token，与 table_id 同时设置时导出追加到该表而不是新建
+  table_id: ""    # 可选：已有数据表的 table_id（多维表格链接中 ?table= 后的部分）
+  dedup_columns: ["URL"] # 追加时按这些列跳过表中已有的论文，如 ["数据源", "平台ID"]；[] 表示不去重
+
+# Notion 配置（可选）
+notion:
+  integration_token: ""  # Notion Integration Token
+  database_id: ""        # 目标数据库 ID
+
+# CrossRef 元数据补全（可选）：按标题匹配 DOI、出版日期与会议/期刊
+crossref:
+  enabled: false
+  mailto: ""                  # 联系邮箱，填写后请求更稳定
+  rate_limit_per_second: 2
+  min_similarity: 0.9         # 标题相似度低于该值不采用，避免错配
+
+# Unpaywall 开放获取链接（可选）：为带 DOI 的论文查询免费 PDF
+unpaywall:
+  email: ""                   # 联系邮箱，Unpaywall 要求填写，为空时不启用
 
 # arXiv 平台配置
 arxiv:
   use_api: false  # 是否使用官方 API（推荐）
   proxy: ""       # 代理设置，如: "http://127.0.0.1:7890"
+  proxy_pool: []  # 备用代理，当前代理返回 403/429 时依次切换
   step: 50
   timeout: 30
+  fetch_citations: false  # 是否通过 Semantic Scholar 补充引用数
+  new_submissions_categories: ["cs", "math.ST", "stat.ML", "eess.SP"]  # 每日推荐爬取的 archive 或分类，同一 archive 的分类合并为一次请求；旧名 daily_archives 仍可使用
+  holidays: []            # arXiv 不公布新论文的节假日，如 ["2025-12-25"]；周末自动跳过
+  rate_limit_rps: 1       # 每秒请求数上限（含 Semantic Scholar），0 表示不限速
+  dial_timeout: 30s       # 建立连接超时；各平台均可单独设置 proxy、dial_timeout 与 insecure_skip_verify
 
 # OpenReview 平台配置
 openreview:
   proxy: ""       # 代理设置
   timeout: 30
+  rate_limit_rps: 2
 
 # ACL Anthology 平台配置
 acl:
   proxy: ""       # 代理设置
   timeout: 600
+  rate_limit_rps: 2
+
+# DBLP 平台配置（按关键词、会议/期刊和年份检索）
+dblp:
+  proxy: ""       # 代理设置
+  timeout: 30
+  page_size: 100  # 每页数量（1-1000）
+  rate_limit_rps: 1
 
 # LLM 配置（用于 Agent）
 agent:
   base_url: "https://openrouter.ai/api/v1"  # API 地址，支持 OpenAI 兼容的 API
   model: "deepseek/deepseek-v3"            # 模型名称
   api_key: ""                               # API Key（如果留空，将尝试使用 embedder 的 api_key）
+  translation_model: ""                     # 翻译标题/摘要使用的模型，留空时使用 model
+  hyde_cache: true                          # 缓存 HyDE 生成结果，相同查询不再调用 LLM
+  hyde_cache_ttl: 168h                      # HyDE 缓存有效期
+  hyde_candidates: 3                        # HyDE 每次生成的候选数（1-8），为 1 时不做选优
+  hyde_temperature: 0.3                     # HyDE 生成温度（0-2）
 `
 
 			if err := os.WriteFile(configFile, []byte(exampleContent), 0644); err != nil {
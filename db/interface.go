@@ -1,25 +1,150 @@
 package db
 
 import (
+	"errors"
+	"time"
+
 	"PaperHunter/internal/models"
 )
 
+// ErrPaperNotFound 论文不存在或已删除，单条查询时用 errors.Is 判断
+var ErrPaperNotFound = errors.New("论文不存在")
+
+// ErrEmbeddingDimMismatch 向量维度与同一模型已存向量或 embedder 配置不一致
+var ErrEmbeddingDimMismatch = errors.New("向量维度不一致")
+
 // 为 postgreSql 保留一下接口, 理论上命令行程序应该简洁更好, 但万一发了呢
 type PaperStorage interface {
 	Upsert(paper *models.Paper) (int64, error)
 
+	// SaveEmbedding 保存论文向量，维度与同一模型已存向量不一致时返回 ErrEmbeddingDimMismatch
 	SaveEmbedding(paperID int64, model string, text string, vec []float32) error
 
 	GetPapersNeedingEmbedding(model string, limit int) ([]*models.Paper, error)
 
+	// GetEmbeddingStats 按模型与维度统计已存向量的论文数，用于发现模型或维度变更
+	GetEmbeddingStats() ([]models.EmbeddingStat, error)
+
+	// GetEmbeddings 按论文 ID 批量读取指定模型的向量
+	GetEmbeddings(paperIDs []int64, model string) (map[int64][]float32, error)
+
 	SearchByEmbedding(queryVec []float32, model string, cond models.SearchCondition, topK int) ([]*models.SimilarPaper, error)
 
 	SearchByKeywords(query string, cond models.SearchCondition) ([]*models.Paper, error)
 
+	// SearchByFTS 全文检索标题和摘要并按相关度排序，不支持 FTS5 时退回 SearchByKeywords
+	SearchByFTS(query string, cond models.SearchCondition) ([]*models.Paper, error)
+
+	// SearchByAuthor 按作者名子串检索论文，cond.Authors 与 authorQuery 之间为 OR，其他过滤条件同样生效
+	SearchByAuthor(authorQuery string, cond models.SearchCondition) ([]*models.Paper, error)
+
 	CountPapers(conditions []string, params []interface{}) (int, error)
 
+	// DeletePapers 软删除：设置 deleted_at，查询接口会自动排除已删除的论文
 	DeletePapers(conditions []string, params []interface{}) (int, error)
 
+	// UndeletePapers 恢复满足条件的已删除论文
+	UndeletePapers(conditions []string, params []interface{}) (int, error)
+
+	// PurgePapers 物理删除 deleted_at 早于 olderThan 之前的论文
+	PurgePapers(olderThan time.Duration) (int, error)
+
+	// UpdateSourceID 修改论文的平台内 ID，与已有记录冲突时返回错误
+	UpdateSourceID(paperID int64, sourceID string) error
+
+	// UpdateDecision 更新论文的录用决定
+	UpdateDecision(paperID int64, decision string) error
+
+	// SaveTranslation 保存论文标题与摘要的译文，lang 为目标语言
+	SaveTranslation(paperID int64, lang, title, abstract string) error
+
+	// FindNearDuplicates 返回其他平台中与 paperID 向量相似度不低于 threshold 的论文
+	FindNearDuplicates(paperID int64, threshold float32, limit int) ([]*models.Paper, error)
+
+	// MergePapers 将 duplicateID 的作者与分类并入 primaryID，并软删除 duplicateID
+	MergePapers(primaryID, duplicateID int64) error
+
+	// SaveCitations 记录 paperSourceID 引用的论文，重复的引用关系会被忽略
+	SaveCitations(paperSourceID string, citedSourceIDs []string, source string) error
+
+	// GetCitations 返回 paperSourceID 引用的论文 SourceID 列表
+	GetCitations(paperSourceID string) ([]string, error)
+
+	// SetPaperStatus 设置论文阅读状态（unread/reading/read/archived）
+	SetPaperStatus(paperID int64, status string) error
+
+	// GetPaperStatus 返回论文阅读状态，没有记录时为 unread
+	GetPaperStatus(paperID int64) (string, error)
+
+	// GetPapersByStatus 分页列出指定阅读状态的论文，按状态更新时间倒序
+	GetPapersByStatus(status string, limit, offset int) ([]*models.Paper, int, error)
+
+	// SetPaperStarred 设置或取消论文收藏，不改变阅读状态
+	SetPaperStarred(paperID int64, starred bool) error
+
+	// LoadPaperStatus 为论文填充 Status 与 Starred 字段
+	LoadPaperStatus(papers []*models.Paper) error
+
+	// SavePaperVersion 记录论文的一个版本，同一版本重复保存时更新提交时间与说明
+	SavePaperVersion(paperID int64, v models.PaperVersion) error
+
+	// GetPaperVersions 返回论文的版本历史，按提交时间从早到晚
+	GetPaperVersions(paperID int64) ([]models.PaperVersion, error)
+
+	// SetNote 设置论文私人笔记（覆盖原有笔记）
+	SetNote(paperID int64, note string) error
+
+	// GetNote 返回论文笔记，没有笔记时为空字符串
+	GetNote(paperID int64) (string, error)
+
+	// DeleteNote 删除论文笔记
+	DeleteNote(paperID int64) error
+
+	// SearchNotes 按子串搜索笔记，返回带笔记的论文；limit <= 0 表示不限
+	SearchNotes(query string, limit int) ([]*models.NotedPaper, error)
+
+	// AddToQueue 将论文加到阅读队列末尾，已在队列中时保持原位置
+	AddToQueue(paperID int64) error
+
+	// RemoveFromQueue 将论文移出阅读队列
+	RemoveFromQueue(paperID int64) error
+
+	// ReorderQueue 按给定顺序把论文排到队列最前，其余论文保持相对顺序
+	ReorderQueue(orderedPaperIDs []int64) error
+
+	// GetQueue 按队列顺序返回论文；limit <= 0 表示不限
+	GetQueue(limit int) ([]*models.Paper, error)
+
+	// PeekNext 返回队首论文，队列为空时返回 nil
+	PeekNext() (*models.Paper, error)
+
+	// SaveCrawlStats 记录一次爬取任务的统计，同一任务 ID 再次写入时覆盖
+	SaveCrawlStats(h models.CrawlHistory) error
+
+	// GetCrawlStats 按开始时间从新到旧返回爬取统计，limit <= 0 表示全部
+	GetCrawlStats(limit int) ([]models.CrawlHistory, error)
+
+	// DeleteCrawlStats 删除开始时间早于 olderThanDays 天前的爬取统计，0 表示全部删除
+	DeleteCrawlStats(olderThanDays int) error
+
+	// SaveSearch 按名称保存搜索，同名搜索覆盖其条件；保存时记录当前命中数作为比较基准
+	SaveSearch(name, queryJSON string) error
+
+	// GetSavedSearches 按名称返回所有保存的搜索
+	GetSavedSearches() ([]models.SavedSearch, error)
+
+	// DeleteSavedSearch 删除保存的搜索，不存在时返回错误
+	DeleteSavedSearch(id string) error
+
+	// CheckSavedSearch 重新执行保存的搜索，返回比上次检查多出的命中数并更新记录
+	CheckSavedSearch(id string) (newCount int, err error)
+
+	// GetPaperByID 按主键查找未删除的论文，不存在时返回 ErrPaperNotFound
+	GetPaperByID(id int64) (*models.Paper, error)
+
+	// GetPaperBySourceID 按 source + sourceID 查找未删除的论文，不存在时返回 ErrPaperNotFound
+	GetPaperBySourceID(source, sourceID string) (*models.Paper, error)
+
 	GetPapersByConditions(conditions []string, params []interface{}, limit int) ([]*models.Paper, error)
 
 	GetPapersList(limit, offset int, conditions []string, params []interface{}, orderBy string) ([]*models.Paper, int, error)
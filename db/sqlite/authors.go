@@ -0,0 +1,62 @@
+package db
+
+import (
+	"strings"
+
+	"PaperHunter/internal/models"
+)
+
+// authorCondition 作者过滤条件：authors 以逗号拼接存储，按子串匹配，多个作者之间为 OR；
+// 空白的作者名被忽略，全部为空时返回空条件
+func authorCondition(authors []string) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	for _, author := range authors {
+		author = strings.TrimSpace(author)
+		if author == "" {
+			continue
+		}
+		conds = append(conds, "authors LIKE '%' || ? || '%'")
+		args = append(args, author)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(conds, " OR ") + ")", args
+}
+
+// SearchByAuthor 按作者名子串检索论文（ASCII 不区分大小写），cond.Authors 与 authorQuery 之间为 OR，
+// cond 中的其他过滤条件同样生效；按首次发布时间倒序返回
+func (s *SQLiteDB) SearchByAuthor(authorQuery string, cond models.SearchCondition) ([]*models.Paper, error) {
+	where := []string{"deleted_at IS NULL"}
+	var args []interface{}
+	if c, p := authorCondition(append([]string{authorQuery}, cond.Authors...)); c != "" {
+		where = append(where, c)
+		args = append(args, p...)
+	}
+	cond.Authors = nil
+	condWhere, condArgs := searchConditionWhere(cond)
+	where = append(where, condWhere...)
+	args = append(args, condArgs...)
+
+	query := `
+	SELECT id, source, source_id, url, title, title_translated, authors,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
+		first_submitted_at, first_announced_at, updated_at
+	FROM papers
+	WHERE ` + strings.Join(where, " AND ") + `
+	ORDER BY first_announced_at DESC`
+
+	if cond.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, cond.Limit, cond.Offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanPapers(rows)
+}
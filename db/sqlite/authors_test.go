@@ -0,0 +1,94 @@
+package db
+
+import (
+	"sort"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func seedAuthorPapers(t *testing.T, d *SQLiteDB) {
+	t.Helper()
+	papers := []*models.Paper{
+		{Source: "arxiv", SourceID: "1", Title: "Deep Learning", Authors: []string{"Geoffrey Hinton", "Yann LeCun"}},
+		{Source: "arxiv", SourceID: "2", Title: "Capsule Networks", Authors: []string{"Sara Sabour", "Geoffrey Hinton"}},
+		{Source: "openreview", SourceID: "3", Title: "Convolutional Networks", Authors: []string{"Yann LeCun"}},
+		{Source: "acl", SourceID: "4", Title: "Attention Is All You Need", Authors: []string{"Ashish Vaswani"}},
+	}
+	for _, p := range papers {
+		insertPaper(t, d, p, []float32{1, 0})
+	}
+}
+
+func titles(papers []*models.Paper) []string {
+	out := make([]string, len(papers))
+	for i, p := range papers {
+		out[i] = p.Title
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestSearchByAuthor(t *testing.T) {
+	d := newTestDB(t)
+	seedAuthorPapers(t, d)
+
+	tests := []struct {
+		name  string
+		query string
+		cond  models.SearchCondition
+		want  []string
+	}{
+		{"exact author", "Ashish Vaswani", models.SearchCondition{}, []string{"Attention Is All You Need"}},
+		{"partial name, case-insensitive", "hinton", models.SearchCondition{}, []string{"Capsule Networks", "Deep Learning"}},
+		{"or with cond.Authors", "Vaswani", models.SearchCondition{Authors: []string{"LeCun"}}, []string{"Attention Is All You Need", "Convolutional Networks", "Deep Learning"}},
+		{"with source filter", "LeCun", models.SearchCondition{Sources: []string{"openreview"}}, []string{"Convolutional Networks"}},
+		{"no match", "Bengio", models.SearchCondition{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			papers, err := d.SearchByAuthor(tt.query, tt.cond)
+			if err != nil {
+				t.Fatalf("SearchByAuthor() error: %v", err)
+			}
+			got := titles(papers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SearchByAuthor(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SearchByAuthor(%q) = %v, want %v", tt.query, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSearchConditionAuthors(t *testing.T) {
+	d := newTestDB(t)
+	seedAuthorPapers(t, d)
+
+	// 关键词搜索叠加作者过滤，多个作者之间为 OR
+	papers, err := d.SearchByKeywords("Networks", models.SearchCondition{Authors: []string{"Sabour", "LeCun"}})
+	if err != nil {
+		t.Fatalf("SearchByKeywords() error: %v", err)
+	}
+	if got := titles(papers); len(got) != 2 || got[0] != "Capsule Networks" || got[1] != "Convolutional Networks" {
+		t.Errorf("Expected both Networks papers, got %v", got)
+	}
+
+	// 空白作者名被忽略
+	papers, err = d.SearchByKeywords("Networks", models.SearchCondition{Authors: []string{" "}})
+	if err != nil || len(papers) != 2 {
+		t.Errorf("Expected blank author to be ignored, got %d papers, %v", len(papers), err)
+	}
+
+	results, err := d.SearchByEmbedding([]float32{1, 0}, "test-model", models.SearchCondition{Authors: []string{"Hinton"}}, 10)
+	if err != nil {
+		t.Fatalf("SearchByEmbedding() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 papers by Hinton from embedding search, got %d", len(results))
+	}
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SaveCitations 在同一事务中写入引用关系，已存在的关系保持不变
+func (s *SQLiteDB) SaveCitations(paperSourceID string, citedSourceIDs []string, source string) error {
+	if paperSourceID == "" || len(citedSourceIDs) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO paper_citations (source_id, cited_id, source) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, cited := range citedSourceIDs {
+		cited = strings.TrimSpace(cited)
+		if cited == "" || cited == paperSourceID {
+			continue
+		}
+		if _, err := stmt.Exec(paperSourceID, cited, source); err != nil {
+			return fmt.Errorf("保存引用关系失败 (%s -> %s): %w", paperSourceID, cited, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetCitations 按写入顺序返回引用的论文 SourceID，没有引用时返回空切片
+func (s *SQLiteDB) GetCitations(paperSourceID string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT cited_id FROM paper_citations WHERE source_id = ? ORDER BY rowid`, paperSourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cited := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		cited = append(cited, id)
+	}
+	return cited, rows.Err()
+}
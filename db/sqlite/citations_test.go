@@ -0,0 +1,59 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSaveCitations_Bidirectional(t *testing.T) {
+	d := newTestDB(t)
+
+	// A 与 B 互相引用，A 还引用了 C
+	if err := d.SaveCitations("A", []string{"B", "C"}, "arxiv"); err != nil {
+		t.Fatalf("SaveCitations(A) error: %v", err)
+	}
+	if err := d.SaveCitations("B", []string{"A"}, "arxiv"); err != nil {
+		t.Fatalf("SaveCitations(B) error: %v", err)
+	}
+	// 重复写入、空白与自引用被忽略
+	if err := d.SaveCitations("A", []string{"B", " ", "A"}, "arxiv"); err != nil {
+		t.Fatalf("repeated SaveCitations(A) error: %v", err)
+	}
+
+	cases := map[string][]string{
+		"A": {"B", "C"},
+		"B": {"A"},
+	}
+	for id, want := range cases {
+		got, err := d.GetCitations(id)
+		if err != nil {
+			t.Fatalf("GetCitations(%s) error: %v", id, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetCitations(%s) = %v, want %v", id, got, want)
+		}
+	}
+
+	var rows int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM paper_citations").Scan(&rows); err != nil || rows != 3 {
+		t.Errorf("Expected 3 citation rows, got %d (%v)", rows, err)
+	}
+}
+
+func TestGetCitations_NoCitations(t *testing.T) {
+	d := newTestDB(t)
+	seedPapers(t, d, 1)
+
+	got, err := d.GetCitations("2401.00000")
+	if err != nil {
+		t.Fatalf("GetCitations() error: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %#v", got)
+	}
+
+	// 没有引用时写入空列表不产生记录
+	if err := d.SaveCitations("2401.00000", nil, "arxiv"); err != nil {
+		t.Errorf("SaveCitations(nil) error: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+// SaveCrawlStats 记录一次爬取任务的统计，同一任务 ID 再次写入时覆盖；时间统一以 UTC 存储以便按文本排序与比较
+func (s *SQLiteDB) SaveCrawlStats(h models.CrawlHistory) error {
+	if h.TaskID == "" {
+		return fmt.Errorf("任务 ID 不能为空")
+	}
+	params, err := json.Marshal(h.Params)
+	if err != nil {
+		return fmt.Errorf("序列化爬取参数失败: %w", err)
+	}
+	ids := h.InsertedIDs
+	if ids == nil {
+		ids = []int64{}
+	}
+	inserted, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("序列化入库论文 ID 失败: %w", err)
+	}
+	var endTime interface{}
+	if !h.EndTime.IsZero() {
+		endTime = h.EndTime.UTC()
+	}
+
+	_, err = s.db.Exec(`
+	INSERT OR REPLACE INTO crawl_stats (task_id, platform, params, total, start_time, end_time, status, inserted_ids)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, h.TaskID, h.Platform, string(params), h.Total, h.StartTime.UTC(), endTime, h.Status, string(inserted))
+	if err != nil {
+		return fmt.Errorf("保存爬取统计失败 (%s): %w", h.TaskID, err)
+	}
+	return nil
+}
+
+// GetCrawlStats 按开始时间从新到旧返回爬取统计，limit <= 0 表示全部；没有记录时返回空切片
+func (s *SQLiteDB) GetCrawlStats(limit int) ([]models.CrawlHistory, error) {
+	query := `
+	SELECT task_id, platform, params, total, start_time, end_time, status, inserted_ids
+	FROM crawl_stats
+	ORDER BY start_time DESC, rowid DESC`
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.CrawlHistory{}
+	for rows.Next() {
+		var h models.CrawlHistory
+		var params, inserted string
+		var endTime sql.NullTime
+		if err := rows.Scan(&h.TaskID, &h.Platform, &params, &h.Total, &h.StartTime, &endTime, &h.Status, &inserted); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(params), &h.Params); err != nil {
+			return nil, fmt.Errorf("解析爬取参数失败 (%s): %w", h.TaskID, err)
+		}
+		if err := json.Unmarshal([]byte(inserted), &h.InsertedIDs); err != nil {
+			return nil, fmt.Errorf("解析入库论文 ID 失败 (%s): %w", h.TaskID, err)
+		}
+		h.StartTime = h.StartTime.Local()
+		if endTime.Valid {
+			h.EndTime = endTime.Time.Local()
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
+// DeleteCrawlStats 删除开始时间早于 olderThanDays 天前的爬取统计，0 表示全部删除
+func (s *SQLiteDB) DeleteCrawlStats(olderThanDays int) error {
+	if olderThanDays < 0 {
+		return fmt.Errorf("天数不能为负数: %d", olderThanDays)
+	}
+	if olderThanDays == 0 {
+		_, err := s.db.Exec("DELETE FROM crawl_stats")
+		return err
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -olderThanDays)
+	_, err := s.db.Exec("DELETE FROM crawl_stats WHERE start_time < ?", cutoff)
+	return err
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func TestCrawlStats_PersistAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "papers.db")
+	d, err := NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	now := time.Now()
+	entries := []models.CrawlHistory{
+		{TaskID: "task_old", Platform: "acl", Total: 1, StartTime: now.AddDate(0, 0, -40), EndTime: now.AddDate(0, 0, -40).Add(time.Minute), Status: "completed"},
+		{TaskID: "task_1", Platform: "arxiv", Params: map[string]interface{}{"keywords": []interface{}{"graph"}}, Total: 2,
+			StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour), Status: "completed", InsertedIDs: []int64{3, 5}},
+		{TaskID: "task_2", Platform: "dblp", StartTime: now.Add(-time.Minute), Status: "failed"},
+	}
+	for _, h := range entries {
+		if err := d.SaveCrawlStats(h); err != nil {
+			t.Fatalf("SaveCrawlStats(%s) error: %v", h.TaskID, err)
+		}
+	}
+	if err := d.SaveCrawlStats(models.CrawlHistory{Platform: "arxiv"}); err == nil {
+		t.Error("Expected error for empty task ID")
+	}
+	d.Close()
+
+	d, err = NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() reopen error: %v", err)
+	}
+	defer d.Close()
+
+	history, err := d.GetCrawlStats(0)
+	if err != nil {
+		t.Fatalf("GetCrawlStats() error: %v", err)
+	}
+	if len(history) != 3 || history[0].TaskID != "task_2" || history[1].TaskID != "task_1" || history[2].TaskID != "task_old" {
+		t.Fatalf("Expected newest first, got %+v", history)
+	}
+	got := history[1]
+	if got.Platform != "arxiv" || got.Total != 2 || got.Status != "completed" || !got.StartTime.Equal(entries[1].StartTime) || !got.EndTime.Equal(entries[1].EndTime) {
+		t.Errorf("Unexpected persisted entry: %+v", got)
+	}
+	if kw, ok := got.Params["keywords"].([]interface{}); !ok || len(kw) != 1 || kw[0] != "graph" {
+		t.Errorf("Params = %v, want keywords [graph]", got.Params)
+	}
+	if len(got.InsertedIDs) != 2 || got.InsertedIDs[0] != 3 || got.InsertedIDs[1] != 5 {
+		t.Errorf("InsertedIDs = %v, want [3 5]", got.InsertedIDs)
+	}
+	if !history[0].EndTime.IsZero() {
+		t.Errorf("Expected zero end time for unfinished entry, got %v", history[0].EndTime)
+	}
+
+	if limited, _ := d.GetCrawlStats(1); len(limited) != 1 || limited[0].TaskID != "task_2" {
+		t.Errorf("GetCrawlStats(1) = %+v, want only the newest entry", limited)
+	}
+
+	if err := d.DeleteCrawlStats(-1); err == nil {
+		t.Error("Expected error for negative days")
+	}
+	if err := d.DeleteCrawlStats(30); err != nil {
+		t.Fatalf("DeleteCrawlStats(30) error: %v", err)
+	}
+	if history, _ := d.GetCrawlStats(0); len(history) != 2 {
+		t.Errorf("Expected entries older than 30 days to be purged, got %+v", history)
+	}
+	if err := d.DeleteCrawlStats(0); err != nil {
+		t.Fatalf("DeleteCrawlStats(0) error: %v", err)
+	}
+	if history, err := d.GetCrawlStats(0); err != nil || history == nil || len(history) != 0 {
+		t.Errorf("Expected empty non-nil slice after purging all, got %v, %v", history, err)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/binary"
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	storage "PaperHunter/db"
 	"PaperHunter/internal/models"
+	"PaperHunter/pkg/quantization"
 	"PaperHunter/pkg/similarity"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -17,20 +21,32 @@ import (
 // 爬取时也需要将对应的标题 embedding
 
 func (s *SQLiteDB) Upsert(p *models.Paper) (int64, error) {
+	fingerprint, doi := paperFingerprint(p), p.DOI()
+	if s.mergeCrossSource {
+		if id, ok, err := s.mergeCrossSourceDuplicate(p, fingerprint, doi); err != nil || ok {
+			return id, err
+		}
+	}
+
 	query := `
 	INSERT INTO papers (
 		source, source_id, url, title, title_translated,
-		authors, abstract, abstract_translated, categories, comments,
-		first_submitted_at, first_announced_at, updated_at
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		authors, abstract, abstract_translated, categories, comments, citations, decision, open_access_url,
+		fingerprint, doi, first_submitted_at, first_announced_at, updated_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 	ON CONFLICT(source, source_id) DO UPDATE SET
 		title = excluded.title,
-		title_translated = excluded.title_translated,
+		title_translated = CASE WHEN COALESCE(excluded.title_translated, '') != '' THEN excluded.title_translated ELSE papers.title_translated END,
 		authors = excluded.authors,
 		abstract = excluded.abstract,
-		abstract_translated = excluded.abstract_translated,
+		abstract_translated = CASE WHEN COALESCE(excluded.abstract_translated, '') != '' THEN excluded.abstract_translated ELSE papers.abstract_translated END,
 		categories = excluded.categories,
 		comments = excluded.comments,
+		citations = CASE WHEN excluded.citations > 0 THEN excluded.citations ELSE papers.citations END,
+		decision = CASE WHEN excluded.decision != '' THEN excluded.decision ELSE papers.decision END,
+		open_access_url = CASE WHEN excluded.open_access_url != '' THEN excluded.open_access_url ELSE papers.open_access_url END,
+		fingerprint = excluded.fingerprint,
+		doi = excluded.doi,
 		first_submitted_at = excluded.first_submitted_at,
 		first_announced_at = excluded.first_announced_at,
 		updated_at = CURRENT_TIMESTAMP
@@ -41,37 +57,130 @@ func (s *SQLiteDB) Upsert(p *models.Paper) (int64, error) {
 	err := s.db.QueryRow(query,
 		p.Source, p.SourceID, p.URL, p.Title, p.TitleTranslated,
 		p.AuthorsCSV(), p.Abstract, p.AbstractTranslated,
-		p.CategoriesCSV(), p.Comments,
-		p.FirstSubmittedAt, p.FirstAnnouncedAt,
+		p.CategoriesCSV(), p.Comments, p.Citations, p.Decision, p.OpenAccessURL,
+		fingerprint, doi, p.FirstSubmittedAt, p.FirstAnnouncedAt,
 	).Scan(&id)
 
 	return id, err
 }
 
-// SaveEmbedding 保存论文的向量表示
+// EnableQuantization 之后保存的向量以 int8 量化存储，已有的 float32 向量仍可正常读取
+func (s *SQLiteDB) EnableQuantization() {
+	s.quantize = true
+}
+
+// SaveEmbedding 保存论文的向量表示，启用量化时改为 SaveQuantizedEmbedding
 func (s *SQLiteDB) SaveEmbedding(paperID int64, model, text string, vec []float32) error {
-	blob := encodeVec(vec)
+	if s.quantize {
+		return s.SaveQuantizedEmbedding(paperID, model, text, vec)
+	}
+	return s.saveEmbedding(paperID, model, text, encodeVec(vec), sql.NullFloat64{}, vec)
+}
+
+// SaveQuantizedEmbedding 将向量量化为 int8 后保存，缩放系数写入 embedding_scale
+func (s *SQLiteDB) SaveQuantizedEmbedding(paperID int64, model, text string, vec []float32) error {
+	var scale float32
+	blob := quantization.QuantizeFloat32ToInt8(vec, &scale)
+	// 索引中使用还原后的向量，与之后从数据库读取的结果一致
+	restored := quantization.DequantizeInt8ToFloat32(blob, scale)
+	return s.saveEmbedding(paperID, model, text, blob, sql.NullFloat64{Float64: float64(scale), Valid: true}, restored)
+}
+
+// checkEmbeddingDim 校验向量维度与同一模型已存向量一致，避免混入维度不同的向量导致相似度计算出错；
+// 该模型尚无向量时以本次维度为准
+func (s *SQLiteDB) checkEmbeddingDim(model string, dim int) error {
+	if dim == 0 {
+		return fmt.Errorf("%w: 向量为空", storage.ErrEmbeddingDimMismatch)
+	}
+
+	s.dimMu.Lock()
+	defer s.dimMu.Unlock()
+	want, ok := s.embeddingDims[model]
+	if !ok {
+		err := s.db.QueryRow(`
+		SELECT CASE WHEN embedding_scale IS NULL THEN LENGTH(embedding) / 4 ELSE LENGTH(embedding) END
+		FROM papers WHERE embedding_model = ? AND embedding IS NOT NULL LIMIT 1`, model).Scan(&want)
+		if err == sql.ErrNoRows {
+			want = dim
+		} else if err != nil {
+			return fmt.Errorf("读取已存向量维度失败: %w", err)
+		}
+		if s.embeddingDims == nil {
+			s.embeddingDims = make(map[string]int)
+		}
+		s.embeddingDims[model] = want
+	}
+	if dim != want {
+		return fmt.Errorf("%w: 模型 %s 已存向量为 %d 维，本次为 %d 维", storage.ErrEmbeddingDimMismatch, model, want, dim)
+	}
+	return nil
+}
+
+// saveEmbedding 校验维度后写入向量，vec 为加入内存索引的向量，与 blob 维度相同
+func (s *SQLiteDB) saveEmbedding(paperID int64, model, text string, blob []byte, scale sql.NullFloat64, vec []float32) error {
+	if err := s.checkEmbeddingDim(model, len(vec)); err != nil {
+		return err
+	}
 	query := `
 	UPDATE papers SET 
 		embedding_text = ?,
 		embedding = ?,
+		embedding_scale = ?,
 		embedding_model = ?,
 		embedding_updated_at = CURRENT_TIMESTAMP
 	WHERE id = ?
 	`
 
-	_, err := s.db.Exec(query, text, blob, model, paperID)
-	return err
+	if _, err := s.db.Exec(query, text, blob, scale, model, paperID); err != nil {
+		return err
+	}
+	if s.embCache != nil {
+		s.embCache.Invalidate(paperID)
+	}
+	s.indexEmbedding(paperID, model, vec)
+	return nil
+}
+
+// GetEmbeddings 按论文 ID 批量读取指定模型的向量，没有向量或模型不一致的论文不在结果中
+func (s *SQLiteDB) GetEmbeddings(paperIDs []int64, model string) (map[int64][]float32, error) {
+	out := make(map[int64][]float32, len(paperIDs))
+	if len(paperIDs) == 0 {
+		return out, nil
+	}
+	placeholders := make([]string, len(paperIDs))
+	args := make([]interface{}, 0, len(paperIDs)+1)
+	args = append(args, model)
+	for i, id := range paperIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	query := `SELECT id, embedding, embedding_scale FROM papers WHERE embedding IS NOT NULL AND embedding_model = ? AND id IN (` + strings.Join(placeholders, ",") + `)`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var blob []byte
+		var scale sql.NullFloat64
+		if err := rows.Scan(&id, &blob, &scale); err != nil {
+			return nil, err
+		}
+		out[id] = decodeEmbedding(blob, scale)
+	}
+	return out, rows.Err()
 }
 
 // GetPapersNeedingEmbedding 获取需要计算向量的论文
 func (s *SQLiteDB) GetPapersNeedingEmbedding(model string, limit int) ([]*models.Paper, error) {
 	query := `
 	SELECT id, source, source_id, url, title, title_translated, authors,
-		abstract, abstract_translated, categories, comments,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
 		first_submitted_at, first_announced_at, updated_at
 	FROM papers 
-	WHERE embedding IS NULL OR embedding_model != ?
+	WHERE deleted_at IS NULL AND (embedding IS NULL OR embedding_model != ?)
 	LIMIT ?
 	`
 
@@ -84,35 +193,64 @@ func (s *SQLiteDB) GetPapersNeedingEmbedding(model string, limit int) ([]*models
 	return s.scanPapers(rows)
 }
 
-// SearchByEmbedding 基于向量相似度检索论文
-func (s *SQLiteDB) SearchByEmbedding(queryVec []float32, model string, cond models.SearchCondition, topK int) ([]*models.SimilarPaper, error) {
-
-	where := []string{"embedding IS NOT NULL", "embedding_model = ?"}
-	args := []interface{}{model}
+// GetEmbeddingStats 按模型与维度统计未删除论文中已存的向量，量化向量每维 1 字节，否则每维 4 字节
+func (s *SQLiteDB) GetEmbeddingStats() ([]models.EmbeddingStat, error) {
+	rows, err := s.db.Query(`
+	SELECT embedding_model,
+		CASE WHEN embedding_scale IS NULL THEN LENGTH(embedding) / 4 ELSE LENGTH(embedding) END AS dim,
+		COUNT(*)
+	FROM papers
+	WHERE deleted_at IS NULL AND embedding IS NOT NULL
+	GROUP BY 1, 2
+	ORDER BY 3 DESC, 1, 2
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	if len(cond.Sources) > 0 {
-		placeholders := strings.Repeat("?,", len(cond.Sources))
-		placeholders = placeholders[:len(placeholders)-1]
-		where = append(where, "source IN ("+placeholders+")")
-		for _, src := range cond.Sources {
-			args = append(args, src)
+	var stats []models.EmbeddingStat
+	for rows.Next() {
+		var st models.EmbeddingStat
+		var model sql.NullString
+		if err := rows.Scan(&model, &st.Dim, &st.Count); err != nil {
+			return nil, err
 		}
+		st.Model = model.String
+		stats = append(stats, st)
 	}
+	return stats, rows.Err()
+}
 
-	if cond.DateFrom != nil {
-		where = append(where, "first_announced_at >= ?")
-		args = append(args, *cond.DateFrom)
+// SearchByEmbedding 基于向量相似度检索论文
+// 启用向量索引时先在索引中近邻检索，候选经过滤后不足 topK 时回退到全表扫描
+func (s *SQLiteDB) SearchByEmbedding(queryVec []float32, model string, cond models.SearchCondition, topK int) ([]*models.SimilarPaper, error) {
+	if s.vectorIndex {
+		results, ok, err := s.searchIndexed(queryVec, model, cond, topK)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return results, nil
+		}
 	}
 
-	if cond.DateTo != nil {
-		where = append(where, "first_announced_at <= ?")
-		args = append(args, *cond.DateTo)
-	}
+	where, args := embeddingWhere(model, cond)
+	return s.scanByEmbedding(queryVec, model, where, args, topK)
+}
+
+// scanChunkSize scanByEmbedding 每批打分的行数
+const scanChunkSize = 1024
 
+// scanByEmbedding 对满足 where 的论文逐一计算相似度，返回最相似的 topK 篇；启用缓存时走 searchCached
+func (s *SQLiteDB) scanByEmbedding(queryVec []float32, model string, where []string, args []interface{}, topK int) ([]*models.SimilarPaper, error) {
+	if s.embCache != nil {
+		return s.searchCached(queryVec, model, where, args, topK)
+	}
 	query := `
 	SELECT id, source, source_id, url, title, title_translated, authors,
-		abstract, abstract_translated, categories, comments,
-		first_submitted_at, first_announced_at, updated_at, embedding
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
+		first_submitted_at, first_announced_at, updated_at, embedding, embedding_scale
 	FROM papers 
 	WHERE ` + strings.Join(where, " AND ")
 
@@ -122,16 +260,27 @@ func (s *SQLiteDB) SearchByEmbedding(queryVec []float32, model string, cond mode
 	}
 	defer rows.Close()
 
+	// 按批打分，内存中只保留一批向量与当前最相似的 topK 篇
 	var results []*models.SimilarPaper
+	chunk := make([]*models.SimilarPaper, 0, scanChunkSize)
+	vecs := make([][]float32, 0, scanChunkSize)
+	flush := func() {
+		for i, sim := range similarity.CosineSimilarityBatch(queryVec, vecs) {
+			chunk[i].Similarity = sim
+		}
+		results = keepTopK(append(results, chunk...), topK)
+		chunk, vecs = chunk[:0], vecs[:0]
+	}
 	for rows.Next() {
 		var p models.Paper
-		var authorsStr, categoriesStr string
+		var authorsStr, categoriesStr, altSourcesStr string
 		var embBlob []byte
+		var embScale sql.NullFloat64
 
 		err := rows.Scan(
 			&p.ID, &p.Source, &p.SourceID, &p.URL, &p.Title, &p.TitleTranslated,
-			&authorsStr, &p.Abstract, &p.AbstractTranslated, &categoriesStr, &p.Comments,
-			&p.FirstSubmittedAt, &p.FirstAnnouncedAt, &p.UpdatedAt, &embBlob,
+			&authorsStr, &p.Abstract, &p.AbstractTranslated, &categoriesStr, &p.Comments, &p.Citations, &p.Decision, &altSourcesStr, &p.OpenAccessURL,
+			&p.FirstSubmittedAt, &p.FirstAnnouncedAt, &p.UpdatedAt, &embBlob, &embScale,
 		)
 		if err != nil {
 			return nil, err
@@ -143,25 +292,31 @@ func (s *SQLiteDB) SearchByEmbedding(queryVec []float32, model string, cond mode
 		if categoriesStr != "" {
 			p.Categories = strings.Split(strings.Trim(categoriesStr, ","), ",")
 		}
+		p.AltSources = splitAltSources(altSourcesStr)
 
-		vec := decodeVec(embBlob)
-		sim := similarity.CosineSimilarity(queryVec, vec)
-
-		results = append(results, &models.SimilarPaper{
-			Paper:      p,
-			Similarity: sim,
-		})
+		vecs = append(vecs, decodeEmbedding(embBlob, embScale))
+		chunk = append(chunk, &models.SimilarPaper{Paper: p})
+		if len(chunk) == scanChunkSize {
+			flush()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
+	flush()
 
-	sort.Slice(results, func(i, j int) bool {
+	return results, nil
+}
+
+// keepTopK 按相似度降序排列并只保留前 topK 个，相似度相同时保持原有顺序
+func keepTopK(results []*models.SimilarPaper, topK int) []*models.SimilarPaper {
+	sort.SliceStable(results, func(i, j int) bool {
 		return results[i].Similarity > results[j].Similarity
 	})
-
 	if len(results) > topK {
 		results = results[:topK]
 	}
-
-	return results, rows.Err()
+	return results
 }
 
 func (s *SQLiteDB) scanPapers(rows *sql.Rows) ([]*models.Paper, error) {
@@ -169,11 +324,11 @@ func (s *SQLiteDB) scanPapers(rows *sql.Rows) ([]*models.Paper, error) {
 
 	for rows.Next() {
 		var p models.Paper
-		var authorsStr, categoriesStr string
+		var authorsStr, categoriesStr, altSourcesStr string
 
 		err := rows.Scan(
 			&p.ID, &p.Source, &p.SourceID, &p.URL, &p.Title, &p.TitleTranslated,
-			&authorsStr, &p.Abstract, &p.AbstractTranslated, &categoriesStr, &p.Comments,
+			&authorsStr, &p.Abstract, &p.AbstractTranslated, &categoriesStr, &p.Comments, &p.Citations, &p.Decision, &altSourcesStr, &p.OpenAccessURL,
 			&p.FirstSubmittedAt, &p.FirstAnnouncedAt, &p.UpdatedAt,
 		)
 		if err != nil {
@@ -186,6 +341,7 @@ func (s *SQLiteDB) scanPapers(rows *sql.Rows) ([]*models.Paper, error) {
 		if categoriesStr != "" {
 			p.Categories = strings.Split(strings.Trim(categoriesStr, ","), ",")
 		}
+		p.AltSources = splitAltSources(altSourcesStr)
 
 		papers = append(papers, &p)
 	}
@@ -206,23 +362,95 @@ func decodeVec(blob []byte) []float32 {
 	return vec
 }
 
-func (s *SQLiteDB) CountPapers(conditions []string, params []interface{}) (int, error) {
-	query := "SELECT COUNT(*) FROM papers"
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+// decodeEmbedding 按 embedding_scale 判断存储格式：非 NULL 为 int8 量化向量，否则为 float32 数组
+func decodeEmbedding(blob []byte, scale sql.NullFloat64) []float32 {
+	if scale.Valid {
+		return quantization.DequantizeInt8ToFloat32(blob, float32(scale.Float64))
+	}
+	return decodeVec(blob)
+}
+
+// activeWhere 组合调用方条件并排除已软删除的论文
+func activeWhere(conditions []string) string {
+	return " WHERE " + strings.Join(append([]string{"deleted_at IS NULL"}, wrapConditions(conditions)...), " AND ")
+}
+
+// wrapConditions 为每个条件加括号，避免条件内的 OR 与拼接的 AND 优先级冲突
+func wrapConditions(conditions []string) []string {
+	wrapped := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		wrapped = append(wrapped, "("+c+")")
 	}
+	return wrapped
+}
+
+func (s *SQLiteDB) CountPapers(conditions []string, params []interface{}) (int, error) {
+	query := "SELECT COUNT(*) FROM papers" + activeWhere(conditions)
 
 	var count int
 	err := s.db.QueryRow(query, params...).Scan(&count)
 	return count, err
 }
 
+// UpdateDecision 更新论文的录用决定
+func (s *SQLiteDB) UpdateDecision(paperID int64, decision string) error {
+	result, err := s.db.Exec(`UPDATE papers SET decision = ? WHERE id = ?`, decision, paperID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("论文不存在: id=%d", paperID)
+	}
+	return nil
+}
+
+// SaveTranslation 保存论文标题与摘要的译文及目标语言，译文为空的字段保持原值
+func (s *SQLiteDB) SaveTranslation(paperID int64, lang, title, abstract string) error {
+	result, err := s.db.Exec(`
+	UPDATE papers SET
+		title_translated = CASE WHEN ? != '' THEN ? ELSE title_translated END,
+		abstract_translated = CASE WHEN ? != '' THEN ? ELSE abstract_translated END,
+		translation_lang = ?
+	WHERE id = ?`, title, title, abstract, abstract, lang, paperID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("论文不存在: id=%d", paperID)
+	}
+	return nil
+}
+
+// UpdateSourceID 修改论文的 source_id，UNIQUE(source, source_id) 冲突时返回错误
+func (s *SQLiteDB) UpdateSourceID(paperID int64, sourceID string) error {
+	result, err := s.db.Exec(`UPDATE papers SET source_id = ? WHERE id = ?`, sourceID, paperID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("论文不存在: id=%d", paperID)
+	}
+	return nil
+}
+
+// DeletePapers 软删除满足条件的论文，可通过 UndeletePapers 恢复
 func (s *SQLiteDB) DeletePapers(conditions []string, params []interface{}) (int, error) {
-	query := "DELETE FROM papers"
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	query := "UPDATE papers SET deleted_at = CURRENT_TIMESTAMP" + activeWhere(conditions)
+
+	result, err := s.db.Exec(query, params...)
+	if err != nil {
+		return 0, err
 	}
 
+	count, err := result.RowsAffected()
+	return int(count), err
+}
+
+// UndeletePapers 恢复满足条件的已删除论文
+func (s *SQLiteDB) UndeletePapers(conditions []string, params []interface{}) (int, error) {
+	where := append([]string{"deleted_at IS NOT NULL"}, wrapConditions(conditions)...)
+	query := "UPDATE papers SET deleted_at = NULL WHERE " + strings.Join(where, " AND ")
+
 	result, err := s.db.Exec(query, params...)
 	if err != nil {
 		return 0, err
@@ -232,11 +460,28 @@ func (s *SQLiteDB) DeletePapers(conditions []string, params []interface{}) (int,
 	return int(count), err
 }
 
-func (s *SQLiteDB) SearchByKeywords(query string, cond models.SearchCondition) ([]*models.Paper, error) {
+// PurgePapers 物理删除软删除时间早于 olderThan 之前的论文，olderThan <= 0 时清除全部已删除论文
+func (s *SQLiteDB) PurgePapers(olderThan time.Duration) (int, error) {
+	if olderThan < 0 {
+		olderThan = 0
+	}
+	// deleted_at 由 CURRENT_TIMESTAMP 写入（UTC 文本），用 datetime('now', ...) 比较避免时区与格式差异
+	query := "DELETE FROM papers WHERE deleted_at IS NOT NULL AND deleted_at <= datetime('now', ?)"
+	modifier := fmt.Sprintf("-%d seconds", int64(olderThan.Seconds()))
 
-	where := []string{"(title LIKE ? OR abstract LIKE ?)"}
-	searchPattern := "%" + query + "%"
-	args := []interface{}{searchPattern, searchPattern}
+	result, err := s.db.Exec(query, modifier)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	return int(count), err
+}
+
+// searchConditionWhere 将 SearchCondition 中的平台、日期、阅读状态、收藏与作者过滤转为 SQL 条件
+func searchConditionWhere(cond models.SearchCondition) ([]string, []interface{}) {
+	var where []string
+	var args []interface{}
 
 	if len(cond.Sources) > 0 {
 		placeholders := strings.Repeat("?,", len(cond.Sources))
@@ -257,9 +502,30 @@ func (s *SQLiteDB) SearchByKeywords(query string, cond models.SearchCondition) (
 		args = append(args, *cond.DateTo)
 	}
 
+	if cond.Status != "" {
+		c, p := StatusCondition(cond.Status)
+		where = append(where, c)
+		args = append(args, p...)
+	}
+
+	if cond.Starred {
+		where = append(where, StarredCondition())
+	}
+
+	if c, p := authorCondition(cond.Authors); c != "" {
+		where = append(where, c)
+		args = append(args, p...)
+	}
+	return where, args
+}
+
+func (s *SQLiteDB) SearchByKeywords(query string, cond models.SearchCondition) ([]*models.Paper, error) {
+
+	where, args := keywordWhere(query, cond)
+
 	sqlQuery := `
 	SELECT id, source, source_id, url, title, title_translated, authors,
-		abstract, abstract_translated, categories, comments,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
 		first_submitted_at, first_announced_at, updated_at
 	FROM papers 
 	WHERE ` + strings.Join(where, " AND ")
@@ -278,16 +544,22 @@ func (s *SQLiteDB) SearchByKeywords(query string, cond models.SearchCondition) (
 	return s.scanPapers(rows)
 }
 
+// keywordWhere 标题或摘要包含 query 的未删除论文，并叠加 cond 中的过滤条件
+func keywordWhere(query string, cond models.SearchCondition) ([]string, []interface{}) {
+	where := []string{"deleted_at IS NULL", "(title LIKE ? OR abstract LIKE ?)"}
+	searchPattern := "%" + query + "%"
+	args := []interface{}{searchPattern, searchPattern}
+
+	condWhere, condArgs := searchConditionWhere(cond)
+	return append(where, condWhere...), append(args, condArgs...)
+}
+
 func (s *SQLiteDB) GetPapersByConditions(conditions []string, params []interface{}, limit int) ([]*models.Paper, error) {
 	query := `
 	SELECT id, source, source_id, url, title, title_translated, authors,
-		abstract, abstract_translated, categories, comments,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
 		first_submitted_at, first_announced_at, updated_at
-	FROM papers`
-
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	FROM papers` + activeWhere(conditions)
 
 	// 添加 LIMIT 子句
 	if limit > 0 {
@@ -304,12 +576,33 @@ func (s *SQLiteDB) GetPapersByConditions(conditions []string, params []interface
 	return s.scanPapers(rows)
 }
 
+// GetPaperByID 按主键查找未删除的论文，不存在时返回包装了 ErrPaperNotFound 的错误
+func (s *SQLiteDB) GetPaperByID(id int64) (*models.Paper, error) {
+	papers, err := s.GetPapersByConditions([]string{"id = ?"}, []interface{}{id}, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(papers) == 0 {
+		return nil, fmt.Errorf("%w: id=%d", storage.ErrPaperNotFound, id)
+	}
+	return papers[0], nil
+}
+
+// GetPaperBySourceID 按 source + sourceID 查找未删除的论文，不存在时返回包装了 ErrPaperNotFound 的错误
+func (s *SQLiteDB) GetPaperBySourceID(source, sourceID string) (*models.Paper, error) {
+	papers, err := s.GetPapersByConditions([]string{"source = ?", "source_id = ?"}, []interface{}{source, sourceID}, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(papers) == 0 {
+		return nil, fmt.Errorf("%w: %s/%s", storage.ErrPaperNotFound, source, sourceID)
+	}
+	return papers[0], nil
+}
+
 func (s *SQLiteDB) GetPapersList(limit, offset int, conditions []string, params []interface{}, orderBy string) ([]*models.Paper, int, error) {
 	//计算总量
-	countQuery := "SELECT COUNT(*) FROM papers"
-	if len(conditions) > 0 {
-		countQuery += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	countQuery := "SELECT COUNT(*) FROM papers" + activeWhere(conditions)
 	var total int
 	err := s.db.QueryRow(countQuery, params...).Scan(&total)
 	if err != nil {
@@ -319,13 +612,9 @@ func (s *SQLiteDB) GetPapersList(limit, offset int, conditions []string, params
 	// 直接查询即可
 	query := `
 	SELECT id, source, source_id, url, title, title_translated, authors,
-		abstract, abstract_translated, categories, comments,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
 		first_submitted_at, first_announced_at, updated_at
-	FROM papers`
-
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
+	FROM papers` + activeWhere(conditions)
 
 	if orderBy != "" {
 		query += " ORDER BY " + orderBy
@@ -0,0 +1,380 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	storage "PaperHunter/db"
+	"PaperHunter/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestDB(t *testing.T) *SQLiteDB {
+	t.Helper()
+	d, err := NewSQLiteDB(filepath.Join(t.TempDir(), "papers.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
+func seedPapers(t *testing.T, d *SQLiteDB, n int) []int64 {
+	t.Helper()
+	ids := make([]int64, n)
+	for i := 0; i < n; i++ {
+		p := &models.Paper{
+			Source:           "arxiv",
+			SourceID:         fmt.Sprintf("2401.%05d", i),
+			URL:              fmt.Sprintf("https://arxiv.org/abs/2401.%05d", i),
+			Title:            fmt.Sprintf("Graph Paper %d", i),
+			Abstract:         "Graph neural networks.",
+			FirstAnnouncedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+		id, err := d.Upsert(p)
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		if err := d.SaveEmbedding(id, "test-model", p.Title, []float32{1, 0}); err != nil {
+			t.Fatalf("SaveEmbedding() error: %v", err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func TestDeletePapers_SoftDeleteAndUndelete(t *testing.T) {
+	d := newTestDB(t)
+	seedPapers(t, d, 3)
+
+	deleted, err := d.DeletePapers([]string{"source_id = ?"}, []interface{}{"2401.00001"})
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeletePapers() = %d, %v; want 1, nil", deleted, err)
+	}
+	// 重复删除不再计数
+	if again, _ := d.DeletePapers([]string{"source_id = ?"}, []interface{}{"2401.00001"}); again != 0 {
+		t.Errorf("Expected repeated delete to affect 0 rows, got %d", again)
+	}
+
+	// 行仍在表中
+	var raw int
+	if err := d.db.QueryRow("SELECT COUNT(*) FROM papers").Scan(&raw); err != nil || raw != 3 {
+		t.Errorf("Expected 3 physical rows after soft delete, got %d (%v)", raw, err)
+	}
+
+	if n, _ := d.CountPapers(nil, nil); n != 2 {
+		t.Errorf("CountPapers() = %d, want 2", n)
+	}
+	restored, err := d.UndeletePapers([]string{"source_id = ?"}, []interface{}{"2401.00001"})
+	if err != nil || restored != 1 {
+		t.Fatalf("UndeletePapers() = %d, %v; want 1, nil", restored, err)
+	}
+	if n, _ := d.CountPapers(nil, nil); n != 3 {
+		t.Errorf("CountPapers() after undelete = %d, want 3", n)
+	}
+}
+
+func TestQueriesExcludeSoftDeleted(t *testing.T) {
+	d := newTestDB(t)
+	seedPapers(t, d, 3)
+
+	// 条件内含 OR，验证与软删除条件组合时的优先级
+	if _, err := d.DeletePapers([]string{"source_id = ? OR source_id = ?"}, []interface{}{"2401.00000", "2401.00002"}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+
+	papers, err := d.GetPapersByConditions(nil, nil, 0)
+	if err != nil || len(papers) != 1 || papers[0].SourceID != "2401.00001" {
+		t.Errorf("GetPapersByConditions() = %d papers, %v; want only 2401.00001", len(papers), err)
+	}
+
+	papers, err = d.GetPapersByConditions([]string{"source = ? OR title LIKE ?"}, []interface{}{"arxiv", "%Graph%"}, 0)
+	if err != nil || len(papers) != 1 {
+		t.Errorf("GetPapersByConditions() with OR condition = %d papers, %v; want 1", len(papers), err)
+	}
+
+	list, total, err := d.GetPapersList(10, 0, nil, nil, "")
+	if err != nil || total != 1 || len(list) != 1 {
+		t.Errorf("GetPapersList() = %d papers, total %d, %v; want 1, 1", len(list), total, err)
+	}
+
+	keyword, err := d.SearchByKeywords("Graph", models.SearchCondition{})
+	if err != nil || len(keyword) != 1 {
+		t.Errorf("SearchByKeywords() = %d papers, %v; want 1", len(keyword), err)
+	}
+
+	similar, err := d.SearchByEmbedding([]float32{1, 0}, "test-model", models.SearchCondition{}, 10)
+	if err != nil || len(similar) != 1 {
+		t.Errorf("SearchByEmbedding() = %d papers, %v; want 1", len(similar), err)
+	}
+
+	pending, err := d.GetPapersNeedingEmbedding("other-model", 10)
+	if err != nil || len(pending) != 1 {
+		t.Errorf("GetPapersNeedingEmbedding() = %d papers, %v; want 1", len(pending), err)
+	}
+}
+
+func TestPurgePapers(t *testing.T) {
+	d := newTestDB(t)
+	seedPapers(t, d, 3)
+
+	if _, err := d.DeletePapers([]string{"source_id IN (?, ?)"}, []interface{}{"2401.00000", "2401.00001"}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	// 把其中一篇的删除时间改到 10 天前
+	if _, err := d.db.Exec("UPDATE papers SET deleted_at = datetime('now', '-10 days') WHERE source_id = ?", "2401.00000"); err != nil {
+		t.Fatalf("backdate deleted_at: %v", err)
+	}
+
+	purged, err := d.PurgePapers(7 * 24 * time.Hour)
+	if err != nil || purged != 1 {
+		t.Fatalf("PurgePapers(7d) = %d, %v; want 1, nil", purged, err)
+	}
+	if restored, _ := d.UndeletePapers([]string{"source_id = ?"}, []interface{}{"2401.00000"}); restored != 0 {
+		t.Errorf("Purged paper should not be recoverable, restored %d", restored)
+	}
+
+	// olderThan 为 0 时清除全部已删除论文，未删除的保留
+	purged, err = d.PurgePapers(0)
+	if err != nil || purged != 1 {
+		t.Fatalf("PurgePapers(0) = %d, %v; want 1, nil", purged, err)
+	}
+	if n, _ := d.CountPapers(nil, nil); n != 1 {
+		t.Errorf("CountPapers() after purge = %d, want 1", n)
+	}
+}
+
+func TestMigrateAddsDeletedAt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+
+	// 模拟缺少 deleted_at 列的旧版本数据库
+	raw, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := raw.Exec(`CREATE TABLE papers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, source TEXT NOT NULL, source_id TEXT NOT NULL,
+		url TEXT UNIQUE NOT NULL, title TEXT NOT NULL, title_translated TEXT, authors TEXT,
+		abstract TEXT, abstract_translated TEXT, categories TEXT, comments TEXT,
+		first_submitted_at DATETIME, first_announced_at DATETIME, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		embedding_text TEXT, embedding BLOB, embedding_model TEXT, embedding_updated_at DATETIME,
+		UNIQUE(source, source_id));
+		CREATE TABLE paper_status (paper_id INTEGER PRIMARY KEY, status TEXT NOT NULL, updated_at TIMESTAMP)`); err != nil {
+		t.Fatalf("create old schema: %v", err)
+	}
+	raw.Close()
+
+	d, err := NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() on old schema error: %v", err)
+	}
+	defer d.Close()
+
+	cols, err := d.tableColumns("papers")
+	if err != nil || !cols["deleted_at"] || !cols["citations"] || !cols["decision"] || !cols["alt_sources"] || !cols["open_access_url"] || !cols["translation_lang"] || !cols["embedding_scale"] {
+		t.Errorf("Expected migrated columns, got %v (%v)", cols, err)
+	}
+	if cols, err := d.tableColumns("paper_status"); err != nil || !cols["starred"] {
+		t.Errorf("Expected paper_status.starred to be migrated, got %v (%v)", cols, err)
+	}
+	// 再次迁移不会重复添加
+	if err := d.migrate(); err != nil {
+		t.Errorf("second migrate() error: %v", err)
+	}
+}
+
+func TestUpdateDecision_SurvivesRecrawl(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 1)
+
+	if err := d.UpdateDecision(ids[0], "Accept (Oral)"); err != nil {
+		t.Fatalf("UpdateDecision() error: %v", err)
+	}
+	if err := d.UpdateDecision(9999, "Reject"); err == nil {
+		t.Error("Expected error for missing paper")
+	}
+
+	// 重新爬取时平台未提供决定，不应覆盖已保存的值
+	if _, err := d.Upsert(&models.Paper{
+		Source: "arxiv", SourceID: "2401.00000", URL: "https://arxiv.org/abs/2401.00000", Title: "Graph Paper 0",
+	}); err != nil {
+		t.Fatalf("Upsert() error: %v", err)
+	}
+	papers, err := d.GetPapersByConditions([]string{"id = ?"}, []interface{}{ids[0]}, 1)
+	if err != nil || len(papers) != 1 {
+		t.Fatalf("GetPapersByConditions() = %d papers, %v", len(papers), err)
+	}
+	if papers[0].Decision != "Accept (Oral)" {
+		t.Errorf("Decision = %q, want %q", papers[0].Decision, "Accept (Oral)")
+	}
+}
+
+func TestGetEmbeddings(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 3)
+	if err := d.SaveEmbedding(ids[0], "model-a", "text", []float32{1, 2, 3}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	if err := d.SaveEmbedding(ids[1], "model-b", "text", []float32{4, 5, 6}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+
+	vecs, err := d.GetEmbeddings(ids, "model-a")
+	if err != nil {
+		t.Fatalf("GetEmbeddings() error: %v", err)
+	}
+	if len(vecs) != 1 || fmt.Sprint(vecs[ids[0]]) != "[1 2 3]" {
+		t.Errorf("Expected only the model-a embedding, got %v", vecs)
+	}
+	if vecs, err := d.GetEmbeddings(nil, "model-a"); err != nil || len(vecs) != 0 {
+		t.Errorf("Expected empty result for no ids, got %v, %v", vecs, err)
+	}
+}
+
+func TestSaveEmbedding_Quantized(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 2)
+	// 启用量化前保存的 float32 向量仍可读取
+	if err := d.SaveEmbedding(ids[0], "m", "text", []float32{1, 0, 0, 0}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	d.EnableQuantization()
+	vec := []float32{0.5, -0.25, 0.125, 1}
+	if err := d.SaveEmbedding(ids[1], "m", "text", vec); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+
+	var blob []byte
+	var scale sql.NullFloat64
+	if err := d.db.QueryRow(`SELECT embedding, embedding_scale FROM papers WHERE id = ?`, ids[1]).Scan(&blob, &scale); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(blob) != len(vec) || !scale.Valid {
+		t.Errorf("Expected %d-byte blob with scale, got %d bytes, scale %v", len(vec), len(blob), scale)
+	}
+
+	vecs, err := d.GetEmbeddings(ids, "m")
+	if err != nil {
+		t.Fatalf("GetEmbeddings() error: %v", err)
+	}
+	if fmt.Sprint(vecs[ids[0]]) != "[1 0 0 0]" {
+		t.Errorf("Expected float32 embedding unchanged, got %v", vecs[ids[0]])
+	}
+	for i, v := range vecs[ids[1]] {
+		if diff := v - vec[i]; diff > 0.01 || diff < -0.01 {
+			t.Errorf("Dequantized %v, want close to %v", vecs[ids[1]], vec)
+			break
+		}
+	}
+
+	res, err := d.SearchByEmbedding(vec, "m", models.SearchCondition{}, 2)
+	if err != nil {
+		t.Fatalf("SearchByEmbedding() error: %v", err)
+	}
+	if len(res) != 2 || res[0].Paper.ID != ids[1] || res[0].Similarity < 0.999 {
+		t.Errorf("Expected quantized paper ranked first, got %+v", res)
+	}
+}
+
+func TestGetPaperByIDAndSourceID(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 2)
+
+	p, err := d.GetPaperByID(ids[1])
+	if err != nil {
+		t.Fatalf("GetPaperByID() error: %v", err)
+	}
+	if p.ID != ids[1] || p.SourceID != "2401.00001" {
+		t.Errorf("GetPaperByID() = %d/%s, want %d/2401.00001", p.ID, p.SourceID, ids[1])
+	}
+
+	p, err = d.GetPaperBySourceID("arxiv", "2401.00000")
+	if err != nil {
+		t.Fatalf("GetPaperBySourceID() error: %v", err)
+	}
+	if p.ID != ids[0] || p.Title != "Graph Paper 0" {
+		t.Errorf("GetPaperBySourceID() = %d/%q, want %d/\"Graph Paper 0\"", p.ID, p.Title, ids[0])
+	}
+
+	if _, err := d.GetPaperByID(9999); !errors.Is(err, storage.ErrPaperNotFound) {
+		t.Errorf("GetPaperByID(missing) error = %v, want ErrPaperNotFound", err)
+	}
+	if _, err := d.GetPaperBySourceID("openreview", "2401.00000"); !errors.Is(err, storage.ErrPaperNotFound) {
+		t.Errorf("GetPaperBySourceID(other source) error = %v, want ErrPaperNotFound", err)
+	}
+
+	// 软删除后视为不存在
+	if _, err := d.DeletePapers([]string{"id = ?"}, []interface{}{ids[0]}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	if _, err := d.GetPaperByID(ids[0]); !errors.Is(err, storage.ErrPaperNotFound) {
+		t.Errorf("GetPaperByID(deleted) error = %v, want ErrPaperNotFound", err)
+	}
+}
+
+func TestSaveEmbedding_RejectsDimMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "papers.db")
+	d, err := NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	defer d.Close()
+	ids := seedPapers(t, d, 2)
+
+	err = d.SaveEmbedding(ids[0], "test-model", "Graph Paper 0", []float32{1, 0, 0})
+	if !errors.Is(err, storage.ErrEmbeddingDimMismatch) {
+		t.Fatalf("Expected ErrEmbeddingDimMismatch for a 3-dim vector, got %v", err)
+	}
+	got, err := d.GetEmbeddings(ids[:1], "test-model")
+	if err != nil {
+		t.Fatalf("GetEmbeddings() error: %v", err)
+	}
+	if len(got[ids[0]]) != 2 {
+		t.Errorf("Expected stored vector to keep 2 dims, got %v", got[ids[0]])
+	}
+
+	// 维度按模型区分，新模型可以使用不同维度；重新打开数据库后仍按已存向量校验
+	if err := d.SaveEmbedding(ids[1], "other-model", "Graph Paper 1", []float32{1, 0, 0}); err != nil {
+		t.Fatalf("SaveEmbedding() for another model error: %v", err)
+	}
+	reopened, err := NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	defer reopened.Close()
+	if err := reopened.SaveEmbedding(ids[0], "other-model", "Graph Paper 0", []float32{1, 0}); !errors.Is(err, storage.ErrEmbeddingDimMismatch) {
+		t.Errorf("Expected ErrEmbeddingDimMismatch after reopening, got %v", err)
+	}
+	// 量化保存走同一校验
+	if err := reopened.SaveQuantizedEmbedding(ids[0], "other-model", "Graph Paper 0", []float32{1, 0}); !errors.Is(err, storage.ErrEmbeddingDimMismatch) {
+		t.Errorf("Expected ErrEmbeddingDimMismatch for a quantized vector, got %v", err)
+	}
+}
+
+func TestSearchByEmbedding_ScoresInChunks(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, scanChunkSize+10)
+	// 最相似的两篇分别位于第一批与第二批
+	if err := d.SaveEmbedding(ids[3], "test-model", "text", []float32{0, 1}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	if err := d.SaveEmbedding(ids[scanChunkSize+5], "test-model", "text", []float32{1, 1}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+
+	res, err := d.SearchByEmbedding([]float32{0, 1}, "test-model", models.SearchCondition{}, 2)
+	if err != nil {
+		t.Fatalf("SearchByEmbedding() error: %v", err)
+	}
+	if len(res) != 2 || res[0].Paper.ID != ids[3] || res[1].Paper.ID != ids[scanChunkSize+5] {
+		t.Fatalf("Expected papers %d and %d across chunks, got %+v", ids[3], ids[scanChunkSize+5], res)
+	}
+	if res[0].Similarity < 0.99 || res[1].Similarity < 0.7 || res[1].Similarity > 0.71 {
+		t.Errorf("Unexpected similarities %v and %v", res[0].Similarity, res[1].Similarity)
+	}
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"PaperHunter/internal/models"
+)
+
+// FindNearDuplicates 返回与 paperID 向量余弦相似度不低于 threshold 的其他平台论文，按相似度降序，最多 limit 篇；
+// paperID 尚无向量时返回空结果
+func (s *SQLiteDB) FindNearDuplicates(paperID int64, threshold float32, limit int) ([]*models.Paper, error) {
+	var source, model string
+	var blob []byte
+	var scale sql.NullFloat64
+	err := s.db.QueryRow(`SELECT source, COALESCE(embedding_model, ''), embedding, embedding_scale FROM papers WHERE id = ? AND deleted_at IS NULL`, paperID).
+		Scan(&source, &model, &blob, &scale)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("论文不存在: %d", paperID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	where := []string{"deleted_at IS NULL", "embedding IS NOT NULL", "embedding_model = ?", "source != ?"}
+	results, err := s.scanByEmbedding(decodeEmbedding(blob, scale), model, where, []interface{}{model, source}, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var papers []*models.Paper
+	for _, r := range results {
+		if r.Similarity < threshold {
+			break
+		}
+		p := r.Paper
+		papers = append(papers, &p)
+	}
+	return papers, nil
+}
+
+// MergePapers 将 duplicateID 的作者与分类并入 primaryID（去重、保持原顺序），然后软删除 duplicateID
+func (s *SQLiteDB) MergePapers(primaryID, duplicateID int64) error {
+	if primaryID == duplicateID {
+		return fmt.Errorf("不能将论文与自身合并: %d", primaryID)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var primaryAuthors, primaryCategories, dupAuthors, dupCategories string
+	load := func(id int64, authors, categories *string) error {
+		err := tx.QueryRow(`SELECT authors, categories FROM papers WHERE id = ? AND deleted_at IS NULL`, id).Scan(authors, categories)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("论文不存在: %d", id)
+		}
+		return err
+	}
+	if err := load(primaryID, &primaryAuthors, &primaryCategories); err != nil {
+		return err
+	}
+	if err := load(duplicateID, &dupAuthors, &dupCategories); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE papers SET authors = ?, categories = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		mergeCSV(primaryAuthors, dupAuthors), mergeCSV(primaryCategories, dupCategories), primaryID)
+	if err != nil {
+		return fmt.Errorf("更新论文失败: %w", err)
+	}
+	if _, err := tx.Exec(`UPDATE papers SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, duplicateID); err != nil {
+		return fmt.Errorf("删除重复论文失败: %w", err)
+	}
+	return tx.Commit()
+}
+
+// mergeCSV 合并两个逗号分隔列表，按忽略大小写的值去重；与 Paper.AuthorsCSV 一致以 ", " 连接
+func mergeCSV(primary, extra string) string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, list := range []string{primary, extra} {
+		for _, item := range strings.Split(list, ",") {
+			item = strings.TrimSpace(item)
+			key := strings.ToLower(item)
+			if item == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, item)
+		}
+	}
+	return strings.Join(out, ", ")
+}
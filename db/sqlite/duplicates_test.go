@@ -0,0 +1,111 @@
+package db
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+// insertPaper 写入一篇论文（URL 由 source/sourceID 生成）并保存 test-model 向量
+func insertPaper(t *testing.T, d *SQLiteDB, p *models.Paper, vec []float32) int64 {
+	t.Helper()
+	p.URL = "https://example.com/" + p.Source + "/" + p.SourceID
+	id, err := d.Upsert(p)
+	if err != nil {
+		t.Fatalf("Upsert() error: %v", err)
+	}
+	if vec != nil {
+		if err := d.SaveEmbedding(id, "test-model", p.Title, vec); err != nil {
+			t.Fatalf("SaveEmbedding() error: %v", err)
+		}
+	}
+	return id
+}
+
+func trimmed(items []string) string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = strings.TrimSpace(item)
+	}
+	return strings.Join(out, "|")
+}
+
+// unitVec 返回与 (1, 0) 余弦相似度为 cos 的单位向量
+func unitVec(cos float64) []float32 {
+	return []float32{float32(cos), float32(math.Sqrt(1 - cos*cos))}
+}
+
+func TestFindNearDuplicates(t *testing.T) {
+	for _, cached := range []bool{false, true} {
+		d := newTestDB(t)
+		if cached {
+			d.EnableEmbeddingCache(10)
+		}
+
+		arxiv := insertPaper(t, d, &models.Paper{Source: "arxiv", SourceID: "2401.00001", Title: "Graph Transformers"}, []float32{1, 0})
+		semantic := insertPaper(t, d, &models.Paper{Source: "semantic", SourceID: "abc", Title: "Graph Transformers"}, unitVec(0.98))
+		insertPaper(t, d, &models.Paper{Source: "openreview", SourceID: "xyz", Title: "Related Work"}, unitVec(0.9))
+		// 同平台的论文即使向量相同也不算重复
+		insertPaper(t, d, &models.Paper{Source: "arxiv", SourceID: "2401.00002", Title: "Same Source"}, []float32{1, 0})
+		noVec := insertPaper(t, d, &models.Paper{Source: "acl", SourceID: "2024.acl-1", Title: "No Embedding"}, nil)
+
+		dups, err := d.FindNearDuplicates(arxiv, 0.97, 5)
+		if err != nil {
+			t.Fatalf("FindNearDuplicates() error: %v", err)
+		}
+		if len(dups) != 1 || dups[0].ID != semantic {
+			t.Errorf("cached=%v: expected only paper %d as near-duplicate, got %+v", cached, semantic, dups)
+		}
+
+		if dups, err := d.FindNearDuplicates(noVec, 0.97, 5); err != nil || len(dups) != 0 {
+			t.Errorf("Expected no duplicates for paper without embedding, got %v, %v", dups, err)
+		}
+		if _, err := d.FindNearDuplicates(9999, 0.97, 5); err == nil {
+			t.Error("Expected error for missing paper")
+		}
+	}
+}
+
+func TestMergePapers(t *testing.T) {
+	d := newTestDB(t)
+	primary := insertPaper(t, d, &models.Paper{
+		Source: "arxiv", SourceID: "2401.00001", Title: "Graph Transformers",
+		Authors: []string{"Alice", "Bob"}, Categories: []string{"cs.LG"},
+	}, []float32{1, 0})
+	duplicate := insertPaper(t, d, &models.Paper{
+		Source: "semantic", SourceID: "abc", Title: "Graph Transformers",
+		Authors: []string{"bob", "Carol"}, Categories: []string{"cs.LG", "Machine Learning"},
+	}, unitVec(0.98))
+
+	if err := d.MergePapers(primary, duplicate); err != nil {
+		t.Fatalf("MergePapers() error: %v", err)
+	}
+
+	papers, err := d.GetPapersByConditions(nil, nil, 0)
+	if err != nil {
+		t.Fatalf("GetPapersByConditions() error: %v", err)
+	}
+	if len(papers) != 1 || papers[0].ID != primary {
+		t.Fatalf("Expected only the primary paper to remain, got %d papers", len(papers))
+	}
+	if got := trimmed(papers[0].Authors); got != "Alice|Bob|Carol" {
+		t.Errorf("Unexpected merged authors: %q", got)
+	}
+	if got := trimmed(papers[0].Categories); got != "cs.LG|Machine Learning" {
+		t.Errorf("Unexpected merged categories: %q", got)
+	}
+
+	// 重复论文被软删除，可以恢复
+	if n, err := d.UndeletePapers([]string{"id = ?"}, []interface{}{duplicate}); err != nil || n != 1 {
+		t.Errorf("Expected duplicate to be soft-deleted, undelete returned %d, %v", n, err)
+	}
+
+	if err := d.MergePapers(primary, primary); err == nil {
+		t.Error("Expected error when merging a paper with itself")
+	}
+	if err := d.MergePapers(primary, 9999); err == nil {
+		t.Error("Expected error for missing duplicate")
+	}
+}
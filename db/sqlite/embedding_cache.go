@@ -0,0 +1,78 @@
+package db
+
+import (
+	"sort"
+	"strings"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/similarity"
+)
+
+// embeddingLoadChunk 补读未缓存向量时每次查询的论文数，避免超出 SQLite 参数上限
+const embeddingLoadChunk = 500
+
+// searchCached 全表扫描检索：只读取论文元数据，向量优先从缓存获取，未命中的批量读取后写入缓存
+func (s *SQLiteDB) searchCached(queryVec []float32, model string, where []string, args []interface{}, topK int) ([]*models.SimilarPaper, error) {
+	query := `
+	SELECT id, source, source_id, url, title, title_translated, authors,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
+		first_submitted_at, first_announced_at, updated_at
+	FROM papers
+	WHERE ` + strings.Join(where, " AND ")
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	papers, err := s.scanPapers(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	vecs := make(map[int64][]float32, len(papers))
+	var missing []int64
+	for _, p := range papers {
+		if vec, ok := s.embCache.Get(p.ID); ok {
+			vecs[p.ID] = vec
+		} else {
+			missing = append(missing, p.ID)
+		}
+	}
+	for start := 0; start < len(missing); start += embeddingLoadChunk {
+		end := start + embeddingLoadChunk
+		if end > len(missing) {
+			end = len(missing)
+		}
+		loaded, err := s.GetEmbeddings(missing[start:end], model)
+		if err != nil {
+			return nil, err
+		}
+		for id, vec := range loaded {
+			vecs[id] = vec
+			s.embCache.Put(id, vec)
+		}
+	}
+
+	results := make([]*models.SimilarPaper, 0, len(papers))
+	docs := make([][]float32, 0, len(papers))
+	for _, p := range papers {
+		vec, ok := vecs[p.ID]
+		if !ok {
+			continue
+		}
+		docs = append(docs, vec)
+		results = append(results, &models.SimilarPaper{Paper: *p})
+	}
+	for i, sim := range similarity.CosineSimilarityBatch(queryVec, docs) {
+		results[i].Similarity = sim
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Similarity > results[j].Similarity
+	})
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results, nil
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func TestSearchByEmbedding_Cache(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 3)
+	if err := d.SaveEmbedding(ids[1], "test-model", "", []float32{0, 1}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	d.EnableEmbeddingCache(10)
+
+	query := []float32{0, 1}
+	first, err := d.SearchByEmbedding(query, "test-model", models.SearchCondition{}, 3)
+	if err != nil {
+		t.Fatalf("SearchByEmbedding() error: %v", err)
+	}
+	if len(first) != 3 || first[0].Paper.ID != ids[1] || first[0].Paper.Title == "" {
+		t.Fatalf("Expected paper %d ranked first with metadata, got %+v", ids[1], first[0])
+	}
+	if n := d.embCache.Len(); n != 3 {
+		t.Errorf("Expected 3 cached vectors after first search, got %d", n)
+	}
+
+	// 保存新向量后缓存失效，检索结果反映最新向量
+	if err := d.SaveEmbedding(ids[1], "test-model", "", []float32{1, 0}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	if _, ok := d.embCache.Get(ids[1]); ok {
+		t.Error("Expected SaveEmbedding to invalidate the cached vector")
+	}
+	if err := d.SaveEmbedding(ids[2], "test-model", "", []float32{0, 1}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	second, err := d.SearchByEmbedding(query, "test-model", models.SearchCondition{}, 1)
+	if err != nil {
+		t.Fatalf("SearchByEmbedding() error: %v", err)
+	}
+	if len(second) != 1 || second[0].Paper.ID != ids[2] {
+		t.Errorf("Expected paper %d after re-embedding, got %+v", ids[2], second)
+	}
+
+	// 其他模型的向量不参与检索
+	if other, _ := d.SearchByEmbedding(query, "other-model", models.SearchCondition{}, 3); len(other) != 0 {
+		t.Errorf("Expected no results for other model, got %d", len(other))
+	}
+}
+
+// seedBenchPapers 在一个事务内写入 n 篇带 dim 维随机向量的论文
+func seedBenchPapers(b *testing.B, d *SQLiteDB, n, dim int) {
+	b.Helper()
+	tx, err := d.db.Begin()
+	if err != nil {
+		b.Fatal(err)
+	}
+	stmt, err := tx.Prepare(`INSERT INTO papers (source, source_id, url, title, title_translated, authors, abstract,
+		abstract_translated, categories, comments, first_submitted_at, first_announced_at, embedding, embedding_model)
+		VALUES ('arxiv', ?, ?, ?, '', '', 'Abstract.', '', '', '', ?, ?, ?, 'bench-model')`)
+	if err != nil {
+		b.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	vec := make([]float32, dim)
+	now := time.Now()
+	for i := 0; i < n; i++ {
+		for j := range vec {
+			vec[j] = rng.Float32()
+		}
+		id := fmt.Sprintf("bench.%05d", i)
+		if _, err := stmt.Exec(id, "https://arxiv.org/abs/"+id, "Paper "+id, now, now, encodeVec(vec)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	stmt.Close()
+	if err := tx.Commit(); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func benchmarkSearchByEmbedding(b *testing.B, cacheSize int) {
+	const papers, dim = 10000, 256
+	d, err := NewSQLiteDB(filepath.Join(b.TempDir(), "bench.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer d.Close()
+	seedBenchPapers(b, d, papers, dim)
+	d.EnableEmbeddingCache(cacheSize)
+
+	query := make([]float32, dim)
+	for i := range query {
+		query[i] = 0.5
+	}
+	// 预热：启用缓存时第一次检索填充缓存
+	if _, err := d.SearchByEmbedding(query, "bench-model", models.SearchCondition{}, 10); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.SearchByEmbedding(query, "bench-model", models.SearchCondition{}, 10); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearchByEmbedding_NoCache(b *testing.B) { benchmarkSearchByEmbedding(b, 0) }
+
+func BenchmarkSearchByEmbedding_Cache(b *testing.B) { benchmarkSearchByEmbedding(b, 10000) }
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// papersFTSSchema 论文标题/摘要全文索引：unicode61 分词加 porter 词干化，由触发器与 papers 保持同步
+const papersFTSSchema = `
+CREATE VIRTUAL TABLE IF NOT EXISTS papers_fts USING fts5(
+  title, abstract, content='papers', content_rowid='id', tokenize='porter unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS papers_fts_ai AFTER INSERT ON papers BEGIN
+  INSERT INTO papers_fts(rowid, title, abstract) VALUES (new.id, new.title, new.abstract);
+END;
+
+CREATE TRIGGER IF NOT EXISTS papers_fts_ad AFTER DELETE ON papers BEGIN
+  INSERT INTO papers_fts(papers_fts, rowid, title, abstract) VALUES ('delete', old.id, old.title, old.abstract);
+END;
+
+CREATE TRIGGER IF NOT EXISTS papers_fts_au AFTER UPDATE OF title, abstract ON papers BEGIN
+  INSERT INTO papers_fts(papers_fts, rowid, title, abstract) VALUES ('delete', old.id, old.title, old.abstract);
+  INSERT INTO papers_fts(rowid, title, abstract) VALUES (new.id, new.title, new.abstract);
+END;
+`
+
+// initPapersFTS 创建论文全文索引；同步触发器缺失（新建索引，或之前以未启用 FTS5 的构建打开过）时
+// 从现有论文回填。当前构建不支持 FTS5 时删除同步触发器，SearchByFTS 退回 LIKE
+func (d *SQLiteDB) initPapersFTS() error {
+	var synced bool
+	if err := d.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type = 'trigger' AND name = 'papers_fts_ai')`).Scan(&synced); err != nil {
+		return err
+	}
+
+	if _, err := d.db.Exec(papersFTSSchema); err != nil {
+		if !strings.Contains(err.Error(), "no such module: fts5") {
+			return fmt.Errorf("创建论文全文索引失败: %w", err)
+		}
+		logger.Debug("SQLite 未启用 FTS5，全文搜索使用 LIKE")
+		for _, trigger := range []string{"papers_fts_ai", "papers_fts_ad", "papers_fts_au"} {
+			if _, err := d.db.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+				return fmt.Errorf("删除触发器 %s 失败: %w", trigger, err)
+			}
+		}
+		return nil
+	}
+
+	if !synced {
+		logger.Debug("回填论文全文索引")
+		if _, err := d.db.Exec(`INSERT INTO papers_fts(papers_fts) VALUES ('rebuild')`); err != nil {
+			return fmt.Errorf("回填论文全文索引失败: %w", err)
+		}
+	}
+	d.papersFTS = true
+	return nil
+}
+
+// ftsMatchQuery 将用户输入拆成词并逐个加引号，词之间为 AND，避免 FTS5 把查询中的运算符当作语法；
+// 没有可检索的词时返回空字符串
+func ftsMatchQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = `"` + w + `"`
+	}
+	return strings.Join(words, " ")
+}
+
+// SearchByFTS 在标题和摘要中全文检索，按 bm25 相关度排序（标题权重更高）；
+// 当前构建未启用 FTS5 或查询中没有可检索的词时退回 SearchByKeywords 的 LIKE 匹配
+func (s *SQLiteDB) SearchByFTS(query string, cond models.SearchCondition) ([]*models.Paper, error) {
+	match := ftsMatchQuery(query)
+	if !s.papersFTS || match == "" {
+		return s.SearchByKeywords(query, cond)
+	}
+
+	where := []string{"deleted_at IS NULL"}
+	args := []interface{}{match}
+	condWhere, condArgs := searchConditionWhere(cond)
+	where = append(where, condWhere...)
+	args = append(args, condArgs...)
+
+	sqlQuery := `
+	SELECT id, source, source_id, url, title, title_translated, authors,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
+		first_submitted_at, first_announced_at, updated_at
+	FROM papers
+	JOIN (SELECT rowid AS fts_id, bm25(papers_fts, 2.0, 1.0) AS fts_rank FROM papers_fts WHERE papers_fts MATCH ?) f
+		ON f.fts_id = papers.id
+	WHERE ` + strings.Join(where, " AND ") + `
+	ORDER BY f.fts_rank, id`
+
+	if cond.Limit > 0 {
+		sqlQuery += " LIMIT ?"
+		args = append(args, cond.Limit)
+	}
+
+	rows, err := s.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanPapers(rows)
+}
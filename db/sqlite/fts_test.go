@@ -0,0 +1,181 @@
+package db
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+// ftsCorpus 小语料：词序颠倒、单复数与大小写不同的论文 LIKE 子串匹配找不到
+var ftsCorpus = []struct{ title, abstract string }{
+	{"Graph Neural Networks for Molecules", "We study message passing on molecular graphs."},
+	{"Networks that are neural", "A survey of learned graph representations."},
+	{"Reinforcement Learning from Human Feedback", "Aligning language models with preferences."},
+	{"Offline learning for reinforcement agents", "Policies trained without interaction."},
+	{"Vision Transformer", "An image is worth 16x16 words."},
+	{"Efficient transformers: a survey", "Attention variants with linear cost."},
+	{"Protein folding", "Structure prediction with deep learning."},
+}
+
+func seedFTSCorpus(t *testing.T, d *SQLiteDB) []int64 {
+	t.Helper()
+	ids := make([]int64, len(ftsCorpus))
+	for i, c := range ftsCorpus {
+		id, err := d.Upsert(&models.Paper{
+			Source:   "arxiv",
+			SourceID: fmt.Sprintf("2402.%05d", i),
+			URL:      fmt.Sprintf("https://arxiv.org/abs/2402.%05d", i),
+			Title:    c.title,
+			Abstract: c.abstract,
+		})
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func recallOf(got []*models.Paper, relevant []int64) float64 {
+	found := make(map[int64]bool, len(got))
+	for _, p := range got {
+		found[p.ID] = true
+	}
+	hits := 0
+	for _, id := range relevant {
+		if found[id] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(relevant))
+}
+
+func TestSearchByFTS_RecallVsLike(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedFTSCorpus(t, d)
+	if !d.papersFTS {
+		t.Skip("SQLite built without FTS5 (run with -tags sqlite_fts5)")
+	}
+
+	queries := []struct {
+		query    string
+		relevant []int64
+	}{
+		{"neural networks", []int64{ids[0], ids[1]}},
+		{"reinforcement learning", []int64{ids[2], ids[3]}},
+		{"transformers", []int64{ids[4], ids[5]}},
+	}
+	var likeTotal, ftsTotal float64
+	for _, q := range queries {
+		like, err := d.SearchByKeywords(q.query, models.SearchCondition{})
+		if err != nil {
+			t.Fatalf("SearchByKeywords(%q) error: %v", q.query, err)
+		}
+		fts, err := d.SearchByFTS(q.query, models.SearchCondition{})
+		if err != nil {
+			t.Fatalf("SearchByFTS(%q) error: %v", q.query, err)
+		}
+		likeRecall, ftsRecall := recallOf(like, q.relevant), recallOf(fts, q.relevant)
+		if ftsRecall < likeRecall {
+			t.Errorf("%q: FTS recall %.2f < LIKE recall %.2f", q.query, ftsRecall, likeRecall)
+		}
+		likeTotal += likeRecall
+		ftsTotal += ftsRecall
+	}
+	if ftsTotal != float64(len(queries)) {
+		t.Errorf("Expected FTS to find every relevant paper, mean recall = %.2f", ftsTotal/float64(len(queries)))
+	}
+	if likeTotal >= ftsTotal {
+		t.Errorf("Expected FTS to beat LIKE on this corpus, got LIKE %.2f vs FTS %.2f", likeTotal, ftsTotal)
+	}
+
+	// 标题命中的排在只有摘要命中的前面
+	papers, err := d.SearchByFTS("graph", models.SearchCondition{})
+	if err != nil {
+		t.Fatalf("SearchByFTS() error: %v", err)
+	}
+	if len(papers) != 2 || papers[0].ID != ids[0] {
+		t.Errorf("Expected title match %d ranked first, got %v", ids[0], papers)
+	}
+
+	// 查询中的 FTS5 语法字符按普通文本处理
+	if _, err := d.SearchByFTS(`"vision" OR NEAR(`, models.SearchCondition{}); err != nil {
+		t.Errorf("SearchByFTS() with operators error: %v", err)
+	}
+}
+
+func TestSearchByFTS_StaysInSync(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedFTSCorpus(t, d)
+	if !d.papersFTS {
+		t.Skip("SQLite built without FTS5 (run with -tags sqlite_fts5)")
+	}
+
+	// 重新爬取时更新标题
+	if _, err := d.Upsert(&models.Paper{Source: "arxiv", SourceID: "2402.00006", URL: "https://arxiv.org/abs/2402.00006", Title: "AlphaFold protein structures"}); err != nil {
+		t.Fatalf("Upsert() error: %v", err)
+	}
+	if got, _ := d.SearchByFTS("alphafold", models.SearchCondition{}); len(got) != 1 || got[0].ID != ids[6] {
+		t.Errorf("Expected updated title to be searchable, got %v", got)
+	}
+
+	if _, err := d.DeletePapers([]string{"id = ?"}, []interface{}{ids[4]}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	if got, _ := d.SearchByFTS("vision", models.SearchCondition{}); len(got) != 0 {
+		t.Errorf("Expected soft-deleted paper to be excluded, got %v", got)
+	}
+}
+
+func TestInitPapersFTS_Backfill(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "papers.db")
+	d, err := NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	if !d.papersFTS {
+		d.Close()
+		t.Skip("SQLite built without FTS5 (run with -tags sqlite_fts5)")
+	}
+	// 模拟旧版本数据库：论文写入时还没有全文索引和同步触发器
+	for _, stmt := range []string{"DROP TRIGGER papers_fts_ai", "DROP TRIGGER papers_fts_ad", "DROP TRIGGER papers_fts_au", "DROP TABLE papers_fts"} {
+		if _, err := d.db.Exec(stmt); err != nil {
+			t.Fatalf("%s error: %v", stmt, err)
+		}
+	}
+	seedFTSCorpus(t, d)
+	d.Close()
+
+	d, err = NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() reopen error: %v", err)
+	}
+	defer d.Close()
+	if got, err := d.SearchByFTS("protein", models.SearchCondition{}); err != nil || len(got) != 1 {
+		t.Errorf("Expected backfilled index to find 1 paper, got %v, %v", got, err)
+	}
+}
+
+func TestSearchByFTS_FallbackWithoutTerms(t *testing.T) {
+	d := newTestDB(t)
+	seedFTSCorpus(t, d)
+
+	// 没有可检索的词（或未启用 FTS5）时与 LIKE 结果一致
+	for _, q := range []string{"16x16", "!!"} {
+		like, err := d.SearchByKeywords(q, models.SearchCondition{})
+		if err != nil {
+			t.Fatalf("SearchByKeywords() error: %v", err)
+		}
+		if !d.papersFTS || ftsMatchQuery(q) == "" {
+			fts, err := d.SearchByFTS(q, models.SearchCondition{})
+			if err != nil {
+				t.Fatalf("SearchByFTS() error: %v", err)
+			}
+			if len(fts) != len(like) {
+				t.Errorf("%q: fallback returned %d papers, LIKE %d", q, len(fts), len(like))
+			}
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	storage "PaperHunter/db"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// EnableCrossSourceMerge 启用跨平台合并：Upsert 遇到其他平台已收录的同一篇论文（DOI 或标题+第一作者相同）时，
+// 并入已有记录并把来源记到 alt_sources，而不是新增一行
+func (s *SQLiteDB) EnableCrossSourceMerge() {
+	s.mergeCrossSource = true
+}
+
+// initFingerprints 创建去重索引，并为旧版本数据库中尚未计算指纹的论文补算
+func (d *SQLiteDB) initFingerprints() error {
+	if _, err := d.db.Exec(`
+	CREATE INDEX IF NOT EXISTS idx_papers_fingerprint ON papers(fingerprint);
+	CREATE INDEX IF NOT EXISTS idx_papers_doi ON papers(doi);
+	`); err != nil {
+		return fmt.Errorf("创建去重索引失败: %w", err)
+	}
+
+	rows, err := d.db.Query(`SELECT id, title, authors, comments, url FROM papers WHERE fingerprint = '' AND title != ''`)
+	if err != nil {
+		return err
+	}
+	type fingerprintRow struct {
+		id               int64
+		fingerprint, doi string
+	}
+	var pending []fingerprintRow
+	for rows.Next() {
+		var p models.Paper
+		var authors, comments sql.NullString
+		if err := rows.Scan(&p.ID, &p.Title, &authors, &comments, &p.URL); err != nil {
+			rows.Close()
+			return err
+		}
+		if authors.String != "" {
+			p.Authors = strings.Split(authors.String, ",")
+		}
+		p.Comments = comments.String
+		pending = append(pending, fingerprintRow{p.ID, paperFingerprint(&p), p.DOI()})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(`UPDATE papers SET fingerprint = ?, doi = ? WHERE id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, r := range pending {
+		if _, err := stmt.Exec(r.fingerprint, r.doi, r.id); err != nil {
+			return fmt.Errorf("补算论文指纹失败: %w", err)
+		}
+	}
+	logger.Debug("已为 %d 篇论文补算去重指纹", len(pending))
+	return tx.Commit()
+}
+
+// mergeCrossSourceDuplicate 将 p 并入其他平台已收录的同一篇论文，返回被并入的论文 ID；
+// p 的 (source, source_id) 已有独立记录或没有匹配的论文时 ok 为 false，由调用方按常规插入
+func (s *SQLiteDB) mergeCrossSourceDuplicate(p *models.Paper, fingerprint, doi string) (int64, bool, error) {
+	var id int64
+	err := s.db.QueryRow(`SELECT id FROM papers WHERE source = ? AND source_id = ?`, p.Source, p.SourceID).Scan(&id)
+	if err == nil {
+		return 0, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, false, err
+	}
+
+	// 之前已合并过的来源直接找到原记录，其次按 DOI 或指纹匹配其他平台的论文
+	ref := altSourceRef(p)
+	err = s.db.QueryRow(`
+	SELECT id FROM papers
+	WHERE deleted_at IS NULL AND (
+		instr(', ' || alt_sources || ',', ', ' || ? || ',') > 0
+		OR (source != ? AND ((? != '' AND doi = ?) OR (? != '' AND fingerprint = ?)))
+	)
+	ORDER BY id LIMIT 1`, ref, p.Source, doi, doi, fingerprint, fingerprint).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	existing, err := s.GetPaperByID(id)
+	if errors.Is(err, storage.ErrPaperNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	m := mergeRecords(existing, p)
+
+	_, err = s.db.Exec(`
+	UPDATE papers SET
+		title = ?, title_translated = ?, authors = ?, abstract = ?, abstract_translated = ?,
+		categories = ?, comments = ?, citations = ?, decision = ?, alt_sources = ?, open_access_url = ?,
+		doi = CASE WHEN doi = '' THEN ? ELSE doi END,
+		first_submitted_at = ?, first_announced_at = ?, updated_at = CURRENT_TIMESTAMP
+	WHERE id = ?`,
+		m.Title, m.TitleTranslated, m.AuthorsCSV(), m.Abstract, m.AbstractTranslated,
+		m.CategoriesCSV(), m.Comments, m.Citations, m.Decision, strings.Join(m.AltSources, ", "), m.OpenAccessURL,
+		doi, m.FirstSubmittedAt, m.FirstAnnouncedAt, id)
+	if err != nil {
+		return 0, false, fmt.Errorf("合并论文失败: %w", err)
+	}
+	logger.Debug("跨平台合并: %s 并入 %s/%s", ref, existing.Source, existing.SourceID)
+	return id, true, nil
+}
+
+// mergeRecords 合并同一篇论文的两条记录：以元数据更完整的一条为准，空字段由另一条补齐，
+// 分类取并集、引用数取较大值、日期取较早值；来源、URL 保持 existing 的值，incoming 的来源记入 AltSources
+func mergeRecords(existing, incoming *models.Paper) *models.Paper {
+	base, other := existing, incoming
+	if richness(incoming) > richness(existing) {
+		base, other = incoming, existing
+	}
+
+	m := *base
+	m.ID, m.Source, m.SourceID, m.URL = existing.ID, existing.Source, existing.SourceID, existing.URL
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&m.Title, other.Title},
+		{&m.TitleTranslated, other.TitleTranslated},
+		{&m.Abstract, other.Abstract},
+		{&m.AbstractTranslated, other.AbstractTranslated},
+		{&m.Comments, other.Comments},
+		{&m.Decision, other.Decision},
+		{&m.OpenAccessURL, other.OpenAccessURL},
+	} {
+		if strings.TrimSpace(*f.dst) == "" {
+			*f.dst = f.src
+		}
+	}
+
+	m.Authors = unionList(base.Authors, nil)
+	if len(m.Authors) == 0 {
+		m.Authors = unionList(other.Authors, nil)
+	}
+	m.Categories = unionList(existing.Categories, incoming.Categories)
+	m.Citations = max(existing.Citations, incoming.Citations)
+	if d := other.FirstSubmittedAt; !d.IsZero() && (m.FirstSubmittedAt.IsZero() || d.Before(m.FirstSubmittedAt)) {
+		m.FirstSubmittedAt = d
+	}
+	if d := other.FirstAnnouncedAt; !d.IsZero() && (m.FirstAnnouncedAt.IsZero() || d.Before(m.FirstAnnouncedAt)) {
+		m.FirstAnnouncedAt = d
+	}
+	m.AltSources = unionList(existing.AltSources, []string{altSourceRef(incoming)})
+	return &m
+}
+
+// richness 元数据完整度，合并时以得分高的记录为准
+func richness(p *models.Paper) int {
+	score := 0
+	for _, s := range []string{p.Abstract, p.Comments, p.TitleTranslated, p.AbstractTranslated, p.Decision} {
+		if strings.TrimSpace(s) != "" {
+			score++
+		}
+	}
+	if len(p.Authors) > 0 {
+		score++
+	}
+	if len(p.Categories) > 0 {
+		score++
+	}
+	if p.Citations > 0 {
+		score++
+	}
+	if !p.FirstSubmittedAt.IsZero() {
+		score++
+	}
+	return score
+}
+
+// unionList 合并两个列表，去掉空白项并按忽略大小写的值去重，保持原顺序
+func unionList(a, b []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, list := range [][]string{a, b} {
+		for _, item := range list {
+			item = strings.TrimSpace(item)
+			key := strings.ToLower(item)
+			if item == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func altSourceRef(p *models.Paper) string {
+	return p.Source + ":" + p.SourceID
+}
+
+func splitAltSources(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return unionList(strings.Split(s, ","), nil)
+}
+
+// paperFingerprint 归一化标题（小写、只保留字母数字）+ 第一作者姓氏，标题为空时返回空字符串
+func paperFingerprint(p *models.Paper) string {
+	title := normalizeWords(p.Title)
+	if title == "" {
+		return ""
+	}
+	var surname string
+	if len(p.Authors) > 0 {
+		name := strings.TrimSpace(p.Authors[0])
+		// "Last, First" 形式取逗号前的姓
+		if last, _, ok := strings.Cut(name, ","); ok {
+			name = last
+		}
+		if words := strings.Fields(normalizeWords(name)); len(words) > 0 {
+			surname = words[len(words)-1]
+		}
+	}
+	return title + "|" + surname
+}
+
+// normalizeWords 小写并把非字母数字字符替换为空格，合并空白
+func normalizeWords(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func arxivACLPair() (*models.Paper, *models.Paper) {
+	arxiv := &models.Paper{
+		Source:           "arxiv",
+		SourceID:         "2401.00001",
+		URL:              "https://arxiv.org/abs/2401.00001",
+		Title:            "Graph Transformers: A Survey",
+		Authors:          []string{"Alice Smith", "Bob Lee"},
+		Abstract:         "We survey graph transformers.",
+		Categories:       []string{"cs.LG", "cs.CL"},
+		FirstSubmittedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	acl := &models.Paper{
+		Source:           "acl",
+		SourceID:         "2024.acl-long.1",
+		URL:              "https://aclanthology.org/2024.acl-long.1",
+		Title:            "Graph Transformers — a survey",
+		Authors:          []string{"Smith, Alice", "Lee, Bob"},
+		Categories:       []string{"cs.CL", "ACL 2024"},
+		Citations:        12,
+		FirstSubmittedAt: time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+	return arxiv, acl
+}
+
+func countPapers(t *testing.T, d *SQLiteDB) int {
+	t.Helper()
+	var n int
+	if err := d.db.QueryRow(`SELECT COUNT(*) FROM papers`).Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	return n
+}
+
+func TestUpsert_MergesCrossSourceDuplicate(t *testing.T) {
+	d := newTestDB(t)
+	d.EnableCrossSourceMerge()
+
+	arxiv, acl := arxivACLPair()
+	arxivID, err := d.Upsert(arxiv)
+	if err != nil {
+		t.Fatalf("Upsert(arxiv) error: %v", err)
+	}
+	aclID, err := d.Upsert(acl)
+	if err != nil {
+		t.Fatalf("Upsert(acl) error: %v", err)
+	}
+	if aclID != arxivID {
+		t.Errorf("Expected ACL paper merged into %d, got %d", arxivID, aclID)
+	}
+	if n := countPapers(t, d); n != 1 {
+		t.Fatalf("Expected 1 paper after merge, got %d", n)
+	}
+
+	papers, err := d.GetPapersByConditions([]string{"id = ?"}, []interface{}{arxivID}, 1)
+	if err != nil || len(papers) != 1 {
+		t.Fatalf("GetPapersByConditions() = %v, %v", papers, err)
+	}
+	p := papers[0]
+	if p.Source != "arxiv" || p.SourceID != "2401.00001" {
+		t.Errorf("Expected source kept as arxiv/2401.00001, got %s/%s", p.Source, p.SourceID)
+	}
+	if got := trimmed(p.Categories); got != "cs.LG|cs.CL|ACL 2024" {
+		t.Errorf("Expected union of categories, got %q", got)
+	}
+	if !reflect.DeepEqual(p.AltSources, []string{"acl:2024.acl-long.1"}) {
+		t.Errorf("Expected AltSources [acl:2024.acl-long.1], got %v", p.AltSources)
+	}
+	if p.Citations != 12 || p.Abstract != arxiv.Abstract {
+		t.Errorf("Expected citations 12 and arXiv abstract, got %d / %q", p.Citations, p.Abstract)
+	}
+	if !p.FirstSubmittedAt.Equal(arxiv.FirstSubmittedAt) {
+		t.Errorf("Expected earliest submission date, got %v", p.FirstSubmittedAt)
+	}
+
+	// 再次爬取 ACL 仍并入同一条记录，来源不会重复记录
+	if id, err := d.Upsert(acl); err != nil || id != arxivID {
+		t.Fatalf("re-Upsert(acl) = %d, %v", id, err)
+	}
+	papers, _ = d.GetPapersByConditions([]string{"id = ?"}, []interface{}{arxivID}, 1)
+	if n := countPapers(t, d); n != 1 || len(papers[0].AltSources) != 1 {
+		t.Errorf("Expected recrawl to be idempotent, got %d papers, alt sources %v", n, papers[0].AltSources)
+	}
+}
+
+func TestUpsert_CrossSourceMergeDisabled(t *testing.T) {
+	d := newTestDB(t)
+
+	arxiv, acl := arxivACLPair()
+	if _, err := d.Upsert(arxiv); err != nil {
+		t.Fatalf("Upsert(arxiv) error: %v", err)
+	}
+	if _, err := d.Upsert(acl); err != nil {
+		t.Fatalf("Upsert(acl) error: %v", err)
+	}
+	if n := countPapers(t, d); n != 2 {
+		t.Errorf("Expected 2 papers without merge, got %d", n)
+	}
+}
+
+func TestUpsert_MergesByDOI(t *testing.T) {
+	d := newTestDB(t)
+	d.EnableCrossSourceMerge()
+
+	first := &models.Paper{Source: "dblp", SourceID: "conf/x/1", URL: "https://doi.org/10.1000/XYZ.1",
+		Title: "Original Title", Authors: []string{"Carol Wu"}}
+	second := &models.Paper{Source: "acl", SourceID: "2024.x-1", URL: "https://aclanthology.org/2024.x-1",
+		Title: "Renamed in Proceedings", Authors: []string{"Carol Wu"}, Comments: "DOI: 10.1000/xyz.1."}
+	id1, _ := d.Upsert(first)
+	id2, err := d.Upsert(second)
+	if err != nil {
+		t.Fatalf("Upsert() error: %v", err)
+	}
+	if id1 != id2 || countPapers(t, d) != 1 {
+		t.Errorf("Expected DOI match to merge into %d, got %d", id1, id2)
+	}
+
+	// 同一平台的不同论文即使标题相同也不合并
+	same := &models.Paper{Source: "dblp", SourceID: "conf/x/2", URL: "https://example.com/2",
+		Title: "Original Title", Authors: []string{"Carol Wu"}}
+	if id3, _ := d.Upsert(same); id3 == id1 {
+		t.Error("Expected papers from the same source to stay separate")
+	}
+}
+
+func TestInitFingerprints_Backfill(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 1)
+	if _, err := d.db.Exec(`UPDATE papers SET fingerprint = '' WHERE id = ?`, ids[0]); err != nil {
+		t.Fatalf("reset fingerprint: %v", err)
+	}
+	if err := d.initFingerprints(); err != nil {
+		t.Fatalf("initFingerprints() error: %v", err)
+	}
+	var fp string
+	d.db.QueryRow(`SELECT fingerprint FROM papers WHERE id = ?`, ids[0]).Scan(&fp)
+	if fp != "graph paper 0|" {
+		t.Errorf("Expected backfilled fingerprint %q, got %q", "graph paper 0|", fp)
+	}
+}
+
+func TestPaperFingerprint(t *testing.T) {
+	a := paperFingerprint(&models.Paper{Title: "BERT: Pre-training of Deep  Transformers", Authors: []string{"Jacob Devlin"}})
+	b := paperFingerprint(&models.Paper{Title: "bert — pre-training of deep transformers.", Authors: []string{"Devlin, Jacob"}})
+	if a != b || a != "bert pre training of deep transformers|devlin" {
+		t.Errorf("Expected equal fingerprints, got %q and %q", a, b)
+	}
+	if got := paperFingerprint(&models.Paper{}); got != "" {
+		t.Errorf("Expected empty fingerprint for missing title, got %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// notesFTSSchema 笔记全文索引：trigram 分词支持词内子串匹配，由触发器与 paper_notes 保持同步
+const notesFTSSchema = `
+CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts5(
+  note, content='paper_notes', content_rowid='paper_id', tokenize='trigram'
+);
+
+CREATE TRIGGER IF NOT EXISTS paper_notes_ai AFTER INSERT ON paper_notes BEGIN
+  INSERT INTO notes_fts(rowid, note) VALUES (new.paper_id, new.note);
+END;
+
+CREATE TRIGGER IF NOT EXISTS paper_notes_ad AFTER DELETE ON paper_notes BEGIN
+  INSERT INTO notes_fts(notes_fts, rowid, note) VALUES ('delete', old.paper_id, old.note);
+END;
+
+CREATE TRIGGER IF NOT EXISTS paper_notes_au AFTER UPDATE ON paper_notes BEGIN
+  INSERT INTO notes_fts(notes_fts, rowid, note) VALUES ('delete', old.paper_id, old.note);
+  INSERT INTO notes_fts(rowid, note) VALUES (new.paper_id, new.note);
+END;
+`
+
+// minFTSQueryLen trigram 分词下少于 3 个字符的查询无法命中索引
+const minFTSQueryLen = 3
+
+// initNotesFTS 创建笔记全文索引并重建（可能有未启用 FTS5 时写入的笔记）；
+// 当前构建不支持 FTS5 时删除同步触发器，避免写入笔记时引用不存在的模块
+func (d *SQLiteDB) initNotesFTS() error {
+	if _, err := d.db.Exec(notesFTSSchema); err != nil {
+		if !strings.Contains(err.Error(), "no such module: fts5") {
+			return fmt.Errorf("创建笔记全文索引失败: %w", err)
+		}
+		logger.Debug("SQLite 未启用 FTS5，笔记搜索使用 LIKE")
+		for _, trigger := range []string{"paper_notes_ai", "paper_notes_ad", "paper_notes_au"} {
+			if _, err := d.db.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+				return fmt.Errorf("删除触发器 %s 失败: %w", trigger, err)
+			}
+		}
+		return nil
+	}
+
+	if _, err := d.db.Exec(`INSERT INTO notes_fts(notes_fts) VALUES ('rebuild')`); err != nil {
+		return fmt.Errorf("重建笔记全文索引失败: %w", err)
+	}
+	d.notesFTS = true
+	return nil
+}
+
+// SetNote 设置论文笔记（覆盖原有笔记），论文不存在时返回错误
+func (s *SQLiteDB) SetNote(paperID int64, note string) error {
+	result, err := s.db.Exec(`
+	INSERT INTO paper_notes (paper_id, note, updated_at)
+	SELECT id, ?, CURRENT_TIMESTAMP FROM papers WHERE id = ?
+	ON CONFLICT(paper_id) DO UPDATE SET
+		note = excluded.note,
+		updated_at = CURRENT_TIMESTAMP
+	`, note, paperID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("论文不存在: id=%d", paperID)
+	}
+	return nil
+}
+
+// GetNote 返回论文笔记，没有笔记时为空字符串
+func (s *SQLiteDB) GetNote(paperID int64) (string, error) {
+	var note string
+	err := s.db.QueryRow(`SELECT note FROM paper_notes WHERE paper_id = ?`, paperID).Scan(&note)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return note, err
+}
+
+// DeleteNote 删除论文笔记，没有笔记时不报错
+func (s *SQLiteDB) DeleteNote(paperID int64) error {
+	_, err := s.db.Exec(`DELETE FROM paper_notes WHERE paper_id = ?`, paperID)
+	return err
+}
+
+// SearchNotes 返回笔记包含 query 子串的未删除论文，按笔记更新时间倒序；limit <= 0 表示不限
+func (s *SQLiteDB) SearchNotes(query string, limit int) ([]*models.NotedPaper, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("搜索内容不能为空")
+	}
+
+	var match string
+	var args []interface{}
+	if s.notesFTS && len([]rune(query)) >= minFTSQueryLen {
+		// 整体作为短语匹配，避免 FTS5 把查询中的运算符当作语法
+		match = "n.paper_id IN (SELECT rowid FROM notes_fts WHERE notes_fts MATCH ?)"
+		args = append(args, `"`+strings.ReplaceAll(query, `"`, `""`)+`"`)
+	} else {
+		match = "n.note LIKE '%' || ? || '%'"
+		args = append(args, query)
+	}
+
+	sqlQuery := `
+	SELECT n.paper_id, n.note, n.updated_at
+	FROM paper_notes n JOIN papers p ON p.id = n.paper_id
+	WHERE p.deleted_at IS NULL AND ` + match + `
+	ORDER BY n.updated_at DESC, n.paper_id`
+	if limit > 0 {
+		sqlQuery += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	var results []*models.NotedPaper
+	var ids []interface{}
+	for rows.Next() {
+		var id int64
+		var np models.NotedPaper
+		var updated sql.NullTime
+		if err := rows.Scan(&id, &np.Note, &updated); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if updated.Valid {
+			np.NoteUpdatedAt = updated.Time
+		}
+		np.Paper.ID = id
+		results = append(results, &np)
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	papers, err := s.GetPapersByConditions([]string{"id IN (" + placeholders + ")"}, ids, 0)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*models.Paper, len(papers))
+	for _, p := range papers {
+		byID[p.ID] = p
+	}
+	for _, r := range results {
+		if p, ok := byID[r.Paper.ID]; ok {
+			r.Paper = *p
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNotes_PersistAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "papers.db")
+	d, err := NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	ids := seedPapers(t, d, 2)
+	if err := d.SetNote(ids[0], "first draft"); err != nil {
+		t.Fatalf("SetNote() error: %v", err)
+	}
+	if err := d.SetNote(ids[0], "revisit the ablation study"); err != nil {
+		t.Fatalf("SetNote() overwrite error: %v", err)
+	}
+	if err := d.SetNote(9999, "orphan"); err == nil {
+		t.Error("Expected error for missing paper")
+	}
+	d.Close()
+
+	d, err = NewSQLiteDB(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() reopen error: %v", err)
+	}
+	defer d.Close()
+
+	if got, err := d.GetNote(ids[0]); err != nil || got != "revisit the ablation study" {
+		t.Errorf("GetNote() = %q, %v; want persisted note", got, err)
+	}
+	if got, err := d.GetNote(ids[1]); err != nil || got != "" {
+		t.Errorf("GetNote() without note = %q, %v; want empty", got, err)
+	}
+
+	// 重新打开后写入的笔记也应能被搜索到
+	if err := d.SetNote(ids[1], "compare with GraphSAGE"); err != nil {
+		t.Fatalf("SetNote() error: %v", err)
+	}
+	results, err := d.SearchNotes("ablation", 0)
+	if err != nil || len(results) != 1 || results[0].Paper.ID != ids[0] {
+		t.Fatalf("SearchNotes() = %v, %v; want paper %d", results, err, ids[0])
+	}
+	if results[0].Paper.Title != "Graph Paper 0" || results[0].NoteUpdatedAt.IsZero() {
+		t.Errorf("Expected full paper metadata and note time, got %+v", results[0])
+	}
+
+	if err := d.DeleteNote(ids[0]); err != nil {
+		t.Fatalf("DeleteNote() error: %v", err)
+	}
+	if results, _ := d.SearchNotes("ablation", 0); len(results) != 0 {
+		t.Errorf("Expected deleted note to leave the index, got %d results", len(results))
+	}
+}
+
+func TestSearchNotes_PartialWords(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 3)
+	notes := []string{
+		"Transformers need careful warmup",
+		"contrastive pretraining baseline",
+		"unrelated reminder",
+	}
+	for i, note := range notes {
+		if err := d.SetNote(ids[i], note); err != nil {
+			t.Fatalf("SetNote() error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []int64
+	}{
+		{"transform", []int64{ids[0]}},
+		{"train", []int64{ids[1]}},
+		{"re", []int64{ids[0], ids[1], ids[2]}},
+		{"warmup\" OR \"x", nil},
+		{"missing", nil},
+	}
+	for _, tt := range tests {
+		results, err := d.SearchNotes(tt.query, 0)
+		if err != nil {
+			t.Fatalf("SearchNotes(%q) error: %v", tt.query, err)
+		}
+		got := map[int64]bool{}
+		for _, r := range results {
+			got[r.Paper.ID] = true
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("SearchNotes(%q) returned %d papers, want %d", tt.query, len(got), len(tt.want))
+			continue
+		}
+		for _, id := range tt.want {
+			if !got[id] {
+				t.Errorf("SearchNotes(%q) missing paper %d", tt.query, id)
+			}
+		}
+	}
+
+	if _, err := d.SearchNotes("  ", 0); err == nil {
+		t.Error("Expected error for empty query")
+	}
+}
+
+func TestNotes_CascadeOnPurge(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 2)
+	for _, id := range ids {
+		if err := d.SetNote(id, "keep an eye on this"); err != nil {
+			t.Fatalf("SetNote() error: %v", err)
+		}
+	}
+
+	if _, err := d.DeletePapers([]string{"id = ?"}, []interface{}{ids[0]}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	// 软删除的论文保留笔记，但不出现在搜索结果中
+	if got, _ := d.GetNote(ids[0]); got == "" {
+		t.Error("Expected note to survive soft delete")
+	}
+	if results, _ := d.SearchNotes("eye", 0); len(results) != 1 || results[0].Paper.ID != ids[1] {
+		t.Errorf("Expected only active paper in results, got %d", len(results))
+	}
+
+	if _, err := d.PurgePapers(0); err != nil {
+		t.Fatalf("PurgePapers() error: %v", err)
+	}
+	var count int
+	if err := d.db.QueryRow(`SELECT COUNT(*) FROM paper_notes`).Scan(&count); err != nil {
+		t.Fatalf("count notes error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected purged paper's note to be removed, %d notes left", count)
+	}
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"fmt"
+
+	"PaperHunter/internal/models"
+)
+
+// AddToQueue 将论文加到阅读队列末尾，已在队列中时保持原位置；论文不存在或已删除时返回错误
+func (s *SQLiteDB) AddToQueue(paperID int64) error {
+	// 单条语句内计算末尾位置，SQLite 串行化写入，并发添加不会得到相同的 position
+	result, err := s.db.Exec(`
+	INSERT INTO reading_queue (paper_id, priority, added_at, position)
+	SELECT id, 0, CURRENT_TIMESTAMP, COALESCE((SELECT MAX(position) FROM reading_queue), 0) + 1
+	FROM papers WHERE id = ? AND deleted_at IS NULL
+	ON CONFLICT(paper_id) DO NOTHING
+	`, paperID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := s.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM reading_queue WHERE paper_id = ?)`, paperID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("论文不存在: id=%d", paperID)
+	}
+	return nil
+}
+
+// RemoveFromQueue 将论文移出阅读队列，不在队列中时不报错
+func (s *SQLiteDB) RemoveFromQueue(paperID int64) error {
+	_, err := s.db.Exec(`DELETE FROM reading_queue WHERE paper_id = ?`, paperID)
+	return err
+}
+
+// ReorderQueue 按 orderedPaperIDs 的顺序把这些论文排到队列最前，其余论文保持原有相对顺序排在后面；
+// 重复的 ID 只取第一次出现的位置，不在队列中的 ID 返回错误。整个队列重新编号为 1..n，消除位置冲突
+func (s *SQLiteDB) ReorderQueue(orderedPaperIDs []int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT paper_id FROM reading_queue ORDER BY position, priority DESC, added_at, paper_id`)
+	if err != nil {
+		return err
+	}
+	var current []int64
+	queued := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		current = append(current, id)
+		queued[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	order := make([]int64, 0, len(current))
+	placed := make(map[int64]bool, len(current))
+	for _, id := range orderedPaperIDs {
+		if !queued[id] {
+			return fmt.Errorf("论文不在阅读队列中: id=%d", id)
+		}
+		if placed[id] {
+			continue
+		}
+		placed[id] = true
+		order = append(order, id)
+	}
+	for _, id := range current {
+		if !placed[id] {
+			order = append(order, id)
+		}
+	}
+
+	stmt, err := tx.Prepare(`UPDATE reading_queue SET position = ? WHERE paper_id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for i, id := range order {
+		if _, err := stmt.Exec(i+1, id); err != nil {
+			return fmt.Errorf("更新阅读队列顺序失败: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetQueue 按队列顺序返回未删除的论文；limit <= 0 表示不限
+func (s *SQLiteDB) GetQueue(limit int) ([]*models.Paper, error) {
+	query := `
+	SELECT p.id, p.source, p.source_id, p.url, p.title, p.title_translated, p.authors,
+		p.abstract, p.abstract_translated, p.categories, p.comments, p.citations, p.decision, p.alt_sources, p.open_access_url,
+		p.first_submitted_at, p.first_announced_at, p.updated_at
+	FROM reading_queue q JOIN papers p ON p.id = q.paper_id
+	WHERE p.deleted_at IS NULL
+	ORDER BY q.position, q.priority DESC, q.added_at, q.paper_id`
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanPapers(rows)
+}
+
+// PeekNext 返回队首论文，队列为空时返回 nil
+func (s *SQLiteDB) PeekNext() (*models.Paper, error) {
+	papers, err := s.GetQueue(1)
+	if err != nil || len(papers) == 0 {
+		return nil, err
+	}
+	return papers[0], nil
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"sync"
+	"testing"
+)
+
+func queueIDs(t *testing.T, d *SQLiteDB) []int64 {
+	t.Helper()
+	papers, err := d.GetQueue(0)
+	if err != nil {
+		t.Fatalf("GetQueue() error: %v", err)
+	}
+	ids := make([]int64, len(papers))
+	for i, p := range papers {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestReorderQueue(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 4)
+	for _, id := range ids {
+		if err := d.AddToQueue(id); err != nil {
+			t.Fatalf("AddToQueue() error: %v", err)
+		}
+	}
+	// 重复添加保持原位置
+	if err := d.AddToQueue(ids[0]); err != nil {
+		t.Fatalf("AddToQueue() again error: %v", err)
+	}
+	if got := queueIDs(t, d); !equalIDs(got, ids) {
+		t.Fatalf("Queue = %v, want %v", got, ids)
+	}
+
+	// 制造位置冲突：所有论文同一 position，按加入顺序排列
+	if _, err := d.db.Exec(`UPDATE reading_queue SET position = 1`); err != nil {
+		t.Fatalf("Failed to collide positions: %v", err)
+	}
+	if got := queueIDs(t, d); !equalIDs(got, ids) {
+		t.Fatalf("Queue with colliding positions = %v, want %v", got, ids)
+	}
+
+	// 只给出部分 ID（含重复），其余保持相对顺序排在后面
+	if err := d.ReorderQueue([]int64{ids[2], ids[0], ids[2]}); err != nil {
+		t.Fatalf("ReorderQueue() error: %v", err)
+	}
+	want := []int64{ids[2], ids[0], ids[1], ids[3]}
+	if got := queueIDs(t, d); !equalIDs(got, want) {
+		t.Errorf("Queue after reorder = %v, want %v", got, want)
+	}
+
+	rows, err := d.db.Query(`SELECT position FROM reading_queue ORDER BY position`)
+	if err != nil {
+		t.Fatalf("Query positions error: %v", err)
+	}
+	defer rows.Close()
+	pos := 0
+	for rows.Next() {
+		var p int
+		rows.Scan(&p)
+		pos++
+		if p != pos {
+			t.Errorf("Expected positions renumbered to 1..n, got %d at index %d", p, pos)
+		}
+	}
+
+	if err := d.ReorderQueue([]int64{9999}); err == nil {
+		t.Error("Expected error for a paper not in the queue")
+	}
+
+	next, err := d.PeekNext()
+	if err != nil || next == nil || next.ID != ids[2] {
+		t.Errorf("PeekNext() = %v, %v; want paper %d", next, err, ids[2])
+	}
+	if err := d.RemoveFromQueue(ids[2]); err != nil {
+		t.Fatalf("RemoveFromQueue() error: %v", err)
+	}
+	if got, _ := d.GetQueue(2); len(got) != 2 || got[0].ID != ids[0] {
+		t.Errorf("GetQueue(2) after remove = %v, want paper %d first", got, ids[0])
+	}
+}
+
+func TestAddToQueue_Concurrent(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 20)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(ids))
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			errs <- d.AddToQueue(id)
+		}(id)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("AddToQueue() error: %v", err)
+		}
+	}
+
+	var count, distinct int
+	if err := d.db.QueryRow(`SELECT COUNT(*), COUNT(DISTINCT position) FROM reading_queue`).Scan(&count, &distinct); err != nil {
+		t.Fatalf("Query error: %v", err)
+	}
+	if count != len(ids) || distinct != len(ids) {
+		t.Errorf("Expected %d queued papers with distinct positions, got %d rows and %d positions", len(ids), count, distinct)
+	}
+
+	if err := d.AddToQueue(9999); err == nil {
+		t.Error("Expected error for missing paper")
+	}
+}
+
+func TestQueue_RemovedWithDeletedPaper(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 3)
+	for _, id := range ids {
+		if err := d.AddToQueue(id); err != nil {
+			t.Fatalf("AddToQueue() error: %v", err)
+		}
+	}
+
+	// 软删除由触发器移出队列
+	if _, err := d.DeletePapers([]string{"id = ?"}, []interface{}{ids[0]}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	var n int
+	d.db.QueryRow(`SELECT COUNT(*) FROM reading_queue WHERE paper_id = ?`, ids[0]).Scan(&n)
+	if n != 0 {
+		t.Error("Expected soft-deleted paper to leave the queue")
+	}
+	if err := d.AddToQueue(ids[0]); err == nil {
+		t.Error("Expected error when queueing a deleted paper")
+	}
+
+	// 物理删除由外键级联
+	if _, err := d.db.Exec(`DELETE FROM papers WHERE id = ?`, ids[1]); err != nil {
+		t.Fatalf("Delete paper error: %v", err)
+	}
+	if got := queueIDs(t, d); !equalIDs(got, []int64{ids[2]}) {
+		t.Errorf("Queue = %v, want only %d", got, ids[2])
+	}
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+// SaveSearch 按名称保存搜索，同名搜索覆盖其条件；保存时记录当前命中数，之后的检查只报告新增的部分
+func (s *SQLiteDB) SaveSearch(name, queryJSON string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("搜索名称不能为空")
+	}
+	count, err := s.countSavedSearch(queryJSON)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	err = s.db.QueryRow("SELECT id FROM saved_searches WHERE name = ?", name).Scan(&id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		id = fmt.Sprintf("search_%d", time.Now().UnixNano())
+	case err != nil:
+		return err
+	}
+
+	_, err = s.db.Exec(`
+	INSERT INTO saved_searches (id, name, query_json, last_checked, last_result_count)
+	VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		query_json = excluded.query_json,
+		last_checked = excluded.last_checked,
+		last_result_count = excluded.last_result_count
+	`, id, name, queryJSON, time.Now().UTC(), count)
+	if err != nil {
+		return fmt.Errorf("保存搜索失败 (%s): %w", name, err)
+	}
+	return nil
+}
+
+// GetSavedSearches 按名称返回所有保存的搜索，没有记录时返回空切片
+func (s *SQLiteDB) GetSavedSearches() ([]models.SavedSearch, error) {
+	rows, err := s.db.Query(`
+	SELECT id, name, query_json, last_checked, last_result_count
+	FROM saved_searches
+	ORDER BY name, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	searches := []models.SavedSearch{}
+	for rows.Next() {
+		var ss models.SavedSearch
+		var lastChecked sql.NullTime
+		if err := rows.Scan(&ss.ID, &ss.Name, &ss.QueryJSON, &lastChecked, &ss.LastResultCount); err != nil {
+			return nil, err
+		}
+		if lastChecked.Valid {
+			ss.LastChecked = lastChecked.Time.Local()
+		}
+		searches = append(searches, ss)
+	}
+	return searches, rows.Err()
+}
+
+// DeleteSavedSearch 删除保存的搜索，不存在时返回错误
+func (s *SQLiteDB) DeleteSavedSearch(id string) error {
+	result, err := s.db.Exec("DELETE FROM saved_searches WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("保存的搜索不存在: %s", id)
+	}
+	return nil
+}
+
+// CheckSavedSearch 重新执行保存的搜索，返回比上次检查多出的命中数（结果变少时为 0），
+// 并将本次命中数与检查时间写回记录
+func (s *SQLiteDB) CheckSavedSearch(id string) (int, error) {
+	var queryJSON string
+	var last int
+	err := s.db.QueryRow("SELECT query_json, last_result_count FROM saved_searches WHERE id = ?", id).Scan(&queryJSON, &last)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("保存的搜索不存在: %s", id)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := s.countSavedSearch(queryJSON)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.db.Exec("UPDATE saved_searches SET last_checked = ?, last_result_count = ? WHERE id = ?",
+		time.Now().UTC(), count, id); err != nil {
+		return 0, fmt.Errorf("更新保存的搜索失败 (%s): %w", id, err)
+	}
+	return max(count-last, 0), nil
+}
+
+// countSavedSearch 按 SavedSearchQuery 统计命中的论文数，忽略条件中的 Limit 与 Offset
+func (s *SQLiteDB) countSavedSearch(queryJSON string) (int, error) {
+	var q models.SavedSearchQuery
+	if err := json.Unmarshal([]byte(queryJSON), &q); err != nil {
+		return 0, fmt.Errorf("解析搜索条件失败: %w", err)
+	}
+	where, args := keywordWhere(strings.TrimSpace(q.Query), q.Condition)
+
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM papers WHERE "+strings.Join(where, " AND "), args...).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestCheckSavedSearch_EmptyDatabase(t *testing.T) {
+	d := newTestDB(t)
+	if err := d.SaveSearch("gnn", `{"query":"graph"}`); err != nil {
+		t.Fatalf("SaveSearch() error: %v", err)
+	}
+	searches, err := d.GetSavedSearches()
+	if err != nil || len(searches) != 1 {
+		t.Fatalf("GetSavedSearches() = %v, %v; want 1 search", searches, err)
+	}
+	if n, err := d.CheckSavedSearch(searches[0].ID); err != nil || n != 0 {
+		t.Errorf("CheckSavedSearch() on empty database = %d, %v; want 0", n, err)
+	}
+	if _, err := d.CheckSavedSearch("search_missing"); err == nil {
+		t.Error("Expected error for missing saved search")
+	}
+}
+
+func TestCheckSavedSearch_ComparesCounts(t *testing.T) {
+	d := newTestDB(t)
+	seedPapers(t, d, 2)
+
+	// 保存时的命中数作为基准，已有论文不算新增
+	if err := d.SaveSearch("gnn", `{"query":"graph","condition":{"Sources":["arxiv"]}}`); err != nil {
+		t.Fatalf("SaveSearch() error: %v", err)
+	}
+	if err := d.SaveSearch("  ", `{"query":"graph"}`); err == nil {
+		t.Error("Expected error for empty name")
+	}
+	if err := d.SaveSearch("broken", `{"query":`); err == nil {
+		t.Error("Expected error for invalid query JSON")
+	}
+	searches, _ := d.GetSavedSearches()
+	if len(searches) != 1 || searches[0].Name != "gnn" || searches[0].LastResultCount != 2 || searches[0].LastChecked.IsZero() {
+		t.Fatalf("Unexpected saved searches: %+v", searches)
+	}
+	id := searches[0].ID
+
+	if n, err := d.CheckSavedSearch(id); err != nil || n != 0 {
+		t.Errorf("CheckSavedSearch() without new papers = %d, %v; want 0", n, err)
+	}
+
+	// seedPapers 从 0 编号，重新播种 5 篇时前 2 篇为更新，新增 3 篇
+	seedPapers(t, d, 5)
+	if n, err := d.CheckSavedSearch(id); err != nil || n != 3 {
+		t.Errorf("CheckSavedSearch() after adding 3 papers = %d, %v; want 3", n, err)
+	}
+	if n, _ := d.CheckSavedSearch(id); n != 0 {
+		t.Errorf("Second CheckSavedSearch() = %d, want 0", n)
+	}
+
+	// 结果变少时不报告新增，并以新的命中数作为基准
+	if _, err := d.DeletePapers([]string{"source_id = ?"}, []interface{}{"2401.00004"}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	if n, _ := d.CheckSavedSearch(id); n != 0 {
+		t.Errorf("CheckSavedSearch() after deletion = %d, want 0", n)
+	}
+	if searches, _ := d.GetSavedSearches(); searches[0].LastResultCount != 4 {
+		t.Errorf("LastResultCount = %d, want 4", searches[0].LastResultCount)
+	}
+
+	// 同名保存覆盖条件并重置基准，ID 不变
+	if err := d.SaveSearch("gnn", `{"query":"graph","condition":{"Sources":["acl"]}}`); err != nil {
+		t.Fatalf("SaveSearch() overwrite error: %v", err)
+	}
+	searches, _ = d.GetSavedSearches()
+	if len(searches) != 1 || searches[0].ID != id || searches[0].LastResultCount != 0 {
+		t.Errorf("Expected overwrite in place with new baseline, got %+v", searches)
+	}
+
+	if err := d.DeleteSavedSearch(id); err != nil {
+		t.Fatalf("DeleteSavedSearch() error: %v", err)
+	}
+	if err := d.DeleteSavedSearch(id); err == nil {
+		t.Error("Expected error deleting a missing saved search")
+	}
+	if searches, err := d.GetSavedSearches(); err != nil || searches == nil || len(searches) != 0 {
+		t.Errorf("Expected empty non-nil slice after delete, got %v, %v", searches, err)
+	}
+}
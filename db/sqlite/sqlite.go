@@ -7,12 +7,41 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
+
+	"PaperHunter/internal/ann"
+	"PaperHunter/pkg/embcache"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
 type SQLiteDB struct {
 	db *sql.DB
+
+	// 向量索引，按 embedding 模型分别在后台构建；未启用或构建完成前 SearchByEmbedding 全表扫描
+	vectorIndex bool
+	indexMu     sync.Mutex
+	indexes     map[string]ann.Index
+	building    map[string][]pendingVec // 构建期间新保存的向量，构建完成后补入索引
+
+	// 全表扫描时的向量缓存，未启用时为 nil
+	embCache *embcache.Cache
+
+	// quantize SaveEmbedding 以 int8 量化存储向量，读取时按 embedding_scale 还原
+	quantize bool
+
+	// mergeCrossSource Upsert 时将其他平台的同一篇论文合并到已有记录，而不是新增一行
+	mergeCrossSource bool
+
+	// notesFTS 笔记全文索引 notes_fts 可用（需以 -tags sqlite_fts5 编译），否则 SearchNotes 使用 LIKE
+	notesFTS bool
+
+	// papersFTS 论文全文索引 papers_fts 可用（需以 -tags sqlite_fts5 编译），否则 SearchByFTS 使用 LIKE
+	papersFTS bool
+
+	// embeddingDims 各模型已存向量的维度，首次保存该模型的向量时从数据库读取
+	dimMu         sync.Mutex
+	embeddingDims map[string]int
 }
 
 func NewSQLiteDB(path string) (*SQLiteDB, error) {
@@ -21,7 +50,8 @@ func NewSQLiteDB(path string) (*SQLiteDB, error) {
 		return nil, fmt.Errorf("无法创建目录，请检查权限问题: %w", err)
 	}
 
-	db, err := sql.Open("sqlite3", path)
+	// 开启外键约束，删除论文时级联删除 paper_status 等关联记录
+	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on")
 	if err != nil {
 		return nil, fmt.Errorf("无法打开数据库，请检查权限问题: %w", err)
 	}
@@ -42,6 +72,11 @@ func NewSQLiteDB(path string) (*SQLiteDB, error) {
 
 func (d *SQLiteDB) Close() error { return d.db.Close() }
 
+// EnableEmbeddingCache 启用容量为 size 的向量 LRU 缓存，全表扫描检索时不再重复读取已缓存的向量；size <= 0 表示不缓存
+func (d *SQLiteDB) EnableEmbeddingCache(size int) {
+	d.embCache = embcache.New(size)
+}
+
 func (d *SQLiteDB) initTable() error {
 	schema := `
 CREATE TABLE IF NOT EXISTS papers (
@@ -56,16 +91,26 @@ CREATE TABLE IF NOT EXISTS papers (
   abstract_translated TEXT,
   categories TEXT,               -- 存 ",cs.AI,cs.LG,"
   comments TEXT,
+  citations INTEGER NOT NULL DEFAULT 0,
+  decision TEXT NOT NULL DEFAULT '', -- 录用决定，如 OpenReview 的 Accept (Oral)
+  translation_lang TEXT NOT NULL DEFAULT '', -- title_translated / abstract_translated 的目标语言
+  fingerprint TEXT NOT NULL DEFAULT '', -- 归一化标题 + 第一作者姓氏，用于跨平台去重
+  doi TEXT NOT NULL DEFAULT '',
+  alt_sources TEXT NOT NULL DEFAULT '', -- 合并进来的其他平台来源 "source:source_id"，以 ", " 分隔
+  open_access_url TEXT NOT NULL DEFAULT '', -- 开放获取的 PDF 链接
   first_submitted_at DATETIME,
   first_announced_at DATETIME,
   updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 
   -- 向量相关
   embedding_text TEXT,           -- 生成向量用的原始文本（title+abstract 等）
-  embedding BLOB,                -- float32 数组（二进制）
+  embedding BLOB,                -- float32 数组（二进制）；embedding_scale 非 NULL 时为 int8 量化向量
+  embedding_scale REAL,          -- int8 量化的缩放系数，NULL 表示未量化
   embedding_model TEXT,
   embedding_updated_at DATETIME,
 
+  deleted_at TIMESTAMP,          -- 软删除时间，NULL 表示未删除
+
   UNIQUE(source, source_id)
 );
 
@@ -73,9 +118,159 @@ CREATE INDEX IF NOT EXISTS idx_papers_source ON papers(source);
 CREATE INDEX IF NOT EXISTS idx_papers_date ON papers(first_announced_at);
 CREATE INDEX IF NOT EXISTS idx_papers_model ON papers(embedding_model);  
 
+-- 引用关系：source_id 引用了 cited_id，二者同属 source 平台
+CREATE TABLE IF NOT EXISTS paper_citations (
+  source_id TEXT NOT NULL,
+  cited_id TEXT NOT NULL,
+  source TEXT NOT NULL,
+  PRIMARY KEY (source_id, cited_id, source)
+);
+
+CREATE INDEX IF NOT EXISTS idx_citations_cited ON paper_citations(cited_id);
+
+-- 阅读状态：unread/reading/read/archived，没有记录视为 unread；starred 为收藏标记
+CREATE TABLE IF NOT EXISTS paper_status (
+  paper_id INTEGER PRIMARY KEY REFERENCES papers(id) ON DELETE CASCADE,
+  status TEXT NOT NULL,
+  starred INTEGER NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_paper_status ON paper_status(status);
+
+-- 版本历史：如 arXiv 的 v1、v2，每次抓取记录当时的最新版本
+CREATE TABLE IF NOT EXISTS paper_versions (
+  paper_id INTEGER NOT NULL REFERENCES papers(id) ON DELETE CASCADE,
+  version TEXT NOT NULL,
+  submitted_at TIMESTAMP,
+  change_note TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (paper_id, version)
+);
+
+-- 私人笔记：每篇论文一条，随论文物理删除级联删除
+CREATE TABLE IF NOT EXISTS paper_notes (
+  paper_id INTEGER PRIMARY KEY REFERENCES papers(id) ON DELETE CASCADE,
+  note TEXT NOT NULL,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 阅读队列：按 position 升序阅读，position 相同时 priority 高的在前
+CREATE TABLE IF NOT EXISTS reading_queue (
+  paper_id INTEGER PRIMARY KEY REFERENCES papers(id) ON DELETE CASCADE,
+  priority INTEGER NOT NULL DEFAULT 0,
+  added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  position INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reading_queue_position ON reading_queue(position);
+
+-- 爬取统计：每个完成、取消或失败的爬取任务一条，params 与 inserted_ids 为 JSON
+CREATE TABLE IF NOT EXISTS crawl_stats (
+  task_id TEXT PRIMARY KEY,
+  platform TEXT NOT NULL,
+  params TEXT NOT NULL DEFAULT '{}',
+  total INTEGER NOT NULL DEFAULT 0,
+  start_time TIMESTAMP NOT NULL,
+  end_time TIMESTAMP,
+  status TEXT NOT NULL,
+  inserted_ids TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS idx_crawl_stats_start ON crawl_stats(start_time);
+
+-- 保存的搜索：query_json 为 SavedSearchQuery，last_result_count 为上次检查时的命中数
+CREATE TABLE IF NOT EXISTS saved_searches (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  query_json TEXT NOT NULL,
+  last_checked TIMESTAMP,
+  last_result_count INTEGER NOT NULL DEFAULT 0
+);
+
+-- 软删除论文时同时移出阅读队列（物理删除由外键级联）
+CREATE TRIGGER IF NOT EXISTS reading_queue_paper_deleted
+AFTER UPDATE OF deleted_at ON papers WHEN new.deleted_at IS NOT NULL BEGIN
+  DELETE FROM reading_queue WHERE paper_id = new.id;
+END;
+
 	`
 
-	_, err := d.db.Exec(schema)
+	if _, err := d.db.Exec(schema); err != nil {
+		return err
+	}
 
-	return err
+	if err := d.migrate(); err != nil {
+		return err
+	}
+	if err := d.initFingerprints(); err != nil {
+		return err
+	}
+	if err := d.initNotesFTS(); err != nil {
+		return err
+	}
+	return d.initPapersFTS()
+}
+
+// migrate 为旧版本数据库补齐后续新增的列
+func (d *SQLiteDB) migrate() error {
+	columns := []struct {
+		table string
+		name  string
+		ddl   string
+	}{
+		{"papers", "citations", "ALTER TABLE papers ADD COLUMN citations INTEGER NOT NULL DEFAULT 0"},
+		{"papers", "deleted_at", "ALTER TABLE papers ADD COLUMN deleted_at TIMESTAMP"},
+		{"papers", "decision", "ALTER TABLE papers ADD COLUMN decision TEXT NOT NULL DEFAULT ''"},
+		{"papers", "translation_lang", "ALTER TABLE papers ADD COLUMN translation_lang TEXT NOT NULL DEFAULT ''"},
+		{"papers", "fingerprint", "ALTER TABLE papers ADD COLUMN fingerprint TEXT NOT NULL DEFAULT ''"},
+		{"papers", "doi", "ALTER TABLE papers ADD COLUMN doi TEXT NOT NULL DEFAULT ''"},
+		{"papers", "alt_sources", "ALTER TABLE papers ADD COLUMN alt_sources TEXT NOT NULL DEFAULT ''"},
+		{"papers", "open_access_url", "ALTER TABLE papers ADD COLUMN open_access_url TEXT NOT NULL DEFAULT ''"},
+		{"papers", "embedding_scale", "ALTER TABLE papers ADD COLUMN embedding_scale REAL"},
+		{"paper_status", "starred", "ALTER TABLE paper_status ADD COLUMN starred INTEGER NOT NULL DEFAULT 0"},
+	}
+
+	existing := make(map[string]map[string]bool)
+	for _, c := range columns {
+		if existing[c.table] == nil {
+			cols, err := d.tableColumns(c.table)
+			if err != nil {
+				return err
+			}
+			existing[c.table] = cols
+		}
+		if existing[c.table][c.name] {
+			continue
+		}
+		if _, err := d.db.Exec(c.ddl); err != nil {
+			return fmt.Errorf("添加列 %s 失败: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+// tableColumns 返回表中已存在的列名集合
+func (d *SQLiteDB) tableColumns(table string) (map[string]bool, error) {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			ctype     string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &dfltValue, &pk); err != nil {
+			return nil, err
+		}
+		cols[name] = true
+	}
+	return cols, rows.Err()
 }
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"PaperHunter/internal/models"
+)
+
+// SetPaperStatus 设置论文阅读状态，论文不存在时返回错误
+func (s *SQLiteDB) SetPaperStatus(paperID int64, status string) error {
+	if !models.ValidPaperStatus(status) {
+		return fmt.Errorf("无效的阅读状态: %s", status)
+	}
+
+	result, err := s.db.Exec(`
+	INSERT INTO paper_status (paper_id, status, updated_at)
+	SELECT id, ?, CURRENT_TIMESTAMP FROM papers WHERE id = ?
+	ON CONFLICT(paper_id) DO UPDATE SET
+		status = excluded.status,
+		updated_at = CURRENT_TIMESTAMP
+	`, status, paperID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("论文不存在: id=%d", paperID)
+	}
+	return nil
+}
+
+// GetPaperStatus 返回论文阅读状态，没有记录时为 unread
+func (s *SQLiteDB) GetPaperStatus(paperID int64) (string, error) {
+	var status string
+	err := s.db.QueryRow(`SELECT status FROM paper_status WHERE paper_id = ?`, paperID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return models.StatusUnread, nil
+	}
+	return status, err
+}
+
+// GetPapersByStatus 分页列出指定阅读状态的论文，最近更新状态的在前；unread 包含没有状态记录的论文
+func (s *SQLiteDB) GetPapersByStatus(status string, limit, offset int) ([]*models.Paper, int, error) {
+	if !models.ValidPaperStatus(status) {
+		return nil, 0, fmt.Errorf("无效的阅读状态: %s", status)
+	}
+
+	from := `
+	FROM papers p LEFT JOIN paper_status ps ON ps.paper_id = p.id
+	WHERE p.deleted_at IS NULL AND COALESCE(ps.status, 'unread') = ?`
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*)"+from, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+	SELECT p.id, p.source, p.source_id, p.url, p.title, p.title_translated, p.authors,
+		p.abstract, p.abstract_translated, p.categories, p.comments, p.citations, p.decision, p.alt_sources, p.open_access_url,
+		p.first_submitted_at, p.first_announced_at, p.updated_at` + from + `
+	ORDER BY ps.updated_at DESC, p.first_announced_at DESC
+	LIMIT ? OFFSET ?`
+
+	rows, err := s.db.Query(query, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	papers, err := s.scanPapers(rows)
+	return papers, total, err
+}
+
+// SetPaperStarred 设置或取消论文收藏，不改变阅读状态；论文不存在时返回错误
+func (s *SQLiteDB) SetPaperStarred(paperID int64, starred bool) error {
+	// 新记录的 updated_at 留空，避免仅收藏的论文排到 GetPapersByStatus 的最前面
+	result, err := s.db.Exec(`
+	INSERT INTO paper_status (paper_id, status, starred, updated_at)
+	SELECT id, ?, ?, NULL FROM papers WHERE id = ?
+	ON CONFLICT(paper_id) DO UPDATE SET starred = excluded.starred
+	`, models.StatusUnread, starred, paperID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("论文不存在: id=%d", paperID)
+	}
+	return nil
+}
+
+// LoadPaperStatus 为论文填充阅读状态与收藏标记，没有记录的论文为 unread、未收藏
+func (s *SQLiteDB) LoadPaperStatus(papers []*models.Paper) error {
+	if len(papers) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*models.Paper, len(papers))
+	placeholders := make([]string, 0, len(papers))
+	args := make([]interface{}, 0, len(papers))
+	for _, p := range papers {
+		p.Status, p.Starred = models.StatusUnread, false
+		byID[p.ID] = p
+		placeholders = append(placeholders, "?")
+		args = append(args, p.ID)
+	}
+
+	rows, err := s.db.Query(`SELECT paper_id, status, starred FROM paper_status WHERE paper_id IN (`+strings.Join(placeholders, ",")+`)`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id      int64
+			status  string
+			starred bool
+		)
+		if err := rows.Scan(&id, &status, &starred); err != nil {
+			return err
+		}
+		if p := byID[id]; p != nil {
+			p.Status, p.Starred = status, starred
+		}
+	}
+	return rows.Err()
+}
+
+// StatusCondition 生成按阅读状态过滤 papers 的条件，unread 包含没有状态记录的论文
+func StatusCondition(status string) (string, []interface{}) {
+	if status == models.StatusUnread {
+		return "id NOT IN (SELECT paper_id FROM paper_status WHERE status != ?)", []interface{}{status}
+	}
+	return "id IN (SELECT paper_id FROM paper_status WHERE status = ?)", []interface{}{status}
+}
+
+// StarredCondition 生成只保留已收藏论文的条件
+func StarredCondition() string {
+	return "id IN (SELECT paper_id FROM paper_status WHERE starred = 1)"
+}
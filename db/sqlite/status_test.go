@@ -0,0 +1,190 @@
+package db
+
+import (
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestPaperStatus_Transitions(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 1)
+
+	if got, err := d.GetPaperStatus(ids[0]); err != nil || got != models.StatusUnread {
+		t.Fatalf("GetPaperStatus() = %q, %v; want unread", got, err)
+	}
+	for _, status := range []string{models.StatusReading, models.StatusRead, models.StatusArchived, models.StatusUnread} {
+		if err := d.SetPaperStatus(ids[0], status); err != nil {
+			t.Fatalf("SetPaperStatus(%q) error: %v", status, err)
+		}
+		if got, _ := d.GetPaperStatus(ids[0]); got != status {
+			t.Errorf("GetPaperStatus() = %q, want %q", got, status)
+		}
+	}
+
+	if err := d.SetPaperStatus(ids[0], "done"); err == nil {
+		t.Error("Expected error for invalid status")
+	}
+	if err := d.SetPaperStatus(9999, models.StatusRead); err == nil {
+		t.Error("Expected error for missing paper")
+	}
+}
+
+func TestGetPapersByStatus_Pagination(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 5)
+	for _, id := range ids[:3] {
+		if err := d.SetPaperStatus(id, models.StatusRead); err != nil {
+			t.Fatalf("SetPaperStatus() error: %v", err)
+		}
+	}
+
+	page1, total, err := d.GetPapersByStatus(models.StatusRead, 2, 0)
+	if err != nil {
+		t.Fatalf("GetPapersByStatus() error: %v", err)
+	}
+	if total != 3 || len(page1) != 2 {
+		t.Fatalf("Expected page of 2 out of 3, got %d of %d", len(page1), total)
+	}
+	page2, _, err := d.GetPapersByStatus(models.StatusRead, 2, 2)
+	if err != nil || len(page2) != 1 {
+		t.Fatalf("Expected 1 paper on page 2, got %d (%v)", len(page2), err)
+	}
+	seen := map[int64]bool{page1[0].ID: true, page1[1].ID: true, page2[0].ID: true}
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 distinct papers across pages, got %v", seen)
+	}
+
+	// 没有状态记录的论文算作 unread
+	unread, total, err := d.GetPapersByStatus(models.StatusUnread, 10, 0)
+	if err != nil || total != 2 || len(unread) != 2 {
+		t.Errorf("Expected 2 unread papers, got %d (%v)", total, err)
+	}
+
+	if _, _, err := d.GetPapersByStatus("done", 10, 0); err == nil {
+		t.Error("Expected error for invalid status")
+	}
+}
+
+func TestSearchByKeywords_StatusFilter(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 3)
+	if err := d.SetPaperStatus(ids[1], models.StatusReading); err != nil {
+		t.Fatalf("SetPaperStatus() error: %v", err)
+	}
+
+	reading, err := d.SearchByKeywords("Graph", models.SearchCondition{Status: models.StatusReading})
+	if err != nil || len(reading) != 1 || reading[0].ID != ids[1] {
+		t.Errorf("Expected only paper %d when filtering reading, got %d (%v)", ids[1], len(reading), err)
+	}
+	unread, err := d.SearchByKeywords("Graph", models.SearchCondition{Status: models.StatusUnread})
+	if err != nil || len(unread) != 2 {
+		t.Errorf("Expected 2 unread papers, got %d (%v)", len(unread), err)
+	}
+}
+
+func TestPaperStatus_CascadeOnDelete(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 2)
+	for _, id := range ids {
+		if err := d.SetPaperStatus(id, models.StatusRead); err != nil {
+			t.Fatalf("SetPaperStatus() error: %v", err)
+		}
+	}
+
+	if _, err := d.DeletePapers([]string{"id = ?"}, []interface{}{ids[0]}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	// 软删除的论文不出现在列表中，但状态保留以便恢复
+	if _, total, _ := d.GetPapersByStatus(models.StatusRead, 10, 0); total != 1 {
+		t.Errorf("Expected soft-deleted paper to be excluded, got total %d", total)
+	}
+
+	if _, err := d.PurgePapers(0); err != nil {
+		t.Fatalf("PurgePapers() error: %v", err)
+	}
+	var rows int
+	if err := d.db.QueryRow(`SELECT COUNT(*) FROM paper_status WHERE paper_id = ?`, ids[0]).Scan(&rows); err != nil {
+		t.Fatalf("count status rows error: %v", err)
+	}
+	if rows != 0 {
+		t.Errorf("Expected status row to be deleted with its paper, got %d", rows)
+	}
+	if got, _ := d.GetPaperStatus(ids[1]); got != models.StatusRead {
+		t.Errorf("Expected other paper to keep its status, got %q", got)
+	}
+}
+
+func TestSetPaperStarred_KeepsStatus(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 3)
+	if err := d.SetPaperStatus(ids[0], models.StatusReading); err != nil {
+		t.Fatalf("SetPaperStatus() error: %v", err)
+	}
+	for _, id := range ids[:2] {
+		if err := d.SetPaperStarred(id, true); err != nil {
+			t.Fatalf("SetPaperStarred() error: %v", err)
+		}
+	}
+	if err := d.SetPaperStarred(9999, true); err == nil {
+		t.Error("Expected error for missing paper")
+	}
+
+	// 收藏不改变阅读状态，仅收藏的论文仍算作 unread
+	if got, _ := d.GetPaperStatus(ids[0]); got != models.StatusReading {
+		t.Errorf("GetPaperStatus() after starring = %q, want reading", got)
+	}
+	if got, _ := d.GetPaperStatus(ids[1]); got != models.StatusUnread {
+		t.Errorf("GetPaperStatus() of starred-only paper = %q, want unread", got)
+	}
+
+	papers, err := d.GetPapersByConditions(nil, nil, 0)
+	if err != nil {
+		t.Fatalf("GetPapersByConditions() error: %v", err)
+	}
+	if err := d.LoadPaperStatus(papers); err != nil {
+		t.Fatalf("LoadPaperStatus() error: %v", err)
+	}
+	want := map[int64]struct {
+		status  string
+		starred bool
+	}{
+		ids[0]: {models.StatusReading, true},
+		ids[1]: {models.StatusUnread, true},
+		ids[2]: {models.StatusUnread, false},
+	}
+	for _, p := range papers {
+		if w := want[p.ID]; p.Status != w.status || p.Starred != w.starred {
+			t.Errorf("Paper %d: got status %q starred %v, want %q %v", p.ID, p.Status, p.Starred, w.status, w.starred)
+		}
+	}
+
+	if err := d.SetPaperStarred(ids[0], false); err != nil {
+		t.Fatalf("SetPaperStarred(false) error: %v", err)
+	}
+	starred, err := d.SearchByKeywords("Graph", models.SearchCondition{Starred: true})
+	if err != nil || len(starred) != 1 || starred[0].ID != ids[1] {
+		t.Errorf("Expected only paper %d when filtering starred, got %d (%v)", ids[1], len(starred), err)
+	}
+	both, err := d.SearchByKeywords("Graph", models.SearchCondition{Status: models.StatusReading, Starred: true})
+	if err != nil || len(both) != 0 {
+		t.Errorf("Expected no reading starred papers, got %d (%v)", len(both), err)
+	}
+}
+
+func TestStatusCondition_ForExport(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 3)
+	if err := d.SetPaperStatus(ids[2], models.StatusArchived); err != nil {
+		t.Fatalf("SetPaperStatus() error: %v", err)
+	}
+	if err := d.SetPaperStarred(ids[2], true); err != nil {
+		t.Fatalf("SetPaperStarred() error: %v", err)
+	}
+
+	c, p := StatusCondition(models.StatusArchived)
+	papers, err := d.GetPapersByConditions([]string{c, StarredCondition()}, p, 0)
+	if err != nil || len(papers) != 1 || papers[0].ID != ids[2] {
+		t.Errorf("Expected only paper %d, got %d (%v)", ids[2], len(papers), err)
+	}
+}
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/ann"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// indexOversample 索引检索的候选倍数，为来源/日期过滤预留余量
+const indexOversample = 4
+
+// indexLoadChunk 构建索引时每次从数据库读取的向量数
+const indexLoadChunk = 1000
+
+type pendingVec struct {
+	id  int64
+	vec []float32
+}
+
+// EnableVectorIndex 启用内存 HNSW 向量索引，首次检索某个模型时在后台从数据库构建
+func (s *SQLiteDB) EnableVectorIndex() {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	s.vectorIndex = true
+	if s.indexes == nil {
+		s.indexes = make(map[string]ann.Index)
+		s.building = make(map[string][]pendingVec)
+	}
+}
+
+// embeddingWhere 向量检索的公共过滤条件
+func embeddingWhere(model string, cond models.SearchCondition) ([]string, []interface{}) {
+	where := []string{"deleted_at IS NULL", "embedding IS NOT NULL", "embedding_model = ?"}
+	args := []interface{}{model}
+
+	condWhere, condArgs := searchConditionWhere(cond)
+	return append(where, condWhere...), append(args, condArgs...)
+}
+
+// indexEmbedding 向已构建的索引中写入新向量；正在构建时先暂存，尚未构建时由构建统一加载
+func (s *SQLiteDB) indexEmbedding(paperID int64, model string, vec []float32) {
+	if !s.vectorIndex {
+		return
+	}
+	s.indexMu.Lock()
+	idx := s.indexes[model]
+	if pending, ok := s.building[model]; ok {
+		s.building[model] = append(pending, pendingVec{id: paperID, vec: vec})
+	}
+	s.indexMu.Unlock()
+	if idx != nil {
+		idx.Insert(paperID, vec)
+	}
+}
+
+// readyIndex 返回已构建完成的索引；尚未构建时在后台启动构建并返回 nil
+func (s *SQLiteDB) readyIndex(model string) ann.Index {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	if idx, ok := s.indexes[model]; ok {
+		return idx
+	}
+	if _, ok := s.building[model]; !ok {
+		s.building[model] = []pendingVec{}
+		go func() {
+			if err := s.BuildVectorIndex(model); err != nil {
+				logger.Warn("向量索引构建失败: model=%s: %v", model, err)
+			}
+		}()
+	}
+	return nil
+}
+
+// BuildVectorIndex 从数据库构建模型对应的索引并替换已有索引，耗时与论文数量成正比
+// 已删除或已换模型的论文也可能留在索引中，检索结果会再经 SQL 条件过滤
+func (s *SQLiteDB) BuildVectorIndex(model string) error {
+	s.indexMu.Lock()
+	if _, ok := s.building[model]; !ok {
+		s.building[model] = []pendingVec{}
+	}
+	s.indexMu.Unlock()
+
+	idx, err := s.loadVectorIndex(model)
+
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	pending := s.building[model]
+	delete(s.building, model)
+	if err != nil {
+		return err
+	}
+	for _, p := range pending {
+		idx.Insert(p.id, p.vec)
+	}
+	s.indexes[model] = idx
+	return nil
+}
+
+// loadVectorIndex 按 id 分块读取向量，避免长时间占用读游标阻塞写入
+func (s *SQLiteDB) loadVectorIndex(model string) (ann.Index, error) {
+	start := time.Now()
+	idx := ann.NewHNSW()
+
+	lastID := int64(0)
+	for {
+		chunk, err := s.embeddingChunk(model, lastID)
+		if err != nil {
+			return nil, err
+		}
+		if len(chunk) == 0 {
+			break
+		}
+		for _, p := range chunk {
+			idx.Insert(p.id, p.vec)
+		}
+		lastID = chunk[len(chunk)-1].id
+	}
+
+	logger.Info("向量索引构建完成: model=%s, %d 篇, 耗时 %v", model, idx.Len(), time.Since(start))
+	return idx, nil
+}
+
+// embeddingChunk 读取 id 大于 afterID 的一批向量
+func (s *SQLiteDB) embeddingChunk(model string, afterID int64) ([]pendingVec, error) {
+	rows, err := s.db.Query(`SELECT id, embedding, embedding_scale FROM papers
+		WHERE embedding IS NOT NULL AND embedding_model = ? AND id > ?
+		ORDER BY id LIMIT ?`, model, afterID, indexLoadChunk)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chunk []pendingVec
+	for rows.Next() {
+		var p pendingVec
+		var blob []byte
+		var scale sql.NullFloat64
+		if err := rows.Scan(&p.id, &blob, &scale); err != nil {
+			return nil, err
+		}
+		p.vec = decodeEmbedding(blob, scale)
+		chunk = append(chunk, p)
+	}
+	return chunk, rows.Err()
+}
+
+// searchIndexed 通过索引检索，ok 为 false 时调用方应回退到全表扫描
+func (s *SQLiteDB) searchIndexed(queryVec []float32, model string, cond models.SearchCondition, topK int) ([]*models.SimilarPaper, bool, error) {
+	idx := s.readyIndex(model)
+	if idx == nil {
+		return nil, false, nil
+	}
+
+	// 需要的结果接近全量时索引没有优势
+	size := idx.Len()
+	if topK <= 0 || size == 0 || topK*indexOversample >= size {
+		return nil, false, nil
+	}
+
+	ids, sims := idx.Search(queryVec, topK*indexOversample, 0)
+	if len(ids) == 0 {
+		return nil, false, nil
+	}
+
+	where, args := embeddingWhere(model, cond)
+	where = append(where, "id IN ("+strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")+")")
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	rows, err := s.db.Query(`
+	SELECT id, source, source_id, url, title, title_translated, authors,
+		abstract, abstract_translated, categories, comments, citations, decision, alt_sources, open_access_url,
+		first_submitted_at, first_announced_at, updated_at
+	FROM papers
+	WHERE `+strings.Join(where, " AND "), args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	papers, err := s.scanPapers(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	byID := make(map[int64]*models.Paper, len(papers))
+	for _, p := range papers {
+		byID[p.ID] = p
+	}
+
+	results := make([]*models.SimilarPaper, 0, topK)
+	for i, id := range ids {
+		p, ok := byID[id]
+		if !ok {
+			continue
+		}
+		results = append(results, &models.SimilarPaper{Paper: *p, Similarity: sims[i]})
+		if len(results) == topK {
+			break
+		}
+	}
+
+	// 过滤条件排除了过多候选，交给全表扫描保证结果完整
+	if len(results) < topK {
+		logger.Debug("向量索引候选经过滤后不足 %d 篇，回退全表扫描", topK)
+		return nil, false, nil
+	}
+	return results, true, nil
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func randomVec(rng *rand.Rand, dim int) []float32 {
+	vec := make([]float32, dim)
+	for i := range vec {
+		vec[i] = rng.Float32()*2 - 1
+	}
+	return vec
+}
+
+// seedEmbeddings 在一个事务中批量写入 n 篇带向量的论文，偶数为 arxiv，奇数为 acl
+func seedEmbeddings(tb testing.TB, d *SQLiteDB, n, dim int) [][]float32 {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(7))
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		tb.Fatalf("Begin() error: %v", err)
+	}
+	stmt, err := tx.Prepare(`INSERT INTO papers (source, source_id, url, title, title_translated, authors,
+		abstract, abstract_translated, categories, comments, first_submitted_at, first_announced_at,
+		embedding, embedding_model)
+		VALUES (?, ?, ?, ?, '', '', '', '', '', '', ?, ?, ?, 'test-model')`)
+	if err != nil {
+		tb.Fatalf("Prepare() error: %v", err)
+	}
+	defer stmt.Close()
+
+	vecs := make([][]float32, n)
+	for i := 0; i < n; i++ {
+		source := "arxiv"
+		if i%2 == 1 {
+			source = "acl"
+		}
+		vecs[i] = randomVec(rng, dim)
+		id := fmt.Sprintf("p%06d", i)
+		date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		if _, err := stmt.Exec(source, id, "https://example.org/"+id, "Paper "+id, date, date, encodeVec(vecs[i])); err != nil {
+			tb.Fatalf("insert error: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		tb.Fatalf("Commit() error: %v", err)
+	}
+	return vecs
+}
+
+func TestSearchByEmbedding_VectorIndex(t *testing.T) {
+	brute := newTestDB(t)
+	indexed, err := NewSQLiteDB(filepath.Join(t.TempDir(), "indexed.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	t.Cleanup(func() { indexed.Close() })
+	indexed.EnableVectorIndex()
+
+	const n, dim, k = 1000, 16, 10
+	seedEmbeddings(t, brute, n, dim)
+	seedEmbeddings(t, indexed, n, dim)
+	if err := indexed.BuildVectorIndex("test-model"); err != nil {
+		t.Fatalf("BuildVectorIndex() error: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(11))
+	conds := []models.SearchCondition{{}, {Sources: []string{"acl"}}}
+	hits, total := 0, 0
+	for q := 0; q < 20; q++ {
+		query := randomVec(rng, dim)
+		for _, cond := range conds {
+			want, err := brute.SearchByEmbedding(query, "test-model", cond, k)
+			if err != nil {
+				t.Fatalf("brute SearchByEmbedding() error: %v", err)
+			}
+			got, err := indexed.SearchByEmbedding(query, "test-model", cond, k)
+			if err != nil {
+				t.Fatalf("indexed SearchByEmbedding() error: %v", err)
+			}
+			if len(got) != k {
+				t.Fatalf("indexed search returned %d results, want %d", len(got), k)
+			}
+
+			exact := make(map[string]bool)
+			for _, r := range want {
+				exact[r.Paper.SourceID] = true
+			}
+			for _, r := range got {
+				if len(cond.Sources) > 0 && r.Paper.Source != "acl" {
+					t.Errorf("indexed result %s ignores source filter", r.Paper.SourceID)
+				}
+				if exact[r.Paper.SourceID] {
+					hits++
+				}
+			}
+			total += k
+		}
+	}
+	if recall := float64(hits) / float64(total); recall < 0.9 {
+		t.Errorf("indexed recall@%d = %.3f, want >= 0.9", k, recall)
+	}
+
+	// 新保存的向量和软删除都能立即反映到索引检索中
+	target := randomVec(rng, dim)
+	if err := indexed.SaveEmbedding(5, "test-model", "", target); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	got, err := indexed.SearchByEmbedding(target, "test-model", models.SearchCondition{}, 1)
+	if err != nil || len(got) != 1 || got[0].Paper.ID != 5 {
+		t.Fatalf("Expected updated paper 5 as top result, got %v (%v)", got, err)
+	}
+	if _, err := indexed.DeletePapers([]string{"id = ?"}, []interface{}{5}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	got, err = indexed.SearchByEmbedding(target, "test-model", models.SearchCondition{}, 1)
+	if err != nil || len(got) != 1 || got[0].Paper.ID == 5 {
+		t.Errorf("Expected deleted paper to be excluded, got %v (%v)", got, err)
+	}
+}
+
+func TestSearchByEmbedding_BuildsIndexInBackground(t *testing.T) {
+	d := newTestDB(t)
+	d.EnableVectorIndex()
+	vecs := seedEmbeddings(t, d, 200, 8)
+
+	// 索引尚未构建时回退全表扫描，结果仍然准确
+	got, err := d.SearchByEmbedding(vecs[42], "test-model", models.SearchCondition{}, 3)
+	if err != nil || len(got) != 3 || got[0].Paper.SourceID != "p000042" {
+		t.Fatalf("Expected brute-force fallback to find p000042, got %v (%v)", got, err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for d.readyIndex("test-model") == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("vector index was not built in background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	got, err = d.SearchByEmbedding(vecs[42], "test-model", models.SearchCondition{}, 3)
+	if err != nil || len(got) != 3 || got[0].Paper.SourceID != "p000042" {
+		t.Errorf("Expected indexed search to find p000042, got %v (%v)", got, err)
+	}
+}
+
+// 运行: go test ./db/sqlite -run '^$' -bench SearchByEmbedding -benchtime 20x
+// 索引构建在计时之外，50k 篇需要数分钟
+func BenchmarkSearchByEmbedding(b *testing.B) {
+	const n, dim, k = 50000, 128, 10
+
+	d, err := NewSQLiteDB(filepath.Join(b.TempDir(), "bench.db"))
+	if err != nil {
+		b.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	defer d.Close()
+	seedEmbeddings(b, d, n, dim)
+	query := randomVec(rand.New(rand.NewSource(3)), dim)
+
+	b.Run("BruteForce", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := d.SearchByEmbedding(query, "test-model", models.SearchCondition{}, k); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	d.EnableVectorIndex()
+	if err := d.BuildVectorIndex("test-model"); err != nil {
+		b.Fatalf("build index: %v", err)
+	}
+	b.Run("Indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := d.SearchByEmbedding(query, "test-model", models.SearchCondition{}, k); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"PaperHunter/internal/models"
+)
+
+// SavePaperVersion 记录论文的一个版本，同一版本再次抓取时更新提交时间与说明
+func (s *SQLiteDB) SavePaperVersion(paperID int64, v models.PaperVersion) error {
+	if v.Version == "" {
+		return fmt.Errorf("版本号不能为空")
+	}
+	var submittedAt interface{}
+	if !v.SubmittedAt.IsZero() {
+		submittedAt = v.SubmittedAt
+	}
+
+	_, err := s.db.Exec(`
+	INSERT INTO paper_versions (paper_id, version, submitted_at, change_note)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT(paper_id, version) DO UPDATE SET
+		submitted_at = COALESCE(excluded.submitted_at, paper_versions.submitted_at),
+		change_note = CASE WHEN excluded.change_note != '' THEN excluded.change_note ELSE paper_versions.change_note END
+	`, paperID, v.Version, submittedAt, v.ChangeNote)
+	if err != nil {
+		return fmt.Errorf("保存论文版本失败 (id=%d %s): %w", paperID, v.Version, err)
+	}
+	return nil
+}
+
+// GetPaperVersions 按提交时间从早到晚返回版本历史，没有记录时返回空切片
+func (s *SQLiteDB) GetPaperVersions(paperID int64) ([]models.PaperVersion, error) {
+	rows, err := s.db.Query(`
+	SELECT version, submitted_at, change_note FROM paper_versions
+	WHERE paper_id = ?
+	ORDER BY submitted_at IS NULL, submitted_at, rowid
+	`, paperID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []models.PaperVersion{}
+	for rows.Next() {
+		var v models.PaperVersion
+		var submittedAt sql.NullTime
+		if err := rows.Scan(&v.Version, &submittedAt, &v.ChangeNote); err != nil {
+			return nil, err
+		}
+		v.SubmittedAt = submittedAt.Time
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func TestPaperVersions_UpsertAndOrder(t *testing.T) {
+	d := newTestDB(t)
+	ids := seedPapers(t, d, 1)
+
+	if versions, err := d.GetPaperVersions(ids[0]); err != nil || versions == nil || len(versions) != 0 {
+		t.Fatalf("Expected empty non-nil history, got %v (%v)", versions, err)
+	}
+
+	v2At := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	v1At := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	for _, v := range []models.PaperVersion{
+		{Version: "v2", SubmittedAt: v2At, ChangeNote: "fixed proofs"},
+		{Version: "v1", SubmittedAt: v1At},
+		// 再次抓取 v2 时没有说明，保留已有的说明
+		{Version: "v2", SubmittedAt: v2At},
+	} {
+		if err := d.SavePaperVersion(ids[0], v); err != nil {
+			t.Fatalf("SavePaperVersion(%s) error: %v", v.Version, err)
+		}
+	}
+	if err := d.SavePaperVersion(ids[0], models.PaperVersion{}); err == nil {
+		t.Error("Expected error for empty version")
+	}
+
+	versions, err := d.GetPaperVersions(ids[0])
+	if err != nil {
+		t.Fatalf("GetPaperVersions() error: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != "v1" || versions[1].Version != "v2" {
+		t.Fatalf("Expected [v1 v2], got %+v", versions)
+	}
+	if !versions[1].SubmittedAt.Equal(v2At) || versions[1].ChangeNote != "fixed proofs" {
+		t.Errorf("Unexpected v2 entry: %+v", versions[1])
+	}
+
+	// 物理删除论文时级联删除版本历史
+	if _, err := d.DeletePapers([]string{"id = ?"}, []interface{}{ids[0]}); err != nil {
+		t.Fatalf("DeletePapers() error: %v", err)
+	}
+	if _, err := d.PurgePapers(0); err != nil {
+		t.Fatalf("PurgePapers() error: %v", err)
+	}
+	if versions, _ := d.GetPaperVersions(ids[0]); len(versions) != 0 {
+		t.Errorf("Expected versions to be purged with the paper, got %+v", versions)
+	}
+}
@@ -100,6 +100,7 @@ func NewPaperAgent(app *App) adk.Agent {
    - 从 Zotero 获取论文（action: get_papers）
    - 根据用户在 Zotero 中保存的论文，推荐指定日期范围内新发布的相似论文（action: daily_recommend）
    - 支持指定日期范围（date_from 和 date_to），默认为今天
+   - 支持指定 arXiv archive 列表（archives，如 ["cs", "stat", "math"]），默认使用配置中的 arxiv.new_submissions_categories
    - 自动爬取各平台指定日期范围内的论文（如果今天还未爬取）
    - 使用语义搜索找出与 Zotero 论文相似的新论文
    - 支持指定平台、Zotero collection、推荐数量等参数
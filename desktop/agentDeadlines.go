@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"PaperHunter/pkg/logger"
+)
+
+// aiDeadlinesURL aideadlin.es 提供的会议截止日期 JSON 接口
+const aiDeadlinesURL = "https://aideadlin.es/api/conferences.json"
+
+const (
+	deadlinesCacheKey = "deadlines"
+	deadlinesCacheTTL = 6 * time.Hour
+	// maxDeadlineReminders 搜索建议中最多提示的截止日期数
+	maxDeadlineReminders = 3
+	// deadlineReminderWindow 只提醒该时间内到期的截止日期
+	deadlineReminderWindow = 60 * 24 * time.Hour
+)
+
+// deadlineLayouts aideadlin.es 中出现过的截止时间格式
+var deadlineLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+var reUTCOffset = regexp.MustCompile(`^(?i)(?:UTC|GMT)\s*([+-]\d{1,2})$`)
+
+// conferenceEntry aideadlin.es 返回的单个会议条目，只解析用到的字段
+type conferenceEntry struct {
+	Title            string `json:"title"`
+	Year             any    `json:"year"`
+	Deadline         string `json:"deadline"`
+	AbstractDeadline string `json:"abstract_deadline"`
+	Timezone         string `json:"timezone"`
+}
+
+// deadlinesCacheFile 截止日期的本地缓存文件内容
+type deadlinesCacheFile struct {
+	ExpiresAt time.Time      `json:"expires_at"`
+	Deadlines []DeadlineInfo `json:"deadlines"`
+}
+
+// WithDeadlinesCache 设置截止日期缓存文件路径，为空时只在内存中缓存
+func (ast *AgentSearchTool) WithDeadlinesCache(path string) *AgentSearchTool {
+	ast.deadlinesCachePath = path
+	return ast
+}
+
+// fetchDeadlinesFromConferenceList 从 aideadlin.es 拉取会议截止日期，格式不正确的条目直接跳过
+func (ast *AgentSearchTool) fetchDeadlinesFromConferenceList(ctx context.Context) ([]DeadlineInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ast.deadlinesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ast.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求会议截止日期失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求会议截止日期失败: HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("解析会议截止日期失败: %w", err)
+	}
+
+	var deadlines []DeadlineInfo
+	skipped := 0
+	for _, item := range raw {
+		var entry conferenceEntry
+		if err := json.Unmarshal(item, &entry); err != nil || strings.TrimSpace(entry.Title) == "" {
+			skipped++
+			continue
+		}
+		venue := strings.TrimSpace(entry.Title)
+		if year := fmt.Sprint(entry.Year); entry.Year != nil && !strings.Contains(venue, year) {
+			venue += " " + year
+		}
+		due, ok := parseDeadline(entry.Deadline, entry.Timezone)
+		if !ok {
+			skipped++
+			continue
+		}
+		if abstractDue, ok := parseDeadline(entry.AbstractDeadline, entry.Timezone); ok {
+			deadlines = append(deadlines, DeadlineInfo{VenueName: venue, Deadline: formatDeadline(abstractDue), Type: "abstract"})
+		}
+		deadlines = append(deadlines, DeadlineInfo{VenueName: venue, Deadline: formatDeadline(due), Type: "submission"})
+	}
+	if skipped > 0 {
+		logger.Warn("AgentSearchTool: 跳过 %d 个格式不正确的会议截止日期", skipped)
+	}
+	sortDeadlines(deadlines)
+	return deadlines, nil
+}
+
+// getDeadlines 读取会议截止日期：依次使用内存缓存、未过期的本地缓存文件，都没有时重新拉取；
+// 拉取失败时退回过期的本地缓存，未配置接口时返回空
+func (ast *AgentSearchTool) getDeadlines(ctx context.Context) ([]DeadlineInfo, error) {
+	if entry, exists := ast.cache[deadlinesCacheKey]; exists && entry.ExpiresAt.After(time.Now()) {
+		return entry.Data.([]DeadlineInfo), nil
+	}
+
+	cached, err := ast.loadDeadlinesCache()
+	if err != nil {
+		logger.Warn("读取截止日期缓存失败: %v", err)
+	}
+	if cached != nil && cached.ExpiresAt.After(time.Now()) {
+		ast.cache[deadlinesCacheKey] = &CacheEntry{Data: cached.Deadlines, ExpiresAt: cached.ExpiresAt}
+		return cached.Deadlines, nil
+	}
+
+	if ast.deadlinesURL == "" {
+		return nil, nil
+	}
+	deadlines, err := ast.fetchDeadlinesFromConferenceList(ctx)
+	if err != nil {
+		if cached != nil {
+			logger.Warn("AgentSearchTool: %v，使用过期的截止日期缓存", err)
+			return cached.Deadlines, nil
+		}
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(deadlinesCacheTTL)
+	ast.cache[deadlinesCacheKey] = &CacheEntry{Data: deadlines, ExpiresAt: expiresAt}
+	if err := ast.saveDeadlinesCache(&deadlinesCacheFile{ExpiresAt: expiresAt, Deadlines: deadlines}); err != nil {
+		logger.Warn("写入截止日期缓存失败: %v", err)
+	}
+	logger.Info("AgentSearchTool: 已获取 %d 个会议截止日期", len(deadlines))
+	return deadlines, nil
+}
+
+// upcomingDeadlines 返回尚未到期的截止日期，按时间先后排序
+func (ast *AgentSearchTool) upcomingDeadlines(ctx context.Context) ([]DeadlineInfo, error) {
+	deadlines, err := ast.getDeadlines(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	upcoming := make([]DeadlineInfo, 0, len(deadlines))
+	for _, d := range deadlines {
+		if due, ok := parseDeadline(d.Deadline, "UTC"); ok && due.After(now) {
+			upcoming = append(upcoming, d)
+		}
+	}
+	return upcoming, nil
+}
+
+// deadlineReminders 生成近期截止日期的提醒
+func deadlineReminders(deadlines []DeadlineInfo, now time.Time) []string {
+	var reminders []string
+	for _, d := range deadlines {
+		if len(reminders) >= maxDeadlineReminders {
+			break
+		}
+		due, ok := parseDeadline(d.Deadline, "UTC")
+		if !ok || !due.After(now) || due.Sub(now) > deadlineReminderWindow {
+			continue
+		}
+		days := int(due.Sub(now).Hours() / 24)
+		reminders = append(reminders, fmt.Sprintf("%s %s deadline is %s (in %d days)", d.VenueName, d.Type, d.Deadline, days))
+	}
+	return reminders
+}
+
+// ExportDeadlines 导出尚未到期的会议截止日期为 JSON，按截止时间排序
+func (ast *AgentSearchTool) ExportDeadlines(ctx context.Context) (string, error) {
+	deadlines, err := ast.upcomingDeadlines(ctx)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(deadlines, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("序列化截止日期失败: %w", err)
+	}
+	return string(data), nil
+}
+
+func (ast *AgentSearchTool) loadDeadlinesCache() (*deadlinesCacheFile, error) {
+	if ast.deadlinesCachePath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(ast.deadlinesCachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cached deadlinesCacheFile
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+func (ast *AgentSearchTool) saveDeadlinesCache(cached *deadlinesCacheFile) error {
+	if ast.deadlinesCachePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(ast.deadlinesCachePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(ast.deadlinesCachePath, data, 0644)
+}
+
+// parseDeadline 按会议时区解析截止时间并转换为 UTC；AoE 视为 UTC-12，未知时区按 UTC 处理
+func parseDeadline(value, timezone string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "TBA") {
+		return time.Time{}, false
+	}
+
+	loc := time.UTC
+	switch tz := strings.TrimSpace(timezone); {
+	case strings.EqualFold(tz, "AoE"):
+		loc = time.FixedZone("AoE", -12*3600)
+	case reUTCOffset.MatchString(tz):
+		hours, _ := strconv.Atoi(reUTCOffset.FindStringSubmatch(tz)[1])
+		loc = time.FixedZone(tz, hours*3600)
+	case tz != "":
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+
+	for _, layout := range deadlineLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func formatDeadline(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04")
+}
+
+// sortDeadlines 按截止时间排序，同一时间按会议名排序
+func sortDeadlines(deadlines []DeadlineInfo) {
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		if deadlines[i].Deadline != deadlines[j].Deadline {
+			return deadlines[i].Deadline < deadlines[j].Deadline
+		}
+		return deadlines[i].VenueName < deadlines[j].VenueName
+	})
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newDeadlineServer 返回会议截止日期接口的测试服务器及其请求计数
+func newDeadlineServer(t *testing.T, body string) (string, *int32) {
+	t.Helper()
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, &hits
+}
+
+func deadlineDate(d time.Duration) string {
+	return time.Now().UTC().Add(d).Format("2006-01-02 15:04:05")
+}
+
+func TestGetDeadlines_CacheTTL(t *testing.T) {
+	body := fmt.Sprintf(`[{"title":"NeurIPS","year":2026,"deadline":%q,"timezone":"UTC"}]`, deadlineDate(20*24*time.Hour))
+	url, hits := newDeadlineServer(t, body)
+	cachePath := filepath.Join(t.TempDir(), "deadlines_cache.json")
+
+	ast := NewAgentSearchTool().WithDeadlinesCache(cachePath)
+	ast.deadlinesURL = url
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		deadlines, err := ast.getDeadlines(ctx)
+		if err != nil || len(deadlines) != 1 || deadlines[0].VenueName != "NeurIPS 2026" {
+			t.Fatalf("获取截止日期结果不符: %v, %v", deadlines, err)
+		}
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("缓存有效期内应只请求一次，实际 %d 次", n)
+	}
+
+	// 重启后从本地缓存文件读取，不重新请求
+	restarted := NewAgentSearchTool().WithDeadlinesCache(cachePath)
+	restarted.deadlinesURL = url
+	if deadlines, err := restarted.getDeadlines(ctx); err != nil || len(deadlines) != 1 {
+		t.Fatalf("读取本地缓存失败: %v, %v", deadlines, err)
+	}
+	if n := atomic.LoadInt32(hits); n != 1 {
+		t.Errorf("本地缓存有效时不应重新请求，实际 %d 次", n)
+	}
+
+	// 内存与本地缓存都过期后重新拉取
+	ast.cache[deadlinesCacheKey].ExpiresAt = time.Now().Add(-time.Minute)
+	expired, _ := json.Marshal(deadlinesCacheFile{ExpiresAt: time.Now().Add(-time.Minute)})
+	if err := os.WriteFile(cachePath, expired, 0644); err != nil {
+		t.Fatalf("写入过期缓存失败: %v", err)
+	}
+	if _, err := ast.getDeadlines(ctx); err != nil {
+		t.Fatalf("重新获取截止日期失败: %v", err)
+	}
+	if n := atomic.LoadInt32(hits); n != 2 {
+		t.Errorf("缓存过期后应重新请求，实际共 %d 次", n)
+	}
+	if entry := ast.cache[deadlinesCacheKey]; entry.ExpiresAt.Before(time.Now().Add(deadlinesCacheTTL - time.Minute)) {
+		t.Errorf("重新拉取后缓存有效期应为 %v，实际到期时间 %v", deadlinesCacheTTL, entry.ExpiresAt)
+	}
+}
+
+func TestFetchDeadlines_SkipsMalformedEntries(t *testing.T) {
+	body := fmt.Sprintf(`[
+		42,
+		{"title":"","deadline":%[1]q},
+		{"title":"ICML","year":2026,"deadline":"TBA"},
+		{"title":"CVPR","year":2026,"deadline":"soon"},
+		{"title":"ACL 2026","deadline":%[2]q,"abstract_deadline":%[1]q,"timezone":"AoE"},
+		{"title":"ICLR","year":"2026","deadline":%[1]q,"timezone":"UTC-8"},
+		{"title":"AAAI","year":2025,"deadline":%[3]q}
+	]`, deadlineDate(10*24*time.Hour), deadlineDate(30*24*time.Hour), deadlineDate(-24*time.Hour))
+	url, _ := newDeadlineServer(t, body)
+
+	ast := NewAgentSearchTool()
+	ast.deadlinesURL = url
+
+	deadlines, err := ast.fetchDeadlinesFromConferenceList(context.Background())
+	if err != nil {
+		t.Fatalf("解析截止日期失败: %v", err)
+	}
+	var got []string
+	for _, d := range deadlines {
+		got = append(got, d.VenueName+"/"+d.Type)
+	}
+	// 时间按会议时区换算为 UTC：ICLR(UTC-8) 早于同一时刻的 ACL(AoE)
+	want := "AAAI 2025/submission,ICLR 2026/submission,ACL 2026/abstract,ACL 2026/submission"
+	if strings.Join(got, ",") != want {
+		t.Errorf("截止日期 = %v，期望 %s", got, want)
+	}
+
+	// 导出时去掉已过期的截止日期，并按时间排序
+	data, err := ast.ExportDeadlines(context.Background())
+	if err != nil {
+		t.Fatalf("导出截止日期失败: %v", err)
+	}
+	var exported []DeadlineInfo
+	if err := json.Unmarshal([]byte(data), &exported); err != nil {
+		t.Fatalf("解析导出结果失败: %v", err)
+	}
+	if len(exported) != 3 || exported[0].VenueName != "ICLR 2026" || exported[0].Deadline > exported[2].Deadline {
+		t.Errorf("导出结果不符: %s", data)
+	}
+
+	suggestions, err := ast.GetSearchSuggestion(context.Background(), "language models")
+	if err != nil {
+		t.Fatalf("生成搜索建议失败: %v", err)
+	}
+	if !strings.Contains(strings.Join(suggestions, "\n"), "ACL 2026 abstract deadline is") {
+		t.Errorf("期望建议中包含截止日期提醒，实际: %v", suggestions)
+	}
+}
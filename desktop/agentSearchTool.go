@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"PaperHunter/desktop/memory"
+	"PaperHunter/pkg/httplimit"
 	"PaperHunter/pkg/logger"
 )
 
@@ -15,6 +17,10 @@ import (
 type AgentSearchTool struct {
 	client *http.Client
 	cache  map[string]*CacheEntry
+	memory *memory.Service // 用户画像来源，为 nil 时不做个性化建议
+
+	deadlinesURL       string // 会议截止日期接口，为空时不拉取
+	deadlinesCachePath string // 截止日期本地缓存文件，为空时只在内存中缓存
 }
 
 // CacheEntry 缓存条目
@@ -30,6 +36,7 @@ type SearchContext struct {
 	TrendingKeywords  []string       `json:"trending_keywords"`
 	CurrentSeason     string         `json:"current_season"`
 	UpcomingDeadlines []DeadlineInfo `json:"upcoming_deadlines"`
+	UserInterests     []string       `json:"user_interests,omitempty"` // 用户画像中的高频关键词
 }
 
 // VenueInfo 会议信息
@@ -56,7 +63,7 @@ type CategoryInfo struct {
 type DeadlineInfo struct {
 	VenueName string `json:"venue_name"`
 	Deadline  string `json:"deadline"`
-	Type      string `json:"type"` // "submission", "notification", "camera_ready"
+	Type      string `json:"type"` // "abstract", "submission", "notification", "camera_ready"
 }
 
 // EnhancedSearchQuery 增强的搜索查询
@@ -73,38 +80,49 @@ type EnhancedSearchQuery struct {
 // NewAgentSearchTool 创建 AgentSearchTool 实例
 func NewAgentSearchTool() *AgentSearchTool {
 	return &AgentSearchTool{
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		cache: make(map[string]*CacheEntry),
+		client:       httplimit.Client(10 * time.Second),
+		cache:        make(map[string]*CacheEntry),
+		deadlinesURL: aiDeadlinesURL,
 	}
 }
 
-// TODO：持续性缓存部分
-func (ast *AgentSearchTool) GetSearchContext(ctx context.Context) (*SearchContext, error) {
+// WithMemory 设置用户画像来源，用于生成结合历史兴趣的搜索建议
+func (ast *AgentSearchTool) WithMemory(mem *memory.Service) *AgentSearchTool {
+	ast.memory = mem
+	return ast
+}
 
+// GetSearchContext 获取搜索上下文。静态信息缓存 24 小时；截止日期单独缓存 6 小时并持久化到本地，
+// 每次调用时填入，获取失败时为空
+func (ast *AgentSearchTool) GetSearchContext(ctx context.Context) (*SearchContext, error) {
 	cacheKey := "search_context"
-	if entry, exists := ast.cache[cacheKey]; exists && entry.ExpiresAt.After(time.Now()) {
-		return entry.Data.(*SearchContext), nil
-	}
+	entry, exists := ast.cache[cacheKey]
+	if !exists || !entry.ExpiresAt.After(time.Now()) {
+		staticContext := &SearchContext{
+			AvailableVenues:  ast.getStaticVenueInfo(),
+			ArxivCategories:  ast.getStaticArxivCategories(),
+			TrendingKeywords: ast.getCurrentTrendingKeywords(),
+			CurrentSeason:    ast.getCurrentSeason(),
+		}
+		entry = &CacheEntry{
+			Data:      staticContext,
+			ExpiresAt: time.Now().Add(24 * time.Hour),
+		}
+		ast.cache[cacheKey] = entry
 
-	searchContext := &SearchContext{
-		AvailableVenues:  ast.getStaticVenueInfo(),
-		ArxivCategories:  ast.getStaticArxivCategories(),
-		TrendingKeywords: ast.getCurrentTrendingKeywords(),
-		CurrentSeason:    ast.getCurrentSeason(),
+		logger.Info("AgentSearchTool: 已构建搜索上下文，包含 %d 个会议和 %d 个分类",
+			len(staticContext.AvailableVenues), len(staticContext.ArxivCategories))
 	}
 
-	// TODO： 将缓存结果导出成本地 json 文件
-	ast.cache[cacheKey] = &CacheEntry{
-		Data:      searchContext,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+	// 截止日期的缓存周期与静态信息不同，填在副本上
+	searchContext := *entry.Data.(*SearchContext)
+	deadlines, err := ast.upcomingDeadlines(ctx)
+	if err != nil {
+		logger.Warn("获取会议截止日期失败: %v", err)
 	}
+	searchContext.UpcomingDeadlines = deadlines
 
-	logger.Info("AgentSearchTool: 已构建搜索上下文，包含 %d 个会议和 %d 个分类",
-		len(searchContext.AvailableVenues), len(searchContext.ArxivCategories))
-
-	return searchContext, nil
+	return &searchContext, nil
 }
 
 // TODO ：下面的静态信息都应该改为 agenticSearch 获取
@@ -523,14 +541,91 @@ func (ast *AgentSearchTool) buildArxivQuery(originalQuery string, categories []s
 	return strings.Join(queryParts, " AND ")
 }
 
-// （已删除置信度和搜索建议相关逻辑）
+// maxInterestSuggestions 个性化建议最多使用的画像关键词数
+const maxInterestSuggestions = 3
+
+// GetSearchSuggestion 根据查询分析结果生成搜索建议，并提醒近期的会议截止日期；加载到用户画像时，
+// 额外生成把历史兴趣关键词与当前查询扩展词组合的建议
+func (ast *AgentSearchTool) GetSearchSuggestion(ctx context.Context, userQuery string) ([]string, error) {
+	enhanced, err := ast.AnalyzeQuery(ctx, userQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []string
+	if enhanced.OpenReviewVenue != "" {
+		suggestions = append(suggestions, fmt.Sprintf("Search OpenReview venue '%s' for accepted papers", enhanced.OpenReviewVenue))
+	}
+	if len(enhanced.RecommendedCategories) > 0 {
+		suggestions = append(suggestions, fmt.Sprintf("Restrict arXiv search to categories %s", strings.Join(enhanced.RecommendedCategories, ", ")))
+	}
+	expanded := enhanced.ExpandedKeywords
+	if len(expanded) == 0 {
+		expanded = []string{userQuery}
+	}
+	for _, kw := range expanded {
+		if !strings.EqualFold(kw, userQuery) && strings.Contains(kw, " ") {
+			suggestions = append(suggestions, fmt.Sprintf("Try the related keyword '%s'", kw))
+		}
+	}
+
+	interests := ast.userInterests(userQuery)
+	for i, interest := range interests {
+		if i >= maxInterestSuggestions {
+			break
+		}
+		kw := expanded[i%len(expanded)]
+		suggestions = append(suggestions, fmt.Sprintf("Combine your interest in '%s' with '%s'", interest, kw))
+	}
+	if len(suggestions) == 0 {
+		suggestions = append(suggestions, fmt.Sprintf("Search title and abstract for '%s'", userQuery))
+	}
+	suggestions = append(suggestions, deadlineReminders(enhanced.Context.UpcomingDeadlines, time.Now().UTC())...)
+	return suggestions, nil
+}
+
+// userInterests 返回画像中的高频关键词，去掉过短的词和已在查询中出现的词；
+// 优先读取画像缓存，没有缓存时按近期事件临时构建
+func (ast *AgentSearchTool) userInterests(userQuery string) []string {
+	if ast.memory == nil {
+		return nil
+	}
+	profile, err := ast.memory.LoadProfileCache()
+	if err != nil || profile == nil {
+		events, err := ast.memory.LoadEvents(30)
+		if err != nil {
+			logger.Warn("读取用户记忆失败: %v", err)
+			return nil
+		}
+		profile = ast.memory.BuildProfile(events, 12, nil, "")
+	}
+	if profile == nil {
+		return nil
+	}
+
+	queryTokens := make(map[string]bool)
+	for _, token := range strings.Fields(strings.ToLower(userQuery)) {
+		queryTokens[token] = true
+	}
+	var interests []string
+	for _, kw := range profile.TopKeywords {
+		if len([]rune(kw)) < 3 || queryTokens[strings.ToLower(kw)] {
+			continue
+		}
+		interests = append(interests, kw)
+	}
+	return interests
+}
 
 // ExportSearchContext 导出搜索上下文为JSON（用于调试）
 func (ast *AgentSearchTool) ExportSearchContext(_ context.Context) (string, error) {
-	searchContext, err := ast.GetSearchContext(context.Background())
+	cached, err := ast.GetSearchContext(context.Background())
 	if err != nil {
 		return "", err
 	}
+	// 缓存的上下文与用户无关，画像关键词只加在导出的副本上
+	searchContext := *cached
+	searchContext.UserInterests = ast.userInterests("")
 
 	data, err := json.MarshalIndent(searchContext, "", "  ")
 	if err != nil {
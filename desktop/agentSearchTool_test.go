@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"PaperHunter/desktop/memory"
+)
+
+// newTestSearchTool 不访问截止日期接口的 AgentSearchTool
+func newTestSearchTool(t *testing.T) *AgentSearchTool {
+	t.Helper()
+	ast := NewAgentSearchTool()
+	ast.deadlinesURL = ""
+	return ast
+}
+
+func newMemoryWithKeywords(t *testing.T, keywords []string) *memory.Service {
+	t.Helper()
+	mem, err := memory.New(t.TempDir(), 30, 7)
+	if err != nil {
+		t.Fatalf("创建记忆服务失败: %v", err)
+	}
+	if err := mem.SaveProfileCache(&memory.ProfileCache{TopKeywords: keywords}); err != nil {
+		t.Fatalf("写入画像缓存失败: %v", err)
+	}
+	return mem
+}
+
+func TestGetSearchSuggestion_UsesProfileKeywords(t *testing.T) {
+	keywords := []string{"retrieval", "graph", "diffusion", "agents", "alignment"}
+	ast := newTestSearchTool(t).WithMemory(newMemoryWithKeywords(t, keywords))
+
+	suggestions, err := ast.GetSearchSuggestion(context.Background(), "language models")
+	if err != nil {
+		t.Fatalf("生成搜索建议失败: %v", err)
+	}
+
+	mentioned := false
+	for _, s := range suggestions {
+		for _, kw := range keywords {
+			if strings.Contains(s, "Combine your interest in '"+kw+"'") {
+				mentioned = true
+			}
+		}
+	}
+	if !mentioned {
+		t.Errorf("期望建议中包含画像关键词，实际: %v", suggestions)
+	}
+
+	data, err := ast.ExportSearchContext(context.Background())
+	if err != nil {
+		t.Fatalf("导出搜索上下文失败: %v", err)
+	}
+	if !strings.Contains(data, `"user_interests"`) || !strings.Contains(data, "retrieval") {
+		t.Errorf("期望搜索上下文包含用户兴趣，实际: %s", data)
+	}
+}
+
+func TestGetSearchSuggestion_WithoutProfile(t *testing.T) {
+	ast := newTestSearchTool(t)
+
+	suggestions, err := ast.GetSearchSuggestion(context.Background(), "language models")
+	if err != nil {
+		t.Fatalf("生成搜索建议失败: %v", err)
+	}
+	if len(suggestions) == 0 {
+		t.Fatal("期望返回通用建议")
+	}
+	for _, s := range suggestions {
+		if strings.HasPrefix(s, "Combine your interest") {
+			t.Errorf("未设置画像时不应生成个性化建议: %s", s)
+		}
+	}
+
+	// 查询中已有的画像关键词不重复建议
+	ast.WithMemory(newMemoryWithKeywords(t, []string{"language", "of"}))
+	suggestions, _ = ast.GetSearchSuggestion(context.Background(), "language models")
+	for _, s := range suggestions {
+		if strings.HasPrefix(s, "Combine your interest") {
+			t.Errorf("不应建议查询中已有或过短的关键词: %s", s)
+		}
+	}
+}
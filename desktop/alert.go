@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"PaperHunter/desktop/alerts"
+	"PaperHunter/desktop/memory"
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// alertCheckInterval 检查到期搜索提醒的间隔
+const alertCheckInterval = 10 * time.Minute
+
+// alertsPath 搜索提醒文件路径（与数据库同目录）
+func (a *App) alertsPath() string {
+	if a.config != nil && a.config.Database.Path != "" {
+		return filepath.Join(filepath.Dir(a.config.Database.Path), "alerts.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".quicksearch", "data", "alerts.json")
+}
+
+func (a *App) initAlerts() {
+	mem, err := memory.New("", 30, 7)
+	if err != nil {
+		logger.Warn("初始化用户记忆失败，搜索提醒不做去重: %v", err)
+	}
+	// 通过闭包读取 coreApp，修改设置重建核心模块后仍使用新实例
+	search := func(ctx context.Context, opts core.SearchOptions) ([]*models.SimilarPaper, error) {
+		if a.coreApp == nil {
+			return nil, fmt.Errorf("app not initialized")
+		}
+		return a.coreApp.Search(ctx, opts)
+	}
+	a.alerts, err = alerts.New(a.alertsPath(), search, mem)
+	if err != nil {
+		logger.Error("加载搜索提醒失败: %v", err)
+		return
+	}
+	if a.ctx != nil {
+		go a.watchAlerts(a.ctx)
+	}
+}
+
+// watchAlerts 定期运行到期的搜索提醒，直到 ctx 结束
+func (a *App) watchAlerts(ctx context.Context) {
+	ticker := time.NewTicker(alertCheckInterval)
+	defer ticker.Stop()
+	for {
+		a.RunDueAlerts()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDueAlerts 运行所有到期的搜索提醒，有新命中时通过 alert-hits 事件发送给前端
+func (a *App) RunDueAlerts() {
+	if a.alerts == nil {
+		return
+	}
+	for _, res := range a.alerts.RunDue(context.Background()) {
+		a.emitAlertHits(res)
+	}
+}
+
+func (a *App) emitAlertHits(res *alerts.Result) {
+	if a.ctx != nil && len(res.Papers) > 0 {
+		runtime.EventsEmit(a.ctx, "alert-hits", res)
+	}
+}
+
+// SaveAlert 新建或更新搜索提醒，返回保存后的提醒（JSON）
+func (a *App) SaveAlert(alert alerts.Alert) (string, error) {
+	if a.alerts == nil {
+		return "", fmt.Errorf("alerts not initialized")
+	}
+	saved, err := a.alerts.Save(alert)
+	if err != nil {
+		return "", err
+	}
+	logger.Info("已保存搜索提醒: %s (%s)", saved.ID, saved.Query)
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alert: %w", err)
+	}
+	return string(data), nil
+}
+
+// ListAlerts 列出所有搜索提醒（JSON）
+func (a *App) ListAlerts() (string, error) {
+	if a.alerts == nil {
+		return "", fmt.Errorf("alerts not initialized")
+	}
+	data, err := json.Marshal(a.alerts.List())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alerts: %w", err)
+	}
+	return string(data), nil
+}
+
+// DeleteAlert 删除搜索提醒
+func (a *App) DeleteAlert(id string) error {
+	if a.alerts == nil {
+		return fmt.Errorf("alerts not initialized")
+	}
+	return a.alerts.Delete(id)
+}
+
+// RunAlert 立即运行搜索提醒，返回新命中的论文（JSON），同时发送 alert-hits 事件
+func (a *App) RunAlert(id string) (string, error) {
+	if a.alerts == nil {
+		return "", fmt.Errorf("alerts not initialized")
+	}
+	res, err := a.alerts.Run(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	a.emitAlertHits(res)
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alert result: %w", err)
+	}
+	return string(data), nil
+}
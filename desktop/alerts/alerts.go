@@ -0,0 +1,305 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"PaperHunter/desktop/memory"
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+const (
+	// DefaultIntervalHours 未设置运行间隔时，每天运行一次
+	DefaultIntervalHours = 24
+	// DefaultWindowDays 未设置时间窗口时，检索最近 7 天的论文
+	DefaultWindowDays = 7
+	// DefaultTopK 每次运行最多检索的论文数
+	DefaultTopK = 50
+	// seenWindowDays 去重时回看的事件天数
+	seenWindowDays = 30
+	// eventTypePrefix 命中论文写入记忆事件时的类型前缀，后接提醒 ID
+	eventTypePrefix = "alert_hit:"
+)
+
+// Alert 保存的搜索提醒：按固定间隔重新执行搜索，只报告之前没有出现过的论文
+type Alert struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Query         string     `json:"query"`
+	Sources       []string   `json:"sources,omitempty"`
+	Categories    []string   `json:"categories,omitempty"` // 分类过滤（如 cs.LG），多个分类之间为 OR
+	Semantic      bool       `json:"semantic"`
+	WindowDays    int        `json:"window_days"`    // 只检索最近 N 天发布的论文
+	IntervalHours int        `json:"interval_hours"` // 运行间隔
+	TopK          int        `json:"top_k"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastRunAt     *time.Time `json:"last_run_at,omitempty"`
+}
+
+// Result 一次提醒运行的新命中
+type Result struct {
+	AlertID string                 `json:"alert_id"`
+	Name    string                 `json:"name"`
+	Papers  []*models.SimilarPaper `json:"papers"`
+}
+
+// SearchFunc 执行搜索，通常为 core.App.Search
+type SearchFunc func(ctx context.Context, opts core.SearchOptions) ([]*models.SimilarPaper, error)
+
+// Service 管理保存的搜索提醒，定义持久化到 JSON 文件，已报告过的论文记入用户记忆用于去重
+type Service struct {
+	path   string
+	search SearchFunc
+	memory *memory.Service
+
+	mu     sync.Mutex
+	alerts []*Alert
+}
+
+// New 创建提醒服务并加载 path 中已保存的提醒；mem 为 nil 时不做去重
+func New(path string, search SearchFunc, mem *memory.Service) (*Service, error) {
+	s := &Service{path: path, search: search, memory: mem}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.alerts); err != nil {
+		return nil, fmt.Errorf("解析搜索提醒失败: %w", err)
+	}
+	return s, nil
+}
+
+// Save 新建或更新提醒（按 ID），返回保存后的提醒
+func (s *Service) Save(alert Alert) (*Alert, error) {
+	if strings.TrimSpace(alert.Query) == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+	if alert.WindowDays < 0 || alert.IntervalHours < 0 || alert.TopK < 0 {
+		return nil, fmt.Errorf("window_days, interval_hours and top_k must not be negative")
+	}
+	if alert.WindowDays == 0 {
+		alert.WindowDays = DefaultWindowDays
+	}
+	if alert.IntervalHours == 0 {
+		alert.IntervalHours = DefaultIntervalHours
+	}
+	if alert.TopK == 0 {
+		alert.TopK = DefaultTopK
+	}
+	if alert.Name == "" {
+		alert.Name = alert.Query
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if alert.ID == "" {
+		alert.ID = fmt.Sprintf("alert_%d", time.Now().UnixNano())
+	}
+	saved := &alert
+	if existing := s.find(alert.ID); existing != nil {
+		// 更新定义时保留创建时间与运行记录
+		alert.CreatedAt, alert.LastRunAt = existing.CreatedAt, existing.LastRunAt
+		*existing = alert
+		saved = existing
+	} else {
+		if alert.CreatedAt.IsZero() {
+			alert.CreatedAt = time.Now()
+		}
+		s.alerts = append(s.alerts, saved)
+	}
+	if err := s.save(); err != nil {
+		return nil, fmt.Errorf("保存搜索提醒失败: %w", err)
+	}
+	out := *saved
+	return &out, nil
+}
+
+// List 返回所有提醒的副本
+func (s *Service) List() []Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(s.alerts))
+	for _, a := range s.alerts {
+		alerts = append(alerts, *a)
+	}
+	return alerts
+}
+
+// Delete 删除提醒
+func (s *Service) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, a := range s.alerts {
+		if a.ID == id {
+			s.alerts = append(s.alerts[:i], s.alerts[i+1:]...)
+			if err := s.save(); err != nil {
+				return fmt.Errorf("保存搜索提醒失败: %w", err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("alert not found: %s", id)
+}
+
+// Run 立即执行一次提醒，返回之前没有报告过的论文，并把它们记入用户记忆
+func (s *Service) Run(ctx context.Context, id string) (*Result, error) {
+	s.mu.Lock()
+	existing := s.find(id)
+	if existing == nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("alert not found: %s", id)
+	}
+	alert := *existing
+	s.mu.Unlock()
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -alert.WindowDays)
+	results, err := s.search(ctx, core.SearchOptions{
+		Query:     alert.Query,
+		Semantic:  alert.Semantic,
+		TopK:      alert.TopK,
+		Condition: models.SearchCondition{Sources: alert.Sources, DateFrom: &from},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("执行搜索提醒失败: %w", err)
+	}
+
+	seen, err := s.seenKeys(alert.ID)
+	if err != nil {
+		logger.Warn("读取提醒 %s 的历史命中失败: %v", alert.ID, err)
+	}
+	result := &Result{AlertID: alert.ID, Name: alert.Name, Papers: []*models.SimilarPaper{}}
+	var events []memory.Event
+	for _, r := range results {
+		if r == nil || !matchCategories(r.Paper.Categories, alert.Categories) {
+			continue
+		}
+		key := r.Paper.Source + ":" + r.Paper.SourceID
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result.Papers = append(result.Papers, r)
+		events = append(events, memory.Event{
+			Type:     eventTypePrefix + alert.ID,
+			Source:   r.Paper.Source,
+			SourceID: r.Paper.SourceID,
+			Title:    r.Paper.Title,
+		})
+	}
+	if s.memory != nil {
+		if err := s.memory.RecordRecommended(events); err != nil {
+			logger.Warn("记录提醒 %s 的命中失败: %v", alert.ID, err)
+		}
+	}
+
+	s.mu.Lock()
+	if a := s.find(alert.ID); a != nil {
+		a.LastRunAt = &now
+		if err := s.save(); err != nil {
+			logger.Warn("保存搜索提醒失败: %v", err)
+		}
+	}
+	s.mu.Unlock()
+
+	logger.Info("搜索提醒 %s 运行完成: 检索 %d 篇，新命中 %d 篇", alert.ID, len(results), len(result.Papers))
+	return result, nil
+}
+
+// RunDue 运行所有已到运行间隔的提醒，只返回有新命中的结果；单个提醒失败不影响其他提醒
+func (s *Service) RunDue(ctx context.Context) []*Result {
+	now := time.Now()
+	var due []string
+	s.mu.Lock()
+	for _, a := range s.alerts {
+		if a.LastRunAt == nil || now.Sub(*a.LastRunAt) >= time.Duration(a.IntervalHours)*time.Hour {
+			due = append(due, a.ID)
+		}
+	}
+	s.mu.Unlock()
+
+	var results []*Result
+	for _, id := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		res, err := s.Run(ctx, id)
+		if err != nil {
+			logger.Warn("搜索提醒 %s 运行失败: %v", id, err)
+			continue
+		}
+		if len(res.Papers) > 0 {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// seenKeys 该提醒之前报告过的论文 key（source:source_id）
+func (s *Service) seenKeys(id string) (map[string]struct{}, error) {
+	seen := make(map[string]struct{})
+	if s.memory == nil {
+		return seen, nil
+	}
+	events, err := s.memory.LoadEvents(seenWindowDays)
+	if err != nil {
+		return seen, err
+	}
+	for _, ev := range events {
+		if ev.Type == eventTypePrefix+id && ev.Source != "" && ev.SourceID != "" {
+			seen[ev.Source+":"+ev.SourceID] = struct{}{}
+		}
+	}
+	return seen, nil
+}
+
+// find 按 ID 查找提醒，调用方需持有锁
+func (s *Service) find(id string) *Alert {
+	for _, a := range s.alerts {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// save 将提醒写回磁盘，调用方需持有锁
+func (s *Service) save() error {
+	data, err := json.MarshalIndent(s.alerts, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// matchCategories 论文分类与过滤分类有交集时返回 true，未设置过滤时总是匹配
+func matchCategories(paperCats, want []string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, c := range paperCats {
+		for _, w := range want {
+			if strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(w)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package alerts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/desktop/memory"
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/models"
+)
+
+// fakeSearch 返回固定结果并记录搜索参数
+type fakeSearch struct {
+	results []*models.SimilarPaper
+	calls   []core.SearchOptions
+}
+
+func (f *fakeSearch) Search(_ context.Context, opts core.SearchOptions) ([]*models.SimilarPaper, error) {
+	f.calls = append(f.calls, opts)
+	return f.results, nil
+}
+
+func hit(sourceID string, categories ...string) *models.SimilarPaper {
+	return &models.SimilarPaper{Paper: models.Paper{Source: "arxiv", SourceID: sourceID, Title: "Paper " + sourceID, Categories: categories}}
+}
+
+func newTestService(t *testing.T, search *fakeSearch) (*Service, string) {
+	t.Helper()
+	dir := t.TempDir()
+	mem, err := memory.New(filepath.Join(dir, "memory"), 30, 7)
+	if err != nil {
+		t.Fatalf("创建记忆服务失败: %v", err)
+	}
+	path := filepath.Join(dir, "alerts.json")
+	s, err := New(path, search.Search, mem)
+	if err != nil {
+		t.Fatalf("创建提醒服务失败: %v", err)
+	}
+	return s, path
+}
+
+func TestSaveListDelete(t *testing.T) {
+	s, path := newTestService(t, &fakeSearch{})
+
+	if _, err := s.Save(Alert{}); err == nil {
+		t.Error("期望空查询保存失败")
+	}
+	saved, err := s.Save(Alert{Query: "transformer", Sources: []string{"arxiv"}})
+	if err != nil {
+		t.Fatalf("保存提醒失败: %v", err)
+	}
+	if saved.ID == "" || saved.WindowDays != DefaultWindowDays || saved.IntervalHours != DefaultIntervalHours || saved.Name != "transformer" {
+		t.Errorf("期望填充默认值，实际: %+v", saved)
+	}
+
+	// 按 ID 更新，保留创建时间
+	updated, err := s.Save(Alert{ID: saved.ID, Query: "transformer", WindowDays: 3})
+	if err != nil {
+		t.Fatalf("更新提醒失败: %v", err)
+	}
+	if updated.WindowDays != 3 || !updated.CreatedAt.Equal(saved.CreatedAt) {
+		t.Errorf("更新结果不符: %+v", updated)
+	}
+
+	reloaded, err := New(path, nil, nil)
+	if err != nil {
+		t.Fatalf("重新加载提醒失败: %v", err)
+	}
+	if list := reloaded.List(); len(list) != 1 || list[0].WindowDays != 3 {
+		t.Fatalf("期望持久化 1 个提醒，实际: %+v", list)
+	}
+
+	if err := s.Delete(saved.ID); err != nil {
+		t.Fatalf("删除提醒失败: %v", err)
+	}
+	if err := s.Delete(saved.ID); err == nil {
+		t.Error("期望删除不存在的提醒失败")
+	}
+	if len(s.List()) != 0 {
+		t.Errorf("期望删除后为空，实际: %+v", s.List())
+	}
+}
+
+func TestRun_ReportsOnlyNewHits(t *testing.T) {
+	search := &fakeSearch{results: []*models.SimilarPaper{hit("1", "cs.LG"), hit("2", "cs.CV"), hit("3", "cs.LG", "cs.AI")}}
+	s, _ := newTestService(t, search)
+	alert, err := s.Save(Alert{Query: "transformer", Categories: []string{"cs.LG"}, WindowDays: 7})
+	if err != nil {
+		t.Fatalf("保存提醒失败: %v", err)
+	}
+
+	res, err := s.Run(context.Background(), alert.ID)
+	if err != nil {
+		t.Fatalf("运行提醒失败: %v", err)
+	}
+	if len(res.Papers) != 2 || res.Papers[0].Paper.SourceID != "1" || res.Papers[1].Paper.SourceID != "3" {
+		t.Errorf("期望按分类过滤后命中 1 和 3，实际: %+v", res.Papers)
+	}
+	from := search.calls[0].Condition.DateFrom
+	if from == nil || time.Since(*from) < 7*24*time.Hour-time.Minute {
+		t.Errorf("期望检索最近 7 天，实际起始时间: %v", from)
+	}
+
+	// 第二次运行只报告新出现的论文
+	search.results = append(search.results, hit("4", "cs.LG"))
+	res, err = s.Run(context.Background(), alert.ID)
+	if err != nil {
+		t.Fatalf("运行提醒失败: %v", err)
+	}
+	if len(res.Papers) != 1 || res.Papers[0].Paper.SourceID != "4" {
+		t.Errorf("期望只命中新论文 4，实际: %+v", res.Papers)
+	}
+	if list := s.List(); list[0].LastRunAt == nil {
+		t.Error("期望记录运行时间")
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	search := &fakeSearch{results: []*models.SimilarPaper{hit("1")}}
+	s, _ := newTestService(t, search)
+	if _, err := s.Save(Alert{ID: "a", Query: "graph"}); err != nil {
+		t.Fatalf("保存提醒失败: %v", err)
+	}
+	if _, err := s.Save(Alert{ID: "b", Query: "diffusion"}); err != nil {
+		t.Fatalf("保存提醒失败: %v", err)
+	}
+
+	results := s.RunDue(context.Background())
+	if len(search.calls) != 2 || len(results) != 2 {
+		t.Fatalf("期望两个提醒都运行并有命中，实际运行 %d 次，结果 %d 个", len(search.calls), len(results))
+	}
+
+	// 未到运行间隔的提醒不再运行
+	if results := s.RunDue(context.Background()); len(search.calls) != 2 || len(results) != 0 {
+		t.Errorf("期望未到期的提醒不运行，实际运行 %d 次，结果 %d 个", len(search.calls), len(results))
+	}
+}
@@ -10,11 +10,20 @@ import (
 	"time"
 
 	"PaperHunter/config"
+	"PaperHunter/desktop/alerts"
+	"PaperHunter/desktop/memory"
 	"PaperHunter/internal/core"
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/crawl"
 	"PaperHunter/internal/hyde"
+	"PaperHunter/internal/ir"
+	"PaperHunter/internal/models"
 
 	"PaperHunter/internal/platform"
+	"PaperHunter/internal/platform/ssrn"
+	"PaperHunter/pkg/httplimit"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/metrics"
 
 	"github.com/cloudwego/eino/adk"
 )
@@ -24,10 +33,13 @@ type App struct {
 	coreApp      *core.App
 	logfile      string
 	config       *config.AppConfig
-	crawlService *CrawlService
+	crawlService *crawl.Service
 	agent        adk.Agent        // Agent 实例
 	searchTool   *AgentSearchTool // AgentSearchTool 实例
 	hydeSvc      hyde.Service     // HyDE 服务（用于生成虚拟论文）
+	scheduler    *CrawlScheduler  // 定时爬取调度器
+	alerts       *alerts.Service  // 保存的搜索提醒
+	metricsSrv   *metrics.Server  // Prometheus 指标服务，metrics.enabled 为 false 时为 nil
 }
 
 func NewApp() *App {
@@ -43,6 +55,31 @@ func (a *App) startup(ctx context.Context) {
 	a.initHyDE()
 	a.initSearchTool()
 	a.initAgent()
+	a.initScheduler()
+	a.initAlerts()
+	a.initMetrics()
+
+	a.initSavedSearches()
+}
+
+func (a *App) shutdown(ctx context.Context) {
+	if a.scheduler != nil {
+		a.scheduler.Stop()
+	}
+	if a.metricsSrv != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := a.metricsSrv.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("关闭 metrics 服务失败: %v", err)
+		}
+		cancel()
+	}
+	// 关闭时写入 IR 索引的增量更新
+	if a.coreApp != nil {
+		if err := a.coreApp.Close(); err != nil {
+			logger.Warn("关闭核心模块失败: %v", err)
+		}
+	}
+	logger.Info("桌面应用退出")
 }
 
 func (a *App) initHyDE() {
@@ -61,6 +98,31 @@ func (a *App) initHyDE() {
 	logger.Info("HyDE 服务初始化成功")
 }
 
+// initMetrics 按 metrics 配置启动 /metrics 服务，论文数量始终读取当前的核心模块（配置重载后同样有效）
+func (a *App) initMetrics() {
+	if a.config == nil || !a.config.Metrics.Enabled {
+		return
+	}
+	srv := metrics.NewServer(a.config.Metrics.Port, appPaperCounter{a})
+	if err := srv.Start(); err != nil {
+		logger.Error("metrics 服务启动失败: %v", err)
+		return
+	}
+	a.metricsSrv = srv
+}
+
+// appPaperCounter 把论文统计转发给当前的核心模块
+type appPaperCounter struct {
+	app *App
+}
+
+func (c appPaperCounter) CountPapers(ctx context.Context, conditions []string, params []interface{}) (int, error) {
+	if c.app.coreApp == nil {
+		return 0, fmt.Errorf("app not initialized")
+	}
+	return c.app.coreApp.CountPapers(ctx, conditions, params)
+}
+
 func (a *App) initConfig() {
 	homeDir, _ := os.UserHomeDir()
 	configFilePath := filepath.Join(homeDir, ".quicksearch", "config", "config.yaml")
@@ -115,6 +177,8 @@ func (a *App) initCoreApp() {
 
 	cfg := a.config
 
+	httplimit.SetMaxConcurrent(cfg.HTTP.MaxConcurrent)
+
 	var err error
 	a.coreApp, err = core.NewApp(cfg.Database.Path, cfg.Embedder,
 		map[string]platform.Config{
@@ -122,24 +186,60 @@ func (a *App) initCoreApp() {
 			"openreview": &cfg.OpenReview,
 			"acl":        &cfg.ACL,
 			"ssrn":       &cfg.SSRN,
-		}, cfg.Zotero, cfg.FeiShu)
+			"dblp":       &cfg.DBLP,
+		}, cfg.Zotero, cfg.FeiShu, cfg.Notion)
 
 	if err != nil {
 		logger.Error("初始化核心模块失败: %v", err)
 	} else {
 		logger.Info("核心模块启动成功")
+		if cfg.Database.MergeCrossSource {
+			a.coreApp.EnableCrossSourceMerge()
+		}
+		a.coreApp.EnableCrossRef(cfg.CrossRef)
+		a.coreApp.EnableUnpaywall(cfg.Unpaywall)
+		a.coreApp.EnableLastCrawlTracking(core.DefaultStatusDir())
+		if err := a.coreApp.ConfigureTokenizer(cfg.IR.Tokenizer()); err != nil {
+			logger.Warn("IR 分词配置无效，使用默认英文分词: %v", err)
+		}
+		if cfg.IR.PersistIndex {
+			a.coreApp.EnableIRIndexPersistence(ir.DefaultIndexPath())
+		}
+		a.initTranslator(cfg)
+
+		if a.crawlService == nil {
+			a.crawlService = NewCrawlService(a)
+		}
+		if err := a.crawlService.MigrateHistoryFile(); err != nil {
+			logger.Warn("导入旧版爬取历史失败: %v", err)
+		}
 	}
 }
 
 func (a *App) initSearchTool() {
 	a.searchTool = NewAgentSearchTool()
 	if a.searchTool != nil {
+		a.searchTool.WithDeadlinesCache(a.deadlinesCachePath())
+		if mem, err := memory.New("", 30, 7); err == nil {
+			a.searchTool.WithMemory(mem)
+		} else {
+			logger.Warn("初始化用户记忆失败，搜索建议不做个性化: %v", err)
+		}
 		logger.Info("AgentSearchTool 初始化成功")
 	} else {
 		logger.Error("AgentSearchTool 初始化失败")
 	}
 }
 
+// deadlinesCachePath 会议截止日期缓存文件路径（与数据库同目录）
+func (a *App) deadlinesCachePath() string {
+	if a.config != nil && a.config.Database.Path != "" {
+		return filepath.Join(filepath.Dir(a.config.Database.Path), "deadlines_cache.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".quicksearch", "data", "deadlines_cache.json")
+}
+
 func (a *App) initAgent() {
 	if a.coreApp == nil {
 		logger.Warn("核心模块未初始化，跳过 agent 初始化")
@@ -169,7 +269,8 @@ func (a *App) CrawlPapers(platform string, params map[string]interface{}) (strin
 		a.crawlService = NewCrawlService(a)
 	}
 
-	taskID, err := a.crawlService.StartCrawl(platform, params)
+	dryRun, _ := params["dryRun"].(bool)
+	taskID, err := a.crawlService.StartCrawl(platform, params, dryRun)
 	if err != nil {
 		return "", fmt.Errorf("failed to start crawl task: %w", err)
 	}
@@ -178,6 +279,23 @@ func (a *App) CrawlPapers(platform string, params map[string]interface{}) (strin
 	return taskID, nil
 }
 
+// PreviewCrawl 按爬取参数检索但不入库，返回 {total, sample} JSON，sample 为前 5 篇论文
+func (a *App) PreviewCrawl(platform string, params map[string]interface{}) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	query := a.coreApp.BuildQuery(platform, params)
+	res, err := a.coreApp.DryRunCrawl(context.Background(), platform, query)
+	if err != nil {
+		return "", fmt.Errorf("failed to preview crawl: %w", err)
+	}
+	data, err := json.Marshal(crawl.NewPreview(res))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal preview: %w", err)
+	}
+	return string(data), nil
+}
+
 func (a *App) GetCrawlTask(taskID string) (string, error) {
 	if a.crawlService == nil {
 		return "", fmt.Errorf("crawl service not initialized")
@@ -196,6 +314,14 @@ func (a *App) GetCrawlTask(taskID string) (string, error) {
 	return string(data), nil
 }
 
+// CancelCrawlTask 取消进行中的爬取任务
+func (a *App) CancelCrawlTask(taskID string) error {
+	if a.crawlService == nil {
+		return fmt.Errorf("crawl service not initialized")
+	}
+	return a.crawlService.CancelCrawl(taskID)
+}
+
 func (a *App) GetCrawlTaskLogs(taskID string) (string, error) {
 	if a.crawlService == nil {
 		return "", fmt.Errorf("crawl service not initialized")
@@ -221,63 +347,138 @@ func (a *App) ExportSelection(format string, source string, ids []string, output
 	if len(ids) == 0 {
 		return "", fmt.Errorf("no papers selected")
 	}
+	conditions, params := selectionConditions(source, ids)
 
-	var conditions []string
-	var params []interface{}
-
-	if source != "" {
-		// 生成条件: source = ? AND source_id IN (?,...,?)
-		placeholders := make([]string, 0, len(ids))
-		for range ids {
-			placeholders = append(placeholders, "?")
-		}
-		params = append(params, source)
-		for _, id := range ids {
-			params = append(params, id)
-		}
-		conditions = []string{"source = ?", fmt.Sprintf("source_id IN (%s)", strings.Join(placeholders, ","))}
-	} else {
-		// source 为空时，只使用 source_id 条件（但这种情况不应该发生）
-		placeholders := make([]string, 0, len(ids))
-		for range ids {
-			placeholders = append(placeholders, "?")
+	ctx := context.Background()
+	format = strings.ToLower(format)
+	if f, ok := exporter.Lookup(format); ok && f.NeedsOutput {
+		if output == "" {
+			now := time.Now().Format("20060102_150405")
+			output = exportOutputName("selection_"+now, f)
 		}
-		for _, id := range ids {
-			params = append(params, id)
+		return output, a.coreApp.ExportPapers(ctx, format, output, conditions, params, 0)
+	}
+	switch format {
+	case "zotero":
+		return "", a.coreApp.ExportToZotero(ctx, collection, conditions, params, 0)
+	case "notion":
+		return "", a.coreApp.ExportToNotion(ctx, "", conditions, params, 0)
+	case "feishu":
+		name := feishuName
+		if name == "" {
+			name = "Papers"
 		}
-		conditions = []string{fmt.Sprintf("source_id IN (%s)", strings.Join(placeholders, ","))}
+		return a.exportToFeishu(ctx, name, core.FeiShuTable{}, conditions, params, 0)
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// ExportGroup 前端传入的推荐分组，用于按推荐结果的结构导出
+type ExportGroup struct {
+	SeedTitle string            `json:"seed_title"`
+	Papers    []ExportGroupItem `json:"papers"`
+}
+
+// ExportGroupItem 分组内的单篇论文引用及其相似度
+type ExportGroupItem struct {
+	Source     string  `json:"source"`
+	ID         string  `json:"id"`
+	Similarity float32 `json:"similarity"`
+}
+
+// ExportSelectionByPapers 按论文列表导出，支持多 source（通过传入完整的 source+id 对）
+// groups 可选：传入时 csv/json 会保留分组顺序并附带种子论文标题与相似度
+func (a *App) ExportSelectionByPapers(format string, paperPairs []map[string]string, output string, feishuName string, collection string, groups []ExportGroup) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	conditions, params, err := paperPairConditions(paperPairs, groups)
+	if err != nil {
+		return "", err
 	}
 
 	ctx := context.Background()
-	switch strings.ToLower(format) {
-	case "csv", "json":
+	format = strings.ToLower(format)
+	if f, ok := exporter.Lookup(format); ok && f.NeedsOutput {
 		if output == "" {
 			now := time.Now().Format("20060102_150405")
-			output = fmt.Sprintf("selection_%s.%s", now, format)
+			output = exportOutputName("selection_"+now, f)
+		}
+		// 只有支持分组的格式保留分组与相似度字段，其余格式按普通列表导出
+		if len(groups) > 0 && f.Grouped {
+			return output, a.exportGroups(ctx, format, output, groups)
 		}
 		return output, a.coreApp.ExportPapers(ctx, format, output, conditions, params, 0)
+	}
+	switch format {
 	case "zotero":
 		return "", a.coreApp.ExportToZotero(ctx, collection, conditions, params, 0)
+	case "notion":
+		return "", a.coreApp.ExportToNotion(ctx, "", conditions, params, 0)
 	case "feishu":
 		name := feishuName
 		if name == "" {
 			name = "Papers"
 		}
-		url, err := a.coreApp.ExportToFeiShuBitableWithURL(ctx, name, name, conditions, params, 0)
-		return url, err
+		return a.exportToFeishu(ctx, name, core.FeiShuTable{}, conditions, params, 0)
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
-// ExportSelectionByPapers 按论文列表导出，支持多 source（通过传入完整的 source+id 对）
-func (a *App) ExportSelectionByPapers(format string, paperPairs []map[string]string, output string, feishuName string, collection string) (string, error) {
+// exportPreviewSampleSize 导出预览返回的样例论文数
+const exportPreviewSampleSize = 5
+
+// PreviewExportSelection 预览 ExportSelection 将导出的论文：返回匹配数量与前几篇样例（JSON），不执行导出
+func (a *App) PreviewExportSelection(source string, ids []string) (string, error) {
 	if a.coreApp == nil {
 		return "", fmt.Errorf("core app not initialized")
 	}
-	if len(paperPairs) == 0 {
+	if len(ids) == 0 {
 		return "", fmt.Errorf("no papers selected")
 	}
+	conditions, params := selectionConditions(source, ids)
+	return a.previewExport(conditions, params)
+}
+
+// PreviewExportSelectionByPapers 预览 ExportSelectionByPapers 将导出的论文，参数含义与其相同
+func (a *App) PreviewExportSelectionByPapers(paperPairs []map[string]string, groups []ExportGroup) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	conditions, params, err := paperPairConditions(paperPairs, groups)
+	if err != nil {
+		return "", err
+	}
+	return a.previewExport(conditions, params)
+}
+
+func (a *App) previewExport(conditions []string, params []interface{}) (string, error) {
+	preview, err := a.coreApp.PreviewExport(context.Background(), conditions, params, 0, exportPreviewSampleSize)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(preview)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal preview: %w", err)
+	}
+	return string(data), nil
+}
+
+// paperPairConditions 由 source+id 对生成查询条件；paperPairs 为空时从 groups 中展开
+func paperPairConditions(paperPairs []map[string]string, groups []ExportGroup) ([]string, []interface{}, error) {
+	if len(paperPairs) == 0 {
+		// 仅传入分组时，从分组中展开 source+id 对
+		for _, g := range groups {
+			for _, item := range g.Papers {
+				paperPairs = append(paperPairs, map[string]string{"source": item.Source, "id": item.ID})
+			}
+		}
+	}
+	if len(paperPairs) == 0 {
+		return nil, nil, fmt.Errorf("no papers selected")
+	}
 
 	// 按 source 分组
 	sourceGroups := make(map[string][]string)
@@ -307,31 +508,55 @@ func (a *App) ExportSelectionByPapers(format string, paperPairs []map[string]str
 	}
 
 	if len(conditionParts) == 0 {
-		return "", fmt.Errorf("no valid papers selected")
+		return nil, nil, fmt.Errorf("no valid papers selected")
 	}
 
-	conditions := []string{fmt.Sprintf("(%s)", strings.Join(conditionParts, " OR "))}
+	return []string{fmt.Sprintf("(%s)", strings.Join(conditionParts, " OR "))}, params, nil
+}
 
-	ctx := context.Background()
-	switch strings.ToLower(format) {
-	case "csv", "json":
-		if output == "" {
-			now := time.Now().Format("20060102_150405")
-			output = fmt.Sprintf("selection_%s.%s", now, format)
+// exportOutputName 由 name 生成默认输出路径：文件类格式追加扩展名，obsidian 导出为同名目录
+func exportOutputName(name string, f exporter.Format) string {
+	if f.Ext == "" {
+		return name
+	}
+	return name + "." + f.Ext
+}
+
+// exportGroups 按分组回查论文完整记录后导出，库中已不存在的论文会被跳过
+func (a *App) exportGroups(ctx context.Context, format string, output string, groups []ExportGroup) error {
+	pairs := make(map[string][]string)
+	for _, g := range groups {
+		for _, item := range g.Papers {
+			if item.Source == "" || item.ID == "" {
+				continue
+			}
+			pairs[item.Source] = append(pairs[item.Source], item.ID)
 		}
-		return output, a.coreApp.ExportPapers(ctx, format, output, conditions, params, 0)
-	case "zotero":
-		return "", a.coreApp.ExportToZotero(ctx, collection, conditions, params, 0)
-	case "feishu":
-		name := feishuName
-		if name == "" {
-			name = "Papers"
+	}
+
+	papers, err := a.coreApp.GetPapersByPairs(ctx, pairs)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*models.Paper, len(papers))
+	for _, p := range papers {
+		byKey[p.Source+"|"+p.SourceID] = p
+	}
+
+	exportGroups := make([]exporter.PaperGroup, 0, len(groups))
+	for _, g := range groups {
+		pg := exporter.PaperGroup{SeedTitle: g.SeedTitle}
+		for _, item := range g.Papers {
+			p, ok := byKey[item.Source+"|"+item.ID]
+			if !ok {
+				continue
+			}
+			pg.Papers = append(pg.Papers, &models.SimilarPaper{Paper: *p, Similarity: item.Similarity})
 		}
-		url, err := a.coreApp.ExportToFeiShuBitableWithURL(ctx, name, name, conditions, params, 0)
-		return url, err
-	default:
-		return "", fmt.Errorf("unsupported format: %s", format)
+		exportGroups = append(exportGroups, pg)
 	}
+
+	return a.coreApp.ExportPaperGroups(ctx, format, output, exportGroups)
 }
 
 // ExportCrawlTask 按某次爬取任务的入库结果一键导出
@@ -340,17 +565,17 @@ func (a *App) ExportCrawlTask(taskID string, format string, output string, feish
 		return "", fmt.Errorf("crawl service not initialized")
 	}
 	task, err := a.crawlService.GetTask(taskID)
-	if err != nil {
-		// 尝试从持久化文件加载
-		if t, perr := a.crawlService.loadPersistedTask(taskID); perr == nil {
-			task = &CrawlTask{
-				ID:       t.TaskID,
-				Platform: t.Platform,
-				Inserted: t.Inserted,
-			}
-		} else {
-			return "", err
+	if err == nil {
+		task = task.Snapshot()
+	} else if t, perr := a.crawlService.LoadPersistedTask(taskID); perr == nil {
+		// 内存中没有时从持久化文件加载
+		task = &crawl.Task{
+			ID:       t.TaskID,
+			Platform: t.Platform,
+			Inserted: t.Inserted,
 		}
+	} else {
+		return "", err
 	}
 	if len(task.Inserted) == 0 {
 		return "", fmt.Errorf("no papers recorded for task: %s", taskID)
@@ -371,18 +596,25 @@ func (a *App) ExportCrawlTask(taskID string, format string, output string, feish
 		return "", fmt.Errorf("no valid papers recorded for task: %s", taskID)
 	}
 
-	// csv/json 默认输出文件
-	if (format == "csv" || format == "json") && strings.TrimSpace(output) == "" {
+	f, ok := exporter.Lookup(format)
+	if !ok {
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+	// 文件类格式默认输出文件
+	if f.NeedsOutput && strings.TrimSpace(output) == "" {
 		now := time.Now().Format("20060102_150405")
-		output = fmt.Sprintf("%s_%s.%s", taskID, now, format)
+		output = exportOutputName(taskID+"_"+now, f)
 	}
+	return a.ExportSelectionByPapers(f.Name, pairs, output, feishuName, collection, nil)
+}
 
-	switch format {
-	case "csv", "json", "feishu", "zotero":
-		return a.ExportSelectionByPapers(format, pairs, output, feishuName, collection)
-	default:
-		return "", fmt.Errorf("unsupported format: %s", format)
+// GetAvailablePlatforms 返回已注册的平台名 JSON 数组（按字母排序）
+func (a *App) GetAvailablePlatforms() (string, error) {
+	data, err := json.Marshal(core.ListPlatforms())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal platforms: %w", err)
 	}
+	return string(data), nil
 }
 
 // GetCrawlHistory 获取爬取历史（limit=0 返回全部，按时间逆序）
@@ -390,7 +622,7 @@ func (a *App) GetCrawlHistory(limit int) (string, error) {
 	if a.crawlService == nil {
 		a.crawlService = NewCrawlService(a)
 	}
-	history, err := a.crawlService.loadHistory(limit)
+	history, err := a.crawlService.LoadHistory(limit)
 	if err != nil {
 		return "", err
 	}
@@ -406,7 +638,23 @@ func (a *App) ClearCrawlHistory() error {
 	if a.crawlService == nil {
 		a.crawlService = NewCrawlService(a)
 	}
-	return a.crawlService.clearHistory()
+	return a.crawlService.PurgeHistory(0)
+}
+
+// PurgeCrawlHistory 删除 olderThanDays 天前开始的爬取历史及其任务文件，0 表示全部删除
+func (a *App) PurgeCrawlHistory(olderThanDays int) error {
+	if a.crawlService == nil {
+		a.crawlService = NewCrawlService(a)
+	}
+	return a.crawlService.PurgeHistory(olderThanDays)
+}
+
+// ResetSSRNCheckpoint 清除 SSRN 增量爬取断点，下次爬取回到全量
+func (a *App) ResetSSRNCheckpoint() error {
+	if a.config == nil {
+		return fmt.Errorf("config not initialized")
+	}
+	return ssrn.ResetCheckpoints(&a.config.SSRN)
 }
 
 // GetCrawlTaskPapers 返回某次爬取任务入库的论文列表（JSON）
@@ -415,17 +663,17 @@ func (a *App) GetCrawlTaskPapers(taskID string) (string, error) {
 		return "", fmt.Errorf("crawl service not initialized")
 	}
 	task, err := a.crawlService.GetTask(taskID)
-	if err != nil {
-		// 尝试从持久化文件加载
-		if t, perr := a.crawlService.loadPersistedTask(taskID); perr == nil {
-			task = &CrawlTask{
-				ID:       t.TaskID,
-				Platform: t.Platform,
-				Inserted: t.Inserted,
-			}
-		} else {
-			return "", err
+	if err == nil {
+		task = task.Snapshot()
+	} else if t, perr := a.crawlService.LoadPersistedTask(taskID); perr == nil {
+		// 内存中没有时从持久化文件加载
+		task = &crawl.Task{
+			ID:       t.TaskID,
+			Platform: t.Platform,
+			Inserted: t.Inserted,
 		}
+	} else {
+		return "", err
 	}
 	if len(task.Inserted) == 0 {
 		return "[]", nil
@@ -492,3 +740,36 @@ func (a *App) GetSearchContext() (string, error) {
 
 	return context, nil
 }
+
+// GetConferenceDeadlines 获取尚未到期的会议截止日期，返回按截止时间排序的 JSON 数组
+func (a *App) GetConferenceDeadlines() (string, error) {
+	if a.searchTool == nil {
+		return "", fmt.Errorf("AgentSearchTool not initialized")
+	}
+
+	deadlines, err := a.searchTool.ExportDeadlines(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("failed to get deadlines: %w", err)
+	}
+	return deadlines, nil
+}
+
+// GetPersonalizedSuggestions 结合用户画像中的历史兴趣生成搜索建议，返回 JSON 字符串数组
+func (a *App) GetPersonalizedSuggestions(query string) (string, error) {
+	if a.searchTool == nil {
+		return "", fmt.Errorf("AgentSearchTool not initialized")
+	}
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("query is required")
+	}
+
+	suggestions, err := a.searchTool.GetSearchSuggestion(context.Background(), query)
+	if err != nil {
+		return "", fmt.Errorf("failed to get suggestions: %w", err)
+	}
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal suggestions: %w", err)
+	}
+	return string(data), nil
+}
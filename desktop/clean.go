@@ -2,8 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
+
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/platform/acl"
 )
 
 
@@ -95,3 +100,86 @@ func (a *App) CleanWithOptions(opts CleanOptions) (CleanResult, error) {
 	return res, nil
 }
 
+
+// selectionConditions 按 source + source_id 列表生成查询条件
+func selectionConditions(source string, ids []string) ([]string, []interface{}) {
+	placeholders := make([]string, 0, len(ids))
+	params := make([]interface{}, 0, len(ids)+1)
+	var conditions []string
+	if source != "" {
+		conditions = append(conditions, "source = ?")
+		params = append(params, source)
+	}
+	for _, id := range ids {
+		placeholders = append(placeholders, "?")
+		params = append(params, id)
+	}
+	conditions = append(conditions, fmt.Sprintf("source_id IN (%s)", strings.Join(placeholders, ",")))
+	return conditions, params
+}
+
+// DeletePapers 软删除选中的论文，可通过 UndeleteSelected 恢复
+func (a *App) DeletePapers(source string, ids []string) (int, error) {
+	if a.coreApp == nil {
+		return 0, fmt.Errorf("app not initialized")
+	}
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("no papers selected")
+	}
+
+	conditions, params := selectionConditions(source, ids)
+	return a.coreApp.DeletePapers(context.Background(), conditions, params)
+}
+
+// UndeleteSelected 恢复选中的已删除论文
+func (a *App) UndeleteSelected(source string, ids []string) (int, error) {
+	if a.coreApp == nil {
+		return 0, fmt.Errorf("app not initialized")
+	}
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("no papers selected")
+	}
+
+	conditions, params := selectionConditions(source, ids)
+	return a.coreApp.UndeletePapers(context.Background(), conditions, params)
+}
+
+// PurgeDeleted 永久清除删除超过 olderThanDays 天的论文，0 表示清除全部已删除论文
+func (a *App) PurgeDeleted(olderThanDays int) (int, error) {
+	if a.coreApp == nil {
+		return 0, fmt.Errorf("app not initialized")
+	}
+	if olderThanDays < 0 {
+		return 0, fmt.Errorf("olderThanDays must not be negative")
+	}
+
+	return a.coreApp.PurgeDeleted(context.Background(), time.Duration(olderThanDays)*24*time.Hour)
+}
+
+// NormalizeACLIds 将 ACL 论文的 SourceID 重新计算为稳定的标题哈希，并合并因此重复的论文
+// dryRun 为 true 时只返回预览，不修改数据库
+func (a *App) NormalizeACLIds(dryRun bool) (*core.NormalizeReport, error) {
+	if a.coreApp == nil {
+		return nil, fmt.Errorf("app not initialized")
+	}
+	return a.coreApp.NormalizeSourceIDs(context.Background(), "acl", acl.StableSourceID, dryRun)
+}
+
+// DeduplicateDatabase 按 mode（title / embedding）合并库中的重复论文，重复的论文被软删除
+// threshold <= 0 时使用默认阈值，返回 JSON 格式的 core.DedupReport
+func (a *App) DeduplicateDatabase(mode string, threshold float64) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+
+	report, err := a.coreApp.DeduplicateDatabase(context.Background(), mode, threshold)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
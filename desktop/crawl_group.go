@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"PaperHunter/internal/crawl"
+	"PaperHunter/pkg/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// startMultiPlatformCrawl 为每个平台创建一个任务并发执行（最多 crawl.MaxConcurrentCrawls 个同时运行），返回任务 ID；
+// 全部结束后通过 crawl-group-complete 事件发送汇总
+func (a *App) startMultiPlatformCrawl(platforms []string, params map[string]map[string]interface{}) ([]string, error) {
+	group, err := a.crawlService.StartTaskGroup(platforms, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 单个任务失败不影响其他任务
+	go func() {
+		tasks, err := group.WaitAll(context.Background())
+		if err != nil {
+			logger.Warn("等待爬取任务组失败: %v", err)
+			return
+		}
+		summary := crawl.Summarize(group.TaskIDs, tasks)
+		logger.Info("多平台爬取结束: %d 个任务，共获取 %d 篇论文", len(summary.TaskIDs), summary.TotalCount)
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "crawl-group-complete", summary)
+		}
+	}()
+
+	return group.TaskIDs, nil
+}
+
+// CrawlMultiplePlatforms 同时爬取多个平台，paramsJSON 为 {"平台名": {参数}, ...}，参数与 StartCrawl 相同；
+// 返回任务 ID 数组的 JSON，全部结束后发送 crawl-group-complete 事件
+func (a *App) CrawlMultiplePlatforms(paramsJSON string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	if a.crawlService == nil {
+		a.crawlService = NewCrawlService(a)
+	}
+
+	var params map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
+		return "", fmt.Errorf("invalid params: %w", err)
+	}
+	platforms := make([]string, 0, len(params))
+	for name := range params {
+		platforms = append(platforms, name)
+	}
+	sort.Strings(platforms)
+
+	taskIDs, err := a.startMultiPlatformCrawl(platforms, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to start crawl tasks: %w", err)
+	}
+	logger.Info("Started %d crawl tasks for platforms: %v", len(taskIDs), platforms)
+	data, err := json.Marshal(taskIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal task ids: %w", err)
+	}
+	return string(data), nil
+}
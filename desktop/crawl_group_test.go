@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/crawl"
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+// countingPlatform 记录同时处于 Search 中的任务数，阻塞到 release 关闭
+type countingPlatform struct{}
+
+var counting struct {
+	mu        sync.Mutex
+	active    int
+	maxActive int
+	release   chan struct{}
+}
+
+func (countingPlatform) Name() string               { return "stub-counting" }
+func (countingPlatform) GetConfig() platform.Config { return blockingConfig{} }
+func (countingPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	counting.mu.Lock()
+	counting.active++
+	if counting.active > counting.maxActive {
+		counting.maxActive = counting.active
+	}
+	release := counting.release
+	counting.mu.Unlock()
+
+	defer func() {
+		counting.mu.Lock()
+		counting.active--
+		counting.mu.Unlock()
+	}()
+
+	select {
+	case <-release:
+		return platform.Result{Papers: []*models.Paper{}}, nil
+	case <-ctx.Done():
+		return platform.Result{}, ctx.Err()
+	}
+}
+
+// papersPlatform 立即返回 q.Limit 篇论文
+type papersPlatform struct{}
+
+func (papersPlatform) Name() string               { return "stub-papers" }
+func (papersPlatform) GetConfig() platform.Config { return blockingConfig{} }
+func (papersPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	papers := make([]*models.Paper, q.Limit)
+	for i := range papers {
+		papers[i] = &models.Paper{
+			Source:   "stub-papers",
+			SourceID: fmt.Sprintf("stub-%d", i),
+			URL:      fmt.Sprintf("https://example.com/stub-%d", i),
+			Title:    fmt.Sprintf("Paper %d", i),
+		}
+	}
+	return platform.Result{Total: len(papers), Papers: papers}, nil
+}
+
+const countingPlatforms = 6
+
+func init() {
+	core.MustRegister(core.Provider{
+		Name:          "stub-papers",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return papersPlatform{}, nil },
+		DefaultConfig: func() platform.Config { return blockingConfig{} },
+	})
+	for i := 0; i < countingPlatforms; i++ {
+		core.MustRegister(core.Provider{
+			Name:          fmt.Sprintf("stub-counting-%d", i),
+			New:           func(cfg platform.Config) (platform.Platform, error) { return countingPlatform{}, nil },
+			DefaultConfig: func() platform.Config { return blockingConfig{} },
+		})
+	}
+}
+
+func activeSearches() (active, maxActive int) {
+	counting.mu.Lock()
+	defer counting.mu.Unlock()
+	return counting.active, counting.maxActive
+}
+
+func TestStartMultiPlatformCrawl_BoundedConcurrency(t *testing.T) {
+	app := newTestApp(t)
+	counting.mu.Lock()
+	counting.active, counting.maxActive = 0, 0
+	counting.release = make(chan struct{})
+	counting.mu.Unlock()
+
+	platforms := make([]string, 0, countingPlatforms)
+	for i := 0; i < countingPlatforms; i++ {
+		platforms = append(platforms, fmt.Sprintf("stub-counting-%d", i))
+	}
+	group, err := app.crawlService.StartTaskGroup(platforms, nil)
+	if err != nil {
+		t.Fatalf("启动任务组失败: %v", err)
+	}
+
+	// 等到信号量占满，再确认没有第 5 个任务进入
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if active, _ := activeSearches(); active == crawl.MaxConcurrentCrawls {
+			break
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	if active, maxActive := activeSearches(); active != crawl.MaxConcurrentCrawls || maxActive != crawl.MaxConcurrentCrawls {
+		t.Fatalf("期望同时运行 %d 个任务，实际 active=%d max=%d", crawl.MaxConcurrentCrawls, active, maxActive)
+	}
+	pending := 0
+	for _, id := range group.TaskIDs {
+		task, _ := app.crawlService.GetTask(id)
+		if task.Snapshot().Status == "pending" {
+			pending++
+		}
+	}
+	if pending != countingPlatforms-crawl.MaxConcurrentCrawls {
+		t.Errorf("期望 %d 个任务排队，实际 %d", countingPlatforms-crawl.MaxConcurrentCrawls, pending)
+	}
+
+	close(counting.release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tasks, err := group.WaitAll(ctx)
+	if err != nil {
+		t.Fatalf("等待任务组失败: %v", err)
+	}
+	for id, task := range tasks {
+		if status := task.Snapshot().Status; status != "completed" {
+			t.Errorf("任务 %s 期望 completed，实际 %s", id, status)
+		}
+	}
+	if _, maxActive := activeSearches(); maxActive > crawl.MaxConcurrentCrawls {
+		t.Errorf("同时运行的任务数超过上限: %d", maxActive)
+	}
+}
+
+func TestStartMultiPlatformCrawl_FailureDoesNotAbortGroup(t *testing.T) {
+	app := newTestApp(t)
+
+	// stub-missing 未注册，任务失败；stub-blocking 需手动取消；其余任务正常完成
+	group, err := app.crawlService.StartTaskGroup([]string{"stub-missing", "stub-blocking", "stub-papers"}, map[string]map[string]interface{}{
+		"stub-papers": {"limit": float64(2)},
+	})
+	if err != nil {
+		t.Fatalf("启动任务组失败: %v", err)
+	}
+	if got := waitTaskStatus(t, app.crawlService, group.TaskIDs[1], "running"); got != "running" {
+		t.Fatalf("期望任务进入 running，实际 %s", got)
+	}
+	if err := app.CancelCrawlTask(group.TaskIDs[1]); err != nil {
+		t.Fatalf("取消任务失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tasks, err := group.WaitAll(ctx)
+	if err != nil {
+		t.Fatalf("等待任务组失败: %v", err)
+	}
+	summary := crawl.Summarize(group.TaskIDs, tasks)
+	want := []string{"failed", "cancelled", "completed"}
+	for i, id := range group.TaskIDs {
+		if summary.Statuses[id] != want[i] {
+			t.Errorf("任务 %s 期望 %s，实际 %s", tasks[id].Snapshot().Platform, want[i], summary.Statuses[id])
+		}
+	}
+	if summary.TotalCount != 2 {
+		t.Errorf("期望共获取 2 篇论文，实际 %d", summary.TotalCount)
+	}
+}
+
+func TestCrawlMultiplePlatforms_Validation(t *testing.T) {
+	app := newTestApp(t)
+
+	for _, input := range []string{"not json", "{}"} {
+		if _, err := app.CrawlMultiplePlatforms(input); err == nil {
+			t.Errorf("期望参数 %q 返回错误", input)
+		}
+	}
+	if _, err := app.startMultiPlatformCrawl([]string{"stub-papers", "stub-papers"}, nil); err == nil {
+		t.Error("期望重复平台返回错误")
+	}
+
+	out, err := app.CrawlMultiplePlatforms(`{"stub-papers": {"limit": 1}, "stub-missing": {}}`)
+	if err != nil {
+		t.Fatalf("启动多平台爬取失败: %v", err)
+	}
+	var ids []string
+	if err := json.Unmarshal([]byte(out), &ids); err != nil || len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("期望返回 2 个不同的任务 ID，实际 %s (%v)", out, err)
+	}
+	for _, id := range ids {
+		task, err := app.crawlService.GetTask(id)
+		if err != nil {
+			t.Fatalf("获取任务失败: %v", err)
+		}
+		<-task.Done()
+	}
+}
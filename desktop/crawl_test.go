@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/crawl"
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+type blockingConfig struct{}
+
+func (blockingConfig) Validate() error    { return nil }
+func (blockingConfig) RateLimit() float64 { return 0 }
+
+// blockingPlatform 一直阻塞到 context 取消，返回已取消错误
+type blockingPlatform struct{}
+
+func (blockingPlatform) Name() string               { return "stub-blocking" }
+func (blockingPlatform) GetConfig() platform.Config { return blockingConfig{} }
+func (blockingPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	<-ctx.Done()
+	return platform.Result{Papers: []*models.Paper{}}, ctx.Err()
+}
+
+func init() {
+	core.MustRegister(core.Provider{
+		Name:          "stub-blocking",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return blockingPlatform{}, nil },
+		DefaultConfig: func() platform.Config { return blockingConfig{} },
+	})
+}
+
+// waitTaskStatus 等待任务进入 status，超时返回最后的状态
+func waitTaskStatus(t *testing.T, cs *crawl.Service, taskID, status string) string {
+	t.Helper()
+	var got string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		task, err := cs.GetTask(taskID)
+		if err != nil {
+			t.Fatalf("获取任务失败: %v", err)
+		}
+		got = task.Snapshot().Status
+		if got == status {
+			break
+		}
+	}
+	return got
+}
+
+func TestCancelCrawl_NotRunning(t *testing.T) {
+	app := newTestApp(t)
+	if err := app.CancelCrawlTask("missing"); err == nil {
+		t.Error("期望取消不存在的任务时报错")
+	}
+
+	taskID, err := app.crawlService.StartCrawl("stub-blocking", map[string]interface{}{}, false)
+	if err != nil {
+		t.Fatalf("启动爬取失败: %v", err)
+	}
+	if err := app.CancelCrawlTask(taskID); err != nil {
+		t.Fatalf("取消任务失败: %v", err)
+	}
+	waitTaskStatus(t, app.crawlService, taskID, "cancelled")
+	if err := app.CancelCrawlTask(taskID); err == nil {
+		t.Error("期望重复取消已结束的任务时报错")
+	}
+}
+
+func TestPersistedTask_RoundTrip(t *testing.T) {
+	app := newTestApp(t)
+	cs := app.crawlService
+
+	taskID, err := cs.StartCrawl("stub-papers", map[string]interface{}{"limit": float64(2)}, false)
+	if err != nil {
+		t.Fatalf("启动爬取失败: %v", err)
+	}
+	if got := waitTaskStatus(t, cs, taskID, "completed"); got != "completed" {
+		t.Fatalf("期望任务进入 completed，实际 %s", got)
+	}
+	task, _ := cs.GetTask(taskID)
+	<-task.Done()
+
+	persisted, err := cs.LoadPersistedTask(taskID)
+	if err != nil {
+		t.Fatalf("读取持久化任务失败: %v", err)
+	}
+	if persisted.TaskID != taskID || persisted.Platform != "stub-papers" || len(persisted.Inserted) != 2 {
+		t.Fatalf("持久化任务内容不符: %+v", persisted)
+	}
+	for i, ref := range persisted.Inserted {
+		if ref.Source != "stub-papers" || ref.SourceID != fmt.Sprintf("stub-%d", i) || ref.PaperID == 0 {
+			t.Errorf("第 %d 条入库记录不符: %+v", i, ref)
+		}
+	}
+
+	// 模拟重启：内存中的任务丢失后仍可从磁盘读取入库论文
+	app.crawlService = NewCrawlService(app)
+	out, err := app.GetCrawlTaskPapers(taskID)
+	if err != nil {
+		t.Fatalf("获取任务论文失败: %v", err)
+	}
+	var papers []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &papers); err != nil || len(papers) != 2 {
+		t.Errorf("期望返回 2 篇论文，实际 %s (%v)", out, err)
+	}
+
+	if err := app.ClearCrawlHistory(); err != nil {
+		t.Fatalf("清空历史失败: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(app.config.Database.Path), taskID+".json")); !os.IsNotExist(err) {
+		t.Errorf("期望清空历史时删除任务文件，实际 %v", err)
+	}
+}
+
+func TestCrawlHistory_StoredInDatabase(t *testing.T) {
+	app := newTestApp(t)
+	cs := app.crawlService
+
+	// 旧版 jsonl 历史在启动时导入数据库
+	historyPath := filepath.Join(filepath.Dir(app.config.Database.Path), "crawl_history.jsonl")
+	legacy := `{"task_id":"crawl_legacy","platform":"arxiv","total":3,"start_time":"2020-01-02T03:04:05Z","end_time":"2020-01-02T03:05:05Z"}` + "\n"
+	if err := os.WriteFile(historyPath, []byte(legacy), 0644); err != nil {
+		t.Fatalf("写入旧版历史失败: %v", err)
+	}
+	if err := cs.MigrateHistoryFile(); err != nil {
+		t.Fatalf("导入旧版历史失败: %v", err)
+	}
+	if _, err := os.Stat(historyPath); !os.IsNotExist(err) {
+		t.Errorf("期望导入后旧版历史文件被改名，实际 %v", err)
+	}
+
+	taskID, err := cs.StartCrawl("stub-papers", map[string]interface{}{"limit": float64(2)}, false)
+	if err != nil {
+		t.Fatalf("启动爬取失败: %v", err)
+	}
+	waitTaskStatus(t, cs, taskID, "completed")
+	task, _ := cs.GetTask(taskID)
+	<-task.Done()
+
+	out, err := app.GetCrawlHistory(0)
+	if err != nil {
+		t.Fatalf("获取爬取历史失败: %v", err)
+	}
+	var history []models.CrawlHistory
+	if err := json.Unmarshal([]byte(out), &history); err != nil {
+		t.Fatalf("解析爬取历史失败: %v", err)
+	}
+	if len(history) != 2 || history[0].TaskID != taskID || history[1].TaskID != "crawl_legacy" {
+		t.Fatalf("期望新任务在前、旧版记录在后，实际 %+v", history)
+	}
+	if history[0].Status != "completed" || history[0].Total != 2 || len(history[0].InsertedIDs) != 2 {
+		t.Errorf("新任务历史不符: %+v", history[0])
+	}
+	if history[1].Status != "completed" || history[1].Total != 3 {
+		t.Errorf("导入的旧版历史不符: %+v", history[1])
+	}
+
+	// 只删除 30 天前的历史，近期任务及其任务文件保留
+	if err := app.PurgeCrawlHistory(30); err != nil {
+		t.Fatalf("清理历史失败: %v", err)
+	}
+	out, _ = app.GetCrawlHistory(0)
+	history = nil
+	if err := json.Unmarshal([]byte(out), &history); err != nil || len(history) != 1 || history[0].TaskID != taskID {
+		t.Errorf("期望仅保留近期任务，实际 %s (%v)", out, err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(app.config.Database.Path), taskID+".json")); err != nil {
+		t.Errorf("期望近期任务文件保留，实际 %v", err)
+	}
+	if err := app.PurgeCrawlHistory(-1); err == nil {
+		t.Error("期望负数天数报错")
+	}
+}
+
+func TestPreviewCrawl_SampleLimited(t *testing.T) {
+	app := newTestApp(t)
+
+	data, err := app.PreviewCrawl("stub-papers", map[string]interface{}{"limit": float64(8)})
+	if err != nil {
+		t.Fatalf("预览爬取失败: %v", err)
+	}
+	var preview crawl.Preview
+	if err := json.Unmarshal([]byte(data), &preview); err != nil {
+		t.Fatalf("解析预览结果失败: %v", err)
+	}
+	if preview.Total != 8 || len(preview.Sample) != crawl.PreviewSampleSize {
+		t.Errorf("期望 total=8、样例 %d 篇，实际 total=%d、样例 %d 篇", crawl.PreviewSampleSize, preview.Total, len(preview.Sample))
+	}
+	if n, _ := app.coreApp.CountPapers(context.Background(), nil, nil); n != 0 {
+		t.Errorf("预览不应入库，实际库中有 %d 篇", n)
+	}
+}
+
+func TestStartCrawl_DryRun(t *testing.T) {
+	app := newTestApp(t)
+	cs := app.crawlService
+
+	taskID, err := cs.StartCrawl("stub-papers", map[string]interface{}{"limit": float64(3)}, true)
+	if err != nil {
+		t.Fatalf("启动预览任务失败: %v", err)
+	}
+	if got := waitTaskStatus(t, cs, taskID, "preview"); got != "preview" {
+		t.Fatalf("期望任务进入 preview，实际 %s", got)
+	}
+	task, _ := cs.GetTask(taskID)
+	<-task.Done()
+
+	task = task.Snapshot()
+	if !task.DryRun || task.TotalCount != 3 || len(task.Sample) != 3 || len(task.Inserted) != 0 {
+		t.Errorf("预览任务结果不符: dryRun=%v total=%d sample=%d inserted=%d", task.DryRun, task.TotalCount, len(task.Sample), len(task.Inserted))
+	}
+	if n, _ := app.coreApp.CountPapers(context.Background(), nil, nil); n != 0 {
+		t.Errorf("预览不应入库，实际库中有 %d 篇", n)
+	}
+}
+
+// sincePlatform 记录每次检索的实际起始日期，返回 sinceAnnounced 中公布日期不早于该日期的论文
+type sincePlatform struct{}
+
+var sinceDates []string
+
+var sinceAnnounced = []time.Time{
+	time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+	time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+}
+
+func (sincePlatform) Name() string               { return "stub-since" }
+func (sincePlatform) GetConfig() platform.Config { return blockingConfig{} }
+func (sincePlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	from := q.EffectiveDateFrom()
+	sinceDates = append(sinceDates, from)
+	var papers []*models.Paper
+	for i, day := range sinceAnnounced {
+		if day.Format("2006-01-02") < from {
+			continue
+		}
+		papers = append(papers, &models.Paper{
+			Source:           "stub-since",
+			SourceID:         fmt.Sprintf("since-%d", i),
+			URL:              fmt.Sprintf("https://example.com/since-%d", i),
+			Title:            fmt.Sprintf("Since Paper %d", i),
+			FirstAnnouncedAt: day,
+		})
+	}
+	return platform.Result{Total: len(papers), Papers: papers}, nil
+}
+
+func init() {
+	core.MustRegister(core.Provider{
+		Name:          "stub-since",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return sincePlatform{}, nil },
+		DefaultConfig: func() platform.Config { return blockingConfig{} },
+	})
+}
+
+func TestStartCrawl_SinceLastNarrowsDateWindow(t *testing.T) {
+	app := newTestApp(t)
+	app.coreApp.EnableLastCrawlTracking(t.TempDir())
+	cs := app.crawlService
+	sinceDates = nil
+
+	params := map[string]interface{}{"dateFrom": "2024-01-01", "since_last": true}
+	for i, want := range []string{"2024-01-01", "2024-03-10"} {
+		taskID, err := cs.StartCrawl("stub-since", params, false)
+		if err != nil {
+			t.Fatalf("启动爬取失败: %v", err)
+		}
+		if status := waitTaskStatus(t, cs, taskID, "completed"); status != "completed" {
+			t.Fatalf("第 %d 次爬取状态为 %s，期望 completed", i+1, status)
+		}
+		if got := sinceDates[len(sinceDates)-1]; got != want {
+			t.Errorf("第 %d 次爬取起始日期为 %s，期望 %s", i+1, got, want)
+		}
+	}
+	if last := app.coreApp.LastCrawl("stub-since"); !last.Equal(sinceAnnounced[1]) {
+		t.Errorf("爬取进度为 %v，期望 %v", last, sinceAnnounced[1])
+	}
+
+	// 未开启 since_last 时不受爬取进度影响
+	taskID, err := cs.StartCrawl("stub-since", map[string]interface{}{"dateFrom": "2024-01-01"}, false)
+	if err != nil {
+		t.Fatalf("启动爬取失败: %v", err)
+	}
+	waitTaskStatus(t, cs, taskID, "completed")
+	if got := sinceDates[len(sinceDates)-1]; got != "2024-01-01" {
+		t.Errorf("未开启 since_last 时起始日期为 %s，期望 2024-01-01", got)
+	}
+}
@@ -35,6 +35,12 @@ type CrawlerInput struct {
 
 	// VenueId OpenReview 平台专用参数
 	VenueId string `json:"venue_id,omitempty" jsonschema:"description=Venue ID for OpenReview platform"`
+
+	// MinRating OpenReview 专用，评审平均分下限
+	MinRating float64 `json:"min_rating,omitempty" jsonschema:"description=Minimum average review rating (OpenReview only; ignored by other platforms)"`
+
+	// Decision OpenReview 专用，录用决定关键字
+	Decision string `json:"decision,omitempty" jsonschema:"description=Decision keyword such as accept or oral (OpenReview only; ignored by other platforms)"`
 }
 
 type CrawlerOutput struct {
@@ -61,6 +67,10 @@ func NewCrawlerTool(app *App) tool.InvokableTool {
 		if input.Platform == "openreview" && input.VenueId != "" {
 			query.Categories = []string{input.VenueId}
 		}
+		if input.Platform == "openreview" {
+			query.MinRating = input.MinRating
+			query.Decision = input.Decision
+		}
 
 		count, err := app.coreApp.Crawl(ctx, input.Platform, query)
 		if err != nil {
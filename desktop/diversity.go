@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"math"
+
+	"PaperHunter/desktop/memory"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/similarity"
+)
+
+// mmrSelect 按最大边际相关性（MMR）贪心选出至多 k 篇论文：每一步选 lambda*相关性 - (1-lambda)*与已选论文的最大相似度 最高的候选。
+// vecs 以论文 ID 为 key，缺少向量的论文视为与其他论文不相似；返回选中顺序
+func mmrSelect(candidates []*models.SimilarPaper, vecs map[int64][]float32, relevance func(*models.SimilarPaper) float64, lambda float64, k int) []*models.SimilarPaper {
+	k = min(k, len(candidates))
+	rel := make([]float64, len(candidates))
+	for i, sp := range candidates {
+		rel[i] = relevance(sp)
+	}
+	// maxSim[i] 候选 i 与已选论文的最大余弦相似度
+	maxSim := make([]float64, len(candidates))
+	used := make([]bool, len(candidates))
+
+	selected := make([]*models.SimilarPaper, 0, k)
+	for len(selected) < k {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range candidates {
+			if used[i] {
+				continue
+			}
+			if score := lambda*rel[i] - (1-lambda)*maxSim[i]; score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		used[best] = true
+		selected = append(selected, candidates[best])
+
+		chosen, ok := vecs[candidates[best].Paper.ID]
+		if !ok {
+			continue
+		}
+		for i := range candidates {
+			if used[i] {
+				continue
+			}
+			if vec, ok := vecs[candidates[i].Paper.ID]; ok {
+				maxSim[i] = max(maxSim[i], float64(similarity.CosineSimilarity(chosen, vec)))
+			}
+		}
+	}
+	return selected
+}
+
+// diversifyGroups 对所有推荐组的论文做一次全局 MMR，保留至多 k 篇；组内按选中顺序排列，空组被移除。
+// 相关性沿用 scorePaperWithProfile 的混合得分
+func diversifyGroups(groups []RecommendationGroup, vecs map[int64][]float32, profile *memory.ProfileCache, lambda float64, k int) []RecommendationGroup {
+	var candidates []*models.SimilarPaper
+	for _, g := range groups {
+		candidates = append(candidates, g.Papers...)
+	}
+	selected := mmrSelect(candidates, vecs, func(sp *models.SimilarPaper) float64 {
+		return scorePaperWithProfile(sp, profile)
+	}, lambda, k)
+
+	rank := make(map[*models.SimilarPaper]int, len(selected))
+	for i, sp := range selected {
+		rank[sp] = i
+	}
+	out := make([]RecommendationGroup, 0, len(groups))
+	for _, g := range groups {
+		papers := make([]*models.SimilarPaper, 0, len(g.Papers))
+		for _, sp := range g.Papers {
+			if _, ok := rank[sp]; ok {
+				papers = append(papers, sp)
+			}
+		}
+		if len(papers) == 0 {
+			continue
+		}
+		sortByRank(papers, rank)
+		g.Papers = papers
+		out = append(out, g)
+	}
+	return out
+}
+
+func sortByRank(papers []*models.SimilarPaper, rank map[*models.SimilarPaper]int) {
+	for i := 1; i < len(papers); i++ {
+		for j := i; j > 0 && rank[papers[j]] < rank[papers[j-1]]; j-- {
+			papers[j], papers[j-1] = papers[j-1], papers[j]
+		}
+	}
+}
+
+// diversifyRecommendations 读取候选论文的向量后做 MMR 多样化；向量只在这里按 ID 批量读取一次，
+// 不放进 SimilarPaper，避免推荐结果 JSON 携带大量向量数据。读取失败时保持原结果
+func (a *App) diversifyRecommendations(ctx context.Context, groups []RecommendationGroup, profile *memory.ProfileCache, lambda float64, k int) []RecommendationGroup {
+	var ids []int64
+	for _, g := range groups {
+		for _, sp := range g.Papers {
+			ids = append(ids, sp.Paper.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return groups
+	}
+	vecs, err := a.coreApp.GetEmbeddings(ctx, ids)
+	if err != nil {
+		logger.Warn("读取候选论文向量失败，跳过多样化: %v", err)
+		return groups
+	}
+	return diversifyGroups(groups, vecs, profile, lambda, k)
+}
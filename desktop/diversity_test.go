@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestMMRSelect_SpreadsNearDuplicates(t *testing.T) {
+	candidates := []*models.SimilarPaper{
+		{Paper: models.Paper{ID: 1, Title: "A"}, Similarity: 0.95},
+		{Paper: models.Paper{ID: 2, Title: "A'"}, Similarity: 0.94},
+		{Paper: models.Paper{ID: 3, Title: "A''"}, Similarity: 0.93},
+		{Paper: models.Paper{ID: 4, Title: "B"}, Similarity: 0.80},
+		{Paper: models.Paper{ID: 5, Title: "C"}, Similarity: 0.70},
+	}
+	vecs := map[int64][]float32{
+		1: {1, 0, 0},
+		2: {0.99, 0.01, 0},
+		3: {0.98, 0.02, 0},
+		4: {0, 1, 0},
+		5: {0, 0, 1},
+	}
+	relevance := func(sp *models.SimilarPaper) float64 { return float64(sp.Similarity) }
+
+	got := mmrSelect(candidates, vecs, relevance, 0.5, 3)
+	want := []int64{1, 4, 5}
+	if len(got) != len(want) {
+		t.Fatalf("期望选出 %d 篇，实际 %d 篇", len(want), len(got))
+	}
+	for i, sp := range got {
+		if sp.Paper.ID != want[i] {
+			t.Errorf("第 %d 篇期望 ID %d，实际 %d", i, want[i], sp.Paper.ID)
+		}
+	}
+
+	// lambda 接近 1 时退化为按相关性排序
+	got = mmrSelect(candidates, vecs, relevance, 0.999, 3)
+	for i, sp := range got {
+		if sp.Paper.ID != int64(i+1) {
+			t.Errorf("lambda≈1 时第 %d 篇期望 ID %d，实际 %d", i, i+1, sp.Paper.ID)
+		}
+	}
+}
+
+func TestDiversifyGroups_AcrossSeeds(t *testing.T) {
+	dup1 := &models.SimilarPaper{Paper: models.Paper{ID: 1, Title: "Diffusion A"}, Similarity: 0.9}
+	dup2 := &models.SimilarPaper{Paper: models.Paper{ID: 2, Title: "Diffusion A v2"}, Similarity: 0.9}
+	other := &models.SimilarPaper{Paper: models.Paper{ID: 3, Title: "Graph B"}, Similarity: 0.6}
+	noVec := &models.SimilarPaper{Paper: models.Paper{ID: 4, Title: "Unknown"}, Similarity: 0.5}
+	groups := []RecommendationGroup{
+		{SeedPaper: models.Paper{Title: "seed1"}, Papers: []*models.SimilarPaper{dup1, other}},
+		{SeedPaper: models.Paper{Title: "seed2"}, Papers: []*models.SimilarPaper{dup2, noVec}},
+	}
+	vecs := map[int64][]float32{1: {1, 0}, 2: {1, 0.01}, 3: {0, 1}}
+
+	out := diversifyGroups(groups, vecs, nil, 0.5, 3)
+	var ids []int64
+	for _, g := range out {
+		for _, sp := range g.Papers {
+			ids = append(ids, sp.Paper.ID)
+		}
+	}
+	if len(ids) != 3 {
+		t.Fatalf("期望保留 3 篇，实际 %v", ids)
+	}
+	for _, id := range ids {
+		if id == 2 {
+			t.Errorf("近似重复的论文不应同时入选，实际 %v", ids)
+		}
+	}
+	if len(out) != 2 || out[1].SeedPaper.Title != "seed2" || out[1].Papers[0] != noVec {
+		t.Errorf("期望第二组只保留无向量的论文，实际 %+v", out)
+	}
+}
@@ -2,20 +2,34 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+
+	"PaperHunter/internal/core"
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/pkg/upload/zotero"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type ExportOptions struct {
-	Format     string   `json:"format"` // csv|json|zotero|feishu
-	Output     string   `json:"output"` // csv/json 必填
+	Format     string   `json:"format"`     // csv|json|ris|bibtex|markdown|obsidian|xlsx|zotero|feishu|notion
+	Output     string   `json:"output"`     // csv/json 必填
+	SplitFiles bool     `json:"splitFiles"` // markdown: 每篇论文一个文件，output 作为目录
 	Query      string   `json:"query"`
 	Keywords   []string `json:"keywords"`
 	Categories []string `json:"categories"`
 	Source     string   `json:"source"`
 	Collection string   `json:"collection"` // zotero
 	FeishuName string   `json:"feishuName"` // feishu: 作为文件与文件夹名
-	Limit      int      `json:"limit"`
+	// feishu: 已有多维表格的 app_token/table_id，同时设置时追加到该表，否则使用配置或新建
+	FeishuAppToken string `json:"feishuAppToken"`
+	FeishuTableID  string `json:"feishuTableId"`
+	NotionName     string `json:"notionName"` // notion: 目标数据库 ID，留空使用配置
+	Status         string `json:"status"`     // 阅读状态过滤，为空表示不限
+	Starred        bool   `json:"starred"`    // 只导出已收藏的论文
+	Limit          int    `json:"limit"`
 }
 
 func (a *App) ExportWithOptions(opts ExportOptions) (string, error) {
@@ -23,14 +37,14 @@ func (a *App) ExportWithOptions(opts ExportOptions) (string, error) {
 		return "", fmt.Errorf("app not initialized")
 	}
 
-	valid := map[string]bool{"csv": true, "json": true, "zotero": true, "feishu": true}
-	if !valid[strings.ToLower(opts.Format)] {
+	f, ok := exporter.Lookup(opts.Format)
+	if !ok {
 		return "", fmt.Errorf("unsupported format: %s", opts.Format)
 	}
 
-	// csv/json 必须提供输出
-	if (opts.Format == "csv" || opts.Format == "json") && strings.TrimSpace(opts.Output) == "" {
-		return "", fmt.Errorf("output is required for csv/json")
+	// 文件类格式必须提供输出（obsidian 为目录）
+	if f.NeedsOutput && strings.TrimSpace(opts.Output) == "" {
+		return "", fmt.Errorf("output is required for %s", strings.Join(exporter.OutputFormatNames(), "/"))
 	}
 
 	// 组装 conditions/params
@@ -67,26 +81,88 @@ func (a *App) ExportWithOptions(opts ExportOptions) (string, error) {
 			params = append(params, "%"+c+"%")
 		}
 	}
+	statusConds, statusParams, err := core.StatusConditions(opts.Status, opts.Starred)
+	if err != nil {
+		return "", err
+	}
+	conditions = append(conditions, statusConds...)
+	params = append(params, statusParams...)
 
 	ctx := context.Background()
 
-	switch opts.Format {
-	case "csv", "json":
-		return opts.Output, a.coreApp.ExportPapers(ctx, opts.Format, opts.Output, conditions, params, opts.Limit)
+	switch f.Name {
+	case "markdown":
+		return opts.Output, a.coreApp.ExportMarkdown(ctx, opts.Output, conditions, params, opts.Limit, opts.SplitFiles)
 	case "zotero":
 		return "", a.coreApp.ExportToZotero(ctx, opts.Collection, conditions, params, opts.Limit)
+	case "notion":
+		return "", a.coreApp.ExportToNotion(ctx, strings.TrimSpace(opts.NotionName), conditions, params, opts.Limit)
 	case "feishu":
 		name := strings.TrimSpace(opts.FeishuName)
-		if name == "" {
+		target := core.FeiShuTable{AppToken: strings.TrimSpace(opts.FeishuAppToken), TableID: strings.TrimSpace(opts.FeishuTableID)}
+		if name == "" && !target.IsSet() {
 			return "", fmt.Errorf("feishuName is required for feishu export")
 		}
-		url, err := a.coreApp.ExportToFeiShuBitableWithURL(ctx, name, name, conditions, params, opts.Limit)
+		url, err := a.exportToFeishu(ctx, name, target, conditions, params, opts.Limit)
 		if err != nil {
 			return "", err
 		}
 		fmt.Println("Feishu URL:", url)
 		return url, nil
 	default:
-		return "", fmt.Errorf("unsupported format: %s", opts.Format)
+		// 其余为文件类格式
+		return opts.Output, a.coreApp.ExportPapers(ctx, f.Name, opts.Output, conditions, params, opts.Limit)
+	}
+}
+
+// exportToFeishu 导出到飞书多维表格并返回数据表链接，新增/跳过数通过 feishu-export-result 事件发送给前端
+func (a *App) exportToFeishu(ctx context.Context, name string, target core.FeiShuTable, conditions []string, params []interface{}, limit int) (string, error) {
+	result, err := a.coreApp.ExportToFeiShuBitableWithURL(ctx, name, name, target, conditions, params, limit)
+	if err != nil {
+		return "", err
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "feishu-export-result", result)
+	}
+	return result.URL, nil
+}
+
+// SyncToZotero 将本地论文增量同步到 Zotero，已存在（Extra 中有相同 source:source_id）的论文跳过
+// 返回 JSON：{"added": n, "skipped": m}
+func (a *App) SyncToZotero(collectionKey string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+
+	added, skipped, err := a.coreApp.SyncToZotero(context.Background(), collectionKey, nil, nil, 0)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(map[string]int{"added": added, "skipped": skipped})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return string(data), nil
+}
+
+// ListZoteroGroupLibraries 列出当前 Zotero 用户所属的群组库，返回 JSON 数组，其中 id 可填入 zotero.group_id
+func (a *App) ListZoteroGroupLibraries() (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+
+	groups, err := a.coreApp.ListZoteroGroups(context.Background())
+	if err != nil {
+		return "", err
+	}
+	if groups == nil {
+		groups = []zotero.Group{}
+	}
+
+	data, err := json.Marshal(groups)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal groups: %w", err)
 	}
+	return string(data), nil
 }
@@ -4,19 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
+	"PaperHunter/internal/core"
+	exporter "PaperHunter/internal/core/export"
+
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/eino-contrib/jsonschema"
 )
 
 
 type ExportInput struct {
-	// Format 导出格式：csv, json, zotero, feishu
-	Format string `json:"format" jsonschema:"required,enum=csv,enum=json,enum=zotero,enum=feishu,description=Export format (csv, json, zotero, feishu)"`
+	// Format 导出格式，可选值与说明由 exportSchemaModifier 按导出格式注册表生成
+	Format string `json:"format" jsonschema:"required"`
+
+	// Output 输出文件路径（文件类格式必填，obsidian 为目录），说明同样由注册表生成
+	Output string `json:"output,omitempty"`
 
-	// Output 输出文件路径（csv/json 格式必填）
-	Output string `json:"output,omitempty" jsonschema:"description=Output file path (required for csv/json format)"`
+	// SplitFiles markdown 格式下每篇论文单独一个文件，Output 作为目录
+	SplitFiles bool `json:"split_files,omitempty" jsonschema:"description=For markdown format: write one file per paper into the output directory instead of a single combined file"`
 
 	// Query 查询字符串过滤（在标题或摘要中搜索）
 	Query string `json:"query,omitempty" jsonschema:"description=Filter by query string (searches in title or abstract)"`
@@ -36,6 +44,13 @@ type ExportInput struct {
 	// FeishuName 飞书多维表格名称（用于 feishu 格式）
 	FeishuName string `json:"feishu_name,omitempty" jsonschema:"description=Feishu Bitable name (for feishu format)"`
 
+	// FeishuAppToken/FeishuTableID 已有多维表格数据表（用于 feishu 格式，同时设置时追加而不是新建）
+	FeishuAppToken string `json:"feishu_app_token,omitempty" jsonschema:"description=Existing Feishu Bitable app_token to append to (for feishu format, requires feishu_table_id)"`
+	FeishuTableID  string `json:"feishu_table_id,omitempty" jsonschema:"description=Existing Feishu Bitable table_id to append to (for feishu format, requires feishu_app_token)"`
+
+	// NotionName Notion 数据库 ID（用于 notion 格式，留空使用配置）
+	NotionName string `json:"notion_name,omitempty" jsonschema:"description=Notion database ID (for notion format, defaults to the configured database)"`
+
 	// Limit 导出数量限制（0 表示不限制）
 	Limit int `json:"limit,omitempty" jsonschema:"description=Export limit (0 means no limit)"`
 }
@@ -45,27 +60,32 @@ type ExportOutput struct {
 	Success bool   `json:"success" jsonschema:"description=Whether the export was successful"`
 	Message string `json:"message" jsonschema:"description=Result message"`
 	URL     string `json:"url,omitempty" jsonschema:"description=Export URL (for feishu format)"`
+	// Inserted/Skipped 飞书导出新增与因已存在而跳过的论文数
+	Inserted int `json:"inserted,omitempty" jsonschema:"description=Number of papers added (for feishu format)"`
+	Skipped  int `json:"skipped,omitempty" jsonschema:"description=Number of papers skipped because they already exist in the table (for feishu format)"`
 }
 
 func NewExportTool(app *App) tool.InvokableTool {
-	exportTool, err := utils.InferTool("export", "Export papers to different formats (csv, json, zotero, feishu) with optional filtering", func(ctx context.Context, input *ExportInput) (output *ExportOutput, err error) {
+	description := fmt.Sprintf("Export papers to different formats (%s) with optional filtering", strings.Join(exporter.Names(), ", "))
+	exportTool, err := utils.InferTool("export", description, func(ctx context.Context, input *ExportInput) (output *ExportOutput, err error) {
 		if app == nil || app.coreApp == nil {
 			return nil, fmt.Errorf("app instance is not initialized")
 		}
 
-		validFormats := map[string]bool{"csv": true, "json": true, "zotero": true, "feishu": true}
-		if !validFormats[strings.ToLower(input.Format)] {
+		f, ok := exporter.Lookup(input.Format)
+		if !ok {
 			return &ExportOutput{
 				Success: false,
-				Message: fmt.Sprintf("Unsupported format: %s. Supported formats: csv, json, zotero, feishu", input.Format),
+				Message: fmt.Sprintf("Unsupported format: %s. Supported formats: %s", input.Format, strings.Join(exporter.Names(), ", ")),
 			}, fmt.Errorf("unsupported format: %s", input.Format)
 		}
 
-		if (input.Format == "csv" || input.Format == "json") && strings.TrimSpace(input.Output) == "" {
+		if f.NeedsOutput && strings.TrimSpace(input.Output) == "" {
+			outputFormats := strings.Join(exporter.OutputFormatNames(), "/")
 			return &ExportOutput{
 				Success: false,
-				Message: "Output path is required for csv/json format",
-			}, fmt.Errorf("output path is required for csv/json format")
+				Message: fmt.Sprintf("Output path is required for %s format", outputFormats),
+			}, fmt.Errorf("output path is required for %s format", outputFormats)
 		}
 
 		var conditions []string
@@ -105,42 +125,43 @@ func NewExportTool(app *App) tool.InvokableTool {
 			}
 		}
 
-		switch strings.ToLower(input.Format) {
-		case "csv", "json":
-			err := app.coreApp.ExportPapers(ctx, input.Format, input.Output, conditions, params, input.Limit)
+		switch f.Name {
+		case "zotero":
+			err := app.coreApp.ExportToZotero(ctx, input.Collection, conditions, params, input.Limit)
 			if err != nil {
 				return &ExportOutput{
 					Success: false,
-					Message: fmt.Sprintf("Export failed: %v", err),
+					Message: fmt.Sprintf("Export to Zotero failed: %v", err),
 				}, err
 			}
 			return &ExportOutput{
 				Success: true,
-				Message: fmt.Sprintf("Successfully exported to %s", input.Output),
+				Message: "Successfully exported to Zotero",
 			}, nil
 
-		case "zotero":
-			err := app.coreApp.ExportToZotero(ctx, input.Collection, conditions, params, input.Limit)
+		case "notion":
+			err := app.coreApp.ExportToNotion(ctx, strings.TrimSpace(input.NotionName), conditions, params, input.Limit)
 			if err != nil {
 				return &ExportOutput{
 					Success: false,
-					Message: fmt.Sprintf("Export to Zotero failed: %v", err),
+					Message: fmt.Sprintf("Export to Notion failed: %v", err),
 				}, err
 			}
 			return &ExportOutput{
 				Success: true,
-				Message: "Successfully exported to Zotero",
+				Message: "Successfully exported to Notion",
 			}, nil
 
 		case "feishu":
 			name := strings.TrimSpace(input.FeishuName)
-			if name == "" {
+			target := core.FeiShuTable{AppToken: strings.TrimSpace(input.FeishuAppToken), TableID: strings.TrimSpace(input.FeishuTableID)}
+			if name == "" && !target.IsSet() {
 				return &ExportOutput{
 					Success: false,
 					Message: "FeishuName is required for feishu format",
 				}, fmt.Errorf("feishu_name is required for feishu format")
 			}
-			url, err := app.coreApp.ExportToFeiShuBitableWithURL(ctx, name, name, conditions, params, input.Limit)
+			result, err := app.coreApp.ExportToFeiShuBitableWithURL(ctx, name, name, target, conditions, params, input.Limit)
 			if err != nil {
 				return &ExportOutput{
 					Success: false,
@@ -148,18 +169,33 @@ func NewExportTool(app *App) tool.InvokableTool {
 				}, err
 			}
 			return &ExportOutput{
-				Success: true,
-				Message: "Successfully exported to Feishu",
-				URL:     url,
+				Success:  true,
+				Message:  fmt.Sprintf("Successfully exported to Feishu: %d added, %d skipped", result.Inserted, result.Skipped),
+				URL:      result.URL,
+				Inserted: result.Inserted,
+				Skipped:  result.Skipped,
 			}, nil
 
 		default:
+			// 其余为文件类格式
+			var err error
+			if f.Name == "markdown" {
+				err = app.coreApp.ExportMarkdown(ctx, input.Output, conditions, params, input.Limit, input.SplitFiles)
+			} else {
+				err = app.coreApp.ExportPapers(ctx, f.Name, input.Output, conditions, params, input.Limit)
+			}
+			if err != nil {
+				return &ExportOutput{
+					Success: false,
+					Message: fmt.Sprintf("Export failed: %v", err),
+				}, err
+			}
 			return &ExportOutput{
-				Success: false,
-				Message: fmt.Sprintf("Unknown format: %s", input.Format),
-			}, fmt.Errorf("unknown format: %s", input.Format)
+				Success: true,
+				Message: fmt.Sprintf("Successfully exported to %s", input.Output),
+			}, nil
 		}
-	})
+	}, utils.WithSchemaModifier(exportSchemaModifier))
 
 	if err != nil {
 		log.Fatalf("failed to create export tool: %v", err)
@@ -168,3 +204,21 @@ func NewExportTool(app *App) tool.InvokableTool {
 	return exportTool
 }
 
+// exportSchemaModifier 按导出格式注册表生成 format 的可选值与 output 的说明，避免与实际支持的格式不一致；
+// 字段的说明会被标签覆盖，因此在生成整个结构体的 schema 时再设置
+func exportSchemaModifier(_ string, _ reflect.Type, _ reflect.StructTag, schema *jsonschema.Schema) {
+	if schema.Properties == nil {
+		return
+	}
+	if format, ok := schema.Properties.Get("format"); ok {
+		names := exporter.Names()
+		format.Enum = make([]any, len(names))
+		for i, name := range names {
+			format.Enum[i] = name
+		}
+		format.Description = fmt.Sprintf("Export format (%s)", strings.Join(names, ", "))
+	}
+	if output, ok := schema.Properties.Get("output"); ok {
+		output.Description = fmt.Sprintf("Output file path (required for %s format; a directory for obsidian or when split_files is set)", strings.Join(exporter.OutputFormatNames(), "/"))
+	}
+}
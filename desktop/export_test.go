@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"PaperHunter/internal/core"
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+)
+
+func TestPreviewExport_DoesNotExport(t *testing.T) {
+	app := newTestApp(t)
+	var papers []*models.Paper
+	for i := 0; i < 8; i++ {
+		papers = append(papers, &models.Paper{Source: "arxiv", SourceID: fmt.Sprint(i), URL: fmt.Sprintf("https://arxiv.org/abs/%d", i), Title: fmt.Sprintf("Paper %d", i)})
+	}
+	papers = append(papers, &models.Paper{Source: "acl", SourceID: "a1", URL: "https://aclanthology.org/a1", Title: "ACL Paper"})
+	if _, err := app.coreApp.SavePapers(context.Background(), papers); err != nil {
+		t.Fatalf("保存论文失败: %v", err)
+	}
+
+	parse := func(out string) core.ExportPreview {
+		t.Helper()
+		var preview core.ExportPreview
+		if err := json.Unmarshal([]byte(out), &preview); err != nil {
+			t.Fatalf("解析预览结果失败: %v", err)
+		}
+		return preview
+	}
+
+	out, err := app.PreviewExportSelection("arxiv", []string{"0", "1", "2", "3", "4", "5", "6", "missing"})
+	if err != nil {
+		t.Fatalf("预览导出失败: %v", err)
+	}
+	preview := parse(out)
+	if preview.Total != 7 || len(preview.Sample) != exportPreviewSampleSize {
+		t.Errorf("期望 7 篇、%d 篇样例，实际: %+v", exportPreviewSampleSize, preview)
+	}
+	if s := preview.Sample[0]; s.Source != "arxiv" || s.Title == "" {
+		t.Errorf("样例缺少标题或来源: %+v", s)
+	}
+
+	pairs := []map[string]string{{"source": "arxiv", "id": "7"}, {"source": "acl", "id": "a1"}}
+	out, err = app.PreviewExportSelectionByPapers(pairs, nil)
+	if err != nil {
+		t.Fatalf("预览导出失败: %v", err)
+	}
+	if preview := parse(out); preview.Total != 2 || len(preview.Sample) != 2 {
+		t.Errorf("期望跨来源 2 篇，实际: %+v", preview)
+	}
+
+	if _, err := app.PreviewExportSelection("arxiv", nil); err == nil {
+		t.Error("期望未选择论文时返回错误")
+	}
+}
+
+func TestExportTool_FormatEnumFromRegistry(t *testing.T) {
+	info, err := NewExportTool(&App{}).Info(context.Background())
+	if err != nil {
+		t.Fatalf("获取工具信息失败: %v", err)
+	}
+	js, err := info.ParamsOneOf.ToJSONSchema()
+	if err != nil {
+		t.Fatalf("生成参数 schema 失败: %v", err)
+	}
+	format, ok := js.Properties.Get("format")
+	if !ok {
+		t.Fatal("schema 缺少 format 参数")
+	}
+	var got []string
+	for _, v := range format.Enum {
+		got = append(got, fmt.Sprint(v))
+	}
+	if want := exporter.Names(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("format 可选值 = %v，期望 %v", got, want)
+	}
+	if !strings.Contains(format.Description, "markdown") {
+		t.Errorf("format 说明应列出 markdown: %q", format.Description)
+	}
+	if output, ok := js.Properties.Get("output"); !ok || !strings.Contains(output.Description, "markdown") {
+		t.Errorf("output 说明应列出 markdown: %+v", output)
+	}
+}
@@ -373,6 +373,7 @@ const LibraryView: React.FC = () => {
                     exportFormat === 'csv' || exportFormat === 'json' ? exportOutput : '',
                     exportFormat === 'feishu' ? (exportFeishuName || 'Papers') : '',
                     exportFormat === 'zotero' ? exportCollection : '',
+                    [],
                 );
                 handleExportSuccess(result);
              }
@@ -286,6 +286,7 @@ const PapersView: React.FC = () => {
             exportFormat === 'csv' || exportFormat === 'json' ? exportOutput : '',
             exportFormat === 'feishu' ? (exportFeishuName || 'Papers') : '',
             exportFormat === 'zotero' ? exportCollection : '',
+            [],
           );
           handleExportSuccess(result);
           toast({
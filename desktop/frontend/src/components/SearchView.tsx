@@ -190,6 +190,7 @@ const SearchView: React.FC = () => {
       limit: crawlParams.limit,
       update: crawlParams.update,
       useAPI: crawlParams.useAPI,
+      since_last: crawlParams.sinceLast,
     };
 
     // 平台特定参数
@@ -909,6 +910,18 @@ const SearchView: React.FC = () => {
                           <span>{t('search.useApi')}</span>
                         </label>
                       )}
+                      {/* 增量爬取只对 arXiv/ACL/OpenReview 生效 */}
+                      {crawlParams.platform !== 'ssrn' && (
+                        <label className="flex items-center gap-2 text-sm cursor-pointer hover:text-foreground transition-colors font-sans">
+                          <Checkbox
+                            checked={crawlParams.sinceLast}
+                            onCheckedChange={(checked: boolean) => 
+                              setCrawlParams(prev => ({ ...prev, sinceLast: checked }))
+                            }
+                          />
+                          <span>{t('search.sinceLast')}</span>
+                        </label>
+                      )}
                     </div>
                   </div>
                 </div>
@@ -10,6 +10,7 @@ export interface CrawlParams {
   limit: number;
   update: boolean;
   useAPI: boolean;
+  sinceLast: boolean;
   venueId: string;
   useRSS: boolean;
   useBibTeX: boolean;
@@ -44,6 +45,7 @@ const initialCrawlParams: CrawlParams = {
   limit: 100,
   update: false,
   useAPI: false,
+  sinceLast: false,
   venueId: '',
   useRSS: true,
   useBibTeX: false
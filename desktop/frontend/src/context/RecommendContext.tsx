@@ -39,6 +39,7 @@ export interface AgentLogEntry {
 
 interface RecommendResult {
   crawledToday: boolean;
+  announcementDate?: string; // 实际获取的 arXiv 列表的公布日，周末、节假日为上一个公布日
   arxivCrawlCount: number;
   seedPaperCount: number;
   recommendations: RecommendationGroup[];
@@ -2,25 +2,55 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 import {config} from '../models';
+import {core} from '../models';
+import {alerts} from '../models';
+
+export function AddScheduledJob(arg1:main.ScheduledJob):Promise<void>;
+
+export function AddToReadingQueue(arg1:string,arg2:string):Promise<void>;
 
 export function AnalyzeSearchQuery(arg1:string):Promise<string>;
 
+export function CancelCrawlTask(arg1:string):Promise<void>;
+
 export function CleanWithOptions(arg1:main.CleanOptions):Promise<main.CleanResult>;
 
 export function ClearCrawlHistory():Promise<void>;
 
 export function ClearLogs():Promise<void>;
 
+export function ClusterPapers(arg1:number,arg2:string):Promise<string>;
+
+export function CrawlMultiplePlatforms(arg1:string):Promise<string>;
+
 export function CrawlPapers(arg1:string,arg2:Record<string, any>):Promise<string>;
 
+export function DeduplicateDatabase(arg1:string,arg2:number):Promise<string>;
+
+export function DeleteAlert(arg1:string):Promise<void>;
+
+export function DeletePapers(arg1:string,arg2:Array<string>):Promise<number>;
+
+export function DeleteSavedSearch(arg1:string):Promise<void>;
+
+export function EnrichOpenAccessURLs(arg1:string,arg2:Array<string>):Promise<string>;
+
+export function EnrichSelected(arg1:string,arg2:Array<string>):Promise<string>;
+
 export function ExportCrawlTask(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<string>;
 
 export function ExportSelection(arg1:string,arg2:string,arg3:Array<string>,arg4:string,arg5:string,arg6:string):Promise<string>;
 
-export function ExportSelectionByPapers(arg1:string,arg2:Array<Record<string, string>>,arg3:string,arg4:string,arg5:string):Promise<string>;
+export function ExportSelectionByPapers(arg1:string,arg2:Array<Record<string, string>>,arg3:string,arg4:string,arg5:string,arg6:Array<main.ExportGroup>):Promise<string>;
 
 export function ExportWithOptions(arg1:main.ExportOptions):Promise<string>;
 
+export function FetchOpenReviewDecision(arg1:string,arg2:string):Promise<string>;
+
+export function GetAvailablePlatforms():Promise<string>;
+
+export function GetConferenceDeadlines():Promise<string>;
+
 export function GetConfig():Promise<config.AppConfig>;
 
 export function GetCrawlHistory(arg1:number):Promise<string>;
@@ -33,16 +63,98 @@ export function GetCrawlTaskPapers(arg1:string):Promise<string>;
 
 export function GetDailyRecommendations(arg1:main.RecommendOptions):Promise<string>;
 
+export function GetEmbeddingMismatches():Promise<string>;
+
 export function GetLogs():Promise<string>;
 
+export function GetPaper(arg1:string,arg2:string):Promise<string>;
+
+export function GetPaperCitations(arg1:string,arg2:string):Promise<string>;
+
+export function GetPaperNote(arg1:string,arg2:string):Promise<string>;
+
+export function GetPaperVersions(arg1:string,arg2:string):Promise<string>;
+
 export function GetPapers(arg1:number,arg2:number,arg3:string,arg4:string):Promise<main.PaperListResponse>;
 
+export function GetPapersByStatus(arg1:string,arg2:number,arg3:number):Promise<string>;
+
+export function GetPersonalizedSuggestions(arg1:string):Promise<string>;
+
+export function GetReadingQueue(arg1:number):Promise<string>;
+
+export function GetSavedSearches():Promise<string>;
+
 export function GetSearchContext():Promise<string>;
 
+export function GetServiceStatus():Promise<string>;
+
+export function ImportBibTeX(arg1:string):Promise<string>;
+
+export function ListAlerts():Promise<string>;
+
+export function ListAvailableModels():Promise<string>;
+
+export function ListScheduledJobs():Promise<string>;
+
+export function ListZoteroGroupLibraries():Promise<string>;
+
+export function MarkQueueItemDone(arg1:string,arg2:string):Promise<void>;
+
+export function MergeDuplicates(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function NormalizeACLIds(arg1:boolean):Promise<core.NormalizeReport>;
+
+export function PreviewCrawl(arg1:string,arg2:Record<string, any>):Promise<string>;
+
+export function PreviewExportSelection(arg1:string,arg2:Array<string>):Promise<string>;
+
+export function PreviewExportSelectionByPapers(arg1:Array<Record<string, string>>,arg2:Array<main.ExportGroup>):Promise<string>;
+
+export function PurgeCrawlHistory(arg1:number):Promise<void>;
+
+export function PurgeDeleted(arg1:number):Promise<number>;
+
+export function ReembedAll(arg1:number):Promise<number>;
+
 export function ReloadConfig():Promise<void>;
 
+export function RemoveFromReadingQueue(arg1:string,arg2:string):Promise<void>;
+
+export function RemoveScheduledJob(arg1:string):Promise<void>;
+
+export function ReorderReadingQueue(arg1:Array<number>):Promise<void>;
+
+export function ResetSSRNCheckpoint():Promise<void>;
+
+export function RunAlert(arg1:string):Promise<string>;
+
+export function RunDueAlerts():Promise<void>;
+
+export function SaveAlert(arg1:alerts.Alert):Promise<string>;
+
+export function SaveCurrentSearch(arg1:string,arg2:main.SearchOptions):Promise<void>;
+
+export function SearchNotes(arg1:string):Promise<string>;
+
+export function SearchPapers(arg1:main.SearchOptions):Promise<string>;
+
 export function SearchWithOptions(arg1:main.SearchOptions):Promise<string>;
 
 export function SetLogLevel(arg1:string):Promise<void>;
 
+export function SetPaperNote(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetPaperStarred(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
+export function SetPaperStatus(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SyncToZotero(arg1:string):Promise<string>;
+
+export function TranslateMissingPapers(arg1:number,arg2:string):Promise<string>;
+
+export function TranslateSelected(arg1:string,arg2:Array<string>,arg3:string):Promise<string>;
+
+export function UndeleteSelected(arg1:string,arg2:Array<string>):Promise<number>;
+
 export function UpdateConfig(arg1:config.AppConfig):Promise<void>;
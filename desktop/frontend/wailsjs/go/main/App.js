@@ -2,10 +2,22 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddScheduledJob(arg1) {
+  return window['go']['main']['App']['AddScheduledJob'](arg1);
+}
+
+export function AddToReadingQueue(arg1, arg2) {
+  return window['go']['main']['App']['AddToReadingQueue'](arg1, arg2);
+}
+
 export function AnalyzeSearchQuery(arg1) {
   return window['go']['main']['App']['AnalyzeSearchQuery'](arg1);
 }
 
+export function CancelCrawlTask(arg1) {
+  return window['go']['main']['App']['CancelCrawlTask'](arg1);
+}
+
 export function CleanWithOptions(arg1) {
   return window['go']['main']['App']['CleanWithOptions'](arg1);
 }
@@ -18,10 +30,42 @@ export function ClearLogs() {
   return window['go']['main']['App']['ClearLogs']();
 }
 
+export function ClusterPapers(arg1, arg2) {
+  return window['go']['main']['App']['ClusterPapers'](arg1, arg2);
+}
+
+export function CrawlMultiplePlatforms(arg1) {
+  return window['go']['main']['App']['CrawlMultiplePlatforms'](arg1);
+}
+
 export function CrawlPapers(arg1, arg2) {
   return window['go']['main']['App']['CrawlPapers'](arg1, arg2);
 }
 
+export function DeduplicateDatabase(arg1, arg2) {
+  return window['go']['main']['App']['DeduplicateDatabase'](arg1, arg2);
+}
+
+export function DeleteAlert(arg1) {
+  return window['go']['main']['App']['DeleteAlert'](arg1);
+}
+
+export function DeletePapers(arg1, arg2) {
+  return window['go']['main']['App']['DeletePapers'](arg1, arg2);
+}
+
+export function DeleteSavedSearch(arg1) {
+  return window['go']['main']['App']['DeleteSavedSearch'](arg1);
+}
+
+export function EnrichOpenAccessURLs(arg1, arg2) {
+  return window['go']['main']['App']['EnrichOpenAccessURLs'](arg1, arg2);
+}
+
+export function EnrichSelected(arg1, arg2) {
+  return window['go']['main']['App']['EnrichSelected'](arg1, arg2);
+}
+
 export function ExportCrawlTask(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['ExportCrawlTask'](arg1, arg2, arg3, arg4, arg5);
 }
@@ -30,14 +74,26 @@ export function ExportSelection(arg1, arg2, arg3, arg4, arg5, arg6) {
   return window['go']['main']['App']['ExportSelection'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
-export function ExportSelectionByPapers(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['App']['ExportSelectionByPapers'](arg1, arg2, arg3, arg4, arg5);
+export function ExportSelectionByPapers(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['ExportSelectionByPapers'](arg1, arg2, arg3, arg4, arg5, arg6);
 }
 
 export function ExportWithOptions(arg1) {
   return window['go']['main']['App']['ExportWithOptions'](arg1);
 }
 
+export function FetchOpenReviewDecision(arg1, arg2) {
+  return window['go']['main']['App']['FetchOpenReviewDecision'](arg1, arg2);
+}
+
+export function GetAvailablePlatforms() {
+  return window['go']['main']['App']['GetAvailablePlatforms']();
+}
+
+export function GetConferenceDeadlines() {
+  return window['go']['main']['App']['GetConferenceDeadlines']();
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
@@ -62,22 +118,158 @@ export function GetDailyRecommendations(arg1) {
   return window['go']['main']['App']['GetDailyRecommendations'](arg1);
 }
 
+export function GetEmbeddingMismatches() {
+  return window['go']['main']['App']['GetEmbeddingMismatches']();
+}
+
 export function GetLogs() {
   return window['go']['main']['App']['GetLogs']();
 }
 
+export function GetPaper(arg1, arg2) {
+  return window['go']['main']['App']['GetPaper'](arg1, arg2);
+}
+
+export function GetPaperCitations(arg1, arg2) {
+  return window['go']['main']['App']['GetPaperCitations'](arg1, arg2);
+}
+
+export function GetPaperNote(arg1, arg2) {
+  return window['go']['main']['App']['GetPaperNote'](arg1, arg2);
+}
+
+export function GetPaperVersions(arg1, arg2) {
+  return window['go']['main']['App']['GetPaperVersions'](arg1, arg2);
+}
+
 export function GetPapers(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['GetPapers'](arg1, arg2, arg3, arg4);
 }
 
+export function GetPapersByStatus(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetPapersByStatus'](arg1, arg2, arg3);
+}
+
+export function GetPersonalizedSuggestions(arg1) {
+  return window['go']['main']['App']['GetPersonalizedSuggestions'](arg1);
+}
+
+export function GetReadingQueue(arg1) {
+  return window['go']['main']['App']['GetReadingQueue'](arg1);
+}
+
+export function GetSavedSearches() {
+  return window['go']['main']['App']['GetSavedSearches']();
+}
+
 export function GetSearchContext() {
   return window['go']['main']['App']['GetSearchContext']();
 }
 
+export function GetServiceStatus() {
+  return window['go']['main']['App']['GetServiceStatus']();
+}
+
+export function ImportBibTeX(arg1) {
+  return window['go']['main']['App']['ImportBibTeX'](arg1);
+}
+
+export function ListAlerts() {
+  return window['go']['main']['App']['ListAlerts']();
+}
+
+export function ListAvailableModels() {
+  return window['go']['main']['App']['ListAvailableModels']();
+}
+
+export function ListScheduledJobs() {
+  return window['go']['main']['App']['ListScheduledJobs']();
+}
+
+export function ListZoteroGroupLibraries() {
+  return window['go']['main']['App']['ListZoteroGroupLibraries']();
+}
+
+export function MarkQueueItemDone(arg1, arg2) {
+  return window['go']['main']['App']['MarkQueueItemDone'](arg1, arg2);
+}
+
+export function MergeDuplicates(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['MergeDuplicates'](arg1, arg2, arg3, arg4);
+}
+
+export function NormalizeACLIds(arg1) {
+  return window['go']['main']['App']['NormalizeACLIds'](arg1);
+}
+
+export function PreviewCrawl(arg1, arg2) {
+  return window['go']['main']['App']['PreviewCrawl'](arg1, arg2);
+}
+
+export function PreviewExportSelection(arg1, arg2) {
+  return window['go']['main']['App']['PreviewExportSelection'](arg1, arg2);
+}
+
+export function PreviewExportSelectionByPapers(arg1, arg2) {
+  return window['go']['main']['App']['PreviewExportSelectionByPapers'](arg1, arg2);
+}
+
+export function PurgeCrawlHistory(arg1) {
+  return window['go']['main']['App']['PurgeCrawlHistory'](arg1);
+}
+
+export function PurgeDeleted(arg1) {
+  return window['go']['main']['App']['PurgeDeleted'](arg1);
+}
+
+export function ReembedAll(arg1) {
+  return window['go']['main']['App']['ReembedAll'](arg1);
+}
+
 export function ReloadConfig() {
   return window['go']['main']['App']['ReloadConfig']();
 }
 
+export function RemoveFromReadingQueue(arg1, arg2) {
+  return window['go']['main']['App']['RemoveFromReadingQueue'](arg1, arg2);
+}
+
+export function RemoveScheduledJob(arg1) {
+  return window['go']['main']['App']['RemoveScheduledJob'](arg1);
+}
+
+export function ReorderReadingQueue(arg1) {
+  return window['go']['main']['App']['ReorderReadingQueue'](arg1);
+}
+
+export function ResetSSRNCheckpoint() {
+  return window['go']['main']['App']['ResetSSRNCheckpoint']();
+}
+
+export function RunAlert(arg1) {
+  return window['go']['main']['App']['RunAlert'](arg1);
+}
+
+export function RunDueAlerts() {
+  return window['go']['main']['App']['RunDueAlerts']();
+}
+
+export function SaveAlert(arg1) {
+  return window['go']['main']['App']['SaveAlert'](arg1);
+}
+
+export function SaveCurrentSearch(arg1, arg2) {
+  return window['go']['main']['App']['SaveCurrentSearch'](arg1, arg2);
+}
+
+export function SearchNotes(arg1) {
+  return window['go']['main']['App']['SearchNotes'](arg1);
+}
+
+export function SearchPapers(arg1) {
+  return window['go']['main']['App']['SearchPapers'](arg1);
+}
+
 export function SearchWithOptions(arg1) {
   return window['go']['main']['App']['SearchWithOptions'](arg1);
 }
@@ -86,6 +278,34 @@ export function SetLogLevel(arg1) {
   return window['go']['main']['App']['SetLogLevel'](arg1);
 }
 
+export function SetPaperNote(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetPaperNote'](arg1, arg2, arg3);
+}
+
+export function SetPaperStarred(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetPaperStarred'](arg1, arg2, arg3);
+}
+
+export function SetPaperStatus(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetPaperStatus'](arg1, arg2, arg3);
+}
+
+export function SyncToZotero(arg1) {
+  return window['go']['main']['App']['SyncToZotero'](arg1);
+}
+
+export function TranslateMissingPapers(arg1, arg2) {
+  return window['go']['main']['App']['TranslateMissingPapers'](arg1, arg2);
+}
+
+export function TranslateSelected(arg1, arg2, arg3) {
+  return window['go']['main']['App']['TranslateSelected'](arg1, arg2, arg3);
+}
+
+export function UndeleteSelected(arg1, arg2) {
+  return window['go']['main']['App']['UndeleteSelected'](arg1, arg2);
+}
+
 export function UpdateConfig(arg1) {
   return window['go']['main']['App']['UpdateConfig'](arg1);
 }
@@ -1,12 +1,53 @@
+export namespace alerts {
+	
+	export class Alert {
+	    id: string;
+	    name: string;
+	    query: string;
+	    sources?: string[];
+	    categories?: string[];
+	    semantic: boolean;
+	    window_days: number;
+	    interval_hours: number;
+	    top_k: number;
+	    created_at: any;
+	    last_run_at?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Alert(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.query = source["query"];
+	        this.sources = source["sources"];
+	        this.categories = source["categories"];
+	        this.semantic = source["semantic"];
+	        this.window_days = source["window_days"];
+	        this.interval_hours = source["interval_hours"];
+	        this.top_k = source["top_k"];
+	        this.created_at = source["created_at"];
+	        this.last_run_at = source["last_run_at"];
+	    }
+	}
+
+}
+
 export namespace acl {
 	
 	export class Config {
 	    BaseURL: string;
 	    Timeout: number;
 	    Proxy: string;
+	    ProxyPool: string[];
 	    Step: number;
 	    UseRSS: boolean;
 	    UseBibTeX: boolean;
+	    RateLimitRPS: number;
+	    InsecureSkipVerify: boolean;
+	    DialTimeout: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Config(source);
@@ -17,9 +58,13 @@ export namespace acl {
 	        this.BaseURL = source["BaseURL"];
 	        this.Timeout = source["Timeout"];
 	        this.Proxy = source["Proxy"];
+	        this.ProxyPool = source["ProxyPool"];
 	        this.Step = source["Step"];
 	        this.UseRSS = source["UseRSS"];
 	        this.UseBibTeX = source["UseBibTeX"];
+	        this.RateLimitRPS = source["RateLimitRPS"];
+	        this.InsecureSkipVerify = source["InsecureSkipVerify"];
+	        this.DialTimeout = source["DialTimeout"];
 	    }
 	}
 
@@ -35,6 +80,14 @@ export namespace arxiv {
 	    APIBase: string;
 	    WebBase: string;
 	    NewBase: string;
+	    DailyArchives: string[];
+	    Holidays: string[];
+	    FetchCitations: boolean;
+	    CitationAPI: string;
+	    RateLimitRPS: number;
+	    ProxyPool: string[];
+	    InsecureSkipVerify: boolean;
+	    DialTimeout: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Config(source);
@@ -49,6 +102,14 @@ export namespace arxiv {
 	        this.APIBase = source["APIBase"];
 	        this.WebBase = source["WebBase"];
 	        this.NewBase = source["NewBase"];
+	        this.DailyArchives = source["DailyArchives"];
+	        this.Holidays = source["Holidays"];
+	        this.FetchCitations = source["FetchCitations"];
+	        this.CitationAPI = source["CitationAPI"];
+	        this.RateLimitRPS = source["RateLimitRPS"];
+	        this.ProxyPool = source["ProxyPool"];
+	        this.InsecureSkipVerify = source["InsecureSkipVerify"];
+	        this.DialTimeout = source["DialTimeout"];
 	    }
 	}
 
@@ -60,6 +121,11 @@ export namespace config {
 	    BaseURL: string;
 	    ModelName: string;
 	    APIKey: string;
+	    TranslationModel: string;
+	    HyDECache: boolean;
+	    HyDECacheTTL: number;
+	    HyDECandidates: number;
+	    HyDETemperature: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new LLMConfig(source);
@@ -70,10 +136,16 @@ export namespace config {
 	        this.BaseURL = source["BaseURL"];
 	        this.ModelName = source["ModelName"];
 	        this.APIKey = source["APIKey"];
+	        this.TranslationModel = source["TranslationModel"];
+	        this.HyDECache = source["HyDECache"];
+	        this.HyDECacheTTL = source["HyDECacheTTL"];
+	        this.HyDECandidates = source["HyDECandidates"];
+	        this.HyDETemperature = source["HyDETemperature"];
 	    }
 	}
 	export class DatabaseConfig {
 	    Path: string;
+	    MergeCrossSource: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new DatabaseConfig(source);
@@ -82,18 +154,87 @@ export namespace config {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.Path = source["Path"];
+	        this.MergeCrossSource = source["MergeCrossSource"];
+	    }
+	}
+	export class HTTPConfig {
+	    MaxConcurrent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HTTPConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.MaxConcurrent = source["MaxConcurrent"];
+	    }
+	}
+	export class IRConfig {
+	    StopwordLang: string;
+	    CustomStopwords: string[];
+	    Stemming: boolean;
+	    MinTokenLength: number;
+	    PersistIndex: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new IRConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.StopwordLang = source["StopwordLang"];
+	        this.CustomStopwords = source["CustomStopwords"];
+	        this.Stemming = source["Stemming"];
+	        this.MinTokenLength = source["MinTokenLength"];
+	        this.PersistIndex = source["PersistIndex"];
+	    }
+	}
+	export class MetricsConfig {
+	    Enabled: boolean;
+	    Port: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MetricsConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Enabled = source["Enabled"];
+	        this.Port = source["Port"];
+	    }
+	}
+	export class ServerConfig {
+	    Addr: string;
+	    AuthToken: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Addr = source["Addr"];
+	        this.AuthToken = source["AuthToken"];
 	    }
 	}
 	export class AppConfig {
 	    Env: string;
 	    Embedder: embedding.EmbedderConfig;
 	    Database: DatabaseConfig;
+	    HTTP: HTTPConfig;
+	    IR: IRConfig;
+	    Metrics: MetricsConfig;
+	    Server: ServerConfig;
 	    Zotero: core.ZoteroConfig;
 	    FeiShu: core.FeiShuConfig;
+	    Notion: core.NotionConfig;
+	    CrossRef: core.CrossRefConfig;
+	    Unpaywall: core.UnpaywallConfig;
 	    Arxiv: arxiv.Config;
 	    OpenReview: openreview.Config;
 	    ACL: acl.Config;
 	    SSRN: ssrn.Config;
+	    DBLP: dblp.Config;
 	    LLM: LLMConfig;
 	
 	    static createFrom(source: any = {}) {
@@ -105,12 +246,20 @@ export namespace config {
 	        this.Env = source["Env"];
 	        this.Embedder = this.convertValues(source["Embedder"], embedding.EmbedderConfig);
 	        this.Database = this.convertValues(source["Database"], DatabaseConfig);
+	        this.HTTP = this.convertValues(source["HTTP"], HTTPConfig);
+	        this.IR = this.convertValues(source["IR"], IRConfig);
+	        this.Metrics = this.convertValues(source["Metrics"], MetricsConfig);
+	        this.Server = this.convertValues(source["Server"], ServerConfig);
 	        this.Zotero = this.convertValues(source["Zotero"], core.ZoteroConfig);
 	        this.FeiShu = this.convertValues(source["FeiShu"], core.FeiShuConfig);
+	        this.Notion = this.convertValues(source["Notion"], core.NotionConfig);
+	        this.CrossRef = this.convertValues(source["CrossRef"], core.CrossRefConfig);
+	        this.Unpaywall = this.convertValues(source["Unpaywall"], core.UnpaywallConfig);
 	        this.Arxiv = this.convertValues(source["Arxiv"], arxiv.Config);
 	        this.OpenReview = this.convertValues(source["OpenReview"], openreview.Config);
 	        this.ACL = this.convertValues(source["ACL"], acl.Config);
 	        this.SSRN = this.convertValues(source["SSRN"], ssrn.Config);
+	        this.DBLP = this.convertValues(source["DBLP"], dblp.Config);
 	        this.LLM = this.convertValues(source["LLM"], LLMConfig);
 	    }
 	
@@ -138,9 +287,30 @@ export namespace config {
 
 export namespace core {
 	
+	export class CrossRefConfig {
+	    Enabled: boolean;
+	    Mailto: string;
+	    RateLimitPerSecond: number;
+	    MinSimilarity: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CrossRefConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Enabled = source["Enabled"];
+	        this.Mailto = source["Mailto"];
+	        this.RateLimitPerSecond = source["RateLimitPerSecond"];
+	        this.MinSimilarity = source["MinSimilarity"];
+	    }
+	}
 	export class FeiShuConfig {
 	    AppID: string;
 	    AppSecret: string;
+	    AppToken: string;
+	    TableID: string;
+	    DedupColumns: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new FeiShuConfig(source);
@@ -150,12 +320,105 @@ export namespace core {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.AppID = source["AppID"];
 	        this.AppSecret = source["AppSecret"];
+	        this.AppToken = source["AppToken"];
+	        this.TableID = source["TableID"];
+	        this.DedupColumns = source["DedupColumns"];
+	    }
+	}
+	export class NormalizeReport {
+	    source: string;
+	    dryRun: boolean;
+	    scanned: number;
+	    renamed: number;
+	    merged: number;
+	    failed: number;
+	    changes: SourceIDChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NormalizeReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.dryRun = source["dryRun"];
+	        this.scanned = source["scanned"];
+	        this.renamed = source["renamed"];
+	        this.merged = source["merged"];
+	        this.failed = source["failed"];
+	        this.changes = this.convertValues(source["changes"], SourceIDChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NotionConfig {
+	    IntegrationToken: string;
+	    DatabaseID: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NotionConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.IntegrationToken = source["IntegrationToken"];
+	        this.DatabaseID = source["DatabaseID"];
+	    }
+	}
+	export class SourceIDChange {
+	    paperId: number;
+	    title: string;
+	    oldId: string;
+	    newId: string;
+	    mergedInto?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SourceIDChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.paperId = source["paperId"];
+	        this.title = source["title"];
+	        this.oldId = source["oldId"];
+	        this.newId = source["newId"];
+	        this.mergedInto = source["mergedInto"];
+	    }
+	}
+	export class UnpaywallConfig {
+	    Email: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UnpaywallConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Email = source["Email"];
 	    }
 	}
 	export class ZoteroConfig {
 	    UserID: string;
 	    APIKey: string;
 	    LibraryType: string;
+	    GroupID: string;
+	    InvalidCollection: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ZoteroConfig(source);
@@ -166,6 +429,37 @@ export namespace core {
 	        this.UserID = source["UserID"];
 	        this.APIKey = source["APIKey"];
 	        this.LibraryType = source["LibraryType"];
+	        this.GroupID = source["GroupID"];
+	        this.InvalidCollection = source["InvalidCollection"];
+	    }
+	}
+
+}
+
+export namespace dblp {
+	
+	export class Config {
+	    APIBase: string;
+	    Proxy: string;
+	    Timeout: number;
+	    PageSize: number;
+	    RateLimitRPS: number;
+	    InsecureSkipVerify: boolean;
+	    DialTimeout: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Config(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.APIBase = source["APIBase"];
+	        this.Proxy = source["Proxy"];
+	        this.Timeout = source["Timeout"];
+	        this.PageSize = source["PageSize"];
+	        this.RateLimitRPS = source["RateLimitRPS"];
+	        this.InsecureSkipVerify = source["InsecureSkipVerify"];
+	        this.DialTimeout = source["DialTimeout"];
 	    }
 	}
 
@@ -178,6 +472,9 @@ export namespace embedding {
 	    APIKey: string;
 	    ModelName: string;
 	    Dim: number;
+	    UseVectorIndex: boolean;
+	    CacheSize: number;
+	    Quantize: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new EmbedderConfig(source);
@@ -189,6 +486,9 @@ export namespace embedding {
 	        this.APIKey = source["APIKey"];
 	        this.ModelName = source["ModelName"];
 	        this.Dim = source["Dim"];
+	        this.UseVectorIndex = source["UseVectorIndex"];
+	        this.CacheSize = source["CacheSize"];
+	        this.Quantize = source["Quantize"];
 	    }
 	}
 
@@ -236,15 +536,69 @@ export namespace main {
 	        this.exportedPath = source["exportedPath"];
 	    }
 	}
+	export class ExportGroupItem {
+	    source: string;
+	    id: string;
+	    similarity: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportGroupItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.id = source["id"];
+	        this.similarity = source["similarity"];
+	    }
+	}
+	export class ExportGroup {
+	    seed_title: string;
+	    papers: ExportGroupItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seed_title = source["seed_title"];
+	        this.papers = this.convertValues(source["papers"], ExportGroupItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class ExportOptions {
 	    format: string;
 	    output: string;
+	    splitFiles: boolean;
 	    query: string;
 	    keywords: string[];
 	    categories: string[];
 	    source: string;
 	    collection: string;
 	    feishuName: string;
+	    feishuAppToken: string;
+	    feishuTableId: string;
+	    notionName: string;
+	    status: string;
+	    starred: boolean;
 	    limit: number;
 	
 	    static createFrom(source: any = {}) {
@@ -255,12 +609,18 @@ export namespace main {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.format = source["format"];
 	        this.output = source["output"];
+	        this.splitFiles = source["splitFiles"];
 	        this.query = source["query"];
 	        this.keywords = source["keywords"];
 	        this.categories = source["categories"];
 	        this.source = source["source"];
 	        this.collection = source["collection"];
 	        this.feishuName = source["feishuName"];
+	        this.feishuAppToken = source["feishuAppToken"];
+	        this.feishuTableId = source["feishuTableId"];
+	        this.notionName = source["notionName"];
+	        this.status = source["status"];
+	        this.starred = source["starred"];
 	        this.limit = source["limit"];
 	    }
 	}
@@ -303,10 +663,22 @@ export namespace main {
 	    topK: number;
 	    maxRecommendations: number;
 	    forceCrawl: boolean;
+	    skipCrawl: boolean;
+	    archives: string[];
 	    dateFrom: string;
 	    dateTo: string;
 	    localFilePath: string;
 	    localFileAction: string;
+	    seedSource: string;
+	    openreviewVenue: string;
+	    openreviewDecisions: string[];
+	    openreviewMinScore: number;
+	    seedSources: string[];
+	    seedMode: string;
+	    diversityLambda: number;
+	    minSimilarity?: number;
+	    sortBy: string;
+	    sortDesc: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new RecommendOptions(source);
@@ -320,10 +692,46 @@ export namespace main {
 	        this.topK = source["topK"];
 	        this.maxRecommendations = source["maxRecommendations"];
 	        this.forceCrawl = source["forceCrawl"];
+	        this.skipCrawl = source["skipCrawl"];
+	        this.archives = source["archives"];
 	        this.dateFrom = source["dateFrom"];
 	        this.dateTo = source["dateTo"];
 	        this.localFilePath = source["localFilePath"];
 	        this.localFileAction = source["localFileAction"];
+	        this.seedSource = source["seedSource"];
+	        this.openreviewVenue = source["openreviewVenue"];
+	        this.openreviewDecisions = source["openreviewDecisions"];
+	        this.openreviewMinScore = source["openreviewMinScore"];
+	        this.seedSources = source["seedSources"];
+	        this.seedMode = source["seedMode"];
+	        this.diversityLambda = source["diversityLambda"];
+	        this.minSimilarity = source["minSimilarity"];
+	        this.sortBy = source["sortBy"];
+	        this.sortDesc = source["sortDesc"];
+	    }
+	}
+	export class ScheduledJob {
+	    id: string;
+	    platform: string;
+	    params: Record<string, any>;
+	    cron_expr: string;
+	    created_at: any;
+	    last_run_at?: any;
+	    last_task_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScheduledJob(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.platform = source["platform"];
+	        this.params = source["params"];
+	        this.cron_expr = source["cron_expr"];
+	        this.created_at = source["created_at"];
+	        this.last_run_at = source["last_run_at"];
+	        this.last_task_id = source["last_task_id"];
 	    }
 	}
 	export class SearchExample {
@@ -351,8 +759,17 @@ export namespace main {
 	    until: string;
 	    computeEmbed: boolean;
 	    embedBatch: number;
+	    fts: boolean;
 	    ir: boolean;
 	    irAlgorithm: string;
+	    hybrid: boolean;
+	    hybridAlpha: number;
+	    sortBy: string;
+	    sortDesc: boolean;
+	    authorQuery: string;
+	    authors: string[];
+	    status: string;
+	    starred: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new SearchOptions(source);
@@ -370,8 +787,17 @@ export namespace main {
 	        this.until = source["until"];
 	        this.computeEmbed = source["computeEmbed"];
 	        this.embedBatch = source["embedBatch"];
+	        this.fts = source["fts"];
 	        this.ir = source["ir"];
 	        this.irAlgorithm = source["irAlgorithm"];
+	        this.hybrid = source["hybrid"];
+	        this.hybridAlpha = source["hybridAlpha"];
+	        this.sortBy = source["sortBy"];
+	        this.sortDesc = source["sortDesc"];
+	        this.authorQuery = source["authorQuery"];
+	        this.authors = source["authors"];
+	        this.status = source["status"];
+	        this.starred = source["starred"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -409,9 +835,18 @@ export namespace models {
 	    AbstractTranslated: string;
 	    Categories: string[];
 	    Comments: string;
+	    Citations: number;
+	    Decision: string;
+	    References: string[];
+	    AltSources: string[];
+	    OpenAccessURL: string;
 	    FirstSubmittedAt: string;
 	    FirstAnnouncedAt: string;
 	    UpdatedAt: string;
+	    Status: string;
+	    Starred: boolean;
+	    LatestVersion: string;
+	    VersionHistory: PaperVersion[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Paper(source);
@@ -430,9 +865,52 @@ export namespace models {
 	        this.AbstractTranslated = source["AbstractTranslated"];
 	        this.Categories = source["Categories"];
 	        this.Comments = source["Comments"];
+	        this.Citations = source["Citations"];
+	        this.Decision = source["Decision"];
+	        this.References = source["References"];
+	        this.AltSources = source["AltSources"];
+	        this.OpenAccessURL = source["OpenAccessURL"];
 	        this.FirstSubmittedAt = source["FirstSubmittedAt"];
 	        this.FirstAnnouncedAt = source["FirstAnnouncedAt"];
 	        this.UpdatedAt = source["UpdatedAt"];
+	        this.Status = source["Status"];
+	        this.Starred = source["Starred"];
+	        this.LatestVersion = source["LatestVersion"];
+	        this.VersionHistory = this.convertValues(source["VersionHistory"], PaperVersion);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PaperVersion {
+	    Version: string;
+	    SubmittedAt: string;
+	    ChangeNote: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaperVersion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Version = source["Version"];
+	        this.SubmittedAt = source["SubmittedAt"];
+	        this.ChangeNote = source["ChangeNote"];
 	    }
 	}
 
@@ -444,6 +922,9 @@ export namespace openreview {
 	    APIBase: string;
 	    Proxy: string;
 	    Timeout: number;
+	    RateLimitRPS: number;
+	    InsecureSkipVerify: boolean;
+	    DialTimeout: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Config(source);
@@ -454,6 +935,9 @@ export namespace openreview {
 	        this.APIBase = source["APIBase"];
 	        this.Proxy = source["Proxy"];
 	        this.Timeout = source["Timeout"];
+	        this.RateLimitRPS = source["RateLimitRPS"];
+	        this.InsecureSkipVerify = source["InsecureSkipVerify"];
+	        this.DialTimeout = source["DialTimeout"];
 	    }
 	}
 
@@ -469,6 +953,11 @@ export namespace ssrn {
 	    MaxPages: number;
 	    RateLimitPerSecond: number;
 	    Sort: string;
+	    FetchAbstracts: boolean;
+	    InsecureSkipVerify: boolean;
+	    DialTimeout: number;
+	    CheckpointPath: string;
+	    ForceFullCrawl: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Config(source);
@@ -483,6 +972,11 @@ export namespace ssrn {
 	        this.MaxPages = source["MaxPages"];
 	        this.RateLimitPerSecond = source["RateLimitPerSecond"];
 	        this.Sort = source["Sort"];
+	        this.FetchAbstracts = source["FetchAbstracts"];
+	        this.InsecureSkipVerify = source["InsecureSkipVerify"];
+	        this.DialTimeout = source["DialTimeout"];
+	        this.CheckpointPath = source["CheckpointPath"];
+	        this.ForceFullCrawl = source["ForceFullCrawl"];
 	    }
 	}
 
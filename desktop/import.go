@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"PaperHunter/internal/core/importer"
+	"PaperHunter/pkg/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ImportBibTeX 从 .bib 文件批量导入论文（入库时同时生成向量），返回 JSON 统计
+func (a *App) ImportBibTeX(filePath string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open bibtex file: %w", err)
+	}
+	defer f.Close()
+
+	logger.Info("开始导入 BibTeX: %s", filePath)
+	im := importer.NewImporter(a.coreApp)
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, err = im.Import(ctx, f, func(done int) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "import-progress", done)
+		}
+	})
+	if err != nil {
+		return "", fmt.Errorf("import bibtex failed: %w", err)
+	}
+
+	data, err := json.Marshal(im.Summary())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal summary: %w", err)
+	}
+	return string(data), nil
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"PaperHunter/internal/models"
 )
@@ -42,3 +45,279 @@ func (a *App) GetPapers(page int, pageSize int, source string, search string) (*
 		Total:  total,
 	}, nil
 }
+
+// GetPaper 按 source + sourceID 获取单篇论文详情，返回 JSON
+func (a *App) GetPaper(source, sourceID string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+
+	paper, err := a.coreApp.GetPaper(context.Background(), source, sourceID)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(paper)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal paper: %w", err)
+	}
+	return string(data), nil
+}
+
+// SetPaperStatus 设置论文阅读状态：unread、reading、read、archived
+func (a *App) SetPaperStatus(source, sourceID, status string) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.SetPaperStatus(context.Background(), source, sourceID, status)
+}
+
+// GetPaperVersions 返回论文的版本历史（JSON），按提交时间从早到晚
+func (a *App) GetPaperVersions(source, sourceID string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	versions, err := a.coreApp.GetPaperVersions(context.Background(), source, sourceID)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(versions)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal versions: %w", err)
+	}
+	return string(data), nil
+}
+
+// SetPaperStarred 设置或取消论文收藏
+func (a *App) SetPaperStarred(source, sourceID string, starred bool) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.SetPaperStarred(context.Background(), source, sourceID, starred)
+}
+
+// AddToReadingQueue 将论文加到阅读队列末尾，已在队列中时保持原位置
+func (a *App) AddToReadingQueue(source, sourceID string) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.AddToReadingQueue(context.Background(), source, sourceID)
+}
+
+// RemoveFromReadingQueue 将论文移出阅读队列
+func (a *App) RemoveFromReadingQueue(source, sourceID string) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.RemoveFromReadingQueue(context.Background(), source, sourceID)
+}
+
+// ReorderReadingQueue 按论文 ID 顺序调整阅读队列（拖拽排序），未列出的论文排在后面
+func (a *App) ReorderReadingQueue(paperIDs []int64) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.ReorderReadingQueue(context.Background(), paperIDs)
+}
+
+// GetReadingQueue 按队列顺序获取论文，返回论文列表 JSON；limit <= 0 表示不限
+func (a *App) GetReadingQueue(limit int) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+
+	papers, err := a.coreApp.GetReadingQueue(context.Background(), limit)
+	if err != nil {
+		return "", err
+	}
+	if papers == nil {
+		papers = []*models.Paper{}
+	}
+
+	data, err := json.Marshal(papers)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal reading queue: %w", err)
+	}
+	return string(data), nil
+}
+
+// MarkQueueItemDone 将论文标记为已读（read）并移出阅读队列
+func (a *App) MarkQueueItemDone(source, sourceID string) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.MarkQueueItemDone(context.Background(), source, sourceID)
+}
+
+// MergeDuplicates 将重复论文的作者与分类并入主论文，并软删除重复论文（可通过恢复已删除论文撤销）
+func (a *App) MergeDuplicates(primarySource, primaryID, duplicateSource, duplicateID string) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.MergeDuplicates(context.Background(), primarySource, primaryID, duplicateSource, duplicateID)
+}
+
+// GetPapersByStatus 分页获取指定阅读状态的论文，返回 PaperListResponse 的 JSON
+func (a *App) GetPapersByStatus(status string, page, pageSize int) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+
+	papers, total, err := a.coreApp.GetPapersByStatus(context.Background(), status, page, pageSize)
+	if err != nil {
+		return "", err
+	}
+	if papers == nil {
+		papers = []*models.Paper{}
+	}
+
+	data, err := json.Marshal(PaperListResponse{Papers: papers, Total: total})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal papers: %w", err)
+	}
+	return string(data), nil
+}
+
+// SetPaperNote 设置论文私人笔记，note 为空时删除笔记
+func (a *App) SetPaperNote(source, sourceID, note string) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.SetPaperNote(context.Background(), source, sourceID, note)
+}
+
+// GetPaperNote 获取论文私人笔记，没有笔记时为空字符串
+func (a *App) GetPaperNote(source, sourceID string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.GetPaperNote(context.Background(), source, sourceID)
+}
+
+// SearchNotes 按子串搜索私人笔记，返回带完整论文信息的 NotedPaper 列表 JSON
+func (a *App) SearchNotes(query string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+
+	results, err := a.coreApp.SearchNotes(context.Background(), query, 0)
+	if err != nil {
+		return "", err
+	}
+	if results == nil {
+		results = []*models.NotedPaper{}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal notes: %w", err)
+	}
+	return string(data), nil
+}
+
+// FetchOpenReviewDecision 查询 OpenReview 论文的录用决定（如 "Accept (Oral)"），已入库的论文会同时保存；尚未公布时返回空字符串
+func (a *App) FetchOpenReviewDecision(venue, paperID string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	return a.coreApp.FetchDecision(context.Background(), "openreview", venue, paperID)
+}
+
+// EnrichSelected 通过 CrossRef 补全选中论文缺失的 DOI、日期、会议/期刊与引用数（已有 DOI 的按 DOI 查询），
+// 返回 EnrichReport JSON；需在配置中启用 crossref
+func (a *App) EnrichSelected(source string, ids []string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no papers selected")
+	}
+
+	conditions, params := selectionConditions(source, ids)
+	report, err := a.coreApp.EnrichPapers(context.Background(), conditions, params)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return string(data), nil
+}
+
+// EnrichOpenAccessURLs 通过 Unpaywall 为选中的、带 DOI 但缺少开放获取链接的论文补全 PDF 链接，
+// 返回 EnrichReport JSON；需在配置中填写 unpaywall.email
+func (a *App) EnrichOpenAccessURLs(source string, ids []string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no papers selected")
+	}
+
+	conditions, params := selectionConditions(source, ids)
+	report, err := a.coreApp.EnrichOpenAccessURLs(context.Background(), conditions, params)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return string(data), nil
+}
+
+// clusterFilter ClusterPapers 的过滤条件，字段均可为空
+type clusterFilter struct {
+	Source string `json:"source"`
+	From   string `json:"from"`  // YYYY-MM-DD
+	Until  string `json:"until"` // YYYY-MM-DD
+}
+
+// ClusterPapers 按向量将论文聚成 k 个主题簇；conditions 为 JSON 过滤条件
+// {"source": "arxiv", "from": "2024-01-01", "until": "2024-12-31"}，为空时聚类全部论文
+func (a *App) ClusterPapers(k int, conditions string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("core app not initialized")
+	}
+
+	var filter clusterFilter
+	if strings.TrimSpace(conditions) != "" {
+		if err := json.Unmarshal([]byte(conditions), &filter); err != nil {
+			return "", fmt.Errorf("invalid cluster conditions: %w", err)
+		}
+	}
+	var conds []string
+	var params []interface{}
+	if filter.Source != "" {
+		conds = append(conds, "source = ?")
+		params = append(params, filter.Source)
+	}
+	if filter.From != "" {
+		t, err := time.Parse("2006-01-02", filter.From)
+		if err != nil {
+			return "", fmt.Errorf("invalid from date: %w", err)
+		}
+		conds = append(conds, "first_announced_at >= ?")
+		params = append(params, t)
+	}
+	if filter.Until != "" {
+		t, err := time.Parse("2006-01-02", filter.Until)
+		if err != nil {
+			return "", fmt.Errorf("invalid until date: %w", err)
+		}
+		conds = append(conds, "first_announced_at <= ?")
+		params = append(params, t)
+	}
+
+	clusters, err := a.coreApp.ClusterPapers(context.Background(), k, conds, params)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(clusters)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal clusters: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestReadingQueue_MarkDone(t *testing.T) {
+	app := newTestApp(t)
+	ctx := context.Background()
+	var papers []*models.Paper
+	for i := 0; i < 3; i++ {
+		papers = append(papers, &models.Paper{Source: "arxiv", SourceID: fmt.Sprint(i), URL: fmt.Sprintf("https://arxiv.org/abs/%d", i), Title: fmt.Sprintf("Paper %d", i)})
+	}
+	if _, err := app.coreApp.SavePapers(ctx, papers); err != nil {
+		t.Fatalf("保存论文失败: %v", err)
+	}
+	for _, p := range papers {
+		if err := app.AddToReadingQueue(p.Source, p.SourceID); err != nil {
+			t.Fatalf("加入阅读队列失败: %v", err)
+		}
+	}
+
+	if err := app.MarkQueueItemDone("arxiv", "0"); err != nil {
+		t.Fatalf("标记阅读完成失败: %v", err)
+	}
+
+	out, err := app.GetReadingQueue(0)
+	if err != nil {
+		t.Fatalf("获取阅读队列失败: %v", err)
+	}
+	var queue []*models.Paper
+	if err := json.Unmarshal([]byte(out), &queue); err != nil {
+		t.Fatalf("解析阅读队列失败: %v", err)
+	}
+	if len(queue) != 2 || queue[0].SourceID != "1" || queue[1].SourceID != "2" {
+		t.Fatalf("期望队列剩余 1、2，实际: %s", out)
+	}
+
+	read, _, err := app.coreApp.GetPapersByStatus(ctx, models.StatusRead, 1, 10)
+	if err != nil || len(read) != 1 || read[0].SourceID != "0" {
+		t.Errorf("期望已完成论文状态为 read，实际: %v, %v", read, err)
+	}
+
+	if err := app.MarkQueueItemDone("arxiv", "missing"); err == nil {
+		t.Error("不存在的论文应返回错误")
+	}
+}
+
+func TestGetPaper(t *testing.T) {
+	app := newTestApp(t)
+	paper := &models.Paper{Source: "arxiv", SourceID: "2401.00001", URL: "https://arxiv.org/abs/2401.00001", Title: "Graph Paper"}
+	if _, err := app.coreApp.SavePapers(context.Background(), []*models.Paper{paper}); err != nil {
+		t.Fatalf("保存论文失败: %v", err)
+	}
+
+	out, err := app.GetPaper("arxiv", "2401.00001")
+	if err != nil {
+		t.Fatalf("获取论文失败: %v", err)
+	}
+	var got models.Paper
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("解析论文失败: %v", err)
+	}
+	if got.Title != "Graph Paper" || got.ID == 0 {
+		t.Errorf("论文不符: %+v", got)
+	}
+
+	if _, err := app.GetPaper("arxiv", "missing"); err == nil {
+		t.Error("不存在的论文应返回错误")
+	}
+}
@@ -4,6 +4,13 @@ import (
 	"embed"
 	"runtime"
 
+	// 平台适配器在 init() 中注册到 core，新增平台只需在此匿名导入
+	_ "PaperHunter/internal/platform/acl"
+	_ "PaperHunter/internal/platform/arxiv"
+	_ "PaperHunter/internal/platform/dblp"
+	_ "PaperHunter/internal/platform/openreview"
+	_ "PaperHunter/internal/platform/ssrn"
+
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
@@ -57,6 +64,7 @@ func main() {
 			},
 			BackgroundColour: &options.RGBA{R: 255, G: 255, B: 255, A: 1},
 			OnStartup:        app.startup,
+			OnShutdown:       app.shutdown,
 			CSSDragProperty:  "--wails-draggable",
 			CSSDragValue:     "drag",
 			Mac:              macOpts,
@@ -81,6 +89,7 @@ func main() {
 			},
 			BackgroundColour: &options.RGBA{R: 255, G: 255, B: 255, A: 1},
 			OnStartup:        app.startup,
+			OnShutdown:       app.shutdown,
 			CSSDragProperty:  "--wails-draggable",
 			CSSDragValue:     "drag",
 			Mac:              macOpts,
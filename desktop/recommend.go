@@ -22,10 +22,54 @@ type RecommendOptions struct {
 	TopK               int      `json:"topK"`               // 推荐数量
 	MaxRecommendations int      `json:"maxRecommendations"` // 最大推荐总数
 	ForceCrawl         bool     `json:"forceCrawl"`         // 强制重新爬取
+	SkipCrawl          bool     `json:"skipCrawl"`          // 预览模式：不爬取，仅基于库中已有论文推荐，优先于 ForceCrawl
+	Archives           []string `json:"archives"`           // arXiv archive 列表，如 cs、stat、math，为空使用配置
 	DateFrom           string   `json:"dateFrom"`           // 开始日期 YYYY-MM-DD
 	DateTo             string   `json:"dateTo"`             // 结束日期 YYYY-MM-DD
 	LocalFilePath      string   `json:"localFilePath"`      // 本地文件路径
 	LocalFileAction    string   `json:"localFileAction"`    // 本地文件操作
+
+	// SeedSource 文献库种子来源："zotero"（默认）或 "openreview_accepted"
+	SeedSource          string   `json:"seedSource"`
+	OpenReviewVenue     string   `json:"openreviewVenue"`     // 会议 ID，如 NeurIPS.cc/2024/Conference
+	OpenReviewDecisions []string `json:"openreviewDecisions"` // 录用类型过滤，如 oral、spotlight
+	OpenReviewMinScore  float64  `json:"openreviewMinScore"`  // 评审平均分下限
+
+	// SeedSources 种子来源顺序（interest/local_file/zotero/openreview_accepted），为空使用默认顺序
+	SeedSources []string `json:"seedSources"`
+	// SeedMode 来源组合方式：fallback（首个有结果的来源）或 combine（合并全部来源），见 seeds.go
+	SeedMode string `json:"seedMode"`
+
+	// DiversityLambda MMR 多样化系数，取值 (0,1)：越小越偏向多样性，0 或 >=1 时不做多样化、只按得分排序
+	DiversityLambda float64 `json:"diversityLambda"`
+
+	// MinSimilarity 推荐论文的相似度下限，取值 [0,1]；为空时使用 defaultMinSimilarity，0 表示不过滤
+	MinSimilarity *float32 `json:"minSimilarity,omitempty"`
+
+	// SortBy 每组推荐的排序字段：similarity、date、citations；为空时保持推荐打分顺序
+	SortBy   string `json:"sortBy"`
+	SortDesc bool   `json:"sortDesc"` // 降序排列
+}
+
+// defaultMinSimilarity 推荐结果默认的相似度下限
+const defaultMinSimilarity float32 = 0.2
+
+// resolveMinSimilarity 返回推荐使用的相似度下限：未设置时为 defaultMinSimilarity，超出 [0,1] 时返回错误
+func resolveMinSimilarity(v *float32) (float32, error) {
+	if v == nil {
+		return defaultMinSimilarity, nil
+	}
+	if *v < 0 || *v > 1 {
+		return 0, fmt.Errorf("min similarity must be within [0,1], got %v", *v)
+	}
+	return *v, nil
+}
+
+// defaultRecommendSeedPlan 每日推荐默认合并文献库、本地文件与兴趣描述三类种子
+var defaultRecommendSeedPlan = seedPlan{
+	Sources: []string{SeedSourceZotero, SeedSourceLocalFile, SeedSourceInterest},
+	Mode:    SeedModeCombine,
+	Limit:   50,
 }
 
 type AgentLogEntry struct {
@@ -35,12 +79,14 @@ type AgentLogEntry struct {
 }
 
 type RecommendResult struct {
-	CrawledToday    bool                  `json:"crawledToday"`
-	ArxivCrawlCount int                   `json:"arxivCrawlCount"`
-	SeedPaperCount  int                   `json:"seedPaperCount"`
-	Recommendations []RecommendationGroup `json:"recommendations"`
-	Message         string                `json:"message"`
-	AgentLogs       []AgentLogEntry       `json:"agentLogs"`
+	CrawledToday     bool                  `json:"crawledToday"`
+	AnnouncementDate string                `json:"announcementDate"` // 实际获取的 arXiv 列表的公布日 YYYY-MM-DD，周末、节假日为上一个公布日
+	ArxivCrawlCount  int                   `json:"arxivCrawlCount"`
+	CrawlSkipped     bool                  `json:"crawlSkipped"` // 预览模式，本次未爬取新论文
+	SeedPaperCount   int                   `json:"seedPaperCount"`
+	Recommendations  []RecommendationGroup `json:"recommendations"`
+	Message          string                `json:"message"`
+	AgentLogs        []AgentLogEntry       `json:"agentLogs"`
 }
 
 type UserIntent struct {
@@ -48,6 +94,14 @@ type UserIntent struct {
 	GeneratedAbstract string `json:"generated_abstract"`
 }
 
+// skipCrawlMessage 预览模式下在结果消息前注明未爬取
+func skipCrawlMessage(opts RecommendOptions, msg string) string {
+	if !opts.SkipCrawl {
+		return msg
+	}
+	return "（预览模式，未爬取新论文）" + msg
+}
+
 func (a *App) logAndEmit(log AgentLogEntry) {
 	if a.ctx != nil {
 		runtime.EventsEmit(a.ctx, "agent-log", log)
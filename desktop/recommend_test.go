@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"PaperHunter/config"
+	"PaperHunter/internal/core"
+	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/platform"
+	"PaperHunter/internal/platform/arxiv"
+)
+
+// newRecommendTestApp 创建 arXiv New Submissions 指向本地服务器的 App，返回服务器收到的请求数
+func newRecommendTestApp(t *testing.T) (*App, *atomic.Int32) {
+	t.Helper()
+	// 爬取状态文件写在 HOME 下，隔离到临时目录
+	t.Setenv("HOME", t.TempDir())
+
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("<html><body></body></html>"))
+	}))
+	t.Cleanup(srv.Close)
+
+	arxivCfg := arxiv.DefaultConfig()
+	arxivCfg.NewBase = srv.URL
+	arxivCfg.RateLimitRPS = 0
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	coreApp, err := core.NewApp(dbPath, emb.EmbedderConfig{}, map[string]platform.Config{"arxiv": arxivCfg}, core.ZoteroConfig{}, core.FeiShuConfig{}, core.NotionConfig{})
+	if err != nil {
+		t.Fatalf("创建核心模块失败: %v", err)
+	}
+	app := &App{
+		coreApp: coreApp,
+		config:  &config.AppConfig{Database: config.DatabaseConfig{Path: dbPath}},
+	}
+	return app, &hits
+}
+
+func TestGetDailyRecommendations_SkipCrawl(t *testing.T) {
+	app, hits := newRecommendTestApp(t)
+	localFile := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(localFile, []byte(`{"title":"Graph Neural Networks","abstract":"Message passing."}`), 0644); err != nil {
+		t.Fatalf("写入本地种子文件失败: %v", err)
+	}
+
+	opts := RecommendOptions{
+		LocalFilePath: localFile,
+		SeedSources:   []string{SeedSourceLocalFile},
+		ForceCrawl:    true,
+		SkipCrawl:     true,
+	}
+	data, err := app.getDailyRecommendationsDirect(opts, nil)
+	if err != nil {
+		t.Fatalf("获取推荐失败: %v", err)
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("SkipCrawl 时不应发起爬取请求，实际 %d 次", n)
+	}
+
+	var result RecommendResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("解析推荐结果失败: %v", err)
+	}
+	if !result.CrawlSkipped || result.ArxivCrawlCount != 0 {
+		t.Errorf("期望结果标记未爬取，实际 crawlSkipped=%v arxivCrawlCount=%d", result.CrawlSkipped, result.ArxivCrawlCount)
+	}
+	if !strings.Contains(result.Message, "未爬取") {
+		t.Errorf("期望消息注明未爬取，实际 %q", result.Message)
+	}
+
+	// 对照：不跳过时会请求 New Submissions 页面
+	opts.SkipCrawl = false
+	if _, err := app.getDailyRecommendationsDirect(opts, nil); err != nil {
+		t.Fatalf("获取推荐失败: %v", err)
+	}
+	if hits.Load() == 0 {
+		t.Error("期望未设置 SkipCrawl 时发起爬取请求")
+	}
+}
+
+func TestArxivAnnouncementDay_WeekendsAndHolidays(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	app := &App{config: &config.AppConfig{}}
+	app.config.Arxiv.Holidays = []string{"2025-10-13"}
+
+	saturday := time.Date(2025, 10, 18, 10, 0, 0, 0, time.Local)
+	monday := time.Date(2025, 10, 20, 10, 0, 0, 0, time.Local)
+	holidayMonday := time.Date(2025, 10, 13, 10, 0, 0, 0, time.Local)
+
+	friday := app.arxivAnnouncementDay(saturday)
+	if friday.Format("2006-01-02") != "2025-10-17" {
+		t.Errorf("周六应使用周五的列表，实际 %s", friday.Format("2006-01-02"))
+	}
+	if got := app.arxivAnnouncementDay(monday); got.Format("2006-01-02") != "2025-10-20" {
+		t.Errorf("周一应使用当天的列表，实际 %s", got.Format("2006-01-02"))
+	}
+	if got := app.arxivAnnouncementDay(holidayMonday); got.Format("2006-01-02") != "2025-10-10" {
+		t.Errorf("节假日应回退到上一个公布日，实际 %s", got.Format("2006-01-02"))
+	}
+
+	// 周五爬取后，周六运行视为已爬取同一列表，周一是新的列表
+	archives := []string{"cs"}
+	if err := markCrawled(friday, archives); err != nil {
+		t.Fatalf("写入爬取状态失败: %v", err)
+	}
+	if !checkCrawled(app.arxivAnnouncementDay(saturday), archives) {
+		t.Error("期望周六识别出周五的列表已爬取")
+	}
+	if checkCrawled(app.arxivAnnouncementDay(monday), archives) {
+		t.Error("期望周一的列表尚未爬取")
+	}
+}
+
+func TestGetDailyRecommendations_ReportsAnnouncementDate(t *testing.T) {
+	app, _ := newRecommendTestApp(t)
+	data, err := app.getDailyRecommendationsDirect(RecommendOptions{SkipCrawl: true, SeedSources: []string{SeedSourceLocalFile}}, nil)
+	if err != nil {
+		t.Fatalf("获取推荐失败: %v", err)
+	}
+	var result RecommendResult
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("解析推荐结果失败: %v", err)
+	}
+	if want := app.arxivAnnouncementDay(time.Now()).Format("2006-01-02"); result.AnnouncementDate != want {
+		t.Errorf("AnnouncementDate = %q，期望 %q", result.AnnouncementDate, want)
+	}
+}
+
+func TestResolveMinSimilarity(t *testing.T) {
+	if v, err := resolveMinSimilarity(nil); err != nil || v != defaultMinSimilarity {
+		t.Errorf("未设置时应使用默认阈值 %v，实际 %v, %v", defaultMinSimilarity, v, err)
+	}
+	zero := float32(0)
+	if v, err := resolveMinSimilarity(&zero); err != nil || v != 0 {
+		t.Errorf("显式设置 0 应关闭过滤，实际 %v, %v", v, err)
+	}
+	for _, bad := range []float32{-0.1, 1.2} {
+		if _, err := resolveMinSimilarity(&bad); err == nil {
+			t.Errorf("阈值 %v 超出 [0,1] 应返回错误", bad)
+		}
+	}
+}
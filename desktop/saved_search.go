@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// savedSearchCheckInterval 定期检查保存的搜索的间隔
+const savedSearchCheckInterval = 10 * time.Minute
+
+// SavedSearchUpdate 保存的搜索有新结果时通过 search-update 事件发送给前端
+type SavedSearchUpdate struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	NewCount int    `json:"new_count"`
+}
+
+// SaveCurrentSearch 以 name 保存当前的关键词与过滤条件，同名搜索会被覆盖
+func (a *App) SaveCurrentSearch(name string, opts SearchOptions) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("app not initialized")
+	}
+	cond, err := searchCondition(opts)
+	if err != nil {
+		return err
+	}
+	if err := a.coreApp.SaveSearch(context.Background(), name, models.SavedSearchQuery{Query: opts.Query, Condition: cond}); err != nil {
+		return err
+	}
+	logger.Info("已保存搜索: %s (%s)", name, opts.Query)
+	return nil
+}
+
+// GetSavedSearches 返回所有保存的搜索（JSON）
+func (a *App) GetSavedSearches() (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+	searches, err := a.coreApp.GetSavedSearches(context.Background())
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(searches)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal saved searches: %w", err)
+	}
+	return string(data), nil
+}
+
+// DeleteSavedSearch 删除保存的搜索
+func (a *App) DeleteSavedSearch(id string) error {
+	if a.coreApp == nil {
+		return fmt.Errorf("app not initialized")
+	}
+	return a.coreApp.DeleteSavedSearch(context.Background(), id)
+}
+
+// checkSavedSearches 逐个检查保存的搜索，返回有新结果的搜索，并通过 search-update 事件通知前端
+func (a *App) checkSavedSearches() []SavedSearchUpdate {
+	if a.coreApp == nil {
+		return nil
+	}
+	ctx := context.Background()
+	searches, err := a.coreApp.GetSavedSearches(ctx)
+	if err != nil {
+		logger.Warn("读取保存的搜索失败: %v", err)
+		return nil
+	}
+	var updates []SavedSearchUpdate
+	for _, s := range searches {
+		n, err := a.coreApp.CheckSavedSearch(ctx, s.ID)
+		if err != nil {
+			logger.Warn("检查保存的搜索失败 (%s): %v", s.Name, err)
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		logger.Info("保存的搜索 %s 有 %d 篇新论文", s.Name, n)
+		update := SavedSearchUpdate{ID: s.ID, Name: s.Name, NewCount: n}
+		updates = append(updates, update)
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "search-update", update)
+		}
+	}
+	return updates
+}
+
+// initSavedSearches 在后台定期检查保存的搜索，不阻塞界面加载
+func (a *App) initSavedSearches() {
+	if a.ctx != nil {
+		go a.watchSavedSearches(a.ctx)
+	}
+}
+
+// watchSavedSearches 启动时及之后每隔 savedSearchCheckInterval 检查一次保存的搜索，直到 ctx 结束
+func (a *App) watchSavedSearches(ctx context.Context) {
+	ticker := time.NewTicker(savedSearchCheckInterval)
+	defer ticker.Stop()
+	for {
+		a.checkSavedSearches()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
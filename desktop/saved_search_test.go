@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestCheckSavedSearches_ReportsNewPapers(t *testing.T) {
+	app := newTestApp(t)
+	cs := app.crawlService
+
+	if err := app.SaveCurrentSearch("stub", SearchOptions{Query: "Paper", Source: "stub-papers", Limit: 1}); err != nil {
+		t.Fatalf("保存搜索失败: %v", err)
+	}
+	if err := app.SaveCurrentSearch("bad", SearchOptions{Query: "Paper", From: "2024/01/01"}); err == nil {
+		t.Error("期望非法日期保存失败")
+	}
+	if updates := app.checkSavedSearches(); len(updates) != 0 {
+		t.Errorf("期望没有新结果，实际 %+v", updates)
+	}
+
+	taskID, err := cs.StartCrawl("stub-papers", map[string]interface{}{"limit": float64(3)}, false)
+	if err != nil {
+		t.Fatalf("启动爬取失败: %v", err)
+	}
+	waitTaskStatus(t, cs, taskID, "completed")
+	task, _ := cs.GetTask(taskID)
+	<-task.Done()
+
+	updates := app.checkSavedSearches()
+	if len(updates) != 1 || updates[0].Name != "stub" || updates[0].NewCount != 3 {
+		t.Fatalf("期望 stub 新增 3 篇，实际 %+v", updates)
+	}
+	if updates := app.checkSavedSearches(); len(updates) != 0 {
+		t.Errorf("期望再次检查没有新结果，实际 %+v", updates)
+	}
+
+	out, err := app.GetSavedSearches()
+	if err != nil {
+		t.Fatalf("获取保存的搜索失败: %v", err)
+	}
+	var searches []models.SavedSearch
+	if err := json.Unmarshal([]byte(out), &searches); err != nil {
+		t.Fatalf("解析保存的搜索失败: %v", err)
+	}
+	if len(searches) != 1 || searches[0].LastResultCount != 3 {
+		t.Fatalf("期望 1 个保存的搜索且结果数为 3，实际 %+v", searches)
+	}
+	if err := app.DeleteSavedSearch(searches[0].ID); err != nil {
+		t.Fatalf("删除保存的搜索失败: %v", err)
+	}
+	if out, _ := app.GetSavedSearches(); out != "[]" {
+		t.Errorf("期望删除后为空，实际 %s", out)
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"PaperHunter/pkg/logger"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ScheduledJob 定时爬取任务
+type ScheduledJob struct {
+	ID         string                 `json:"id"`
+	Platform   string                 `json:"platform"`
+	Params     map[string]interface{} `json:"params"`
+	CronExpr   string                 `json:"cron_expr"` // 标准 5 段 cron 表达式，或 @daily / @every 6h 等描述符
+	CreatedAt  time.Time              `json:"created_at"`
+	LastRunAt  *time.Time             `json:"last_run_at,omitempty"`
+	LastTaskID string                 `json:"last_task_id,omitempty"`
+}
+
+// CrawlScheduler 基于 cron 表达式定时触发爬取任务
+type CrawlScheduler struct {
+	app     *App
+	path    string
+	cron    *cron.Cron
+	jobs    []*ScheduledJob
+	entries map[string]cron.EntryID
+	running bool
+	mu      sync.Mutex
+}
+
+// NewCrawlScheduler 创建调度器，path 为任务持久化文件
+func NewCrawlScheduler(app *App, path string) *CrawlScheduler {
+	return &CrawlScheduler{
+		app:     app,
+		path:    path,
+		cron:    cron.New(),
+		entries: make(map[string]cron.EntryID),
+	}
+}
+
+// scheduledJobsPath 定时任务文件路径（与数据库同目录）
+func (a *App) scheduledJobsPath() string {
+	if a.config != nil && a.config.Database.Path != "" {
+		return filepath.Join(filepath.Dir(a.config.Database.Path), "scheduled_jobs.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".quicksearch", "data", "scheduled_jobs.json")
+}
+
+// validateCronExpr 校验 cron 表达式
+func validateCronExpr(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return fmt.Errorf("cron expression cannot be empty")
+	}
+	if _, err := cron.ParseStandard(expr); err != nil {
+		return fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	return nil
+}
+
+// Load 从磁盘加载定时任务并注册到 cron
+func (s *CrawlScheduler) Load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var jobs []*ScheduledJob
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return fmt.Errorf("解析定时任务失败: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, job := range jobs {
+		if err := s.register(job); err != nil {
+			logger.Warn("跳过无效的定时任务 %s: %v", job.ID, err)
+			continue
+		}
+		s.jobs = append(s.jobs, job)
+	}
+	return nil
+}
+
+// save 将定时任务写回磁盘，调用方需持有锁
+func (s *CrawlScheduler) save() error {
+	data, err := json.MarshalIndent(s.jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// register 将任务注册到 cron，调用方需持有锁
+func (s *CrawlScheduler) register(job *ScheduledJob) error {
+	if err := validateCronExpr(job.CronExpr); err != nil {
+		return err
+	}
+	id := job.ID
+	entryID, err := s.cron.AddFunc(job.CronExpr, func() { s.fire(id) })
+	if err != nil {
+		return err
+	}
+	s.entries[job.ID] = entryID
+	return nil
+}
+
+// Add 添加定时任务并持久化
+func (s *CrawlScheduler) Add(job ScheduledJob) (*ScheduledJob, error) {
+	if strings.TrimSpace(job.Platform) == "" {
+		return nil, fmt.Errorf("platform cannot be empty")
+	}
+	if job.ID == "" {
+		job.ID = fmt.Sprintf("job_%d", time.Now().UnixNano())
+	}
+	if job.Params == nil {
+		job.Params = map[string]interface{}{}
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[job.ID]; exists {
+		return nil, fmt.Errorf("scheduled job already exists: %s", job.ID)
+	}
+	if err := s.register(&job); err != nil {
+		return nil, err
+	}
+	s.jobs = append(s.jobs, &job)
+	if err := s.save(); err != nil {
+		return nil, fmt.Errorf("保存定时任务失败: %w", err)
+	}
+	return &job, nil
+}
+
+// Remove 删除定时任务并持久化
+func (s *CrawlScheduler) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entryID, exists := s.entries[id]
+	if !exists {
+		return fmt.Errorf("scheduled job not found: %s", id)
+	}
+	s.cron.Remove(entryID)
+	delete(s.entries, id)
+
+	for i, job := range s.jobs {
+		if job.ID == id {
+			s.jobs = append(s.jobs[:i], s.jobs[i+1:]...)
+			break
+		}
+	}
+	if err := s.save(); err != nil {
+		return fmt.Errorf("保存定时任务失败: %w", err)
+	}
+	return nil
+}
+
+// List 返回当前所有定时任务的副本
+func (s *CrawlScheduler) List() []ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]ScheduledJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// Len 返回定时任务数量
+func (s *CrawlScheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.jobs)
+}
+
+// Start 启动调度，ctx 结束时自动停止
+func (s *CrawlScheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.cron.Start()
+	logger.Info("定时爬取调度已启动，共 %d 个任务", s.Len())
+
+	if ctx != nil {
+		go func() {
+			<-ctx.Done()
+			s.Stop()
+		}()
+	}
+}
+
+// Stop 停止调度并等待正在触发的任务返回
+func (s *CrawlScheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	<-s.cron.Stop().Done()
+	logger.Info("定时爬取调度已停止")
+}
+
+// fire 触发一次定时任务，返回创建的爬取任务 ID
+func (s *CrawlScheduler) fire(id string) (string, error) {
+	s.mu.Lock()
+	var job *ScheduledJob
+	for _, j := range s.jobs {
+		if j.ID == id {
+			job = j
+			break
+		}
+	}
+	if job == nil {
+		s.mu.Unlock()
+		return "", fmt.Errorf("scheduled job not found: %s", id)
+	}
+	platform, params := job.Platform, job.Params
+	s.mu.Unlock()
+
+	taskID, err := s.app.CrawlPapers(platform, params)
+	if err != nil {
+		logger.Error("定时任务 %s 启动爬取失败: %v", id, err)
+		return "", err
+	}
+	logger.Info("定时任务 %s 已触发爬取: %s", id, taskID)
+
+	s.mu.Lock()
+	now := time.Now()
+	job.LastRunAt = &now
+	job.LastTaskID = taskID
+	if err := s.save(); err != nil {
+		logger.Warn("保存定时任务失败: %v", err)
+	}
+	s.mu.Unlock()
+
+	return taskID, nil
+}
+
+// initScheduler 加载已保存的定时任务，存在任务时自动启动调度
+func (a *App) initScheduler() {
+	if a.crawlService == nil {
+		a.crawlService = NewCrawlService(a)
+	}
+
+	a.scheduler = NewCrawlScheduler(a, a.scheduledJobsPath())
+	if err := a.scheduler.Load(); err != nil {
+		logger.Error("加载定时任务失败: %v", err)
+		return
+	}
+	if a.scheduler.Len() > 0 {
+		a.scheduler.Start(a.ctx)
+	}
+}
+
+// AddScheduledJob 添加定时爬取任务
+func (a *App) AddScheduledJob(job ScheduledJob) error {
+	if a.scheduler == nil {
+		return fmt.Errorf("scheduler not initialized")
+	}
+	added, err := a.scheduler.Add(job)
+	if err != nil {
+		return err
+	}
+	a.scheduler.Start(a.ctx)
+	logger.Info("已添加定时任务: %s (%s, %s)", added.ID, added.Platform, added.CronExpr)
+	return nil
+}
+
+// RemoveScheduledJob 删除定时爬取任务
+func (a *App) RemoveScheduledJob(id string) error {
+	if a.scheduler == nil {
+		return fmt.Errorf("scheduler not initialized")
+	}
+	return a.scheduler.Remove(id)
+}
+
+// ListScheduledJobs 列出所有定时爬取任务（JSON）
+func (a *App) ListScheduledJobs() (string, error) {
+	if a.scheduler == nil {
+		return "", fmt.Errorf("scheduler not initialized")
+	}
+	data, err := json.Marshal(a.scheduler.List())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal scheduled jobs: %w", err)
+	}
+	return string(data), nil
+}
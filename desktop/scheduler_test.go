@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"PaperHunter/config"
+	"PaperHunter/internal/core"
+	emb "PaperHunter/internal/embedding"
+)
+
+// newTestApp 创建使用临时数据库的 App（不启动 Wails 运行时）；
+// 测试结束时取消并等待仍在运行的爬取任务，再关闭核心模块，避免任务在临时目录删除后继续写文件
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+
+	coreApp, err := core.NewApp(dbPath, emb.EmbedderConfig{}, nil, core.ZoteroConfig{}, core.FeiShuConfig{}, core.NotionConfig{})
+	if err != nil {
+		t.Fatalf("创建核心模块失败: %v", err)
+	}
+
+	app := &App{
+		coreApp: coreApp,
+		config:  &config.AppConfig{Database: config.DatabaseConfig{Path: dbPath}},
+	}
+	app.crawlService = NewCrawlService(app)
+	t.Cleanup(func() {
+		for _, task := range app.crawlService.GetAllTasks() {
+			app.crawlService.CancelCrawl(task.ID)
+			<-task.Done()
+		}
+		app.coreApp.Close()
+	})
+	return app
+}
+
+func TestValidateCronExpr(t *testing.T) {
+	valid := []string{"0 3 * * *", "*/30 * * * *", "@daily", "@every 6h"}
+	for _, expr := range valid {
+		if err := validateCronExpr(expr); err != nil {
+			t.Errorf("期望 %q 合法，实际错误: %v", expr, err)
+		}
+	}
+
+	invalid := []string{"", "   ", "61 * * * *", "* * *", "not a cron"}
+	for _, expr := range invalid {
+		if err := validateCronExpr(expr); err == nil {
+			t.Errorf("期望 %q 非法", expr)
+		}
+	}
+}
+
+func TestScheduledJobPersistence(t *testing.T) {
+	app := newTestApp(t)
+	path := app.scheduledJobsPath()
+
+	s := NewCrawlScheduler(app, path)
+	job, err := s.Add(ScheduledJob{
+		Platform: "arxiv",
+		Params:   map[string]interface{}{"keywords": []interface{}{"llm"}, "limit": float64(20)},
+		CronExpr: "0 3 * * *",
+	})
+	if err != nil {
+		t.Fatalf("添加定时任务失败: %v", err)
+	}
+	if job.ID == "" {
+		t.Fatal("期望自动生成任务 ID")
+	}
+
+	if _, err := s.Add(ScheduledJob{Platform: "arxiv", CronExpr: "bad"}); err == nil {
+		t.Error("期望非法 cron 表达式被拒绝")
+	}
+
+	// 重新加载后应得到相同的任务
+	reloaded := NewCrawlScheduler(app, path)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("加载定时任务失败: %v", err)
+	}
+	jobs := reloaded.List()
+	if len(jobs) != 1 {
+		t.Fatalf("期望 1 个任务，实际 %d 个", len(jobs))
+	}
+	got := jobs[0]
+	if got.ID != job.ID || got.Platform != "arxiv" || got.CronExpr != "0 3 * * *" {
+		t.Errorf("任务字段不一致: %+v", got)
+	}
+	if limit, ok := got.Params["limit"].(float64); !ok || limit != 20 {
+		t.Errorf("期望 limit=20，实际 %v", got.Params["limit"])
+	}
+
+	if err := reloaded.Remove(job.ID); err != nil {
+		t.Fatalf("删除定时任务失败: %v", err)
+	}
+	if err := reloaded.Remove(job.ID); err == nil {
+		t.Error("期望重复删除返回错误")
+	}
+
+	empty := NewCrawlScheduler(app, path)
+	if err := empty.Load(); err != nil {
+		t.Fatalf("加载定时任务失败: %v", err)
+	}
+	if empty.Len() != 0 {
+		t.Errorf("期望删除后无任务，实际 %d 个", empty.Len())
+	}
+}
+
+func TestScheduledJobFireCreatesTask(t *testing.T) {
+	app := newTestApp(t)
+	app.scheduler = NewCrawlScheduler(app, app.scheduledJobsPath())
+
+	// 使用未注册的平台，任务会很快失败，但仍应创建可查询的 CrawlTask
+	job, err := app.scheduler.Add(ScheduledJob{Platform: "unknown-platform", CronExpr: "@daily"})
+	if err != nil {
+		t.Fatalf("添加定时任务失败: %v", err)
+	}
+
+	taskID, err := app.scheduler.fire(job.ID)
+	if err != nil {
+		t.Fatalf("触发定时任务失败: %v", err)
+	}
+
+	data, err := app.GetCrawlTask(taskID)
+	if err != nil {
+		t.Fatalf("查询爬取任务失败: %v", err)
+	}
+	var task struct {
+		ID       string `json:"id"`
+		Platform string `json:"platform"`
+	}
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatalf("解析任务失败: %v", err)
+	}
+	if task.ID != taskID || task.Platform != "unknown-platform" {
+		t.Errorf("任务信息不一致: %+v", task)
+	}
+	if got := waitTaskStatus(t, app.crawlService, taskID, "failed"); got != "failed" {
+		t.Errorf("期望未知平台的任务失败，实际 %s", got)
+	}
+
+	jobs := app.scheduler.List()
+	if len(jobs) != 1 || jobs[0].LastTaskID != taskID || jobs[0].LastRunAt == nil {
+		t.Errorf("期望记录最近一次触发信息: %+v", jobs)
+	}
+
+	app.scheduler.Start(nil)
+	app.scheduler.Stop()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"PaperHunter/internal/core"
@@ -23,8 +24,17 @@ type SearchOptions struct {
 	Until        string          `json:"until"` // YYYY-MM-DD
 	ComputeEmbed bool            `json:"computeEmbed"`
 	EmbedBatch   int             `json:"embedBatch"`
+	FTS          bool            `json:"fts"` // 关键词搜索使用全文索引按相关度排序
 	IR           bool            `json:"ir"`
 	IRAlgorithm  string          `json:"irAlgorithm"`
+	Hybrid       bool            `json:"hybrid"`
+	HybridAlpha  float64         `json:"hybridAlpha"` // 语义权重，0 表示使用默认值
+	SortBy       string          `json:"sortBy"`      // similarity|date|citations，为空时保持打分顺序
+	SortDesc     bool            `json:"sortDesc"`
+	AuthorQuery  string          `json:"authorQuery"` // 作者过滤（子串匹配）；query 与 examples 都为空时按作者列出论文
+	Authors      []string        `json:"authors"`     // 多个作者之间为 OR
+	Status       string          `json:"status"`      // 阅读状态过滤：unread|reading|read|archived，为空表示不限
+	Starred      bool            `json:"starred"`     // 只保留已收藏的论文
 }
 
 // SearchWithOptions 执行搜索并返回 JSON 字符串结果
@@ -40,11 +50,91 @@ func (a *App) SearchWithOptions(opts SearchOptions) (string, error) {
 		if batch <= 0 {
 			batch = 100
 		}
-		if _, err := a.coreApp.ComputeMissingEmbeddings(ctx, batch); err != nil {
+		if _, err := a.coreApp.ComputeMissingEmbeddings(ctx, batch, 0); err != nil {
 			return "", fmt.Errorf("compute embeddings failed: %w", err)
 		}
 	}
 
+	cond, err := searchCondition(opts)
+	if err != nil {
+		return "", err
+	}
+
+	// 转换示例
+	var examples []*models.Paper
+	if len(opts.Examples) > 0 {
+		for _, e := range opts.Examples {
+			p := &models.Paper{Title: e.Title, Abstract: e.Abstract}
+			if p.Title != "" || p.Abstract != "" {
+				examples = append(examples, p)
+			}
+		}
+	}
+
+	sopts := core.SearchOptions{
+		Query:       opts.Query,
+		Examples:    examples,
+		Condition:   cond,
+		TopK:        opts.TopK,
+		Semantic:    opts.Semantic,
+		FTS:         opts.FTS,
+		IR:          opts.IR,
+		IRAlgorithm: opts.IRAlgorithm,
+		Hybrid:      opts.Hybrid,
+		HybridAlpha: opts.HybridAlpha,
+		SortBy:      opts.SortBy,
+		SortDesc:    opts.SortDesc,
+	}
+
+	results, err := a.coreApp.Search(ctx, sopts)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SearchPapers 统一的搜索入口：提供 query 或 examples 时与 SearchWithOptions 相同（作者作为过滤条件），
+// 只提供 authorQuery/authors 时按作者列出论文
+func (a *App) SearchPapers(opts SearchOptions) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+	if strings.TrimSpace(opts.Query) != "" || len(opts.Examples) > 0 {
+		return a.SearchWithOptions(opts)
+	}
+
+	cond, err := searchCondition(opts)
+	if err != nil {
+		return "", err
+	}
+	if len(cond.Authors) == 0 {
+		return "", fmt.Errorf("query, examples or authorQuery is required")
+	}
+
+	papers, err := a.coreApp.SearchByAuthor(context.Background(), "", cond)
+	if err != nil {
+		return "", err
+	}
+	results := make([]*models.SimilarPaper, 0, len(papers))
+	for _, p := range papers {
+		results = append(results, &models.SimilarPaper{Paper: *p, Similarity: 1.0})
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// searchCondition 由搜索参数构造过滤条件，authorQuery 与 authors 合并为 OR 关系的作者过滤；
+// status 与 starred 按阅读状态和收藏过滤
+func searchCondition(opts SearchOptions) (models.SearchCondition, error) {
 	cond := models.SearchCondition{Limit: opts.Limit}
 
 	if opts.Source != "" {
@@ -54,7 +144,7 @@ func (a *App) SearchWithOptions(opts SearchOptions) (string, error) {
 	if opts.From != "" {
 		t, err := time.Parse("2006-01-02", opts.From)
 		if err != nil {
-			return "", fmt.Errorf("invalid from date: %w", err)
+			return cond, fmt.Errorf("invalid from date: %w", err)
 		}
 		cond.DateFrom = &t
 	}
@@ -62,33 +152,37 @@ func (a *App) SearchWithOptions(opts SearchOptions) (string, error) {
 	if opts.Until != "" {
 		t, err := time.Parse("2006-01-02", opts.Until)
 		if err != nil {
-			return "", fmt.Errorf("invalid until date: %w", err)
+			return cond, fmt.Errorf("invalid until date: %w", err)
 		}
 		cond.DateTo = &t
 	}
 
-	// 转换示例
-	var examples []*models.Paper
-	if len(opts.Examples) > 0 {
-		for _, e := range opts.Examples {
-			p := &models.Paper{Title: e.Title, Abstract: e.Abstract}
-			if p.Title != "" || p.Abstract != "" {
-				examples = append(examples, p)
-			}
+	if opts.Status != "" {
+		if !models.ValidPaperStatus(opts.Status) {
+			return cond, fmt.Errorf("invalid status: %s", opts.Status)
 		}
+		cond.Status = opts.Status
 	}
+	cond.Starred = opts.Starred
 
-	sopts := core.SearchOptions{
-		Query:       opts.Query,
-		Examples:    examples,
-		Condition:   cond,
-		TopK:        opts.TopK,
-		Semantic:    opts.Semantic,
-		IR:          opts.IR,
-		IRAlgorithm: opts.IRAlgorithm,
+	for _, author := range append([]string{opts.AuthorQuery}, opts.Authors...) {
+		if author = strings.TrimSpace(author); author != "" {
+			cond.Authors = append(cond.Authors, author)
+		}
 	}
+	return cond, nil
+}
 
-	results, err := a.coreApp.Search(ctx, sopts)
+// GetPaperCitations 返回论文引用的已入库论文（JSON），按与原论文的相似度排序
+func (a *App) GetPaperCitations(source, sourceID string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+	if sourceID == "" {
+		return "", fmt.Errorf("sourceID is required")
+	}
+
+	results, err := a.coreApp.GetPaperCitations(context.Background(), source, sourceID)
 	if err != nil {
 		return "", err
 	}
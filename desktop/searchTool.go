@@ -43,6 +43,9 @@ type SearchInput struct {
 	// Source 数据源过滤（如 arxiv, openreview, acl 等）
 	Source string `json:"source,omitempty" jsonschema:"description=Filter by data source (e.g., arxiv, openreview, acl)"`
 
+	// AuthorQuery 作者过滤（子串匹配）；未提供 query/examples 时按作者列出论文
+	AuthorQuery string `json:"author_query,omitempty" jsonschema:"description=Filter by author name (substring match). If query and examples are empty, lists papers by this author"`
+
 	// DateFrom 开始日期，格式 YYYY-MM-DD
 	DateFrom string `json:"date_from,omitempty" jsonschema:"description=Start date in YYYY-MM-DD format"`
 
@@ -54,6 +57,15 @@ type SearchInput struct {
 
 	// EmbedBatch embedding 批量计算数量
 	EmbedBatch int `json:"embed_batch,omitempty" jsonschema:"description=Batch size for computing embeddings"`
+
+	// MinSimilarity 相似度下限 [0,1]，0 表示不过滤；只作用于语义与混合搜索
+	MinSimilarity float32 `json:"min_similarity,omitempty" jsonschema:"description=Drop semantic or hybrid results whose similarity is below this value, within [0,1]; ignored for keyword and IR search (default: 0, no filtering)"`
+
+	// SortBy 排序字段 similarity/date/citations，为空时按相关度
+	SortBy string `json:"sort_by,omitempty" jsonschema:"enum=similarity,enum=date,enum=citations,description=Sort results by similarity/date/citations (default: relevance order)"`
+
+	// SortDesc 是否降序
+	SortDesc bool `json:"sort_desc,omitempty" jsonschema:"description=Sort in descending order (e.g. newest first for date; default: false)"`
 }
 
 // SearchOutput 搜索工具的输出结果
@@ -78,10 +90,14 @@ func NewSearchTool(app *App) tool.InvokableTool {
 - source: Filter by data source (equivalent to CLI --source=arxiv)
 - date_from: Start date in YYYY-MM-DD format (equivalent to CLI --from=YYYY-MM-DD)
 - date_to: End date in YYYY-MM-DD format (equivalent to CLI --until=YYYY-MM-DD)
+- author_query: Filter by author name (substring match, e.g. "Hinton"); can be used alone to list an author's papers
 - semantic: Whether to use semantic search (default: true)
+- min_similarity: Drop results below this similarity, within [0,1] (default: 0, no filtering)
+- sort_by: Sort results by "similarity", "date" or "citations"; ties are broken by date, newest first (default: relevance order)
+- sort_desc: Sort in descending order, e.g. sort_by="date" with sort_desc=true lists the newest papers first (default: false)
 
 **IMPORTANT:** 
-- You MUST provide either 'query' OR 'examples' parameter. The tool will fail if both are missing.
+- You MUST provide 'query', 'examples' or 'author_query'. The tool will fail if all are missing.
 - **DO NOT use this tool for Zotero-based recommendations. Use zotero_recommend tool instead.**
 - Use top_k (not limit) to control the number of results returned.
 - If top_k is not specified but limit is provided, limit will be used as top_k.
@@ -97,7 +113,7 @@ func NewSearchTool(app *App) tool.InvokableTool {
 			if batch <= 0 {
 				batch = 100
 			}
-			_, err := app.coreApp.ComputeMissingEmbeddings(ctx, batch)
+			_, err := app.coreApp.ComputeMissingEmbeddings(ctx, batch, 0)
 			if err != nil {
 				return nil, fmt.Errorf("failed to compute embeddings: %w", err)
 			}
@@ -112,6 +128,11 @@ func NewSearchTool(app *App) tool.InvokableTool {
 			cond.Sources = []string{input.Source}
 		}
 
+		authorQuery := strings.TrimSpace(input.AuthorQuery)
+		if authorQuery != "" {
+			cond.Authors = []string{authorQuery}
+		}
+
 		if input.DateFrom != "" {
 			t, err := time.Parse("2006-01-02", input.DateFrom)
 			if err != nil {
@@ -152,6 +173,30 @@ func NewSearchTool(app *App) tool.InvokableTool {
 			}
 		}
 
+		// 只提供作者时按作者列出论文
+		if strings.TrimSpace(input.Query) == "" && len(examples) == 0 && authorQuery != "" {
+			if cond.Limit <= 0 {
+				cond.Limit = topK
+			}
+			papers, err := app.coreApp.SearchByAuthor(ctx, authorQuery, cond)
+			if err != nil {
+				return &SearchOutput{
+					Count:   0,
+					Papers:  nil,
+					Message: fmt.Sprintf("Search failed: %v", err),
+				}, err
+			}
+			results := make([]*models.SimilarPaper, 0, len(papers))
+			for _, p := range papers {
+				results = append(results, &models.SimilarPaper{Paper: *p, Similarity: 1.0})
+			}
+			return &SearchOutput{
+				Count:   len(results),
+				Papers:  results,
+				Message: fmt.Sprintf("Successfully found %d papers by %s", len(results), authorQuery),
+			}, nil
+		}
+
 		if strings.TrimSpace(input.Query) == "" && len(examples) == 0 {
 			logger.Warn("search 工具调用失败：缺少必需参数。query='%s', examples_count=%d", input.Query, len(examples))
 			return &SearchOutput{
@@ -163,16 +208,19 @@ func NewSearchTool(app *App) tool.InvokableTool {
 
 
 		opts := core.SearchOptions{
-			Query:     input.Query,
-			Examples:  examples,
-			Condition: cond,
-			TopK:      topK,
-			Semantic:  input.Semantic,
+			Query:         input.Query,
+			Examples:      examples,
+			Condition:     cond,
+			TopK:          topK,
+			Semantic:      input.Semantic,
+			MinSimilarity: input.MinSimilarity,
+			SortBy:        input.SortBy,
+			SortDesc:      input.SortDesc,
 		}
 
 
-		logger.Info("搜索参数: query=%s, examples_count=%d, source=%s, date_from=%s, date_to=%s, top_k=%d, limit=%d, semantic=%v",
-			input.Query, len(examples), input.Source, input.DateFrom, input.DateTo, topK, input.Limit, input.Semantic)
+		logger.Info("搜索参数: query=%s, examples_count=%d, source=%s, author=%s, date_from=%s, date_to=%s, top_k=%d, limit=%d, semantic=%v",
+			input.Query, len(examples), input.Source, authorQuery, input.DateFrom, input.DateTo, topK, input.Limit, input.Semantic)
 
 
 		results, err := app.coreApp.Search(ctx, opts)
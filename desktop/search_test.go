@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestSearchPapers_ByAuthor(t *testing.T) {
+	app := newTestApp(t)
+	papers := []*models.Paper{
+		{Source: "arxiv", SourceID: "1", URL: "https://arxiv.org/abs/1", Title: "Deep Learning", Authors: []string{"Geoffrey Hinton", "Yann LeCun"}},
+		{Source: "arxiv", SourceID: "2", URL: "https://arxiv.org/abs/2", Title: "Capsule Networks", Authors: []string{"Sara Sabour", "Geoffrey Hinton"}},
+		{Source: "acl", SourceID: "3", URL: "https://aclanthology.org/3", Title: "Attention Is All You Need", Authors: []string{"Ashish Vaswani"}},
+	}
+	if _, err := app.coreApp.SavePapers(context.Background(), papers); err != nil {
+		t.Fatalf("保存论文失败: %v", err)
+	}
+
+	cases := []struct {
+		opts SearchOptions
+		want int
+	}{
+		{SearchOptions{AuthorQuery: "hinton"}, 2},
+		{SearchOptions{AuthorQuery: "Vaswani", Authors: []string{"Sabour"}}, 2},
+		{SearchOptions{AuthorQuery: "Hinton", Source: "acl"}, 0},
+	}
+	for _, c := range cases {
+		out, err := app.SearchPapers(c.opts)
+		if err != nil {
+			t.Fatalf("按作者搜索失败: %v", err)
+		}
+		var results []*models.SimilarPaper
+		if err := json.Unmarshal([]byte(out), &results); err != nil {
+			t.Fatalf("解析结果失败: %v", err)
+		}
+		if len(results) != c.want {
+			t.Errorf("参数 %+v 期望 %d 篇，实际 %d 篇", c.opts, c.want, len(results))
+		}
+	}
+
+	if _, err := app.SearchPapers(SearchOptions{AuthorQuery: "  "}); err == nil {
+		t.Error("期望缺少 query/examples/authorQuery 时返回错误")
+	}
+	if _, err := app.SearchPapers(SearchOptions{AuthorQuery: "Hinton", From: "2024/01/01"}); err == nil {
+		t.Error("期望非法日期返回错误")
+	}
+	if _, err := app.SearchPapers(SearchOptions{AuthorQuery: "Hinton", Status: "done"}); err == nil {
+		t.Error("期望非法阅读状态返回错误")
+	}
+}
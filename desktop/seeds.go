@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"PaperHunter/config"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// 种子论文来源
+const (
+	SeedSourceInterest           = "interest"            // 用户兴趣描述（HyDE 生成的虚拟论文）
+	SeedSourceLocalFile          = "local_file"          // 本地 JSON 文件
+	SeedSourceZotero             = "zotero"              // Zotero 文献库
+	SeedSourceOpenReviewAccepted = "openreview_accepted" // OpenReview 已录用论文
+)
+
+// 多个种子来源的组合方式
+//   - fallback：按顺序尝试各来源，第一个产出种子的来源即为最终结果，后续来源不再请求
+//   - combine：按顺序收集所有来源的种子并合并，例如兴趣描述 + Zotero 历史一起作为种子
+//
+// 两种方式下种子都会跨来源去重（相同 source:source_id 或相同标题只保留首次出现的一篇），
+// 因此来源顺序同时决定了推荐分组的先后。
+const (
+	SeedModeFallback = "fallback"
+	SeedModeCombine  = "combine"
+)
+
+// seedPlan 一次种子收集的来源顺序与组合方式
+type seedPlan struct {
+	Sources []string
+	Mode    string
+	Limit   int // 单次从 Zotero/OpenReview 获取的种子论文上限
+}
+
+// resolveSeedPlan 根据选项确定来源顺序与组合方式，未指定时使用调用方给出的默认值
+// SeedSource=openreview_accepted 时在默认顺序中以其替换 zotero
+func resolveSeedPlan(opts RecommendOptions, defaults seedPlan) (seedPlan, error) {
+	plan := defaults
+	if len(opts.SeedSources) > 0 {
+		plan.Sources = nil
+		seen := make(map[string]bool)
+		for _, s := range opts.SeedSources {
+			s = strings.ToLower(strings.TrimSpace(s))
+			switch s {
+			case SeedSourceInterest, SeedSourceLocalFile, SeedSourceZotero, SeedSourceOpenReviewAccepted:
+			default:
+				return seedPlan{}, fmt.Errorf("unsupported seed source: %s", s)
+			}
+			if !seen[s] {
+				seen[s] = true
+				plan.Sources = append(plan.Sources, s)
+			}
+		}
+	} else if opts.SeedSource == SeedSourceOpenReviewAccepted {
+		sources := make([]string, len(plan.Sources))
+		for i, s := range plan.Sources {
+			if s == SeedSourceZotero {
+				s = SeedSourceOpenReviewAccepted
+			}
+			sources[i] = s
+		}
+		plan.Sources = sources
+	}
+
+	switch strings.ToLower(strings.TrimSpace(opts.SeedMode)) {
+	case "":
+	case SeedModeFallback:
+		plan.Mode = SeedModeFallback
+	case SeedModeCombine:
+		plan.Mode = SeedModeCombine
+	default:
+		return seedPlan{}, fmt.Errorf("unsupported seed mode: %s", opts.SeedMode)
+	}
+	return plan, nil
+}
+
+// collectSeeds 按计划依次从各来源收集种子论文并去重
+// interest 在轮到兴趣来源时才调用，fallback 模式下前序来源已有结果时不会触发 HyDE 生成
+func (a *App) collectSeeds(ctx context.Context, plan seedPlan, opts RecommendOptions, interest func() *models.Paper) []*models.Paper {
+	var seeds []*models.Paper
+	seen := make(map[string]bool)
+
+	for _, source := range plan.Sources {
+		papers, err := a.fetchSeeds(ctx, source, opts, plan.Limit, interest)
+		if err != nil {
+			logger.Warn("获取种子论文失败 [%s]: %v", source, err)
+			continue
+		}
+
+		added := 0
+		for _, p := range papers {
+			if p == nil {
+				continue
+			}
+			keys := seedKeys(p)
+			duplicate := false
+			for _, k := range keys {
+				if seen[k] {
+					duplicate = true
+					break
+				}
+			}
+			if duplicate {
+				continue
+			}
+			for _, k := range keys {
+				seen[k] = true
+			}
+			seeds = append(seeds, p)
+			added++
+		}
+		logger.Info("种子来源 %s: 新增 %d 篇", source, added)
+
+		if plan.Mode != SeedModeCombine && added > 0 {
+			break
+		}
+	}
+	return seeds
+}
+
+// fetchSeeds 从单个来源获取种子，来源未配置时返回空结果
+func (a *App) fetchSeeds(ctx context.Context, source string, opts RecommendOptions, limit int, interest func() *models.Paper) ([]*models.Paper, error) {
+	switch source {
+	case SeedSourceInterest:
+		if interest == nil {
+			return nil, nil
+		}
+		if p := interest(); p != nil {
+			return []*models.Paper{p}, nil
+		}
+		return nil, nil
+	case SeedSourceLocalFile:
+		if strings.TrimSpace(opts.LocalFilePath) == "" {
+			return nil, nil
+		}
+		p, err := importJSONFile(opts.LocalFilePath)
+		if err != nil {
+			return nil, err
+		}
+		return []*models.Paper{p}, nil
+	case SeedSourceZotero:
+		cfg := config.Get()
+		if cfg == nil || cfg.Zotero.UserID == "" || cfg.Zotero.APIKey == "" {
+			logger.Info("Zotero 未配置，跳过 Zotero 种子论文")
+			return nil, nil
+		}
+		return getZoteroPapers(opts.ZoteroCollection, limit)
+	case SeedSourceOpenReviewAccepted:
+		return getOpenReviewAcceptedPapers(ctx, a, opts, limit)
+	default:
+		return nil, fmt.Errorf("unsupported seed source: %s", source)
+	}
+}
+
+// seedKeys 种子去重键：source:source_id 与规范化标题
+func seedKeys(p *models.Paper) []string {
+	var keys []string
+	if p.Source != "" && p.SourceID != "" {
+		keys = append(keys, "id:"+p.Source+":"+p.SourceID)
+	}
+	if title := strings.Join(strings.Fields(strings.ToLower(p.Title)), " "); title != "" {
+		keys = append(keys, "title:"+title)
+	}
+	return keys
+}
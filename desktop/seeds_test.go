@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func TestResolveSeedPlan(t *testing.T) {
+	plan, err := resolveSeedPlan(RecommendOptions{}, defaultRecommendSeedPlan)
+	if err != nil {
+		t.Fatalf("解析默认种子计划失败: %v", err)
+	}
+	if plan.Mode != SeedModeCombine || len(plan.Sources) != 3 || plan.Sources[0] != SeedSourceZotero {
+		t.Errorf("默认种子计划不符合预期: %+v", plan)
+	}
+
+	// SeedSource=openreview_accepted 替换默认顺序中的 zotero
+	plan, _ = resolveSeedPlan(RecommendOptions{SeedSource: SeedSourceOpenReviewAccepted}, defaultRecommendSeedPlan)
+	if plan.Sources[0] != SeedSourceOpenReviewAccepted {
+		t.Errorf("期望 openreview_accepted 替换 zotero，实际: %v", plan.Sources)
+	}
+	if defaultRecommendSeedPlan.Sources[0] != SeedSourceZotero {
+		t.Errorf("默认种子计划被修改: %v", defaultRecommendSeedPlan.Sources)
+	}
+
+	// 显式顺序去重，并覆盖组合方式
+	plan, err = resolveSeedPlan(RecommendOptions{
+		SeedSources: []string{"Interest", "zotero", "interest"},
+		SeedMode:    "fallback",
+	}, defaultRecommendSeedPlan)
+	if err != nil {
+		t.Fatalf("解析显式种子计划失败: %v", err)
+	}
+	if len(plan.Sources) != 2 || plan.Sources[0] != SeedSourceInterest || plan.Mode != SeedModeFallback {
+		t.Errorf("显式种子计划不符合预期: %+v", plan)
+	}
+
+	if _, err := resolveSeedPlan(RecommendOptions{SeedSources: []string{"twitter"}}, defaultRecommendSeedPlan); err == nil {
+		t.Error("期望未知种子来源返回错误")
+	}
+	if _, err := resolveSeedPlan(RecommendOptions{SeedMode: "all"}, defaultRecommendSeedPlan); err == nil {
+		t.Error("期望未知组合方式返回错误")
+	}
+}
+
+func TestCollectSeeds_FallbackAndCombine(t *testing.T) {
+	app := &App{}
+	localFile := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(localFile, []byte(`{"title":"Graph Neural Networks","abstract":"Message passing."}`), 0644); err != nil {
+		t.Fatalf("写入本地种子文件失败: %v", err)
+	}
+	opts := RecommendOptions{LocalFilePath: localFile}
+
+	interestCalls := 0
+	interest := func() *models.Paper {
+		interestCalls++
+		return &models.Paper{Title: "Retrieval-Augmented Generation", Abstract: "RAG.", Source: "user_query", SourceID: "hype_generated"}
+	}
+
+	// fallback：本地文件已有结果，不再调用兴趣来源
+	seeds := app.collectSeeds(context.Background(), seedPlan{
+		Sources: []string{SeedSourceLocalFile, SeedSourceInterest},
+		Mode:    SeedModeFallback,
+	}, opts, interest)
+	if len(seeds) != 1 || seeds[0].Title != "Graph Neural Networks" {
+		t.Errorf("fallback 种子不符合预期: %v", seeds)
+	}
+	if interestCalls != 0 {
+		t.Errorf("fallback 模式下不应调用兴趣来源，实际调用 %d 次", interestCalls)
+	}
+
+	// fallback：前序来源为空时回退到下一个来源
+	seeds = app.collectSeeds(context.Background(), seedPlan{
+		Sources: []string{SeedSourceLocalFile, SeedSourceInterest},
+		Mode:    SeedModeFallback,
+	}, RecommendOptions{}, interest)
+	if len(seeds) != 1 || seeds[0].SourceID != "hype_generated" {
+		t.Errorf("回退种子不符合预期: %v", seeds)
+	}
+
+	// combine：合并全部来源，保持来源顺序
+	seeds = app.collectSeeds(context.Background(), seedPlan{
+		Sources: []string{SeedSourceInterest, SeedSourceLocalFile},
+		Mode:    SeedModeCombine,
+	}, opts, interest)
+	if len(seeds) != 2 || seeds[0].SourceID != "hype_generated" || seeds[1].Source != "local_json" {
+		t.Errorf("combine 种子不符合预期: %v", seeds)
+	}
+}
+
+func TestCollectSeeds_DedupAcrossSources(t *testing.T) {
+	app := &App{}
+	localFile := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(localFile, []byte(`{"title":"Attention  Is All You Need","abstract":"Transformers."}`), 0644); err != nil {
+		t.Fatalf("写入本地种子文件失败: %v", err)
+	}
+
+	// 标题大小写与空白不同，仍视为同一篇
+	interest := func() *models.Paper {
+		return &models.Paper{Title: "attention is all you need", Abstract: "Self-attention.", Source: "user_interest", SourceID: "interest_seed"}
+	}
+
+	seeds := app.collectSeeds(context.Background(), seedPlan{
+		Sources: []string{SeedSourceInterest, SeedSourceLocalFile},
+		Mode:    SeedModeCombine,
+	}, RecommendOptions{LocalFilePath: localFile}, interest)
+	if len(seeds) != 1 || seeds[0].Source != "user_interest" {
+		t.Errorf("期望跨来源去重后保留首个种子，实际: %v", seeds)
+	}
+}
+
+func TestRecommendFromSeeds_DedupUnderConcurrency(t *testing.T) {
+	seeds := make([]*models.Paper, 12)
+	for i := range seeds {
+		seeds[i] = &models.Paper{Source: "zotero", SourceID: fmt.Sprintf("seed%d", i), Title: fmt.Sprintf("Seed %d", i)}
+	}
+	// 种子本身也被 arxiv 收录时不应推荐自己
+	seeds[0] = &models.Paper{Source: "arxiv", SourceID: "2501.00000", Title: "Seed 0"}
+
+	var inFlight, maxInFlight int32
+	search := func(ctx context.Context, seed *models.Paper) ([]*models.SimilarPaper, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		// 每个种子都返回大量重叠的结果，外加一篇种子论文
+		results := []*models.SimilarPaper{{Paper: models.Paper{Source: "arxiv", SourceID: "2501.00000"}, Similarity: 0.9}}
+		for j := 0; j < 5; j++ {
+			results = append(results, &models.SimilarPaper{
+				Paper:      models.Paper{Source: "arxiv", SourceID: fmt.Sprintf("2501.%05d", 1+j+len(seed.SourceID)%3)},
+				Similarity: 0.8,
+			})
+		}
+		return results, nil
+	}
+
+	recent := map[string]struct{}{"arxiv:2501.00001": {}}
+	groups, all := recommendFromSeeds(context.Background(), seeds, search, recent, nil, 100)
+
+	if maxInFlight < 2 || maxInFlight > seedSearchWorkers {
+		t.Errorf("期望并发检索且不超过 %d 个 worker，实际最大并发 %d", seedSearchWorkers, maxInFlight)
+	}
+	seen := make(map[string]bool)
+	total := 0
+	for _, g := range groups {
+		for _, sp := range g.Papers {
+			key := sp.Paper.Source + ":" + sp.Paper.SourceID
+			if seen[key] {
+				t.Errorf("论文 %s 被重复推荐", key)
+			}
+			if key == "arxiv:2501.00000" {
+				t.Error("种子论文不应出现在推荐中")
+			}
+			if key == "arxiv:2501.00001" && sp.Similarity >= 0.8 {
+				t.Errorf("近期推送过的论文应被降权，实际相似度 %.2f", sp.Similarity)
+			}
+			seen[key] = true
+			total++
+		}
+	}
+	if total != len(all) || total != 7 {
+		t.Errorf("期望 7 篇去重后的推荐，实际分组内 %d 篇，合并结果 %d 篇", total, len(all))
+	}
+}
+
+func TestRecommendFromSeeds_StopsAtMax(t *testing.T) {
+	seeds := make([]*models.Paper, 40)
+	for i := range seeds {
+		seeds[i] = &models.Paper{Source: "zotero", SourceID: fmt.Sprintf("seed%d", i)}
+	}
+	var calls int32
+	search := func(ctx context.Context, seed *models.Paper) ([]*models.SimilarPaper, error) {
+		atomic.AddInt32(&calls, 1)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return []*models.SimilarPaper{
+			{Paper: models.Paper{Source: "arxiv", SourceID: seed.SourceID + "-a"}},
+			{Paper: models.Paper{Source: "arxiv", SourceID: seed.SourceID + "-b"}},
+		}, nil
+	}
+
+	groups, all := recommendFromSeeds(context.Background(), seeds, search, nil, nil, 6)
+	if len(all) < 6 {
+		t.Errorf("期望至少 6 篇推荐，实际 %d", len(all))
+	}
+	if int(calls) >= len(seeds) {
+		t.Errorf("达到上限后应停止检索剩余种子，实际检索 %d 次", calls)
+	}
+	index := make(map[string]int, len(seeds))
+	for i, s := range seeds {
+		index[s.SourceID] = i
+	}
+	for i := 1; i < len(groups); i++ {
+		if index[groups[i-1].SeedPaper.SourceID] > index[groups[i].SeedPaper.SourceID] {
+			t.Errorf("推荐组应按种子顺序排列: %s 在 %s 之前", groups[i-1].SeedPaper.SourceID, groups[i].SeedPaper.SourceID)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -9,9 +11,14 @@ import (
 
 	"PaperHunter/config"
 	"PaperHunter/internal/core"
+	"PaperHunter/internal/embedding"
+	"PaperHunter/internal/ir"
+	"PaperHunter/internal/models"
 	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/httplimit"
 	"PaperHunter/pkg/logger"
 
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"gopkg.in/yaml.v2"
 )
 
@@ -22,6 +29,72 @@ func (a *App) GetConfig() (*config.AppConfig, error) {
 	return a.config, nil
 }
 
+// ListAvailableModels 返回当前 embedder 配置的接口提供的全部模型，JSON 数组
+func (a *App) ListAvailableModels() (string, error) {
+	if a.config == nil {
+		return "", fmt.Errorf("配置未加载")
+	}
+
+	list, err := embedding.ListModels(context.Background(), a.config.Embedder)
+	if err != nil {
+		return "", err
+	}
+	if list == nil {
+		list = []embedding.ModelInfo{}
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal models: %w", err)
+	}
+	return string(data), nil
+}
+
+// GetEmbeddingMismatches 返回库中模型或维度与当前 embedder 配置不一致的向量分组，JSON 数组
+func (a *App) GetEmbeddingMismatches() (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+	mismatches, err := a.coreApp.EmbeddingMismatches()
+	if err != nil {
+		return "", err
+	}
+	if mismatches == nil {
+		mismatches = []models.EmbeddingStat{}
+	}
+
+	data, err := json.Marshal(mismatches)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mismatches: %w", err)
+	}
+	return string(data), nil
+}
+
+// ReembedAll 用当前模型重新计算所有旧模型或缺失的向量，返回完成的论文数；
+// 每完成一批发送 reembed-progress 事件
+func (a *App) ReembedAll(batchSize int) (int, error) {
+	if a.coreApp == nil {
+		return 0, fmt.Errorf("app not initialized")
+	}
+	return a.coreApp.ReembedAll(context.Background(), batchSize, func(done, total int) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "reembed-progress", map[string]int{"done": done, "total": total})
+		}
+	})
+}
+
+// GetServiceStatus 检查 embedder、LLM、Zotero、飞书与各平台的连通性，返回每项服务的状态 JSON 数组，供设置页展示
+func (a *App) GetServiceStatus() (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+	data, err := json.Marshal(a.coreApp.Diagnostics(context.Background()))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal service status: %w", err)
+	}
+	return string(data), nil
+}
+
 func (a *App) UpdateConfig(cfg *config.AppConfig) error {
 	oldConfig := a.config
 
@@ -113,24 +186,41 @@ func (a *App) reloadCoreApp(cfg *config.AppConfig) error {
 		return fmt.Errorf("配置不能为空")
 	}
 
+	httplimit.SetMaxConcurrent(cfg.HTTP.MaxConcurrent)
 	coreApp, err := core.NewApp(cfg.Database.Path, cfg.Embedder,
 		map[string]platform.Config{
 			"arxiv":      &cfg.Arxiv,
 			"openreview": &cfg.OpenReview,
 			"acl":        &cfg.ACL,
 			"ssrn":       &cfg.SSRN,
-		}, cfg.Zotero, cfg.FeiShu)
+			"dblp":       &cfg.DBLP,
+		}, cfg.Zotero, cfg.FeiShu, cfg.Notion)
 
 	if err != nil {
 		return fmt.Errorf("重新初始化核心模块失败: %w", err)
 	}
 
+	if err := coreApp.ConfigureTokenizer(cfg.IR.Tokenizer()); err != nil {
+		_ = coreApp.Close()
+		return fmt.Errorf("IR 分词配置无效: %w", err)
+	}
+	if cfg.IR.PersistIndex {
+		coreApp.EnableIRIndexPersistence(ir.DefaultIndexPath())
+	}
+
 	if a.coreApp != nil {
 		_ = a.coreApp.Close()
 		logger.Debug("Closing old core application instance")
 	}
 
+	if cfg.Database.MergeCrossSource {
+		coreApp.EnableCrossSourceMerge()
+	}
+	coreApp.EnableCrossRef(cfg.CrossRef)
+	coreApp.EnableUnpaywall(cfg.Unpaywall)
+	coreApp.EnableLastCrawlTracking(core.DefaultStatusDir())
 	a.coreApp = coreApp
+	a.initTranslator(cfg)
 	logger.Debug("Core application reloaded with new config")
 	return nil
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"PaperHunter/config"
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/translate"
+	"PaperHunter/pkg/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// TranslateResult 翻译选中论文的结果
+type TranslateResult struct {
+	Total      int    `json:"total"`
+	Translated int    `json:"translated"`
+	TargetLang string `json:"targetLang"`
+}
+
+// initTranslator 按 LLM 配置创建翻译服务并挂到核心模块，translation_model 为空时使用 Agent 的模型
+func (a *App) initTranslator(cfg *config.AppConfig) {
+	if cfg == nil || a.coreApp == nil {
+		return
+	}
+	model := cfg.LLM.TranslationModel
+	if model == "" {
+		model = cfg.LLM.ModelName
+	}
+	svc, err := translate.New(translate.Config{BaseURL: cfg.LLM.BaseURL, APIKey: cfg.LLM.APIKey, Model: model})
+	if err != nil {
+		logger.Error("翻译服务初始化失败: %v", err)
+		return
+	}
+	a.coreApp.Translator = svc
+}
+
+// TranslateSelected 翻译选中论文的标题和摘要并保存，targetLang 如 zh、en、ja，为空时翻译为中文；
+// 每完成一批发送 translate-progress 事件
+func (a *App) TranslateSelected(source string, ids []string, targetLang string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no papers selected")
+	}
+	if targetLang == "" {
+		targetLang = core.DefaultTranslateLang
+	}
+
+	ctx := context.Background()
+	conditions, params := selectionConditions(source, ids)
+	papers, _, err := a.coreApp.GetPapers(ctx, 1, len(ids), conditions, params, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to load papers: %w", err)
+	}
+
+	res := TranslateResult{Total: len(papers), TargetLang: targetLang}
+	for start := 0; start < len(papers); start += core.TranslateBatchSize {
+		batch := papers[start:min(start+core.TranslateBatchSize, len(papers))]
+		if err := a.coreApp.TranslateAbstracts(ctx, batch, targetLang); err != nil {
+			return "", fmt.Errorf("translate failed after %d papers: %w", res.Translated, err)
+		}
+		res.Translated += len(batch)
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "translate-progress", map[string]int{"done": res.Translated, "total": res.Total})
+		}
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return string(data), nil
+}
+
+// TranslateMissingPapers 翻译库中尚无 targetLang 译文的全部论文，batchSize <= 0 时使用默认批大小
+func (a *App) TranslateMissingPapers(batchSize int, targetLang string) (string, error) {
+	if a.coreApp == nil {
+		return "", fmt.Errorf("app not initialized")
+	}
+	if targetLang == "" {
+		targetLang = core.DefaultTranslateLang
+	}
+
+	n, err := a.coreApp.TranslateMissing(context.Background(), batchSize, targetLang)
+	if err != nil {
+		return "", fmt.Errorf("translate failed after %d papers: %w", n, err)
+	}
+	data, err := json.Marshal(TranslateResult{Total: n, Translated: n, TargetLang: targetLang})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return string(data), nil
+}
@@ -7,10 +7,11 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
-	"PaperHunter/config"
 	"PaperHunter/desktop/memory"
+	"PaperHunter/internal/core"
 	"PaperHunter/internal/models"
 	"PaperHunter/pkg/logger"
 )
@@ -25,6 +26,13 @@ func (a *App) getDailyRecommendationsDirect(opts RecommendOptions, agentLogs []A
 	if maxRecommendations <= 0 {
 		maxRecommendations = 20
 	}
+	minSimilarity, err := resolveMinSimilarity(opts.MinSimilarity)
+	if err != nil {
+		return "", err
+	}
+	if err := core.ValidateSortBy(opts.SortBy); err != nil {
+		return "", err
+	}
 
 	ctx := context.Background()
 
@@ -33,23 +41,36 @@ func (a *App) getDailyRecommendationsDirect(opts RecommendOptions, agentLogs []A
 		Recommendations: make([]RecommendationGroup, 0),
 	}
 
-	today := time.Now().Format("2006-01-02")
-	alreadyCrawled := checkTodayCrawled()
+	// 周末与节假日 arXiv 不公布新论文，默认使用最近一个公布日的列表
+	announceDay := a.arxivAnnouncementDay(time.Now())
+	announced := announceDay.Format("2006-01-02")
+	archives := a.dailyArchives(opts.Archives)
+	alreadyCrawled := checkCrawled(announceDay, archives)
 	output.CrawledToday = alreadyCrawled
+	output.AnnouncementDate = announced
 
 	dateFrom := opts.DateFrom
 	if dateFrom == "" {
-		dateFrom = today
+		dateFrom = announced
 	}
 	dateTo := opts.DateTo
 	if dateTo == "" {
-		dateTo = today
+		dateTo = announced
 	}
 
-	if !alreadyCrawled || opts.ForceCrawl {
-		logger.Info("使用 New Submissions 页面爬取今日 arXiv CS 论文...")
+	if opts.SkipCrawl {
+		logger.Info("预览模式：跳过 arXiv 爬取，基于库中 %s ~ %s 的论文推荐", dateFrom, dateTo)
+		skipLog := AgentLogEntry{
+			Type:      "tool_result",
+			Content:   fmt.Sprintf("预览模式：未爬取新论文，仅基于库中 %s ~ %s 的已有论文推荐", dateFrom, dateTo),
+			Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		}
+		agentLogs = append(agentLogs, skipLog)
+		a.logAndEmit(skipLog)
+	} else if !alreadyCrawled || opts.ForceCrawl {
+		logger.Info("使用 New Submissions 页面爬取今日 arXiv 论文: %s", strings.Join(archives, ", "))
 
-		crawlCount, err := crawlTodayNewSubmissions(ctx, a, "cs")
+		crawlCount, err := crawlTodayNewSubmissions(ctx, a, archives, announceDay)
 		if err != nil {
 			logger.Warn("爬取失败: %v", err)
 
@@ -57,60 +78,66 @@ func (a *App) getDailyRecommendationsDirect(opts RecommendOptions, agentLogs []A
 			output.ArxivCrawlCount = crawlCount
 
 			if crawlCount > 0 {
-				if err := markTodayCrawled(); err == nil {
+				if err := markCrawled(announceDay, archives); err == nil {
 					output.CrawledToday = true
 				}
 			}
 		}
 	}
 
-	// HyDE 意图分析：先使用关键词 topK=5（标题+摘要）作为上下文
-	const hydeKeywordTopK = 5
-	intent, intentLogs, _ := a.analyzeUserIntent(opts, dateFrom, dateTo, hydeKeywordTopK)
-	if len(intentLogs) > 0 {
-		agentLogs = append(agentLogs, intentLogs...)
+	plan, err := resolveSeedPlan(opts, defaultRecommendSeedPlan)
+	if err != nil {
+		return "", err
 	}
 
-	var seeds []*models.Paper
-
-	cfg := config.Get()
-	if cfg != nil && cfg.Zotero.UserID != "" && cfg.Zotero.APIKey != "" {
-		zoteroPapers, err := getZoteroPapers(opts.ZoteroCollection, 50)
-		if err != nil {
-			logger.Warn("从 Zotero 获取论文失败: %v", err)
-		} else {
-			seeds = append(seeds, zoteroPapers...)
+	// HyDE 意图分析：先使用关键词 topK=5（标题+摘要）作为上下文，仅在轮到兴趣来源时执行
+	const hydeKeywordTopK = 5
+	interestSeed := func() *models.Paper {
+		intent, intentLogs, _ := a.analyzeUserIntent(opts, dateFrom, dateTo, hydeKeywordTopK)
+		if len(intentLogs) > 0 {
+			agentLogs = append(agentLogs, intentLogs...)
 		}
-	} else {
-		logger.Info("Zotero 未配置，跳过 Zotero 种子论文")
-	}
-
-	if intent != nil && intent.GeneratedTitle != "" && intent.GeneratedAbstract != "" {
-		seeds = append(seeds, &models.Paper{
+		if intent == nil || intent.GeneratedTitle == "" || intent.GeneratedAbstract == "" {
+			return nil
+		}
+		return &models.Paper{
 			Title:    intent.GeneratedTitle,
 			Abstract: intent.GeneratedAbstract,
 			Source:   "user_query",
 			SourceID: "hype_generated",
-		})
+		}
+	}
+
+	seeds := a.collectSeeds(ctx, plan, opts, interestSeed)
+	if len(seeds) > 0 {
+		seedLog := AgentLogEntry{
+			Type:      "tool_result",
+			Content:   fmt.Sprintf("种子论文 %d 篇（来源: %s，方式: %s）", len(seeds), strings.Join(plan.Sources, " → "), plan.Mode),
+			Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		}
+		agentLogs = append(agentLogs, seedLog)
+		a.logAndEmit(seedLog)
 	}
 
 	if len(seeds) == 0 {
 
 		warnLog := AgentLogEntry{
 			Type:      "error",
-			Content:   "未找到种子论文（Zotero 为空且未生成示例）",
+			Content:   "未找到种子论文（种子来源为空且未生成示例）",
 			Timestamp: time.Now().Format("2006-01-02 15:04:05"),
 		}
 		agentLogs = append(agentLogs, warnLog)
 		a.logAndEmit(warnLog)
 
 		recommendResult := RecommendResult{
-			CrawledToday:    output.CrawledToday,
-			ArxivCrawlCount: output.ArxivCrawlCount,
-			SeedPaperCount:  len(seeds),
-			Recommendations: make([]RecommendationGroup, 0),
-			Message:         "未找到种子论文",
-			AgentLogs:       agentLogs,
+			CrawledToday:     output.CrawledToday,
+			AnnouncementDate: output.AnnouncementDate,
+			ArxivCrawlCount:  output.ArxivCrawlCount,
+			SeedPaperCount:   len(seeds),
+			Recommendations:  make([]RecommendationGroup, 0),
+			CrawlSkipped:     opts.SkipCrawl,
+			Message:          skipCrawlMessage(opts, "未找到种子论文"),
+			AgentLogs:        agentLogs,
 		}
 		data, marshalErr := json.Marshal(recommendResult)
 		if marshalErr != nil {
@@ -137,8 +164,6 @@ func (a *App) getDailyRecommendationsDirect(opts RecommendOptions, agentLogs []A
 
 	logger.Info("搜索日期范围: %s 至 %s", searchDateFrom, searchDateTo)
 
-	allRecommendedPapers := make(map[string]*models.SimilarPaper)
-
 	mem, _ := memory.New("", 30, 7)
 	if mem != nil {
 		mem.Cleanup()
@@ -162,49 +187,24 @@ func (a *App) getDailyRecommendationsDirect(opts RecommendOptions, agentLogs []A
 			return nil, nil
 		}
 		profile = mem.BuildProfile(recentEvents, 12, embedFunc, "")
-	}
-
-	for _, seedPaper := range seeds {
-		similarPapers, err := searchSimilarPapers(ctx, a, seedPaper, topK, fromDate, toDate)
-		if err != nil {
-			continue
-		}
-
-		filteredPapers := make([]*models.SimilarPaper, 0)
-		for _, sp := range similarPapers {
-			key := fmt.Sprintf("%s:%s", sp.Paper.Source, sp.Paper.SourceID)
-			if recentKeys != nil {
-				if _, exists := recentKeys[key]; exists {
-					// 近期推送过的论文：降权但不直接过滤，保留丰富度
-					sp.Similarity *= 0.7
-				}
-			}
-			if _, exists := allRecommendedPapers[key]; !exists {
-				isDuplicate := false
-				for _, s := range seeds {
-					if s.Source == sp.Paper.Source && s.SourceID == sp.Paper.SourceID {
-						isDuplicate = true
-						break
-					}
-				}
-				if !isDuplicate {
-					filteredPapers = append(filteredPapers, sp)
-					allRecommendedPapers[key] = sp
-				}
-			}
+		// 写入画像缓存，供搜索建议读取
+		if err := mem.SaveProfileCache(profile); err != nil {
+			logger.Warn("保存用户画像失败: %v", err)
 		}
+	}
 
-		if len(filteredPapers) > 0 {
-			personalizedRerank(filteredPapers, profile)
-			output.Recommendations = append(output.Recommendations, RecommendationGroup{
-				SeedPaper: *seedPaper,
-				Papers:    filteredPapers,
-			})
-		}
+	search := func(ctx context.Context, seed *models.Paper) ([]*models.SimilarPaper, error) {
+		return searchSimilarPapers(ctx, a, seed, topK, minSimilarity, fromDate, toDate)
+	}
+	var allRecommendedPapers map[string]*models.SimilarPaper
+	output.Recommendations, allRecommendedPapers = recommendFromSeeds(ctx, seeds, search, recentKeys, profile, maxRecommendations)
 
-		if len(allRecommendedPapers) >= maxRecommendations {
-			break
-		}
+	if opts.DiversityLambda > 0 && opts.DiversityLambda < 1 {
+		output.Recommendations = a.diversifyRecommendations(ctx, output.Recommendations, profile, opts.DiversityLambda, maxRecommendations)
+	}
+	// 排序字段已在开头校验
+	for _, group := range output.Recommendations {
+		core.SortResults(group.Papers, opts.SortBy, opts.SortDesc)
 	}
 
 	// 限制总推荐数量
@@ -245,12 +245,14 @@ func (a *App) getDailyRecommendationsDirect(opts RecommendOptions, agentLogs []A
 	}
 
 	recommendResult := RecommendResult{
-		CrawledToday:    output.CrawledToday,
-		ArxivCrawlCount: output.ArxivCrawlCount,
-		SeedPaperCount:  len(seeds),
-		Recommendations: output.Recommendations,
-		Message:         output.Message,
-		AgentLogs:       agentLogs,
+		CrawledToday:     output.CrawledToday,
+		AnnouncementDate: output.AnnouncementDate,
+		ArxivCrawlCount:  output.ArxivCrawlCount,
+		SeedPaperCount:   len(seeds),
+		Recommendations:  output.Recommendations,
+		CrawlSkipped:     opts.SkipCrawl,
+		Message:          skipCrawlMessage(opts, output.Message),
+		AgentLogs:        agentLogs,
 	}
 
 	data, err := json.Marshal(recommendResult)
@@ -281,6 +283,96 @@ func (a *App) getDailyRecommendationsDirect(opts RecommendOptions, agentLogs []A
 	return string(data), nil
 }
 
+// seedSearchWorkers 推荐时并发检索种子论文的 worker 数
+const seedSearchWorkers = 4
+
+// recommendFromSeeds 用有限的 worker 池并发检索每篇种子的相似论文，结果在锁内合并：
+// 近期推送过的论文降权，已推荐过或本身是种子的论文跳过；推荐数达到 maxRecommendations 后不再检索剩余种子。
+// 返回按种子顺序排列的推荐组，以及所有已推荐论文（key 为 source:source_id）
+func recommendFromSeeds(ctx context.Context, seeds []*models.Paper, search func(context.Context, *models.Paper) ([]*models.SimilarPaper, error),
+	recentKeys map[string]struct{}, profile *memory.ProfileCache, maxRecommendations int) ([]RecommendationGroup, map[string]*models.SimilarPaper) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	seedKeys := make(map[string]struct{}, len(seeds))
+	for _, s := range seeds {
+		seedKeys[fmt.Sprintf("%s:%s", s.Source, s.SourceID)] = struct{}{}
+	}
+
+	type seedGroup struct {
+		index int
+		group RecommendationGroup
+	}
+	var (
+		mu     sync.Mutex
+		groups []seedGroup
+		all    = make(map[string]*models.SimilarPaper)
+	)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(seedSearchWorkers, len(seeds)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				similarPapers, err := search(ctx, seeds[i])
+				if err != nil {
+					continue
+				}
+
+				mu.Lock()
+				filteredPapers := make([]*models.SimilarPaper, 0)
+				for _, sp := range similarPapers {
+					key := fmt.Sprintf("%s:%s", sp.Paper.Source, sp.Paper.SourceID)
+					if recentKeys != nil {
+						if _, exists := recentKeys[key]; exists {
+							// 近期推送过的论文：降权但不直接过滤，保留丰富度
+							sp.Similarity *= 0.7
+						}
+					}
+					if _, exists := all[key]; exists {
+						continue
+					}
+					if _, isSeed := seedKeys[key]; isSeed {
+						continue
+					}
+					filteredPapers = append(filteredPapers, sp)
+					all[key] = sp
+				}
+				if len(filteredPapers) > 0 {
+					personalizedRerank(filteredPapers, profile)
+					groups = append(groups, seedGroup{index: i, group: RecommendationGroup{SeedPaper: *seeds[i], Papers: filteredPapers}})
+				}
+				if len(all) >= maxRecommendations {
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for i := range seeds {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	// 结果到达顺序不定，按种子顺序输出，保证上限截断与顺序执行时一致
+	sort.Slice(groups, func(i, j int) bool { return groups[i].index < groups[j].index })
+	out := make([]RecommendationGroup, len(groups))
+	for i, g := range groups {
+		out[i] = g.group
+	}
+	return out, all
+}
+
 func personalizedRerank(papers []*models.SimilarPaper, profile *memory.ProfileCache) {
 	if len(papers) <= 1 {
 		return
@@ -14,8 +14,8 @@ import (
 	"PaperHunter/internal/core"
 	"PaperHunter/internal/models"
 	"PaperHunter/internal/platform/arxiv"
+	"PaperHunter/internal/platform/openreview"
 	"PaperHunter/pkg/logger"
-	"PaperHunter/pkg/upload/zotero"
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
@@ -30,17 +30,32 @@ type ZoteroRecommendInput struct {
 	CollectionKey string `json:"collection_key,omitempty" jsonschema:"description=Collection key for get_papers or daily_recommend action"`
 	Limit         int    `json:"limit,omitempty" jsonschema:"description=Limit number of papers to return (for get_papers)"`
 
-	TopK               int    `json:"top_k,omitempty" jsonschema:"description=Number of recommended papers (default: 10)"`
-	MaxRecommendations int    `json:"max_recommendations,omitempty" jsonschema:"description=Maximum total number of papers to recommend (default: 30)"`
-	ForceCrawl         bool   `json:"force_crawl,omitempty" jsonschema:"description=Force re-crawl today's arXiv papers (default: false)"`
-	DateFrom           string `json:"date_from,omitempty" jsonschema:"description=Date in YYYY-MM-DD format (default: today)"`
-	DateTo             string `json:"date_to,omitempty" jsonschema:"description=Date in YYYY-MM-DD format (default: today)"`
-	ExampleTitle       string `json:"example_title,omitempty" jsonschema:"description=Your research interests or topic (used for recommendation)"`
-	ExampleAbstract    string `json:"example_abstract,omitempty" jsonschema:"description=Detailed description of your research interests"`
+	TopK               int      `json:"top_k,omitempty" jsonschema:"description=Number of recommended papers (default: 10)"`
+	MaxRecommendations int      `json:"max_recommendations,omitempty" jsonschema:"description=Maximum total number of papers to recommend (default: 30)"`
+	ForceCrawl         bool     `json:"force_crawl,omitempty" jsonschema:"description=Force re-crawl today's arXiv papers (default: false)"`
+	Archives           []string `json:"archives,omitempty" jsonschema:"description=arXiv archives or categories to crawl for daily_recommend, e.g. cs, stat, math.ST, stat.ML, eess.SP (default: arxiv.new_submissions_categories in config)"`
+	DateFrom           string   `json:"date_from,omitempty" jsonschema:"description=Date in YYYY-MM-DD format (default: today)"`
+	DateTo             string   `json:"date_to,omitempty" jsonschema:"description=Date in YYYY-MM-DD format (default: today)"`
+	ExampleTitle       string   `json:"example_title,omitempty" jsonschema:"description=Your research interests or topic (used for recommendation)"`
+	ExampleAbstract    string   `json:"example_abstract,omitempty" jsonschema:"description=Detailed description of your research interests"`
 
 	// 新增：本地JSON文件导入支持
 	LocalFilePath   string `json:"local_file_path,omitempty" jsonschema:"description=Path to local JSON file to import for recommendation"`
 	LocalFileAction string `json:"local_file_action,omitempty" jsonschema:"description=Action: 'import_for_recommend'"`
+
+	// 种子来源顺序与组合方式，见 seeds.go
+	SeedSources []string `json:"seed_sources,omitempty" jsonschema:"description=Ordered seed sources: interest, local_file, zotero, openreview_accepted (default: interest, local_file, zotero)"`
+	SeedMode    string   `json:"seed_mode,omitempty" jsonschema:"enum=fallback,enum=combine,description=fallback uses the first source that yields seeds; combine merges all sources (default: fallback)"`
+
+	// MinSimilarity 推荐论文的相似度下限，为空时使用 0.2
+	MinSimilarity *float32 `json:"min_similarity,omitempty" jsonschema:"description=Minimum similarity in [0,1] for recommended papers; raise it to cut noise, 0 disables filtering (default: 0.2)"`
+}
+
+// defaultToolSeedPlan 工具调用默认按兴趣描述、本地文件、Zotero 的顺序回退
+var defaultToolSeedPlan = seedPlan{
+	Sources: []string{SeedSourceInterest, SeedSourceLocalFile, SeedSourceZotero},
+	Mode:    SeedModeFallback,
+	Limit:   10,
 }
 
 type ZoteroRecommendOutput struct {
@@ -50,10 +65,11 @@ type ZoteroRecommendOutput struct {
 	Data    any    `json:"data,omitempty" jsonschema:"description=Result data (collections or papers for get_collections/get_papers)"`
 
 	// 用于 daily_recommend（arXiv专注）
-	CrawledToday    bool                  `json:"crawled_today,omitempty" jsonschema:"description=Whether arXiv papers were crawled today (for daily_recommend)"`
-	ArxivCrawlCount int                   `json:"arxiv_crawl_count,omitempty" jsonschema:"description=Number of arXiv papers crawled today (for daily_recommend)"`
-	SeedPaperCount  int                   `json:"seed_paper_count,omitempty" jsonschema:"description=Number of seed papers used for recommendation (Zotero + interests)"`
-	Recommendations []RecommendationGroup `json:"recommendations,omitempty" jsonschema:"description=Grouped recommendations based on seed papers or interests (for daily_recommend)"`
+	CrawledToday     bool                  `json:"crawled_today,omitempty" jsonschema:"description=Whether the latest arXiv listing has been crawled (for daily_recommend)"`
+	AnnouncementDate string                `json:"announcement_date,omitempty" jsonschema:"description=Date (YYYY-MM-DD) of the arXiv listing actually fetched; weekends and holidays fall back to the previous announcement day (for daily_recommend)"`
+	ArxivCrawlCount  int                   `json:"arxiv_crawl_count,omitempty" jsonschema:"description=Number of arXiv papers crawled from the listing (for daily_recommend)"`
+	SeedPaperCount   int                   `json:"seed_paper_count,omitempty" jsonschema:"description=Number of seed papers used for recommendation (Zotero + interests)"`
+	Recommendations  []RecommendationGroup `json:"recommendations,omitempty" jsonschema:"description=Grouped recommendations based on seed papers or interests (for daily_recommend)"`
 }
 
 type RecommendationGroup struct {
@@ -61,38 +77,88 @@ type RecommendationGroup struct {
 	Papers    []*models.SimilarPaper `json:"papers" jsonschema:"description=Recommended arXiv papers similar to the seed paper or interest"`
 }
 
-func getTodayCrawlStatusFile() string {
+// getCrawlStatusFile 公布日 day 的爬取状态文件，周末与节假日运行时与上一个公布日共用
+func getCrawlStatusFile(day time.Time) string {
 	homeDir, _ := os.UserHomeDir()
 	statusDir := filepath.Join(homeDir, ".quicksearch", "status")
 	os.MkdirAll(statusDir, 0755)
-	today := time.Now().Format("2006-01-02")
-	return filepath.Join(statusDir, fmt.Sprintf("crawl_%s.txt", today))
+	return filepath.Join(statusDir, fmt.Sprintf("crawl_%s.txt", day.Format("2006-01-02")))
 }
 
-func checkTodayCrawled() bool {
-	statusFile := getTodayCrawlStatusFile()
-	_, err := os.Stat(statusFile)
-	return err == nil
+// checkCrawled 公布日 day 的列表是否已爬取过全部指定的 archive
+// 状态文件第一行为爬取时间，第二行为已爬取的 archive 列表；旧格式没有第二行，视为只爬取了 cs
+func checkCrawled(day time.Time, archives []string) bool {
+	data, err := os.ReadFile(getCrawlStatusFile(day))
+	if err != nil {
+		return false
+	}
+
+	crawled := map[string]bool{}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) < 2 {
+		crawled["cs"] = true
+	} else {
+		for _, archive := range strings.Split(lines[1], ",") {
+			crawled[strings.TrimSpace(archive)] = true
+		}
+	}
+
+	for _, archive := range archives {
+		if !crawled[archive] {
+			return false
+		}
+	}
+	return true
 }
 
-func markTodayCrawled() error {
-	statusFile := getTodayCrawlStatusFile()
-	return os.WriteFile(statusFile, []byte(time.Now().Format(time.RFC3339)), 0644)
+func markCrawled(day time.Time, archives []string) error {
+	statusFile := getCrawlStatusFile(day)
+	content := time.Now().Format(time.RFC3339) + "\n" + strings.Join(archives, ",")
+	return os.WriteFile(statusFile, []byte(content), 0644)
 }
 
+// arxivAnnouncementDay now 当天或之前最近的 arXiv 公布日，跳过周末与配置 arxiv.holidays 中的节假日
+func (a *App) arxivAnnouncementDay(now time.Time) time.Time {
+	var holidays []string
+	if a != nil && a.config != nil {
+		holidays = a.config.Arxiv.Holidays
+	}
+	return arxiv.AnnouncementDay(now, holidays)
+}
 
-// 使用 https://arxiv.org/list/cs/new 获取今日公布的 CS 领域论文
-func crawlTodayNewSubmissions(ctx context.Context, app *App, category string) (int, error) {
-	if app == nil || app.coreApp == nil {
-		return 0, fmt.Errorf("app instance is not initialized")
+// dailyArchives 每日推荐要爬取的 arXiv archive 或分类：优先使用请求参数，其次为配置 arxiv.new_submissions_categories，默认 cs
+func (a *App) dailyArchives(requested []string) []string {
+	var archives []string
+	seen := make(map[string]bool)
+	add := func(list []string) {
+		for _, archive := range list {
+			archive = strings.TrimSpace(archive)
+			if archive != "" && !seen[archive] {
+				seen[archive] = true
+				archives = append(archives, archive)
+			}
+		}
 	}
 
-	if category == "" {
-		category = "cs" // 默认 CS 全部
+	add(requested)
+	if len(archives) == 0 && a != nil && a.config != nil {
+		add(a.config.Arxiv.DailyCategories())
 	}
+	if len(archives) == 0 {
+		archives = []string{"cs"}
+	}
+	return archives
+}
 
+// 使用 https://arxiv.org/list/<archive>/new 获取公布日 day 的论文，多个 archive 间交叉列出的论文只保存一次；
+// 该页面总是最近一个公布日的列表，论文的公布时间记为 day 而不是爬取时间
+func crawlTodayNewSubmissions(ctx context.Context, app *App, archives []string, day time.Time) (int, error) {
+	if app == nil || app.coreApp == nil {
+		return 0, fmt.Errorf("app instance is not initialized")
+	}
 
-	logger.Info("使用 New Submissions 页面获取今日 arXiv %s 论文", category)
+	archives = app.dailyArchives(archives)
+	logger.Info("使用 New Submissions 页面获取 %s 公布的 arXiv %s 论文", day.Format("2006-01-02"), strings.Join(archives, ", "))
 
 	// 获取 arxiv adapter
 	plat, err := app.coreApp.GetPlatform("arxiv")
@@ -106,7 +172,7 @@ func crawlTodayNewSubmissions(ctx context.Context, app *App, category string) (i
 	}
 
 
-	result, err := arxivAdapter.FetchNewSubmissions(ctx, category)
+	result, err := arxivAdapter.FetchNewSubmissions(ctx, archives)
 	if err != nil {
 		return 0, fmt.Errorf("获取今日新论文失败: %w", err)
 	}
@@ -117,6 +183,9 @@ func crawlTodayNewSubmissions(ctx context.Context, app *App, category string) (i
 	}
 
 	logger.Info("获取到 %d 篇今日新论文，开始保存到数据库", len(result.Papers))
+	for _, p := range result.Papers {
+		p.FirstAnnouncedAt = day
+	}
 
 
 	count, err := app.coreApp.SavePapers(ctx, result.Papers)
@@ -124,7 +193,7 @@ func crawlTodayNewSubmissions(ctx context.Context, app *App, category string) (i
 		logger.Warn("保存论文时出错: %v", err)
 	}
 
-	logger.Info("今日 arXiv %s 新论文保存完成: %d 篇", category, count)
+	logger.Info("今日 arXiv %s 新论文保存完成: %d 篇", strings.Join(archives, ", "), count)
 	return count, nil
 }
 
@@ -135,7 +204,7 @@ func getZoteroPapers(collectionKey string, limit int) ([]*models.Paper, error) {
 		return nil, fmt.Errorf("zotero 配置不完整，请在配置文件中设置 zotero.user_id 和 zotero.api_key")
 	}
 
-	client := zotero.NewClient(cfg.Zotero.UserID, cfg.Zotero.APIKey)
+	client := cfg.Zotero.NewClient()
 	papers, err := client.GetPapers(collectionKey, limit)
 	if err != nil {
 		return nil, fmt.Errorf("从 Zotero 获取论文失败: %w", err)
@@ -144,8 +213,44 @@ func getZoteroPapers(collectionKey string, limit int) ([]*models.Paper, error) {
 	return papers, nil
 }
 
+// getOpenReviewAcceptedPapers 获取指定会议的录用论文作为种子，按评审平均分取前 limit 篇
+func getOpenReviewAcceptedPapers(ctx context.Context, app *App, opts RecommendOptions, limit int) ([]*models.Paper, error) {
+	if app == nil || app.coreApp == nil {
+		return nil, fmt.Errorf("app instance is not initialized")
+	}
+	if strings.TrimSpace(opts.OpenReviewVenue) == "" {
+		return nil, fmt.Errorf("openreview_accepted 种子来源需要指定会议 ID")
+	}
+
+	plat, err := app.coreApp.GetPlatform("openreview")
+	if err != nil {
+		return nil, fmt.Errorf("获取 openreview 平台失败: %w", err)
+	}
+
+	orAdapter, ok := plat.(*openreview.Adapter)
+	if !ok {
+		return nil, fmt.Errorf("类型转换失败: 不是 openreview.Adapter")
+	}
+
+	accepted, err := orAdapter.FetchAccepted(ctx, openreview.AcceptedQuery{
+		VenueID:   opts.OpenReviewVenue,
+		Decisions: opts.OpenReviewDecisions,
+		MinScore:  opts.OpenReviewMinScore,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	papers := make([]*models.Paper, 0, len(accepted))
+	for _, ap := range accepted {
+		papers = append(papers, ap.Paper)
+	}
+	return papers, nil
+}
 
-func searchSimilarPapers(ctx context.Context, app *App, seedPaper *models.Paper, topK int, fromDate, toDate *time.Time) ([]*models.SimilarPaper, error) {
+// searchSimilarPapers 基于种子论文语义搜索 arXiv 论文，相似度低于 minSimilarity 的结果被过滤（0 表示不过滤）
+func searchSimilarPapers(ctx context.Context, app *App, seedPaper *models.Paper, topK int, minSimilarity float32, fromDate, toDate *time.Time) ([]*models.SimilarPaper, error) {
 	if app == nil || app.coreApp == nil {
 		return nil, fmt.Errorf("app not initialized")
 	}
@@ -162,8 +267,9 @@ func searchSimilarPapers(ctx context.Context, app *App, seedPaper *models.Paper,
 	opts := core.SearchOptions{
 		Examples:  []*models.Paper{seedPaper},
 		Condition: cond,
-		TopK:      topK * 3,
-		Semantic:  true,
+		TopK:          topK * 3,
+		Semantic:      true,
+		MinSimilarity: minSimilarity,
 	}
 
 	results, err := app.coreApp.Search(ctx, opts)
@@ -171,15 +277,7 @@ func searchSimilarPapers(ctx context.Context, app *App, seedPaper *models.Paper,
 		return nil, fmt.Errorf("搜索失败: %w", err)
 	}
 
-
-	const minSimilarity = 0.2 // 降低阈值
-	filtered := make([]*models.SimilarPaper, 0, len(results))
-	for _, sp := range results {
-		if sp.Similarity >= minSimilarity {
-			filtered = append(filtered, sp)
-		}
-	}
-
+	filtered := results
 	if len(filtered) > topK {
 		filtered = filtered[:topK]
 	}
@@ -188,7 +286,7 @@ func searchSimilarPapers(ctx context.Context, app *App, seedPaper *models.Paper,
 	if len(titleSample) > 30 {
 		titleSample = titleSample[:30] + "..."
 	}
-	logger.Info("基于种子 [%s] 搜索完成: 原始 %d 篇，过滤后 %d 篇 (阈值: %.2f)", titleSample, len(results), len(filtered), minSimilarity)
+	logger.Info("基于种子 [%s] 搜索完成: 阈值过滤后 %d 篇，返回 %d 篇 (阈值: %.2f)", titleSample, len(results), len(filtered), minSimilarity)
 	return filtered, nil
 }
 
@@ -204,7 +302,7 @@ func NewZoteroRecommendTool(app *App) tool.InvokableTool {
 				}, fmt.Errorf("zotero config incomplete")
 			}
 
-			client := zotero.NewClient(cfg.Zotero.UserID, cfg.Zotero.APIKey)
+			client := cfg.Zotero.NewClient()
 
 			switch input.Action {
 			case "get_collections":
@@ -278,18 +376,27 @@ func NewZoteroRecommendTool(app *App) tool.InvokableTool {
 				if maxRecommendations <= 0 {
 					maxRecommendations = 20
 				}
+				minSimilarity, err := resolveMinSimilarity(input.MinSimilarity)
+				if err != nil {
+					return &ZoteroRecommendOutput{
+						Success: false,
+						Message: err.Error(),
+					}, err
+				}
 
-				// 解析日期范围，如果没有指定则使用今天
+				// 解析日期范围，如果没有指定则使用最近一个 arXiv 公布日（周末、节假日取上一个公布日）
+				announceDay := app.arxivAnnouncementDay(time.Now())
+				announced := announceDay.Format("2006-01-02")
 				var dateFrom, dateTo string
 				if input.DateFrom != "" {
 					dateFrom = input.DateFrom
 				} else {
-					dateFrom = time.Now().Format("2006-01-02")
+					dateFrom = announced
 				}
 				if input.DateTo != "" {
 					dateTo = input.DateTo
 				} else {
-					dateTo = time.Now().Format("2006-01-02")
+					dateTo = announced
 				}
 
 				output := &ZoteroRecommendOutput{
@@ -297,61 +404,59 @@ func NewZoteroRecommendTool(app *App) tool.InvokableTool {
 					Recommendations: make([]RecommendationGroup, 0),
 				}
 
-				// 检查今天是否已爬取
-				today := time.Now().Format("2006-01-02")
-				alreadyCrawled := checkTodayCrawled()
+				// 检查最近一个公布日的列表是否已爬取
+				archives := app.dailyArchives(input.Archives)
+				alreadyCrawled := checkCrawled(announceDay, archives)
 				output.CrawledToday = alreadyCrawled
+				output.AnnouncementDate = announced
 
 				// 使用 New Submissions 页面爬取今日论文
 				if !alreadyCrawled || input.ForceCrawl {
-					logger.Info("使用 New Submissions 页面爬取今日 arXiv CS 论文")
-					crawlCount, err := crawlTodayNewSubmissions(ctx, app, "cs")
+					logger.Info("使用 New Submissions 页面爬取今日 arXiv 论文: %s", strings.Join(archives, ", "))
+					crawlCount, err := crawlTodayNewSubmissions(ctx, app, archives, announceDay)
 					if err != nil {
 						logger.Warn("爬取失败: %v", err)
 					} else {
 						output.ArxivCrawlCount = crawlCount
 						if crawlCount > 0 {
-							markTodayCrawled()
+							markCrawled(announceDay, archives)
 							output.CrawledToday = true
 						}
-						logger.Info("今日 arXiv CS 论文爬取完成: %d 篇", crawlCount)
+						logger.Info("今日 arXiv 论文爬取完成: %d 篇", crawlCount)
 					}
 				} else {
 					logger.Info("今日 arXiv 论文已爬取，跳过")
 				}
 
-				// 简化种子收集：优先使用用户兴趣描述
-				var seeds []*models.Paper
+				seedOpts := RecommendOptions{
+					ZoteroCollection: input.CollectionKey,
+					SeedSources:      input.SeedSources,
+					SeedMode:         input.SeedMode,
+				}
+				if input.LocalFileAction == "import_for_recommend" {
+					seedOpts.LocalFilePath = input.LocalFilePath
+				}
+				plan, err := resolveSeedPlan(seedOpts, defaultToolSeedPlan)
+				if err != nil {
+					return &ZoteroRecommendOutput{
+						Success: false,
+						Message: err.Error(),
+					}, err
+				}
 
-				// 优先使用用户兴趣描述
-				if input.ExampleTitle != "" && input.ExampleAbstract != "" {
+				interestSeed := func() *models.Paper {
+					if input.ExampleTitle == "" || input.ExampleAbstract == "" {
+						return nil
+					}
 					logger.Info("使用研究兴趣: %s", input.ExampleTitle)
-					seeds = append(seeds, &models.Paper{
+					return &models.Paper{
 						Title:    input.ExampleTitle,
 						Abstract: input.ExampleAbstract,
 						Source:   "user_interest",
 						SourceID: "interest_seed",
-					})
-				}
-
-				if input.LocalFilePath != "" && input.LocalFileAction == "import_for_recommend" {
-					logger.Info("导入本地JSON文件: %s", input.LocalFilePath)
-					localPaper, err := importJSONFile(input.LocalFilePath)
-					if err != nil {
-						logger.Warn("导入JSON文件失败: %v", err)
-					} else {
-						seeds = append(seeds, localPaper)
-						logger.Info("成功导入JSON论文: %s", localPaper.Title)
-					}
-				}
-
-				if cfg.Zotero.UserID != "" && cfg.Zotero.APIKey != "" && len(seeds) == 0 {
-					zoteroPapers, err := getZoteroPapers(input.CollectionKey, 10)
-					if err == nil && len(zoteroPapers) > 0 {
-						seeds = append(seeds, zoteroPapers...)
-						logger.Info("补充 %d 篇Zotero论文", len(zoteroPapers))
 					}
 				}
+				seeds := app.collectSeeds(ctx, plan, seedOpts, interestSeed)
 
 				if len(seeds) == 0 {
 					return &ZoteroRecommendOutput{
@@ -386,7 +491,7 @@ func NewZoteroRecommendTool(app *App) tool.InvokableTool {
 					logger.Info("基于种子论文搜索: %s", seedPaper.Title)
 
 					// 简化：使用固定的搜索数量
-					similarPapers, err := searchSimilarPapers(ctx, app, seedPaper, topK, &fromDate, &toDate)
+					similarPapers, err := searchSimilarPapers(ctx, app, seedPaper, topK, minSimilarity, &fromDate, &toDate)
 					if err != nil {
 						logger.Warn("搜索失败: %v", err)
 						continue
@@ -442,9 +547,9 @@ func NewZoteroRecommendTool(app *App) tool.InvokableTool {
 					totalRecommended += len(group.Papers)
 				}
 
-				if dateFrom == dateTo && dateFrom == today {
-					output.Message = fmt.Sprintf("成功从今日arXiv论文中推荐 %d 篇，基于 %d 个兴趣源", totalRecommended, len(output.Recommendations))
-					logger.Info("成功从今日arXiv论文中推荐 %d 篇，基于 %d 个兴趣源", totalRecommended, len(output.Recommendations))
+				if dateFrom == dateTo && dateFrom == announced {
+					output.Message = fmt.Sprintf("成功从 %s 公布的arXiv论文中推荐 %d 篇，基于 %d 个兴趣源", announced, totalRecommended, len(output.Recommendations))
+					logger.Info("成功从 %s 公布的arXiv论文中推荐 %d 篇，基于 %d 个兴趣源", announced, totalRecommended, len(output.Recommendations))
 				} else {
 					output.Message = fmt.Sprintf("成功推荐 %d 篇arXiv论文，基于 %d 篇种子论文", totalRecommended, len(output.Recommendations))
 					logger.Info("成功推荐 %d 篇arXiv论文，基于 %d 篇种子论文", totalRecommended, len(output.Recommendations))
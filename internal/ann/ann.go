@@ -0,0 +1,53 @@
+package ann
+
+import "math"
+
+// Index 向量近邻索引，相似度为余弦相似度
+type Index interface {
+	// Insert 插入向量，id 已存在时覆盖
+	Insert(id int64, vec []float32)
+	// Remove 移除向量，之后的查询不再返回该 id
+	Remove(id int64)
+	// Search 返回与 vec 最相似的 k 个 id 及相似度，按相似度降序；efSearch 为候选集大小，<= 0 时使用索引默认值
+	Search(vec []float32, k, efSearch int) ([]int64, []float32)
+	// Len 索引中的向量数
+	Len() int
+}
+
+// normalize 返回单位化后的向量副本，零向量原样返回
+func normalize(vec []float32) []float32 {
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	out := make([]float32, len(vec))
+	if norm == 0 {
+		copy(out, vec)
+		return out
+	}
+	inv := float32(1 / math.Sqrt(norm))
+	for i, v := range vec {
+		out[i] = v * inv
+	}
+	return out
+}
+
+// dot 单位向量的点积即余弦相似度，维度不一致时返回 0；按 4 路展开以减少循环开销
+func dot(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		x, y := a[i:i+4:i+4], b[i:i+4:i+4]
+		s0 += x[0] * y[0]
+		s1 += x[1] * y[1]
+		s2 += x[2] * y[2]
+		s3 += x[3] * y[3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return s0 + s1 + s2 + s3
+}
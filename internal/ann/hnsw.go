@@ -0,0 +1,470 @@
+package ann
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+const (
+	// DefaultM 每层最大连接数
+	DefaultM = 16
+	// DefaultEfConstruction 构建时的候选集大小
+	DefaultEfConstruction = 200
+	// DefaultEfSearch 查询时的最小候选集大小
+	DefaultEfSearch = 64
+)
+
+// HNSW 纯 Go 实现的分层可导航小世界图（Hierarchical Navigable Small World），
+// 向量在插入时单位化，距离为 1 - 余弦相似度
+type HNSW struct {
+	M              int
+	EfConstruction int
+	EfSearch       int
+
+	mu        sync.RWMutex
+	nodes     []*hnswNode
+	byID      map[int64]int32
+	entry     int32
+	maxLevel  int
+	levelMult float64
+	rng       *rand.Rand
+	removed   int
+	visited   sync.Pool // *visitedSet，避免每次搜索分配
+}
+
+type hnswNode struct {
+	id      int64
+	vec     []float32
+	deleted bool
+	friends [][]int32   // 每层的邻居
+	dists   [][]float32 // 与 friends 对应的距离，裁剪邻居时无需重新计算
+}
+
+// NewHNSW 使用默认参数创建索引
+func NewHNSW() *HNSW {
+	return &HNSW{
+		M:              DefaultM,
+		EfConstruction: DefaultEfConstruction,
+		EfSearch:       DefaultEfSearch,
+		byID:           make(map[int64]int32),
+		entry:          -1,
+		levelMult:      1 / math.Log(DefaultM),
+		rng:            rand.New(rand.NewSource(42)),
+	}
+}
+
+// Len 有效向量数（不含已移除的）
+func (h *HNSW) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.nodes) - h.removed
+}
+
+// Insert 插入向量；id 已存在时原节点标记为删除后重新插入
+func (h *HNSW) Insert(id int64, vec []float32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if old, ok := h.byID[id]; ok && !h.nodes[old].deleted {
+		h.nodes[old].deleted = true
+		h.removed++
+	}
+
+	level := int(-math.Log(1-h.rng.Float64()) * h.levelMult)
+	n := &hnswNode{id: id, vec: normalize(vec), friends: make([][]int32, level+1), dists: make([][]float32, level+1)}
+	idx := int32(len(h.nodes))
+	h.nodes = append(h.nodes, n)
+	h.byID[id] = idx
+
+	if h.entry < 0 {
+		h.entry, h.maxLevel = idx, level
+		return
+	}
+
+	ep := h.entry
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.greedy(n.vec, ep, l)
+	}
+
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		candidates := h.searchLayer(n.vec, []int32{ep}, h.EfConstruction, l)
+		if len(candidates) > h.maxConn(l) {
+			candidates = candidates[:h.maxConn(l)]
+		}
+		n.friends[l] = make([]int32, len(candidates))
+		n.dists[l] = make([]float32, len(candidates))
+		for i, c := range candidates {
+			n.friends[l][i], n.dists[l][i] = c.idx, c.dist
+			h.link(c.idx, idx, c.dist, l)
+		}
+		ep = candidates[0].idx
+	}
+
+	if level > h.maxLevel {
+		h.entry, h.maxLevel = idx, level
+	}
+}
+
+// Remove 标记删除，节点仍参与图的导航但不会出现在结果中
+func (h *HNSW) Remove(id int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if idx, ok := h.byID[id]; ok && !h.nodes[idx].deleted {
+		h.nodes[idx].deleted = true
+		h.removed++
+		delete(h.byID, id)
+	}
+}
+
+// Search 返回最相似的 k 个向量；efSearch 为候选集大小，越大召回率越高、查询越慢，<= 0 时取 EfSearch，小于 k 时取 k
+func (h *HNSW) Search(vec []float32, k, efSearch int) ([]int64, []float32) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.entry < 0 || k <= 0 {
+		return nil, nil
+	}
+
+	q := normalize(vec)
+	ep := h.entry
+	for l := h.maxLevel; l > 0; l-- {
+		ep = h.greedy(q, ep, l)
+	}
+
+	ef := efSearch
+	if ef <= 0 {
+		ef = h.EfSearch
+	}
+	if ef < k {
+		ef = k
+	}
+	// 已删除节点不计入结果，按比例放大候选集
+	if h.removed > 0 && len(h.nodes) > h.removed {
+		ef = ef * len(h.nodes) / (len(h.nodes) - h.removed)
+	}
+
+	ids := make([]int64, 0, k)
+	sims := make([]float32, 0, k)
+	for _, c := range h.searchLayer(q, []int32{ep}, ef, 0) {
+		n := h.nodes[c.idx]
+		if n.deleted {
+			continue
+		}
+		ids = append(ids, n.id)
+		sims = append(sims, 1-c.dist)
+		if len(ids) == k {
+			break
+		}
+	}
+	return ids, sims
+}
+
+func (h *HNSW) maxConn(level int) int {
+	if level == 0 {
+		return 2 * h.M
+	}
+	return h.M
+}
+
+func (h *HNSW) distance(q []float32, idx int32) float32 {
+	return 1 - dot(q, h.nodes[idx].vec)
+}
+
+// greedy 在单层上贪心移动到离 q 最近的节点
+func (h *HNSW) greedy(q []float32, ep int32, level int) int32 {
+	best := h.distance(q, ep)
+	for changed := true; changed; {
+		changed = false
+		for _, nb := range h.nodes[ep].friends[level] {
+			if d := h.distance(q, nb); d < best {
+				best, ep, changed = d, nb, true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer 在指定层做束搜索，返回按距离升序的最多 ef 个候选
+func (h *HNSW) searchLayer(q []float32, entries []int32, ef int, level int) []candidate {
+	visited := h.acquireVisited()
+	defer h.visited.Put(visited)
+	var frontier minHeap
+	var results maxHeap
+
+	for _, ep := range entries {
+		c := candidate{idx: ep, dist: h.distance(q, ep)}
+		visited.visit(ep)
+		heap.Push(&frontier, c)
+		heap.Push(&results, c)
+	}
+
+	for frontier.Len() > 0 {
+		cur := heap.Pop(&frontier).(candidate)
+		if results.Len() >= ef && cur.dist > results[0].dist {
+			break
+		}
+		node := h.nodes[cur.idx]
+		if level >= len(node.friends) {
+			continue
+		}
+		for _, nb := range node.friends[level] {
+			if !visited.visit(nb) {
+				continue
+			}
+			d := h.distance(q, nb)
+			if results.Len() < ef || d < results[0].dist {
+				heap.Push(&frontier, candidate{idx: nb, dist: d})
+				heap.Push(&results, candidate{idx: nb, dist: d})
+				if results.Len() > ef {
+					heap.Pop(&results)
+				}
+			}
+		}
+	}
+
+	out := make([]candidate, len(results))
+	copy(out, results)
+	sort.Slice(out, func(i, j int) bool { return out[i].dist < out[j].dist })
+	return out
+}
+
+// link 为 from 增加指向 to 的连接；连接已满时替换掉最远的邻居（若 to 更近）
+func (h *HNSW) link(from, to int32, dist float32, level int) {
+	node := h.nodes[from]
+	if len(node.friends[level]) < h.maxConn(level) {
+		node.friends[level] = append(node.friends[level], to)
+		node.dists[level] = append(node.dists[level], dist)
+		return
+	}
+
+	worst := 0
+	for i, d := range node.dists[level] {
+		if d > node.dists[level][worst] {
+			worst = i
+		}
+	}
+	if dist < node.dists[level][worst] {
+		node.friends[level][worst] = to
+		node.dists[level][worst] = dist
+	}
+}
+
+// hnswMagic 序列化文件头，最后一字节为格式版本
+var hnswMagic = [4]byte{'H', 'N', 'S', 1}
+
+// Save 将图结构与向量以小端二进制写入 w，已移除的节点一并保存以保持图的连通性
+func (h *HNSW) Save(w io.Writer) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	header := []int32{int32(h.M), int32(h.EfConstruction), int32(h.EfSearch), int32(h.maxLevel), h.entry, int32(len(h.nodes))}
+	if err := binary.Write(bw, binary.LittleEndian, hnswMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	for _, n := range h.nodes {
+		var deleted uint8
+		if n.deleted {
+			deleted = 1
+		}
+		fields := []interface{}{n.id, deleted, int32(len(n.vec)), n.vec, int32(len(n.friends))}
+		for l := range n.friends {
+			fields = append(fields, int32(len(n.friends[l])), n.friends[l], n.dists[l])
+		}
+		for _, f := range fields {
+			if err := binary.Write(bw, binary.LittleEndian, f); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// Load 从 Save 写出的数据恢复索引，替换当前内容；数据损坏时返回错误且索引保持不变
+func (h *HNSW) Load(r io.Reader) error {
+	br := bufio.NewReader(r)
+	var magic [4]byte
+	if err := binary.Read(br, binary.LittleEndian, &magic); err != nil {
+		return fmt.Errorf("读取索引头失败: %w", err)
+	}
+	if magic != hnswMagic {
+		return fmt.Errorf("不支持的索引格式: %x", magic)
+	}
+	var header [6]int32
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("读取索引头失败: %w", err)
+	}
+	m, efc, efs, maxLevel, entry, count := header[0], header[1], header[2], header[3], header[4], header[5]
+	if m <= 0 || count < 0 || entry < -1 || entry >= count || (count > 0 && entry < 0) {
+		return fmt.Errorf("索引头损坏: %v", header)
+	}
+
+	nodes := make([]*hnswNode, count)
+	byID := make(map[int64]int32, count)
+	removed := 0
+	for i := range nodes {
+		n, err := readNode(br, count)
+		if err != nil {
+			return fmt.Errorf("读取第 %d 个节点失败: %w", i, err)
+		}
+		nodes[i] = n
+		if n.deleted {
+			removed++
+		} else {
+			byID[n.id] = int32(i)
+		}
+	}
+	if entry >= 0 && len(nodes[entry].friends) != int(maxLevel)+1 {
+		return fmt.Errorf("索引入口层数不一致: %d", maxLevel)
+	}
+	// 搜索时会在邻居的同一层继续前进，邻居必须存在该层
+	for i, n := range nodes {
+		for l, friends := range n.friends {
+			for _, f := range friends {
+				if len(nodes[f].friends) <= l {
+					return fmt.Errorf("节点 %d 第 %d 层的邻居 %d 不在该层", i, l, f)
+				}
+			}
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.M, h.EfConstruction, h.EfSearch = int(m), int(efc), int(efs)
+	h.nodes, h.byID, h.removed = nodes, byID, removed
+	h.entry, h.maxLevel = entry, int(maxLevel)
+	h.levelMult = 1 / math.Log(float64(max(m, 2)))
+	if h.rng == nil {
+		h.rng = rand.New(rand.NewSource(42))
+	}
+	return nil
+}
+
+// readNode 读取单个节点，邻居下标需落在 [0, count) 内
+func readNode(r io.Reader, count int32) (*hnswNode, error) {
+	var id int64
+	var deleted uint8
+	var dim, levels int32
+	if err := binary.Read(r, binary.LittleEndian, &id); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &deleted); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &dim); err != nil {
+		return nil, err
+	}
+	if dim < 0 {
+		return nil, fmt.Errorf("维度无效: %d", dim)
+	}
+	n := &hnswNode{id: id, deleted: deleted != 0, vec: make([]float32, dim)}
+	if err := binary.Read(r, binary.LittleEndian, n.vec); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &levels); err != nil {
+		return nil, err
+	}
+	if levels <= 0 {
+		return nil, fmt.Errorf("层数无效: %d", levels)
+	}
+	n.friends = make([][]int32, levels)
+	n.dists = make([][]float32, levels)
+	for l := range n.friends {
+		var size int32
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, err
+		}
+		if size < 0 || size > count {
+			return nil, fmt.Errorf("邻居数无效: %d", size)
+		}
+		n.friends[l] = make([]int32, size)
+		n.dists[l] = make([]float32, size)
+		if err := binary.Read(r, binary.LittleEndian, n.friends[l]); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.LittleEndian, n.dists[l]); err != nil {
+			return nil, err
+		}
+		for _, f := range n.friends[l] {
+			if f < 0 || f >= count {
+				return nil, fmt.Errorf("邻居下标越界: %d", f)
+			}
+		}
+	}
+	return n, nil
+}
+
+// visitedSet 以轮次标记访问过的节点，复用时只需递增轮次
+type visitedSet struct {
+	marks []uint32
+	epoch uint32
+}
+
+func (h *HNSW) acquireVisited() *visitedSet {
+	v, _ := h.visited.Get().(*visitedSet)
+	if v == nil {
+		v = &visitedSet{}
+	}
+	if len(v.marks) < len(h.nodes) {
+		v.marks = make([]uint32, len(h.nodes)*2)
+		v.epoch = 0
+	}
+	v.epoch++
+	if v.epoch == 0 {
+		clear(v.marks)
+		v.epoch = 1
+	}
+	return v
+}
+
+// visit 标记节点，首次访问时返回 true
+func (v *visitedSet) visit(idx int32) bool {
+	if v.marks[idx] == v.epoch {
+		return false
+	}
+	v.marks[idx] = v.epoch
+	return true
+}
+
+type candidate struct {
+	idx  int32
+	dist float32
+}
+
+// minHeap 按距离升序弹出，用作待扩展队列
+type minHeap []candidate
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// maxHeap 堆顶为当前结果中最远的候选
+type maxHeap []candidate
+
+func (h maxHeap) Len() int            { return len(h) }
+func (h maxHeap) Less(i, j int) bool  { return h[i].dist > h[j].dist }
+func (h maxHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
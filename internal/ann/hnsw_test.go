@@ -0,0 +1,310 @@
+package ann
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func randomVectors(n, dim int, seed int64) [][]float32 {
+	rng := rand.New(rand.NewSource(seed))
+	vecs := make([][]float32, n)
+	for i := range vecs {
+		vecs[i] = make([]float32, dim)
+		for j := range vecs[i] {
+			vecs[i][j] = rng.Float32()*2 - 1
+		}
+	}
+	return vecs
+}
+
+// bruteForce 精确的 top-k，作为召回率基准
+func bruteForce(vecs [][]float32, q []float32, k int) []int64 {
+	qn := normalize(q)
+	ids := make([]int64, len(vecs))
+	sims := make([]float32, len(vecs))
+	for i, v := range vecs {
+		ids[i] = int64(i)
+		sims[i] = dot(qn, normalize(v))
+	}
+	sort.Slice(ids, func(a, b int) bool { return sims[ids[a]] > sims[ids[b]] })
+	return ids[:k]
+}
+
+func TestHNSW_Recall(t *testing.T) {
+	const n, dim, k = 2000, 32, 10
+	vecs := randomVectors(n, dim, 1)
+
+	h := NewHNSW()
+	for i, v := range vecs {
+		h.Insert(int64(i), v)
+	}
+	if h.Len() != n {
+		t.Fatalf("Len() = %d, want %d", h.Len(), n)
+	}
+
+	queries := randomVectors(50, dim, 2)
+	hits := 0
+	for _, q := range queries {
+		exact := make(map[int64]bool)
+		for _, id := range bruteForce(vecs, q, k) {
+			exact[id] = true
+		}
+		ids, sims := h.Search(q, k, 0)
+		if len(ids) != k {
+			t.Fatalf("Search() returned %d results, want %d", len(ids), k)
+		}
+		for i := 1; i < len(sims); i++ {
+			if sims[i] > sims[i-1] {
+				t.Fatalf("Results not sorted by similarity: %v", sims)
+			}
+		}
+		for _, id := range ids {
+			if exact[id] {
+				hits++
+			}
+		}
+	}
+
+	recall := float64(hits) / float64(len(queries)*k)
+	if recall < 0.95 {
+		t.Errorf("recall@%d = %.3f, want >= 0.95", k, recall)
+	}
+}
+
+func TestHNSW_RemoveAndReplace(t *testing.T) {
+	h := NewHNSW()
+	h.Insert(1, []float32{1, 0})
+	h.Insert(2, []float32{0, 1})
+	h.Insert(3, []float32{0.9, 0.1})
+
+	ids, sims := h.Search([]float32{1, 0}, 1, 0)
+	if len(ids) != 1 || ids[0] != 1 || sims[0] < 0.999 {
+		t.Errorf("Expected exact match id=1, got %v %v", ids, sims)
+	}
+
+	h.Remove(1)
+	if ids, _ := h.Search([]float32{1, 0}, 3, 0); len(ids) != 2 || ids[0] != 3 {
+		t.Errorf("Expected removed id to be skipped, got %v", ids)
+	}
+
+	// 覆盖已有 id 的向量
+	h.Insert(2, []float32{1, 0})
+	if ids, _ := h.Search([]float32{1, 0}, 1, 0); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("Expected replaced vector for id=2, got %v", ids)
+	}
+	if h.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", h.Len())
+	}
+
+	if ids, _ := NewHNSW().Search([]float32{1, 0}, 5, 0); len(ids) != 0 {
+		t.Errorf("Expected no results from empty index, got %v", ids)
+	}
+}
+
+func TestHNSW_SaveLoad(t *testing.T) {
+	const n, dim, k = 500, 16, 10
+	vecs := randomVectors(n, dim, 3)
+	h := NewHNSW()
+	for i, v := range vecs {
+		h.Insert(int64(i), v)
+	}
+	h.Remove(7)
+	h.Insert(8, vecs[9])
+
+	var buf bytes.Buffer
+	if err := h.Save(&buf); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	data := buf.Bytes()
+
+	loaded := NewHNSW()
+	if err := loaded.Load(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Len() != h.Len() {
+		t.Fatalf("Len() after load = %d, want %d", loaded.Len(), h.Len())
+	}
+	for _, q := range randomVectors(20, dim, 4) {
+		wantIDs, wantSims := h.Search(q, k, 0)
+		gotIDs, gotSims := loaded.Search(q, k, 0)
+		if fmt.Sprint(gotIDs, gotSims) != fmt.Sprint(wantIDs, wantSims) {
+			t.Fatalf("Search() after load = %v %v, want %v %v", gotIDs, gotSims, wantIDs, wantSims)
+		}
+		for _, id := range gotIDs {
+			if id == 7 {
+				t.Fatal("Removed id returned after load")
+			}
+		}
+	}
+
+	// 加载后的索引可继续插入
+	loaded.Insert(1000, []float32{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	if ids, _ := loaded.Search([]float32{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 1, 0); len(ids) != 1 || ids[0] != 1000 {
+		t.Errorf("Expected inserted id 1000 after load, got %v", ids)
+	}
+
+	// 截断或损坏的数据返回错误，索引保持不变
+	for _, bad := range [][]byte{data[:len(data)/2], append([]byte("XXXX"), data[4:]...)} {
+		if err := loaded.Load(bytes.NewReader(bad)); err == nil {
+			t.Error("Expected error for corrupted data")
+		}
+	}
+	if loaded.Len() != h.Len()+1 {
+		t.Errorf("Len() after failed load = %d, want %d", loaded.Len(), h.Len()+1)
+	}
+}
+
+// TestHNSW_SubsetOfBruteForce 多组随机数据与 k 下，结果都落在精确 top-2k 内且 recall@k >= 0.9
+func TestHNSW_SubsetOfBruteForce(t *testing.T) {
+	for seed := int64(10); seed < 14; seed++ {
+		dim := 8 + int(seed%4)*24
+		vecs := randomVectors(1000, dim, seed)
+		h := NewHNSW()
+		for i, v := range vecs {
+			h.Insert(int64(i), v)
+		}
+
+		for _, k := range []int{1, 5, 10, 50} {
+			hits, total := 0, 0
+			for _, q := range randomVectors(20, dim, seed+100) {
+				exact := bruteForce(vecs, q, 2*k)
+				top2k := make(map[int64]bool, len(exact))
+				for _, id := range exact {
+					top2k[id] = true
+				}
+				ids, _ := h.Search(q, k, 0)
+				for _, id := range ids {
+					if !top2k[id] {
+						t.Fatalf("seed=%d dim=%d k=%d: id %d not in brute-force top-%d", seed, dim, k, id, 2*k)
+					}
+				}
+				hits += overlap(ids, exact[:k])
+				total += k
+			}
+			if recall := float64(hits) / float64(total); recall < 0.9 {
+				t.Errorf("seed=%d dim=%d k=%d: recall = %.3f, want >= 0.9", seed, dim, k, recall)
+			}
+		}
+	}
+}
+
+func overlap(got, want []int64) int {
+	set := make(map[int64]bool, len(want))
+	for _, id := range want {
+		set[id] = true
+	}
+	n := 0
+	for _, id := range got {
+		if set[id] {
+			n++
+		}
+	}
+	return n
+}
+
+// embeddingLikeVectors 由低维隐变量线性投影到 dim 维并加少量噪声，
+// 模拟真实 embedding 内在维度远低于向量维度的分布
+func embeddingLikeVectors(n, dim int, seed int64) [][]float32 {
+	const latent = 16
+	proj := randomVectors(latent, dim, 99)
+	rng := rand.New(rand.NewSource(seed))
+	vecs := make([][]float32, n)
+	z := make([]float32, latent)
+	for i := range vecs {
+		for j := range z {
+			z[j] = float32(rng.NormFloat64())
+		}
+		vecs[i] = make([]float32, dim)
+		for j, row := range proj {
+			for d, p := range row {
+				vecs[i][d] += z[j] * p
+			}
+		}
+		for d := range vecs[i] {
+			vecs[i][d] += float32(rng.NormFloat64()) * 0.05
+		}
+	}
+	return vecs
+}
+
+var benchIndexes sync.Map // n -> *benchFixture
+
+type benchFixture struct {
+	vecs  [][]float32
+	index *HNSW
+}
+
+func loadBenchFixture(n, dim int) *benchFixture {
+	if f, ok := benchIndexes.Load(n); ok {
+		return f.(*benchFixture)
+	}
+	f := &benchFixture{vecs: embeddingLikeVectors(n, dim, 5), index: NewHNSW()}
+	for i, v := range f.vecs {
+		f.index.Insert(int64(i), v)
+	}
+	benchIndexes.Store(n, f)
+	return f
+}
+
+// BenchmarkHNSW_VsBruteForce 对比 HNSW 与暴力扫描的单次查询耗时，要求提速 >10× 且 recall@10 >95%。
+// 构建 10 万条 2560 维向量的索引耗时较长，需显式运行：go test -bench HNSW -benchtime 100x ./internal/ann
+func BenchmarkHNSW_VsBruteForce(b *testing.B) {
+	const dim, k = 2560, 10
+	// 规模越大图越深，需要更大的候选集才能保持 recall
+	for _, tc := range []struct{ n, efSearch int }{{10_000, 24}, {100_000, 96}} {
+		n, efSearch := tc.n, tc.efSearch
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			f := loadBenchFixture(n, dim)
+			queries := embeddingLikeVectors(20, dim, 6)
+
+			// 预先单位化，暴力扫描只计时点积
+			normed := make([][]float32, len(f.vecs))
+			for i, v := range f.vecs {
+				normed[i] = normalize(v)
+			}
+			exact := make([][]int64, len(queries))
+			start := time.Now()
+			for i, q := range queries {
+				exact[i] = bruteForceNormalized(normed, q, k)
+			}
+			bruteNs := float64(time.Since(start).Nanoseconds()) / float64(len(queries))
+
+			hits := 0
+			for i, q := range queries {
+				ids, _ := f.index.Search(q, k, efSearch)
+				hits += overlap(ids, exact[i])
+			}
+			recall := float64(hits) / float64(len(queries)*k)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				f.index.Search(queries[i%len(queries)], k, efSearch)
+			}
+			b.StopTimer()
+
+			speedup := bruteNs / (float64(b.Elapsed().Nanoseconds()) / float64(b.N))
+			b.ReportMetric(recall, "recall@10")
+			b.ReportMetric(speedup, "speedup")
+			if recall <= 0.95 || speedup <= 10 {
+				b.Errorf("recall@10 = %.3f, speedup = %.1fx; want > 0.95 and > 10x", recall, speedup)
+			}
+		})
+	}
+}
+
+func bruteForceNormalized(normed [][]float32, q []float32, k int) []int64 {
+	qn := normalize(q)
+	ids := make([]int64, len(normed))
+	sims := make([]float32, len(normed))
+	for i, v := range normed {
+		ids[i] = int64(i)
+		sims[i] = dot(qn, v)
+	}
+	sort.Slice(ids, func(a, b int) bool { return sims[ids[a]] > sims[ids[b]] })
+	return ids[:k]
+}
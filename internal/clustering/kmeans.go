@@ -0,0 +1,169 @@
+package clustering
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+const (
+	// DefaultMaxIterations 迭代次数上限
+	DefaultMaxIterations = 100
+	// DefaultTolerance 质心最大位移不超过该值时视为收敛
+	DefaultTolerance = 1e-4
+)
+
+// Config k-means 参数，零值字段使用默认值
+type Config struct {
+	K             int
+	MaxIterations int
+	Tolerance     float64
+	Seed          int64 // 相同种子与输入得到相同的聚类结果
+}
+
+// Result 聚类结果，Assignments[i] 为第 i 个向量所属的簇
+type Result struct {
+	Assignments []int
+	Centroids   [][]float32
+	Iterations  int
+	Converged   bool
+}
+
+// KMeans 以 k-means++ 初始化质心后迭代聚类，距离为欧氏距离；
+// 余弦聚类时调用方应先将向量单位化
+func KMeans(vecs [][]float32, cfg Config) (*Result, error) {
+	if cfg.K <= 0 {
+		return nil, fmt.Errorf("聚类数 k 需大于 0: %d", cfg.K)
+	}
+	if cfg.K > len(vecs) {
+		return nil, fmt.Errorf("聚类数 k=%d 超过向量数 %d", cfg.K, len(vecs))
+	}
+	dim := len(vecs[0])
+	for i, v := range vecs {
+		if len(v) != dim || dim == 0 {
+			return nil, fmt.Errorf("第 %d 个向量维度为 %d，与首个向量的 %d 不一致", i, len(v), dim)
+		}
+	}
+	if cfg.MaxIterations <= 0 {
+		cfg.MaxIterations = DefaultMaxIterations
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = DefaultTolerance
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	centroids := initPlusPlus(vecs, cfg.K, rng)
+	res := &Result{Assignments: make([]int, len(vecs)), Centroids: centroids}
+
+	for res.Iterations < cfg.MaxIterations {
+		res.Iterations++
+		for i, v := range vecs {
+			res.Assignments[i], _ = nearest(v, centroids)
+		}
+
+		next := make([][]float64, cfg.K)
+		counts := make([]int, cfg.K)
+		for c := range next {
+			next[c] = make([]float64, dim)
+		}
+		for i, v := range vecs {
+			c := res.Assignments[i]
+			counts[c]++
+			for d, x := range v {
+				next[c][d] += float64(x)
+			}
+		}
+
+		shift := 0.0
+		for c := range centroids {
+			var moved []float32
+			if counts[c] == 0 {
+				// 空簇移到离当前质心最远的点，避免簇数减少
+				moved = append([]float32(nil), vecs[farthest(vecs, centroids)]...)
+			} else {
+				moved = make([]float32, dim)
+				for d := range moved {
+					moved[d] = float32(next[c][d] / float64(counts[c]))
+				}
+			}
+			shift = math.Max(shift, math.Sqrt(sqDist(centroids[c], moved)))
+			centroids[c] = moved
+		}
+		if shift <= cfg.Tolerance {
+			res.Converged = true
+			break
+		}
+	}
+
+	// 按最终质心重新分配，保证 Assignments 与 Centroids 一致
+	for i, v := range vecs {
+		res.Assignments[i], _ = nearest(v, centroids)
+	}
+	return res, nil
+}
+
+// initPlusPlus k-means++：首个质心随机选取，之后按到最近质心距离的平方加权抽样
+func initPlusPlus(vecs [][]float32, k int, rng *rand.Rand) [][]float32 {
+	centroids := make([][]float32, 0, k)
+	centroids = append(centroids, append([]float32(nil), vecs[rng.Intn(len(vecs))]...))
+
+	weights := make([]float64, len(vecs))
+	for len(centroids) < k {
+		total := 0.0
+		for i, v := range vecs {
+			_, d := nearest(v, centroids)
+			weights[i] = d
+			total += d
+		}
+		pick := 0
+		if total > 0 {
+			r := rng.Float64() * total
+			for i, w := range weights {
+				if w == 0 {
+					continue
+				}
+				// 浮点累计误差可能让 r 落在末尾之外，此时取最后一个候选
+				pick = i
+				if r < w {
+					break
+				}
+				r -= w
+			}
+		} else {
+			// 所有点都与已有质心重合
+			pick = rng.Intn(len(vecs))
+		}
+		centroids = append(centroids, append([]float32(nil), vecs[pick]...))
+	}
+	return centroids
+}
+
+// nearest 返回最近质心的下标及距离的平方
+func nearest(v []float32, centroids [][]float32) (int, float64) {
+	best, bestDist := 0, math.Inf(1)
+	for c, centroid := range centroids {
+		if d := sqDist(v, centroid); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best, bestDist
+}
+
+func farthest(vecs [][]float32, centroids [][]float32) int {
+	best, bestDist := 0, -1.0
+	for i, v := range vecs {
+		if _, d := nearest(v, centroids); d > bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func sqDist(a, b []float32) float64 {
+	var s float64
+	for i := range a {
+		d := float64(a[i]) - float64(b[i])
+		s += d * d
+	}
+	return s
+}
@@ -0,0 +1,87 @@
+package clustering
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// blobs 在给定中心附近各生成 n 个二维点
+func blobs(centers [][2]float32, n int, seed int64) ([][]float32, []int) {
+	rng := rand.New(rand.NewSource(seed))
+	var vecs [][]float32
+	var labels []int
+	for c, center := range centers {
+		for i := 0; i < n; i++ {
+			vecs = append(vecs, []float32{center[0] + float32(rng.NormFloat64()), center[1] + float32(rng.NormFloat64())})
+			labels = append(labels, c)
+		}
+	}
+	return vecs, labels
+}
+
+func TestKMeans_ConvergesOnBlobs(t *testing.T) {
+	vecs, labels := blobs([][2]float32{{0, 0}, {20, 0}, {0, 20}}, 50, 1)
+	res, err := KMeans(vecs, Config{K: 3, Seed: 7})
+	if err != nil {
+		t.Fatalf("KMeans() error: %v", err)
+	}
+	if !res.Converged {
+		t.Errorf("Expected convergence within %d iterations, got %d", DefaultMaxIterations, res.Iterations)
+	}
+
+	// 每个真实簇的点都被分到同一个簇，且三个簇互不相同
+	mapping := make(map[int]int)
+	used := make(map[int]bool)
+	for i, c := range res.Assignments {
+		want, ok := mapping[labels[i]]
+		if !ok {
+			if used[c] {
+				t.Fatalf("Blob %d shares cluster %d with another blob", labels[i], c)
+			}
+			mapping[labels[i]], used[c] = c, true
+			continue
+		}
+		if c != want {
+			t.Fatalf("Point %d of blob %d assigned to cluster %d, want %d", i, labels[i], c, want)
+		}
+	}
+}
+
+func TestKMeans_StableWithSameSeed(t *testing.T) {
+	vecs, _ := blobs([][2]float32{{0, 0}, {5, 5}, {10, 0}, {5, -5}}, 30, 2)
+	first, err := KMeans(vecs, Config{K: 4, Seed: 42})
+	if err != nil {
+		t.Fatalf("KMeans() error: %v", err)
+	}
+	second, err := KMeans(vecs, Config{K: 4, Seed: 42})
+	if err != nil {
+		t.Fatalf("KMeans() error: %v", err)
+	}
+	for i := range first.Assignments {
+		if first.Assignments[i] != second.Assignments[i] {
+			t.Fatalf("Assignment %d differs between runs: %d vs %d", i, first.Assignments[i], second.Assignments[i])
+		}
+	}
+}
+
+func TestKMeans_InvalidInput(t *testing.T) {
+	vecs, _ := blobs([][2]float32{{0, 0}}, 3, 3)
+	_, err := KMeans(vecs, Config{K: 4})
+	if err == nil || !strings.Contains(err.Error(), "k=4") {
+		t.Errorf("Expected descriptive error for k > len(vecs), got %v", err)
+	}
+	if _, err := KMeans(vecs, Config{K: 0}); err == nil {
+		t.Error("Expected error for k = 0")
+	}
+	if _, err := KMeans(append(vecs, []float32{1}), Config{K: 2}); err == nil {
+		t.Error("Expected error for mismatched dimensions")
+	}
+
+	// 重复点也能得到 k 个质心
+	same := [][]float32{{1, 1}, {1, 1}, {1, 1}}
+	res, err := KMeans(same, Config{K: 2, MaxIterations: 5})
+	if err != nil || len(res.Centroids) != 2 {
+		t.Errorf("KMeans() on duplicates = %v, %v; want 2 centroids", res, err)
+	}
+}
@@ -2,34 +2,95 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	storage "PaperHunter/db"
 	dbsqlite "PaperHunter/db/sqlite"
 
 	exporter "PaperHunter/internal/core/export"
 	csv "PaperHunter/internal/core/export/csv"
-	json "PaperHunter/internal/core/export/json"
+	markdown "PaperHunter/internal/core/export/markdown"
+	// 文件类导出器在 init() 中注册到 exporter，新增格式只需在此匿名导入
+	_ "PaperHunter/internal/core/export/bibtex"
+	_ "PaperHunter/internal/core/export/json"
+	_ "PaperHunter/internal/core/export/obsidian"
+	_ "PaperHunter/internal/core/export/ris"
+	_ "PaperHunter/internal/core/export/xlsx"
 	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/ir"
 	"PaperHunter/internal/models"
 	"PaperHunter/internal/platform"
+	"PaperHunter/internal/translate"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/metrics"
+	"PaperHunter/pkg/ratelimit"
 	feishu "PaperHunter/pkg/upload/feishu"
+	notion "PaperHunter/pkg/upload/notion"
 	zotero "PaperHunter/pkg/upload/zotero"
 )
 
 type ZoteroConfig struct {
 	UserID      string `mapstructure:"user_id" yaml:"user_id"`
 	APIKey      string `mapstructure:"api_key" yaml:"api_key"`
-	LibraryType string `mapstructure:"library_type" yaml:"library_type"`
+	LibraryType string `mapstructure:"library_type" yaml:"library_type"` // user 个人库 / group 群组库
+	GroupID     string `mapstructure:"group_id" yaml:"group_id"`         // 群组库 ID，可通过 ListZoteroGroups 查询
+	// InvalidCollection 指定的 collection 不存在时的处理方式: fail / warn / create
+	InvalidCollection string `mapstructure:"invalid_collection" yaml:"invalid_collection"`
+}
+
+// NewClient 按配置的库类型创建 Zotero 客户端
+func (c ZoteroConfig) NewClient() *zotero.Client {
+	return zotero.NewLibraryClient(c.UserID, c.APIKey, c.LibraryType, c.GroupID)
 }
 
 type FeiShuConfig struct {
 	AppID     string `mapstructure:"app_id" yaml:"app_id"`
 	AppSecret string `mapstructure:"app_secret" yaml:"app_secret"`
+	AppToken  string `mapstructure:"app_token" yaml:"app_token"` // 已有多维表格的 app_token，与 TableID 同时设置时导出追加到该表
+	TableID   string `mapstructure:"table_id" yaml:"table_id"`   // 已有数据表的 table_id
+	// DedupColumns 追加到已有数据表时用于判断重复的 CSV 列（如 URL 或 数据源+平台ID），为空时不去重
+	DedupColumns []string `mapstructure:"dedup_columns" yaml:"dedup_columns"`
+}
+
+// FeiShuTable 已有的多维表格数据表，为空时导出新建多维表格
+type FeiShuTable struct {
+	AppToken string `json:"appToken"`
+	TableID  string `json:"tableId"`
+}
+
+func (t FeiShuTable) IsSet() bool {
+	return t.AppToken != "" && t.TableID != ""
+}
+
+// FeiShuExportResult 飞书导出结果；新建多维表格时 Skipped 恒为 0
+type FeiShuExportResult struct {
+	URL      string `json:"url"`
+	Inserted int    `json:"inserted"`
+	Skipped  int    `json:"skipped"`
+}
+
+type NotionConfig struct {
+	IntegrationToken string `mapstructure:"integration_token" yaml:"integration_token"`
+	DatabaseID       string `mapstructure:"database_id" yaml:"database_id"`
+}
+
+// CrossRefConfig 通过 CrossRef 按标题补全 DOI、出版日期与会议/期刊，默认关闭
+type CrossRefConfig struct {
+	Enabled            bool    `mapstructure:"enabled" yaml:"enabled"`
+	Mailto             string  `mapstructure:"mailto" yaml:"mailto"`                               // 联系邮箱，填写后进入 CrossRef polite 池
+	RateLimitPerSecond float64 `mapstructure:"rate_limit_per_second" yaml:"rate_limit_per_second"` // 每秒请求数上限
+	MinSimilarity      float64 `mapstructure:"min_similarity" yaml:"min_similarity"`               // 标题相似度阈值 (0, 1]，低于该值不采用
+}
+
+// UnpaywallConfig 通过 Unpaywall 按 DOI 查询开放获取的 PDF 链接，Email 为空时不启用
+type UnpaywallConfig struct {
+	Email string `mapstructure:"email" yaml:"email"` // 联系邮箱，Unpaywall 要求每个请求带上
 }
 
 var GlobalApp *App
@@ -41,9 +102,23 @@ type App struct {
 	searcher    *Searcher
 	zoteroCfg   ZoteroConfig //上传这部分就不考虑单例模式了？ 不是配置必选项，要使用时再说
 	feishuCfg   FeiShuConfig
+	notionCfg   NotionConfig
+
+	// DedupMode 爬取结果入库前的去重方式: "" 不去重 / title / embedding
+	DedupMode string
+	// Translator 标题与摘要翻译服务，未配置时 TranslateAbstracts 返回错误
+	Translator translate.Service
+	// translated 本次运行中已翻译并保存的论文：paper ID -> 目标语言，避免重复调用 LLM
+	translated sync.Map
+	// enricher 元数据补全服务，EnableCrossRef 启用前为 nil
+	enricher MetadataLookup
+	// oaLookup 开放获取链接查询服务，EnableUnpaywall 启用前为 nil
+	oaLookup OpenAccessLookup
+	// statusDir 各平台最近一次爬取进度的保存目录，EnableLastCrawlTracking 启用前为空，不记录
+	statusDir string
 }
 
-func NewApp(databasePath string, embCfg emb.EmbedderConfig, pCfg map[string]platform.Config, zoteroCfg ZoteroConfig, feishuCfg FeiShuConfig) (*App, error) {
+func NewApp(databasePath string, embCfg emb.EmbedderConfig, pCfg map[string]platform.Config, zoteroCfg ZoteroConfig, feishuCfg FeiShuConfig, notionCfg NotionConfig) (*App, error) {
 	if databasePath == "" {
 		homeDir, _ := os.UserHomeDir()
 
@@ -53,9 +128,17 @@ func NewApp(databasePath string, embCfg emb.EmbedderConfig, pCfg map[string]plat
 	if err != nil {
 		return nil, err
 	}
+	if embCfg.UseVectorIndex {
+		sqliteDB.EnableVectorIndex()
+	}
+	sqliteDB.EnableEmbeddingCache(embCfg.CacheSize)
+	if embCfg.Quantize {
+		sqliteDB.EnableQuantization()
+	}
 
 	embedSvc, err := emb.New(embCfg)
 	if err != nil {
+		sqliteDB.Close()
 		return nil, err
 	}
 	if pCfg == nil {
@@ -71,8 +154,11 @@ func NewApp(databasePath string, embCfg emb.EmbedderConfig, pCfg map[string]plat
 		searcher:    searcher,
 		zoteroCfg:   zoteroCfg,
 		feishuCfg:   feishuCfg,
+		notionCfg:   notionCfg,
 	}
 
+	app.warnEmbeddingMismatch()
+
 	// 设置全局实例
 	GlobalApp = app
 
@@ -83,6 +169,9 @@ func (a *App) Close() error {
 	if a == nil || a.db == nil {
 		return nil
 	}
+	if a.searcher != nil {
+		a.searcher.SaveIRIndex()
+	}
 	return a.db.Close()
 }
 
@@ -94,41 +183,31 @@ func (a *App) Crawl(ctx context.Context, platformName string, q platform.Query)
 
 func (a *App) CrawlWithProgress(ctx context.Context, platformName string, q platform.Query, progress CrawlProgress) (int, error) {
 	logger.Info("开始爬取平台: %s", platformName)
-	prov, ok := Get(platformName)
-	if !ok {
-		return 0, fmt.Errorf("未知或未实现的平台: %s", platformName)
-	}
-
-	pcfg, ok := a.platformCfg[platformName]
-	if !ok {
-		logger.Debug("使用平台默认配置: %s", platformName)
-		pcfg = prov.DefaultConfig()
-	}
-
-	logger.Debug("创建平台实例: %s", platformName)
-	plat, err := prov.New(pcfg)
-	if err != nil {
-		logger.Error("创建平台实例失败: %v", err)
-		return 0, fmt.Errorf("创建平台实例失败: %w", err)
-	}
-
-	logger.Debug("执行搜索查询: keywords=%v, categories=%v, limit=%d", q.Keywords, q.Categories, q.Limit)
-	res, err := plat.Search(ctx, q)
+	started := time.Now()
+	res, err := a.searchPlatform(ctx, platformName, q)
 	if err != nil {
-		logger.Error("平台搜索失败: %v", err)
-		return 0, fmt.Errorf("爬取失败: %w", err)
+		return 0, err
 	}
-	logger.Info("搜索返回 %d 篇论文", len(res.Papers))
+	fetched := res.Papers
+	res.Papers = a.dedup(ctx, res.Papers)
 	count := 0
 	total := len(res.Papers)
+	// 已入库、等待批量生成向量的论文
+	var pending []*models.Paper
 	for i, p := range res.Papers {
 		if p == nil {
 			continue
 		}
+		if err := ctx.Err(); err != nil {
+			logger.Warn("爬取已取消，已保存 %d 篇论文", count)
+			a.embedAndCheckDuplicates(context.WithoutCancel(ctx), pending)
+			return count, err
+		}
 		logger.Debug("[%d/%d] 保存论文: %s", i+1, len(res.Papers), p.Title)
 		pid, err := a.db.Upsert(p)
 		if err != nil {
 			logger.Error("保存论文失败 [%s]: %v", p.URL, err)
+			a.embedAndCheckDuplicates(ctx, pending)
 			return count, fmt.Errorf("保存论文失败(%s): %w", p.URL, err)
 		}
 		// 更新 ID 并添加到 IR 索引
@@ -136,40 +215,156 @@ func (a *App) CrawlWithProgress(ctx context.Context, platformName string, q plat
 		if a.searcher != nil {
 			a.searcher.AddPaperToIR(p)
 		}
+		a.saveReferences(p)
+		a.saveVersions(p)
 
 		count++
+		metrics.PapersCrawled.WithLabelValues(platformName).Inc()
 
 		if progress != nil {
 			progress(i, total, p, pid)
 		}
 
 		if a.embedder != nil {
-			logger.Debug("生成向量: paper_id=%d, model=%s", pid, a.embedder.ModelName())
-			text := emb.BuildEmbeddingText(p)
-			vec, err := a.embedder.EmbedQuery(ctx, text)
-			if err != nil {
-				logger.Warn("向量生成失败 [paper_id=%d]: %v", pid, err)
-			} else if len(vec) > 0 {
-				if err := a.db.SaveEmbedding(pid, a.embedder.ModelName(), text, vec); err != nil {
-					logger.Warn("向量保存失败 [paper_id=%d]: %v", pid, err)
-				} else {
-					logger.Debug("向量保存成功: paper_id=%d, dim=%d", pid, len(vec))
-				}
+			pending = append(pending, p)
+			if len(pending) >= EmbedBatchSize {
+				a.embedAndCheckDuplicates(ctx, pending)
+				pending = nil
 			}
 		}
 	}
+	a.embedAndCheckDuplicates(ctx, pending)
+	// 只有增量爬取推进进度：其他关键词或日期范围的爬取不代表该平台已取全；
+	// 达到 Limit 的结果可能被截断，之后公布但未取到的论文不能被跳过
+	if q.SinceLast && (q.Limit <= 0 || len(fetched) < q.Limit) {
+		a.recordLastCrawl(platformName, fetched, started)
+	}
 	logger.Info("爬取完成，共保存 %d 篇论文", count)
 	return count, nil
 }
 
+// DryRunCrawl 执行与 Crawl 相同的平台检索，但不写入数据库、不生成向量，用于爬取前预览；
+// 向量去重需要调用 Embedding 接口，预览时跳过，其余去重模式照常生效
+func (a *App) DryRunCrawl(ctx context.Context, platformName string, q platform.Query) (platform.Result, error) {
+	logger.Info("预览爬取平台: %s", platformName)
+	res, err := a.searchPlatform(ctx, platformName, q)
+	if err != nil {
+		return platform.Result{}, err
+	}
+	papers := make([]*models.Paper, 0, len(res.Papers))
+	for _, p := range res.Papers {
+		if p != nil {
+			papers = append(papers, p)
+		}
+	}
+	if a.DedupMode != DedupEmbedding {
+		papers = a.dedup(ctx, papers)
+	}
+	// 去重后的数量才是实际会入库的数量
+	res.Papers, res.Total = papers, len(papers)
+	logger.Info("预览完成，将获取 %d 篇论文", res.Total)
+	return res, nil
+}
+
+// searchPlatform 按配置创建平台实例并执行检索，返回未去重的结果
+func (a *App) searchPlatform(ctx context.Context, platformName string, q platform.Query) (platform.Result, error) {
+	prov, ok := Get(platformName)
+	if !ok {
+		return platform.Result{}, fmt.Errorf("未知或未实现的平台: %s", platformName)
+	}
+
+	pcfg, ok := a.platformCfg[platformName]
+	if !ok {
+		logger.Debug("使用平台默认配置: %s", platformName)
+		pcfg = prov.DefaultConfig()
+	}
+
+	logger.Debug("创建平台实例: %s", platformName)
+	plat, err := prov.New(pcfg)
+	if err != nil {
+		logger.Error("创建平台实例失败: %v", err)
+		return platform.Result{}, fmt.Errorf("创建平台实例失败: %w", err)
+	}
+	if rl, ok := plat.(platform.RateLimited); ok {
+		logger.Debug("平台限速: %s, %.2f req/s", platformName, pcfg.RateLimit())
+		rl.SetLimiter(ratelimit.For(platformName, pcfg.RateLimit()))
+	}
+
+	logger.Debug("执行搜索查询: keywords=%v, categories=%v, limit=%d", q.Keywords, q.Categories, q.Limit)
+	res, err := plat.Search(ctx, q)
+	if err != nil {
+		logger.Error("平台搜索失败: %v", err)
+		return platform.Result{}, fmt.Errorf("爬取失败: %w", err)
+	}
+	logger.Info("搜索返回 %d 篇论文", len(res.Papers))
+	return res, nil
+}
+
+// embedAndCheckDuplicates 批量生成向量后检查其他平台是否已有疑似重复论文
+func (a *App) embedAndCheckDuplicates(ctx context.Context, papers []*models.Paper) {
+	a.embedPapers(ctx, papers)
+	a.warnNearDuplicates(papers)
+}
+
+// GetPaperCitations 返回论文引用的、已入库的同平台论文，按与原论文的相似度排序
+func (a *App) GetPaperCitations(ctx context.Context, source, sourceID string) ([]*models.SimilarPaper, error) {
+	opts := SearchOptions{}
+	if source != "" {
+		opts.Condition.Sources = []string{source}
+	}
+	return a.searcher.SearchByCitation(ctx, sourceID, opts)
+}
+
+// saveReferences 写入爬取时获得的引用关系，失败只记录警告
+func (a *App) saveReferences(p *models.Paper) {
+	if len(p.References) == 0 {
+		return
+	}
+	if err := a.db.SaveCitations(p.SourceID, p.References, p.Source); err != nil {
+		logger.Warn("保存引用关系失败 [%s]: %v", p.SourceID, err)
+	}
+}
+
 func (a *App) Search(ctx context.Context, opts SearchOptions) ([]*models.SimilarPaper, error) {
 	logger.Info("开始本地搜索")
 	return a.searcher.Search(ctx, opts)
 }
 
-func (a *App) ComputeMissingEmbeddings(ctx context.Context, batchSize int) (int, error) {
+// SearchByAuthor 按作者名子串检索论文，cond.Authors 中的作者与 authorQuery 之间为 OR，平台、日期等过滤同样生效
+func (a *App) SearchByAuthor(ctx context.Context, authorQuery string, cond models.SearchCondition) ([]*models.Paper, error) {
+	if strings.TrimSpace(authorQuery) == "" && len(cond.Authors) == 0 {
+		return nil, fmt.Errorf("作者不能为空")
+	}
+	logger.Info("按作者搜索: %s %v", authorQuery, cond.Authors)
+	papers, err := a.db.SearchByAuthor(strings.TrimSpace(authorQuery), cond)
+	if err != nil {
+		return nil, fmt.Errorf("按作者搜索失败: %w", err)
+	}
+	return papers, nil
+}
+
+// ConfigureTokenizer 设置 IR 检索的分词方式：停用词语言（en/zh）、自定义停用词、词干提取与最短词长
+func (a *App) ConfigureTokenizer(cfg ir.TokenizerConfig) error {
+	return a.searcher.SetTokenizerConfig(cfg)
+}
+
+// EnableIRIndexPersistence 将 IR 索引持久化到 path（如 ir.DefaultIndexPath()），重启后加载而不是从数据库重建；
+// 关闭应用时写入未保存的增量更新
+func (a *App) EnableIRIndexPersistence(path string) {
+	a.searcher.SetIRIndexPath(path)
+}
+
+func (a *App) ComputeMissingEmbeddings(ctx context.Context, batchSize int, concurrency int) (int, error) {
 	logger.Info("开始计算缺失的向量")
-	return a.searcher.ComputeMissingEmbeddings(ctx, batchSize)
+	return a.searcher.ComputeMissingEmbeddings(ctx, batchSize, concurrency)
+}
+
+// GetEmbeddings 读取论文在当前 embedding 模型下的向量，key 为论文 ID
+func (a *App) GetEmbeddings(ctx context.Context, paperIDs []int64) (map[int64][]float32, error) {
+	if a.embedder == nil {
+		return nil, fmt.Errorf("未配置 embedder")
+	}
+	return a.db.GetEmbeddings(paperIDs, a.embedder.ModelName())
 }
 
 func (a *App) CountPapers(ctx context.Context, conditions []string, params []interface{}) (int, error) {
@@ -177,9 +372,40 @@ func (a *App) CountPapers(ctx context.Context, conditions []string, params []int
 	return a.db.CountPapers(conditions, params)
 }
 
+// DeletePapers 软删除论文，可通过 UndeletePapers 恢复
 func (a *App) DeletePapers(ctx context.Context, conditions []string, params []interface{}) (int, error) {
 	logger.Info("删除论文")
-	return a.db.DeletePapers(conditions, params)
+	count, err := a.db.DeletePapers(conditions, params)
+	if err == nil && count > 0 && a.searcher != nil {
+		a.searcher.InvalidateIRIndex()
+	}
+	return count, err
+}
+
+// UndeletePapers 恢复已软删除的论文
+func (a *App) UndeletePapers(ctx context.Context, conditions []string, params []interface{}) (int, error) {
+	logger.Info("恢复已删除论文")
+	count, err := a.db.UndeletePapers(conditions, params)
+	if err == nil && count > 0 && a.searcher != nil {
+		a.searcher.InvalidateIRIndex()
+	}
+	return count, err
+}
+
+// PurgeDeleted 物理删除软删除超过 olderThan 的论文，不可恢复
+func (a *App) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int, error) {
+	logger.Info("清除 %v 前删除的论文", olderThan)
+	return a.db.PurgePapers(olderThan)
+}
+
+// GetPaperByID 按主键获取单篇论文，不存在时返回的错误可用 errors.Is(err, storage.ErrPaperNotFound) 判断
+func (a *App) GetPaperByID(ctx context.Context, id int64) (*models.Paper, error) {
+	return a.db.GetPaperByID(id)
+}
+
+// GetPaper 按 source + sourceID 获取单篇论文
+func (a *App) GetPaper(ctx context.Context, source, sourceID string) (*models.Paper, error) {
+	return a.lookupPaper(source, sourceID)
 }
 
 // GetPapersByPairs 按 source+id 组合批量查询论文（不分页，limit=0 表示全部）
@@ -223,10 +449,62 @@ func (a *App) GetPapers(ctx context.Context, page, pageSize int, conditions []st
 	return a.db.GetPapersList(pageSize, offset, conditions, params, orderBy)
 }
 
+// ExportPreview 导出预览：符合条件的论文数与前几篇样例，不执行导出
+type ExportPreview struct {
+	Total  int                 `json:"total"`
+	Sample []ExportPreviewItem `json:"sample"`
+}
+
+// ExportPreviewItem 预览样例中的单篇论文
+type ExportPreviewItem struct {
+	Title    string `json:"title"`
+	Source   string `json:"source"`
+	SourceID string `json:"source_id"`
+}
+
+// PreviewExport 按与导出相同的条件查询论文，返回数量与前 sampleSize 篇样例，不写文件也不推送到外部服务
+func (a *App) PreviewExport(ctx context.Context, conditions []string, params []interface{}, limit, sampleSize int) (*ExportPreview, error) {
+	papers, err := a.db.GetPapersByConditions(conditions, params, limit)
+	if err != nil {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+
+	preview := &ExportPreview{Total: len(papers), Sample: []ExportPreviewItem{}}
+	for _, p := range papers {
+		if len(preview.Sample) >= sampleSize {
+			break
+		}
+		preview.Sample = append(preview.Sample, ExportPreviewItem{Title: p.Title, Source: p.Source, SourceID: p.SourceID})
+	}
+	return preview, nil
+}
+
 // ExportPapers 导出论文到文件
 func (a *App) ExportPapers(ctx context.Context, format string, outputPath string, conditions []string, params []interface{}, limit int) error {
 	logger.Info("开始导出论文: 格式=%s, 输出=%s", format, outputPath)
 
+	// notion 不落地文件，outputPath 作为数据库 ID（为空时使用配置）
+	if format == "notion" {
+		return a.ExportToNotion(ctx, outputPath, conditions, params, limit)
+	}
+
+	// 文件类格式由导出子包注册构造函数；obsidian 的 outputPath 作为仓库目录，每篇论文一个笔记
+	f, ok := exporter.Lookup(format)
+	if !ok || f.New == nil {
+		return fmt.Errorf("不支持的导出格式: %s", format)
+	}
+
+	return a.exportPapers(f.Name, f.New(), outputPath, conditions, params, limit)
+}
+
+// ExportMarkdown 导出为 Markdown；splitFiles 为 true 时 outputPath 作为目录，每篇论文一个文件
+func (a *App) ExportMarkdown(ctx context.Context, outputPath string, conditions []string, params []interface{}, limit int, splitFiles bool) error {
+	logger.Info("开始导出论文: 格式=markdown, 输出=%s, 分文件=%v", outputPath, splitFiles)
+	return a.exportPapers("markdown", markdown.NewMarkdownExporter(splitFiles), outputPath, conditions, params, limit)
+}
+
+// exportPapers 查询符合条件的论文并交给 exp 写出
+func (a *App) exportPapers(format string, exp exporter.Exporter, outputPath string, conditions []string, params []interface{}, limit int) error {
 	// 规范化输出路径，支持相对路径与 ~，并确保父目录存在
 	normalizedPath, err := normalizeOutputPath(outputPath)
 	if err != nil {
@@ -241,24 +519,52 @@ func (a *App) ExportPapers(ctx context.Context, format string, outputPath string
 	if len(papers) == 0 {
 		return fmt.Errorf("没有找到符合条件的论文")
 	}
+	if err := a.db.LoadPaperStatus(papers); err != nil {
+		return fmt.Errorf("读取阅读状态失败: %w", err)
+	}
 
 	logger.Info("找到 %d 篇论文待导出", len(papers))
 
-	var exp exporter.Exporter
-	switch format {
-	case "csv":
-		exp = csv.NewCSVExporter()
-	case "json":
-		exp = json.NewJSONExporter()
-	default:
-		return fmt.Errorf("不支持的导出格式: %s", format)
-	}
-
 	if err := exp.Export(papers, normalizedPath); err != nil {
 		return fmt.Errorf("导出失败: %w", err)
 	}
 
 	logger.Info("导出成功: %d 篇论文 -> %s", len(papers), normalizedPath)
+	metrics.Exports.WithLabelValues(format).Inc()
+	return nil
+}
+
+// ExportPaperGroups 按推荐分组导出论文，保留分组顺序与相似度
+func (a *App) ExportPaperGroups(ctx context.Context, format string, outputPath string, groups []exporter.PaperGroup) error {
+	logger.Info("开始分组导出论文: 格式=%s, 输出=%s, 分组数=%d", format, outputPath, len(groups))
+
+	normalizedPath, err := normalizeOutputPath(outputPath)
+	if err != nil {
+		return fmt.Errorf("处理输出路径失败: %w", err)
+	}
+
+	total := 0
+	for _, g := range groups {
+		total += len(g.Papers)
+	}
+	if total == 0 {
+		return fmt.Errorf("没有找到符合条件的论文")
+	}
+
+	var exp exporter.GroupExporter
+	if f, ok := exporter.Lookup(format); ok && f.Grouped && f.New != nil {
+		exp, _ = f.New().(exporter.GroupExporter)
+	}
+	if exp == nil {
+		return fmt.Errorf("不支持的分组导出格式: %s", format)
+	}
+
+	if err := exp.ExportGroups(groups, normalizedPath); err != nil {
+		return fmt.Errorf("导出失败: %w", err)
+	}
+
+	logger.Info("分组导出成功: %d 组 %d 篇论文 -> %s", len(groups), total, normalizedPath)
+	metrics.Exports.WithLabelValues(format).Inc()
 	return nil
 }
 
@@ -308,16 +614,75 @@ func (a *App) ExportToZotero(ctx context.Context, collectionKey string, conditio
 
 	logger.Info("找到 %d 篇论文待导出", len(papers))
 
-	client := zotero.NewClient(a.zoteroCfg.UserID, a.zoteroCfg.APIKey)
+	client := a.zoteroCfg.NewClient()
+
+	collectionKey, err = client.ResolveCollection(collectionKey, a.zoteroCfg.InvalidCollection)
+	if err != nil {
+		return fmt.Errorf("解析 Zotero collection 失败: %w", err)
+	}
 
 	if err := client.AddPapers(papers, collectionKey); err != nil {
-		return fmt.Errorf("添加到 Zotero 失败: %w", err)
+		var skipped *zotero.SkippedError
+		if !errors.As(err, &skipped) {
+			return fmt.Errorf("添加到 Zotero 失败: %w", err)
+		}
+		exported := len(papers) - len(skipped.Papers)
+		logger.Warn("导出到 Zotero 完成: %d 篇论文，%d 篇过大被跳过", exported, len(skipped.Papers))
+		metrics.Exports.WithLabelValues("zotero").Inc()
+		return fmt.Errorf("已导出 %d 篇论文，%d 篇被跳过: %w", exported, len(skipped.Papers), err)
 	}
 
 	logger.Info("导出到 Zotero 成功: %d 篇论文", len(papers))
+	metrics.Exports.WithLabelValues("zotero").Inc()
 	return nil
 }
 
+// ListZoteroGroups 列出配置的 Zotero 用户所属的群组库，用于查找 zotero.group_id
+func (a *App) ListZoteroGroups(ctx context.Context) ([]zotero.Group, error) {
+	if a.zoteroCfg.UserID == "" || a.zoteroCfg.APIKey == "" {
+		return nil, fmt.Errorf("zotero 配置不完整，请在配置文件中设置 zotero.user_id 和 zotero.api_key")
+	}
+	groups, err := a.zoteroCfg.NewClient().ListGroups()
+	if err != nil {
+		return nil, fmt.Errorf("获取 Zotero 群组失败: %w", err)
+	}
+	return groups, nil
+}
+
+// SyncToZotero 增量同步论文到 Zotero，仅上传库中尚不存在的论文，返回上传与跳过的数量
+func (a *App) SyncToZotero(ctx context.Context, collectionKey string, conditions []string, params []interface{}, limit int) (int, int, error) {
+	logger.Info("开始增量同步到 Zotero")
+
+	if a.zoteroCfg.UserID == "" || a.zoteroCfg.APIKey == "" {
+		return 0, 0, fmt.Errorf("zotero 配置不完整，请在配置文件中设置 zotero.user_id 和 zotero.api_key")
+	}
+
+	papers, err := a.db.GetPapersByConditions(conditions, params, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("查询论文失败: %w", err)
+	}
+
+	if len(papers) == 0 {
+		return 0, 0, fmt.Errorf("没有找到符合条件的论文")
+	}
+
+	client := a.zoteroCfg.NewClient()
+
+	collectionKey, err = client.ResolveCollection(collectionKey, a.zoteroCfg.InvalidCollection)
+	if err != nil {
+		return 0, 0, fmt.Errorf("解析 Zotero collection 失败: %w", err)
+	}
+
+	added, skipped, err := client.SyncPapers(papers, collectionKey)
+	if err != nil {
+		return added, skipped, fmt.Errorf("同步到 Zotero 失败: %w", err)
+	}
+
+	logger.Info("同步到 Zotero 完成: 新增 %d 篇，跳过 %d 篇", added, skipped)
+	metrics.Exports.WithLabelValues("zotero").Inc()
+	return added, skipped, nil
+}
+
 func (a *App) ExportToFeiShuBitable(ctx context.Context, fileName, folderName string, conditions []string, params []interface{}, limit int) error {
 	logger.Info("开始导出到 FeiShu")
 
@@ -360,44 +725,93 @@ func (a *App) ExportToFeiShuBitable(ctx context.Context, fileName, folderName st
 	}
 
 	logger.Info("导出到飞书成功: %d 篇论文", len(papers))
+	metrics.Exports.WithLabelValues("feishu").Inc()
 	return nil
 }
 
-func (a *App) ExportToFeiShuBitableWithURL(ctx context.Context, fileName, folderName string, conditions []string, params []interface{}, limit int) (string, error) {
+// ExportToFeiShuBitableWithURL 导出到飞书多维表格，返回数据表链接与新增/跳过数；
+// target 未设置时使用配置中的 app_token/table_id，两者都为空则新建多维表格；
+// 追加到已有数据表时按 feishu.dedup_columns 跳过表中已有的论文
+func (a *App) ExportToFeiShuBitableWithURL(ctx context.Context, fileName, folderName string, target FeiShuTable, conditions []string, params []interface{}, limit int) (FeiShuExportResult, error) {
 	logger.Info("开始导出到 FeiShu (with URL)")
 
 	if a.feishuCfg.AppID == "" || a.feishuCfg.AppSecret == "" {
-		return "", fmt.Errorf("feishu 配置不完整，请在配置文件中设置 feishu.app_id 和 feishu.app_secret")
+		return FeiShuExportResult{}, fmt.Errorf("feishu 配置不完整，请在配置文件中设置 feishu.app_id 和 feishu.app_secret")
 	}
 
 	papers, err := a.db.GetPapersByConditions(conditions, params, limit)
 	if err != nil {
-		return "", fmt.Errorf("查询论文失败: %w", err)
+		return FeiShuExportResult{}, fmt.Errorf("查询论文失败: %w", err)
 	}
 	if len(papers) == 0 {
-		return "", fmt.Errorf("没有找到符合条件的论文")
+		return FeiShuExportResult{}, fmt.Errorf("没有找到符合条件的论文")
 	}
 
 	tmpFile, err := os.CreateTemp("", "quicksearch_*.csv")
 	if err != nil {
-		return "", fmt.Errorf("创建临时文件失败: %w", err)
+		return FeiShuExportResult{}, fmt.Errorf("创建临时文件失败: %w", err)
 	}
 	tmpPath := tmpFile.Name()
 	defer func() { tmpFile.Close(); os.Remove(tmpPath) }()
 
 	exp := csv.NewCSVExporter()
 	if err := exp.Export(papers, tmpPath); err != nil {
-		return "", fmt.Errorf("导出 CSV 失败: %w", err)
+		return FeiShuExportResult{}, fmt.Errorf("导出 CSV 失败: %w", err)
 	}
 
+	if !target.IsSet() {
+		target = FeiShuTable{AppToken: a.feishuCfg.AppToken, TableID: a.feishuCfg.TableID}
+	}
 	client := feishu.NewClient(a.feishuCfg.AppID, a.feishuCfg.AppSecret, fileName, folderName)
-	url, err := client.UploadCSVToBitable(tmpPath)
+	result := FeiShuExportResult{Inserted: len(papers)}
+	if target.IsSet() {
+		logger.Info("追加到已有多维表格: app_token=%s, table_id=%s", target.AppToken, target.TableID)
+		var res *feishu.AppendResult
+		res, err = client.AppendCSVToBitable(tmpPath, target.AppToken, target.TableID, a.feishuCfg.DedupColumns)
+		if err == nil {
+			result = FeiShuExportResult{URL: res.URL, Inserted: res.Inserted, Skipped: res.Skipped}
+		}
+	} else {
+		result.URL, err = client.UploadCSVToBitable(tmpPath)
+	}
 	if err != nil {
-		return "", fmt.Errorf("上传到飞书失败: %w", err)
+		return FeiShuExportResult{}, fmt.Errorf("上传到飞书失败: %w", err)
 	}
 
-	logger.Info("导出到飞书成功: %d 篇论文, url=%s", len(papers), url)
-	return url, nil
+	logger.Info("导出到飞书成功: 新增 %d 篇，跳过 %d 篇, url=%s", result.Inserted, result.Skipped, result.URL)
+	metrics.Exports.WithLabelValues("feishu").Inc()
+	return result, nil
+}
+
+func (a *App) ExportToNotion(ctx context.Context, databaseID string, conditions []string, params []interface{}, limit int) error {
+	logger.Info("开始导出到 Notion")
+
+	if databaseID == "" {
+		databaseID = a.notionCfg.DatabaseID
+	}
+	if a.notionCfg.IntegrationToken == "" || databaseID == "" {
+		return fmt.Errorf("notion 配置不完整，请在配置文件中设置 notion.integration_token 和 notion.database_id")
+	}
+
+	papers, err := a.db.GetPapersByConditions(conditions, params, limit)
+	if err != nil {
+		return fmt.Errorf("查询论文失败: %w", err)
+	}
+
+	if len(papers) == 0 {
+		return fmt.Errorf("没有找到符合条件的论文")
+	}
+
+	logger.Info("找到 %d 篇论文待导出", len(papers))
+
+	client := notion.NewClient(a.notionCfg.IntegrationToken, databaseID)
+	if err := client.AddPapers(papers); err != nil {
+		return fmt.Errorf("添加到 Notion 失败: %w", err)
+	}
+
+	logger.Info("导出到 Notion 成功: %d 篇论文", len(papers))
+	metrics.Exports.WithLabelValues("notion").Inc()
+	return nil
 }
 
 func (a *App) ZoteroCfg() ZoteroConfig {
@@ -415,11 +829,20 @@ func (a *App) GetPlatform(platformName string) (platform.Platform, error) {
 		pcfg = prov.DefaultConfig()
 	}
 
-	return prov.New(pcfg)
+	plat, err := prov.New(pcfg)
+	if err != nil {
+		return nil, err
+	}
+	if rl, ok := plat.(platform.RateLimited); ok {
+		rl.SetLimiter(ratelimit.For(platformName, pcfg.RateLimit()))
+	}
+	return plat, nil
 }
 
+// SavePapers 保存已获取的论文（如每日推荐的新论文），与 CrawlWithProgress 一样批量生成向量并检查跨平台疑似重复
 func (a *App) SavePapers(ctx context.Context, papers []*models.Paper) (int, error) {
 	count := 0
+	var pending []*models.Paper
 	for _, p := range papers {
 		if p == nil {
 			continue
@@ -434,24 +857,73 @@ func (a *App) SavePapers(ctx context.Context, papers []*models.Paper) (int, erro
 		if a.searcher != nil {
 			a.searcher.AddPaperToIR(p)
 		}
+		a.saveReferences(p)
+		a.saveVersions(p)
 
 		count++
 
 		if a.embedder != nil {
-			text := emb.BuildEmbeddingText(p)
-			vec, err := a.embedder.EmbedQuery(ctx, text)
-			if err != nil {
-				logger.Warn("向量生成失败 [paper_id=%d]: %v", pid, err)
-			} else if len(vec) > 0 {
-				if err := a.db.SaveEmbedding(pid, a.embedder.ModelName(), text, vec); err != nil {
-					logger.Warn("向量保存失败 [paper_id=%d]: %v", pid, err)
-				}
+			pending = append(pending, p)
+			if len(pending) >= EmbedBatchSize {
+				a.embedAndCheckDuplicates(ctx, pending)
+				pending = nil
 			}
 		}
 	}
+	a.embedAndCheckDuplicates(ctx, pending)
 	return count, nil
 }
 
+// EmbedBatchSize 入库时单次批量生成向量的论文数
+const EmbedBatchSize = 32
+
+// embedPapers 为已入库的论文批量生成并保存向量
+// 整批请求失败时退化为逐篇生成，单篇失败只记录警告，不影响其他论文
+func (a *App) embedPapers(ctx context.Context, papers []*models.Paper) {
+	if a.embedder == nil || len(papers) == 0 {
+		return
+	}
+
+	model := a.embedder.ModelName()
+	texts := make([]string, len(papers))
+	for i, p := range papers {
+		texts[i] = emb.BuildEmbeddingText(p)
+	}
+
+	logger.Debug("批量生成向量: %d 篇, model=%s", len(papers), model)
+	vecs, err := a.embedder.EmbedBatch(ctx, texts)
+	if err == nil && len(vecs) != len(papers) {
+		err = fmt.Errorf("返回 %d 个向量，期望 %d 个", len(vecs), len(papers))
+	}
+	if err != nil {
+		logger.Warn("批量生成向量失败，改为逐篇生成 (%d 篇): %v", len(papers), err)
+		vecs = make([][]float32, len(papers))
+		for i, p := range papers {
+			vec, err := a.embedder.EmbedQuery(ctx, texts[i])
+			if err != nil {
+				logger.Warn("向量生成失败 [paper_id=%d]: %v", p.ID, err)
+				continue
+			}
+			vecs[i] = vec
+		}
+	}
+
+	for i, p := range papers {
+		if len(vecs[i]) == 0 || a.searcher.checkEmbeddingDim(vecs[i]) != nil {
+			continue
+		}
+		if err := a.db.SaveEmbedding(p.ID, model, texts[i], vecs[i]); err != nil {
+			logger.Warn("向量保存失败 [paper_id=%d]: %v", p.ID, err)
+		} else {
+			logger.Debug("向量保存成功: paper_id=%d, dim=%d", p.ID, len(vecs[i]))
+		}
+	}
+}
+
 func (a *App) FeishuCfg() FeiShuConfig {
 	return a.feishuCfg
 }
+
+func (a *App) NotionCfg() NotionConfig {
+	return a.notionCfg
+}
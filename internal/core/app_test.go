@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+func newEmbeddingApp(t *testing.T, embedder *fakeEmbedder) *App {
+	t.Helper()
+	s := newEmbeddingSearcher(t, embedder, 0)
+	return &App{db: s.db, embedder: embedder, searcher: s}
+}
+
+func newPapers(n int) []*models.Paper {
+	papers := make([]*models.Paper, n)
+	for i := range papers {
+		papers[i] = &models.Paper{
+			Source:   "arxiv",
+			SourceID: fmt.Sprintf("2402.%05d", i),
+			URL:      fmt.Sprintf("https://arxiv.org/abs/2402.%05d", i),
+			Title:    fmt.Sprintf("Paper %d", i),
+		}
+	}
+	return papers
+}
+
+func pendingEmbeddings(t *testing.T, a *App) int {
+	t.Helper()
+	papers, err := a.db.GetPapersNeedingEmbedding("fake-model", 1000)
+	if err != nil {
+		t.Fatalf("GetPapersNeedingEmbedding() error: %v", err)
+	}
+	return len(papers)
+}
+
+func TestSavePapers_BatchesEmbeddings(t *testing.T) {
+	embedder := &fakeEmbedder{}
+	a := newEmbeddingApp(t, embedder)
+
+	count, err := a.SavePapers(context.Background(), newPapers(EmbedBatchSize+8))
+	if err != nil || count != EmbedBatchSize+8 {
+		t.Fatalf("SavePapers() = %d, %v", count, err)
+	}
+	if fmt.Sprint(embedder.batchSizes) != fmt.Sprint([]int{EmbedBatchSize, 8}) {
+		t.Errorf("Expected batches of %d and 8, got %v", EmbedBatchSize, embedder.batchSizes)
+	}
+	if n := pendingEmbeddings(t, a); n != 0 {
+		t.Errorf("Expected all embeddings saved, %d papers still pending", n)
+	}
+}
+
+func TestSavePapers_BatchFailureFallsBackToSingle(t *testing.T) {
+	// 批量接口失败后逐篇重试，只有单篇也失败的论文缺少向量
+	embedder := &fakeEmbedder{failBatch: true, failTitle: "Paper 3"}
+	a := newEmbeddingApp(t, embedder)
+
+	count, err := a.SavePapers(context.Background(), newPapers(5))
+	if err != nil || count != 5 {
+		t.Fatalf("SavePapers() = %d, %v", count, err)
+	}
+	if embedder.calls != 5 {
+		t.Errorf("Expected 5 per-paper retries, got %d", embedder.calls)
+	}
+	if n := pendingEmbeddings(t, a); n != 1 {
+		t.Errorf("Expected only the failing paper without embedding, got %d pending", n)
+	}
+}
+
+func init() {
+	MustRegister(Provider{
+		Name:          "stub-cancel",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return &stubPlatform{n: 5}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func TestCrawlWithProgress_StopsOnCancel(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	a.platformCfg = map[string]platform.Config{}
+
+	// 保存第 2 篇后取消，已保存的论文保留
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var saved []string
+	count, err := a.CrawlWithProgress(ctx, "stub-cancel", platform.Query{}, func(idx, total int, p *models.Paper, paperID int64) {
+		saved = append(saved, p.SourceID)
+		if len(saved) == 2 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if count != 2 || len(saved) != 2 {
+		t.Errorf("Expected 2 saved papers before cancel, got count=%d saved=%v", count, saved)
+	}
+	if n, _ := a.db.CountPapers([]string{"source = ?"}, []interface{}{"stub"}); n != 2 {
+		t.Errorf("Expected 2 papers in database, got %d", n)
+	}
+}
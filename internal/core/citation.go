@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// SearchByCitation 查找 paperID（论文的 SourceID）引用的、已入库的论文，并按与原论文的相似度排序
+// 相似度基于库中已保存的向量；未配置 embedding 服务或向量缺失时相似度为 0，按被引用次数排序
+// opts.Condition.Sources 可用于限定原论文所属平台，opts.TopK > 0 时截断结果
+func (s *Searcher) SearchByCitation(ctx context.Context, paperID string, opts SearchOptions) ([]*models.SimilarPaper, error) {
+	cited, err := s.db.GetCitations(paperID)
+	if err != nil {
+		return nil, fmt.Errorf("获取引用关系失败: %w", err)
+	}
+	if len(cited) == 0 {
+		return []*models.SimilarPaper{}, nil
+	}
+
+	conditions := []string{"source_id = ?"}
+	params := []interface{}{paperID}
+	if len(opts.Condition.Sources) > 0 {
+		conditions = append(conditions, "source IN ("+placeholders(len(opts.Condition.Sources))+")")
+		for _, src := range opts.Condition.Sources {
+			params = append(params, src)
+		}
+	}
+	origins, err := s.db.GetPapersByConditions(conditions, params, 1)
+	if err != nil {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+	if len(origins) == 0 {
+		return nil, fmt.Errorf("论文不存在: %s", paperID)
+	}
+	origin := origins[0]
+
+	params = []interface{}{origin.Source}
+	for _, id := range cited {
+		params = append(params, id)
+	}
+	papers, err := s.db.GetPapersByConditions(
+		[]string{"source = ?", "source_id IN (" + placeholders(len(cited)) + ")"}, params, 0)
+	if err != nil {
+		return nil, fmt.Errorf("查询被引用论文失败: %w", err)
+	}
+
+	scores := s.citationScores(ctx, origin, len(papers))
+	results := make([]*models.SimilarPaper, 0, len(papers))
+	for _, p := range papers {
+		results = append(results, &models.SimilarPaper{Paper: *p, Similarity: scores[p.ID]})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Similarity != results[j].Similarity {
+			return results[i].Similarity > results[j].Similarity
+		}
+		return results[i].Paper.Citations > results[j].Paper.Citations
+	})
+
+	if opts.TopK > 0 && len(results) > opts.TopK {
+		results = results[:opts.TopK]
+	}
+	logger.Info("论文 %s 引用 %d 篇，其中 %d 篇已入库", paperID, len(cited), len(papers))
+	return results, nil
+}
+
+// citationScores 计算同平台论文与原论文的向量相似度，返回 paper_id -> 相似度
+func (s *Searcher) citationScores(ctx context.Context, origin *models.Paper, n int) map[int64]float32 {
+	scores := make(map[int64]float32)
+	if s.embedder == nil || n == 0 {
+		return scores
+	}
+
+	queryVec, err := s.embedder.EmbedQuery(ctx, emb.BuildEmbeddingText(origin))
+	if err != nil {
+		logger.Warn("生成论文向量失败，按被引用次数排序: %v", err)
+		return scores
+	}
+
+	cond := models.SearchCondition{Sources: []string{origin.Source}}
+	similar, err := s.db.SearchByEmbedding(queryVec, s.embedder.ModelName(), cond, math.MaxInt32)
+	if err != nil {
+		logger.Warn("向量检索失败，按被引用次数排序: %v", err)
+		return scores
+	}
+	for _, sp := range similar {
+		scores[sp.Paper.ID] = sp.Similarity
+	}
+	return scores
+}
+
+// placeholders 生成 n 个以逗号分隔的 SQL 占位符
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSearchByCitation(t *testing.T) {
+	embedder := &fakeEmbedder{}
+	s := newEmbeddingSearcher(t, embedder, 4)
+
+	papers, err := s.db.GetPapersByConditions(nil, nil, 0)
+	if err != nil {
+		t.Fatalf("GetPapersByConditions() error: %v", err)
+	}
+	ids := make(map[string]int64)
+	for _, p := range papers {
+		ids[p.SourceID] = p.ID
+	}
+
+	// 原论文 00000 引用 00001、00002 以及一篇未入库的论文；fakeEmbedder 的查询向量为 [1,0,0]
+	if err := s.db.SaveCitations("2401.00000", []string{"2401.00001", "2401.00002", "9999.99999"}, "arxiv"); err != nil {
+		t.Fatalf("SaveCitations() error: %v", err)
+	}
+	if err := s.db.SaveEmbedding(ids["2401.00001"], "fake-model", "", []float32{0, 1, 0}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+	if err := s.db.SaveEmbedding(ids["2401.00002"], "fake-model", "", []float32{1, 0.1, 0}); err != nil {
+		t.Fatalf("SaveEmbedding() error: %v", err)
+	}
+
+	results, err := s.SearchByCitation(context.Background(), "2401.00000", SearchOptions{})
+	if err != nil {
+		t.Fatalf("SearchByCitation() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 cited papers in the database, got %d", len(results))
+	}
+	if results[0].Paper.SourceID != "2401.00002" || results[1].Paper.SourceID != "2401.00001" {
+		t.Errorf("Expected cited papers ranked by similarity, got %s, %s",
+			results[0].Paper.SourceID, results[1].Paper.SourceID)
+	}
+
+	results, err = s.SearchByCitation(context.Background(), "2401.00000", SearchOptions{TopK: 1})
+	if err != nil || len(results) != 1 {
+		t.Errorf("Expected TopK to limit results to 1, got %d (%v)", len(results), err)
+	}
+
+	// 没有引用关系的论文返回空结果
+	results, err = s.SearchByCitation(context.Background(), "2401.00003", SearchOptions{})
+	if err != nil || results == nil || len(results) != 0 {
+		t.Errorf("Expected empty results for paper without citations, got %v (%v)", results, err)
+	}
+}
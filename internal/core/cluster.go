@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"PaperHunter/internal/clustering"
+	"PaperHunter/internal/ir"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// clusterSeed 固定随机种子，同一批论文多次聚类结果一致
+const clusterSeed = 1
+
+// PaperCluster 一个主题簇，CentroidLabel 为簇内标题中出现最多的非停用词
+type PaperCluster struct {
+	ClusterID     int             `json:"clusterId"`
+	CentroidLabel string          `json:"centroidLabel"`
+	Papers        []*models.Paper `json:"papers"`
+}
+
+// ClusterPapers 对满足条件且已有向量的论文做 k-means 聚类，簇按论文数从多到少编号
+func (a *App) ClusterPapers(ctx context.Context, k int, conditions []string, params []interface{}) ([]PaperCluster, error) {
+	if a.embedder == nil {
+		return nil, fmt.Errorf("未配置 embedder")
+	}
+
+	papers, err := a.db.GetPapersByConditions(conditions, params, 0)
+	if err != nil {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+	ids := make([]int64, len(papers))
+	for i, p := range papers {
+		ids[i] = p.ID
+	}
+	embeddings, err := a.db.GetEmbeddings(ids, a.embedder.ModelName())
+	if err != nil {
+		return nil, fmt.Errorf("读取向量失败: %w", err)
+	}
+
+	var members []*models.Paper
+	var vecs [][]float32
+	for _, p := range papers {
+		if vec, ok := embeddings[p.ID]; ok {
+			members = append(members, p)
+			// 单位化后欧氏距离与余弦距离排序一致
+			vecs = append(vecs, unitVector(vec))
+		}
+	}
+	if k > len(members) {
+		return nil, fmt.Errorf("聚类数 k=%d 超过已有向量的论文数 %d，请减小 k 或先计算向量", k, len(members))
+	}
+
+	res, err := clustering.KMeans(vecs, clustering.Config{K: k, Seed: clusterSeed})
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("论文聚类完成: %d 篇, k=%d, 迭代 %d 次, 收敛=%v", len(members), k, res.Iterations, res.Converged)
+
+	clusters := make([]PaperCluster, k)
+	for i, c := range res.Assignments {
+		clusters[c].Papers = append(clusters[c].Papers, members[i])
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return len(clusters[i].Papers) > len(clusters[j].Papers) })
+
+	tokenizer, _ := ir.NewTokenizer()
+	for i := range clusters {
+		clusters[i].ClusterID = i
+		clusters[i].CentroidLabel = clusterLabel(tokenizer, clusters[i].Papers)
+	}
+	return clusters, nil
+}
+
+// clusterLabel 返回簇内标题中出现次数最多的非停用词，次数相同时取字典序较小者；
+// 每篇论文的同一个词只计一次
+func clusterLabel(tokenizer *ir.Tokenizer, papers []*models.Paper) string {
+	counts := make(map[string]int)
+	for _, p := range papers {
+		seen := make(map[string]bool)
+		for _, tok := range tokenizer.Tokenize(p.Title) {
+			if !seen[tok] {
+				seen[tok] = true
+				counts[tok]++
+			}
+		}
+	}
+	best := ""
+	for tok, n := range counts {
+		if n > counts[best] || (n == counts[best] && tok < best) {
+			best = tok
+		}
+	}
+	return best
+}
+
+// unitVector 返回单位化后的向量副本，零向量返回全零
+func unitVector(vec []float32) []float32 {
+	var norm float32
+	for _, v := range vec {
+		norm += v * v
+	}
+	out := make([]float32, len(vec))
+	if norm == 0 {
+		return out
+	}
+	inv := 1 / float32(math.Sqrt(float64(norm)))
+	for i, v := range vec {
+		out[i] = v * inv
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestClusterPapers(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	titles := []string{
+		"Graph neural networks for molecules", "Scalable graph transformers", "Graph contrastive learning",
+		"Diffusion models for images", "Latent diffusion at scale",
+	}
+	vecs := [][]float32{{1, 0.1, 0}, {1, 0, 0.1}, {0.9, 0.1, 0.1}, {0, 1, 0}, {0.1, 1, 0}}
+	papers := newPapers(len(titles))
+	for i, p := range papers {
+		p.Title = titles[i]
+		id, err := a.db.Upsert(p)
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		if err := a.db.SaveEmbedding(id, "fake-model", p.Title, vecs[i]); err != nil {
+			t.Fatalf("SaveEmbedding() error: %v", err)
+		}
+	}
+	ctx := context.Background()
+
+	clusters, err := a.ClusterPapers(ctx, 2, nil, nil)
+	if err != nil {
+		t.Fatalf("ClusterPapers() error: %v", err)
+	}
+	if len(clusters) != 2 || len(clusters[0].Papers) != 3 || len(clusters[1].Papers) != 2 {
+		t.Fatalf("Expected clusters of 3 and 2 papers, got %+v", clusters)
+	}
+	if clusters[0].ClusterID != 0 || clusters[0].CentroidLabel != "graph" {
+		t.Errorf("Cluster 0 = %d/%q, want 0/\"graph\"", clusters[0].ClusterID, clusters[0].CentroidLabel)
+	}
+	if clusters[1].CentroidLabel != "diffusion" {
+		t.Errorf("Cluster 1 label = %q, want \"diffusion\"", clusters[1].CentroidLabel)
+	}
+
+	_, err = a.ClusterPapers(ctx, 6, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "k=6") {
+		t.Errorf("Expected descriptive error for k > total papers, got %v", err)
+	}
+
+	// 条件过滤后论文数不足
+	if _, err := a.ClusterPapers(ctx, 2, []string{"source = ?"}, []interface{}{"openreview"}); err == nil {
+		t.Error("Expected error when no papers match the conditions")
+	}
+}
@@ -0,0 +1,22 @@
+package core
+
+import (
+	"context"
+
+	"PaperHunter/internal/models"
+)
+
+// RecordCrawlStats 持久化一次爬取任务的统计
+func (a *App) RecordCrawlStats(ctx context.Context, h models.CrawlHistory) error {
+	return a.db.SaveCrawlStats(h)
+}
+
+// GetCrawlStats 按开始时间从新到旧返回爬取统计，limit <= 0 表示全部
+func (a *App) GetCrawlStats(ctx context.Context, limit int) ([]models.CrawlHistory, error) {
+	return a.db.GetCrawlStats(limit)
+}
+
+// PurgeCrawlStats 删除开始时间早于 olderThanDays 天前的爬取统计，0 表示全部删除
+func (a *App) PurgeCrawlStats(ctx context.Context, olderThanDays int) error {
+	return a.db.DeleteCrawlStats(olderThanDays)
+}
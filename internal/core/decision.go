@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"PaperHunter/pkg/logger"
+)
+
+// DecisionFetcher 支持查询录用决定的平台（如 OpenReview）
+type DecisionFetcher interface {
+	FetchDecision(ctx context.Context, venue, paperID string) (string, error)
+}
+
+// FetchDecision 从平台查询论文的录用决定，论文已入库时一并保存；尚未公布决定时返回空字符串
+func (a *App) FetchDecision(ctx context.Context, platformName, venue, paperID string) (string, error) {
+	plat, err := a.GetPlatform(platformName)
+	if err != nil {
+		return "", err
+	}
+	fetcher, ok := plat.(DecisionFetcher)
+	if !ok {
+		return "", fmt.Errorf("平台 %s 不支持查询录用决定", platformName)
+	}
+
+	decision, err := fetcher.FetchDecision(ctx, venue, paperID)
+	if err != nil {
+		return "", fmt.Errorf("查询录用决定失败: %w", err)
+	}
+	if decision == "" {
+		return "", nil
+	}
+
+	if paper, err := a.lookupPaper(platformName, paperID); err == nil {
+		if err := a.db.UpdateDecision(paper.ID, decision); err != nil {
+			return "", fmt.Errorf("保存录用决定失败: %w", err)
+		}
+		logger.Debug("保存录用决定: %s/%s -> %s", platformName, paperID, decision)
+	}
+	return decision, nil
+}
@@ -0,0 +1,433 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"PaperHunter/internal/ann"
+	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/similarity"
+)
+
+// 去重模式，用于 App.DedupMode 和 DeduplicateDatabase
+const (
+	DedupNone      = ""
+	DedupTitle     = "title"
+	DedupEmbedding = "embedding"
+)
+
+const (
+	// DefaultTitleDedupThreshold 标题归一化编辑距离低于该值视为重复
+	DefaultTitleDedupThreshold = 0.15
+	// DefaultEmbeddingDedupThreshold 标题+摘要向量余弦相似度高于该值视为重复
+	DefaultEmbeddingDedupThreshold = 0.95
+)
+
+// DedupReport 数据库去重的结果
+type DedupReport struct {
+	Mode      string  `json:"mode"`
+	Threshold float64 `json:"threshold"`
+	Scanned   int     `json:"scanned"`
+	Removed   int     `json:"removed"`
+	Failed    int     `json:"failed"`
+}
+
+// DeduplicateByTitle 按小写标题的归一化编辑距离聚类，距离低于 threshold 的论文视为同一篇，
+// 每组保留元数据最完整的一篇，结果保持输入顺序
+func DeduplicateByTitle(papers []*models.Paper, threshold float64) []*models.Paper {
+	titles := make([][]rune, len(papers))
+	for i, p := range papers {
+		titles[i] = []rune(dedupTitle(p.Title))
+	}
+
+	// 按长度排序后只比较长度相近的标题：长度差本身就是编辑距离的下界
+	order := make([]int, len(papers))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return len(titles[order[a]]) < len(titles[order[b]]) })
+
+	uf := newUnionFind(len(papers))
+	for a, i := range order {
+		if len(titles[i]) == 0 {
+			continue
+		}
+		for _, j := range order[a+1:] {
+			longer := len(titles[j])
+			if float64(longer-len(titles[i])) >= threshold*float64(longer) {
+				break
+			}
+			if uf.find(i) == uf.find(j) {
+				continue
+			}
+			if titleDistance(titles[i], titles[j]) < threshold {
+				uf.union(i, j)
+			}
+		}
+	}
+	return keepMostComplete(papers, uf)
+}
+
+// dedupBruteForceMax 不超过该数量时两两比较向量，更多时借助 HNSW 索引只比较近邻候选
+const dedupBruteForceMax = 512
+
+// dedupNeighbors 使用索引时每篇论文检查的近邻数，近重复的论文应位于彼此的最近邻中
+const dedupNeighbors = 16
+
+// indexCtxCheck 构建和查询索引时每处理多少个向量检查一次 ctx
+const indexCtxCheck = 256
+
+// dedupIDChunk 按 ID 批量读取已存向量时每次查询的 ID 数，避免超出 SQLite 参数上限
+const dedupIDChunk = 500
+
+// DeduplicateByEmbedding 为每篇论文的标题+摘要生成向量，余弦相似度高于 threshold 的论文视为同一篇，
+// 每组保留元数据最完整的一篇，结果保持输入顺序
+func DeduplicateByEmbedding(ctx context.Context, papers []*models.Paper, embedder emb.Service, threshold float32) ([]*models.Paper, error) {
+	if len(papers) < 2 {
+		return papers, nil
+	}
+	if embedder == nil {
+		return nil, fmt.Errorf("未配置 embedder")
+	}
+
+	vecs, err := embedPapers(ctx, embedder, papers)
+	if err != nil {
+		return nil, err
+	}
+	uf, err := clusterByEmbedding(ctx, vecs, threshold)
+	if err != nil {
+		return nil, err
+	}
+	return keepMostComplete(papers, uf), nil
+}
+
+// embedPapers 按 EmbedBatchSize 分批为论文的标题+摘要生成向量
+func embedPapers(ctx context.Context, embedder emb.Service, papers []*models.Paper) ([][]float32, error) {
+	texts := make([]string, len(papers))
+	for i, p := range papers {
+		texts[i] = emb.BuildEmbeddingText(p)
+	}
+	vecs := make([][]float32, 0, len(papers))
+	for start := 0; start < len(texts); start += EmbedBatchSize {
+		end := min(start+EmbedBatchSize, len(texts))
+		batch, err := embedder.EmbedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("生成向量失败: %w", err)
+		}
+		if len(batch) != end-start {
+			return nil, fmt.Errorf("返回 %d 个向量，期望 %d 个", len(batch), end-start)
+		}
+		vecs = append(vecs, batch...)
+	}
+	return vecs, nil
+}
+
+// clusterByEmbedding 将余弦相似度高于 threshold 的向量合并为一组；数量较多时用 HNSW 索引找近邻候选，
+// 避免 O(n²) 比较
+func clusterByEmbedding(ctx context.Context, vecs [][]float32, threshold float32) (*unionFind, error) {
+	uf := newUnionFind(len(vecs))
+	if len(vecs) <= dedupBruteForceMax {
+		for i := range vecs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			for j := i + 1; j < len(vecs); j++ {
+				if uf.find(i) == uf.find(j) {
+					continue
+				}
+				if similarity.CosineSimilarity(vecs[i], vecs[j]) > threshold {
+					uf.union(i, j)
+				}
+			}
+		}
+		return uf, nil
+	}
+
+	idx := ann.NewHNSW()
+	for i, vec := range vecs {
+		if i%indexCtxCheck == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		idx.Insert(int64(i), vec)
+	}
+	for i, vec := range vecs {
+		if i%indexCtxCheck == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		ids, sims := idx.Search(vec, dedupNeighbors+1, 0)
+		for k, id := range ids {
+			// 结果按相似度降序，低于阈值后的候选都不是重复
+			if sims[k] <= threshold {
+				break
+			}
+			if j := int(id); j != i {
+				uf.union(i, j)
+			}
+		}
+	}
+	return uf, nil
+}
+
+// storedEmbeddings 读取论文在当前模型下已存的向量，缺失的才调用 embedder 生成并写回数据库
+func (a *App) storedEmbeddings(ctx context.Context, papers []*models.Paper) ([][]float32, error) {
+	model := a.embedder.ModelName()
+	stored := make(map[int64][]float32, len(papers))
+	for start := 0; start < len(papers); start += dedupIDChunk {
+		end := min(start+dedupIDChunk, len(papers))
+		ids := make([]int64, 0, end-start)
+		for _, p := range papers[start:end] {
+			ids = append(ids, p.ID)
+		}
+		chunk, err := a.db.GetEmbeddings(ids, model)
+		if err != nil {
+			return nil, fmt.Errorf("读取向量失败: %w", err)
+		}
+		for id, vec := range chunk {
+			stored[id] = vec
+		}
+	}
+
+	var missing []*models.Paper
+	var missingAt []int
+	vecs := make([][]float32, len(papers))
+	for i, p := range papers {
+		if vec, ok := stored[p.ID]; ok {
+			vecs[i] = vec
+			continue
+		}
+		missing = append(missing, p)
+		missingAt = append(missingAt, i)
+	}
+	if len(missing) == 0 {
+		return vecs, nil
+	}
+
+	logger.Info("去重: %d 篇论文没有已存向量，开始生成", len(missing))
+	generated, err := embedPapers(ctx, a.embedder, missing)
+	if err != nil {
+		return nil, err
+	}
+	for k, p := range missing {
+		vecs[missingAt[k]] = generated[k]
+		if err := a.db.SaveEmbedding(p.ID, model, emb.BuildEmbeddingText(p), generated[k]); err != nil {
+			logger.Warn("保存向量失败 [paper_id=%d]: %v", p.ID, err)
+		}
+	}
+	return vecs, nil
+}
+
+// dedup 按 DedupMode 对爬取结果去重，向量去重失败时保留原结果
+func (a *App) dedup(ctx context.Context, papers []*models.Paper) []*models.Paper {
+	if a.DedupMode == DedupNone {
+		return papers
+	}
+	valid := make([]*models.Paper, 0, len(papers))
+	for _, p := range papers {
+		if p != nil {
+			valid = append(valid, p)
+		}
+	}
+	papers = valid
+
+	var out []*models.Paper
+	switch a.DedupMode {
+	case DedupTitle:
+		out = DeduplicateByTitle(papers, DefaultTitleDedupThreshold)
+	case DedupEmbedding:
+		var err error
+		out, err = DeduplicateByEmbedding(ctx, papers, a.embedder, DefaultEmbeddingDedupThreshold)
+		if err != nil {
+			logger.Warn("向量去重失败，跳过去重: %v", err)
+			return papers
+		}
+	default:
+		logger.Warn("未知的去重模式: %s，跳过去重", a.DedupMode)
+		return papers
+	}
+	if removed := len(papers) - len(out); removed > 0 {
+		logger.Info("去重(%s): %d 篇中合并 %d 篇重复论文", a.DedupMode, len(papers), removed)
+	}
+	return out
+}
+
+// DeduplicateDatabase 对库中所有未删除的论文去重，重复的论文软删除，可通过 UndeletePapers 恢复；
+// threshold <= 0 时使用模式的默认阈值
+func (a *App) DeduplicateDatabase(ctx context.Context, mode string, threshold float64) (*DedupReport, error) {
+	papers, err := a.db.GetPapersByConditions(nil, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+	sort.Slice(papers, func(i, j int) bool { return papers[i].ID < papers[j].ID })
+
+	var kept []*models.Paper
+	switch mode {
+	case DedupTitle:
+		if threshold <= 0 {
+			threshold = DefaultTitleDedupThreshold
+		}
+		kept = DeduplicateByTitle(papers, threshold)
+	case DedupEmbedding:
+		if threshold <= 0 {
+			threshold = DefaultEmbeddingDedupThreshold
+		}
+		if a.embedder == nil {
+			return nil, fmt.Errorf("未配置 embedder")
+		}
+		// 优先使用库中已存的向量，只为缺失的论文生成
+		vecs, err := a.storedEmbeddings(ctx, papers)
+		if err != nil {
+			return nil, err
+		}
+		uf, err := clusterByEmbedding(ctx, vecs, float32(threshold))
+		if err != nil {
+			return nil, err
+		}
+		kept = keepMostComplete(papers, uf)
+	default:
+		return nil, fmt.Errorf("未知的去重模式: %s", mode)
+	}
+
+	report := &DedupReport{Mode: mode, Threshold: threshold, Scanned: len(papers)}
+	keep := make(map[int64]bool, len(kept))
+	for _, p := range kept {
+		keep[p.ID] = true
+	}
+	for _, p := range papers {
+		if keep[p.ID] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if _, err := a.db.DeletePapers([]string{"id = ?"}, []interface{}{p.ID}); err != nil {
+			logger.Warn("删除重复论文失败 [id=%d]: %v", p.ID, err)
+			report.Failed++
+			continue
+		}
+		report.Removed++
+	}
+
+	if report.Removed > 0 && a.searcher != nil {
+		a.searcher.InvalidateIRIndex()
+	}
+	logger.Info("数据库去重(%s)完成: 扫描 %d 篇，删除重复 %d 篇，失败 %d 篇", mode, report.Scanned, report.Removed, report.Failed)
+	return report, nil
+}
+
+// dedupTitle 小写并合并空白，用于标题比较
+func dedupTitle(title string) string {
+	return strings.Join(strings.Fields(strings.ToLower(title)), " ")
+}
+
+// titleDistance 编辑距离除以较长标题的长度，范围 [0, 1]
+func titleDistance(a, b []rune) float64 {
+	longer := max(len(a), len(b))
+	if longer == 0 {
+		return 0
+	}
+	return float64(levenshtein(a, b)) / float64(longer)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// completeness 元数据完整度，去重时保留得分最高的论文
+func completeness(p *models.Paper) int {
+	score := 0
+	for _, s := range []string{p.URL, p.Abstract, p.Comments, p.TitleTranslated, p.AbstractTranslated} {
+		if strings.TrimSpace(s) != "" {
+			score++
+		}
+	}
+	if len(p.Authors) > 0 {
+		score++
+	}
+	if len(p.Categories) > 0 {
+		score++
+	}
+	if p.Citations > 0 {
+		score++
+	}
+	if !p.FirstSubmittedAt.IsZero() {
+		score++
+	}
+	if !p.FirstAnnouncedAt.IsZero() {
+		score++
+	}
+	return score
+}
+
+// keepMostComplete 每组保留完整度最高的论文（相同时保留靠前的），按组内首篇的位置输出
+func keepMostComplete(papers []*models.Paper, uf *unionFind) []*models.Paper {
+	best := make(map[int]int)
+	var roots []int
+	for i, p := range papers {
+		root := uf.find(i)
+		cur, ok := best[root]
+		if !ok {
+			roots = append(roots, root)
+			best[root] = i
+			continue
+		}
+		if completeness(p) > completeness(papers[cur]) {
+			best[root] = i
+		}
+	}
+
+	out := make([]*models.Paper, 0, len(roots))
+	for _, root := range roots {
+		out = append(out, papers[best[root]])
+	}
+	return out
+}
+
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(x int) int {
+	for u.parent[x] != x {
+		u.parent[x] = u.parent[u.parent[x]]
+		x = u.parent[x]
+	}
+	return x
+}
+
+func (u *unionFind) union(a, b int) {
+	if ra, rb := u.find(a), u.find(b); ra != rb {
+		u.parent[rb] = ra
+	}
+}
@@ -0,0 +1,257 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+// topicEmbedder 按文本中的关键词返回固定向量，未命中时返回 {0, 0, 1}
+type topicEmbedder struct {
+	topics map[string][]float32
+	texts  int // EmbedBatch 收到的文本总数
+}
+
+func (e *topicEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	for kw, vec := range e.topics {
+		if strings.Contains(text, kw) {
+			return vec, nil
+		}
+	}
+	return []float32{0, 0, 1}, nil
+}
+
+func (e *topicEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	e.texts += len(texts)
+	vecs := make([][]float32, len(texts))
+	for i, text := range texts {
+		vecs[i], _ = e.EmbedQuery(ctx, text)
+	}
+	return vecs, nil
+}
+
+func (e *topicEmbedder) ModelName() string { return "topic-model" }
+func (e *topicEmbedder) Dim() int          { return 3 }
+
+func (e *topicEmbedder) ValidateModel(ctx context.Context) error { return nil }
+
+func titles(papers []*models.Paper) []string {
+	out := make([]string, len(papers))
+	for i, p := range papers {
+		out[i] = p.Source + ":" + p.Title
+	}
+	return out
+}
+
+func TestDeduplicateByTitle(t *testing.T) {
+	papers := []*models.Paper{
+		{Source: "arxiv", Title: "Attention Is All You Need"},
+		{Source: "acl", Title: "Attention is all you need.", Abstract: "Transformers.", Authors: []string{"Vaswani"}},
+		{Source: "openreview", Title: "BERT: Pre-training of Deep Bidirectional Transformers"},
+		{Source: "arxiv", Title: "BERT: Pre-training of Deep  Bidirectional Transformer"},
+		{Source: "arxiv", Title: "Attention Is All You Need In Speech Separation"},
+		{Source: "acl", Title: ""},
+		{Source: "arxiv", Title: ""},
+	}
+
+	got := titles(DeduplicateByTitle(papers, DefaultTitleDedupThreshold))
+	want := []string{
+		"acl:Attention is all you need.",
+		"openreview:BERT: Pre-training of Deep Bidirectional Transformers",
+		"arxiv:Attention Is All You Need In Speech Separation",
+		"acl:",
+		"arxiv:",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("DeduplicateByTitle() =\n%q\nwant\n%q", got, want)
+	}
+
+	if got := DeduplicateByTitle(papers, 0); len(got) != len(papers) {
+		t.Errorf("Expected threshold 0 to keep all papers, got %d", len(got))
+	}
+}
+
+func TestDeduplicateByTitle_Transitive(t *testing.T) {
+	// a~b、b~c 时三篇归为一组，即使 a 与 c 的距离超过阈值
+	papers := []*models.Paper{
+		{Title: "graph neural networks for molecules"},
+		{Title: "graph neural network for molecule"},
+		{Title: "graph neural network for a molecule!"},
+	}
+	if d := titleDistance([]rune(dedupTitle(papers[0].Title)), []rune(dedupTitle(papers[2].Title))); d < 0.1 {
+		t.Fatalf("test setup: distance(a, c) = %.3f, want >= 0.1", d)
+	}
+	if got := DeduplicateByTitle(papers, 0.1); len(got) != 1 || got[0] != papers[0] {
+		t.Errorf("Expected a single cluster keeping the first paper, got %q", titles(got))
+	}
+}
+
+func TestDeduplicateByEmbedding(t *testing.T) {
+	embedder := &topicEmbedder{topics: map[string][]float32{
+		"Diffusion":   {1, 0, 0},
+		"Denoising":   {0.99, 0.05, 0},
+		"Contrastive": {0, 1, 0},
+	}}
+	papers := []*models.Paper{
+		{Source: "arxiv", Title: "Diffusion Models Beat GANs"},
+		{Source: "openreview", Title: "Denoising Probabilistic Models", URL: "https://openreview.net/x", FirstSubmittedAt: time.Now()},
+		{Source: "arxiv", Title: "Contrastive Learning of Visual Representations"},
+		{Source: "acl", Title: "Unrelated Work"},
+	}
+
+	got, err := DeduplicateByEmbedding(context.Background(), papers, embedder, DefaultEmbeddingDedupThreshold)
+	if err != nil {
+		t.Fatalf("DeduplicateByEmbedding() error: %v", err)
+	}
+	want := []*models.Paper{papers[1], papers[2], papers[3]}
+	if fmt.Sprint(titles(got)) != fmt.Sprint(titles(want)) {
+		t.Errorf("DeduplicateByEmbedding() = %q, want %q", titles(got), titles(want))
+	}
+
+	if _, err := DeduplicateByEmbedding(context.Background(), papers, nil, 0.9); err == nil {
+		t.Error("Expected error without embedder")
+	}
+}
+
+func TestDeduplicateDatabase(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	papers := []*models.Paper{
+		{Source: "arxiv", SourceID: "2403.00001", URL: "https://arxiv.org/abs/2403.00001", Title: "Scaling Laws for Neural Language Models"},
+		{Source: "acl", SourceID: "acl_scaling", URL: "https://aclanthology.org/x", Title: "Scaling laws for neural language model", Abstract: "We study scaling."},
+		{Source: "arxiv", SourceID: "2403.00002", URL: "https://arxiv.org/abs/2403.00002", Title: "Language Models are Few-Shot Learners"},
+	}
+	for _, p := range papers {
+		id, err := a.db.Upsert(p)
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		p.ID = id
+	}
+
+	if _, err := a.DeduplicateDatabase(context.Background(), "fuzzy", 0); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+
+	report, err := a.DeduplicateDatabase(context.Background(), DedupTitle, 0)
+	if err != nil {
+		t.Fatalf("DeduplicateDatabase() error: %v", err)
+	}
+	if report.Scanned != 3 || report.Removed != 1 || report.Threshold != DefaultTitleDedupThreshold {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	left, err := a.db.GetPapersByConditions(nil, nil, 0)
+	if err != nil {
+		t.Fatalf("GetPapersByConditions() error: %v", err)
+	}
+	ids := make(map[int64]bool)
+	for _, p := range left {
+		ids[p.ID] = true
+	}
+	if len(left) != 2 || ids[papers[0].ID] || !ids[papers[1].ID] {
+		t.Errorf("Expected the paper with abstract to be kept, got %q", titles(left))
+	}
+}
+
+func TestDeduplicateDatabase_Embedding(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	embedder := &topicEmbedder{topics: map[string][]float32{"Contrastive": {0, 1, 0}}}
+	a.embedder = embedder
+	papers := []*models.Paper{
+		{Source: "arxiv", SourceID: "2403.00001", URL: "https://arxiv.org/abs/2403.00001", Title: "Diffusion Models Beat GANs"},
+		{Source: "openreview", SourceID: "or_diffusion", URL: "https://openreview.net/x", Title: "Denoising Probabilistic Models", Abstract: "We study diffusion."},
+		{Source: "arxiv", SourceID: "2403.00002", URL: "https://arxiv.org/abs/2403.00002", Title: "Contrastive Learning of Visual Representations"},
+	}
+	for _, p := range papers {
+		id, err := a.db.Upsert(p)
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		p.ID = id
+	}
+	// 前两篇已有向量（与 embedder 生成的不同），去重应使用已存向量
+	for i, vec := range [][]float32{{1, 0, 0}, {0.99, 0.05, 0}} {
+		if err := a.db.SaveEmbedding(papers[i].ID, embedder.ModelName(), papers[i].Title, vec); err != nil {
+			t.Fatalf("SaveEmbedding() error: %v", err)
+		}
+	}
+
+	report, err := a.DeduplicateDatabase(context.Background(), DedupEmbedding, 0)
+	if err != nil {
+		t.Fatalf("DeduplicateDatabase() error: %v", err)
+	}
+	if report.Scanned != 3 || report.Removed != 1 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if embedder.texts != 1 {
+		t.Errorf("Expected only the paper without a stored vector to be embedded, got %d texts", embedder.texts)
+	}
+	stored, err := a.db.GetEmbeddings([]int64{papers[2].ID}, embedder.ModelName())
+	if err != nil || len(stored[papers[2].ID]) != 3 {
+		t.Errorf("Expected generated vector to be saved, got %v (%v)", stored, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := DeduplicateByEmbedding(ctx, papers, embedder, DefaultEmbeddingDedupThreshold); err == nil {
+		t.Error("Expected error for cancelled context")
+	}
+}
+
+func TestClusterByEmbedding_Indexed(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := dedupBruteForceMax + 100
+	vecs := make([][]float32, n, n+1)
+	for i := range vecs {
+		vec := make([]float32, 16)
+		for j := range vec {
+			vec[j] = rng.Float32()*2 - 1
+		}
+		vecs[i] = vec
+	}
+	// 与第 7 个向量几乎相同的重复
+	dup := append([]float32(nil), vecs[7]...)
+	dup[0] += 0.01
+	vecs = append(vecs, dup)
+
+	uf, err := clusterByEmbedding(context.Background(), vecs, DefaultEmbeddingDedupThreshold)
+	if err != nil {
+		t.Fatalf("clusterByEmbedding() error: %v", err)
+	}
+	if uf.find(7) != uf.find(n) {
+		t.Error("Expected near-duplicate vectors to be clustered")
+	}
+	groups := make(map[int]bool)
+	for i := range vecs {
+		groups[uf.find(i)] = true
+	}
+	if len(groups) != n {
+		t.Errorf("Expected %d groups, got %d", n, len(groups))
+	}
+}
+
+func TestCrawlDedup(t *testing.T) {
+	papers := []*models.Paper{
+		{Title: "Deep Residual Learning"},
+		nil,
+		{Title: "Deep residual learning."},
+	}
+	a := &App{}
+	if got := a.dedup(context.Background(), papers); len(got) != 3 {
+		t.Errorf("Expected no dedup without DedupMode, got %d papers", len(got))
+	}
+	a.DedupMode = DedupTitle
+	if got := a.dedup(context.Background(), papers); len(got) != 1 {
+		t.Errorf("Expected title dedup to merge duplicates, got %q", titles(got))
+	}
+	// 未配置 embedder 时向量去重失败，保留原结果
+	a.DedupMode = DedupEmbedding
+	if got := a.dedup(context.Background(), papers); len(got) != 2 {
+		t.Errorf("Expected original papers when embedding dedup fails, got %d", len(got))
+	}
+}
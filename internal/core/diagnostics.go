@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/platform"
+	"PaperHunter/internal/translate"
+	feishu "PaperHunter/pkg/upload/feishu"
+)
+
+// diagnosticsTimeout 单项检查的超时时间，超时的服务记为失败，不阻塞其它检查
+var diagnosticsTimeout = 15 * time.Second
+
+// ServiceStatus 单项服务的连通性检查结果
+type ServiceStatus struct {
+	Service    string `json:"service"`    // embedder、llm、zotero、feishu 或平台名
+	Configured bool   `json:"configured"` // 未配置的服务不做检查，OK 为 false
+	OK         bool   `json:"ok"`
+	Error      string `json:"error,omitempty"`
+	LatencyMS  int64  `json:"latency_ms"`
+}
+
+// serviceCheck 一项检查，check 为 nil 表示服务未配置
+type serviceCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// Diagnostics 并发检查已配置的各项服务：embedder 做一次探测向量化，LLM 做一次极短的翻译，
+// Zotero 读取 collection 列表，飞书获取 Tenant Access Token，各平台执行一次只取 1 篇的检索。
+// 每项检查最长 diagnosticsTimeout，结果按 embedder、llm、zotero、feishu、平台名的顺序返回
+func (a *App) Diagnostics(ctx context.Context) []ServiceStatus {
+	checks := []serviceCheck{{name: "embedder"}, {name: "llm"}, {name: "zotero"}, {name: "feishu"}}
+	if emb.Configured(a.embedder) {
+		checks[0].check = func(ctx context.Context) error {
+			vec, err := a.embedder.EmbedQuery(ctx, "ping")
+			if err != nil {
+				return err
+			}
+			if dim := a.embedder.Dim(); dim > 0 && len(vec) != dim {
+				return fmt.Errorf("向量维度 %d 与配置的 %d 不一致", len(vec), dim)
+			}
+			return nil
+		}
+	}
+	if a.Translator != nil {
+		checks[1].check = func(ctx context.Context) error {
+			_, err := a.Translator.Translate(ctx, []translate.Item{{Title: "ping"}}, "en")
+			return err
+		}
+	}
+	if a.zoteroCfg.UserID != "" && a.zoteroCfg.APIKey != "" {
+		checks[2].check = func(ctx context.Context) error {
+			_, err := a.zoteroCfg.NewClient().GetCollections()
+			return err
+		}
+	}
+	if a.feishuCfg.AppID != "" && a.feishuCfg.AppSecret != "" {
+		checks[3].check = func(ctx context.Context) error {
+			return feishu.NewClient(a.feishuCfg.AppID, a.feishuCfg.AppSecret, "", "").CheckCredentials()
+		}
+	}
+
+	names := make([]string, 0, len(a.platformCfg))
+	for name := range a.platformCfg {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checks = append(checks, serviceCheck{name: name, check: func(ctx context.Context) error {
+			if err := a.platformCfg[name].Validate(); err != nil {
+				return fmt.Errorf("配置无效: %w", err)
+			}
+			_, err := a.searchPlatform(ctx, name, platform.Query{Keywords: []string{"learning"}, Limit: 1})
+			return err
+		}})
+	}
+
+	statuses := make([]ServiceStatus, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		statuses[i].Service = c.name
+		if c.check == nil {
+			continue
+		}
+		statuses[i].Configured = true
+		wg.Add(1)
+		go func(st *ServiceStatus, check func(ctx context.Context) error) {
+			defer wg.Done()
+			start := time.Now()
+			err := runWithTimeout(ctx, diagnosticsTimeout, check)
+			st.LatencyMS = time.Since(start).Milliseconds()
+			if err != nil {
+				st.Error = err.Error()
+				return
+			}
+			st.OK = true
+		}(&statuses[i], c.check)
+	}
+	wg.Wait()
+	return statuses
+}
+
+// runWithTimeout 在 timeout 内执行 check；不支持 context 的检查（如 Zotero、飞书客户端）超时后在后台自行结束
+func runWithTimeout(ctx context.Context, timeout time.Duration, check func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("检查超时（%v）: %w", timeout, ctx.Err())
+	}
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/platform"
+	"PaperHunter/internal/translate"
+)
+
+// hangingPlatform 忽略 context 一直阻塞到 release 关闭，模拟不响应的服务
+type hangingPlatform struct {
+	release chan struct{}
+}
+
+func (p *hangingPlatform) Name() string               { return "stub-diag-hang" }
+func (p *hangingPlatform) GetConfig() platform.Config { return stubConfig{} }
+func (p *hangingPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	<-p.release
+	return platform.Result{}, nil
+}
+
+// diagRelease 关闭后 stub-diag-hang 的检索返回，由测试在开始时创建
+var diagRelease chan struct{}
+
+func init() {
+	MustRegister(Provider{
+		Name:          "stub-diag-ok",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return &stubPlatform{n: 1}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+	MustRegister(Provider{
+		Name: "stub-diag-hang",
+		New: func(cfg platform.Config) (platform.Platform, error) {
+			return &hangingPlatform{release: diagRelease}, nil
+		},
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func TestDiagnostics(t *testing.T) {
+	release := make(chan struct{})
+	diagRelease = release
+	t.Cleanup(func() { close(release) })
+
+	old := diagnosticsTimeout
+	diagnosticsTimeout = 200 * time.Millisecond
+	t.Cleanup(func() { diagnosticsTimeout = old })
+
+	srv, _ := newMockLLM(t)
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	a.Translator, _ = translate.New(translate.Config{BaseURL: srv.URL, APIKey: "test-key", Model: "test-model"})
+	a.platformCfg = map[string]platform.Config{"stub-diag-ok": stubConfig{}, "stub-diag-hang": stubConfig{}}
+
+	start := time.Now()
+	statuses := a.Diagnostics(context.Background())
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected hanging check to be cut off by the timeout, took %v", elapsed)
+	}
+
+	want := []struct {
+		service    string
+		configured bool
+		ok         bool
+	}{
+		{"embedder", true, true},
+		{"llm", true, true},
+		{"zotero", false, false},
+		{"feishu", false, false},
+		{"stub-diag-hang", true, false},
+		{"stub-diag-ok", true, true},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("Expected %d statuses, got %+v", len(want), statuses)
+	}
+	for i, w := range want {
+		got := statuses[i]
+		if got.Service != w.service || got.Configured != w.configured || got.OK != w.ok {
+			t.Errorf("statuses[%d] = %+v, want service=%s configured=%v ok=%v", i, got, w.service, w.configured, w.ok)
+		}
+		if got.OK && got.Error != "" {
+			t.Errorf("Expected no error for healthy %s, got %q", got.Service, got.Error)
+		}
+	}
+	if !strings.Contains(statuses[4].Error, "超时") {
+		t.Errorf("Expected timeout error for hanging platform, got %q", statuses[4].Error)
+	}
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"PaperHunter/internal/platform"
+)
+
+func init() {
+	MustRegister(Provider{
+		Name:          "stub-dryrun",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return &stubPlatform{n: 8}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func TestDryRunCrawl_DoesNotSave(t *testing.T) {
+	embedder := &fakeEmbedder{}
+	a := newEmbeddingApp(t, embedder)
+	a.platformCfg = map[string]platform.Config{}
+
+	before, err := a.db.CountPapers(nil, nil)
+	if err != nil {
+		t.Fatalf("CountPapers() error: %v", err)
+	}
+	res, err := a.DryRunCrawl(context.Background(), "stub-dryrun", platform.Query{})
+	if err != nil {
+		t.Fatalf("DryRunCrawl() error: %v", err)
+	}
+	if res.Total != 8 || len(res.Papers) != 8 {
+		t.Errorf("Expected 8 previewed papers, got total=%d papers=%d", res.Total, len(res.Papers))
+	}
+
+	after, err := a.db.CountPapers(nil, nil)
+	if err != nil {
+		t.Fatalf("CountPapers() error: %v", err)
+	}
+	if before != after {
+		t.Errorf("Expected no papers written, count went from %d to %d", before, after)
+	}
+	if embedder.calls != 0 {
+		t.Errorf("Expected no embedding calls, got %d", embedder.calls)
+	}
+}
+
+func TestDryRunCrawl_UnknownPlatform(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	if _, err := a.DryRunCrawl(context.Background(), "no-such-platform", platform.Query{}); err == nil {
+		t.Error("Expected error for unknown platform")
+	}
+}
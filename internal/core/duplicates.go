@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	storage "PaperHunter/db"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+const (
+	// NearDuplicateThreshold 不同平台论文的向量相似度达到该值时视为疑似重复
+	NearDuplicateThreshold float32 = 0.97
+	// nearDuplicateLimit 每篇论文最多报告的疑似重复数
+	nearDuplicateLimit = 5
+)
+
+// warnNearDuplicates 检查刚生成向量的论文在其他平台是否已有疑似重复，只记录警告
+func (a *App) warnNearDuplicates(papers []*models.Paper) {
+	for _, p := range papers {
+		dups, err := a.db.FindNearDuplicates(p.ID, NearDuplicateThreshold, nearDuplicateLimit)
+		if err != nil {
+			logger.Warn("检查重复论文失败 [paper_id=%d]: %v", p.ID, err)
+			continue
+		}
+		for _, d := range dups {
+			logger.Warn("疑似重复论文: %s/%s 与 %s/%s (%s)", p.Source, p.SourceID, d.Source, d.SourceID, d.Title)
+		}
+	}
+}
+
+// EnableCrossSourceMerge 启用入库时的跨平台合并：DOI 或标题+第一作者相同的论文并入已有记录，
+// 存储层不支持时只记录警告
+func (a *App) EnableCrossSourceMerge() {
+	m, ok := a.db.(interface{ EnableCrossSourceMerge() })
+	if !ok {
+		logger.Warn("当前存储不支持跨平台合并，已忽略 database.merge_cross_source")
+		return
+	}
+	m.EnableCrossSourceMerge()
+}
+
+// MergeDuplicates 将重复论文的作者与分类并入主论文，然后软删除重复论文
+func (a *App) MergeDuplicates(ctx context.Context, primarySource, primaryID, duplicateSource, duplicateID string) error {
+	primary, err := a.lookupPaper(primarySource, primaryID)
+	if err != nil {
+		return err
+	}
+	duplicate, err := a.lookupPaper(duplicateSource, duplicateID)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("合并重复论文: %s/%s -> %s/%s", duplicateSource, duplicateID, primarySource, primaryID)
+	if err := a.db.MergePapers(primary.ID, duplicate.ID); err != nil {
+		return fmt.Errorf("合并论文失败: %w", err)
+	}
+	if a.searcher != nil {
+		a.searcher.InvalidateIRIndex()
+	}
+	return nil
+}
+
+// lookupPaper 按 source + sourceID 查找未删除的论文
+func (a *App) lookupPaper(source, sourceID string) (*models.Paper, error) {
+	paper, err := a.db.GetPaperBySourceID(source, sourceID)
+	if err != nil && !errors.Is(err, storage.ErrPaperNotFound) {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+	return paper, err
+}
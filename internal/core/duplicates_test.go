@@ -0,0 +1,64 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	storage "PaperHunter/db"
+	"PaperHunter/internal/models"
+)
+
+// nearDupSpy 记录 FindNearDuplicates 检查过的论文
+type nearDupSpy struct {
+	storage.PaperStorage
+	checked []int64
+}
+
+func (s *nearDupSpy) FindNearDuplicates(paperID int64, threshold float32, limit int) ([]*models.Paper, error) {
+	s.checked = append(s.checked, paperID)
+	return s.PaperStorage.FindNearDuplicates(paperID, threshold, limit)
+}
+
+func TestSavePapers_ChecksNearDuplicates(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	spy := &nearDupSpy{PaperStorage: a.db}
+	a.db = spy
+
+	count, err := a.SavePapers(context.Background(), newPapers(3))
+	if err != nil || count != 3 {
+		t.Fatalf("SavePapers() = %d, %v", count, err)
+	}
+	if len(spy.checked) != 3 {
+		t.Errorf("Expected near-duplicate check for 3 saved papers, got %v", spy.checked)
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	ctx := context.Background()
+	primary := &models.Paper{Source: "arxiv", SourceID: "2401.00001", URL: "https://arxiv.org/abs/2401.00001", Title: "Graph Transformers", Authors: []string{"Alice"}}
+	duplicate := &models.Paper{Source: "semantic", SourceID: "abc", URL: "https://example.com/abc", Title: "Graph Transformers", Authors: []string{"Bob"}}
+	for _, p := range []*models.Paper{primary, duplicate} {
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+
+	if err := a.MergeDuplicates(ctx, "arxiv", "2401.00001", "semantic", "missing"); err == nil {
+		t.Error("Expected error for unknown duplicate")
+	}
+	if err := a.MergeDuplicates(ctx, "arxiv", "2401.00001", "semantic", "abc"); err != nil {
+		t.Fatalf("MergeDuplicates() error: %v", err)
+	}
+
+	merged, err := a.lookupPaper("arxiv", "2401.00001")
+	if err != nil {
+		t.Fatalf("lookupPaper() error: %v", err)
+	}
+	if len(merged.Authors) != 2 {
+		t.Errorf("Expected authors from both papers, got %v", merged.Authors)
+	}
+	if _, err := a.lookupPaper("semantic", "abc"); err == nil {
+		t.Error("Expected duplicate to be removed from active papers")
+	}
+}
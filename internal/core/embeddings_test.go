@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	storage "PaperHunter/db"
+	dbsqlite "PaperHunter/db/sqlite"
+	"PaperHunter/internal/models"
+)
+
+// fakeEmbedder 记录并发调用数，标题包含 failTitle 的论文返回错误，failBatch 时批量接口整体失败
+type fakeEmbedder struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	calls       int
+	batchSizes  []int
+	delay       time.Duration
+	failTitle   string
+	failBatch   bool
+	onCall      func()
+	dim         int // 非零时 Dim() 返回该值，模拟 embedder.dim 与实际向量不一致
+}
+
+func (f *fakeEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.calls++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	onCall := f.onCall
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	if onCall != nil {
+		onCall()
+	}
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if f.failTitle != "" && strings.Contains(text, f.failTitle) {
+		return nil, errors.New("embedding failed")
+	}
+	return []float32{1, 0, 0}, nil
+}
+
+func (f *fakeEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	f.mu.Lock()
+	f.batchSizes = append(f.batchSizes, len(texts))
+	failBatch := f.failBatch
+	f.mu.Unlock()
+	if failBatch {
+		return nil, errors.New("batch embedding failed")
+	}
+
+	vecs := make([][]float32, len(texts))
+	for i, text := range texts {
+		vec, err := f.EmbedQuery(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vecs[i] = vec
+	}
+	return vecs, nil
+}
+
+func (f *fakeEmbedder) ModelName() string { return "fake-model" }
+
+func (f *fakeEmbedder) Dim() int {
+	if f.dim != 0 {
+		return f.dim
+	}
+	return 3
+}
+
+func (f *fakeEmbedder) ValidateModel(ctx context.Context) error { return nil }
+
+func newEmbeddingSearcher(t *testing.T, embedder *fakeEmbedder, n int) *Searcher {
+	t.Helper()
+	d, err := dbsqlite.NewSQLiteDB(filepath.Join(t.TempDir(), "papers.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+
+	for i := 0; i < n; i++ {
+		p := &models.Paper{
+			Source:   "arxiv",
+			SourceID: fmt.Sprintf("2401.%05d", i),
+			URL:      fmt.Sprintf("https://arxiv.org/abs/2401.%05d", i),
+			Title:    fmt.Sprintf("Paper %d", i),
+			Abstract: "Graph neural networks.",
+		}
+		if _, err := d.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+	return NewSearcher(d, embedder)
+}
+
+func TestComputeMissingEmbeddings_Concurrent(t *testing.T) {
+	embedder := &fakeEmbedder{delay: 20 * time.Millisecond, failTitle: "Paper 7"}
+	s := newEmbeddingSearcher(t, embedder, 20)
+
+	count, err := s.ComputeMissingEmbeddings(context.Background(), 100, 4)
+	if err != nil {
+		t.Fatalf("ComputeMissingEmbeddings() error: %v", err)
+	}
+	// 单篇失败只记录警告，不影响其他论文
+	if count != 19 {
+		t.Errorf("Expected 19 embeddings saved, got %d", count)
+	}
+	if embedder.maxInFlight < 2 || embedder.maxInFlight > 4 {
+		t.Errorf("Expected between 2 and 4 concurrent calls, got %d", embedder.maxInFlight)
+	}
+
+	// 已保存的论文不再需要计算，只剩失败的一篇
+	embedder.failTitle = ""
+	count, err = s.ComputeMissingEmbeddings(context.Background(), 100, 0)
+	if err != nil {
+		t.Fatalf("second ComputeMissingEmbeddings() error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 remaining embedding, got %d", count)
+	}
+}
+
+func TestComputeMissingEmbeddings_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	embedder := &fakeEmbedder{delay: 50 * time.Millisecond}
+	var once sync.Once
+	embedder.onCall = func() { once.Do(cancel) }
+	s := newEmbeddingSearcher(t, embedder, 50)
+
+	start := time.Now()
+	count, err := s.ComputeMissingEmbeddings(ctx, 100, 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if count >= 50 {
+		t.Errorf("Expected dispatch to stop early, got %d embeddings", count)
+	}
+	if embedder.calls > 4 {
+		t.Errorf("Expected at most a few calls after cancel, got %d", embedder.calls)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected prompt return after cancel, took %v", elapsed)
+	}
+}
+
+func TestComputeMissingEmbeddings_SkipsWrongDim(t *testing.T) {
+	// embedder 声明 4 维却返回 3 维向量，不应写入数据库
+	embedder := &fakeEmbedder{dim: 4}
+	s := newEmbeddingSearcher(t, embedder, 3)
+
+	count, err := s.ComputeMissingEmbeddings(context.Background(), 10, 1)
+	if err != nil {
+		t.Fatalf("ComputeMissingEmbeddings() error: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no embeddings saved for mismatched dim, got %d", count)
+	}
+	if err := s.checkEmbeddingDim([]float32{1, 0, 0}); !errors.Is(err, storage.ErrEmbeddingDimMismatch) {
+		t.Errorf("Expected ErrEmbeddingDimMismatch, got %v", err)
+	}
+
+	a := &App{db: s.db, embedder: embedder, searcher: s}
+	if _, err := a.SavePapers(context.Background(), newPapers(2)); err != nil {
+		t.Fatalf("SavePapers() error: %v", err)
+	}
+	if n := pendingEmbeddings(t, a); n != 5 {
+		t.Errorf("Expected all 5 papers still pending, got %d", n)
+	}
+}
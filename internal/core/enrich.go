@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/enrich/crossref"
+	"PaperHunter/pkg/logger"
+)
+
+// MetadataLookup 按标题或 DOI 查找出版信息，由 crossref.Client 实现
+type MetadataLookup interface {
+	Lookup(ctx context.Context, title string) (*crossref.Work, error)
+	LookupDOI(ctx context.Context, doi string) (*crossref.Work, error)
+}
+
+// EnrichReport 元数据补全结果
+type EnrichReport struct {
+	Scanned int `json:"scanned"` // 缺少 DOI、日期或分类的论文数
+	Matched int `json:"matched"` // 找到匹配的论文数
+	Updated int `json:"updated"` // 补全并保存的论文数
+	Failed  int `json:"failed"`  // 检索或保存失败的论文数
+}
+
+// EnableCrossRef 启用 CrossRef 元数据补全，cfg.Enabled 为 false 时关闭
+func (a *App) EnableCrossRef(cfg CrossRefConfig) {
+	if !cfg.Enabled {
+		a.enricher = nil
+		return
+	}
+	c := crossref.NewClient(cfg.Mailto, cfg.RateLimitPerSecond)
+	c.MinSimilarity = cfg.MinSimilarity
+	a.enricher = c
+}
+
+// EnrichPapers 为满足条件且缺少 DOI、首次提交时间或分类的论文查询 CrossRef，只补全缺失字段并保存；
+// 已有 DOI（备注或 doi.org 链接）的论文按 DOI 精确查询，其余按标题检索。单篇检索失败只计入 Failed，ctx 取消时返回已完成的部分
+func (a *App) EnrichPapers(ctx context.Context, conditions []string, params []interface{}) (*EnrichReport, error) {
+	return a.enrich(ctx, conditions, params, needsEnrichment)
+}
+
+// EnrichFromCrossRef 为满足条件、带 DOI 的论文按 DOI 查询 CrossRef，
+// 补全为空的首次提交时间、分类、作者与引用数，返回更新的论文数
+func (a *App) EnrichFromCrossRef(ctx context.Context, conditions []string, params []interface{}) (int, error) {
+	conditions = append(append([]string{}, conditions...), "doi != ''")
+	report, err := a.enrich(ctx, conditions, params, func(p *models.Paper) bool {
+		return p.DOI() != "" && missingMetadata(p)
+	})
+	if report == nil {
+		return 0, err
+	}
+	return report.Updated, err
+}
+
+func (a *App) enrich(ctx context.Context, conditions []string, params []interface{}, want func(p *models.Paper) bool) (*EnrichReport, error) {
+	if a.enricher == nil {
+		return nil, fmt.Errorf("未启用 CrossRef 元数据补全（crossref.enabled）")
+	}
+	papers, err := a.db.GetPapersByConditions(conditions, params, 0)
+	if err != nil {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+
+	report := &EnrichReport{}
+	for _, p := range papers {
+		if !want(p) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Scanned++
+
+		var work *crossref.Work
+		if doi := p.DOI(); doi != "" {
+			work, err = a.enricher.LookupDOI(ctx, doi)
+		} else {
+			work, err = a.enricher.Lookup(ctx, p.Title)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			logger.Warn("CrossRef 检索失败 [%s:%s]: %v", p.Source, p.SourceID, err)
+			report.Failed++
+			continue
+		}
+		if work == nil {
+			continue
+		}
+		report.Matched++
+
+		if !applyWork(p, work) {
+			continue
+		}
+		if _, err := a.db.Upsert(p); err != nil {
+			logger.Warn("保存补全信息失败 [%s:%s]: %v", p.Source, p.SourceID, err)
+			report.Failed++
+			continue
+		}
+		report.Updated++
+	}
+	logger.Info("CrossRef 补全完成: 待补全 %d 篇，匹配 %d 篇，更新 %d 篇，失败 %d 篇", report.Scanned, report.Matched, report.Updated, report.Failed)
+	return report, nil
+}
+
+func needsEnrichment(p *models.Paper) bool {
+	return p.DOI() == "" || p.FirstSubmittedAt.IsZero() || len(p.Categories) == 0
+}
+
+// missingMetadata 是否有可由 CrossRef 按 DOI 补全的空字段
+func missingMetadata(p *models.Paper) bool {
+	return p.FirstSubmittedAt.IsZero() || len(p.Categories) == 0 || len(p.Authors) == 0 || p.Citations == 0
+}
+
+// applyWork 用 CrossRef 结果补全缺失字段，已有的值不覆盖；有字段变化时返回 true
+func applyWork(p *models.Paper, w *crossref.Work) bool {
+	changed := false
+	if w.DOI != "" && p.DOI() == "" {
+		if strings.TrimSpace(p.Comments) == "" {
+			p.Comments = "DOI: " + w.DOI
+		} else {
+			p.Comments += " | DOI: " + w.DOI
+		}
+		changed = true
+	}
+	if p.FirstSubmittedAt.IsZero() && !w.Published.IsZero() {
+		p.FirstSubmittedAt = w.Published
+		changed = true
+	}
+	if len(p.Categories) == 0 && w.Venue != "" {
+		p.Categories = []string{w.Venue}
+		changed = true
+	}
+	if len(p.Authors) == 0 && len(w.Authors) > 0 {
+		p.Authors = w.Authors
+		changed = true
+	}
+	if p.Citations == 0 && w.Citations > 0 {
+		p.Citations = w.Citations
+		changed = true
+	}
+	return changed
+}
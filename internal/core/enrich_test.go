@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"PaperHunter/pkg/enrich/crossref"
+)
+
+type fakeLookup struct {
+	works  map[string]*crossref.Work
+	dois   map[string]*crossref.Work
+	failed map[string]bool
+	calls  []string
+}
+
+func (f *fakeLookup) LookupDOI(_ context.Context, doi string) (*crossref.Work, error) {
+	f.calls = append(f.calls, "doi:"+doi)
+	return f.dois[doi], nil
+}
+
+func (f *fakeLookup) Lookup(_ context.Context, title string) (*crossref.Work, error) {
+	f.calls = append(f.calls, title)
+	if f.failed[title] {
+		return nil, errors.New("crossref unavailable")
+	}
+	return f.works[title], nil
+}
+
+func TestEnrichPapers_FillsMissingFields(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	papers := newPapers(4)
+	papers[0].Comments = "Accepted at ACL"
+	papers[0].Categories = []string{"cs.CL"}
+	// 已有 DOI、日期和分类，不需要补全
+	papers[3].Comments = "DOI: 10.1/kept"
+	papers[3].Categories = []string{"cs.LG"}
+	papers[3].FirstSubmittedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, p := range papers {
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+
+	published := time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC)
+	lookup := &fakeLookup{
+		works: map[string]*crossref.Work{
+			"Paper 0": {DOI: "10.18653/v1/x", Venue: "ACL 2024", Published: published, Authors: []string{"Alice Smith"}},
+		},
+		failed: map[string]bool{"Paper 2": true},
+	}
+	a.enricher = lookup
+
+	report, err := a.EnrichPapers(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("EnrichPapers() error: %v", err)
+	}
+	want := EnrichReport{Scanned: 3, Matched: 1, Updated: 1, Failed: 1}
+	if *report != want {
+		t.Errorf("Report = %+v, want %+v", *report, want)
+	}
+	if len(lookup.calls) != 3 {
+		t.Errorf("Expected 3 lookups, got %v", lookup.calls)
+	}
+
+	got, err := a.db.GetPapersByConditions([]string{"source_id = ?"}, []interface{}{papers[0].SourceID}, 1)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("GetPapersByConditions() = %v, %v", got, err)
+	}
+	p := got[0]
+	if p.Comments != "Accepted at ACL | DOI: 10.18653/v1/x" {
+		t.Errorf("Comments = %q", p.Comments)
+	}
+	if !p.FirstSubmittedAt.Equal(published) {
+		t.Errorf("FirstSubmittedAt = %v, want %v", p.FirstSubmittedAt, published)
+	}
+	if len(p.Categories) != 1 || p.Categories[0] != "cs.CL" {
+		t.Errorf("Existing categories should be kept, got %v", p.Categories)
+	}
+	if len(p.Authors) != 1 || p.Authors[0] != "Alice Smith" {
+		t.Errorf("Authors = %v", p.Authors)
+	}
+}
+
+func TestEnrichPapers_Disabled(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	a.EnableCrossRef(CrossRefConfig{Enabled: false})
+	if _, err := a.EnrichPapers(context.Background(), nil, nil); err == nil {
+		t.Error("Expected error when CrossRef is disabled")
+	}
+}
+
+func TestEnrichFromCrossRef(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	papers := newPapers(3)
+	papers[0].Comments = "DOI: 10.18653/v1/2023.acl-long.1"
+	papers[0].Authors = []string{"Kept Author"}
+	papers[1].Comments = "DOI: 10.9999/unknown"
+	for _, p := range papers {
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+
+	published := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	lookup := &fakeLookup{dois: map[string]*crossref.Work{
+		"10.18653/v1/2023.acl-long.1": {
+			DOI: "10.18653/v1/2023.acl-long.1", Venue: "Proceedings of ACL 2023", Published: published,
+			Authors: []string{"Alice Smith"}, Citations: 12,
+		},
+	}}
+	a.enricher = lookup
+
+	n, err := a.EnrichFromCrossRef(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("EnrichFromCrossRef() error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 enriched paper, got %d", n)
+	}
+	// 没有 DOI 的论文不查询
+	if len(lookup.calls) != 2 || lookup.calls[0] != "doi:10.18653/v1/2023.acl-long.1" {
+		t.Errorf("Unexpected lookups: %v", lookup.calls)
+	}
+
+	got, err := a.db.GetPapersByConditions([]string{"source_id = ?"}, []interface{}{papers[0].SourceID}, 1)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("GetPapersByConditions() = %v, %v", got, err)
+	}
+	p := got[0]
+	if p.Comments != "DOI: 10.18653/v1/2023.acl-long.1" || !p.FirstSubmittedAt.Equal(published) || p.Citations != 12 {
+		t.Errorf("Unexpected enriched paper: comments=%q date=%v citations=%d", p.Comments, p.FirstSubmittedAt, p.Citations)
+	}
+	if len(p.Categories) != 1 || p.Categories[0] != "Proceedings of ACL 2023" {
+		t.Errorf("Categories = %v", p.Categories)
+	}
+	if len(p.Authors) != 1 || p.Authors[0] != "Kept Author" {
+		t.Errorf("Existing authors should be kept, got %v", p.Authors)
+	}
+}
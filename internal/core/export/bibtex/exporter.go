@@ -0,0 +1,184 @@
+package bibtex
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// keyStopWords 生成引用键时跳过的标题虚词
+var keyStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "on": true, "of": true, "for": true, "in": true,
+	"to": true, "and": true, "with": true, "towards": true, "toward": true, "via": true, "is": true,
+}
+
+// latexEscaper 转义 LaTeX 特殊字符；花括号转义后仍可被 pkg/bibtex 正确解析
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+)
+
+type BibTeXExporter struct{}
+
+func NewBibTeXExporter() *BibTeXExporter {
+	return &BibTeXExporter{}
+}
+
+func init() {
+	exporter.MustRegister("bibtex", func() exporter.Exporter { return NewBibTeXExporter() })
+}
+
+// Export 每篇论文写出一条 BibTeX 记录，同一文件内的引用键保证唯一
+func (e *BibTeXExporter) Export(papers []*models.Paper, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	used := make(map[string]bool)
+	for _, p := range papers {
+		if p == nil {
+			continue
+		}
+		key := uniqueKey(CitationKey(p), used)
+		if _, err := w.WriteString(FormatPaper(p, key)); err != nil {
+			return fmt.Errorf("写入数据失败: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("写入数据失败: %w", err)
+	}
+	return nil
+}
+
+// FormatPaper 将单篇论文序列化为 BibTeX 记录，空字段跳过
+func FormatPaper(p *models.Paper, key string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "@%s{%s,\n", entryType(p.Source), key)
+	writeField(&sb, "title", p.Title)
+	var authors []string
+	for _, author := range p.Authors {
+		if author = strings.TrimSpace(author); author != "" {
+			authors = append(authors, author)
+		}
+	}
+	writeField(&sb, "author", strings.Join(authors, " and "))
+	if !p.FirstSubmittedAt.IsZero() {
+		writeField(&sb, "year", p.FirstSubmittedAt.Format("2006"))
+	}
+	writeField(&sb, "abstract", p.Abstract)
+	writeField(&sb, "url", p.URL)
+	if strings.EqualFold(p.Source, "arxiv") && p.SourceID != "" {
+		writeField(&sb, "eprint", p.SourceID)
+		writeField(&sb, "archivePrefix", "arXiv")
+		if len(p.Categories) > 0 {
+			writeField(&sb, "primaryClass", strings.TrimSpace(p.Categories[0]))
+		}
+	}
+	sb.WriteString("}\n\n")
+
+	return sb.String()
+}
+
+// CitationKey 由第一作者姓氏 + 年份 + 标题首个实词生成引用键，如 vaswani2017attention；
+// 缺失的部分直接省略，全部缺失时使用 source+sourceID
+func CitationKey(p *models.Paper) string {
+	var sb strings.Builder
+	if len(p.Authors) > 0 {
+		sb.WriteString(keyPart(lastName(p.Authors[0])))
+	}
+	if !p.FirstSubmittedAt.IsZero() {
+		sb.WriteString(p.FirstSubmittedAt.Format("2006"))
+	}
+	for _, word := range strings.Fields(p.Title) {
+		if part := keyPart(word); part != "" && !keyStopWords[part] {
+			sb.WriteString(part)
+			break
+		}
+	}
+	if sb.Len() == 0 {
+		sb.WriteString(keyPart(p.Source + p.SourceID))
+	}
+	if sb.Len() == 0 {
+		return "paper"
+	}
+	return sb.String()
+}
+
+// uniqueKey 键已被使用时依次追加 a、b、c…（超过 26 个时改用数字后缀）
+func uniqueKey(key string, used map[string]bool) string {
+	candidate := key
+	for i := 0; used[candidate]; i++ {
+		if i < 26 {
+			candidate = key + string(rune('a'+i))
+		} else {
+			candidate = key + strconv.Itoa(i)
+		}
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// lastName 取作者姓氏：支持 "Last, First" 与 "First Last" 两种写法
+func lastName(author string) string {
+	author = strings.TrimSpace(author)
+	if i := strings.Index(author, ","); i >= 0 {
+		return author[:i]
+	}
+	fields := strings.Fields(author)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// keyPart 去掉重音与非字母数字字符并转小写，保证引用键只含 ASCII
+func keyPart(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return sb.String()
+}
+
+// entryType 预印本平台使用 @misc，其余使用 @article
+func entryType(source string) string {
+	switch strings.ToLower(source) {
+	case "arxiv", "ssrn":
+		return "misc"
+	default:
+		return "article"
+	}
+}
+
+// writeField 写出一个字段，合并空白并转义 LaTeX 特殊字符；URL 只做空白处理
+func writeField(sb *strings.Builder, name, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+	if name != "url" {
+		value = latexEscaper.Replace(value)
+	}
+	fmt.Fprintf(sb, "  %s = {%s},\n", name, value)
+}
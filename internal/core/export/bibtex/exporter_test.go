@@ -0,0 +1,138 @@
+package bibtex
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+	bibparser "PaperHunter/pkg/bibtex"
+)
+
+func samplePapers() []*models.Paper {
+	return []*models.Paper{
+		{
+			Source:           "arxiv",
+			SourceID:         "2401.01234",
+			URL:              "https://arxiv.org/abs/2401.01234",
+			Title:            "The Attention Mechanism & Its {Limits}: 100% of $O(n^2)$",
+			Authors:          []string{"Ashish Vaswani", "Noam Shazeer"},
+			Abstract:         "We study attention_heads\nin depth.",
+			Categories:       []string{"cs.CL"},
+			FirstSubmittedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Source:   "acl",
+			SourceID: "2023.acl-long.1",
+			URL:      "https://aclanthology.org/2023.acl-long.1",
+			Title:    "Untitled Work Without Metadata",
+		},
+		{
+			Source:           "openreview",
+			SourceID:         "abc123",
+			Title:            "Attention Revisited",
+			Authors:          []string{"Müller, Jörg"},
+			FirstSubmittedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "papers.bib")
+	if err := NewBibTeXExporter().Export(append(samplePapers(), nil), out); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	entries := bibparser.Parse(string(data))
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d:\n%s", len(entries), data)
+	}
+
+	arxiv := entries[0]
+	if arxiv.Type != "misc" || arxiv.Key != "vaswani2024attention" {
+		t.Errorf("Unexpected arXiv entry header: @%s{%s}", arxiv.Type, arxiv.Key)
+	}
+	if got := arxiv.RawField("title"); got != `The Attention Mechanism \& Its \{Limits\}: 100\% of \$O(n^2)\$` {
+		t.Errorf("Unexpected escaped title: %s", got)
+	}
+	if got := arxiv.Field("author"); got != "Ashish Vaswani and Noam Shazeer" {
+		t.Errorf("Unexpected author field: %s", got)
+	}
+	if got := arxiv.RawField("abstract"); got != `We study attention\_heads in depth.` {
+		t.Errorf("Unexpected abstract: %s", got)
+	}
+	if arxiv.RawField("year") != "2024" || arxiv.RawField("eprint") != "2401.01234" || arxiv.RawField("archiveprefix") != "arXiv" ||
+		arxiv.RawField("primaryclass") != "cs.CL" {
+		t.Errorf("Missing arXiv fields: %+v", arxiv.Fields)
+	}
+
+	// 缺少作者与日期时不输出空字段，引用键退化为标题词
+	bare := entries[1]
+	if bare.Type != "article" || bare.Key != "untitled" {
+		t.Errorf("Unexpected entry header: @%s{%s}", bare.Type, bare.Key)
+	}
+	for _, field := range []string{"author", "year", "eprint", "abstract"} {
+		if _, ok := bare.Fields[field]; ok {
+			t.Errorf("Expected no %s field for paper without metadata", field)
+		}
+	}
+
+	if entries[2].Key != "muller2024attention" {
+		t.Errorf("Expected ASCII key from \"Last, First\" author, got %s", entries[2].Key)
+	}
+}
+
+func TestCitationKey(t *testing.T) {
+	used := map[string]bool{}
+	p := &models.Paper{Title: "A Study", Authors: []string{"Jane Doe"}, FirstSubmittedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if key := CitationKey(p); key != "doe2020study" {
+		t.Errorf("CitationKey() = %s, want doe2020study", key)
+	}
+	if first, second := uniqueKey("doe2020study", used), uniqueKey("doe2020study", used); first != "doe2020study" || second != "doe2020studya" {
+		t.Errorf("Expected suffixed duplicate key, got %s and %s", first, second)
+	}
+	if key := CitationKey(&models.Paper{Source: "dblp", SourceID: "conf/x/1"}); key != "dblpconfx1" {
+		t.Errorf("Expected source-based fallback key, got %s", key)
+	}
+}
+
+func TestExport_UniqueKeysForSameAuthorYearWord(t *testing.T) {
+	var papers []*models.Paper
+	for _, id := range []string{"2401.00001", "2401.00002", "2401.00003"} {
+		papers = append(papers, &models.Paper{
+			Source:           "arxiv",
+			SourceID:         id,
+			Title:            "Attention Is Enough " + id,
+			Authors:          []string{"Ashish Vaswani"},
+			FirstSubmittedAt: time.Date(2017, 6, 12, 0, 0, 0, 0, time.UTC),
+		})
+	}
+
+	out := filepath.Join(t.TempDir(), "dups.bib")
+	if err := NewBibTeXExporter().Export(papers, out); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	entries := bibparser.Parse(string(data))
+	want := []string{"vaswani2017attention", "vaswani2017attentiona", "vaswani2017attentionb"}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d:\n%s", len(want), len(entries), data)
+	}
+	for i, e := range entries {
+		if e.Key != want[i] {
+			t.Errorf("entries[%d].Key = %s, want %s", i, e.Key, want[i])
+		}
+		if e.RawField("eprint") != papers[i].SourceID {
+			t.Errorf("entries[%d] parsed eprint %q, want %q", i, e.RawField("eprint"), papers[i].SourceID)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	exporter "PaperHunter/internal/core/export"
 	"PaperHunter/internal/models"
 )
 
@@ -17,6 +18,10 @@ func NewCSVExporter() *CSVExporter {
 	return &CSVExporter{}
 }
 
+func init() {
+	exporter.MustRegister("csv", func() exporter.Exporter { return NewCSVExporter() })
+}
+
 func (e *CSVExporter) Export(papers []*models.Paper, outputPath string) error {
 	file, err := os.Create(outputPath)
 	if err != nil {
@@ -32,30 +37,12 @@ func (e *CSVExporter) Export(papers []*models.Paper, outputPath string) error {
 	defer writer.Flush()
 
 	// 写入表头
-	headers := []string{
-		"ID", "数据源", "平台ID", "标题", "标题译文", "作者",
-		"摘要", "摘要译文", "分类", "URL", "首次提交日期", "首次发布日期",
-	}
-	if err := writer.Write(headers); err != nil {
+	if err := writer.Write(paperHeaders()); err != nil {
 		return fmt.Errorf("写入表头失败: %w", err)
 	}
 
 	for _, p := range papers {
-		record := []string{
-			fmt.Sprintf("%d", p.ID),
-			p.Source,
-			p.SourceID,
-			p.Title,
-			p.TitleTranslated,
-			strings.Join(p.Authors, "; "),
-			truncate(p.Abstract, 500),
-			truncate(p.AbstractTranslated, 500),
-			strings.Join(p.Categories, "; "),
-			p.URL,
-			formatTime(p.FirstSubmittedAt),
-			formatTime(p.FirstAnnouncedAt),
-		}
-		if err := writer.Write(record); err != nil {
+		if err := writer.Write(paperRecord(p)); err != nil {
 			return fmt.Errorf("写入数据失败: %w", err)
 		}
 	}
@@ -63,6 +50,76 @@ func (e *CSVExporter) Export(papers []*models.Paper, outputPath string) error {
 	return nil
 }
 
+// ExportGroups 按推荐分组导出，每行前置种子论文标题与相似度两列
+func (e *CSVExporter) ExportGroups(groups []exporter.PaperGroup, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return fmt.Errorf("写入 BOM 失败: %w", err)
+	}
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	headers := append([]string{"种子论文", "相似度"}, paperHeaders()...)
+	if err := writer.Write(headers); err != nil {
+		return fmt.Errorf("写入表头失败: %w", err)
+	}
+
+	for _, g := range groups {
+		for _, sp := range g.Papers {
+			if sp == nil {
+				continue
+			}
+			record := append([]string{g.SeedTitle, fmt.Sprintf("%.4f", sp.Similarity)}, paperRecord(&sp.Paper)...)
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("写入数据失败: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func paperHeaders() []string {
+	return []string{
+		"ID", "数据源", "平台ID", "标题", "标题译文", "作者",
+		"摘要", "摘要译文", "分类", "引用数", "URL", "首次提交日期", "首次发布日期",
+		"阅读状态", "收藏",
+	}
+}
+
+func paperRecord(p *models.Paper) []string {
+	return []string{
+		fmt.Sprintf("%d", p.ID),
+		p.Source,
+		p.SourceID,
+		p.Title,
+		p.TitleTranslated,
+		strings.Join(p.Authors, "; "),
+		truncate(p.Abstract, 500),
+		truncate(p.AbstractTranslated, 500),
+		strings.Join(p.Categories, "; "),
+		fmt.Sprintf("%d", p.Citations),
+		p.URL,
+		formatTime(p.FirstSubmittedAt),
+		formatTime(p.FirstAnnouncedAt),
+		p.Status,
+		formatBool(p.Starred),
+	}
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "是"
+	}
+	return "否"
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
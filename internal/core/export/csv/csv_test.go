@@ -0,0 +1,64 @@
+package csv
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+)
+
+func testGroups() []exporter.PaperGroup {
+	return []exporter.PaperGroup{
+		{
+			SeedTitle: "Diffusion Models",
+			Papers: []*models.SimilarPaper{
+				{Paper: models.Paper{Source: "arxiv", SourceID: "2401.00001", Title: "Score Matching"}, Similarity: 0.91},
+				nil,
+				{Paper: models.Paper{Source: "arxiv", SourceID: "2401.00002", Title: "Flow Matching"}, Similarity: 0.8},
+			},
+		},
+		{
+			SeedTitle: "Graph Networks",
+			Papers: []*models.SimilarPaper{
+				{Paper: models.Paper{Source: "acl", SourceID: "2024.acl-long.1", Title: "Message Passing"}, Similarity: 0.75},
+			},
+		},
+	}
+}
+
+func TestExportGroups(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "groups.csv")
+	if err := NewCSVExporter().ExportGroups(testGroups(), out); err != nil {
+		t.Fatalf("ExportGroups() error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+
+	if len(records) != 4 {
+		t.Fatalf("Expected header + 3 papers (nil skipped), got %d rows", len(records))
+	}
+	if records[0][0] != "种子论文" || records[0][1] != "相似度" || records[0][5] != "标题" {
+		t.Errorf("Unexpected header: %v", records[0])
+	}
+	want := [][3]string{
+		{"Diffusion Models", "0.9100", "Score Matching"},
+		{"Diffusion Models", "0.8000", "Flow Matching"},
+		{"Graph Networks", "0.7500", "Message Passing"},
+	}
+	for i, w := range want {
+		row := records[i+1]
+		if row[0] != w[0] || row[1] != w[1] || row[5] != w[2] {
+			t.Errorf("row %d = [%s %s %s], want %v", i+1, row[0], row[1], row[5], w)
+		}
+	}
+}
@@ -9,3 +9,15 @@ type Exporter interface {
 	// Export 导出论文到指定文件
 	Export(papers []*models.Paper, outputPath string) error
 }
+
+// PaperGroup 一组以种子论文为中心的相似论文（对应推荐结果中的 RecommendationGroup）
+type PaperGroup struct {
+	SeedTitle string                 `json:"seed_title"`
+	Papers    []*models.SimilarPaper `json:"papers"`
+}
+
+// GroupExporter 支持保留分组与相似度信息的导出器
+type GroupExporter interface {
+	// ExportGroups 按分组顺序导出论文，附带种子标题与相似度
+	ExportGroups(groups []PaperGroup, outputPath string) error
+}
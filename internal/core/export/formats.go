@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format 导出格式的元数据，各入口的格式校验、输出路径检查与工具参数枚举均由此派生
+type Format struct {
+	Name string
+	// Ext 默认文件扩展名，写入目录（obsidian）或不落地文件的格式为空
+	Ext string
+	// NeedsOutput 写入本地文件或目录，必须提供输出路径
+	NeedsOutput bool
+	// Grouped 支持按推荐分组导出并保留相似度，New 创建的导出器实现 GroupExporter
+	Grouped bool
+	// New 创建文件导出器，由导出子包在 init() 中通过 MustRegister 设置；不落地文件的格式为 nil
+	New func() Exporter
+}
+
+// formats 全部导出格式，顺序即展示顺序
+var formats = []Format{
+	{Name: "csv", Ext: "csv", NeedsOutput: true, Grouped: true},
+	{Name: "json", Ext: "json", NeedsOutput: true, Grouped: true},
+	{Name: "ris", Ext: "ris", NeedsOutput: true},
+	{Name: "bibtex", Ext: "bib", NeedsOutput: true},
+	{Name: "markdown", Ext: "md", NeedsOutput: true},
+	{Name: "obsidian", NeedsOutput: true},
+	{Name: "xlsx", Ext: "xlsx", NeedsOutput: true},
+	{Name: "zotero"},
+	{Name: "feishu"},
+	{Name: "notion"},
+}
+
+// MustRegister 供导出子包 init() 使用，为 formats 中的格式设置导出器构造函数；格式未知或重复注册时 panic
+func MustRegister(name string, newExporter func() Exporter) {
+	for i := range formats {
+		if formats[i].Name != name {
+			continue
+		}
+		if formats[i].New != nil {
+			panic(fmt.Sprintf("export.MustRegister: format %s already registered", name))
+		}
+		formats[i].New = newExporter
+		return
+	}
+	panic(fmt.Sprintf("export.MustRegister: unknown format %s", name))
+}
+
+// Lookup 按名称查找导出格式，不区分大小写
+func Lookup(name string) (Format, bool) {
+	name = strings.ToLower(name)
+	for _, f := range formats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// Names 返回全部导出格式名
+func Names() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// OutputFormatNames 返回必须提供输出路径的格式名
+func OutputFormatNames() []string {
+	var names []string
+	for _, f := range formats {
+		if f.NeedsOutput {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	f, ok := Lookup("Markdown")
+	if !ok || f.Name != "markdown" || f.Ext != "md" || !f.NeedsOutput {
+		t.Errorf("Lookup(Markdown) = %+v, %v", f, ok)
+	}
+	if f, ok := Lookup("obsidian"); !ok || f.Ext != "" {
+		t.Errorf("Expected obsidian to export to a directory, got %+v", f)
+	}
+	if f, ok := Lookup("zotero"); !ok || f.NeedsOutput {
+		t.Errorf("Expected zotero not to need an output path, got %+v", f)
+	}
+	if _, ok := Lookup("pdf"); ok {
+		t.Error("Expected pdf to be unsupported")
+	}
+}
+
+func TestOutputFormatNames(t *testing.T) {
+	got := strings.Join(OutputFormatNames(), "/")
+	if want := "csv/json/ris/bibtex/markdown/obsidian/xlsx"; got != want {
+		t.Errorf("OutputFormatNames() = %s, want %s", got, want)
+	}
+	if len(Names()) != len(formats) {
+		t.Errorf("Names() returned %d formats, want %d", len(Names()), len(formats))
+	}
+}
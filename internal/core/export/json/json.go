@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	exporter "PaperHunter/internal/core/export"
 	"PaperHunter/internal/models"
 )
 
@@ -14,6 +15,10 @@ func NewJSONExporter() *JSONExporter {
 	return &JSONExporter{}
 }
 
+func init() {
+	exporter.MustRegister("json", func() exporter.Exporter { return NewJSONExporter() })
+}
+
 func (e *JSONExporter) Export(papers []*models.Paper, outputPath string) error {
 	file, err := os.Create(outputPath)
 	if err != nil {
@@ -36,3 +41,51 @@ func (e *JSONExporter) Export(papers []*models.Paper, outputPath string) error {
 
 	return nil
 }
+
+// groupedPaper 在论文字段之外附带相似度
+type groupedPaper struct {
+	*models.Paper
+	Similarity float32
+}
+
+// ExportGroups 按推荐分组导出为 {"total","groups":[{"seed_title","papers"}]}
+func (e *JSONExporter) ExportGroups(groups []exporter.PaperGroup, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	total := 0
+	out := make([]map[string]interface{}, 0, len(groups))
+	for _, g := range groups {
+		papers := make([]groupedPaper, 0, len(g.Papers))
+		for _, sp := range g.Papers {
+			if sp == nil {
+				continue
+			}
+			p := sp.Paper
+			papers = append(papers, groupedPaper{Paper: &p, Similarity: sp.Similarity})
+		}
+		total += len(papers)
+		out = append(out, map[string]interface{}{
+			"seed_title": g.SeedTitle,
+			"papers":     papers,
+		})
+	}
+
+	data := map[string]interface{}{
+		"total":  total,
+		"groups": out,
+	}
+
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("写入 JSON 失败: %w", err)
+	}
+
+	return nil
+}
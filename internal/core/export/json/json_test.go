@@ -0,0 +1,68 @@
+package json
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+)
+
+func TestExportGroups(t *testing.T) {
+	groups := []exporter.PaperGroup{
+		{
+			SeedTitle: "Diffusion Models",
+			Papers: []*models.SimilarPaper{
+				{Paper: models.Paper{Source: "arxiv", SourceID: "2401.00001", Title: "Score Matching"}, Similarity: 0.91},
+				nil,
+				{Paper: models.Paper{Source: "arxiv", SourceID: "2401.00002", Title: "Flow Matching"}, Similarity: 0.8},
+			},
+		},
+		{
+			SeedTitle: "Graph Networks",
+			Papers: []*models.SimilarPaper{
+				{Paper: models.Paper{Source: "acl", SourceID: "2024.acl-long.1", Title: "Message Passing"}, Similarity: 0.75},
+			},
+		},
+	}
+	out := filepath.Join(t.TempDir(), "groups.json")
+	if err := NewJSONExporter().ExportGroups(groups, out); err != nil {
+		t.Fatalf("ExportGroups() error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read json: %v", err)
+	}
+
+	var got struct {
+		Total  int `json:"total"`
+		Groups []struct {
+			SeedTitle string `json:"seed_title"`
+			Papers    []struct {
+				Title      string
+				SourceID   string
+				Similarity float32
+			} `json:"papers"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+
+	if got.Total != 3 {
+		t.Errorf("total = %d, want 3 (nil skipped)", got.Total)
+	}
+	if len(got.Groups) != 2 || got.Groups[0].SeedTitle != "Diffusion Models" || got.Groups[1].SeedTitle != "Graph Networks" {
+		t.Fatalf("Unexpected groups: %+v", got.Groups)
+	}
+	first := got.Groups[0].Papers
+	if len(first) != 2 || first[0].Title != "Score Matching" || first[0].Similarity != 0.91 || first[1].Title != "Flow Matching" || first[1].Similarity != 0.8 {
+		t.Errorf("Unexpected first group papers: %+v", first)
+	}
+	second := got.Groups[1].Papers
+	if len(second) != 1 || second[0].SourceID != "2024.acl-long.1" || second[0].Similarity != 0.75 {
+		t.Errorf("Unexpected second group papers: %+v", second)
+	}
+}
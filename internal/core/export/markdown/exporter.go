@@ -0,0 +1,188 @@
+package markdown
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+)
+
+// maxFileNameLength 单篇导出时文件名（不含扩展名）的最大字符数
+const maxFileNameLength = 120
+
+// MarkdownExporter 导出为 Markdown（兼容 Obsidian）。
+// SplitFiles 为 false 时所有论文写入 outputPath 一个文件；为 true 时 outputPath 视为目录，每篇论文一个 .md 文件
+type MarkdownExporter struct {
+	SplitFiles bool
+}
+
+func NewMarkdownExporter(splitFiles bool) *MarkdownExporter {
+	return &MarkdownExporter{SplitFiles: splitFiles}
+}
+
+func init() {
+	exporter.MustRegister("markdown", func() exporter.Exporter { return NewMarkdownExporter(false) })
+}
+
+func (e *MarkdownExporter) Export(papers []*models.Paper, outputPath string) error {
+	if e.SplitFiles {
+		return e.exportSplit(papers, outputPath)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	for _, p := range papers {
+		if p == nil {
+			continue
+		}
+		if _, err := w.WriteString(FormatPaper(p)); err != nil {
+			return fmt.Errorf("写入数据失败: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("写入数据失败: %w", err)
+	}
+	return nil
+}
+
+// exportSplit 每篇论文写入 dir 下以标题命名的文件，重名时追加序号
+func (e *MarkdownExporter) exportSplit(papers []*models.Paper, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+
+	used := make(map[string]bool)
+	for _, p := range papers {
+		if p == nil {
+			continue
+		}
+		base := fileName(p)
+		name := base
+		for i := 2; used[strings.ToLower(name)]; i++ {
+			name = fmt.Sprintf("%s (%d)", base, i)
+		}
+		used[strings.ToLower(name)] = true
+
+		if err := os.WriteFile(filepath.Join(dir, name+".md"), []byte(FormatPaper(p)), 0644); err != nil {
+			return fmt.Errorf("写入文件失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// FormatPaper 将单篇论文序列化为一个 Markdown 小节：链接标题、作者列表、摘要引用块与分类标签
+func FormatPaper(p *models.Paper) string {
+	var sb strings.Builder
+
+	title := strings.Join(strings.Fields(p.Title), " ")
+	if title == "" {
+		title = p.Source + ":" + p.SourceID
+	}
+	if p.URL != "" {
+		fmt.Fprintf(&sb, "## [%s](<%s>)\n\n", escapeLinkText(title), p.URL)
+	} else {
+		fmt.Fprintf(&sb, "## %s\n\n", title)
+	}
+
+	wroteAuthors := false
+	for _, author := range p.Authors {
+		if author = strings.TrimSpace(author); author != "" {
+			fmt.Fprintf(&sb, "- %s\n", author)
+			wroteAuthors = true
+		}
+	}
+	if wroteAuthors {
+		sb.WriteString("\n")
+	}
+
+	if abstract := strings.TrimSpace(p.Abstract); abstract != "" {
+		for _, line := range strings.Split(abstract, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(&sb, "> %s\n", line)
+			} else {
+				sb.WriteString(">\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	var tags []string
+	for _, cat := range p.Categories {
+		if tag := Tag(cat); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > 0 {
+		sb.WriteString(strings.Join(tags, " "))
+		sb.WriteString("\n\n")
+	}
+
+	return sb.String()
+}
+
+// Tag 将分类转为 Obsidian 标签：cs.CL → #cs/CL（嵌套标签），空白转为 -，去掉其他非法字符；
+// 纯数字标签在 Obsidian 中无效，返回空串
+func Tag(category string) string {
+	var sb strings.Builder
+	hasLetter := false
+	for _, r := range strings.TrimSpace(category) {
+		switch {
+		case r == '.':
+			sb.WriteRune('/')
+		case unicode.IsSpace(r):
+			sb.WriteRune('-')
+		case unicode.IsLetter(r):
+			hasLetter = true
+			sb.WriteRune(r)
+		case unicode.IsDigit(r) || r == '_' || r == '-' || r == '/':
+			sb.WriteRune(r)
+		}
+	}
+	tag := strings.Trim(sb.String(), "/-")
+	if tag == "" || !hasLetter {
+		return ""
+	}
+	return "#" + tag
+}
+
+// escapeLinkText 转义链接文本中的方括号，避免破坏 [text](url) 语法
+func escapeLinkText(text string) string {
+	return strings.NewReplacer(`[`, `\[`, `]`, `\]`).Replace(text)
+}
+
+// fileName 由标题生成合法文件名，去掉各平台及 Obsidian 链接中不允许的字符
+func fileName(p *models.Paper) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|#^[]`, r) || unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, p.Title)
+	name = strings.Trim(strings.Join(strings.Fields(name), " "), ". ")
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = strings.TrimSpace(string(runes[:maxFileNameLength]))
+	}
+	if name == "" {
+		name = strings.Trim(strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' {
+				return r
+			}
+			return '_'
+		}, p.Source+"_"+p.SourceID), "._")
+	}
+	if name == "" {
+		name = "paper"
+	}
+	return name
+}
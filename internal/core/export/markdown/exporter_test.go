@@ -0,0 +1,94 @@
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func samplePapers() []*models.Paper {
+	return []*models.Paper{
+		{
+			Source:     "arxiv",
+			SourceID:   "2401.01234",
+			URL:        "https://arxiv.org/abs/2401.01234",
+			Title:      "Attention [Is] Still\nAll You Need",
+			Authors:    []string{"Ashish Vaswani", " ", "Noam Shazeer"},
+			Abstract:   "First line.\nSecond line.",
+			Categories: []string{"cs.CL", "Machine Learning", "2024"},
+		},
+		{
+			Source:   "acl",
+			SourceID: "2023.acl-long.1",
+			Title:    "Attention [Is] Still All You Need",
+		},
+		{
+			Source:   "ssrn",
+			SourceID: "12345",
+		},
+	}
+}
+
+func TestFormatPaper(t *testing.T) {
+	got := FormatPaper(samplePapers()[0])
+	want := "## [Attention \\[Is\\] Still All You Need](<https://arxiv.org/abs/2401.01234>)\n\n" +
+		"- Ashish Vaswani\n- Noam Shazeer\n\n" +
+		"> First line.\n> Second line.\n\n" +
+		"#cs/CL #Machine-Learning\n\n"
+	if got != want {
+		t.Errorf("FormatPaper() =\n%q\nwant\n%q", got, want)
+	}
+
+	// 缺少 URL、作者、摘要时只输出标题
+	if got := FormatPaper(samplePapers()[2]); got != "## ssrn:12345\n\n" {
+		t.Errorf("Unexpected output for bare paper: %q", got)
+	}
+}
+
+func TestExport_Combined(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "papers.md")
+	if err := NewMarkdownExporter(false).Export(append(samplePapers(), nil), out); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if n := strings.Count(string(data), "\n## "); n+1 != 3 || !strings.HasPrefix(string(data), "## ") {
+		t.Errorf("Expected 3 sections, got:\n%s", data)
+	}
+}
+
+func TestExport_Split(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "vault")
+	if err := NewMarkdownExporter(true).Export(samplePapers(), dir); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	// 同名标题追加序号，非法字符替换，无标题时使用 source_sourceID
+	want := []string{"Attention Is Still All You Need (2).md", "Attention Is Still All You Need.md", "ssrn_12345.md"}
+	if strings.Join(names, "|") != strings.Join(want, "|") {
+		t.Errorf("Unexpected files: %v, want %v", names, want)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "Attention Is Still All You Need.md"))
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if string(data) != FormatPaper(samplePapers()[0]) {
+		t.Errorf("Unexpected file content:\n%s", data)
+	}
+}
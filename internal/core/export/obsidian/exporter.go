@@ -0,0 +1,185 @@
+package obsidian
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/core/export/markdown"
+	"PaperHunter/internal/models"
+)
+
+// maxFileNameLength 文件名（不含扩展名）的最大字符数
+const maxFileNameLength = 120
+
+// frontmatter 笔记开头的 YAML 属性，字段顺序即输出顺序
+type frontmatter struct {
+	Title      string   `yaml:"title"`
+	Authors    []string `yaml:"authors"`
+	Date       string   `yaml:"date,omitempty"`
+	Source     string   `yaml:"source"`
+	URL        string   `yaml:"url,omitempty"`
+	Categories []string `yaml:"categories"`
+	Tags       []string `yaml:"tags"`
+}
+
+// ObsidianExporter 导出为 Obsidian 仓库：outputPath 视为目录，每篇论文一个 <sourceID>.md 笔记，
+// 同一分类的论文通过 [[分类]] 双链互相关联
+type ObsidianExporter struct{}
+
+func NewObsidianExporter() *ObsidianExporter {
+	return &ObsidianExporter{}
+}
+
+func init() {
+	exporter.MustRegister("obsidian", func() exporter.Exporter { return NewObsidianExporter() })
+}
+
+func (e *ObsidianExporter) Export(papers []*models.Paper, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+
+	used := make(map[string]bool)
+	for _, p := range papers {
+		if p == nil {
+			continue
+		}
+		base := FileName(p)
+		name := base
+		for i := 2; used[strings.ToLower(name)]; i++ {
+			name = fmt.Sprintf("%s (%d)", base, i)
+		}
+		used[strings.ToLower(name)] = true
+
+		note, err := FormatNote(p)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name+".md"), note, 0644); err != nil {
+			return fmt.Errorf("写入文件失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// FormatNote 生成单篇论文的笔记：YAML frontmatter、摘要正文、分类双链与笔记占位小节
+func FormatNote(p *models.Paper) ([]byte, error) {
+	fm := frontmatter{
+		Title:      strings.Join(strings.Fields(p.Title), " "),
+		Authors:    trimAll(p.Authors),
+		Source:     p.Source,
+		URL:        p.URL,
+		Categories: trimAll(p.Categories),
+		Tags:       []string{},
+	}
+	if fm.Authors == nil {
+		fm.Authors = []string{}
+	}
+	if fm.Categories == nil {
+		fm.Categories = []string{}
+	}
+	if d := paperDate(p); !d.IsZero() {
+		fm.Date = d.Format("2006-01-02")
+	}
+	for _, cat := range fm.Categories {
+		if tag := markdown.Tag(cat); tag != "" {
+			fm.Tags = append(fm.Tags, strings.TrimPrefix(tag, "#"))
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(fm); err != nil {
+		return nil, fmt.Errorf("生成 frontmatter 失败: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("生成 frontmatter 失败: %w", err)
+	}
+	buf.WriteString("---\n\n")
+
+	if abstract := strings.TrimSpace(p.Abstract); abstract != "" {
+		buf.WriteString(abstract)
+		buf.WriteString("\n\n")
+	}
+
+	var links []string
+	for _, cat := range fm.Categories {
+		if link := wikiLink(cat); link != "" {
+			links = append(links, "- "+link)
+		}
+	}
+	if len(links) > 0 {
+		buf.WriteString("## Related Categories\n\n")
+		buf.WriteString(strings.Join(links, "\n"))
+		buf.WriteString("\n\n")
+	}
+
+	buf.WriteString("## Notes\n\n")
+	return buf.Bytes(), nil
+}
+
+// FileName 由 SourceID 生成合法文件名（缺失时使用标题），去掉各平台及 Obsidian 链接中不允许的字符
+func FileName(p *models.Paper) string {
+	for _, s := range []string{p.SourceID, p.Title} {
+		if name := sanitize(s); name != "" {
+			return name
+		}
+	}
+	return "paper"
+}
+
+func sanitize(s string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|#^[]`, r) || unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, s)
+	name = strings.Trim(strings.Join(strings.Fields(name), " "), ". ")
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = strings.TrimSpace(string(runes[:maxFileNameLength]))
+	}
+	return name
+}
+
+// wikiLink 将分类转为 [[分类]] 双链，去掉链接语法中的保留字符
+func wikiLink(category string) string {
+	name := strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]|#^`, r) {
+			return ' '
+		}
+		return r
+	}, category)), " ")
+	if name == "" {
+		return ""
+	}
+	return "[[" + name + "]]"
+}
+
+// paperDate 优先使用首次提交时间，缺失时使用公布时间
+func paperDate(p *models.Paper) time.Time {
+	if !p.FirstSubmittedAt.IsZero() {
+		return p.FirstSubmittedAt
+	}
+	return p.FirstAnnouncedAt
+}
+
+func trimAll(items []string) []string {
+	var out []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
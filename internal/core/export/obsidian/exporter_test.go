@@ -0,0 +1,131 @@
+package obsidian
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"PaperHunter/internal/models"
+)
+
+func samplePapers() []*models.Paper {
+	return []*models.Paper{
+		{
+			Source:           "arxiv",
+			SourceID:         "2401.01234",
+			URL:              "https://arxiv.org/abs/2401.01234",
+			Title:            "Input/Output: \"Quoted\" Title #1",
+			Authors:          []string{"Ashish Vaswani", " ", "Noam Shazeer"},
+			Abstract:         "First line.\nSecond line.",
+			Categories:       []string{"cs.CL", "Machine Learning"},
+			FirstSubmittedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Source:     "dblp",
+			SourceID:   "conf/acl/Smith24",
+			Title:      "Another Paper",
+			Categories: []string{"cs.CL"},
+		},
+		{
+			Source: "ssrn",
+			Title:  "Markets / Prices: A Study",
+		},
+	}
+}
+
+// parseFrontmatter 取出 --- 之间的内容并用 yaml.v3 解析
+func parseFrontmatter(t *testing.T, note []byte) frontmatter {
+	t.Helper()
+	if !bytes.HasPrefix(note, []byte("---\n")) {
+		t.Fatalf("Note does not start with frontmatter:\n%s", note)
+	}
+	end := bytes.Index(note[4:], []byte("\n---\n"))
+	if end < 0 {
+		t.Fatalf("Unterminated frontmatter:\n%s", note)
+	}
+	var fm frontmatter
+	if err := yaml.Unmarshal(note[4:4+end], &fm); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v\n%s", err, note)
+	}
+	return fm
+}
+
+func TestFormatNote_Frontmatter(t *testing.T) {
+	note, err := FormatNote(samplePapers()[0])
+	if err != nil {
+		t.Fatalf("FormatNote() error: %v", err)
+	}
+	fm := parseFrontmatter(t, note)
+	want := frontmatter{
+		Title:      `Input/Output: "Quoted" Title #1`,
+		Authors:    []string{"Ashish Vaswani", "Noam Shazeer"},
+		Date:       "2024-01-15",
+		Source:     "arxiv",
+		URL:        "https://arxiv.org/abs/2401.01234",
+		Categories: []string{"cs.CL", "Machine Learning"},
+		Tags:       []string{"cs/CL", "Machine-Learning"},
+	}
+	if !reflect.DeepEqual(fm, want) {
+		t.Errorf("frontmatter = %+v, want %+v", fm, want)
+	}
+
+	body := string(note)
+	for _, s := range []string{"First line.\nSecond line.\n", "## Related Categories\n\n- [[cs.CL]]\n- [[Machine Learning]]\n", "## Notes\n"} {
+		if !strings.Contains(body, s) {
+			t.Errorf("Expected note to contain %q, got:\n%s", s, body)
+		}
+	}
+
+	// 没有分类时不输出 Related Categories，空列表仍是合法 YAML
+	note, _ = FormatNote(samplePapers()[2])
+	if fm := parseFrontmatter(t, note); fm.Title != "Markets / Prices: A Study" || len(fm.Authors) != 0 {
+		t.Errorf("Unexpected frontmatter for bare paper: %+v", fm)
+	}
+	if strings.Contains(string(note), "Related Categories") {
+		t.Errorf("Expected no Related Categories section, got:\n%s", note)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	papers := samplePapers()
+	cases := []struct {
+		paper *models.Paper
+		want  string
+	}{
+		{papers[0], "2401.01234"},
+		{papers[1], "conf acl Smith24"},
+		{papers[2], "Markets Prices A Study"},
+		{&models.Paper{}, "paper"},
+	}
+	for _, c := range cases {
+		if got := FileName(c.paper); got != c.want {
+			t.Errorf("FileName(%q, %q) = %q, want %q", c.paper.SourceID, c.paper.Title, got, c.want)
+		}
+	}
+}
+
+func TestExport_CreatesDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "vault", "papers")
+	papers := append(samplePapers(), &models.Paper{Source: "acl", SourceID: "2401.01234", Title: "Same ID"}, nil)
+	if err := NewObsidianExporter().Export(papers, dir); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+
+	for _, name := range []string{"2401.01234.md", "2401.01234 (2).md", "conf acl Smith24.md", "Markets Prices A Study.md"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("Expected file %s: %v", name, err)
+			continue
+		}
+		parseFrontmatter(t, data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 4 {
+		t.Errorf("Expected 4 notes, got %d", len(entries))
+	}
+}
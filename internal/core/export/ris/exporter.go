@@ -0,0 +1,128 @@
+package ris
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+)
+
+// maxLineLength RIS 规范中单行最大长度（含 "XX  - " 前缀），超出部分折行续写
+const maxLineLength = 255
+
+type RISExporter struct{}
+
+func NewRISExporter() *RISExporter {
+	return &RISExporter{}
+}
+
+func init() {
+	exporter.MustRegister("ris", func() exporter.Exporter { return NewRISExporter() })
+}
+
+// Export 每篇论文写出一个以 TY 开始、ER 结束的 RIS 记录
+func (e *RISExporter) Export(papers []*models.Paper, outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	for _, p := range papers {
+		if p == nil {
+			continue
+		}
+		if _, err := w.WriteString(FormatPaper(p)); err != nil {
+			return fmt.Errorf("写入数据失败: %w", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("写入数据失败: %w", err)
+	}
+	return nil
+}
+
+// FormatPaper 将单篇论文序列化为 RIS 记录
+func FormatPaper(p *models.Paper) string {
+	var sb strings.Builder
+
+	writeTag(&sb, "TY", referenceType(p.Source))
+	writeTag(&sb, "TI", p.Title)
+	for _, author := range p.Authors {
+		writeTag(&sb, "AU", strings.TrimSpace(author))
+	}
+	writeTag(&sb, "AB", p.Abstract)
+	if !p.FirstSubmittedAt.IsZero() {
+		writeTag(&sb, "PY", p.FirstSubmittedAt.Format("2006"))
+		writeTag(&sb, "DA", p.FirstSubmittedAt.Format("2006/01/02"))
+	}
+	writeTag(&sb, "UR", p.URL)
+	if len(p.Categories) > 0 {
+		writeTag(&sb, "T2", strings.Join(p.Categories, "; "))
+	}
+	if p.Source != "" && p.SourceID != "" {
+		writeTag(&sb, "ID", p.Source+":"+p.SourceID)
+	}
+	sb.WriteString("ER  - \r\n\r\n")
+
+	return sb.String()
+}
+
+// referenceType 按数据源选择 RIS 文献类型
+func referenceType(source string) string {
+	switch strings.ToLower(source) {
+	case "acl", "openreview":
+		return "CONF"
+	case "arxiv", "ssrn":
+		return "UNPB"
+	default:
+		return "GEN"
+	}
+}
+
+// writeTag 写出一个字段，空值跳过；过长的值按单词折行，续行不带标签
+func writeTag(sb *strings.Builder, tag, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+
+	prefix := tag + "  - "
+	lines := wrap(value, maxLineLength-len(prefix))
+	sb.WriteString(prefix)
+	sb.WriteString(lines[0])
+	sb.WriteString("\r\n")
+	for _, line := range lines[1:] {
+		sb.WriteString(line)
+		sb.WriteString("\r\n")
+	}
+}
+
+// wrap 按空格将文本切分为不超过 width 字节的行，单个超长单词单独成行
+func wrap(text string, width int) []string {
+	if len(text) <= width {
+		return []string{text}
+	}
+
+	var lines []string
+	var current strings.Builder
+	for _, word := range strings.Fields(text) {
+		if current.Len() > 0 && current.Len()+1+len(word) > width {
+			lines = append(lines, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteByte(' ')
+		}
+		current.WriteString(word)
+	}
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+	return lines
+}
@@ -0,0 +1,152 @@
+package ris
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+var reTagLine = regexp.MustCompile(`^([A-Z][A-Z0-9])  - ?(.*)$`)
+
+// parseRIS 简易 RIS 解析器：无标签的行视为上一字段的续行
+func parseRIS(content string) []map[string][]string {
+	var records []map[string][]string
+	var current map[string][]string
+	lastTag := ""
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		m := reTagLine.FindStringSubmatch(line)
+		if m == nil {
+			if current != nil && lastTag != "" {
+				values := current[lastTag]
+				values[len(values)-1] += " " + line
+			}
+			continue
+		}
+		tag, value := m[1], m[2]
+		switch tag {
+		case "TY":
+			current = map[string][]string{}
+		case "ER":
+			records = append(records, current)
+			current = nil
+			lastTag = ""
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		current[tag] = append(current[tag], value)
+		lastTag = tag
+	}
+	return records
+}
+
+func samplePapers() []*models.Paper {
+	return []*models.Paper{
+		{
+			Source:           "arxiv",
+			SourceID:         "2401.01234",
+			URL:              "https://arxiv.org/abs/2401.01234",
+			Title:            "Attention Is Still All You Need",
+			Authors:          []string{"Ashish Vaswani", "Noam Shazeer", "Niki Parmar"},
+			Abstract:         strings.Repeat("Transformers scale remarkably well across many domains. ", 20),
+			Categories:       []string{"cs.CL", "cs.LG"},
+			FirstSubmittedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Source:   "acl",
+			SourceID: "2023.acl-long.1",
+			URL:      "https://aclanthology.org/2023.acl-long.1",
+			Title:    "Müller's Survey of Retrieval",
+			Authors:  []string{"Jörg Müller"},
+		},
+	}
+}
+
+func TestFormatPaper_MultiAuthor(t *testing.T) {
+	out := FormatPaper(samplePapers()[0])
+
+	if !strings.HasPrefix(out, "TY  - UNPB\r\n") {
+		t.Errorf("Expected record to start with TY, got %q", out[:20])
+	}
+	if !strings.HasSuffix(out, "ER  - \r\n\r\n") {
+		t.Errorf("Expected record to end with ER")
+	}
+	for _, author := range []string{"Ashish Vaswani", "Noam Shazeer", "Niki Parmar"} {
+		if !strings.Contains(out, "AU  - "+author+"\r\n") {
+			t.Errorf("Missing AU line for %q", author)
+		}
+	}
+	if strings.Count(out, "AU  - ") != 3 {
+		t.Errorf("Expected 3 AU lines, got %d", strings.Count(out, "AU  - "))
+	}
+	for _, line := range []string{"PY  - 2024", "DA  - 2024/01/03", "T2  - cs.CL; cs.LG", "UR  - https://arxiv.org/abs/2401.01234"} {
+		if !strings.Contains(out, line+"\r\n") {
+			t.Errorf("Missing line %q", line)
+		}
+	}
+}
+
+func TestFormatPaper_AbstractWrapping(t *testing.T) {
+	p := samplePapers()[0]
+	out := FormatPaper(p)
+
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("Line exceeds %d bytes: %d", maxLineLength, len(line))
+		}
+	}
+	if strings.Count(out, "AB  - ") != 1 {
+		t.Errorf("Expected exactly one AB tag")
+	}
+
+	records := parseRIS(out)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	expected := strings.Join(strings.Fields(p.Abstract), " ")
+	if records[0]["AB"][0] != expected {
+		t.Errorf("Wrapped abstract not recovered:\n got %q\nwant %q", records[0]["AB"][0], expected)
+	}
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	papers := samplePapers()
+	path := filepath.Join(t.TempDir(), "papers.ris")
+
+	if err := NewRISExporter().Export(papers, path); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+
+	records := parseRIS(string(content))
+	if len(records) != len(papers) {
+		t.Fatalf("Expected %d records, got %d", len(papers), len(records))
+	}
+	for i, p := range papers {
+		if got := records[i]["TI"]; len(got) != 1 || got[0] != p.Title {
+			t.Errorf("records[%d].TI = %v, expected %q", i, got, p.Title)
+		}
+		if got := records[i]["AU"]; strings.Join(got, "|") != strings.Join(p.Authors, "|") {
+			t.Errorf("records[%d].AU = %v, expected %v", i, got, p.Authors)
+		}
+	}
+	if records[1]["TY"][0] != "CONF" {
+		t.Errorf("Expected CONF for acl, got %q", records[1]["TY"][0])
+	}
+	if _, ok := records[1]["PY"]; ok {
+		t.Errorf("Expected no PY when date is missing")
+	}
+}
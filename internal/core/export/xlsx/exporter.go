@@ -0,0 +1,161 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// SheetName 论文所在的工作表名
+const SheetName = "Papers"
+
+const (
+	// maxCellLength Excel 单元格最多容纳的字符数
+	maxCellLength = 32767
+	minColWidth   = 10
+	maxColWidth   = 60
+)
+
+// Headers 表头，与 paperRow 的列一一对应
+var Headers = []string{"Title", "Authors", "Abstract", "Categories", "Source", "SourceID", "URL", "FirstSubmittedAt"}
+
+// XLSXExporter 导出为 Excel 工作簿：表头加粗并冻结，列宽按内容长度设置
+type XLSXExporter struct{}
+
+func NewXLSXExporter() *XLSXExporter {
+	return &XLSXExporter{}
+}
+
+func init() {
+	exporter.MustRegister("xlsx", func() exporter.Exporter { return NewXLSXExporter() })
+}
+
+// Export 以流式写入生成工作簿，数据量较大时内存占用保持稳定
+func (e *XLSXExporter) Export(papers []*models.Paper, outputPath string) error {
+	rows := make([][]string, 0, len(papers))
+	for _, p := range papers {
+		if p != nil {
+			rows = append(rows, paperRow(p))
+		}
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	if err := f.SetSheetName(f.GetSheetName(0), SheetName); err != nil {
+		return fmt.Errorf("创建工作表失败: %w", err)
+	}
+	sw, err := f.NewStreamWriter(SheetName)
+	if err != nil {
+		return fmt.Errorf("创建工作表失败: %w", err)
+	}
+
+	// 流式写入要求列宽与窗格在写入行之前设置
+	for i, width := range columnWidths(rows) {
+		if err := sw.SetColWidth(i+1, i+1, width); err != nil {
+			return fmt.Errorf("设置列宽失败: %w", err)
+		}
+	}
+	if err := sw.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return fmt.Errorf("冻结表头失败: %w", err)
+	}
+
+	boldID, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("创建表头样式失败: %w", err)
+	}
+	header := make([]interface{}, len(Headers))
+	for i, h := range Headers {
+		header[i] = excelize.Cell{StyleID: boldID, Value: h}
+	}
+	if err := sw.SetRow("A1", header); err != nil {
+		return fmt.Errorf("写入表头失败: %w", err)
+	}
+
+	for i, row := range rows {
+		values := make([]interface{}, len(row))
+		for j, v := range row {
+			values[j] = v
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := sw.SetRow(cell, values); err != nil {
+			return fmt.Errorf("写入数据失败: %w", err)
+		}
+	}
+
+	if err := sw.Flush(); err != nil {
+		return fmt.Errorf("写入数据失败: %w", err)
+	}
+	if err := f.SaveAs(outputPath); err != nil {
+		return fmt.Errorf("保存文件失败: %w", err)
+	}
+	return nil
+}
+
+func paperRow(p *models.Paper) []string {
+	submitted := ""
+	if !p.FirstSubmittedAt.IsZero() {
+		submitted = p.FirstSubmittedAt.Format("2006-01-02")
+	}
+	return []string{
+		truncate(p.Title),
+		truncate(strings.Join(p.Authors, ";")),
+		truncate(p.Abstract),
+		truncate(strings.Join(p.Categories, ";")),
+		p.Source,
+		p.SourceID,
+		p.URL,
+		submitted,
+	}
+}
+
+// columnWidths 按每列最长内容（含表头）计算列宽，中日韩字符按两个宽度计，限制在 [minColWidth, maxColWidth]
+func columnWidths(rows [][]string) []float64 {
+	widths := make([]float64, len(Headers))
+	for i, h := range Headers {
+		widths[i] = float64(displayWidth(h))
+	}
+	for _, row := range rows {
+		for i, v := range row {
+			if w := float64(displayWidth(v)); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	for i := range widths {
+		widths[i] = min(max(widths[i]+2, minColWidth), maxColWidth)
+	}
+	return widths
+}
+
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if r >= 0x1100 && utf8.RuneLen(r) >= 3 {
+			width += 2
+		} else {
+			width++
+		}
+		// 超过最大列宽后无需继续统计
+		if width > maxColWidth {
+			break
+		}
+	}
+	return width
+}
+
+// truncate 截断超过 Excel 单元格上限的内容
+func truncate(s string) string {
+	if len(s) <= maxCellLength {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= maxCellLength {
+		return s
+	}
+	return string(runes[:maxCellLength-3]) + "..."
+}
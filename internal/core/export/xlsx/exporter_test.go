@@ -0,0 +1,116 @@
+package xlsx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func samplePapers() []*models.Paper {
+	return []*models.Paper{
+		{
+			Source:           "arxiv",
+			SourceID:         "2401.01234",
+			URL:              "https://arxiv.org/abs/2401.01234",
+			Title:            "Attention Is All You Need",
+			Authors:          []string{"Ashish Vaswani", "Noam Shazeer"},
+			Abstract:         "我们提出了 Transformer。",
+			Categories:       []string{"cs.CL", "cs.LG"},
+			FirstSubmittedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		nil,
+		{
+			Source:   "acl",
+			SourceID: "2023.acl-long.1",
+			URL:      "https://aclanthology.org/2023.acl-long.1",
+			Title:    "Untitled Work Without Metadata",
+		},
+	}
+}
+
+func TestExport_OpensInExcelize(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "papers.xlsx")
+	if err := NewXLSXExporter().Export(samplePapers(), out); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+
+	f, err := excelize.OpenFile(out)
+	if err != nil {
+		t.Fatalf("OpenFile() error: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(SheetName)
+	if err != nil {
+		t.Fatalf("GetRows() error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected header + 2 rows, got %d: %v", len(rows), rows)
+	}
+	if strings.Join(rows[0], ",") != strings.Join(Headers, ",") {
+		t.Errorf("Row 1 = %v, want headers %v", rows[0], Headers)
+	}
+	first := rows[1]
+	if first[0] != "Attention Is All You Need" || first[1] != "Ashish Vaswani;Noam Shazeer" || first[3] != "cs.CL;cs.LG" {
+		t.Errorf("Unexpected first row: %v", first)
+	}
+	if first[2] != "我们提出了 Transformer。" || first[7] != "2024-01-03" {
+		t.Errorf("Unexpected abstract or date: %v", first)
+	}
+
+	// 表头加粗并冻结
+	styleID, err := f.GetCellStyle(SheetName, "A1")
+	if err != nil {
+		t.Fatalf("GetCellStyle() error: %v", err)
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil || style.Font == nil || !style.Font.Bold {
+		t.Errorf("Expected bold header, got %+v (%v)", style, err)
+	}
+	panes, err := f.GetPanes(SheetName)
+	if err != nil || !panes.Freeze || panes.YSplit != 1 {
+		t.Errorf("Expected frozen header row, got %+v (%v)", panes, err)
+	}
+
+	// 列宽随内容变化：标题列宽于来源列
+	titleWidth, _ := f.GetColWidth(SheetName, "A")
+	sourceWidth, _ := f.GetColWidth(SheetName, "E")
+	if titleWidth <= sourceWidth || titleWidth > maxColWidth {
+		t.Errorf("Unexpected column widths: title %.1f, source %.1f", titleWidth, sourceWidth)
+	}
+}
+
+func TestExport_LargeFileSize(t *testing.T) {
+	papers := make([]*models.Paper, 10000)
+	for i := range papers {
+		papers[i] = &models.Paper{
+			Source:           "arxiv",
+			SourceID:         fmt.Sprintf("2401.%05d", i),
+			URL:              fmt.Sprintf("https://arxiv.org/abs/2401.%05d", i),
+			Title:            fmt.Sprintf("Paper %d on scalable retrieval", i),
+			Authors:          []string{"Alice Smith", "Bob Lee", "Carol White"},
+			Abstract:         strings.Repeat("We study large scale retrieval systems. ", 25),
+			Categories:       []string{"cs.IR"},
+			FirstSubmittedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	out := filepath.Join(t.TempDir(), "large.xlsx")
+	if err := NewXLSXExporter().Export(papers, out); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	info, err := os.Stat(out)
+	if err != nil {
+		t.Fatalf("Stat() error: %v", err)
+	}
+	if info.Size() >= 10<<20 {
+		t.Errorf("Expected file smaller than 10 MB, got %d bytes", info.Size())
+	}
+}
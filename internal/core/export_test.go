@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	exporter "PaperHunter/internal/core/export"
+	"PaperHunter/internal/models"
+)
+
+func TestExportFormats_Registered(t *testing.T) {
+	for _, name := range exporter.Names() {
+		f, _ := exporter.Lookup(name)
+		if f.NeedsOutput != (f.New != nil) {
+			t.Errorf("Format %s: NeedsOutput=%v but constructor registered=%v", name, f.NeedsOutput, f.New != nil)
+			continue
+		}
+		if f.New == nil {
+			continue
+		}
+		if _, ok := f.New().(exporter.GroupExporter); ok != f.Grouped {
+			t.Errorf("Format %s: Grouped=%v but GroupExporter implemented=%v", name, f.Grouped, ok)
+		}
+	}
+}
+
+func TestExportPaperGroups_UsesRegistry(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	groups := []exporter.PaperGroup{{
+		SeedTitle: "Seed",
+		Papers:    []*models.SimilarPaper{{Paper: models.Paper{Source: "arxiv", SourceID: "2401.00001", Title: "A"}, Similarity: 0.9}},
+	}}
+	out := filepath.Join(t.TempDir(), "groups.json")
+	if err := a.ExportPaperGroups(context.Background(), "JSON", out, groups); err != nil {
+		t.Fatalf("ExportPaperGroups(JSON) error: %v", err)
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("Expected grouped export file: %v", err)
+	}
+	for _, format := range []string{"ris", "zotero", "pdf"} {
+		if err := a.ExportPaperGroups(context.Background(), format, out, groups); err == nil {
+			t.Errorf("Expected grouped export to reject %s", format)
+		}
+	}
+}
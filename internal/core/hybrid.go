@@ -0,0 +1,161 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// DefaultHybridAlpha 混合搜索中语义分数的默认权重，BM25 权重为 1-alpha
+const DefaultHybridAlpha = 0.6
+
+// hybridCandidateFactor 每路检索召回 TopK 的倍数，保证融合后仍有足够候选
+const hybridCandidateFactor = 3
+
+// searchHybrid 同时执行 BM25 与语义搜索，分数归一化到 [0,1] 后按
+// alpha*semantic + (1-alpha)*bm25 融合。任意一路失败时退化为另一路的结果，
+// 实际执行的各路都失败时返回错误（无查询文本时只执行语义检索）
+func (s *Searcher) searchHybrid(ctx context.Context, opts SearchOptions) ([]*models.SimilarPaper, error) {
+	if opts.Query == "" && len(opts.Examples) == 0 {
+		return nil, fmt.Errorf("混合搜索需要提供查询文本或示例论文")
+	}
+	if opts.TopK <= 0 {
+		opts.TopK = 10
+	}
+
+	alpha := opts.HybridAlpha
+	if alpha <= 0 || alpha > 1 {
+		alpha = DefaultHybridAlpha
+	}
+
+	candidateOpts := opts
+	candidateOpts.TopK = opts.TopK * hybridCandidateFactor
+
+	var bm25Results []*models.SimilarPaper
+	var bm25Err error
+	if opts.Query != "" {
+		irOpts := candidateOpts
+		irOpts.IRAlgorithm = "bm25"
+		bm25Results, bm25Err = s.searchWithIR(ctx, irOpts)
+		if bm25Err != nil {
+			logger.Warn("混合搜索: BM25 检索失败: %v", bm25Err)
+		}
+	}
+
+	semanticResults, semErr := s.searchSemantic(ctx, candidateOpts)
+	if semErr != nil {
+		logger.Warn("混合搜索: 语义检索失败: %v", semErr)
+	}
+
+	if semErr != nil && (opts.Query == "" || bm25Err != nil) {
+		return nil, fmt.Errorf("混合搜索失败: %w", semErr)
+	}
+
+	results := mergeHybridResults(semanticResults, bm25Results, alpha, opts.TopK)
+	logger.Info("混合搜索完成(alpha=%.2f)，语义 %d 篇，BM25 %d 篇，返回 %d 篇", alpha, len(semanticResults), len(bm25Results), len(results))
+	return results, nil
+}
+
+// mergeHybridResults 将两路结果分别做 min-max 归一化后加权融合，按论文去重并返回前 topK
+func mergeHybridResults(semantic, bm25 []*models.SimilarPaper, alpha float64, topK int) []*models.SimilarPaper {
+	type merged struct {
+		paper    models.Paper
+		semantic float64
+		bm25     float64
+	}
+
+	byKey := make(map[string]*merged)
+	order := make([]string, 0, len(semantic)+len(bm25))
+
+	collect := func(results []*models.SimilarPaper, isSemantic bool) {
+		scores := normalizeScores(results)
+		for i, r := range results {
+			if r == nil {
+				continue
+			}
+			key := hybridKey(&r.Paper)
+			m, ok := byKey[key]
+			if !ok {
+				m = &merged{paper: r.Paper}
+				byKey[key] = m
+				order = append(order, key)
+			}
+			// 同一路中重复出现时保留最高分
+			if isSemantic {
+				if scores[i] > m.semantic {
+					m.semantic = scores[i]
+				}
+			} else if scores[i] > m.bm25 {
+				m.bm25 = scores[i]
+			}
+		}
+	}
+	collect(semantic, true)
+	collect(bm25, false)
+
+	results := make([]*models.SimilarPaper, 0, len(order))
+	for _, key := range order {
+		m := byKey[key]
+		score := alpha*m.semantic + (1-alpha)*m.bm25
+		results = append(results, &models.SimilarPaper{
+			Paper:      m.paper,
+			Similarity: float32(score),
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Similarity > results[j].Similarity
+	})
+
+	if topK > 0 && len(results) > topK {
+		results = results[:topK]
+	}
+	return results
+}
+
+// normalizeScores 将分数 min-max 归一化到 [0,1]，所有分数相同时均记为 1
+func normalizeScores(results []*models.SimilarPaper) []float64 {
+	scores := make([]float64, len(results))
+	if len(results) == 0 {
+		return scores
+	}
+
+	minScore, maxScore := 0.0, 0.0
+	first := true
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		v := float64(r.Similarity)
+		if first || v < minScore {
+			minScore = v
+		}
+		if first || v > maxScore {
+			maxScore = v
+		}
+		first = false
+	}
+
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+		if maxScore == minScore {
+			scores[i] = 1
+			continue
+		}
+		scores[i] = (float64(r.Similarity) - minScore) / (maxScore - minScore)
+	}
+	return scores
+}
+
+// hybridKey 论文去重键，优先使用数据库 ID
+func hybridKey(p *models.Paper) string {
+	if p.ID > 0 {
+		return fmt.Sprintf("id:%d", p.ID)
+	}
+	return p.Source + "|" + p.SourceID
+}
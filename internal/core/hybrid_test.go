@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func paper(id int64, title string) models.Paper {
+	return models.Paper{ID: id, Source: "arxiv", SourceID: title, Title: title}
+}
+
+func TestNormalizeScores(t *testing.T) {
+	results := []*models.SimilarPaper{
+		{Paper: paper(1, "a"), Similarity: 12},
+		{Paper: paper(2, "b"), Similarity: 7},
+		{Paper: paper(3, "c"), Similarity: 2},
+	}
+
+	scores := normalizeScores(results)
+	expected := []float64{1, 0.5, 0}
+	for i := range expected {
+		if math.Abs(scores[i]-expected[i]) > 1e-6 {
+			t.Errorf("scores[%d] = %.4f, expected %.4f", i, scores[i], expected[i])
+		}
+	}
+
+	// 所有分数相同时归一化为 1
+	same := []*models.SimilarPaper{
+		{Paper: paper(1, "a"), Similarity: 0.3},
+		{Paper: paper(2, "b"), Similarity: 0.3},
+	}
+	for i, s := range normalizeScores(same) {
+		if s != 1 {
+			t.Errorf("same scores[%d] = %.4f, expected 1", i, s)
+		}
+	}
+
+	if len(normalizeScores(nil)) != 0 {
+		t.Error("Expected empty scores for empty input")
+	}
+}
+
+func TestMergeHybridResults(t *testing.T) {
+	// 语义分数在 [0,1]，BM25 分数量级不同，归一化后才能融合
+	semantic := []*models.SimilarPaper{
+		{Paper: paper(1, "transformer"), Similarity: 0.9},
+		{Paper: paper(2, "diffusion"), Similarity: 0.7},
+		{Paper: paper(3, "graph"), Similarity: 0.5},
+	}
+	bm25 := []*models.SimilarPaper{
+		{Paper: paper(2, "diffusion"), Similarity: 15},
+		{Paper: paper(4, "retrieval"), Similarity: 10},
+		{Paper: paper(1, "transformer"), Similarity: 5},
+	}
+
+	results := mergeHybridResults(semantic, bm25, 0.6, 10)
+
+	// 论文 1 与 2 在两路中都出现，去重后共 4 篇
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 merged results, got %d", len(results))
+	}
+
+	seen := make(map[int64]bool)
+	for _, r := range results {
+		if seen[r.Paper.ID] {
+			t.Errorf("Paper %d appears more than once", r.Paper.ID)
+		}
+		seen[r.Paper.ID] = true
+	}
+
+	// 归一化后:
+	// 语义: 1 -> 1.0, 2 -> 0.5, 3 -> 0.0
+	// BM25: 2 -> 1.0, 4 -> 0.5, 1 -> 0.0
+	// 融合: 1 -> 0.6, 2 -> 0.7, 3 -> 0.0, 4 -> 0.2
+	expected := []struct {
+		id    int64
+		score float64
+	}{
+		{2, 0.7},
+		{1, 0.6},
+		{4, 0.2},
+		{3, 0.0},
+	}
+	for i, e := range expected {
+		if results[i].Paper.ID != e.id {
+			t.Errorf("results[%d].ID = %d, expected %d", i, results[i].Paper.ID, e.id)
+		}
+		if math.Abs(float64(results[i].Similarity)-e.score) > 1e-6 {
+			t.Errorf("results[%d].Similarity = %.4f, expected %.4f", i, results[i].Similarity, e.score)
+		}
+	}
+}
+
+func TestMergeHybridResults_TopKAndSingleSource(t *testing.T) {
+	bm25 := []*models.SimilarPaper{
+		{Paper: paper(1, "a"), Similarity: 3},
+		{Paper: paper(2, "b"), Similarity: 2},
+		{Paper: paper(3, "c"), Similarity: 1},
+	}
+
+	// 语义结果为空时仅依赖 BM25
+	results := mergeHybridResults(nil, bm25, DefaultHybridAlpha, 2)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results after TopK, got %d", len(results))
+	}
+	if results[0].Paper.ID != 1 || results[1].Paper.ID != 2 {
+		t.Errorf("Unexpected order: %d, %d", results[0].Paper.ID, results[1].Paper.ID)
+	}
+	if math.Abs(float64(results[0].Similarity)-(1-DefaultHybridAlpha)) > 1e-6 {
+		t.Errorf("Expected top score %.2f, got %.4f", 1-DefaultHybridAlpha, results[0].Similarity)
+	}
+}
+
+func TestSearchHybrid_ErrorsWhenOnlyLegFails(t *testing.T) {
+	s := newEmbeddingSearcher(t, &fakeEmbedder{}, 3)
+	s.embedder = nil
+
+	// 只有示例论文时不执行 BM25，语义检索失败必须返回错误而不是空结果
+	opts := SearchOptions{Hybrid: true, Examples: []*models.Paper{{Title: "graph"}}}
+	if results, err := s.Search(context.Background(), opts); err == nil {
+		t.Errorf("Expected error when the semantic leg fails, got %d results", len(results))
+	}
+
+	// 有查询文本时 BM25 成功即可退化为 BM25 结果
+	if _, err := s.Search(context.Background(), SearchOptions{Hybrid: true, Query: "graph"}); err != nil {
+		t.Errorf("Expected BM25 fallback, got error: %v", err)
+	}
+}
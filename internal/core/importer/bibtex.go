@@ -0,0 +1,197 @@
+package importer
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/bibtex"
+	"PaperHunter/pkg/logger"
+)
+
+// SourceBibTeX 通过 BibTeX 导入的论文来源标识
+const SourceBibTeX = "bibtex"
+
+// saveBatchSize 每批保存的论文数量，每批结束后回调一次进度
+const saveBatchSize = 50
+
+// PaperSaver 论文保存接口，core.App 实现了该接口
+type PaperSaver interface {
+	SavePapers(ctx context.Context, papers []*models.Paper) (int, error)
+}
+
+// Summary 一次导入的统计信息
+type Summary struct {
+	Parsed  int `json:"parsed"`  // 成功解析为论文的条目数
+	Saved   int `json:"saved"`   // 成功入库的论文数
+	Failed  int `json:"failed"`  // 解析成功但入库失败的论文数
+	Skipped int `json:"skipped"` // 不支持的条目类型或缺少标题的条目数
+}
+
+// Importer BibTeX 批量导入器
+type Importer struct {
+	saver   PaperSaver
+	summary Summary
+}
+
+// NewImporter 创建 BibTeX 导入器
+func NewImporter(saver PaperSaver) *Importer {
+	return &Importer{saver: saver}
+}
+
+// Summary 返回最近一次导入的统计信息
+func (im *Importer) Summary() Summary {
+	return im.summary
+}
+
+// Import 解析 BibTeX 并保存论文，progress 在每批保存后以已处理条目数回调
+// 重复的 source_id 通过 Upsert 更新已有记录
+func (im *Importer) Import(ctx context.Context, reader io.Reader, progress func(int)) ([]*models.Paper, error) {
+	im.summary = Summary{}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("读取 BibTeX 失败: %w", err)
+	}
+
+	entries := bibtex.Parse(string(content))
+	papers := make([]*models.Paper, 0, len(entries))
+	for _, entry := range entries {
+		paper := EntryToPaper(entry)
+		if paper == nil {
+			im.summary.Skipped++
+			continue
+		}
+		papers = append(papers, paper)
+	}
+	im.summary.Parsed = len(papers)
+	logger.Info("BibTeX 解析完成: %d 个条目，%d 篇论文，跳过 %d 个", len(entries), len(papers), im.summary.Skipped)
+
+	if im.saver == nil {
+		return papers, nil
+	}
+
+	for start := 0; start < len(papers); start += saveBatchSize {
+		if err := ctx.Err(); err != nil {
+			return papers, err
+		}
+		end := start + saveBatchSize
+		if end > len(papers) {
+			end = len(papers)
+		}
+
+		saved, err := im.saver.SavePapers(ctx, papers[start:end])
+		if err != nil {
+			return papers, fmt.Errorf("保存论文失败: %w", err)
+		}
+		im.summary.Saved += saved
+		im.summary.Failed += (end - start) - saved
+
+		if progress != nil {
+			progress(end)
+		}
+	}
+
+	logger.Info("BibTeX 导入完成: 保存 %d 篇，失败 %d 篇", im.summary.Saved, im.summary.Failed)
+	return papers, nil
+}
+
+var reArxivID = regexp.MustCompile(`(\d{4}\.\d{4,5})(v\d+)?`)
+
+// EntryToPaper 将 @article/@inproceedings/@misc 条目转换为论文，其他类型或缺少标题时返回 nil
+func EntryToPaper(entry *bibtex.Entry) *models.Paper {
+	switch entry.Type {
+	case "article", "inproceedings", "misc":
+	default:
+		return nil
+	}
+
+	title := entry.Field("title")
+	if title == "" {
+		return nil
+	}
+
+	paper := &models.Paper{
+		Source:   SourceBibTeX,
+		Title:    title,
+		Abstract: entry.Field("abstract"),
+		Authors:  bibtex.ParseAuthors(entry.Field("author")),
+	}
+
+	if year := entry.Field("year"); year != "" {
+		if t, err := time.Parse("2006", year); err == nil {
+			paper.FirstSubmittedAt = t
+			paper.FirstAnnouncedAt = t
+		}
+	}
+
+	venue := entry.Field("booktitle")
+	if venue == "" {
+		venue = entry.Field("journal")
+	}
+	if venue == "" {
+		venue = entry.Field("howpublished")
+	}
+	if venue != "" {
+		paper.Categories = []string{venue}
+	}
+
+	doi := entry.RawField("doi")
+	if doi != "" {
+		paper.Comments = "DOI: " + doi
+	}
+
+	// SourceID 优先使用 DOI，其次 arXiv 编号，最后使用引用键
+	arxivID := ""
+	if strings.EqualFold(entry.RawField("archiveprefix"), "arxiv") || strings.EqualFold(entry.RawField("eprinttype"), "arxiv") {
+		if m := reArxivID.FindStringSubmatch(entry.RawField("eprint")); len(m) > 1 {
+			arxivID = m[1]
+		}
+	}
+	switch {
+	case doi != "":
+		paper.SourceID = "doi:" + strings.ToLower(doi)
+	case arxivID != "":
+		paper.SourceID = "arxiv:" + arxivID
+	case entry.Key != "":
+		paper.SourceID = entry.Key
+	default:
+		// 没有引用键时由标题、作者与年份生成，重复导入同一条目得到相同结果
+		paper.SourceID = "bib_" + entryHash(title, paper.AuthorsCSV(), entry.Field("year"))
+	}
+
+	paper.URL = entry.RawField("url")
+	if paper.URL == "" {
+		switch {
+		case doi != "":
+			paper.URL = "https://doi.org/" + doi
+		case arxivID != "":
+			paper.URL = "https://arxiv.org/abs/" + arxivID
+		default:
+			paper.URL = "bibtex://" + paper.SourceID
+		}
+	}
+
+	return paper
+}
+
+// entryHash 由条目字段生成稳定的 SourceID 片段：各字段小写并只保留字母数字后取 SHA-1 摘要，
+// 与 ACL 解析器的 FallbackHash 一致
+func entryHash(parts ...string) string {
+	normalized := make([]string, len(parts))
+	for i, part := range parts {
+		fields := strings.FieldsFunc(strings.ToLower(part), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		normalized[i] = strings.Join(fields, " ")
+	}
+	sum := sha1.Sum([]byte(strings.Join(normalized, "|")))
+	return hex.EncodeToString(sum[:])[:10]
+}
@@ -0,0 +1,205 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/bibtex"
+)
+
+const sampleBib = `
+% 个人文献库导出
+@comment{jabref-meta: databaseType:bibtex;}
+
+@inproceedings{mueller2023attention,
+  title     = {Linear-Time {Attention} with $\mathcal{O}(n)$ Memory},
+  author    = {M{\"u}ller, J{\"o}rg and Garc\'{i}a, Mar\'ia and 王, 小明},
+  booktitle = {Proceedings of ACL},
+  year      = {2023},
+  doi       = {10.18653/v1/2023.acl-long.1},
+  abstract  = {We propose an attention variant with $O(n)$ memory.}
+}
+
+@article{smith2021,
+  title   = "Scaling Laws for $\alpha$-Divergence Training",
+  author  = "Smith, John and Ångström, Anders",
+  journal = "Journal of Machine Learning Research",
+  year    = 2021
+}
+
+@misc{doe2024survey,
+  title         = {A Survey of Retrieval-Augmented Generation},
+  author        = {Jane Doe},
+  year          = {2024},
+  eprint        = {2401.01234v2},
+  archivePrefix = {arXiv}
+}
+
+@book{knuth1984,
+  title  = {The TeXbook},
+  author = {Donald Knuth},
+  year   = {1984}
+}
+
+@misc{notitle,
+  author = {Anonymous}
+}
+`
+
+// fakeSaver 记录保存调用，并模拟按 source_id 去重的 Upsert 行为
+type fakeSaver struct {
+	bySourceID map[string]*models.Paper
+	nextID     int64
+	failTitle  string
+}
+
+func newFakeSaver() *fakeSaver {
+	return &fakeSaver{bySourceID: make(map[string]*models.Paper)}
+}
+
+func (f *fakeSaver) SavePapers(ctx context.Context, papers []*models.Paper) (int, error) {
+	count := 0
+	for _, p := range papers {
+		if f.failTitle != "" && strings.Contains(p.Title, f.failTitle) {
+			continue
+		}
+		if existing, ok := f.bySourceID[p.Source+"|"+p.SourceID]; ok {
+			p.ID = existing.ID
+		} else {
+			f.nextID++
+			p.ID = f.nextID
+		}
+		f.bySourceID[p.Source+"|"+p.SourceID] = p
+		count++
+	}
+	return count, nil
+}
+
+func TestImport_MultiEntry(t *testing.T) {
+	saver := newFakeSaver()
+	im := NewImporter(saver)
+
+	var progressCalls []int
+	papers, err := im.Import(context.Background(), strings.NewReader(sampleBib), func(n int) {
+		progressCalls = append(progressCalls, n)
+	})
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+
+	if len(papers) != 3 {
+		t.Fatalf("Expected 3 papers, got %d", len(papers))
+	}
+
+	summary := im.Summary()
+	if summary.Parsed != 3 || summary.Saved != 3 || summary.Failed != 0 || summary.Skipped != 2 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+	if len(progressCalls) == 0 || progressCalls[len(progressCalls)-1] != 3 {
+		t.Errorf("Expected final progress 3, got %v", progressCalls)
+	}
+
+	// Unicode 作者名（LaTeX 重音与原生 UTF-8 都应正确处理）
+	first := papers[0]
+	expectedAuthors := []string{"Jörg Müller", "María García", "小明 王"}
+	if len(first.Authors) != len(expectedAuthors) {
+		t.Fatalf("Expected %d authors, got %v", len(expectedAuthors), first.Authors)
+	}
+	for i, a := range expectedAuthors {
+		if first.Authors[i] != a {
+			t.Errorf("Author[%d] = %q, expected %q", i, first.Authors[i], a)
+		}
+	}
+	if papers[1].Authors[1] != "Anders Ångström" {
+		t.Errorf("Expected UTF-8 author preserved, got %q", papers[1].Authors[1])
+	}
+
+	// 标题中的 LaTeX 数学
+	if first.Title != "Linear-Time Attention with O(n) Memory" {
+		t.Errorf("Unexpected title: %q", first.Title)
+	}
+	if papers[1].Title != "Scaling Laws for alpha-Divergence Training" {
+		t.Errorf("Unexpected title: %q", papers[1].Title)
+	}
+
+	// 标识与链接
+	if first.SourceID != "doi:10.18653/v1/2023.acl-long.1" || first.URL != "https://doi.org/10.18653/v1/2023.acl-long.1" {
+		t.Errorf("Unexpected DOI mapping: source_id=%q url=%q", first.SourceID, first.URL)
+	}
+	if papers[1].SourceID != "smith2021" || papers[1].Categories[0] != "Journal of Machine Learning Research" {
+		t.Errorf("Unexpected article mapping: %+v", papers[1])
+	}
+	if papers[2].SourceID != "arxiv:2401.01234" || papers[2].URL != "https://arxiv.org/abs/2401.01234" {
+		t.Errorf("Unexpected arXiv mapping: source_id=%q url=%q", papers[2].SourceID, papers[2].URL)
+	}
+	if papers[1].FirstSubmittedAt.Year() != 2021 {
+		t.Errorf("Expected year 2021, got %d", papers[1].FirstSubmittedAt.Year())
+	}
+}
+
+func TestImport_MissingAbstract(t *testing.T) {
+	im := NewImporter(newFakeSaver())
+	papers, err := im.Import(context.Background(), strings.NewReader(sampleBib), nil)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+
+	// 缺少摘要的条目仍然导入，摘要为空
+	for _, p := range papers[1:] {
+		if p.Abstract != "" {
+			t.Errorf("Expected empty abstract for %q, got %q", p.Title, p.Abstract)
+		}
+	}
+	if papers[0].Abstract != "We propose an attention variant with O(n) memory." {
+		t.Errorf("Unexpected abstract: %q", papers[0].Abstract)
+	}
+}
+
+func TestImport_DuplicatesAndFailures(t *testing.T) {
+	saver := newFakeSaver()
+	saver.failTitle = "Survey"
+	im := NewImporter(saver)
+
+	// 重复导入同一文件，Upsert 不产生新记录
+	if _, err := im.Import(context.Background(), strings.NewReader(sampleBib), nil); err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if _, err := im.Import(context.Background(), strings.NewReader(sampleBib), nil); err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+
+	if len(saver.bySourceID) != 2 {
+		t.Errorf("Expected 2 unique papers stored, got %d", len(saver.bySourceID))
+	}
+
+	summary := im.Summary()
+	if summary.Saved != 2 || summary.Failed != 1 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+}
+
+func TestEntryToPaper_StableFallbackID(t *testing.T) {
+	const noKey = `@misc{,
+  title  = {Keyless Entry},
+  author = {Jane Doe},
+  year   = {2020}
+}`
+	parse := func(src string) *models.Paper {
+		t.Helper()
+		entries := bibtex.Parse(src)
+		if len(entries) != 1 || entries[0].Key != "" {
+			t.Fatalf("Expected one keyless entry, got %+v", entries)
+		}
+		return EntryToPaper(entries[0])
+	}
+
+	first, second := parse(noKey), parse(noKey)
+	if !strings.HasPrefix(first.SourceID, "bib_") || first.SourceID != second.SourceID {
+		t.Errorf("Expected a stable bib_ SourceID, got %q and %q", first.SourceID, second.SourceID)
+	}
+	if other := parse(strings.Replace(noKey, "2020", "2021", 1)); other.SourceID == first.SourceID {
+		t.Errorf("Expected a different year to change the SourceID, got %q", other.SourceID)
+	}
+}
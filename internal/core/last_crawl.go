@@ -0,0 +1,84 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// DefaultStatusDir 默认爬取状态目录 ~/.quicksearch/status
+func DefaultStatusDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".quicksearch", "status")
+	}
+	return filepath.Join(home, ".quicksearch", "status")
+}
+
+// EnableLastCrawlTracking 在 dir 下记录各平台最近一次爬取到的最新公布时间，供增量爬取（Query.Since）使用；
+// dir 为空时关闭
+func (a *App) EnableLastCrawlTracking(dir string) {
+	a.statusDir = dir
+}
+
+// lastCrawlFile 平台最近一次爬取进度文件，内容为 RFC3339 时间
+func (a *App) lastCrawlFile(platformName string) string {
+	return filepath.Join(a.statusDir, fmt.Sprintf("last_crawl_%s.txt", platformName))
+}
+
+// LastCrawl 返回平台最近一次成功爬取到的最新论文公布时间，未启用记录或没有记录时返回零值
+func (a *App) LastCrawl(platformName string) time.Time {
+	if a.statusDir == "" {
+		return time.Time{}
+	}
+	data, err := os.ReadFile(a.lastCrawlFile(platformName))
+	if err != nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		logger.Warn("爬取进度文件格式错误 (%s): %v", platformName, err)
+		return time.Time{}
+	}
+	return t
+}
+
+// recordLastCrawl 记录本次爬取结果中最新的公布时间（没有公布时间时取提交时间，都没有时取爬取开始时间）；
+// 只向后推进，避免指定了较早日期范围的爬取把进度倒回去
+func (a *App) recordLastCrawl(platformName string, papers []*models.Paper, started time.Time) {
+	if a.statusDir == "" || len(papers) == 0 {
+		return
+	}
+	var latest time.Time
+	for _, p := range papers {
+		if p == nil {
+			continue
+		}
+		t := p.FirstAnnouncedAt
+		if t.IsZero() {
+			t = p.FirstSubmittedAt
+		}
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	if latest.IsZero() {
+		latest = started
+	}
+	if !latest.After(a.LastCrawl(platformName)) {
+		return
+	}
+
+	if err := os.MkdirAll(a.statusDir, 0o755); err != nil {
+		logger.Warn("创建爬取状态目录失败: %v", err)
+		return
+	}
+	if err := os.WriteFile(a.lastCrawlFile(platformName), []byte(latest.Format(time.RFC3339)), 0o644); err != nil {
+		logger.Warn("保存爬取进度失败 (%s): %v", platformName, err)
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+func init() {
+	MustRegister(Provider{
+		Name:          "stub-lastcrawl",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return &stubPlatform{n: 4}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func TestRecordLastCrawl_OnlyMovesForward(t *testing.T) {
+	a := &App{}
+	started := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	a.recordLastCrawl("arxiv", []*models.Paper{{FirstAnnouncedAt: started}}, started)
+	if got := a.LastCrawl("arxiv"); !got.IsZero() {
+		t.Errorf("Expected no record when tracking is disabled, got %v", got)
+	}
+
+	a.EnableLastCrawlTracking(t.TempDir())
+	march := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	a.recordLastCrawl("arxiv", []*models.Paper{
+		{FirstAnnouncedAt: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		nil,
+		{FirstAnnouncedAt: march},
+	}, started)
+	if got := a.LastCrawl("arxiv"); !got.Equal(march) {
+		t.Errorf("LastCrawl() = %v, want latest announcement %v", got, march)
+	}
+
+	// 较早日期范围的爬取不会把进度倒回去
+	a.recordLastCrawl("arxiv", []*models.Paper{{FirstAnnouncedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}}, started)
+	if got := a.LastCrawl("arxiv"); !got.Equal(march) {
+		t.Errorf("LastCrawl() = %v after older crawl, want %v", got, march)
+	}
+
+	// 没有日期的论文以爬取开始时间记录
+	a.recordLastCrawl("acl", []*models.Paper{{Title: "undated"}}, started)
+	if got := a.LastCrawl("acl"); !got.Equal(started) {
+		t.Errorf("LastCrawl(acl) = %v, want crawl start %v", got, started)
+	}
+}
+
+func TestCrawl_OnlySinceLastCrawlsAdvanceProgress(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	a.platformCfg = map[string]platform.Config{}
+	a.EnableLastCrawlTracking(t.TempDir())
+	ctx := context.Background()
+
+	// 普通爬取（其他关键词）不推进进度
+	if _, err := a.Crawl(ctx, "stub-lastcrawl", platform.Query{Keywords: []string{"other"}}); err != nil {
+		t.Fatalf("Crawl() error: %v", err)
+	}
+	if got := a.LastCrawl("stub-lastcrawl"); !got.IsZero() {
+		t.Errorf("Expected no progress after a non-incremental crawl, got %v", got)
+	}
+
+	// 达到 Limit 的增量爬取可能被截断，不推进进度
+	if _, err := a.Crawl(ctx, "stub-lastcrawl", platform.Query{SinceLast: true, Limit: 4}); err != nil {
+		t.Fatalf("Crawl() error: %v", err)
+	}
+	if got := a.LastCrawl("stub-lastcrawl"); !got.IsZero() {
+		t.Errorf("Expected no progress after a truncated crawl, got %v", got)
+	}
+
+	if _, err := a.Crawl(ctx, "stub-lastcrawl", platform.Query{SinceLast: true, Limit: 10}); err != nil {
+		t.Fatalf("Crawl() error: %v", err)
+	}
+	if got := a.LastCrawl("stub-lastcrawl"); got.IsZero() {
+		t.Error("Expected progress after a complete incremental crawl")
+	}
+}
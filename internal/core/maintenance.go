@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// SourceIDChange 一篇论文的 SourceID 调整
+type SourceIDChange struct {
+	PaperID    int64  `json:"paperId"`
+	Title      string `json:"title"`
+	OldID      string `json:"oldId"`
+	NewID      string `json:"newId"`
+	MergedInto int64  `json:"mergedInto,omitempty"` // 非 0 表示与该论文重复，将被软删除
+}
+
+// NormalizeReport SourceID 规范化的结果，DryRun 时只包含预览不做修改
+type NormalizeReport struct {
+	Source  string           `json:"source"`
+	DryRun  bool             `json:"dryRun"`
+	Scanned int              `json:"scanned"`
+	Renamed int              `json:"renamed"`
+	Merged  int              `json:"merged"`
+	Failed  int              `json:"failed"`
+	Changes []SourceIDChange `json:"changes"`
+}
+
+// NormalizeSourceIDs 用 recompute 重新计算 source 平台下所有论文的 SourceID：
+// 新 ID 相同的论文视为重复，保留一篇（优先已是新 ID 的，其次最近更新的），其余软删除，
+// 可通过 UndeletePapers 恢复；dryRun 为 true 时只返回预览
+func (a *App) NormalizeSourceIDs(ctx context.Context, source string, recompute func(*models.Paper) string, dryRun bool) (*NormalizeReport, error) {
+	papers, err := a.db.GetPapersByConditions([]string{"source = ?"}, []interface{}{source}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+	sort.Slice(papers, func(i, j int) bool { return papers[i].ID < papers[j].ID })
+
+	report := &NormalizeReport{Source: source, DryRun: dryRun, Scanned: len(papers), Changes: []SourceIDChange{}}
+
+	var order []string
+	groups := make(map[string][]*models.Paper)
+	for _, p := range papers {
+		newID := recompute(p)
+		if newID == "" {
+			continue
+		}
+		if _, ok := groups[newID]; !ok {
+			order = append(order, newID)
+		}
+		groups[newID] = append(groups[newID], p)
+	}
+
+	var renames, merges []SourceIDChange
+	for _, newID := range order {
+		group := groups[newID]
+		keeper := pickKeeper(group, newID)
+		for _, p := range group {
+			if p == keeper {
+				continue
+			}
+			merges = append(merges, SourceIDChange{PaperID: p.ID, Title: p.Title, OldID: p.SourceID, NewID: newID, MergedInto: keeper.ID})
+		}
+		if keeper.SourceID != newID {
+			renames = append(renames, SourceIDChange{PaperID: keeper.ID, Title: keeper.Title, OldID: keeper.SourceID, NewID: newID})
+		}
+	}
+
+	report.Changes = append(append(report.Changes, renames...), merges...)
+	if dryRun {
+		report.Renamed, report.Merged = len(renames), len(merges)
+		logger.Info("[%s] SourceID 规范化预览: 扫描 %d 篇，将更新 %d 篇，合并重复 %d 篇", source, len(papers), len(renames), len(merges))
+		return report, nil
+	}
+
+	// 先软删除重复论文，再更新保留论文的 SourceID
+	for _, c := range merges {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if _, err := a.db.DeletePapers([]string{"id = ?"}, []interface{}{c.PaperID}); err != nil {
+			logger.Warn("合并重复论文失败 [id=%d]: %v", c.PaperID, err)
+			report.Failed++
+			continue
+		}
+		report.Merged++
+	}
+	for _, c := range renames {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if err := a.db.UpdateSourceID(c.PaperID, c.NewID); err != nil {
+			logger.Warn("更新 SourceID 失败 [id=%d, %s -> %s]: %v", c.PaperID, c.OldID, c.NewID, err)
+			report.Failed++
+			continue
+		}
+		report.Renamed++
+	}
+
+	if (report.Renamed > 0 || report.Merged > 0) && a.searcher != nil {
+		a.searcher.InvalidateIRIndex()
+	}
+	logger.Info("[%s] SourceID 规范化完成: 更新 %d 篇，合并重复 %d 篇，失败 %d 篇", source, report.Renamed, report.Merged, report.Failed)
+	return report, nil
+}
+
+// pickKeeper 选出重复论文中保留的一篇：已使用新 ID 的优先，其次最近更新的，再次 ID 最小的
+func pickKeeper(group []*models.Paper, newID string) *models.Paper {
+	keeper := group[0]
+	for _, p := range group[1:] {
+		switch {
+		case keeper.SourceID == newID:
+			return keeper
+		case p.SourceID == newID:
+			keeper = p
+		case p.UpdatedAt.After(keeper.UpdatedAt):
+			keeper = p
+		}
+	}
+	return keeper
+}
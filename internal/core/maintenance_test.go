@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func TestNormalizeSourceIDs_MergesDuplicates(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+
+	seed := []*models.Paper{
+		{Source: "acl", SourceID: "acl_attention_111", URL: "u1", Title: "Attention"},
+		{Source: "acl", SourceID: "acl_attention_222", URL: "u2", Title: "Attention"},
+		{Source: "acl", SourceID: "acl_parsing", URL: "u3", Title: "Parsing"},
+		{Source: "acl", SourceID: "acl_parsing_333", URL: "u4", Title: "Parsing"},
+		{Source: "acl", SourceID: "acl_untitled", URL: "u5", Title: ""},
+		{Source: "arxiv", SourceID: "2401.00001", URL: "u6", Title: "Attention"},
+	}
+	for _, p := range seed {
+		id, err := a.db.Upsert(p)
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		p.ID = id
+	}
+
+	recompute := func(p *models.Paper) string {
+		if p.Title == "" {
+			return p.SourceID
+		}
+		return "acl_" + strings.ToLower(p.Title)
+	}
+
+	// 预览不修改数据库
+	preview, err := a.NormalizeSourceIDs(context.Background(), "acl", recompute, true)
+	if err != nil {
+		t.Fatalf("NormalizeSourceIDs(dryRun) error: %v", err)
+	}
+	if preview.Scanned != 5 || preview.Renamed != 1 || preview.Merged != 2 || len(preview.Changes) != 3 {
+		t.Errorf("Unexpected preview: %+v", preview)
+	}
+	if n, _ := a.db.CountPapers([]string{"source = ?"}, []interface{}{"acl"}); n != 5 {
+		t.Errorf("Dry run should not modify papers, got %d ACL papers", n)
+	}
+
+	report, err := a.NormalizeSourceIDs(context.Background(), "acl", recompute, false)
+	if err != nil {
+		t.Fatalf("NormalizeSourceIDs() error: %v", err)
+	}
+	if report.Renamed != 1 || report.Merged != 2 || report.Failed != 0 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	papers, err := a.db.GetPapersByConditions([]string{"source = ?"}, []interface{}{"acl"}, 0)
+	if err != nil {
+		t.Fatalf("GetPapersByConditions() error: %v", err)
+	}
+	got := make(map[string]int64)
+	for _, p := range papers {
+		got[p.SourceID] = p.ID
+	}
+	// 更新时间相同时保留 ID 最小的论文并改为新 ID，已是新 ID 的论文保持不变
+	if len(got) != 3 || got["acl_attention"] != seed[0].ID || got["acl_parsing"] != seed[2].ID || got["acl_untitled"] == 0 {
+		t.Errorf("Unexpected papers after normalization: %v", got)
+	}
+
+	// 再次执行没有任何变化
+	again, err := a.NormalizeSourceIDs(context.Background(), "acl", recompute, false)
+	if err != nil || again.Renamed != 0 || again.Merged != 0 {
+		t.Errorf("Expected idempotent normalization, got %+v (%v)", again, err)
+	}
+}
+
+func TestPickKeeper(t *testing.T) {
+	older := &models.Paper{ID: 1, SourceID: "a_1", UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newer := &models.Paper{ID: 2, SourceID: "a_2", UpdatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+	stable := &models.Paper{ID: 3, SourceID: "a", UpdatedAt: older.UpdatedAt}
+
+	if got := pickKeeper([]*models.Paper{older, newer}, "a"); got != newer {
+		t.Errorf("Expected most recently updated paper, got id=%d", got.ID)
+	}
+	if got := pickKeeper([]*models.Paper{older, newer, stable}, "a"); got != stable {
+		t.Errorf("Expected paper already using the new ID, got id=%d", got.ID)
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/metrics"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+type stubConfig struct{}
+
+func (stubConfig) Validate() error    { return nil }
+func (stubConfig) RateLimit() float64 { return 0 }
+
+// stubPlatform 每次搜索返回固定数量的论文
+type stubPlatform struct {
+	n int
+}
+
+func (p *stubPlatform) Name() string               { return "stub" }
+func (p *stubPlatform) GetConfig() platform.Config { return stubConfig{} }
+func (p *stubPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	papers := make([]*models.Paper, p.n)
+	for i := range papers {
+		papers[i] = &models.Paper{Source: "stub", SourceID: fmt.Sprintf("stub-%d", i), URL: fmt.Sprintf("https://example.com/stub-%d", i), Title: fmt.Sprintf("Stub Paper %d", i)}
+	}
+	return platform.Result{Papers: papers}, nil
+}
+
+func counterValue(t *testing.T, name string) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := metrics.PapersCrawled.WithLabelValues(name).Write(&m); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func init() {
+	MustRegister(Provider{
+		Name:          "stub-metrics",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return &stubPlatform{n: 3}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func TestCrawlIncrementsMetrics(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	a.platformCfg = map[string]platform.Config{}
+
+	before := counterValue(t, "stub-metrics")
+	count, err := a.Crawl(context.Background(), "stub-metrics", platform.Query{})
+	if err != nil {
+		t.Fatalf("Crawl() error: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("Crawl() = %d, want 3", count)
+	}
+	if got := counterValue(t, "stub-metrics"); got != before+3 {
+		t.Errorf("papers_crawled_total{platform=stub-metrics} = %v, want %v", got, before+3)
+	}
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// SetPaperNote 按 source + sourceID 设置论文私人笔记，note 为空白时删除笔记
+func (a *App) SetPaperNote(ctx context.Context, source, sourceID, note string) error {
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(note) == "" {
+		logger.Debug("删除笔记: %s/%s", source, sourceID)
+		return a.db.DeleteNote(paper.ID)
+	}
+	logger.Debug("设置笔记: %s/%s", source, sourceID)
+	return a.db.SetNote(paper.ID, note)
+}
+
+// GetPaperNote 返回论文私人笔记，没有笔记时为空字符串
+func (a *App) GetPaperNote(ctx context.Context, source, sourceID string) (string, error) {
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return "", err
+	}
+	return a.db.GetNote(paper.ID)
+}
+
+// SearchNotes 按子串搜索私人笔记，limit <= 0 表示不限
+func (a *App) SearchNotes(ctx context.Context, query string, limit int) ([]*models.NotedPaper, error) {
+	return a.db.SearchNotes(query, limit)
+}
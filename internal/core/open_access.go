@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"PaperHunter/pkg/enrich/unpaywall"
+	"PaperHunter/pkg/logger"
+)
+
+// OpenAccessLookup 按 DOI 查询开放获取链接，由 unpaywall.Client 实现
+type OpenAccessLookup interface {
+	LookupPDF(ctx context.Context, doi string) (string, error)
+}
+
+// EnableUnpaywall 启用 Unpaywall 开放获取链接查询，cfg.Email 为空时关闭
+func (a *App) EnableUnpaywall(cfg UnpaywallConfig) {
+	if cfg.Email == "" {
+		a.oaLookup = nil
+		return
+	}
+	a.oaLookup = unpaywall.NewClient(cfg.Email)
+}
+
+// EnrichOpenAccessURLs 为满足条件、带 DOI（备注或 doi.org 链接）但缺少开放获取链接的论文查询 Unpaywall 并保存；
+// 单篇查询失败只计入 Failed，ctx 取消时返回已完成的部分
+func (a *App) EnrichOpenAccessURLs(ctx context.Context, conditions []string, params []interface{}) (*EnrichReport, error) {
+	if a.oaLookup == nil {
+		return nil, fmt.Errorf("未配置 Unpaywall 联系邮箱（unpaywall.email）")
+	}
+	conditions = append(append([]string{}, conditions...), "doi != ''", "open_access_url = ''")
+	papers, err := a.db.GetPapersByConditions(conditions, params, 0)
+	if err != nil {
+		return nil, fmt.Errorf("查询论文失败: %w", err)
+	}
+
+	report := &EnrichReport{}
+	for _, p := range papers {
+		doi := p.DOI()
+		if doi == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Scanned++
+
+		pdf, err := a.oaLookup.LookupPDF(ctx, doi)
+		if err != nil {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			logger.Warn("Unpaywall 查询失败 [%s:%s]: %v", p.Source, p.SourceID, err)
+			report.Failed++
+			continue
+		}
+		if pdf == "" {
+			continue
+		}
+		report.Matched++
+
+		p.OpenAccessURL = pdf
+		if _, err := a.db.Upsert(p); err != nil {
+			logger.Warn("保存开放获取链接失败 [%s:%s]: %v", p.Source, p.SourceID, err)
+			report.Failed++
+			continue
+		}
+		report.Updated++
+	}
+	logger.Info("Unpaywall 查询完成: 待查询 %d 篇，找到 %d 篇，更新 %d 篇，失败 %d 篇", report.Scanned, report.Matched, report.Updated, report.Failed)
+	return report, nil
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeOALookup struct {
+	pdfs   map[string]string
+	failed map[string]bool
+	calls  []string
+}
+
+func (f *fakeOALookup) LookupPDF(_ context.Context, doi string) (string, error) {
+	f.calls = append(f.calls, doi)
+	if f.failed[doi] {
+		return "", errors.New("unpaywall unavailable")
+	}
+	return f.pdfs[doi], nil
+}
+
+func TestEnrichOpenAccessURLs(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	papers := newPapers(6)
+	papers[0].Comments = "DOI: 10.1/open"
+	papers[1].Comments = "DOI: 10.1/closed"
+	papers[2].Comments = "DOI: 10.1/broken"
+	// 已有开放获取链接或没有 DOI 的论文不查询
+	papers[3].Comments = "DOI: 10.1/known"
+	papers[3].OpenAccessURL = "https://example.org/known.pdf"
+	// doi.org 链接中的 DOI 同样查询
+	papers[5].URL = "https://doi.org/10.1/Linked"
+	for _, p := range papers {
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+
+	if _, err := a.EnrichOpenAccessURLs(context.Background(), nil, nil); err == nil {
+		t.Error("Expected error when Unpaywall is not configured")
+	}
+
+	lookup := &fakeOALookup{
+		pdfs:   map[string]string{"10.1/open": "https://example.org/open.pdf", "10.1/linked": "https://example.org/linked.pdf"},
+		failed: map[string]bool{"10.1/broken": true},
+	}
+	a.oaLookup = lookup
+
+	report, err := a.EnrichOpenAccessURLs(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("EnrichOpenAccessURLs() error: %v", err)
+	}
+	want := EnrichReport{Scanned: 4, Matched: 2, Updated: 2, Failed: 1}
+	if *report != want {
+		t.Errorf("Report = %+v, want %+v", *report, want)
+	}
+	if len(lookup.calls) != 4 {
+		t.Errorf("Expected 4 lookups, got %v", lookup.calls)
+	}
+
+	got, err := a.db.GetPapersByConditions([]string{"source_id = ?"}, []interface{}{papers[0].SourceID}, 1)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("GetPapersByConditions() = %v, %v", got, err)
+	}
+	if got[0].OpenAccessURL != "https://example.org/open.pdf" {
+		t.Errorf("OpenAccessURL = %q", got[0].OpenAccessURL)
+	}
+
+	// 已补全的论文再次运行时跳过
+	lookup.calls = nil
+	if _, err := a.EnrichOpenAccessURLs(context.Background(), nil, nil); err != nil {
+		t.Fatalf("EnrichOpenAccessURLs() second run error: %v", err)
+	}
+	for _, doi := range lookup.calls {
+		if doi == "10.1/open" {
+			t.Errorf("Paper with OpenAccessURL should be skipped, got calls %v", lookup.calls)
+		}
+	}
+}
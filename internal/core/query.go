@@ -0,0 +1,67 @@
+package core
+
+import (
+	"PaperHunter/internal/platform"
+)
+
+// BuildQuery 将爬取参数（桌面端与 HTTP 服务共用的 JSON 格式）转换为平台查询：
+// keywords、categories、dateFrom、dateTo、limit、sortBy、sortOrder 对所有平台通用；
+// OpenReview 使用 venueId 作为 categories，并支持 minRating、decision 评审过滤；
+// since_last 为 true 时只获取该平台上次爬取之后公布的论文
+func (a *App) BuildQuery(platformName string, params map[string]interface{}) platform.Query {
+	query := platform.Query{}
+
+	if keywords, ok := params["keywords"].([]interface{}); ok {
+		for _, k := range keywords {
+			if keyword, ok := k.(string); ok {
+				query.Keywords = append(query.Keywords, keyword)
+			}
+		}
+	}
+	if categories, ok := params["categories"].([]interface{}); ok {
+		for _, c := range categories {
+			if category, ok := c.(string); ok {
+				query.Categories = append(query.Categories, category)
+			}
+		}
+	}
+	if dateFrom, ok := params["dateFrom"].(string); ok {
+		query.DateFrom = dateFrom
+	}
+	if dateTo, ok := params["dateTo"].(string); ok {
+		query.DateTo = dateTo
+	}
+	if limit, ok := params["limit"].(float64); ok {
+		query.Limit = int(limit)
+	}
+
+	// 排序：sortBy 为 submittedDate/lastUpdatedDate/relevance，sortOrder 为 asc/desc
+	if sortBy, ok := params["sortBy"].(string); ok {
+		query.SortBy = sortBy
+	}
+	if sortOrder, ok := params["sortOrder"].(string); ok {
+		query.SortOrder = sortOrder
+	}
+
+	// 增量爬取
+	if sinceLast, ok := params["since_last"].(bool); ok && sinceLast {
+		query.SinceLast = true
+		query.Since = a.LastCrawl(platformName)
+	}
+
+	// 平台特定参数
+	if platformName == "openreview" {
+		if venueID, ok := params["venueId"].(string); ok {
+			query.Categories = []string{venueID}
+		}
+		// 评审过滤条件仅对 OpenReview 有效
+		if minRating, ok := params["minRating"].(float64); ok {
+			query.MinRating = minRating
+		}
+		if decision, ok := params["decision"].(string); ok {
+			query.Decision = decision
+		}
+	}
+
+	return query
+}
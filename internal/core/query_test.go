@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func TestBuildQuery(t *testing.T) {
+	a := &App{}
+	params := map[string]interface{}{
+		"keywords":   []interface{}{"graph", 3},
+		"categories": []interface{}{"cs.LG"},
+		"dateFrom":   "2024-01-01",
+		"dateTo":     "2024-02-01",
+		"limit":      float64(20),
+		"sortBy":     "submittedDate",
+		"sortOrder":  "asc",
+		"venueId":    "ICLR.cc/2024/Conference",
+		"minRating":  float64(6),
+		"decision":   "accept",
+	}
+
+	q := a.BuildQuery("arxiv", params)
+	if len(q.Keywords) != 1 || q.Keywords[0] != "graph" || len(q.Categories) != 1 || q.Categories[0] != "cs.LG" {
+		t.Errorf("Unexpected keywords/categories: %+v", q)
+	}
+	if q.DateFrom != "2024-01-01" || q.DateTo != "2024-02-01" || q.Limit != 20 || q.SortBy != "submittedDate" || q.SortOrder != "asc" {
+		t.Errorf("Unexpected common params: %+v", q)
+	}
+	if q.MinRating != 0 || q.Decision != "" {
+		t.Errorf("Expected review filters to be ignored outside OpenReview, got %+v", q)
+	}
+
+	q = a.BuildQuery("openreview", params)
+	if len(q.Categories) != 1 || q.Categories[0] != "ICLR.cc/2024/Conference" || q.MinRating != 6 || q.Decision != "accept" {
+		t.Errorf("Unexpected OpenReview params: %+v", q)
+	}
+
+	// since_last 读取该平台的爬取进度
+	a.EnableLastCrawlTracking(t.TempDir())
+	last := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	a.recordLastCrawl("arxiv", nil, last)
+	if q := a.BuildQuery("arxiv", map[string]interface{}{"since_last": true}); !q.Since.IsZero() || !q.SinceLast {
+		t.Errorf("Expected incremental query with zero Since without progress, got %+v", q)
+	}
+	a.recordLastCrawl("arxiv", []*models.Paper{{FirstAnnouncedAt: last}}, last)
+	if q := a.BuildQuery("arxiv", map[string]interface{}{"since_last": true}); !q.Since.Equal(last) {
+		t.Errorf("Since = %v, want %v", q.Since, last)
+	}
+	if q := a.BuildQuery("arxiv", map[string]interface{}{"since_last": false}); !q.Since.IsZero() || q.SinceLast {
+		t.Errorf("Expected zero Since when since_last is false, got %v", q.Since)
+	}
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"context"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// AddToReadingQueue 按 source + sourceID 将论文加到阅读队列末尾
+func (a *App) AddToReadingQueue(ctx context.Context, source, sourceID string) error {
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return err
+	}
+	logger.Debug("加入阅读队列: %s/%s", source, sourceID)
+	return a.db.AddToQueue(paper.ID)
+}
+
+// RemoveFromReadingQueue 按 source + sourceID 将论文移出阅读队列
+func (a *App) RemoveFromReadingQueue(ctx context.Context, source, sourceID string) error {
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return err
+	}
+	logger.Debug("移出阅读队列: %s/%s", source, sourceID)
+	return a.db.RemoveFromQueue(paper.ID)
+}
+
+// ReorderReadingQueue 按论文 ID 顺序调整阅读队列，未列出的论文保持相对顺序排在后面
+func (a *App) ReorderReadingQueue(ctx context.Context, paperIDs []int64) error {
+	return a.db.ReorderQueue(paperIDs)
+}
+
+// GetReadingQueue 按队列顺序返回论文；limit <= 0 表示不限
+func (a *App) GetReadingQueue(ctx context.Context, limit int) ([]*models.Paper, error) {
+	return a.db.GetQueue(limit)
+}
+
+// NextInReadingQueue 返回队首论文，队列为空时返回 nil
+func (a *App) NextInReadingQueue(ctx context.Context) (*models.Paper, error) {
+	return a.db.PeekNext()
+}
+
+// MarkQueueItemDone 将论文标记为已读并移出阅读队列
+func (a *App) MarkQueueItemDone(ctx context.Context, source, sourceID string) error {
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return err
+	}
+	if err := a.db.SetPaperStatus(paper.ID, models.StatusRead); err != nil {
+		return err
+	}
+	logger.Debug("阅读完成: %s/%s", source, sourceID)
+	return a.db.RemoveFromQueue(paper.ID)
+}
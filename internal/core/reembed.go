@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// DefaultReembedBatchSize ReembedAll 每批处理的论文数
+const DefaultReembedBatchSize = 100
+
+// ReembedProgress 重新计算向量的进度回调，每完成一批调用一次
+type ReembedProgress func(done, total int)
+
+// ReembedAll 为向量模型与当前 embedder 不一致（以及尚无向量）的论文逐批重新计算向量，
+// 直到全部处理完成；某一批全部失败时停止并返回错误，避免对同一批论文反复重试。
+// batchSize <= 0 时使用默认值，progress 可为 nil
+func (a *App) ReembedAll(ctx context.Context, batchSize int, progress ReembedProgress) (int, error) {
+	if a.embedder == nil {
+		return 0, fmt.Errorf("未配置 embedder")
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultReembedBatchSize
+	}
+
+	model := a.embedder.ModelName()
+	total, err := a.countStaleEmbeddings(model)
+	if err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		logger.Info("所有论文的向量均来自当前模型 %s，无需重新计算", model)
+		return 0, nil
+	}
+	logger.Info("开始用模型 %s 重新计算 %d 篇论文的向量", model, total)
+
+	done := 0
+	for done < total {
+		n, err := a.searcher.ComputeMissingEmbeddings(ctx, batchSize, 0)
+		done += n
+		if progress != nil {
+			progress(done, total)
+		}
+		if err != nil {
+			return done, err
+		}
+		if n == 0 {
+			remaining, err := a.countStaleEmbeddings(model)
+			if err != nil {
+				return done, err
+			}
+			if remaining == 0 {
+				break
+			}
+			return done, fmt.Errorf("重新计算向量中断: %d 篇论文仍未完成", remaining)
+		}
+	}
+
+	logger.Info("向量重新计算完成: %d/%d", done, total)
+	return done, nil
+}
+
+// countStaleEmbeddings 统计尚无向量或向量不是由 model 生成的论文数
+func (a *App) countStaleEmbeddings(model string) (int, error) {
+	n, err := a.db.CountPapers([]string{"embedding IS NULL OR embedding_model != ?"}, []interface{}{model})
+	if err != nil {
+		return 0, fmt.Errorf("统计待重新计算的论文失败: %w", err)
+	}
+	return n, nil
+}
+
+// EmbeddingMismatches 返回库中模型或维度与当前 embedder 配置不一致的向量分组，
+// 不一致的向量不会参与语义搜索，需要调用 ReembedAll 迁移
+func (a *App) EmbeddingMismatches() ([]models.EmbeddingStat, error) {
+	if a.embedder == nil {
+		return nil, fmt.Errorf("未配置 embedder")
+	}
+	stats, err := a.db.GetEmbeddingStats()
+	if err != nil {
+		return nil, fmt.Errorf("统计向量模型失败: %w", err)
+	}
+
+	model, dim := a.embedder.ModelName(), a.embedder.Dim()
+	var mismatches []models.EmbeddingStat
+	for _, st := range stats {
+		if st.Model != model || (dim > 0 && st.Dim != dim) {
+			mismatches = append(mismatches, st)
+		}
+	}
+	return mismatches, nil
+}
+
+// warnEmbeddingMismatch 启动时检查已存向量，模型或维度与配置不一致时输出警告
+func (a *App) warnEmbeddingMismatch() {
+	mismatches, err := a.EmbeddingMismatches()
+	if err != nil {
+		logger.Warn("检查已存向量失败: %v", err)
+		return
+	}
+	model, dim := a.embedder.ModelName(), a.embedder.Dim()
+	for _, st := range mismatches {
+		if st.Model == model {
+			// 同一模型维度不同，多半是 embedder.dim 配置与模型实际输出不符，重新计算无法解决
+			logger.Warn("有 %d 篇论文的向量维度为 %d，与配置的 embedder.dim=%d 不一致，请检查配置", st.Count, st.Dim, dim)
+			continue
+		}
+		logger.Warn("有 %d 篇论文的向量来自模型 %s（维度 %d），与当前模型 %s 不一致，语义搜索会忽略这些论文，请重新计算向量",
+			st.Count, st.Model, st.Dim, model)
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReembedAll_ReprocessesRowsFromOldModel(t *testing.T) {
+	embedder := &fakeEmbedder{}
+	a := newEmbeddingApp(t, embedder)
+
+	const n = 7
+	for _, p := range newPapers(n) {
+		id, err := a.db.Upsert(p)
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		if err := a.db.SaveEmbedding(id, "old-model", p.Title, []float32{0, 1, 0, 0}); err != nil {
+			t.Fatalf("SaveEmbedding() error: %v", err)
+		}
+	}
+
+	mismatches, err := a.EmbeddingMismatches()
+	if err != nil {
+		t.Fatalf("EmbeddingMismatches() error: %v", err)
+	}
+	if len(mismatches) != 1 || mismatches[0].Model != "old-model" || mismatches[0].Dim != 4 || mismatches[0].Count != n {
+		t.Fatalf("EmbeddingMismatches() = %+v, want one group old-model/4/%d", mismatches, n)
+	}
+
+	var progress [][2]int
+	done, err := a.ReembedAll(context.Background(), 3, func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("ReembedAll() error: %v", err)
+	}
+	if done != n {
+		t.Errorf("ReembedAll() = %d, want %d", done, n)
+	}
+	if embedder.calls != n {
+		t.Errorf("Expected %d embedding calls, got %d", n, embedder.calls)
+	}
+	if len(progress) != 3 || progress[len(progress)-1] != [2]int{n, n} {
+		t.Errorf("Expected 3 progress reports ending at %d/%d, got %v", n, n, progress)
+	}
+	if pending := pendingEmbeddings(t, a); pending != 0 {
+		t.Errorf("Expected no pending embeddings after reembed, got %d", pending)
+	}
+
+	mismatches, err = a.EmbeddingMismatches()
+	if err != nil {
+		t.Fatalf("EmbeddingMismatches() error: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches after reembed, got %+v", mismatches)
+	}
+
+	// 已全部迁移时不再调用 embedder
+	if done, err := a.ReembedAll(context.Background(), 3, nil); err != nil || done != 0 {
+		t.Errorf("Second ReembedAll() = %d, %v, want 0, nil", done, err)
+	}
+	if embedder.calls != n {
+		t.Errorf("Expected no further embedding calls, got %d", embedder.calls-n)
+	}
+}
+
+func TestReembedAll_StopsWhenBatchFails(t *testing.T) {
+	embedder := &fakeEmbedder{failTitle: "Paper"}
+	a := newEmbeddingApp(t, embedder)
+	if _, err := a.SavePapers(context.Background(), newPapers(2)); err != nil {
+		t.Fatalf("SavePapers() error: %v", err)
+	}
+
+	if _, err := a.ReembedAll(context.Background(), 10, nil); err == nil {
+		t.Error("Expected error when every embedding in a batch fails")
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"PaperHunter/internal/platform"
@@ -19,10 +20,9 @@ type Provider struct {
 	DefaultConfig func() platform.Config
 }
 
-var (
-	regMu    sync.RWMutex
-	registry = map[string]Provider{}
-)
+// registry 平台名 -> Provider；各适配器包在 init() 中调用 MustRegister 注册，
+// 新平台只需在 desktop/main.go 或 cmd/server/main.go 中匿名导入，无需修改 core
+var registry = new(sync.Map)
 
 func Register(p Provider) error {
 	if p.Name == "" {
@@ -32,36 +32,34 @@ func Register(p Provider) error {
 		return fmt.Errorf("provider %s 的配置不正确", p.Name)
 	}
 
-	regMu.Lock()
-	defer regMu.Unlock()
-	if _, exists := registry[p.Name]; exists {
-		return fmt.Errorf("provider %s 已经注册过了", p.Name)
+	if _, exists := registry.LoadOrStore(p.Name, p); exists {
+		return fmt.Errorf("provider %s 已经注册过了，请检查是否有多个适配器使用了同一平台名", p.Name)
 	}
-	registry[p.Name] = p
 	return nil
 }
 
-
+// MustRegister 供适配器 init() 使用，注册失败（如平台名重复）时 panic
 func MustRegister(p Provider) {
 	if err := Register(p); err != nil {
-		panic(err)
+		panic(fmt.Sprintf("core.MustRegister: %v", err))
 	}
 }
 
 func Get(name string) (Provider, bool) {
-	regMu.RLock()
-	defer regMu.RUnlock()
-	p, ok := registry[name]
-	return p, ok
+	v, ok := registry.Load(name)
+	if !ok {
+		return Provider{}, false
+	}
+	return v.(Provider), true
 }
 
-/*
-func List() []string {
-	regMu.RLock()
-	defer regMu.RUnlock()
-	names := make([]string, 0, len(registry))
-	for n := range registry {
-		names = append(names, n)
-	}
+// ListPlatforms 返回所有已注册的平台名（按字母排序）
+func ListPlatforms() []string {
+	var names []string
+	registry.Range(func(key, _ any) bool {
+		names = append(names, key.(string))
+		return true
+	})
+	sort.Strings(names)
 	return names
-}*/
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"PaperHunter/internal/platform"
+)
+
+// withEmptyRegistry 在空注册表上运行 fn，结束后恢复全局注册表
+func withEmptyRegistry(t *testing.T, fn func()) {
+	t.Helper()
+	saved := registry
+	registry = new(sync.Map)
+	defer func() { registry = saved }()
+	fn()
+}
+
+func testProvider(name string) Provider {
+	return Provider{
+		Name:          name,
+		New:           func(cfg platform.Config) (platform.Platform, error) { return nil, nil },
+		DefaultConfig: func() platform.Config { return nil },
+	}
+}
+
+func TestMustRegister_DuplicatePanics(t *testing.T) {
+	withEmptyRegistry(t, func() {
+		MustRegister(testProvider("dup"))
+
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Fatal("expected MustRegister to panic on duplicate name")
+			}
+			msg, _ := r.(string)
+			if !strings.Contains(msg, "dup") || !strings.Contains(msg, "已经注册过了") {
+				t.Errorf("expected panic message to name the duplicate platform, got %v", r)
+			}
+		}()
+		MustRegister(testProvider("dup"))
+	})
+}
+
+func TestListPlatforms(t *testing.T) {
+	withEmptyRegistry(t, func() {
+		if got := ListPlatforms(); len(got) != 0 {
+			t.Errorf("expected no platforms, got %v", got)
+		}
+		for _, name := range []string{"ssrn", "arxiv", "acl"} {
+			MustRegister(testProvider(name))
+		}
+		if err := Register(Provider{Name: "broken"}); err == nil {
+			t.Error("expected error for provider without constructor")
+		}
+
+		want := []string{"acl", "arxiv", "ssrn"}
+		if got := ListPlatforms(); !reflect.DeepEqual(got, want) {
+			t.Errorf("ListPlatforms() = %v, want %v", got, want)
+		}
+		if _, ok := Get("arxiv"); !ok {
+			t.Error("expected Get to find registered platform")
+		}
+		if _, ok := Get("broken"); ok {
+			t.Error("expected invalid provider not to be registered")
+		}
+	})
+}
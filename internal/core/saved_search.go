@@ -0,0 +1,35 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"PaperHunter/internal/models"
+)
+
+// SaveSearch 按名称保存检索条件，同名搜索会被覆盖；分页参数不参与结果计数，保存前清除
+func (a *App) SaveSearch(ctx context.Context, name string, q models.SavedSearchQuery) error {
+	q.Condition.Limit = 0
+	q.Condition.Offset = 0
+	data, err := json.Marshal(q)
+	if err != nil {
+		return fmt.Errorf("序列化检索条件失败: %w", err)
+	}
+	return a.db.SaveSearch(name, string(data))
+}
+
+// GetSavedSearches 按名称返回所有保存的搜索
+func (a *App) GetSavedSearches(ctx context.Context) ([]models.SavedSearch, error) {
+	return a.db.GetSavedSearches()
+}
+
+// DeleteSavedSearch 删除保存的搜索
+func (a *App) DeleteSavedSearch(ctx context.Context, id string) error {
+	return a.db.DeleteSavedSearch(id)
+}
+
+// CheckSavedSearch 重新执行保存的搜索，返回自上次检查以来新增的结果数
+func (a *App) CheckSavedSearch(ctx context.Context, id string) (int, error) {
+	return a.db.CheckSavedSearch(id)
+}
@@ -2,13 +2,17 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"sync"
 
 	storage "PaperHunter/db"
 	emb "PaperHunter/internal/embedding"
 	"PaperHunter/internal/ir"
 	"PaperHunter/internal/models"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/metrics"
 )
 
 // Searcher 本地检索器，支持语义搜索、关键词搜索和IR搜索
@@ -16,6 +20,13 @@ type Searcher struct {
 	db         storage.PaperStorage
 	embedder   emb.Service
 	irSearcher *ir.IRSearcher // IR搜索引擎
+
+	irMu        sync.Mutex
+	irBuilt     bool   // IR 索引是否已从数据库完成首次构建，之后通过 AddPaperToIR 增量更新
+	irIndexPath string // 非空时 IR 索引持久化到该文件，启动后优先加载而不是重建
+	irDirty     bool   // 索引有尚未写入 irIndexPath 的增量更新
+
+	dimErrOnce sync.Once // 向量维度与 embedder 不一致的错误只记录一次
 }
 
 func NewSearcher(db storage.PaperStorage, embedder emb.Service) *Searcher {
@@ -49,16 +60,79 @@ type SearchOptions struct {
 	TopK int
 	// 是否使用语义搜索（需要配置 embedder）
 	Semantic bool
+	// 关键词搜索使用 FTS5 全文索引并按 bm25 排序，未启用 FTS5 时退回 LIKE
+	FTS bool
 	// IR搜索模式
 	IR          bool   // 是否使用IR搜索
 	IRAlgorithm string // IR算法类型: "tfidf", "bm25", "all"
+	// 混合搜索模式：同时执行 BM25 与语义搜索并按权重融合
+	Hybrid      bool
+	HybridAlpha float64 // 语义分数权重，取值 (0,1]，未设置时使用 DefaultHybridAlpha
+	// 相似度下限，取值 [0,1]，低于该值的结果被过滤；0 表示不过滤。
+	// 只作用于语义搜索（余弦相似度）与混合搜索（归一化后的融合分数）；
+	// IR 的 BM25/TF-IDF 原始分数与关键词搜索的固定分数不在 [0,1] 的相似度尺度上，不过滤
+	MinSimilarity float32
+	// 打分后的排序字段：similarity、date、citations；为空时保持打分顺序
+	SortBy   string
+	SortDesc bool // 降序排列
 }
 
 // Search 执行搜索
 // - IR搜索: 使用TF-IDF或BM25算法进行传统信息检索
 // - 语义搜索: 将 query/examples 转为向量，在数据库中查找相似论文
-// - 关键词搜索: 在标题和摘要中使用 SQL LIKE 查询
+// - 关键词搜索: 在标题和摘要中使用 SQL LIKE 查询，FTS 为 true 时使用全文索引
+// - 混合搜索: BM25 与语义搜索结果归一化后加权融合
 func (s *Searcher) Search(ctx context.Context, opts SearchOptions) ([]*models.SimilarPaper, error) {
+	metrics.SearchRequests.WithLabelValues(searchType(opts)).Inc()
+
+	if opts.MinSimilarity < 0 || opts.MinSimilarity > 1 {
+		return nil, fmt.Errorf("相似度阈值需在 [0,1] 范围内: %v", opts.MinSimilarity)
+	}
+	if err := ValidateSortBy(opts.SortBy); err != nil {
+		return nil, err
+	}
+
+	results, err := s.search(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if scoresAreSimilarities(opts) {
+		results = filterBySimilarity(results, opts.MinSimilarity)
+	}
+	if err := SortResults(results, opts.SortBy, opts.SortDesc); err != nil {
+		return nil, err
+	}
+	// 语义搜索不一定包含查询词，只取摘要首句
+	attachSnippets(results, opts.Query, !opts.Semantic || opts.IR || opts.Hybrid)
+	return results, nil
+}
+
+// scoresAreSimilarities 结果分数是否为 [0,1] 的相似度：语义搜索与混合搜索为真，IR 与关键词搜索为假；
+// 判断顺序与 search 的模式优先级一致
+func scoresAreSimilarities(opts SearchOptions) bool {
+	return opts.Hybrid || (!opts.IR && opts.Semantic)
+}
+
+// filterBySimilarity 去掉相似度低于 minSimilarity 的结果，minSimilarity 为 0 时原样返回
+func filterBySimilarity(results []*models.SimilarPaper, minSimilarity float32) []*models.SimilarPaper {
+	if minSimilarity <= 0 {
+		return results
+	}
+	filtered := results[:0]
+	for _, r := range results {
+		if r.Similarity >= minSimilarity {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func (s *Searcher) search(ctx context.Context, opts SearchOptions) ([]*models.SimilarPaper, error) {
+	// 混合搜索模式
+	if opts.Hybrid {
+		return s.searchHybrid(ctx, opts)
+	}
+
 	// IR搜索模式
 	if opts.IR {
 		return s.searchWithIR(ctx, opts)
@@ -71,7 +145,11 @@ func (s *Searcher) Search(ctx context.Context, opts SearchOptions) ([]*models.Si
 		}
 
 		logger.Info("使用关键词搜索: %s", opts.Query)
-		papers, err := s.db.SearchByKeywords(opts.Query, opts.Condition)
+		search := s.db.SearchByKeywords
+		if opts.FTS {
+			search = s.db.SearchByFTS
+		}
+		papers, err := search(opts.Query, opts.Condition)
 		if err != nil {
 			return nil, fmt.Errorf("关键词搜索失败: %w", err)
 		}
@@ -94,8 +172,27 @@ func (s *Searcher) Search(ctx context.Context, opts SearchOptions) ([]*models.Si
 		return results, nil
 	}
 
-	// 语义搜索模式
+	return s.searchSemantic(ctx, opts)
+}
+
+// searchType 搜索方式，用作 paperhunter_search_requests_total 的 type 标签
+func searchType(opts SearchOptions) string {
+	switch {
+	case opts.Hybrid:
+		return "hybrid"
+	case opts.IR:
+		return "ir"
+	case !opts.Semantic && opts.FTS:
+		return "fts"
+	case !opts.Semantic:
+		return "keyword"
+	default:
+		return "semantic"
+	}
+}
 
+// searchSemantic 语义搜索：将 query/examples 转为向量后在数据库中检索
+func (s *Searcher) searchSemantic(ctx context.Context, opts SearchOptions) ([]*models.SimilarPaper, error) {
 	if s.embedder == nil {
 		return nil, fmt.Errorf("语义搜索需要配置 embedding 服务，请检查配置文件中的 embedding.apikey")
 	}
@@ -159,9 +256,13 @@ func (s *Searcher) embedFromExamples(ctx context.Context, examples []*models.Pap
 	return avgVec, nil
 }
 
+// DefaultEmbedConcurrency ComputeMissingEmbeddings 默认的并发请求数
+const DefaultEmbedConcurrency = 4
+
 // ComputeMissingEmbeddings 批量计算缺失的 embedding
-// 用于为已爬取的论文补充向量数据
-func (s *Searcher) ComputeMissingEmbeddings(ctx context.Context, batchSize int) (int, error) {
+// 用于为已爬取的论文补充向量数据：concurrency 个 worker 并发调用 embedder，
+// 结果通过 channel 交给单个写入方顺序保存，避免 SQLite 写竞争；concurrency <= 0 时使用默认值
+func (s *Searcher) ComputeMissingEmbeddings(ctx context.Context, batchSize int, concurrency int) (int, error) {
 	if s.embedder == nil {
 		return 0, fmt.Errorf("未配置 embedding 服务")
 	}
@@ -177,30 +278,97 @@ func (s *Searcher) ComputeMissingEmbeddings(ctx context.Context, batchSize int)
 		return 0, nil
 	}
 
-	logger.Info("开始为 %d 篇论文计算向量", len(papers))
+	if concurrency <= 0 {
+		concurrency = DefaultEmbedConcurrency
+	}
+	if concurrency > len(papers) {
+		concurrency = len(papers)
+	}
 
-	count := 0
-	for i, p := range papers {
-		text := emb.BuildEmbeddingText(p)
-		vec, err := s.embedder.EmbedQuery(ctx, text)
-		if err != nil {
-			logger.Warn("[%d/%d] 向量生成失败 (paper_id=%d): %v", i+1, len(papers), p.ID, err)
-			continue
+	logger.Info("开始为 %d 篇论文计算向量（并发 %d）", len(papers), concurrency)
+
+	type embedded struct {
+		index int
+		paper *models.Paper
+		text  string
+		vec   []float32
+	}
+
+	jobs := make(chan int)
+	results := make(chan embedded)
+
+	// 分发任务，ctx 取消后立即停止派发
+	go func() {
+		defer close(jobs)
+		for i := range papers {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				p := papers[i]
+				text := emb.BuildEmbeddingText(p)
+				vec, err := s.embedder.EmbedQuery(ctx, text)
+				if err != nil {
+					logger.Warn("[%d/%d] 向量生成失败 (paper_id=%d): %v", i+1, len(papers), p.ID, err)
+					continue
+				}
+				if err := s.checkEmbeddingDim(vec); err != nil {
+					continue
+				}
+				results <- embedded{index: i, paper: p, text: text, vec: vec}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-		if err := s.db.SaveEmbedding(p.ID, model, text, vec); err != nil {
-			logger.Warn("[%d/%d] 向量保存失败 (paper_id=%d): %v", i+1, len(papers), p.ID, err)
+	count := 0
+	for r := range results {
+		if err := s.db.SaveEmbedding(r.paper.ID, model, r.text, r.vec); err != nil {
+			logger.Warn("[%d/%d] 向量保存失败 (paper_id=%d): %v", r.index+1, len(papers), r.paper.ID, err)
 			continue
 		}
 
-		logger.Debug("[%d/%d] 向量保存成功: paper_id=%d, dim=%d", i+1, len(papers), p.ID, len(vec))
+		logger.Debug("[%d/%d] 向量保存成功: paper_id=%d, dim=%d", r.index+1, len(papers), r.paper.ID, len(r.vec))
 		count++
 	}
 
+	if err := ctx.Err(); err != nil {
+		logger.Warn("向量计算已取消: %d/%d 成功", count, len(papers))
+		return count, err
+	}
+
 	logger.Info("向量计算完成: %d/%d 成功", count, len(papers))
 	return count, nil
 }
 
+// checkEmbeddingDim 校验 embedder 返回的向量维度与其 Dim() 一致，不一致的向量不应保存；
+// 错误只在首次出现时记录，避免逐篇刷屏
+func (s *Searcher) checkEmbeddingDim(vec []float32) error {
+	want := s.embedder.Dim()
+	if want <= 0 || len(vec) == want {
+		return nil
+	}
+	err := fmt.Errorf("%w: 模型 %s 返回 %d 维向量，期望 %d 维", storage.ErrEmbeddingDimMismatch, s.embedder.ModelName(), len(vec), want)
+	s.dimErrOnce.Do(func() {
+		logger.Error("%v，向量不会被保存，请检查 embedder.dim 配置", err)
+	})
+	return err
+}
+
 // searchWithIR 使用传统IR算法进行搜索
 func (s *Searcher) searchWithIR(ctx context.Context, opts SearchOptions) ([]*models.SimilarPaper, error) {
 	if s.irSearcher == nil {
@@ -211,24 +379,11 @@ func (s *Searcher) searchWithIR(ctx context.Context, opts SearchOptions) ([]*mod
 		return nil, fmt.Errorf("IR搜索需要提供查询文本")
 	}
 
-	// 如果索引为空，需要构建索引
+	if err := s.ensureIRIndex(ctx); err != nil {
+		return nil, err
+	}
 	if s.irSearcher.IsEmpty() {
-		logger.Info("IR索引为空，正在从数据库构建索引...")
-		papers, err := s.getAllPapersForIR(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("获取论文数据失败: %w", err)
-		}
-
-		if len(papers) == 0 {
-			return nil, fmt.Errorf("数据库中没有论文数据")
-		}
-
-		err = s.irSearcher.BuildIndex(papers)
-		if err != nil {
-			return nil, fmt.Errorf("构建IR索引失败: %w", err)
-		}
-
-		logger.Info("IR索引构建完成，包含 %d 篇论文", len(papers))
+		return nil, fmt.Errorf("数据库中没有论文数据")
 	}
 
 	// 设置默认值
@@ -287,6 +442,97 @@ func (s *Searcher) searchWithIR(ctx context.Context, opts SearchOptions) ([]*mod
 	return similarPapers, nil
 }
 
+// ensureIRIndex 首次使用时准备IR索引，之后的新论文由 AddPaperToIR 增量加入。
+// 配置了 irIndexPath 时优先加载磁盘上的索引并按数据库增量修正，文件缺失、分词配置变化或差异过大时从数据库重建
+func (s *Searcher) ensureIRIndex(ctx context.Context) error {
+	s.irMu.Lock()
+	defer s.irMu.Unlock()
+
+	if s.irBuilt {
+		return nil
+	}
+
+	papers, err := s.getAllPapersForIR(ctx)
+	if err != nil {
+		return fmt.Errorf("获取论文数据失败: %w", err)
+	}
+
+	if s.irIndexPath != "" {
+		drift, err := s.irSearcher.LoadIndex(s.irIndexPath, papers)
+		switch {
+		case err == nil:
+			s.irBuilt = true
+			logger.Info("已加载磁盘上的IR索引，包含 %d 篇论文（增量修正 %d 篇）", len(papers), drift)
+			if drift > 0 {
+				s.saveIRIndexLocked()
+			}
+			return nil
+		case errors.Is(err, fs.ErrNotExist):
+			logger.Debug("IR索引文件不存在: %s", s.irIndexPath)
+		default:
+			logger.Info("磁盘上的IR索引不可用，重新构建: %v", err)
+		}
+	}
+
+	logger.Info("IR索引未构建，正在从数据库构建索引...")
+	s.irSearcher.ClearIndex()
+	if len(papers) > 0 {
+		if err := s.irSearcher.BuildIndex(papers); err != nil {
+			return fmt.Errorf("构建IR索引失败: %w", err)
+		}
+	}
+	s.irBuilt = true
+
+	logger.Info("IR索引构建完成，包含 %d 篇论文", len(papers))
+	if s.irIndexPath != "" {
+		s.saveIRIndexLocked()
+	}
+	return nil
+}
+
+// SetIRIndexPath 设置IR索引的持久化文件，空字符串表示不持久化；应在开始搜索前调用
+func (s *Searcher) SetIRIndexPath(path string) {
+	s.irMu.Lock()
+	defer s.irMu.Unlock()
+	s.irIndexPath = path
+}
+
+// SaveIRIndex 将有增量更新的IR索引写入持久化文件，未配置持久化或索引未构建时跳过
+func (s *Searcher) SaveIRIndex() {
+	if s.irSearcher == nil {
+		return
+	}
+
+	s.irMu.Lock()
+	defer s.irMu.Unlock()
+
+	if s.irIndexPath == "" || !s.irBuilt || !s.irDirty {
+		return
+	}
+	s.saveIRIndexLocked()
+}
+
+// saveIRIndexLocked 写入持久化文件，失败只记录警告（下次启动时重建）；调用方需持有 irMu
+func (s *Searcher) saveIRIndexLocked() {
+	if err := s.irSearcher.SaveIndex(s.irIndexPath); err != nil {
+		logger.Warn("保存IR索引失败: %v", err)
+		return
+	}
+	s.irDirty = false
+	logger.Debug("IR索引已保存: %s", s.irIndexPath)
+}
+
+// InvalidateIRIndex 标记IR索引需要重建（如论文被删除或恢复），下次IR搜索时从数据库重新构建；
+// 配置了持久化时先保存增量更新，下次加载后再按数据库修正
+func (s *Searcher) InvalidateIRIndex() {
+	s.irMu.Lock()
+	defer s.irMu.Unlock()
+	if s.irIndexPath != "" && s.irBuilt && s.irDirty {
+		s.saveIRIndexLocked()
+	}
+	s.irBuilt = false
+}
+
 // getAllPapersForIR 获取所有论文用于构建IR索引
 func (s *Searcher) getAllPapersForIR(ctx context.Context) ([]*models.Paper, error) {
 	// 设置一个较大的limit来获取所有论文
@@ -326,11 +572,39 @@ func (s *Searcher) SetBM25Parameters(k1, b float64) error {
 	return nil
 }
 
+// SetTokenizerConfig 按分词配置重建 IR 分词器，索引与查询共用同一分词器；
+// 已有索引会在下次 IR 搜索时重新构建，应在启动时、开始搜索前调用
+func (s *Searcher) SetTokenizerConfig(cfg ir.TokenizerConfig) error {
+	tokenizer, err := ir.NewTokenizerWithConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	s.irMu.Lock()
+	defer s.irMu.Unlock()
+	s.irSearcher = ir.NewIRSearcher(tokenizer)
+	s.irBuilt = false
+	logger.Debug("IR 分词配置已更新: lang=%s, 自定义停用词 %d 个, 词干提取=%v, 最短词长=%d",
+		cfg.StopwordLang, len(cfg.CustomStopwords), cfg.Stem, cfg.MinTokenLength)
+	return nil
+}
+
 // AddPaperToIR 添加论文到 IR 索引
+// 索引尚未构建时跳过，首次搜索时会从数据库完整加载（已包含该论文）
 func (s *Searcher) AddPaperToIR(paper *models.Paper) {
-	if s.irSearcher != nil {
-		if err := s.irSearcher.AddDocument(paper); err != nil {
-			logger.Warn("添加论文到IR索引失败: %v", err)
-		}
+	if s.irSearcher == nil {
+		return
+	}
+
+	s.irMu.Lock()
+	defer s.irMu.Unlock()
+
+	if !s.irBuilt {
+		return
+	}
+	if err := s.irSearcher.AddDocument(paper); err != nil {
+		logger.Warn("添加论文到IR索引失败: %v", err)
+		return
 	}
+	s.irDirty = true
 }
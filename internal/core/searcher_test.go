@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func TestFilterBySimilarity(t *testing.T) {
+	newResults := func() []*models.SimilarPaper {
+		return []*models.SimilarPaper{
+			{Paper: paper(1, "a"), Similarity: 0.9},
+			{Paper: paper(2, "b"), Similarity: 0.35},
+			{Paper: paper(3, "c"), Similarity: 0.2},
+			{Paper: paper(4, "d"), Similarity: 0.1},
+		}
+	}
+
+	got := filterBySimilarity(newResults(), 0.3)
+	if len(got) != 2 || got[0].Paper.ID != 1 || got[1].Paper.ID != 2 {
+		t.Errorf("Expected papers 1 and 2 above 0.3, got %d results", len(got))
+	}
+
+	// 阈值本身保留
+	if got := filterBySimilarity(newResults(), 0.2); len(got) != 3 {
+		t.Errorf("Expected 3 results at threshold 0.2, got %d", len(got))
+	}
+
+	// 0 表示不过滤
+	if got := filterBySimilarity(newResults(), 0); len(got) != 4 {
+		t.Errorf("Expected all 4 results when threshold is 0, got %d", len(got))
+	}
+}
+
+func TestSearch_RejectsInvalidMinSimilarity(t *testing.T) {
+	s := newEmbeddingSearcher(t, &fakeEmbedder{}, 1)
+	for _, v := range []float32{-0.1, 1.5} {
+		if _, err := s.Search(context.Background(), SearchOptions{Query: "graph", MinSimilarity: v}); err == nil {
+			t.Errorf("Expected error for MinSimilarity %v", v)
+		}
+	}
+	if _, err := s.Search(context.Background(), SearchOptions{Query: "graph", MinSimilarity: 1}); err != nil {
+		t.Errorf("Search() with MinSimilarity 1 error: %v", err)
+	}
+}
+
+func TestSearch_MinSimilarityOnlyFiltersSimilarityScores(t *testing.T) {
+	cases := []struct {
+		opts SearchOptions
+		want bool
+	}{
+		{SearchOptions{Semantic: true}, true},
+		{SearchOptions{Hybrid: true}, true},
+		{SearchOptions{Semantic: true, IR: true}, false},
+		{SearchOptions{IR: true}, false},
+		{SearchOptions{FTS: true}, false},
+		{SearchOptions{}, false},
+	}
+	for _, c := range cases {
+		if got := scoresAreSimilarities(c.opts); got != c.want {
+			t.Errorf("scoresAreSimilarities(%+v) = %v, want %v", c.opts, got, c.want)
+		}
+	}
+
+	// IR 的 BM25 原始分数不受相似度阈值影响
+	s := newEmbeddingSearcher(t, &fakeEmbedder{}, 12)
+	results, err := s.Search(context.Background(), SearchOptions{Query: "graph", TopK: 20, IR: true, MinSimilarity: 1})
+	if err != nil || len(results) != 12 {
+		t.Errorf("IR Search() with MinSimilarity 1 = %d results, %v; want 12", len(results), err)
+	}
+}
+
+func TestSearchWithIR_ReusesPersistedIndex(t *testing.T) {
+	ctx := context.Background()
+	s := newEmbeddingSearcher(t, &fakeEmbedder{}, 12)
+	path := filepath.Join(t.TempDir(), "index", "ir.gob")
+	s.SetIRIndexPath(path)
+
+	opts := SearchOptions{Query: "graph", TopK: 20, IR: true}
+	results, err := s.Search(ctx, opts)
+	if err != nil || len(results) != 12 {
+		t.Fatalf("Search() = %d results, %v; want 12", len(results), err)
+	}
+	// 首次构建后写入索引文件；把修改时间调到过去，之后据此判断文件是否被重写
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatalf("Expected index file after first build: %v", err)
+	}
+	modTime := func() time.Time {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Stat() error: %v", err)
+		}
+		return info.ModTime()
+	}
+
+	// 模拟重启：新的 Searcher 直接加载索引，与数据库一致时不重建也不重写文件
+	restarted := NewSearcher(s.db, &fakeEmbedder{})
+	restarted.SetIRIndexPath(path)
+	if results, err := restarted.Search(ctx, opts); err != nil || len(results) != 12 {
+		t.Fatalf("Search() after restart = %d results, %v; want 12", len(results), err)
+	}
+	if !modTime().Equal(past) {
+		t.Error("Expected persisted index to be reused without rewriting")
+	}
+
+	// 增量加入的论文在保存后随索引一起被下次启动加载
+	p := &models.Paper{Source: "arxiv", SourceID: "2401.99999", URL: "https://arxiv.org/abs/2401.99999", Title: "Protein folding", Abstract: "Structure prediction."}
+	id, err := s.db.Upsert(p)
+	if err != nil {
+		t.Fatalf("Upsert() error: %v", err)
+	}
+	p.ID = id
+	restarted.AddPaperToIR(p)
+	restarted.SaveIRIndex()
+	if modTime().Equal(past) {
+		t.Fatal("Expected SaveIRIndex to write incremental updates")
+	}
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatalf("Chtimes() error: %v", err)
+	}
+
+	again := NewSearcher(s.db, &fakeEmbedder{})
+	again.SetIRIndexPath(path)
+	results, err = again.Search(ctx, SearchOptions{Query: "protein", TopK: 5, IR: true})
+	if err != nil || len(results) != 1 || results[0].Paper.SourceID != "2401.99999" {
+		t.Fatalf("Search(protein) = %v, %v; want the incrementally added paper", results, err)
+	}
+	if !modTime().Equal(past) {
+		t.Error("Expected index with incremental updates to load without rebuilding")
+	}
+}
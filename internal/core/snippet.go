@@ -0,0 +1,155 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+
+	"PaperHunter/internal/models"
+)
+
+// snippetWindow 摘要片段的最大长度（字符数）
+const snippetWindow = 200
+
+// snippetLead 命中词之前保留的上下文长度（字符数）
+const snippetLead = 60
+
+// attachSnippets 为搜索结果生成摘要片段：highlight 为 true 时截取首个命中词附近的窗口并用 **term** 标出查询词，
+// 没有命中或语义搜索时取摘要的第一句。完整摘要仍保留在 Paper.Abstract 中
+func attachSnippets(results []*models.SimilarPaper, query string, highlight bool) {
+	terms := snippetTerms(query)
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		if highlight {
+			if s, ok := highlightSnippet(r.Paper.Abstract, terms); ok {
+				r.Snippet = s
+				continue
+			}
+		}
+		r.Snippet = leadingSentence(r.Paper.Abstract)
+	}
+}
+
+// snippetTerms 将查询拆分为小写的查询词，去掉标点与重复项
+func snippetTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, f := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	}) {
+		if f = strings.Trim(f, "-"); f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// highlightSnippet 截取 text 中首个命中词附近约 snippetWindow 个字符，窗口内的所有查询词加上 ** 标记；
+// 没有任何查询词出现时 ok 为 false
+func highlightSnippet(text string, terms []string) (string, bool) {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// 记录每个位置上命中的最长查询词长度
+	matches := make([]int, len(runes))
+	first := -1
+	for _, term := range terms {
+		tr := []rune(term)
+		for i := 0; i+len(tr) <= len(lower); i++ {
+			if matches[i] < len(tr) && runesEqual(lower[i:i+len(tr)], tr) {
+				matches[i] = len(tr)
+				if first < 0 || i < first {
+					first = i
+				}
+			}
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	start := max(0, first-snippetLead)
+	end := min(len(runes), start+snippetWindow)
+	if end == len(runes) {
+		start = max(0, end-snippetWindow)
+	}
+	// 窗口边界对齐到空白处，避免截断单词
+	if start > 0 {
+		if i := indexSpace(runes[start:first]); i >= 0 {
+			start += i + 1
+		}
+	}
+	if end < len(runes) {
+		if i := lastIndexSpace(runes[first:end]); i > 0 {
+			end = first + i
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	for i := start; i < end; {
+		if n := matches[i]; n > 0 && i+n <= end {
+			b.WriteString("**")
+			b.WriteString(string(runes[i : i+n]))
+			b.WriteString("**")
+			i += n
+			continue
+		}
+		b.WriteRune(runes[i])
+		i++
+	}
+	if end < len(runes) {
+		b.WriteString("...")
+	}
+	return b.String(), true
+}
+
+// leadingSentence 返回摘要的第一句，超过 snippetWindow 时截断
+func leadingSentence(text string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	for i, r := range runes {
+		if r == '。' || r == '！' || r == '？' || ((r == '.' || r == '!' || r == '?') && (i+1 == len(runes) || runes[i+1] == ' ')) {
+			runes = runes[:i+1]
+			break
+		}
+	}
+	if len(runes) > snippetWindow {
+		return string(runes[:snippetWindow]) + "..."
+	}
+	return string(runes)
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func indexSpace(rs []rune) int {
+	for i, r := range rs {
+		if r == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+func lastIndexSpace(rs []rune) int {
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
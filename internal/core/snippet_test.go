@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// longAbstract 构造一段超过 snippetWindow 的摘要，target 出现在第 pos 个词
+func longAbstract(pos int, target string) string {
+	words := make([]string, 80)
+	for i := range words {
+		words[i] = "filler"
+	}
+	words[pos] = target
+	return "First sentence here. " + strings.Join(words, " ") + "."
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	terms := snippetTerms("Diffusion, models")
+
+	t.Run("match near start", func(t *testing.T) {
+		got, ok := highlightSnippet("Diffusion models generate images. They are slow.", terms)
+		if !ok || got != "**Diffusion** **models** generate images. They are slow." {
+			t.Errorf("highlightSnippet() = %q, %v", got, ok)
+		}
+	})
+
+	t.Run("match in middle", func(t *testing.T) {
+		got, ok := highlightSnippet(longAbstract(50, "diffusion"), terms)
+		if !ok {
+			t.Fatalf("Expected a match")
+		}
+		if !strings.HasPrefix(got, "...") || !strings.HasSuffix(got, "...") || !strings.Contains(got, " **diffusion** ") {
+			t.Errorf("Expected an elided window around the term, got %q", got)
+		}
+		if n := len([]rune(got)); n > snippetWindow+6 {
+			t.Errorf("Snippet length = %d, want <= %d", n, snippetWindow+6)
+		}
+		if strings.Contains(got, "First sentence") {
+			t.Errorf("Expected the window to skip the leading text, got %q", got)
+		}
+	})
+
+	t.Run("absent term", func(t *testing.T) {
+		if got, ok := highlightSnippet(longAbstract(50, "transformer"), terms); ok {
+			t.Errorf("Expected no match, got %q", got)
+		}
+	})
+}
+
+func TestSearch_Snippets(t *testing.T) {
+	s := newEmbeddingSearcher(t, &fakeEmbedder{}, 0)
+	papers := newPapers(2)
+	papers[0].Abstract = longAbstract(40, "graph")
+	papers[1].Title = "Graph survey"
+	papers[1].Abstract = "Surveys of message passing. More details follow."
+	for _, p := range papers {
+		if _, err := s.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+
+	results, err := s.Search(context.Background(), SearchOptions{Query: "graph"})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		var want string
+		switch r.Paper.SourceID {
+		case papers[0].SourceID:
+			if !strings.Contains(r.Snippet, "**graph**") || r.Paper.Abstract != papers[0].Abstract {
+				t.Errorf("Expected highlighted snippet and full abstract, got %q", r.Snippet)
+			}
+			continue
+		case papers[1].SourceID:
+			// 只有标题命中时退回摘要首句
+			want = "Surveys of message passing."
+		}
+		if r.Snippet != want {
+			t.Errorf("Snippet = %q, want %q", r.Snippet, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+// 搜索与推荐结果的排序字段
+const (
+	SortBySimilarity = "similarity"
+	SortByDate       = "date"
+	SortByCitations  = "citations"
+)
+
+// ValidateSortBy 检查排序字段，空字符串表示保持打分顺序
+func ValidateSortBy(sortBy string) error {
+	switch sortBy {
+	case "", SortBySimilarity, SortByDate, SortByCitations:
+		return nil
+	}
+	return fmt.Errorf("不支持的排序字段: %s（可选 similarity、date、citations）", sortBy)
+}
+
+// SortResults 按 sortBy 原地排序，desc 为 true 时降序；sortBy 为空时不改变顺序。
+// 主键相同时按日期从新到旧、再按 source/source_id 排列，保证结果稳定
+func SortResults(results []*models.SimilarPaper, sortBy string, desc bool) error {
+	if err := ValidateSortBy(sortBy); err != nil {
+		return err
+	}
+	if sortBy == "" || len(results) < 2 {
+		return nil
+	}
+
+	// cmp 返回 a 相对 b 的主键比较结果（升序意义下 a 在前为负）
+	cmp := func(a, b *models.SimilarPaper) int {
+		switch sortBy {
+		case SortBySimilarity:
+			return compareFloat(float64(a.Similarity), float64(b.Similarity))
+		case SortByDate:
+			return paperDate(a.Paper).Compare(paperDate(b.Paper))
+		default:
+			return a.Paper.Citations - b.Paper.Citations
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if c := cmp(a, b); c != 0 {
+			return (c < 0) != desc
+		}
+		if c := paperDate(a.Paper).Compare(paperDate(b.Paper)); c != 0 {
+			return c > 0
+		}
+		if a.Paper.Source != b.Paper.Source {
+			return a.Paper.Source < b.Paper.Source
+		}
+		return a.Paper.SourceID < b.Paper.SourceID
+	})
+	return nil
+}
+
+// paperDate 排序使用的论文日期：首次公布时间，缺失时使用更新时间
+func paperDate(p models.Paper) time.Time {
+	if !p.FirstAnnouncedAt.IsZero() {
+		return p.FirstAnnouncedAt
+	}
+	return p.UpdatedAt
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
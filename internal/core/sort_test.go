@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+func sortFixture() []*models.SimilarPaper {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	withDates := func(p models.Paper, announced, updated time.Time, citations int) models.Paper {
+		p.FirstAnnouncedAt, p.UpdatedAt, p.Citations = announced, updated, citations
+		return p
+	}
+	return []*models.SimilarPaper{
+		{Paper: withDates(paper(1, "a"), day(3), day(3), 5), Similarity: 0.8},
+		{Paper: withDates(paper(2, "b"), day(10), day(10), 5), Similarity: 0.8},
+		// 缺少公布时间，按更新时间排序
+		{Paper: withDates(paper(3, "c"), time.Time{}, day(7), 40), Similarity: 0.5},
+		{Paper: withDates(paper(4, "d"), day(1), day(1), 0), Similarity: 0.9},
+	}
+}
+
+func sortedIDs(results []*models.SimilarPaper) []int64 {
+	ids := make([]int64, len(results))
+	for i, r := range results {
+		ids[i] = r.Paper.ID
+	}
+	return ids
+}
+
+func TestSortResults(t *testing.T) {
+	tests := []struct {
+		sortBy string
+		desc   bool
+		want   []int64
+	}{
+		{"", false, []int64{1, 2, 3, 4}},
+		// 相似度相同的 1、2 按日期从新到旧
+		{SortBySimilarity, true, []int64{4, 2, 1, 3}},
+		{SortBySimilarity, false, []int64{3, 2, 1, 4}},
+		{SortByDate, true, []int64{2, 3, 1, 4}},
+		{SortByDate, false, []int64{4, 1, 3, 2}},
+		// 引用数相同的 1、2 同样按日期从新到旧
+		{SortByCitations, true, []int64{3, 2, 1, 4}},
+		{SortByCitations, false, []int64{4, 2, 1, 3}},
+	}
+	for _, tt := range tests {
+		results := sortFixture()
+		if err := SortResults(results, tt.sortBy, tt.desc); err != nil {
+			t.Fatalf("SortResults(%q, %v) error: %v", tt.sortBy, tt.desc, err)
+		}
+		got := sortedIDs(results)
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("SortResults(%q, %v) = %v, want %v", tt.sortBy, tt.desc, got, tt.want)
+				break
+			}
+		}
+	}
+
+	if err := SortResults(sortFixture(), "title", false); err == nil {
+		t.Error("Expected error for unsupported sort field")
+	}
+}
+
+func TestSearch_RejectsUnknownSortBy(t *testing.T) {
+	s := newEmbeddingSearcher(t, &fakeEmbedder{}, 1)
+	if _, err := s.Search(context.Background(), SearchOptions{Query: "graph", SortBy: "title"}); err == nil {
+		t.Error("Expected error for unsupported SortBy")
+	}
+}
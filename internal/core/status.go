@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	dbsqlite "PaperHunter/db/sqlite"
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// SetPaperStatus 按 source + sourceID 设置论文阅读状态（unread/reading/read/archived）
+func (a *App) SetPaperStatus(ctx context.Context, source, sourceID, status string) error {
+	if !models.ValidPaperStatus(status) {
+		return fmt.Errorf("无效的阅读状态: %s", status)
+	}
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return err
+	}
+	logger.Debug("设置阅读状态: %s/%s -> %s", source, sourceID, status)
+	return a.db.SetPaperStatus(paper.ID, status)
+}
+
+// GetPaperStatus 返回论文阅读状态，没有记录时为 unread
+func (a *App) GetPaperStatus(ctx context.Context, paperID int64) (string, error) {
+	return a.db.GetPaperStatus(paperID)
+}
+
+// GetPapersByStatus 分页列出指定阅读状态的论文，page 从 1 开始
+func (a *App) GetPapersByStatus(ctx context.Context, status string, page, pageSize int) ([]*models.Paper, int, error) {
+	offset := (page - 1) * pageSize
+	if offset < 0 {
+		offset = 0
+	}
+	return a.db.GetPapersByStatus(status, pageSize, offset)
+}
+
+// SetPaperStarred 按 source + sourceID 设置或取消论文收藏
+func (a *App) SetPaperStarred(ctx context.Context, source, sourceID string, starred bool) error {
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return err
+	}
+	logger.Debug("设置收藏: %s/%s -> %v", source, sourceID, starred)
+	return a.db.SetPaperStarred(paper.ID, starred)
+}
+
+// StatusConditions 生成按阅读状态与收藏过滤的查询条件，供导出等按 conditions 查询的接口使用；
+// status 为空表示不限
+func StatusConditions(status string, starred bool) ([]string, []interface{}, error) {
+	var conditions []string
+	var params []interface{}
+	if status != "" {
+		if !models.ValidPaperStatus(status) {
+			return nil, nil, fmt.Errorf("无效的阅读状态: %s", status)
+		}
+		c, p := dbsqlite.StatusCondition(status)
+		conditions = append(conditions, c)
+		params = append(params, p...)
+	}
+	if starred {
+		conditions = append(conditions, dbsqlite.StarredCondition())
+	}
+	return conditions, params, nil
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestExportPapers_StatusAndStarred(t *testing.T) {
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	ctx := context.Background()
+	papers := newPapers(3)
+	for _, p := range papers {
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+	if err := a.SetPaperStatus(ctx, "arxiv", papers[0].SourceID, models.StatusReading); err != nil {
+		t.Fatalf("SetPaperStatus() error: %v", err)
+	}
+	for _, p := range papers[:2] {
+		if err := a.SetPaperStarred(ctx, "arxiv", p.SourceID, true); err != nil {
+			t.Fatalf("SetPaperStarred() error: %v", err)
+		}
+	}
+	if err := a.SetPaperStarred(ctx, "arxiv", "9999.99999", true); err == nil {
+		t.Error("Expected error for missing paper")
+	}
+
+	conditions, params, err := StatusConditions("", true)
+	if err != nil {
+		t.Fatalf("StatusConditions() error: %v", err)
+	}
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "starred.csv")
+	if err := a.ExportPapers(ctx, "csv", csvPath, conditions, params, 0); err != nil {
+		t.Fatalf("ExportPapers(csv) error: %v", err)
+	}
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header + 2 starred papers, got %d rows", len(records))
+	}
+	header := records[0]
+	if header[len(header)-2] != "阅读状态" || header[len(header)-1] != "收藏" {
+		t.Errorf("Expected status columns at the end of header, got %v", header)
+	}
+	statuses := map[string]string{}
+	for _, r := range records[1:] {
+		statuses[r[2]] = r[len(r)-2] + "/" + r[len(r)-1]
+	}
+	if statuses[papers[0].SourceID] != "reading/是" || statuses[papers[1].SourceID] != "unread/是" {
+		t.Errorf("Unexpected status columns: %v", statuses)
+	}
+
+	conditions, params, err = StatusConditions(models.StatusReading, false)
+	if err != nil {
+		t.Fatalf("StatusConditions() error: %v", err)
+	}
+	jsonPath := filepath.Join(dir, "reading.json")
+	if err := a.ExportPapers(ctx, "json", jsonPath, conditions, params, 0); err != nil {
+		t.Fatalf("ExportPapers(json) error: %v", err)
+	}
+	data, err = os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("read json: %v", err)
+	}
+	var out struct {
+		Total  int
+		Papers []models.Paper
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	if out.Total != 1 || out.Papers[0].Status != models.StatusReading || !out.Papers[0].Starred {
+		t.Errorf("Expected one reading starred paper, got %+v", out)
+	}
+
+	if _, _, err := StatusConditions("done", false); err == nil {
+		t.Error("Expected error for invalid status")
+	}
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/translate"
+	"PaperHunter/pkg/logger"
+)
+
+// TranslateBatchSize 每次 LLM 调用翻译的论文数
+const TranslateBatchSize = 10
+
+// DefaultTranslateLang 未指定目标语言时翻译为中文
+const DefaultTranslateLang = "zh"
+
+// TranslateAbstracts 翻译论文的标题和摘要，结果写入 TitleTranslated / AbstractTranslated 并通过 SaveTranslation 保存；
+// 每批最多 TranslateBatchSize 篇，模型未返回译文的字段保持不变，本次运行中已译为 targetLang 的论文直接跳过
+func (a *App) TranslateAbstracts(ctx context.Context, papers []*models.Paper, targetLang string) error {
+	if a.Translator == nil {
+		return fmt.Errorf("未配置翻译服务")
+	}
+	if targetLang == "" {
+		targetLang = DefaultTranslateLang
+	}
+
+	pending := make([]*models.Paper, 0, len(papers))
+	for _, p := range papers {
+		if lang, ok := a.translated.Load(p.ID); ok && p.ID != 0 && lang == targetLang {
+			continue
+		}
+		pending = append(pending, p)
+	}
+	if skipped := len(papers) - len(pending); skipped > 0 {
+		logger.Debug("跳过 %d 篇已翻译的论文", skipped)
+	}
+
+	for start := 0; start < len(pending); start += TranslateBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch := pending[start:min(start+TranslateBatchSize, len(pending))]
+		items := make([]translate.Item, len(batch))
+		for i, p := range batch {
+			items[i] = translate.Item{Title: p.Title, Abstract: p.Abstract}
+		}
+
+		translated, err := a.Translator.Translate(ctx, items, targetLang)
+		if err != nil {
+			return fmt.Errorf("翻译第 %d-%d 篇失败: %w", start+1, start+len(batch), err)
+		}
+
+		for i, p := range batch {
+			if i >= len(translated) || !translate.Apply(p, translated[i]) {
+				logger.Warn("未返回译文: %s", p.Title)
+				continue
+			}
+			if err := a.saveTranslation(p, targetLang); err != nil {
+				return err
+			}
+		}
+	}
+	logger.Info("翻译完成: %d 篇论文 -> %s", len(pending), targetLang)
+	return nil
+}
+
+// TranslateMissing 按 id 顺序分批翻译尚无 lang 译文的论文并保存，返回成功翻译的篇数；
+// 未记录语言的旧译文视为已翻译，模型漏译的论文本次不再重试
+func (a *App) TranslateMissing(ctx context.Context, batchSize int, lang string) (int, error) {
+	if a.Translator == nil {
+		return 0, fmt.Errorf("未配置翻译服务")
+	}
+	if lang == "" {
+		lang = DefaultTranslateLang
+	}
+	if batchSize <= 0 {
+		batchSize = TranslateBatchSize
+	}
+
+	done := 0
+	var lastID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return done, err
+		}
+		papers, _, err := a.db.GetPapersList(batchSize, 0,
+			[]string{"id > ?", "(COALESCE(title_translated, '') = '' OR (translation_lang != '' AND translation_lang != ?))"},
+			[]interface{}{lastID, lang}, "id ASC")
+		if err != nil {
+			return done, fmt.Errorf("查询待翻译论文失败: %w", err)
+		}
+		if len(papers) == 0 {
+			break
+		}
+		lastID = papers[len(papers)-1].ID
+
+		if err := a.TranslateAbstracts(ctx, papers, lang); err != nil {
+			return done, err
+		}
+		for _, p := range papers {
+			if l, ok := a.translated.Load(p.ID); ok && l == lang {
+				done++
+			}
+		}
+	}
+	logger.Info("补全翻译完成: %d 篇论文 -> %s", done, lang)
+	return done, nil
+}
+
+// saveTranslation 保存译文并记入缓存；论文没有 ID 时按 source/source_id 查找
+func (a *App) saveTranslation(p *models.Paper, lang string) error {
+	if p.ID == 0 {
+		stored, err := a.lookupPaper(p.Source, p.SourceID)
+		if err != nil {
+			return fmt.Errorf("保存译文失败 [%s:%s]: %w", p.Source, p.SourceID, err)
+		}
+		p.ID = stored.ID
+	}
+	if err := a.db.SaveTranslation(p.ID, lang, p.TitleTranslated, p.AbstractTranslated); err != nil {
+		return fmt.Errorf("保存译文失败 [%s:%s]: %w", p.Source, p.SourceID, err)
+	}
+	a.translated.Store(p.ID, lang)
+	return nil
+}
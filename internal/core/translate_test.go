@@ -0,0 +1,203 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"PaperHunter/internal/translate"
+)
+
+// newMockLLM 模拟 OpenAI 兼容的 chat/completions 接口，把每条标题和摘要加上 "译:" 前缀返回
+func newMockLLM(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var req struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Messages) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var entries []map[string]any
+		if err := json.Unmarshal([]byte(req.Messages[len(req.Messages)-1].Content), &entries); err != nil {
+			http.Error(w, "bad payload", http.StatusBadRequest)
+			return
+		}
+		for _, e := range entries {
+			e["title"] = "译:" + e["title"].(string)
+			if abs, _ := e["abstract"].(string); abs != "" {
+				e["abstract"] = "译:" + abs
+			}
+		}
+		content, _ := json.Marshal(entries)
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":     "chatcmpl-test",
+			"object": "chat.completion",
+			"model":  "test-model",
+			"choices": []map[string]any{{
+				"index":         0,
+				"message":       map[string]any{"role": "assistant", "content": "```json\n" + string(content) + "\n```"},
+				"finish_reason": "stop",
+			}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestTranslateAbstracts(t *testing.T) {
+	srv, calls := newMockLLM(t)
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+
+	papers := newPapers(12)
+	for i, p := range papers {
+		if i%2 == 0 {
+			p.Abstract = "An abstract."
+		}
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+
+	if err := a.TranslateAbstracts(context.Background(), papers, "zh"); err == nil {
+		t.Error("Expected error without translator")
+	}
+
+	svc, err := translate.New(translate.Config{BaseURL: srv.URL, APIKey: "test-key", Model: "test-model"})
+	if err != nil {
+		t.Fatalf("translate.New() error: %v", err)
+	}
+	a.Translator = svc
+	if err := a.TranslateAbstracts(context.Background(), papers, "zh"); err != nil {
+		t.Fatalf("TranslateAbstracts() error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected 2 LLM calls for 12 papers, got %d", got)
+	}
+
+	stored, err := a.db.GetPapersByConditions([]string{"source = ?"}, []interface{}{"arxiv"}, 0)
+	if err != nil {
+		t.Fatalf("GetPapersByConditions() error: %v", err)
+	}
+	if len(stored) != len(papers) {
+		t.Fatalf("Expected %d stored papers, got %d", len(papers), len(stored))
+	}
+	for _, p := range stored {
+		if p.TitleTranslated != "译:"+p.Title {
+			t.Errorf("TitleTranslated = %q, want %q", p.TitleTranslated, "译:"+p.Title)
+		}
+		if p.Abstract != "" && p.AbstractTranslated != "译:"+p.Abstract {
+			t.Errorf("AbstractTranslated = %q, want %q", p.AbstractTranslated, "译:"+p.Abstract)
+		}
+		if p.Abstract == "" && strings.TrimSpace(p.AbstractTranslated) != "" {
+			t.Errorf("Expected empty AbstractTranslated for %s, got %q", p.SourceID, p.AbstractTranslated)
+		}
+	}
+}
+
+func TestTranslateMissing(t *testing.T) {
+	srv, calls := newMockLLM(t)
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	svc, err := translate.New(translate.Config{BaseURL: srv.URL, APIKey: "test-key", Model: "test-model"})
+	if err != nil {
+		t.Fatalf("translate.New() error: %v", err)
+	}
+
+	papers := newPapers(5)
+	for _, p := range papers {
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+	// 已有旧译文（未记录语言）的论文不再翻译
+	papers[0].TitleTranslated = "已有译文"
+	if _, err := a.db.Upsert(papers[0]); err != nil {
+		t.Fatalf("Upsert() error: %v", err)
+	}
+
+	if _, err := a.TranslateMissing(context.Background(), 2, "zh"); err == nil {
+		t.Error("Expected error without translator")
+	}
+	a.Translator = svc
+
+	n, err := a.TranslateMissing(context.Background(), 2, "zh")
+	if err != nil {
+		t.Fatalf("TranslateMissing() error: %v", err)
+	}
+	if n != 4 || calls.Load() != 2 {
+		t.Errorf("Expected 4 papers in 2 LLM calls, got %d papers in %d calls", n, calls.Load())
+	}
+
+	stored, _ := a.db.GetPapersByConditions([]string{"source = ?"}, []interface{}{"arxiv"}, 0)
+	for _, p := range stored {
+		want := "译:" + p.Title
+		if p.SourceID == papers[0].SourceID {
+			want = "已有译文"
+		}
+		if p.TitleTranslated != want {
+			t.Errorf("%s TitleTranslated = %q, want %q", p.SourceID, p.TitleTranslated, want)
+		}
+	}
+
+	// 译文已持久化，再次运行不调用 LLM；重新爬取也不会清空译文
+	for _, p := range newPapers(5) {
+		if _, err := a.db.Upsert(p); err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+	}
+	if n, err := a.TranslateMissing(context.Background(), 2, "zh"); err != nil || n != 0 {
+		t.Errorf("Second TranslateMissing() = %d, %v; want 0, nil", n, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected no further LLM calls, got %d total", got)
+	}
+
+	// 换一种语言时 zh 译文需要重新翻译，旧译文保持不变
+	if n, err := a.TranslateMissing(context.Background(), 10, "ja"); err != nil || n != 4 {
+		t.Errorf("TranslateMissing(ja) = %d, %v; want 4, nil", n, err)
+	}
+}
+
+func TestTranslateAbstracts_SkipsCachedPapers(t *testing.T) {
+	srv, calls := newMockLLM(t)
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	svc, err := translate.New(translate.Config{BaseURL: srv.URL, APIKey: "test-key", Model: "test-model"})
+	if err != nil {
+		t.Fatalf("translate.New() error: %v", err)
+	}
+	a.Translator = svc
+
+	papers := newPapers(3)
+	for _, p := range papers {
+		id, err := a.db.Upsert(p)
+		if err != nil {
+			t.Fatalf("Upsert() error: %v", err)
+		}
+		p.ID = id
+	}
+	for i := 0; i < 2; i++ {
+		if err := a.TranslateAbstracts(context.Background(), papers, "zh"); err != nil {
+			t.Fatalf("TranslateAbstracts() error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected cached papers to be skipped, got %d LLM calls", got)
+	}
+
+	if err := a.Translator.TranslatePaper(context.Background(), papers[0], "en"); err != nil {
+		t.Fatalf("TranslatePaper() error: %v", err)
+	}
+	if papers[0].TitleTranslated != "译:"+papers[0].Title {
+		t.Errorf("TranslatePaper() TitleTranslated = %q", papers[0].TitleTranslated)
+	}
+}
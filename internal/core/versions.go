@@ -0,0 +1,26 @@
+package core
+
+import (
+	"context"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// GetPaperVersions 按 source + sourceID 返回论文的版本历史，按提交时间从早到晚
+func (a *App) GetPaperVersions(ctx context.Context, source, sourceID string) ([]models.PaperVersion, error) {
+	paper, err := a.lookupPaper(source, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	return a.db.GetPaperVersions(paper.ID)
+}
+
+// saveVersions 记录抓取到的论文版本，失败只记录日志，不影响论文本身的保存
+func (a *App) saveVersions(p *models.Paper) {
+	for _, v := range p.VersionHistory {
+		if err := a.db.SavePaperVersion(p.ID, v); err != nil {
+			logger.Warn("保存论文版本失败 [%s %s]: %v", p.SourceID, v.Version, err)
+		}
+	}
+}
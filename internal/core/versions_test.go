@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+// feedPlatform 每次搜索返回 papers 的副本，测试中可在两次爬取之间替换
+type feedPlatform struct {
+	papers []*models.Paper
+}
+
+func (p *feedPlatform) Name() string               { return "feed" }
+func (p *feedPlatform) GetConfig() platform.Config { return stubConfig{} }
+func (p *feedPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	papers := make([]*models.Paper, len(p.papers))
+	for i, paper := range p.papers {
+		cp := *paper
+		papers[i] = &cp
+	}
+	return platform.Result{Papers: papers}, nil
+}
+
+// versionsFeed stub-versions 平台返回的论文，由测试在每次爬取前设置
+var versionsFeed = &feedPlatform{}
+
+func init() {
+	MustRegister(Provider{
+		Name:          "stub-versions",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return versionsFeed, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func TestCrawl_RecordsPaperVersions(t *testing.T) {
+	feed := versionsFeed
+	a := newEmbeddingApp(t, &fakeEmbedder{})
+	a.platformCfg = map[string]platform.Config{}
+	ctx := context.Background()
+
+	crawl := func(version, abstract string, updated time.Time) {
+		t.Helper()
+		feed.papers = []*models.Paper{{
+			Source: "stub-versions", SourceID: "2403.00001", URL: "https://arxiv.org/abs/2403.00001",
+			Title: "Versioned Paper", Abstract: abstract, LatestVersion: version,
+			VersionHistory: []models.PaperVersion{{Version: version, SubmittedAt: updated, ChangeNote: abstract}},
+		}}
+		if _, err := a.Crawl(ctx, "stub-versions", platform.Query{}); err != nil {
+			t.Fatalf("Crawl(%s) error: %v", version, err)
+		}
+	}
+	v1At := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	v2At := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	crawl("v1", "First abstract.", v1At)
+	crawl("v2", "Revised abstract.", v2At)
+
+	if n, _ := a.db.CountPapers(nil, nil); n != 1 {
+		t.Fatalf("Expected both crawls to update the same paper, got %d papers", n)
+	}
+	paper, err := a.db.GetPaperBySourceID("stub-versions", "2403.00001")
+	if err != nil {
+		t.Fatalf("GetPaperBySourceID() error: %v", err)
+	}
+	if paper.Abstract != "Revised abstract." {
+		t.Errorf("Expected latest abstract to be stored, got %q", paper.Abstract)
+	}
+
+	versions, err := a.GetPaperVersions(ctx, "stub-versions", "2403.00001")
+	if err != nil {
+		t.Fatalf("GetPaperVersions() error: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 version entries, got %+v", versions)
+	}
+	if versions[0].Version != "v1" || !versions[0].SubmittedAt.Equal(v1At) ||
+		versions[1].Version != "v2" || !versions[1].SubmittedAt.Equal(v2At) || versions[1].ChangeNote != "Revised abstract." {
+		t.Errorf("Unexpected version history: %+v", versions)
+	}
+
+	// 重复爬取同一版本不会新增记录
+	crawl("v2", "Revised abstract.", v2At)
+	if versions, _ := a.GetPaperVersions(ctx, "stub-versions", "2403.00001"); len(versions) != 2 {
+		t.Errorf("Expected re-crawling v2 to keep 2 entries, got %d", len(versions))
+	}
+}
@@ -0,0 +1,89 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MaxConcurrentCrawls 多平台爬取时同时运行的任务上限
+const MaxConcurrentCrawls = 4
+
+// TaskGroup 一次多平台爬取启动的一组任务
+type TaskGroup struct {
+	TaskIDs []string
+	s       *Service
+}
+
+// GroupSummary 任务组全部结束后的汇总
+type GroupSummary struct {
+	TaskIDs    []string          `json:"task_ids"`
+	Statuses   map[string]string `json:"statuses"` // taskID -> completed/failed/cancelled
+	TotalCount int               `json:"total_count"`
+}
+
+// StartTaskGroup 为每个平台创建一个任务，登记全部任务后再启动，最多 MaxConcurrentCrawls 个同时执行；
+// 单个任务失败不影响其他任务
+func (s *Service) StartTaskGroup(platforms []string, params map[string]map[string]interface{}) (*TaskGroup, error) {
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("no platforms specified")
+	}
+	seen := make(map[string]bool, len(platforms))
+	for _, name := range platforms {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("platform name is empty")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate platform: %s", name)
+		}
+		seen[name] = true
+	}
+
+	group := &TaskGroup{s: s}
+	sem := make(chan struct{}, MaxConcurrentCrawls)
+	for _, name := range platforms {
+		ctx, task := s.newTask(name, params[name])
+		group.TaskIDs = append(group.TaskIDs, task.ID)
+
+		go func() {
+			// 排队期间被取消时直接执行，由 executeCrawlTask 记录为 cancelled
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+			}
+			s.executeCrawlTask(ctx, task)
+		}()
+	}
+	return group, nil
+}
+
+// WaitAll 等待组内所有任务结束，返回 taskID -> 任务；ctx 取消时提前返回
+func (g *TaskGroup) WaitAll(ctx context.Context) (map[string]*Task, error) {
+	tasks := make(map[string]*Task, len(g.TaskIDs))
+	for _, id := range g.TaskIDs {
+		task, err := g.s.GetTask(id)
+		if err != nil {
+			return nil, err
+		}
+		select {
+		case <-task.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		tasks[id] = task
+	}
+	return tasks, nil
+}
+
+// Summarize 汇总已结束任务的状态与获取的论文总数
+func Summarize(taskIDs []string, tasks map[string]*Task) GroupSummary {
+	summary := GroupSummary{TaskIDs: taskIDs, Statuses: make(map[string]string, len(tasks))}
+	for id, task := range tasks {
+		task.mu.RLock()
+		summary.Statuses[id] = task.Status
+		summary.TotalCount += task.TotalCount
+		task.mu.RUnlock()
+	}
+	return summary
+}
@@ -0,0 +1,184 @@
+package crawl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// historyPath 旧版 jsonl 历史文件路径
+func (s *Service) historyPath() string {
+	return filepath.Join(s.dir(), "crawl_history.jsonl")
+}
+
+// saveTaskHistory 将结束的任务（完成、取消或失败）写入 crawl_stats 表，并持久化入库记录
+func (s *Service) saveTaskHistory(task *Task) {
+	task.mu.RLock()
+	defer task.mu.RUnlock()
+	if task.EndTime == nil {
+		return
+	}
+	switch task.Status {
+	case StatusCompleted, StatusCancelled, StatusFailed:
+	default:
+		return
+	}
+	ids := make([]int64, 0, len(task.Inserted))
+	for _, ref := range task.Inserted {
+		ids = append(ids, ref.PaperID)
+	}
+	entry := models.CrawlHistory{
+		TaskID:      task.ID,
+		Platform:    task.Platform,
+		Params:      task.Params,
+		Total:       task.TotalCount,
+		StartTime:   task.StartTime,
+		EndTime:     *task.EndTime,
+		Status:      task.Status,
+		InsertedIDs: ids,
+	}
+	if app := s.app(); app == nil {
+		logger.Warn("写入历史失败: 核心模块未初始化")
+	} else if err := app.RecordCrawlStats(context.Background(), entry); err != nil {
+		logger.Warn("写入历史失败: %v", err)
+	}
+
+	s.persistTask(task)
+}
+
+// LoadHistory 从 crawl_stats 表读取历史记录（最新在前），limit=0 表示全部
+func (s *Service) LoadHistory(limit int) ([]models.CrawlHistory, error) {
+	app := s.app()
+	if app == nil {
+		return nil, fmt.Errorf("core app not initialized")
+	}
+	return app.GetCrawlStats(context.Background(), limit)
+}
+
+// PurgeHistory 删除开始时间早于 olderThanDays 天前的历史及其对应的任务文件，0 表示全部删除
+func (s *Service) PurgeHistory(olderThanDays int) error {
+	history, err := s.LoadHistory(0)
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().AddDate(0, 0, -olderThanDays)
+	if err := s.app().PurgeCrawlStats(context.Background(), olderThanDays); err != nil {
+		return err
+	}
+	for _, h := range history {
+		if olderThanDays == 0 || h.StartTime.Before(cutoff) {
+			s.removePersistedTask(h.TaskID)
+		}
+	}
+	return nil
+}
+
+// MigrateHistoryFile 将旧版 jsonl 历史导入 crawl_stats 表，导入后文件改名为 .imported 避免重复导入
+func (s *Service) MigrateHistoryFile() error {
+	path := s.historyPath()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	app := s.app()
+	if app == nil {
+		return fmt.Errorf("core app not initialized")
+	}
+
+	imported := 0
+	for _, line := range bytes.Split(bytes.TrimSpace(content), []byte{'\n'}) {
+		var h models.CrawlHistory
+		if err := json.Unmarshal(line, &h); err != nil || h.TaskID == "" {
+			continue
+		}
+		// 旧版只记录完成的任务，入库论文 ID 从任务文件中补齐
+		h.Status = StatusCompleted
+		if t, err := s.LoadPersistedTask(h.TaskID); err == nil {
+			for _, ref := range t.Inserted {
+				h.InsertedIDs = append(h.InsertedIDs, ref.PaperID)
+			}
+		}
+		if err := app.RecordCrawlStats(context.Background(), h); err != nil {
+			return err
+		}
+		imported++
+	}
+	if err := os.Rename(path, path+".imported"); err != nil {
+		return err
+	}
+	logger.Info("已将 %d 条爬取历史导入数据库", imported)
+	return nil
+}
+
+// taskDataPath 任务持久化文件路径
+func (s *Service) taskDataPath(taskID string) string {
+	return filepath.Join(s.dir(), fmt.Sprintf("%s.json", taskID))
+}
+
+// persistTask 将已完成或已取消任务的插入记录持久化，调用方需持有 task.mu
+func (s *Service) persistTask(task *Task) {
+	if (task.Status != StatusCompleted && task.Status != StatusCancelled) || len(task.Inserted) == 0 || task.EndTime == nil {
+		return
+	}
+	data, err := json.Marshal(PersistedTask{
+		TaskID:    task.ID,
+		Platform:  task.Platform,
+		Inserted:  task.Inserted,
+		StartTime: task.StartTime,
+		EndTime:   *task.EndTime,
+	})
+	if err != nil {
+		logger.Warn("持久化任务失败(序列化): %v", err)
+		return
+	}
+	path := s.taskDataPath(task.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logger.Warn("持久化任务失败(创建目录): %v", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		logger.Warn("持久化任务失败(写文件): %v", err)
+	}
+}
+
+// validTaskID 任务 ID 会拼进文件路径，拒绝可能跳出数据目录的 ID
+func validTaskID(taskID string) bool {
+	return taskID != "" && !strings.HasPrefix(taskID, ".") && !strings.ContainsAny(taskID, `/\`)
+}
+
+// LoadPersistedTask 从磁盘读取任务数据（仅包含插入记录等）
+func (s *Service) LoadPersistedTask(taskID string) (*PersistedTask, error) {
+	if !validTaskID(taskID) {
+		return nil, fmt.Errorf("无效的任务 ID: %q", taskID)
+	}
+	data, err := os.ReadFile(s.taskDataPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+	var t PersistedTask
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// removePersistedTask 删除任务持久化文件，文件不存在时忽略
+func (s *Service) removePersistedTask(taskID string) {
+	if !validTaskID(taskID) {
+		return
+	}
+	if err := os.Remove(s.taskDataPath(taskID)); err != nil && !os.IsNotExist(err) {
+		logger.Warn("删除任务文件失败: %v", err)
+	}
+}
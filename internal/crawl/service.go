@@ -0,0 +1,419 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/logger"
+)
+
+// subscriberBuffer 每个订阅者的日志缓冲，订阅时已有的日志额外占用缓冲
+const subscriberBuffer = 100
+
+// Service 爬取服务
+type Service struct {
+	coreApp func() *core.App
+	dataDir func() string
+
+	// OnLog 每条任务日志的回调（如桌面端转发为前端事件），在写日志的 goroutine 中同步调用
+	OnLog func(LogEntry)
+
+	mu    sync.RWMutex
+	tasks map[string]*Task
+
+	// subsMu 保护订阅者集合，并保证日志追加与推送的顺序一致
+	subsMu sync.Mutex
+	subs   map[string]map[chan LogEntry]struct{}
+	logSeq int64
+}
+
+// NewService 创建爬取服务；coreApp 每次使用时调用，以便配置重新加载后使用新的核心模块；
+// dataDir 为任务持久化文件与旧版历史所在目录，为空时使用 ~/.quicksearch/data
+func NewService(coreApp func() *core.App, dataDir func() string) *Service {
+	return &Service{
+		coreApp: coreApp,
+		dataDir: dataDir,
+		tasks:   make(map[string]*Task),
+		subs:    make(map[string]map[chan LogEntry]struct{}),
+	}
+}
+
+// app 当前的核心模块，未初始化时为 nil
+func (s *Service) app() *core.App {
+	if s.coreApp == nil {
+		return nil
+	}
+	return s.coreApp()
+}
+
+// StartCrawl 开始爬取任务；dryRun 为 true 时只检索不入库，任务以 preview 状态结束并携带样例论文
+func (s *Service) StartCrawl(platformName string, params map[string]interface{}, dryRun bool) (string, error) {
+	ctx, task := s.newTask(platformName, params)
+	task.DryRun = dryRun
+
+	// 异步执行爬取任务
+	if dryRun {
+		go s.executePreviewTask(ctx, task)
+	} else {
+		go s.executeCrawlTask(ctx, task)
+	}
+
+	return task.ID, nil
+}
+
+// newTask 创建 pending 状态的任务并登记，返回任务的可取消 context
+func (s *Service) newTask(platformName string, params map[string]interface{}) (context.Context, *Task) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 同一纳秒内创建多个任务时（批量启动）避免 ID 冲突
+	taskID := fmt.Sprintf("crawl_%d", time.Now().UnixNano())
+	for i := 1; s.tasks[taskID] != nil; i++ {
+		taskID = fmt.Sprintf("crawl_%d_%d", time.Now().UnixNano(), i)
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	task := &Task{
+		ID:        taskID,
+		Platform:  platformName,
+		Params:    params,
+		Status:    StatusPending,
+		StartTime: time.Now(),
+		Logs:      make([]LogEntry, 0),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	s.tasks[taskID] = task
+	return ctx, task
+}
+
+// GetTask 获取任务，读取字段请使用 Snapshot 或 json.Marshal
+func (s *Service) GetTask(taskID string) (*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[taskID]
+	if !exists {
+		return nil, fmt.Errorf("task not found: %s", taskID)
+	}
+	return task, nil
+}
+
+// CancelCrawl 取消进行中的爬取任务，已入库的论文仍保留在任务记录中
+func (s *Service) CancelCrawl(taskID string) error {
+	task, err := s.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+
+	task.mu.RLock()
+	status, cancel := task.Status, task.cancel
+	task.mu.RUnlock()
+	if (status != StatusPending && status != StatusRunning) || cancel == nil {
+		return fmt.Errorf("task is not running: %s", taskID)
+	}
+
+	s.addLog(task, "warning", "正在取消爬取任务...")
+	cancel()
+	return nil
+}
+
+// GetTaskLogs 获取任务日志
+func (s *Service) GetTaskLogs(taskID string) ([]LogEntry, error) {
+	task, err := s.GetTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+	task.mu.RLock()
+	defer task.mu.RUnlock()
+	return append([]LogEntry(nil), task.Logs...), nil
+}
+
+// Subscribe 订阅任务日志：先收到订阅前已有的日志，之后按顺序收到新日志，任务结束后通道关闭；
+// 订阅者消费过慢、缓冲占满时通道被提前关闭而不是丢弃日志，调用方可通过任务状态区分两种关闭。
+// 返回的函数用于提前取消订阅
+func (s *Service) Subscribe(taskID string) (<-chan LogEntry, func(), error) {
+	task, err := s.GetTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+
+	task.mu.RLock()
+	ch := make(chan LogEntry, subscriberBuffer+len(task.Logs))
+	for _, entry := range task.Logs {
+		ch <- entry
+	}
+	finished := task.finished()
+	task.mu.RUnlock()
+	if finished {
+		close(ch)
+		return ch, func() {}, nil
+	}
+
+	if s.subs[taskID] == nil {
+		s.subs[taskID] = make(map[chan LogEntry]struct{})
+	}
+	s.subs[taskID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		s.subsMu.Lock()
+		defer s.subsMu.Unlock()
+		if _, ok := s.subs[taskID][ch]; ok {
+			delete(s.subs[taskID], ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe, nil
+}
+
+// closeSubscribers 任务结束后关闭全部订阅
+func (s *Service) closeSubscribers(taskID string) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	for ch := range s.subs[taskID] {
+		close(ch)
+	}
+	delete(s.subs, taskID)
+}
+
+// executeCrawlTask 执行爬取任务，ctx 取消时任务标记为 cancelled
+func (s *Service) executeCrawlTask(ctx context.Context, task *Task) {
+	defer task.cancel()
+	defer close(task.done)
+	defer s.closeSubscribers(task.ID)
+
+	task.mu.Lock()
+	task.Status = StatusRunning
+	task.mu.Unlock()
+
+	s.addLog(task, "info", fmt.Sprintf("开始从 %s 爬取论文...", task.Platform))
+
+	app := s.app()
+	if app == nil {
+		s.finishTask(task, 0, fmt.Errorf("core app not initialized"), false)
+		return
+	}
+
+	query := app.BuildQuery(task.Platform, task.Params)
+	// 平台的耗时阶段（如 SSRN 补抓摘要）通过 ctx 写入任务日志
+	ctx = platform.WithStatus(ctx, func(message string) {
+		s.addLog(task, "info", message)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(3 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.addLog(task, "debug", "抓取进行中...")
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// 带进度回调，逐条记录 URL
+	count, err := app.CrawlWithProgress(ctx, task.Platform, query, func(idx int, total int, p *models.Paper, paperID int64) {
+		if p == nil {
+			return
+		}
+		// 记录入库成功的论文引用，便于一键导出
+		task.mu.Lock()
+		task.Progress = idx + 1
+		task.TotalCount = total
+		task.Inserted = append(task.Inserted, PaperRef{
+			Source:   p.Source,
+			SourceID: p.SourceID,
+			URL:      p.URL,
+			PaperID:  paperID,
+		})
+		task.mu.Unlock()
+
+		s.addLog(task, "debug", fmt.Sprintf("[%d/%d] %s", idx+1, total, p.URL))
+	})
+
+	// 停止心跳
+	close(done)
+
+	s.finishTask(task, count, err, err != nil && ctx.Err() != nil)
+	s.saveTaskHistory(task)
+}
+
+// finishTask 设置任务的终态并记录最后一条日志
+func (s *Service) finishTask(task *Task, count int, err error, cancelled bool) {
+	task.mu.Lock()
+	now := time.Now()
+	task.EndTime = &now
+	switch {
+	case cancelled:
+		task.Status = StatusCancelled
+		task.TotalCount = len(task.Inserted)
+	case err != nil:
+		task.Status = StatusFailed
+		task.Error = err.Error()
+	default:
+		task.Status = StatusCompleted
+		task.TotalCount = count
+	}
+	total := task.TotalCount
+	task.mu.Unlock()
+
+	switch {
+	case cancelled:
+		s.addLog(task, "warning", fmt.Sprintf("爬取已取消，已保存 %d 篇论文", total), total)
+	case err != nil:
+		s.addLog(task, "error", fmt.Sprintf("爬取失败: %v", err))
+	default:
+		s.addLog(task, "success", fmt.Sprintf("爬取完成！共获取 %d 篇论文", count), count)
+	}
+}
+
+// executePreviewTask 执行预览任务：只检索不入库，不写入任务历史
+func (s *Service) executePreviewTask(ctx context.Context, task *Task) {
+	defer task.cancel()
+	defer close(task.done)
+	defer s.closeSubscribers(task.ID)
+
+	task.mu.Lock()
+	task.Status = StatusRunning
+	task.mu.Unlock()
+
+	s.addLog(task, "info", fmt.Sprintf("开始预览 %s 爬取结果...", task.Platform))
+	var res platform.Result
+	err := fmt.Errorf("core app not initialized")
+	if app := s.app(); app != nil {
+		res, err = app.DryRunCrawl(ctx, task.Platform, app.BuildQuery(task.Platform, task.Params))
+	}
+
+	task.mu.Lock()
+	now := time.Now()
+	task.EndTime = &now
+	switch {
+	case err != nil && ctx.Err() != nil:
+		task.Status = StatusCancelled
+	case err != nil:
+		task.Status = StatusFailed
+		task.Error = err.Error()
+	default:
+		preview := NewPreview(res)
+		task.Status = StatusPreview
+		task.TotalCount = preview.Total
+		task.Sample = preview.Sample
+	}
+	status := task.Status
+	task.mu.Unlock()
+
+	switch status {
+	case StatusCancelled:
+		s.addLog(task, "warning", "预览已取消")
+	case StatusFailed:
+		s.addLog(task, "error", fmt.Sprintf("预览失败: %v", err))
+	default:
+		s.addLog(task, "success", fmt.Sprintf("预览完成，将获取 %d 篇论文（未入库）", res.Total), res.Total)
+	}
+}
+
+// addLog 记录任务日志并按顺序推送给订阅者；订阅者缓冲已满时关闭其通道，避免阻塞爬取或丢失日志
+func (s *Service) addLog(task *Task, level, message string, count ...int) {
+	s.subsMu.Lock()
+	s.logSeq++
+	entry := LogEntry{
+		ID:        fmt.Sprintf("log_%d_%d", time.Now().UnixNano(), s.logSeq),
+		Timestamp: time.Now(),
+		Level:     level,
+		Message:   message,
+		Platform:  task.Platform,
+		TaskID:    task.ID,
+	}
+	if len(count) > 0 {
+		entry.Count = count[0]
+	}
+
+	task.mu.Lock()
+	task.Logs = append(task.Logs, entry)
+	task.mu.Unlock()
+
+	for ch := range s.subs[task.ID] {
+		select {
+		case ch <- entry:
+		default:
+			logger.Warn("任务 %s 的日志订阅者消费过慢，已断开", task.ID)
+			delete(s.subs[task.ID], ch)
+			close(ch)
+		}
+	}
+	s.subsMu.Unlock()
+
+	// 记录到系统日志
+	switch level {
+	case "error":
+		logger.Error("[%s] %s", task.Platform, message)
+	case "warning":
+		logger.Warn("[%s] %s", task.Platform, message)
+	case "success":
+		logger.Info("[%s] %s", task.Platform, message)
+	default:
+		logger.Debug("[%s] %s", task.Platform, message)
+	}
+
+	if s.OnLog != nil {
+		s.OnLog(entry)
+	}
+}
+
+// GetAllTasks 获取所有任务
+func (s *Service) GetAllTasks() []*Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// CleanupCompletedTasks 清理 24 小时前结束的任务
+func (s *Service) CleanupCompletedTasks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	for id, task := range s.tasks {
+		task.mu.RLock()
+		expired := task.finished() && task.EndTime != nil && task.EndTime.Before(cutoff)
+		task.mu.RUnlock()
+		if expired {
+			delete(s.tasks, id)
+		}
+	}
+}
+
+// dir 任务持久化文件与旧版历史所在目录
+func (s *Service) dir() string {
+	if s.dataDir != nil {
+		if dir := s.dataDir(); dir != "" {
+			return dir
+		}
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".quicksearch", "data")
+}
@@ -0,0 +1,173 @@
+package crawl
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/core"
+	emb "PaperHunter/internal/embedding"
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+type stubConfig struct{}
+
+func (stubConfig) Validate() error    { return nil }
+func (stubConfig) RateLimit() float64 { return 0 }
+
+// blockingPlatform 一直阻塞到 context 取消，返回已取消错误
+type blockingPlatform struct{}
+
+func (blockingPlatform) Name() string               { return "stub-blocking" }
+func (blockingPlatform) GetConfig() platform.Config { return stubConfig{} }
+func (blockingPlatform) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	<-ctx.Done()
+	return platform.Result{Papers: []*models.Paper{}}, ctx.Err()
+}
+
+func init() {
+	core.MustRegister(core.Provider{
+		Name:          "stub-blocking",
+		New:           func(cfg platform.Config) (platform.Platform, error) { return blockingPlatform{}, nil },
+		DefaultConfig: func() platform.Config { return stubConfig{} },
+	})
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	dir := t.TempDir()
+	app, err := core.NewApp(filepath.Join(dir, "test.db"), emb.EmbedderConfig{}, map[string]platform.Config{}, core.ZoteroConfig{}, core.FeiShuConfig{}, core.NotionConfig{})
+	if err != nil {
+		t.Fatalf("NewApp() error: %v", err)
+	}
+	t.Cleanup(func() { app.Close() })
+	return NewService(func() *core.App { return app }, func() string { return dir })
+}
+
+// startBlocking 启动 stub-blocking 任务并等待其进入 running，测试结束前取消并等待任务退出
+func startBlocking(t *testing.T, s *Service) *Task {
+	t.Helper()
+	taskID, err := s.StartCrawl("stub-blocking", nil, false)
+	if err != nil {
+		t.Fatalf("StartCrawl() error: %v", err)
+	}
+	task, err := s.GetTask(taskID)
+	if err != nil {
+		t.Fatalf("GetTask() error: %v", err)
+	}
+	t.Cleanup(func() {
+		task.cancel()
+		<-task.Done()
+	})
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if task.Snapshot().Status == StatusRunning {
+			return task
+		}
+	}
+	t.Fatalf("task did not start, status %s", task.Snapshot().Status)
+	return nil
+}
+
+func TestCancelCrawl_KeepsInserted(t *testing.T) {
+	s := newTestService(t)
+	task := startBlocking(t, s)
+
+	// 模拟已入库的论文，取消后应保留以便导出
+	task.mu.Lock()
+	task.Inserted = append(task.Inserted, PaperRef{Source: "stub", SourceID: "stub-0"})
+	task.mu.Unlock()
+
+	if err := s.CancelCrawl(task.ID); err != nil {
+		t.Fatalf("CancelCrawl() error: %v", err)
+	}
+	<-task.Done()
+
+	got := task.Snapshot()
+	if got.Status != StatusCancelled || len(got.Inserted) != 1 || got.TotalCount != 1 || got.EndTime == nil {
+		t.Errorf("Unexpected cancelled task: status=%s inserted=%d total=%d", got.Status, len(got.Inserted), got.TotalCount)
+	}
+	last := got.Logs[len(got.Logs)-1]
+	if last.Level != "warning" || last.Message != fmt.Sprintf("爬取已取消，已保存 %d 篇论文", 1) {
+		t.Errorf("Expected cancel log entry, got %+v", last)
+	}
+	if _, err := s.LoadPersistedTask(task.ID); err != nil {
+		t.Errorf("Expected cancelled task to be persisted: %v", err)
+	}
+	if err := s.CancelCrawl(task.ID); err == nil {
+		t.Error("Expected error when cancelling a finished task")
+	}
+}
+
+func TestSubscribe_ReplaysInOrder(t *testing.T) {
+	s := newTestService(t)
+	task := startBlocking(t, s)
+
+	logs, unsubscribe, err := s.Subscribe(task.ID)
+	if err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	defer unsubscribe()
+	s.addLog(task, "info", "after subscribe")
+
+	before, _ := s.GetTaskLogs(task.ID)
+	for i, want := range before {
+		select {
+		case got := <-logs:
+			if got.ID != want.ID {
+				t.Fatalf("entry %d = %+v, want %+v", i, got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("entry %d not delivered", i)
+		}
+	}
+	if before[len(before)-1].Message != "after subscribe" {
+		t.Errorf("Expected last entry to be the new log, got %+v", before[len(before)-1])
+	}
+}
+
+func TestSubscribe_SlowSubscriberClosed(t *testing.T) {
+	s := newTestService(t)
+	task := startBlocking(t, s)
+
+	logs, unsubscribe, err := s.Subscribe(task.ID)
+	if err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	defer unsubscribe()
+	for i := 0; i < 2*subscriberBuffer; i++ {
+		s.addLog(task, "info", fmt.Sprintf("log %d", i))
+	}
+
+	var received int
+	for range logs {
+		received++
+	}
+	all, _ := s.GetTaskLogs(task.ID)
+	if received >= len(all) {
+		t.Errorf("Expected slow subscriber to be closed early, received %d of %d", received, len(all))
+	}
+	if task.Snapshot().Status != StatusRunning {
+		t.Errorf("Expected task still running, got %s", task.Snapshot().Status)
+	}
+	// 日志不丢失，重新订阅可拿到完整日志
+	again, unsubscribeAgain, err := s.Subscribe(task.ID)
+	if err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	defer unsubscribeAgain()
+	if len(again) != len(all) {
+		t.Errorf("Expected %d replayed entries, got %d", len(all), len(again))
+	}
+}
+
+func TestLoadPersistedTask_RejectsPathTraversal(t *testing.T) {
+	s := NewService(nil, func() string { return t.TempDir() })
+	for _, id := range []string{"", "../test", "..", "a/b", `a\\b`} {
+		if _, err := s.LoadPersistedTask(id); err == nil {
+			t.Errorf("Expected task ID %q to be rejected", id)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Package crawl 管理后台爬取任务：创建、取消、日志订阅、任务组并发控制与历史记录，
+// 桌面端与 HTTP 服务共用
+package crawl
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+// 任务状态
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusPreview   = "preview"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// Task 爬取任务
+type Task struct {
+	ID         string                 `json:"id"`
+	Platform   string                 `json:"platform"`
+	Params     map[string]interface{} `json:"params"`
+	Status     string                 `json:"status"` // pending, running, completed, preview, failed, cancelled
+	DryRun     bool                   `json:"dry_run"`
+	Progress   int                    `json:"progress"`
+	TotalCount int                    `json:"total_count"`
+	StartTime  time.Time              `json:"start_time"`
+	EndTime    *time.Time             `json:"end_time,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Logs       []LogEntry             `json:"logs"`
+	Inserted   []PaperRef             `json:"inserted,omitempty"`
+	Sample     []*models.Paper        `json:"sample,omitempty"` // 预览任务的样例论文
+	cancel     context.CancelFunc
+	done       chan struct{} // 任务执行结束时关闭
+	mu         sync.RWMutex
+}
+
+// MarshalJSON 在读锁内序列化，任务执行期间也可安全读取
+func (t *Task) MarshalJSON() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	type task Task
+	return json.Marshal((*task)(t))
+}
+
+// Snapshot 返回任务当前状态的副本，不含取消与结束信号
+func (t *Task) Snapshot() *Task {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return &Task{
+		ID:         t.ID,
+		Platform:   t.Platform,
+		Params:     t.Params,
+		Status:     t.Status,
+		DryRun:     t.DryRun,
+		Progress:   t.Progress,
+		TotalCount: t.TotalCount,
+		StartTime:  t.StartTime,
+		EndTime:    t.EndTime,
+		Error:      t.Error,
+		Logs:       append([]LogEntry(nil), t.Logs...),
+		Inserted:   append([]PaperRef(nil), t.Inserted...),
+		Sample:     append([]*models.Paper(nil), t.Sample...),
+	}
+}
+
+// Done 任务执行结束（任意终态）时关闭
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// finished 任务是否已结束，调用方需持有 t.mu
+func (t *Task) finished() bool {
+	switch t.Status {
+	case StatusCompleted, StatusPreview, StatusFailed, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// LogEntry 任务日志条目
+type LogEntry struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"` // info, success, warning, error, debug
+	Message   string    `json:"message"`
+	Platform  string    `json:"platform,omitempty"`
+	Count     int       `json:"count,omitempty"`
+	TaskID    string    `json:"task_id,omitempty"`
+}
+
+// PaperRef 记录任务成功入库的论文引用，便于一键导出
+type PaperRef struct {
+	Source   string `json:"source"`
+	SourceID string `json:"source_id"`
+	URL      string `json:"url"`
+	PaperID  int64  `json:"paper_id"`
+}
+
+// PersistedTask 持久化到磁盘的任务入库记录，重启后仍可导出
+type PersistedTask struct {
+	TaskID    string     `json:"task_id"`
+	Platform  string     `json:"platform"`
+	Inserted  []PaperRef `json:"inserted"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   time.Time  `json:"end_time"`
+}
+
+// PreviewSampleSize 预览爬取返回的样例论文数
+const PreviewSampleSize = 5
+
+// Preview 预览爬取结果：将获取的论文总数与前几篇样例
+type Preview struct {
+	Total  int             `json:"total"`
+	Sample []*models.Paper `json:"sample"`
+}
+
+// NewPreview 由检索结果生成预览，样例最多 PreviewSampleSize 篇
+func NewPreview(res platform.Result) Preview {
+	sample := res.Papers
+	if len(sample) > PreviewSampleSize {
+		sample = sample[:PreviewSampleSize]
+	}
+	return Preview{Total: res.Total, Sample: sample}
+}
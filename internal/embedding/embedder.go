@@ -2,19 +2,42 @@ package embedding
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cloudwego/eino-ext/components/embedding/openai"
 
 	"PaperHunter/internal/models"
+	"PaperHunter/pkg/httplimit"
+	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/metrics"
 )
 
+// defaultBaseURL 未配置 baseurl 时使用的 OpenAI 接口地址
+const defaultBaseURL = "https://api.openai.com/v1"
+
+// validateTimeout 后台校验模型名、探测向量维度的超时时间
+const validateTimeout = 10 * time.Second
+
+// ErrModelNotFound 配置的模型不在接口返回的模型列表中
+var ErrModelNotFound = errors.New("模型不存在")
+
 type EmbedderConfig struct {
 	BaseURL   string `mapstructure:"baseurl" yaml:"baseurl"`
 	APIKey    string `mapstructure:"apikey" yaml:"apikey"`
 	ModelName string `mapstructure:"model" yaml:"model"`
 	Dim       int    `mapstructure:"dim" yaml:"dim"`
+
+	UseVectorIndex bool `mapstructure:"use_vector_index" yaml:"use_vector_index"` // 语义检索使用内存 HNSW 索引代替全表扫描
+	CacheSize      int  `mapstructure:"cache_size" yaml:"cache_size"`             // 全表扫描时缓存的向量数（LRU），0 表示不缓存
+	Quantize       bool `mapstructure:"quantize" yaml:"quantize"`                 // 以 int8 量化存储新生成的向量，占用空间约为 float32 的 1/4
 }
 
 type Service interface {
@@ -22,11 +45,22 @@ type Service interface {
 	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
 	ModelName() string
 	Dim() int
+	// ValidateModel 查询接口的模型列表，确认配置的模型名存在
+	ValidateModel(ctx context.Context) error
 }
 
 type openaiAdapter struct {
 	cfg   EmbedderConfig
 	inner *openai.Embedder
+	warnf func(format string, v ...interface{})
+
+	// mu 保护后台校验写入的 dim 与 modelErr
+	mu  sync.RWMutex
+	dim int
+	// modelErr 后台校验发现模型不存在时设置，之后的向量请求直接返回该错误
+	modelErr error
+	// validated 后台校验结束后关闭
+	validated chan struct{}
 }
 
 func New(cfg EmbedderConfig) (Service, error) {
@@ -45,20 +79,179 @@ func New(cfg EmbedderConfig) (Service, error) {
 		APIKey:  cfg.APIKey,
 		Model:   cfg.ModelName,
 		BaseURL: cfg.BaseURL,
+		// 向量请求同样计入全局并发预算
+		HTTPClient: httplimit.Client(0),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("创建向量服务失败: %w", err)
 	}
-	return &openaiAdapter{cfg: cfg, inner: inner}, nil
+	adapter := &openaiAdapter{cfg: cfg, inner: inner, warnf: logger.Warn, dim: cfg.Dim, validated: make(chan struct{})}
+
+	// 校验需要网络请求，放到后台执行，避免拖慢启动或因网络问题无法启动
+	go adapter.validate()
+	return adapter, nil
+}
+
+// validate 校验模型名并探测向量维度；模型不存在时停用向量服务并记录警告，网络或接口不支持 /models 时只记录警告
+func (a *openaiAdapter) validate() {
+	defer close(a.validated)
+
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	if err := a.ValidateModel(ctx); err != nil {
+		if errors.Is(err, ErrModelNotFound) {
+			a.mu.Lock()
+			a.modelErr = err
+			a.mu.Unlock()
+			a.warnf("向量模型校验失败，已停用向量生成与语义检索: %v", err)
+			return
+		}
+		a.warnf("无法校验向量模型 %s: %v", a.cfg.ModelName, err)
+	}
+
+	probeCtx, probeCancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer probeCancel()
+	if err := a.probeDim(probeCtx); err != nil {
+		a.warnf("无法探测向量维度，按配置的 %d 维处理: %v", a.cfg.Dim, err)
+	}
+}
+
+// checkModel 后台校验发现模型不存在时返回错误
+func (a *openaiAdapter) checkModel() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.modelErr
+}
+
+// dimProbeText 启动时用于探测向量维度的文本
+const dimProbeText = "dimension probe"
+
+// probeDim 嵌入一段探测文本确认接口实际返回的向量维度；与配置不一致时记录警告并改用实际维度，
+// 之后保存向量时按该维度校验
+func (a *openaiAdapter) probeDim(ctx context.Context) error {
+	vec, err := a.EmbedQuery(ctx, dimProbeText)
+	if err != nil {
+		return err
+	}
+	if len(vec) != a.cfg.Dim {
+		a.warnf("embedder.dim 配置为 %d，但模型 %s 实际返回 %d 维向量，已按 %d 维处理，请修改配置",
+			a.cfg.Dim, a.cfg.ModelName, len(vec), len(vec))
+		a.mu.Lock()
+		a.dim = len(vec)
+		a.mu.Unlock()
+	}
+	return nil
+}
+
+// ModelInfo OpenAI 兼容 /models 接口返回的模型信息
+type ModelInfo struct {
+	ID      string `json:"id"`
+	OwnedBy string `json:"owned_by,omitempty"`
+}
+
+// ListModels 调用 GET <baseurl>/models 获取接口提供的全部模型，按 ID 排序
+func ListModels(ctx context.Context, cfg EmbedderConfig) ([]ModelInfo, error) {
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+	}
+
+	resp, err := httplimit.Client(0).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求模型列表失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("请求模型列表失败: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		Data []ModelInfo `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("解析模型列表失败: %w", err)
+	}
+	sort.Slice(payload.Data, func(i, j int) bool { return payload.Data[i].ID < payload.Data[j].ID })
+	return payload.Data, nil
+}
+
+func (a *openaiAdapter) ValidateModel(ctx context.Context) error {
+	list, err := ListModels(ctx, a.cfg)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, len(list))
+	for i, m := range list {
+		ids[i] = m.ID
+	}
+	suggestion, err := matchModel(ids, a.cfg.ModelName)
+	if err != nil {
+		return err
+	}
+	if suggestion != "" {
+		a.warnf("向量模型 %s 与接口中的 %s 仅大小写不同，建议改为后者", a.cfg.ModelName, suggestion)
+	}
+	return nil
+}
+
+// matchModel 在 ids 中查找 name：完全匹配时返回空；仅大小写不同时返回建议的模型名；
+// 否则返回包装了 ErrModelNotFound 的错误，并附上可用的 embedding 模型
+func matchModel(ids []string, name string) (string, error) {
+	var caseMatch, suffixMatch string
+	for _, id := range ids {
+		if id == name {
+			return "", nil
+		}
+		if caseMatch == "" && strings.EqualFold(id, name) {
+			caseMatch = id
+		}
+		// 常见错误：漏写组织前缀，如 Qwen3-Embedding-4B 与 Qwen/Qwen3-Embedding-4B
+		if suffixMatch == "" && strings.EqualFold(id[strings.LastIndex(id, "/")+1:], name[strings.LastIndex(name, "/")+1:]) {
+			suffixMatch = id
+		}
+	}
+	if caseMatch != "" {
+		return caseMatch, nil
+	}
+	if suffixMatch != "" {
+		return "", fmt.Errorf("%w: %s，是否应为 %s", ErrModelNotFound, name, suffixMatch)
+	}
+
+	var embeds []string
+	for _, id := range ids {
+		if strings.Contains(strings.ToLower(id), "embed") {
+			embeds = append(embeds, id)
+		}
+	}
+	if len(embeds) == 0 {
+		return "", fmt.Errorf("%w: %s，接口未提供 embedding 模型", ErrModelNotFound, name)
+	}
+	return "", fmt.Errorf("%w: %s，可用的 embedding 模型: %s", ErrModelNotFound, name, strings.Join(embeds, ", "))
 }
 
 func (a *openaiAdapter) ModelName() string { return a.cfg.ModelName }
-func (a *openaiAdapter) Dim() int          { return a.cfg.Dim }
+func (a *openaiAdapter) Dim() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.dim
+}
 
 func (a *openaiAdapter) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
 	if strings.TrimSpace(text) == "" {
 		return nil, fmt.Errorf("query text is empty")
 	}
+	if err := a.checkModel(); err != nil {
+		return nil, err
+	}
+	metrics.EmbedRequests.Inc()
 	vecs, err := a.inner.EmbedStrings(ctx, []string{text})
 	if err != nil {
 		return nil, err
@@ -80,6 +273,10 @@ func (a *openaiAdapter) EmbedBatch(ctx context.Context, texts []string) ([][]flo
 	if len(filtered) == 0 {
 		return nil, fmt.Errorf("no texts to embed")
 	}
+	if err := a.checkModel(); err != nil {
+		return nil, err
+	}
+	metrics.EmbedRequests.Inc()
 	vecs64, err := a.inner.EmbedStrings(ctx, filtered)
 	if err != nil {
 		return nil, err
@@ -91,6 +288,15 @@ func (a *openaiAdapter) EmbedBatch(ctx context.Context, texts []string) ([][]flo
 	return vecs32, nil
 }
 
+// Configured 判断 s 是否为可用的 embedding 服务，未配置 APIKey 时的空实现返回 false
+func Configured(s Service) bool {
+	if s == nil {
+		return false
+	}
+	_, noop := s.(*noopService)
+	return !noop
+}
+
 // noopService 空实现，用于没有配置 APIKey 时
 type noopService struct {
 	cfg EmbedderConfig
@@ -104,6 +310,9 @@ func (n *noopService) EmbedQuery(ctx context.Context, text string) ([]float32, e
 func (n *noopService) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
 	return nil, fmt.Errorf("embedder not configured (missing APIKey)")
 }
+func (n *noopService) ValidateModel(ctx context.Context) error {
+	return fmt.Errorf("embedder not configured (missing APIKey)")
+}
 
 // toFloat32 转换 float64 到 float32（SQLite BLOB 存储用）
 func toFloat32(v []float64) []float32 {
@@ -0,0 +1,173 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newModelsServer 模拟 OpenAI 兼容的 /models 接口，/embeddings 固定返回 3 维向量
+func newModelsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/embeddings" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"object": "list", "model": "text-embedding-3-small",
+				"data": [{"object": "embedding", "index": 0, "embedding": [0.1, 0.2, 0.3]}],
+				"usage": {"prompt_tokens": 2, "total_tokens": 2}}`)
+			return
+		}
+		if r.URL.Path != "/v1/models" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"object": "list", "data": [
+			{"id": "Qwen/Qwen3-Embedding-4B", "owned_by": "qwen"},
+			{"id": "BAAI/bge-m3", "owned_by": "baai"},
+			{"id": "text-embedding-3-small", "owned_by": "openai"},
+			{"id": "gpt-4o-mini", "owned_by": "openai"}
+		]}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestAdapter(srv *httptest.Server, model string, warnings *[]string) *openaiAdapter {
+	return &openaiAdapter{
+		cfg: EmbedderConfig{BaseURL: srv.URL + "/v1/", APIKey: "test-key", ModelName: model},
+		warnf: func(format string, v ...interface{}) {
+			*warnings = append(*warnings, fmt.Sprintf(format, v...))
+		},
+	}
+}
+
+func TestListModels(t *testing.T) {
+	srv := newModelsServer(t)
+	list, err := ListModels(context.Background(), EmbedderConfig{BaseURL: srv.URL + "/v1", APIKey: "test-key"})
+	if err != nil {
+		t.Fatalf("ListModels() error: %v", err)
+	}
+	if len(list) != 4 || list[0].ID != "BAAI/bge-m3" {
+		t.Errorf("ListModels() = %+v, want 4 models sorted by ID", list)
+	}
+
+	if _, err := ListModels(context.Background(), EmbedderConfig{BaseURL: srv.URL + "/v1", APIKey: "wrong"}); err == nil {
+		t.Error("Expected error for unauthorized request")
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	srv := newModelsServer(t)
+	ctx := context.Background()
+
+	var warnings []string
+	if err := newTestAdapter(srv, "Qwen/Qwen3-Embedding-4B", &warnings).ValidateModel(ctx); err != nil {
+		t.Errorf("exact match: unexpected error %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("exact match: unexpected warnings %v", warnings)
+	}
+
+	// 仅大小写不同：通过校验并给出建议
+	if err := newTestAdapter(srv, "baai/BGE-M3", &warnings).ValidateModel(ctx); err != nil {
+		t.Errorf("case-insensitive match: unexpected error %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "BAAI/bge-m3") {
+		t.Errorf("case-insensitive match: expected a suggestion for BAAI/bge-m3, got %v", warnings)
+	}
+
+	// 漏写组织前缀：报错并提示完整名称
+	err := newTestAdapter(srv, "Qwen3-Embedding-4B", &warnings).ValidateModel(ctx)
+	if !errors.Is(err, ErrModelNotFound) || !strings.Contains(err.Error(), "Qwen/Qwen3-Embedding-4B") {
+		t.Errorf("missing prefix: expected ErrModelNotFound suggesting Qwen/Qwen3-Embedding-4B, got %v", err)
+	}
+
+	// 完全错误：报错并列出可用的 embedding 模型
+	err = newTestAdapter(srv, "no-such-model", &warnings).ValidateModel(ctx)
+	if !errors.Is(err, ErrModelNotFound) {
+		t.Fatalf("wrong model: expected ErrModelNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "text-embedding-3-small") || strings.Contains(err.Error(), "gpt-4o-mini") {
+		t.Errorf("wrong model: expected only embedding models listed, got %v", err)
+	}
+}
+
+// newValidated 调用 New 并等待后台校验结束
+func newValidated(t *testing.T, cfg EmbedderConfig) *openaiAdapter {
+	t.Helper()
+	svc, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	a := svc.(*openaiAdapter)
+	<-a.validated
+	return a
+}
+
+func TestNew_ModelValidation(t *testing.T) {
+	srv := newModelsServer(t)
+
+	// 模型不存在时不阻止创建，但向量请求直接返回 ErrModelNotFound
+	a := newValidated(t, EmbedderConfig{BaseURL: srv.URL + "/v1", APIKey: "test-key", ModelName: "no-such-model"})
+	if _, err := a.EmbedQuery(context.Background(), "query"); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound from EmbedQuery, got %v", err)
+	}
+	if _, err := a.EmbedBatch(context.Background(), []string{"query"}); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("Expected ErrModelNotFound from EmbedBatch, got %v", err)
+	}
+
+	a = newValidated(t, EmbedderConfig{BaseURL: srv.URL + "/v1", APIKey: "test-key", ModelName: "text-embedding-3-small"})
+	if _, err := a.EmbedQuery(context.Background(), "query"); err != nil {
+		t.Errorf("EmbedQuery() error: %v", err)
+	}
+
+	// 接口不可用时只警告
+	a = newValidated(t, EmbedderConfig{BaseURL: srv.URL + "/unavailable", APIKey: "test-key", ModelName: "no-such-model"})
+	if err := a.checkModel(); err != nil {
+		t.Errorf("Expected an unavailable /models endpoint to be tolerated, got %v", err)
+	}
+}
+
+func TestNew_DoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	start := time.Now()
+	if _, err := New(EmbedderConfig{BaseURL: srv.URL, APIKey: "test-key"}); err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected New to return without waiting for validation, took %v", elapsed)
+	}
+}
+
+func TestNew_ProbesDim(t *testing.T) {
+	srv := newModelsServer(t)
+
+	// 配置的维度与接口实际返回不一致时以实际维度为准
+	svc := newValidated(t, EmbedderConfig{BaseURL: srv.URL + "/v1", APIKey: "test-key", ModelName: "text-embedding-3-small", Dim: 1536})
+	if svc.Dim() != 3 {
+		t.Errorf("Expected Dim() = 3 after probing, got %d", svc.Dim())
+	}
+
+	// 无法探测时保留配置的维度
+	svc = newValidated(t, EmbedderConfig{BaseURL: srv.URL + "/unavailable", APIKey: "test-key", ModelName: "text-embedding-3-small", Dim: 1536})
+	if svc.Dim() != 1536 {
+		t.Errorf("Expected configured Dim() = 1536 when probe fails, got %d", svc.Dim())
+	}
+}
@@ -0,0 +1,174 @@
+package hyde
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"PaperHunter/pkg/logger"
+)
+
+// DefaultCacheTTL HyDE 生成结果的默认缓存有效期
+const DefaultCacheTTL = 7 * 24 * time.Hour
+
+// DefaultCacheDir 默认缓存目录 ~/.quicksearch/cache/hyde
+func DefaultCacheDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".quicksearch", "cache", "hyde")
+	}
+	return filepath.Join(home, ".quicksearch", "cache", "hyde")
+}
+
+// cacheEntry 缓存文件内容，记录查询与模型以便排查哈希冲突
+type cacheEntry struct {
+	Query     string             `json:"query"`
+	Model     string             `json:"model"`
+	Paper     *HypotheticalPaper `json:"paper"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// cacheCall 同一缓存键正在进行的生成，后到的调用等待其结果
+type cacheCall struct {
+	done  chan struct{}
+	paper *HypotheticalPaper
+	err   error
+}
+
+// cachedService 在 Service 外包一层磁盘缓存：按归一化查询 + 模型命中时不再调用 LLM，
+// 同一查询的并发调用只生成一次
+type cachedService struct {
+	next  Service
+	dir   string
+	model string
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu       sync.Mutex
+	inflight map[string]*cacheCall
+}
+
+// WithCache 为 next 加上磁盘缓存，dir 为空时使用 DefaultCacheDir，ttl <= 0 时使用 DefaultCacheTTL
+func WithCache(next Service, dir, model string, ttl time.Duration) Service {
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &cachedService{
+		next:     next,
+		dir:      dir,
+		model:    model,
+		ttl:      ttl,
+		now:      time.Now,
+		inflight: make(map[string]*cacheCall),
+	}
+}
+
+func (s *cachedService) GenerateHypotheticalPaper(ctx context.Context, userQuery string) (*HypotheticalPaper, error) {
+	query := normalizeKey(userQuery)
+	if query == "" {
+		return s.next.GenerateHypotheticalPaper(ctx, userQuery)
+	}
+	key := s.cacheKey(query)
+
+	if paper := s.load(key, query); paper != nil {
+		logger.Info("HyDE 命中缓存: %s", userQuery)
+		return paper, nil
+	}
+
+	s.mu.Lock()
+	if call, ok := s.inflight[key]; ok {
+		s.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.paper, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	s.inflight[key] = call
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.inflight, key)
+		s.mu.Unlock()
+		close(call.done)
+	}()
+
+	// 上一个同键调用可能在 load 之后才写完缓存
+	if paper := s.load(key, query); paper != nil {
+		call.paper = paper
+		return paper, nil
+	}
+	call.paper, call.err = s.next.GenerateHypotheticalPaper(ctx, userQuery)
+	// 降级结果不缓存，避免 LLM 暂时不可用时长期命中降级结果
+	if call.err == nil && call.paper != nil && !isFallback(call.paper, userQuery) {
+		if err := s.save(key, query, call.paper); err != nil {
+			logger.Warn("写入 HyDE 缓存失败: %v", err)
+		}
+	}
+	return call.paper, call.err
+}
+
+// cacheKey 归一化查询与模型名的哈希
+func (s *cachedService) cacheKey(query string) string {
+	sum := sha256.Sum256([]byte(s.model + "\x00" + query))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (s *cachedService) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+// load 读取未过期的缓存，不存在、损坏、过期或查询不一致时返回 nil
+func (s *cachedService) load(key, query string) *HypotheticalPaper {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warn("读取 HyDE 缓存失败: %v", err)
+		}
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Paper == nil {
+		return nil
+	}
+	if entry.Query != query || entry.Model != s.model || s.now().Sub(entry.CreatedAt) >= s.ttl {
+		return nil
+	}
+	return entry.Paper
+}
+
+func (s *cachedService) save(key, query string, paper *HypotheticalPaper) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+	data, err := json.MarshalIndent(cacheEntry{
+		Query:     query,
+		Model:     s.model,
+		Paper:     paper,
+		CreatedAt: s.now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(key), data, 0644)
+}
+
+// isFallback 判断是否为 fallbackHypotheticalPaper 生成的降级结果
+func isFallback(p *HypotheticalPaper, userQuery string) bool {
+	q := strings.TrimSpace(userQuery)
+	return p.Title == q && p.Abstract == q
+}
@@ -0,0 +1,120 @@
+package hyde
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingService 记录调用次数的假 HyDE 服务
+type countingService struct {
+	mu    sync.Mutex
+	calls int
+	delay time.Duration
+	paper *HypotheticalPaper
+}
+
+func (c *countingService) GenerateHypotheticalPaper(ctx context.Context, userQuery string) (*HypotheticalPaper, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+	time.Sleep(c.delay)
+	if c.paper != nil {
+		return c.paper, nil
+	}
+	return &HypotheticalPaper{Title: "About " + userQuery, Abstract: "We study " + userQuery + "."}, nil
+}
+
+func (c *countingService) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func TestCachedService_HitMissExpiry(t *testing.T) {
+	next := &countingService{}
+	dir := t.TempDir()
+	svc := WithCache(next, dir, "model-a", time.Hour).(*cachedService)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	first, err := svc.GenerateHypotheticalPaper(ctx, "Graph Neural Networks")
+	if err != nil {
+		t.Fatalf("GenerateHypotheticalPaper() error: %v", err)
+	}
+	if next.count() != 1 {
+		t.Fatalf("Expected 1 model call on miss, got %d", next.count())
+	}
+
+	// 归一化后相同的查询命中缓存
+	second, err := svc.GenerateHypotheticalPaper(ctx, "  graph neural   networks! ")
+	if err != nil {
+		t.Fatalf("GenerateHypotheticalPaper() error: %v", err)
+	}
+	if next.count() != 1 || second.Title != first.Title {
+		t.Errorf("Expected a cache hit, got %d calls and title %q", next.count(), second.Title)
+	}
+
+	// 不同模型使用不同的缓存键
+	other := WithCache(next, dir, "model-b", time.Hour)
+	if _, err := other.GenerateHypotheticalPaper(ctx, "Graph Neural Networks"); err != nil {
+		t.Fatalf("GenerateHypotheticalPaper() error: %v", err)
+	}
+	if next.count() != 2 {
+		t.Errorf("Expected a miss for another model, got %d calls", next.count())
+	}
+
+	// 过期后重新生成
+	now = now.Add(time.Hour)
+	if _, err := svc.GenerateHypotheticalPaper(ctx, "Graph Neural Networks"); err != nil {
+		t.Fatalf("GenerateHypotheticalPaper() error: %v", err)
+	}
+	if next.count() != 3 {
+		t.Errorf("Expected a miss after expiry, got %d calls", next.count())
+	}
+}
+
+func TestCachedService_SkipsFallback(t *testing.T) {
+	next := &countingService{paper: fallbackHypotheticalPaper("diffusion models")}
+	svc := WithCache(next, t.TempDir(), "model-a", time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.GenerateHypotheticalPaper(context.Background(), "diffusion models"); err != nil {
+			t.Fatalf("GenerateHypotheticalPaper() error: %v", err)
+		}
+	}
+	if next.count() != 2 {
+		t.Errorf("Expected fallback results not to be cached, got %d calls", next.count())
+	}
+}
+
+func TestCachedService_SingleFlight(t *testing.T) {
+	next := &countingService{delay: 50 * time.Millisecond}
+	svc := WithCache(next, t.TempDir(), "model-a", time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := svc.GenerateHypotheticalPaper(context.Background(), "retrieval augmented generation")
+			if err == nil && p == nil {
+				t.Error("Expected a paper from a shared call")
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("GenerateHypotheticalPaper() error: %v", err)
+		}
+	}
+	if next.count() != 1 {
+		t.Errorf("Expected concurrent calls to share 1 model call, got %d", next.count())
+	}
+}
@@ -13,9 +13,19 @@ import (
 
 	embopenai "github.com/cloudwego/eino-ext/components/embedding/openai"
 	"github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
 )
 
+const (
+	// DefaultCandidates 每次生成的候选虚拟论文数，从中选出与查询最相关的一篇
+	DefaultCandidates = 3
+	// MaxCandidates 候选数上限
+	MaxCandidates = 8
+	// DefaultTemperature 生成候选时的默认温度
+	DefaultTemperature = 0.3
+)
+
 // HypotheticalPaper 虚拟论文结构
 type HypotheticalPaper struct {
 	Title    string `json:"title"`
@@ -27,8 +37,9 @@ type Service interface {
 }
 
 type hydeService struct {
-	model    *openai.ChatModel
-	embedder *embopenai.Embedder
+	model      model.BaseChatModel
+	embedder   *embopenai.Embedder
+	candidates int
 }
 
 func New(cfg config.LLMConfig) (Service, error) {
@@ -37,10 +48,21 @@ func New(cfg config.LLMConfig) (Service, error) {
 		return nil, nil
 	}
 
+	candidates := cfg.HyDECandidates
+	if candidates == 0 {
+		candidates = DefaultCandidates
+	}
+	if candidates < 1 || candidates > MaxCandidates {
+		return nil, fmt.Errorf("HyDE 候选数需在 1 到 %d 之间，当前为 %d", MaxCandidates, candidates)
+	}
+	temp := cfg.HyDETemperature
+	if temp < 0 || temp > 2 {
+		return nil, fmt.Errorf("HyDE 温度需在 0 到 2 之间，当前为 %v", temp)
+	}
+
 	ctx := context.Background()
-	temp := float32(0.3)
 
-	model, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
+	chatModel, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
 		APIKey:      cfg.APIKey,
 		Model:       cfg.ModelName,
 		BaseURL:     cfg.BaseURL,
@@ -50,6 +72,11 @@ func New(cfg config.LLMConfig) (Service, error) {
 		return nil, fmt.Errorf("创建 LLM 客户端失败: %w", err)
 	}
 
+	// 只有一个候选时不需要选优
+	if candidates == 1 {
+		return withCache(cfg, &hydeService{model: chatModel, candidates: candidates}), nil
+	}
+
 	// 尝试创建 embedding 客户端用于候选选优（失败则降级为词重合评分）
 	embedModel := cfg.ModelName
 	if embedModel == "" || !strings.Contains(embedModel, "embedding") {
@@ -64,7 +91,15 @@ func New(cfg config.LLMConfig) (Service, error) {
 		logger.Warn("创建 embedding 客户端失败，选优将使用词重合: %v", err)
 	}
 
-	return &hydeService{model: model, embedder: embedder}, nil
+	return withCache(cfg, &hydeService{model: chatModel, embedder: embedder, candidates: candidates}), nil
+}
+
+// withCache 按配置为服务加上磁盘缓存
+func withCache(cfg config.LLMConfig, svc Service) Service {
+	if cfg.HyDECache {
+		return WithCache(svc, DefaultCacheDir(), cfg.ModelName, cfg.HyDECacheTTL)
+	}
+	return svc
 }
 
 func (s *hydeService) GenerateHypotheticalPaper(ctx context.Context, userQuery string) (*HypotheticalPaper, error) {
@@ -87,7 +122,10 @@ func (s *hydeService) GenerateHypotheticalPaper(ctx context.Context, userQuery s
 		},
 	}
 
-	const candidateAttempts = 3
+	candidateAttempts := s.candidates
+	if candidateAttempts <= 0 {
+		candidateAttempts = DefaultCandidates
+	}
 	candidates := make([]*HypotheticalPaper, 0, candidateAttempts)
 	seen := make(map[string]struct{})
 
@@ -108,6 +146,9 @@ func (s *hydeService) GenerateHypotheticalPaper(ctx context.Context, userQuery s
 			logger.Warn("解析 LLM 响应失败(第 %d 次): %v", i+1, err)
 			continue
 		}
+		if candidateAttempts == 1 {
+			return paper, nil
+		}
 
 		key := normalizeKey(paper.Title + "|" + paper.Abstract)
 		if _, exists := seen[key]; exists {
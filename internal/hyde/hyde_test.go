@@ -0,0 +1,61 @@
+package hyde
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"PaperHunter/config"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// stubChatModel 返回编号递增的虚拟论文，记录调用次数
+type stubChatModel struct {
+	calls int
+}
+
+func (m *stubChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.calls++
+	content := fmt.Sprintf(`{"title": "Graph neural networks %d", "abstract": "Graph neural networks study %d."}`, m.calls, m.calls)
+	return &schema.Message{Role: schema.Assistant, Content: content}, nil
+}
+
+func (m *stubChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestGenerateHypotheticalPaper_Candidates(t *testing.T) {
+	for _, n := range []int{1, 3, 8} {
+		stub := &stubChatModel{}
+		svc := &hydeService{model: stub, candidates: n}
+
+		paper, err := svc.GenerateHypotheticalPaper(context.Background(), "graph neural networks")
+		if err != nil {
+			t.Fatalf("GenerateHypotheticalPaper() error: %v", err)
+		}
+		if stub.calls != n {
+			t.Errorf("candidates=%d: expected %d model calls, got %d", n, n, stub.calls)
+		}
+		if paper == nil || paper.Title == "" {
+			t.Errorf("candidates=%d: expected a generated paper, got %+v", n, paper)
+		}
+	}
+}
+
+func TestNew_ValidatesCandidatesAndTemperature(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.LLMConfig
+	}{
+		{"too many candidates", config.LLMConfig{APIKey: "k", HyDECandidates: MaxCandidates + 1}},
+		{"negative candidates", config.LLMConfig{APIKey: "k", HyDECandidates: -1}},
+		{"temperature too high", config.LLMConfig{APIKey: "k", HyDETemperature: 2.5}},
+	}
+	for _, tt := range tests {
+		if _, err := New(tt.cfg); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
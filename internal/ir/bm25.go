@@ -36,25 +36,19 @@ func NewBM25SearcherWithParams(index *InvertedIndex, tokenizer *Tokenizer, k1, b
 
 // Search 执行 BM25 搜索
 func (s *BM25Searcher) Search(query string, topK int) []*SearchResult {
-	// 分词查询
-	queryTerms := s.tokenizer.Tokenize(query)
-	if len(queryTerms) == 0 {
+	// 解析查询（字段前缀与短语）
+	parsed := ParseQuery(query, s.tokenizer)
+	if parsed.IsEmpty() {
 		return make([]*SearchResult, 0)
 	}
 
-	// 获取包含查询词的所有文档
-	candidateDocs := make(map[int64]bool)
-	for _, term := range queryTerms {
-		postingList := s.index.GetPostingList(term)
-		for _, posting := range postingList {
-			candidateDocs[posting.DocID] = true
-		}
-	}
+	// 获取满足查询的所有候选文档
+	candidateDocs := s.index.MatchDocuments(parsed)
 
 	// 计算每个候选文档的 BM25 分数
 	docScores := make(map[int64]float64)
 	for docID := range candidateDocs {
-		score := s.computeDocumentScore(queryTerms, docID)
+		score := s.computeQueryScore(parsed, docID)
 		docScores[docID] = score
 	}
 
@@ -202,6 +196,56 @@ func (s *BM25Searcher) computeDocumentScore(queryTerms []string, docID int64) fl
 	return totalScore
 }
 
+// computeQueryScore 按子句累加分数，限定字段的子句只使用该字段的词频与长度
+func (s *BM25Searcher) computeQueryScore(q *ParsedQuery, docID int64) float64 {
+	var totalScore float64
+	for _, clause := range q.Clauses {
+		if clause.Field == FieldAll {
+			totalScore += s.computeDocumentScore(clause.Terms, docID)
+		} else {
+			totalScore += s.computeFieldScore(clause.Terms, clause.Field, docID)
+		}
+	}
+	return totalScore
+}
+
+// computeFieldScore 计算查询词在单个字段上的 BM25 分数
+func (s *BM25Searcher) computeFieldScore(queryTerms []string, field Field, docID int64) float64 {
+	fieldLength := s.index.GetFieldLength(docID, field)
+	avgFieldLength := s.index.GetAverageFieldLength(field)
+	if fieldLength == 0 || avgFieldLength == 0 {
+		return 0
+	}
+
+	// 与 computeDocumentScore 保持一致，标题命中权重更高
+	weight := 1.0
+	if field == FieldTitle {
+		weight = 2.0
+	}
+
+	var totalScore float64
+	for _, term := range queryTerms {
+		posting, ok := s.index.GetPosting(term, docID)
+		if !ok {
+			continue
+		}
+		tf := fieldFreq(posting, field)
+		if tf == 0 {
+			continue
+		}
+
+		idf := s.computeIDF(term)
+		if idf == 0 {
+			continue
+		}
+
+		numerator := float64(tf) * (s.k1 + 1)
+		denominator := float64(tf) + s.k1*(1-s.b+s.b*float64(fieldLength)/avgFieldLength)
+		totalScore += idf * (numerator / denominator) * weight
+	}
+	return totalScore
+}
+
 // SetParameters 设置 BM25 参数
 func (s *BM25Searcher) SetParameters(k1, b float64) {
 	s.k1 = k1
@@ -214,17 +258,12 @@ func (s *BM25Searcher) GetParameters() (k1, b float64) {
 }
 
 // SearchWithPapers 执行搜索并返回包含论文信息的完整结果
-func (s *BM25Searcher) SearchWithPapers(query string, topK int, papers []*models.Paper) []*SearchResult {
+func (s *BM25Searcher) SearchWithPapers(query string, topK int, papers map[int64]*models.Paper) []*SearchResult {
 	results := s.Search(query, topK)
 
 	// 为结果添加论文信息
-	paperMap := make(map[int64]*models.Paper)
-	for i, paper := range papers {
-		paperMap[int64(i+1)] = paper // 使用与索引相同的ID映射
-	}
-
 	for _, result := range results {
-		if paper, exists := paperMap[result.DocID]; exists {
+		if paper, exists := papers[result.DocID]; exists {
 			result.Paper = paper
 		}
 	}
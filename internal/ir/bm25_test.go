@@ -273,7 +273,7 @@ func TestBM25Searcher_SearchWithPapers(t *testing.T) {
 
 	index.AddDocuments(papers)
 
-	results := searcher.SearchWithPapers("learning", 10, papers)
+	results := searcher.SearchWithPapers("learning", 10, PapersByID(papers))
 
 	if len(results) == 0 {
 		t.Error("Expected search results, got empty slice")
@@ -11,6 +11,8 @@ type Posting struct {
 	TermFreq     int    // 总词频（标题+摘要）
 	TitleFreq    int    // 标题中的词频
 	AbstractFreq int    // 摘要中的词频
+	TitlePositions    []int // 词在标题分词结果中的位置，用于短语匹配
+	AbstractPositions []int // 词在摘要分词结果中的位置
 }
 
 // PostingList 某个词的倒排列表
@@ -22,10 +24,13 @@ type InvertedIndex struct {
 	docLengths     map[int64]int           // 文档总长度
 	titleLengths   map[int64]int           // 标题长度
 	abstractLengths map[int64]int          // 摘要长度
+	docTerms       map[int64][]string     // 文档包含的词项，用于重复添加时移除旧 posting
 	tokenizer      *Tokenizer
 	mutex          sync.RWMutex           // 读写锁，保证并发安全
 	totalDocs      int                    // 文档总数
 	avgDocLength   float64                // 平均文档长度
+	avgTitleLength    float64             // 平均标题长度
+	avgAbstractLength float64             // 平均摘要长度
 }
 
 // NewInvertedIndex 创建新的倒排索引
@@ -35,31 +40,40 @@ func NewInvertedIndex(tokenizer *Tokenizer) *InvertedIndex {
 		docLengths:      make(map[int64]int),
 		titleLengths:    make(map[int64]int),
 		abstractLengths: make(map[int64]int),
+		docTerms:        make(map[int64][]string),
 		tokenizer:       tokenizer,
 		totalDocs:       0,
 		avgDocLength:    0,
 	}
 }
 
-// AddDocument 添加单个文档到索引
+// AddDocument 添加单个文档到索引，相同 docID 已存在时替换旧文档
 func (ii *InvertedIndex) AddDocument(docID int64, paper *models.Paper) {
 	ii.mutex.Lock()
 	defer ii.mutex.Unlock()
 
+	if _, exists := ii.docLengths[docID]; exists {
+		ii.removeDocument(docID)
+	}
+
 	// 分词标题和摘要
 	titleTokens := ii.tokenizer.Tokenize(paper.Title)
 	abstractTokens := ii.tokenizer.Tokenize(paper.Abstract)
 
-	// 计算标题词频
+	// 计算标题词频与位置
 	titleTermFreqs := make(map[string]int)
-	for _, token := range titleTokens {
+	titlePositions := make(map[string][]int)
+	for pos, token := range titleTokens {
 		titleTermFreqs[token]++
+		titlePositions[token] = append(titlePositions[token], pos)
 	}
 
-	// 计算摘要词频
+	// 计算摘要词频与位置
 	abstractTermFreqs := make(map[string]int)
-	for _, token := range abstractTokens {
+	abstractPositions := make(map[string][]int)
+	for pos, token := range abstractTokens {
 		abstractTermFreqs[token]++
+		abstractPositions[token] = append(abstractPositions[token], pos)
 	}
 
 	// 合并所有词项
@@ -72,7 +86,9 @@ func (ii *InvertedIndex) AddDocument(docID int64, paper *models.Paper) {
 	}
 
 	// 为每个词项创建 posting
+	terms := make([]string, 0, len(allTerms))
 	for term := range allTerms {
+		terms = append(terms, term)
 		titleFreq := titleTermFreqs[term]
 		abstractFreq := abstractTermFreqs[term]
 		totalFreq := titleFreq + abstractFreq
@@ -82,6 +98,8 @@ func (ii *InvertedIndex) AddDocument(docID int64, paper *models.Paper) {
 			TermFreq:     totalFreq,
 			TitleFreq:    titleFreq,
 			AbstractFreq: abstractFreq,
+			TitlePositions:    titlePositions[term],
+			AbstractPositions: abstractPositions[term],
 		}
 
 		// 添加到倒排索引
@@ -92,6 +110,7 @@ func (ii *InvertedIndex) AddDocument(docID int64, paper *models.Paper) {
 	}
 
 	// 记录文档长度
+	ii.docTerms[docID] = terms
 	ii.docLengths[docID] = len(titleTokens) + len(abstractTokens)
 	ii.titleLengths[docID] = len(titleTokens)
 	ii.abstractLengths[docID] = len(abstractTokens)
@@ -101,12 +120,68 @@ func (ii *InvertedIndex) AddDocument(docID int64, paper *models.Paper) {
 	ii.updateAverageDocumentLength()
 }
 
-// AddDocuments 批量添加文档到索引
+// AddDocuments 批量添加文档到索引，DocID 使用论文在数据库中的 ID
 func (ii *InvertedIndex) AddDocuments(papers []*models.Paper) {
-	for i, paper := range papers {
-		docID := int64(i + 1) // 临时ID，实际应用中应该使用真实的文档ID
-		ii.AddDocument(docID, paper)
+	for _, paper := range papers {
+		ii.AddDocument(paper.ID, paper)
+	}
+}
+
+// RemoveDocument 从索引中移除文档
+func (ii *InvertedIndex) RemoveDocument(docID int64) {
+	ii.mutex.Lock()
+	defer ii.mutex.Unlock()
+
+	if _, exists := ii.docLengths[docID]; !exists {
+		return
+	}
+	ii.removeDocument(docID)
+	ii.updateAverageDocumentLength()
+}
+
+// removeDocument 移除文档的 posting 与长度信息，调用方需持有写锁
+func (ii *InvertedIndex) removeDocument(docID int64) {
+	for _, term := range ii.docTerms[docID] {
+		postings := ii.index[term]
+		for i, posting := range postings {
+			if posting.DocID == docID {
+				postings = append(postings[:i], postings[i+1:]...)
+				break
+			}
+		}
+		if len(postings) == 0 {
+			delete(ii.index, term)
+		} else {
+			ii.index[term] = postings
+		}
+	}
+
+	delete(ii.docTerms, docID)
+	delete(ii.docLengths, docID)
+	delete(ii.titleLengths, docID)
+	delete(ii.abstractLengths, docID)
+	ii.totalDocs--
+}
+
+// HasDocument 判断文档是否已在索引中
+func (ii *InvertedIndex) HasDocument(docID int64) bool {
+	ii.mutex.RLock()
+	defer ii.mutex.RUnlock()
+
+	_, exists := ii.docLengths[docID]
+	return exists
+}
+
+// DocIDs 获取索引中的所有文档 ID
+func (ii *InvertedIndex) DocIDs() []int64 {
+	ii.mutex.RLock()
+	defer ii.mutex.RUnlock()
+
+	ids := make([]int64, 0, len(ii.docLengths))
+	for docID := range ii.docLengths {
+		ids = append(ids, docID)
 	}
+	return ids
 }
 
 // GetPostingList 获取词的倒排列表
@@ -120,6 +195,19 @@ func (ii *InvertedIndex) GetPostingList(term string) PostingList {
 	return make(PostingList, 0)
 }
 
+// GetPosting 获取词在指定文档中的 posting
+func (ii *InvertedIndex) GetPosting(term string, docID int64) (Posting, bool) {
+	ii.mutex.RLock()
+	defer ii.mutex.RUnlock()
+
+	for _, posting := range ii.index[term] {
+		if posting.DocID == docID {
+			return posting, true
+		}
+	}
+	return Posting{}, false
+}
+
 // GetDocumentFrequency 获取文档频率（DF）- 包含该词的文档数
 func (ii *InvertedIndex) GetDocumentFrequency(term string) int {
 	ii.mutex.RLock()
@@ -187,6 +275,33 @@ func (ii *InvertedIndex) GetAbstractLength(docID int64) int {
 	return 0
 }
 
+// GetAverageFieldLength 获取指定字段的平均长度，FieldAll 返回平均文档长度
+func (ii *InvertedIndex) GetAverageFieldLength(field Field) float64 {
+	ii.mutex.RLock()
+	defer ii.mutex.RUnlock()
+
+	switch field {
+	case FieldTitle:
+		return ii.avgTitleLength
+	case FieldAbstract:
+		return ii.avgAbstractLength
+	default:
+		return ii.avgDocLength
+	}
+}
+
+// GetFieldLength 获取文档指定字段的长度，FieldAll 返回文档总长度
+func (ii *InvertedIndex) GetFieldLength(docID int64, field Field) int {
+	switch field {
+	case FieldTitle:
+		return ii.GetTitleLength(docID)
+	case FieldAbstract:
+		return ii.GetAbstractLength(docID)
+	default:
+		return ii.GetDocumentLength(docID)
+	}
+}
+
 // GetTotalDocs 获取文档总数
 func (ii *InvertedIndex) GetTotalDocs() int {
 	ii.mutex.RLock()
@@ -199,6 +314,8 @@ func (ii *InvertedIndex) GetTotalDocs() int {
 func (ii *InvertedIndex) updateAverageDocumentLength() {
 	if ii.totalDocs == 0 {
 		ii.avgDocLength = 0
+		ii.avgTitleLength = 0
+		ii.avgAbstractLength = 0
 		return
 	}
 
@@ -207,6 +324,14 @@ func (ii *InvertedIndex) updateAverageDocumentLength() {
 		totalLength += length
 	}
 	ii.avgDocLength = float64(totalLength) / float64(ii.totalDocs)
+
+	titleLength, abstractLength := 0, 0
+	for docID := range ii.docLengths {
+		titleLength += ii.titleLengths[docID]
+		abstractLength += ii.abstractLengths[docID]
+	}
+	ii.avgTitleLength = float64(titleLength) / float64(ii.totalDocs)
+	ii.avgAbstractLength = float64(abstractLength) / float64(ii.totalDocs)
 }
 
 // GetVocabularySize 获取词汇表大小
@@ -2,30 +2,40 @@ package ir
 
 import (
 	"bufio"
+	"strings"
 )
 
 
 
 func init(){
 	loadStopWords()
+	stopWordsZh = readStopWords("stopWords_zh.txt")
 }
 
 
 func loadStopWords()  {
-	stopWords = make(map[string]bool)
-	file, err := stopWordsFS.Open("stopWords.txt")
-	if err != nil {
+	stopWords = readStopWords("stopWords.txt")
+	if len(stopWords) == 0 {
 		initDefaultStopWords()
-		return
+	}
+}
+
+// readStopWords 读取内置停用词文件，每行一个词，文件缺失时返回空集合
+func readStopWords(name string) map[string]bool {
+	words := make(map[string]bool)
+	file, err := stopWordsFS.Open(name)
+	if err != nil {
+		return words
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
-
 	for scanner.Scan() { // scan ! EOF
-		word := scanner.Text()
-		stopWords[word] = true
+		if word := strings.TrimSpace(scanner.Text()); word != "" {
+			words[word] = true
+		}
 	}
+	return words
 }
 
 func initDefaultStopWords(){
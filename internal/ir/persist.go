@@ -0,0 +1,188 @@
+package ir
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"PaperHunter/internal/models"
+)
+
+// indexFormatVersion 索引文件格式版本，Posting 等结构变化时递增，旧文件随之失效
+const indexFormatVersion = 1
+
+// MaxIndexDriftRatio 加载磁盘索引时允许与当前论文集合相差的比例，超过则需要重建
+const MaxIndexDriftRatio = 0.1
+
+// ErrIndexStale 磁盘上的索引与当前论文集合相差过多，应重新构建
+var ErrIndexStale = errors.New("IR 索引已过期")
+
+// DefaultIndexPath 默认索引文件路径 ~/.quicksearch/index/ir.gob
+func DefaultIndexPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".quicksearch", "index", "ir.gob")
+	}
+	return filepath.Join(home, ".quicksearch", "index", "ir.gob")
+}
+
+// indexSnapshot 倒排索引的磁盘格式
+type indexSnapshot struct {
+	Version           int
+	Tokenizer         string // 建立索引的分词器配置，见 Tokenizer.signature
+	Index             map[string]PostingList
+	DocLengths        map[int64]int
+	TitleLengths      map[int64]int
+	AbstractLengths   map[int64]int
+	DocTerms          map[int64][]string
+	TotalDocs         int
+	AvgDocLength      float64
+	AvgTitleLength    float64
+	AvgAbstractLength float64
+}
+
+// SaveToFile 以 gob 格式将倒排列表、文档长度与统计信息写入 path，先写临时文件再改名，避免中途失败留下损坏的文件
+func (ii *InvertedIndex) SaveToFile(path string) error {
+	ii.mutex.RLock()
+	defer ii.mutex.RUnlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建索引目录失败: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("创建索引文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	snapshot := indexSnapshot{
+		Version:           indexFormatVersion,
+		Tokenizer:         ii.tokenizer.signature(),
+		Index:             ii.index,
+		DocLengths:        ii.docLengths,
+		TitleLengths:      ii.titleLengths,
+		AbstractLengths:   ii.abstractLengths,
+		DocTerms:          ii.docTerms,
+		TotalDocs:         ii.totalDocs,
+		AvgDocLength:      ii.avgDocLength,
+		AvgTitleLength:    ii.avgTitleLength,
+		AvgAbstractLength: ii.avgAbstractLength,
+	}
+	if err := gob.NewEncoder(tmp).Encode(&snapshot); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入索引文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("写入索引文件失败: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadFromFile 从 path 读取 SaveToFile 写入的索引并替换当前内容；
+// 文件格式版本或分词配置与当前分词器不一致时返回 ErrIndexStale，当前内容不变
+func (ii *InvertedIndex) LoadFromFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var snapshot indexSnapshot
+	if err := gob.NewDecoder(f).Decode(&snapshot); err != nil {
+		return fmt.Errorf("读取索引文件失败: %w", err)
+	}
+	if snapshot.Version != indexFormatVersion {
+		return fmt.Errorf("%w: 文件格式版本 %d，当前 %d", ErrIndexStale, snapshot.Version, indexFormatVersion)
+	}
+	if snapshot.Tokenizer != ii.tokenizer.signature() {
+		return fmt.Errorf("%w: 分词配置已变化", ErrIndexStale)
+	}
+
+	ii.mutex.Lock()
+	defer ii.mutex.Unlock()
+
+	// gob 不区分空 map 与 nil，统一补齐以便后续写入
+	ii.index = snapshot.Index
+	if ii.index == nil {
+		ii.index = make(map[string]PostingList)
+	}
+	ii.docLengths = orEmpty(snapshot.DocLengths)
+	ii.titleLengths = orEmpty(snapshot.TitleLengths)
+	ii.abstractLengths = orEmpty(snapshot.AbstractLengths)
+	ii.docTerms = snapshot.DocTerms
+	if ii.docTerms == nil {
+		ii.docTerms = make(map[int64][]string)
+	}
+	ii.totalDocs = snapshot.TotalDocs
+	ii.avgDocLength = snapshot.AvgDocLength
+	ii.avgTitleLength = snapshot.AvgTitleLength
+	ii.avgAbstractLength = snapshot.AvgAbstractLength
+	return nil
+}
+
+func orEmpty(m map[int64]int) map[int64]int {
+	if m == nil {
+		return make(map[int64]int)
+	}
+	return m
+}
+
+// SaveIndex 将当前倒排索引写入 path
+func (s *IRSearcher) SaveIndex(path string) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.index.SaveToFile(path)
+}
+
+// LoadIndex 从 path 加载倒排索引，并以 papers（通常为数据库中的全部论文）作为论文数据：
+// 索引中缺少的论文增量加入，已不存在的文档移除，返回修正的文档数。
+// 修正数超过论文数的 MaxIndexDriftRatio 时返回 ErrIndexStale，当前索引保持不变
+func (s *IRSearcher) LoadIndex(path string, papers []*models.Paper) (int, error) {
+	for _, p := range papers {
+		if p == nil || p.ID <= 0 {
+			return 0, fmt.Errorf("论文缺少数据库 ID，无法建立索引")
+		}
+	}
+
+	index := NewInvertedIndex(s.tokenizer)
+	if err := index.LoadFromFile(path); err != nil {
+		return 0, err
+	}
+
+	byID := PapersByID(papers)
+	var missing []*models.Paper
+	for _, p := range papers {
+		if !index.HasDocument(p.ID) {
+			missing = append(missing, p)
+		}
+	}
+	var removed []int64
+	for _, docID := range index.DocIDs() {
+		if _, ok := byID[docID]; !ok {
+			removed = append(removed, docID)
+		}
+	}
+	drift := len(missing) + len(removed)
+	if float64(drift) > float64(len(papers))*MaxIndexDriftRatio {
+		return drift, fmt.Errorf("%w: 与当前 %d 篇论文相差 %d 篇", ErrIndexStale, len(papers), drift)
+	}
+	for _, docID := range removed {
+		index.RemoveDocument(docID)
+	}
+	for _, p := range missing {
+		index.AddDocument(p.ID, p)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	k1, b := s.bm25Searcher.GetParameters()
+	s.index = index
+	s.tfidfSearcher = NewTFIDFSearcher(index, s.tokenizer)
+	s.bm25Searcher = NewBM25SearcherWithParams(index, s.tokenizer, k1, b)
+	s.papers = byID
+	return drift, nil
+}
@@ -0,0 +1,126 @@
+package ir
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func persistTestPapers(n int) []*models.Paper {
+	topics := []string{"graph neural networks", "speech recognition", "image segmentation", "language models"}
+	papers := make([]*models.Paper, n)
+	for i := range papers {
+		topic := topics[i%len(topics)]
+		papers[i] = &models.Paper{
+			ID:       int64(i*3 + 1),
+			Title:    fmt.Sprintf("Scalable %s %d", topic, i),
+			Abstract: fmt.Sprintf("We study %s with attention and message passing on benchmark %d.", topic, i%5),
+		}
+	}
+	return papers
+}
+
+func TestIRSearcher_SaveLoadIndexRoundTrip(t *testing.T) {
+	tokenizer, _ := NewTokenizer()
+	papers := persistTestPapers(20)
+	built := NewIRSearcher(tokenizer)
+	if err := built.BuildIndex(papers); err != nil {
+		t.Fatalf("BuildIndex() error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "index", "ir.gob")
+	if err := built.SaveIndex(path); err != nil {
+		t.Fatalf("SaveIndex() error: %v", err)
+	}
+
+	loaded := NewIRSearcher(tokenizer)
+	drift, err := loaded.LoadIndex(path, papers)
+	if err != nil || drift != 0 {
+		t.Fatalf("LoadIndex() = %d, %v; want 0, nil", drift, err)
+	}
+	if !reflect.DeepEqual(built.GetIndexStats(), loaded.GetIndexStats()) {
+		t.Errorf("Index stats differ: built %v, loaded %v", built.GetIndexStats(), loaded.GetIndexStats())
+	}
+	// 同分文档的先后顺序不固定，按文档比较分数
+	for _, query := range []string{"graph networks", `"speech recognition"`, "title:segmentation", "attention benchmark"} {
+		for _, algorithm := range []string{"bm25", "tfidf"} {
+			want, err := built.Search(SearchOptions{Query: query, TopK: len(papers), Algorithm: algorithm})
+			if err != nil {
+				t.Fatalf("Search(%q, %s) error: %v", query, algorithm, err)
+			}
+			got, err := loaded.Search(SearchOptions{Query: query, TopK: len(papers), Algorithm: algorithm})
+			if err != nil {
+				t.Fatalf("Search(%q, %s) on loaded index error: %v", query, algorithm, err)
+			}
+			if len(want) == 0 || !reflect.DeepEqual(scoresByDoc(got), scoresByDoc(want)) {
+				t.Errorf("Search(%q, %s) = %v, want %v", query, algorithm, scoresByDoc(got), scoresByDoc(want))
+			}
+			for _, r := range got {
+				if r.Paper == nil || r.Paper.ID != r.DocID {
+					t.Errorf("Search(%q, %s) result %d mapped to paper %v", query, algorithm, r.DocID, r.Paper)
+				}
+			}
+		}
+	}
+}
+
+func scoresByDoc(results []*SearchResult) map[int64]float64 {
+	m := make(map[int64]float64, len(results))
+	for _, r := range results {
+		m[r.DocID] = r.Score
+	}
+	return m
+}
+
+func TestIRSearcher_LoadIndexReconcilesDrift(t *testing.T) {
+	tokenizer, _ := NewTokenizer()
+	papers := persistTestPapers(20)
+	built := NewIRSearcher(tokenizer)
+	if err := built.BuildIndex(papers); err != nil {
+		t.Fatalf("BuildIndex() error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "ir.gob")
+	if err := built.SaveIndex(path); err != nil {
+		t.Fatalf("SaveIndex() error: %v", err)
+	}
+
+	// 索引保存后删除一篇、新增一篇：差异在阈值内，增量修正
+	added := &models.Paper{ID: 999, Title: "Protein folding", Abstract: "Structure prediction."}
+	current := append(append([]*models.Paper{}, papers[1:]...), added)
+	loaded := NewIRSearcher(tokenizer)
+	drift, err := loaded.LoadIndex(path, current)
+	if err != nil || drift != 2 {
+		t.Fatalf("LoadIndex() = %d, %v; want 2, nil", drift, err)
+	}
+	if results, _ := loaded.Search(SearchOptions{Query: "protein", TopK: 5}); len(results) != 1 || results[0].DocID != 999 {
+		t.Errorf("Expected newly added paper to be searchable, got %v", results)
+	}
+	if stats := loaded.GetIndexStats(); stats["total_docs"] != 20 {
+		t.Errorf("total_docs = %v, want 20", stats["total_docs"])
+	}
+	if results, _ := loaded.Search(SearchOptions{Query: papers[0].Title, TopK: 50}); containsDoc(results, papers[0].ID) {
+		t.Error("Expected removed paper to leave the index")
+	}
+
+	// 差异超过阈值时拒绝加载
+	if _, err := NewIRSearcher(tokenizer).LoadIndex(path, papers[:10]); !errors.Is(err, ErrIndexStale) {
+		t.Errorf("Expected ErrIndexStale for large drift, got %v", err)
+	}
+	// 分词配置不同的索引不可复用
+	stemmed, _ := NewTokenizerWithConfig(TokenizerConfig{Stem: true})
+	if _, err := NewIRSearcher(stemmed).LoadIndex(path, papers); !errors.Is(err, ErrIndexStale) {
+		t.Errorf("Expected ErrIndexStale for different tokenizer config, got %v", err)
+	}
+}
+
+func containsDoc(results []*SearchResult, docID int64) bool {
+	for _, r := range results {
+		if r.DocID == docID {
+			return true
+		}
+	}
+	return false
+}
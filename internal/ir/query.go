@@ -0,0 +1,207 @@
+package ir
+
+import (
+	"strings"
+)
+
+// Field 查询作用的字段
+type Field string
+
+const (
+	FieldAll      Field = ""         // 标题与摘要
+	FieldTitle    Field = "title"    // 仅标题
+	FieldAbstract Field = "abstract" // 仅摘要
+)
+
+// QueryClause 查询子句：一组词项，Phrase 为 true 时要求在同一字段中连续出现
+type QueryClause struct {
+	Field  Field
+	Terms  []string
+	Phrase bool
+}
+
+// ParsedQuery 解析后的查询
+type ParsedQuery struct {
+	Clauses []QueryClause
+}
+
+// ParseQuery 解析查询字符串，支持字段前缀与双引号短语：
+//
+//	graph neural            普通词项，匹配标题或摘要
+//	"graph neural network"  短语，要求在标题或摘要中连续出现
+//	title:transformer       词项仅匹配标题
+//	abstract:"message passing"  短语仅匹配摘要
+//
+// 词项与短语经过同一分词器处理，停用词被移除后再比较相邻位置
+func ParseQuery(query string, tokenizer *Tokenizer) *ParsedQuery {
+	pq := &ParsedQuery{}
+	var plain []string
+
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		field, body, ok := cutFieldPrefix(rest)
+		if !ok {
+			body = rest
+		}
+
+		var text string
+		phrase := false
+		if strings.HasPrefix(body, `"`) {
+			// 引号短语，缺少右引号时取到末尾
+			if end := strings.Index(body[1:], `"`); end >= 0 {
+				text, rest = body[1:end+1], body[end+2:]
+			} else {
+				text, rest = body[1:], ""
+			}
+			phrase = true
+		} else {
+			end := strings.IndexAny(body, " \t\n")
+			if end < 0 {
+				end = len(body)
+			}
+			text, rest = body[:end], body[end:]
+		}
+		rest = strings.TrimSpace(rest)
+
+		if !phrase && !ok {
+			// 无前缀的普通词项合并为一个子句，与原有的打分方式一致
+			plain = append(plain, text)
+			continue
+		}
+
+		terms := tokenizer.Tokenize(text)
+		if len(terms) == 0 {
+			continue
+		}
+		pq.Clauses = append(pq.Clauses, QueryClause{Field: field, Terms: terms, Phrase: phrase})
+	}
+
+	if terms := tokenizer.Tokenize(strings.Join(plain, " ")); len(terms) > 0 {
+		pq.Clauses = append([]QueryClause{{Field: FieldAll, Terms: terms}}, pq.Clauses...)
+	}
+	return pq
+}
+
+// cutFieldPrefix 识别 title: / abstract: 前缀（不区分大小写）
+func cutFieldPrefix(s string) (Field, string, bool) {
+	lower := strings.ToLower(s)
+	for _, field := range []Field{FieldTitle, FieldAbstract} {
+		prefix := string(field) + ":"
+		if strings.HasPrefix(lower, prefix) && len(s) > len(prefix) {
+			return field, s[len(prefix):], true
+		}
+	}
+	return FieldAll, s, false
+}
+
+// IsEmpty 查询中没有任何可用词项
+func (q *ParsedQuery) IsEmpty() bool {
+	return len(q.Clauses) == 0
+}
+
+// MatchDocuments 返回满足查询的候选文档：
+// 所有短语子句必须匹配；若存在词项子句，还需至少一个词项出现在对应字段中
+func (ii *InvertedIndex) MatchDocuments(q *ParsedQuery) map[int64]bool {
+	var phrases []QueryClause
+	candidates := make(map[int64]bool)
+	hasTerms := false
+
+	for _, clause := range q.Clauses {
+		if clause.Phrase {
+			phrases = append(phrases, clause)
+			continue
+		}
+		hasTerms = true
+		for _, term := range clause.Terms {
+			for _, posting := range ii.GetPostingList(term) {
+				if fieldFreq(posting, clause.Field) > 0 {
+					candidates[posting.DocID] = true
+				}
+			}
+		}
+	}
+
+	if len(phrases) == 0 {
+		return candidates
+	}
+
+	// 以第一个短语的首词作为候选集合，再逐一校验所有短语
+	if !hasTerms {
+		for _, posting := range ii.GetPostingList(phrases[0].Terms[0]) {
+			candidates[posting.DocID] = true
+		}
+	}
+	for docID := range candidates {
+		for _, clause := range phrases {
+			if !ii.MatchPhrase(docID, clause.Terms, clause.Field) {
+				delete(candidates, docID)
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+// MatchPhrase 判断词项是否在文档指定字段中连续出现；FieldAll 时标题或摘要任一满足即可，
+// 短语不会跨越标题与摘要的边界
+func (ii *InvertedIndex) MatchPhrase(docID int64, terms []string, field Field) bool {
+	if len(terms) == 0 {
+		return false
+	}
+
+	postings := make([]Posting, len(terms))
+	for i, term := range terms {
+		posting, ok := ii.GetPosting(term, docID)
+		if !ok {
+			return false
+		}
+		postings[i] = posting
+	}
+
+	switch field {
+	case FieldTitle:
+		return adjacent(postings, func(p Posting) []int { return p.TitlePositions })
+	case FieldAbstract:
+		return adjacent(postings, func(p Posting) []int { return p.AbstractPositions })
+	default:
+		return adjacent(postings, func(p Posting) []int { return p.TitlePositions }) ||
+			adjacent(postings, func(p Posting) []int { return p.AbstractPositions })
+	}
+}
+
+// adjacent 检查是否存在起始位置 p，使第 i 个词出现在 p+i
+func adjacent(postings []Posting, positions func(Posting) []int) bool {
+	sets := make([]map[int]bool, len(postings))
+	for i, posting := range postings {
+		sets[i] = make(map[int]bool)
+		for _, pos := range positions(posting) {
+			sets[i][pos] = true
+		}
+	}
+
+	for start := range sets[0] {
+		matched := true
+		for i := 1; i < len(sets); i++ {
+			if !sets[i][start+i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldFreq 词在指定字段中的频率
+func fieldFreq(posting Posting, field Field) int {
+	switch field {
+	case FieldTitle:
+		return posting.TitleFreq
+	case FieldAbstract:
+		return posting.AbstractFreq
+	default:
+		return posting.TermFreq
+	}
+}
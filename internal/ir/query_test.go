@@ -0,0 +1,139 @@
+package ir
+
+import (
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestParseQuery(t *testing.T) {
+	tokenizer, _ := NewTokenizer()
+
+	q := ParseQuery(`transformer Title:"Graph Neural Network" abstract:passing "open set`, tokenizer)
+	if len(q.Clauses) != 4 {
+		t.Fatalf("Expected 4 clauses, got %d: %+v", len(q.Clauses), q.Clauses)
+	}
+
+	plain := q.Clauses[0]
+	if plain.Field != FieldAll || plain.Phrase || len(plain.Terms) != 1 || plain.Terms[0] != "transformer" {
+		t.Errorf("Unexpected plain clause: %+v", plain)
+	}
+	title := q.Clauses[1]
+	if title.Field != FieldTitle || !title.Phrase || len(title.Terms) != 3 || title.Terms[0] != "graph" {
+		t.Errorf("Unexpected title phrase clause: %+v", title)
+	}
+	abstract := q.Clauses[2]
+	if abstract.Field != FieldAbstract || abstract.Phrase || abstract.Terms[0] != "passing" {
+		t.Errorf("Unexpected abstract term clause: %+v", abstract)
+	}
+	// 缺少右引号时短语取到末尾
+	open := q.Clauses[3]
+	if open.Field != FieldAll || !open.Phrase || len(open.Terms) != 2 {
+		t.Errorf("Unexpected unterminated phrase clause: %+v", open)
+	}
+
+	// 空短语被丢弃，没有内容的前缀按普通词处理
+	q = ParseQuery(`"" title:`, tokenizer)
+	if len(q.Clauses) != 1 || q.Clauses[0].Phrase || q.Clauses[0].Terms[0] != "title" {
+		t.Errorf("Unexpected clauses for empty phrase: %+v", q.Clauses)
+	}
+}
+
+func phraseSearcher(t *testing.T) *IRSearcher {
+	t.Helper()
+	tokenizer, _ := NewTokenizer()
+	searcher := NewIRSearcher(tokenizer)
+
+	papers := []*models.Paper{
+		// 短语完整出现在标题中
+		{ID: 1, Title: "Graph Neural Network for Molecules", Abstract: "We study molecular property prediction."},
+		// 短语完整出现在摘要中
+		{ID: 2, Title: "Molecular Property Prediction", Abstract: "We propose a graph neural network with attention."},
+		// 短语跨越标题末尾与摘要开头，不应匹配
+		{ID: 3, Title: "Scalable Learning on Graph", Abstract: "Neural network models for large datasets."},
+		// 词项都出现但不相邻
+		{ID: 4, Title: "Neural Methods", Abstract: "A network of graph samplers."},
+		// 不含查询词，保证 IDF 为正
+		{ID: 5, Title: "Speech Recognition", Abstract: "Acoustic models for spoken language."},
+	}
+	if err := searcher.BuildIndex(papers); err != nil {
+		t.Fatalf("BuildIndex() error: %v", err)
+	}
+	return searcher
+}
+
+func resultIDs(results []*SearchResult) map[int64]bool {
+	ids := make(map[int64]bool, len(results))
+	for _, r := range results {
+		ids[r.DocID] = true
+	}
+	return ids
+}
+
+func TestSearch_PhraseAcrossTitleAndAbstract(t *testing.T) {
+	searcher := phraseSearcher(t)
+
+	cases := []struct {
+		query string
+		want  []int64
+	}{
+		{`"graph neural network"`, []int64{1, 2}},
+		{`title:"graph neural network"`, []int64{1}},
+		{`abstract:"graph neural network"`, []int64{2}},
+		{`title:"neural network"`, []int64{1}},
+	}
+
+	for _, algorithm := range []string{"bm25", "tfidf"} {
+		for _, tc := range cases {
+			results, err := searcher.Search(SearchOptions{Query: tc.query, TopK: 10, Algorithm: algorithm})
+			if err != nil {
+				t.Fatalf("Search(%s, %q) error: %v", algorithm, tc.query, err)
+			}
+			ids := resultIDs(results)
+			if len(ids) != len(tc.want) {
+				t.Errorf("Search(%s, %q) expected %v, got %v", algorithm, tc.query, tc.want, ids)
+				continue
+			}
+			for _, id := range tc.want {
+				if !ids[id] {
+					t.Errorf("Search(%s, %q) missing doc %d, got %v", algorithm, tc.query, id, ids)
+				}
+			}
+			for _, r := range results {
+				if r.Score <= 0 {
+					t.Errorf("Search(%s, %q) doc %d has non-positive score %f", algorithm, tc.query, r.DocID, r.Score)
+				}
+			}
+		}
+	}
+}
+
+func TestSearch_FieldScopedTerms(t *testing.T) {
+	searcher := phraseSearcher(t)
+
+	// "molecular" 只出现在 2 的标题与 1 的摘要中
+	results, err := searcher.Search(SearchOptions{Query: "title:molecular", TopK: 10, Algorithm: "bm25"})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if ids := resultIDs(results); len(ids) != 1 || !ids[2] {
+		t.Errorf("Expected only doc 2 for title:molecular, got %v", ids)
+	}
+
+	results, err = searcher.Search(SearchOptions{Query: "abstract:molecular", TopK: 10, Algorithm: "tfidf"})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if ids := resultIDs(results); len(ids) != 1 || !ids[1] {
+		t.Errorf("Expected only doc 1 for abstract:molecular, got %v", ids)
+	}
+
+	// 不带前缀的查询保持原有行为，匹配任一字段
+	results, err = searcher.Search(SearchOptions{Query: "molecular", TopK: 10, Algorithm: "bm25"})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if ids := resultIDs(results); len(ids) != 2 {
+		t.Errorf("Expected docs 1 and 2 for unscoped query, got %v", ids)
+	}
+}
@@ -3,6 +3,7 @@ package ir
 import (
 	"PaperHunter/internal/models"
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -12,13 +13,13 @@ type IRSearcher struct {
 	tokenizer    *Tokenizer
 	tfidfSearcher *TFIDFSearcher
 	bm25Searcher  *BM25Searcher
-	papers       []*models.Paper // 存储论文数据
+	papers       map[int64]*models.Paper // 论文数据，键为数据库 ID（即索引 DocID）
 	mutex        sync.RWMutex    // 保护论文数据
 }
 
 // SearchOptions 搜索选项
 type SearchOptions struct {
-	Query         string  // 查询字符串
+	Query         string  // 查询字符串，支持 title:/abstract: 前缀与双引号短语，见 ParseQuery
 	TopK          int     // 返回结果数量
 	Algorithm     string  // 算法类型: "tfidf", "bm25"
 	TitleWeight   float64 // 标题权重，默认 2.0
@@ -39,10 +40,21 @@ func NewIRSearcher(tokenizer *Tokenizer) *IRSearcher {
 		tokenizer:     tokenizer,
 		tfidfSearcher: tfidfSearcher,
 		bm25Searcher:  bm25Searcher,
-		papers:        make([]*models.Paper, 0),
+		papers:        make(map[int64]*models.Paper),
 	}
 }
 
+// PapersByID 按数据库 ID 建立论文映射，供 SearchWithPapers 解析结果
+func PapersByID(papers []*models.Paper) map[int64]*models.Paper {
+	m := make(map[int64]*models.Paper, len(papers))
+	for _, p := range papers {
+		if p != nil {
+			m[p.ID] = p
+		}
+	}
+	return m
+}
+
 // BuildIndex 从论文列表构建索引
 func (s *IRSearcher) BuildIndex(papers []*models.Paper) error {
 	s.mutex.Lock()
@@ -51,10 +63,14 @@ func (s *IRSearcher) BuildIndex(papers []*models.Paper) error {
 	if len(papers) == 0 {
 		return fmt.Errorf("论文列表为空")
 	}
+	for _, p := range papers {
+		if p == nil || p.ID <= 0 {
+			return fmt.Errorf("论文缺少数据库 ID，无法建立索引")
+		}
+	}
 
 	// 保存论文数据
-	s.papers = make([]*models.Paper, len(papers))
-	copy(s.papers, papers)
+	s.papers = PapersByID(papers)
 
 	// 批量添加文档到索引
 	s.index.AddDocuments(papers)
@@ -141,15 +157,13 @@ func (s *IRSearcher) AddDocument(paper *models.Paper) error {
 	if paper == nil {
 		return fmt.Errorf("论文不能为空")
 	}
+	if paper.ID <= 0 {
+		return fmt.Errorf("论文缺少数据库 ID，无法加入索引")
+	}
 
-	// 生成新的文档ID
-	docID := int64(len(s.papers) + 1)
-
-	// 添加到论文列表
-	s.papers = append(s.papers, paper)
-
-	// 添加到索引
-	s.index.AddDocument(docID, paper)
+	// 使用数据库 ID 作为文档ID，已存在时替换（如 Upsert 更新了标题或摘要）
+	s.papers[paper.ID] = paper
+	s.index.AddDocument(paper.ID, paper)
 
 	return nil
 }
@@ -189,8 +203,11 @@ func (s *IRSearcher) GetPapers() []*models.Paper {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	papers := make([]*models.Paper, len(s.papers))
-	copy(papers, s.papers)
+	papers := make([]*models.Paper, 0, len(s.papers))
+	for _, p := range s.papers {
+		papers = append(papers, p)
+	}
+	sort.Slice(papers, func(i, j int) bool { return papers[i].ID < papers[j].ID })
 	return papers
 }
 
@@ -199,11 +216,7 @@ func (s *IRSearcher) GetPaperByID(docID int64) *models.Paper {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	if docID <= 0 || int(docID) > len(s.papers) {
-		return nil
-	}
-
-	return s.papers[docID-1] // ID 从 1 开始，数组索引从 0 开始
+	return s.papers[docID]
 }
 
 // IsIndexEmpty 检查索引是否为空
@@ -225,7 +238,7 @@ func (s *IRSearcher) ClearIndex() {
 	s.bm25Searcher = NewBM25Searcher(s.index, s.tokenizer)
 
 	// 清空论文数据
-	s.papers = make([]*models.Paper, 0)
+	s.papers = make(map[int64]*models.Paper)
 }
 
 // IsEmpty 检查索引是否为空
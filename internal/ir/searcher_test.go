@@ -0,0 +1,114 @@
+package ir
+
+import (
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+func TestIRSearcher_NonSequentialIDs(t *testing.T) {
+	tokenizer, _ := NewTokenizer()
+	searcher := NewIRSearcher(tokenizer)
+
+	// 数据库 ID 不连续，搜索结果必须映射回正确的论文
+	papers := []*models.Paper{
+		{ID: 10, Title: "Machine Learning Basics", Abstract: "Introduction to machine learning."},
+		{ID: 42, Title: "Computer Vision", Abstract: "Image analysis with convolutional networks."},
+		{ID: 7, Title: "Speech Recognition", Abstract: "Acoustic models for spoken language."},
+	}
+	if err := searcher.BuildIndex(papers); err != nil {
+		t.Fatalf("BuildIndex() error: %v", err)
+	}
+
+	for _, algorithm := range []string{"bm25", "tfidf"} {
+		results, err := searcher.Search(SearchOptions{Query: "vision image", TopK: 10, Algorithm: algorithm})
+		if err != nil {
+			t.Fatalf("Search(%s) error: %v", algorithm, err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Search(%s) expected 1 result, got %d", algorithm, len(results))
+		}
+		if results[0].DocID != 42 || results[0].Paper == nil || results[0].Paper.Title != "Computer Vision" {
+			t.Errorf("Search(%s) returned DocID %d with paper %v, expected paper 42", algorithm, results[0].DocID, results[0].Paper)
+		}
+	}
+
+	results, err := searcher.Search(SearchOptions{Query: "spoken", TopK: 10})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(results) != 1 || results[0].Paper == nil || results[0].Paper.ID != 7 {
+		t.Errorf("Expected paper 7 for 'spoken', got %v", results)
+	}
+
+	if p := searcher.GetPaperByID(42); p == nil || p.Title != "Computer Vision" {
+		t.Errorf("GetPaperByID(42) = %v, expected Computer Vision", p)
+	}
+	if p := searcher.GetPaperByID(1); p != nil {
+		t.Errorf("GetPaperByID(1) = %q, expected nil", p.Title)
+	}
+}
+
+func TestIRSearcher_AddDocumentReplacesExisting(t *testing.T) {
+	tokenizer, _ := NewTokenizer()
+	searcher := NewIRSearcher(tokenizer)
+
+	if err := searcher.BuildIndex([]*models.Paper{
+		{ID: 10, Title: "Graph Networks", Abstract: "Message passing."},
+	}); err != nil {
+		t.Fatalf("BuildIndex() error: %v", err)
+	}
+
+	// 增量添加的论文同样使用数据库 ID
+	if err := searcher.AddDocument(&models.Paper{ID: 100, Title: "Vision Transformers", Abstract: "Transformers for images."}); err != nil {
+		t.Fatalf("AddDocument() error: %v", err)
+	}
+	// 同一 ID 再次添加（Upsert 更新），应替换而不是重复计数
+	if err := searcher.AddDocument(&models.Paper{ID: 10, Title: "Graph Transformers", Abstract: "Attention over graphs."}); err != nil {
+		t.Fatalf("AddDocument() error: %v", err)
+	}
+
+	if total := searcher.index.GetTotalDocs(); total != 2 {
+		t.Errorf("Expected 2 docs, got %d", total)
+	}
+	if df := searcher.index.GetDocumentFrequency("transformers"); df != 2 {
+		t.Errorf("Expected DF(transformers) = 2, got %d", df)
+	}
+	if df := searcher.index.GetDocumentFrequency("message"); df != 0 {
+		t.Errorf("Expected stale term 'message' removed, got DF %d", df)
+	}
+	if p := searcher.GetPaperByID(10); p == nil || p.Title != "Graph Transformers" {
+		t.Errorf("GetPaperByID(10) = %v, expected updated paper", p)
+	}
+
+	if err := searcher.AddDocument(&models.Paper{Title: "No ID"}); err == nil {
+		t.Error("Expected error when adding paper without ID")
+	}
+}
+
+func TestIRSearcher_StemmingMatchesInflections(t *testing.T) {
+	papers := []*models.Paper{
+		{ID: 1, Title: "Graph Neural Networks", Abstract: "Message passing on graphs."},
+		{ID: 2, Title: "Speech Recognition", Abstract: "Acoustic models for spoken language."},
+	}
+	for _, tc := range []struct {
+		stem bool
+		want int
+	}{{false, 0}, {true, 1}} {
+		tokenizer, err := NewTokenizerWithConfig(TokenizerConfig{Stem: tc.stem})
+		if err != nil {
+			t.Fatalf("NewTokenizerWithConfig() error: %v", err)
+		}
+		searcher := NewIRSearcher(tokenizer)
+		if err := searcher.BuildIndex(papers); err != nil {
+			t.Fatalf("BuildIndex() error: %v", err)
+		}
+		results, err := searcher.Search(SearchOptions{Query: "network", TopK: 10, Algorithm: "bm25"})
+		if err != nil {
+			t.Fatalf("Search() error: %v", err)
+		}
+		if len(results) != tc.want {
+			t.Errorf("stem=%v: expected %d results for \"network\", got %d", tc.stem, tc.want, len(results))
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package ir
+
+// Stem 对小写英文单词做 Porter 词干提取（M.F. Porter, 1980），如 networks -> network、
+// running -> run；含非 a-z 字符或长度不超过 2 的词原样返回
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	s := &stemmer{b: []byte(word), k: len(word) - 1}
+	s.step1ab()
+	if s.k > 0 {
+		s.step1c()
+		s.step2()
+		s.step3()
+		s.step4()
+		s.step5()
+	}
+	return string(s.b[:s.k+1])
+}
+
+// stemmer 词干提取的中间状态：b[:k+1] 为当前词，j 为 ends 匹配后缀之前的位置
+type stemmer struct {
+	b    []byte
+	k, j int
+}
+
+// cons b[i] 是否为辅音；y 前面是辅音时视为元音
+func (s *stemmer) cons(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.cons(i-1)
+	}
+	return true
+}
+
+// m 统计 b[:j+1] 中 [C](VC)^m[V] 的 m
+func (s *stemmer) m() int {
+	n, i := 0, 0
+	for {
+		if i > s.j {
+			return n
+		}
+		if !s.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+	for {
+		for {
+			if i > s.j {
+				return n
+			}
+			if s.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+		for {
+			if i > s.j {
+				return n
+			}
+			if !s.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+// vowelInStem b[:j+1] 中是否含元音
+func (s *stemmer) vowelInStem() bool {
+	for i := 0; i <= s.j; i++ {
+		if !s.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleC b[i-1:i+1] 是否为两个相同的辅音
+func (s *stemmer) doubleC(i int) bool {
+	return i >= 1 && s.b[i] == s.b[i-1] && s.cons(i)
+}
+
+// cvc b[i-2:i+1] 是否为辅音-元音-辅音，且最后一个辅音不是 w、x、y，如 hop、cav
+func (s *stemmer) cvc(i int) bool {
+	if i < 2 || !s.cons(i) || s.cons(i-1) || !s.cons(i-2) {
+		return false
+	}
+	switch s.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends 当前词是否以 suffix 结尾，是则将 j 设为后缀之前的位置
+func (s *stemmer) ends(suffix string) bool {
+	n := len(suffix)
+	if n > s.k+1 || string(s.b[s.k+1-n:s.k+1]) != suffix {
+		return false
+	}
+	s.j = s.k - n
+	return true
+}
+
+// setTo 将 b[j+1:k+1] 替换为 suffix
+func (s *stemmer) setTo(suffix string) {
+	s.b = append(s.b[:s.j+1], suffix...)
+	s.k = len(s.b) - 1
+}
+
+// r m > 0 时将后缀替换为 suffix
+func (s *stemmer) r(suffix string) {
+	if s.m() > 0 {
+		s.setTo(suffix)
+	}
+}
+
+// replaceFirst 找到第一个匹配的后缀并在 m > 0 时替换，返回是否有后缀匹配
+func (s *stemmer) replaceFirst(pairs [][2]string) bool {
+	for _, p := range pairs {
+		if s.ends(p[0]) {
+			s.r(p[1])
+			return true
+		}
+	}
+	return false
+}
+
+// step1ab 去掉复数与 -ed、-ing，如 caresses -> caress、ponies -> poni、hopping -> hop
+func (s *stemmer) step1ab() {
+	if s.b[s.k] == 's' {
+		switch {
+		case s.ends("sses"):
+			s.k -= 2
+		case s.ends("ies"):
+			s.setTo("i")
+		case s.b[s.k-1] != 's':
+			s.k--
+		}
+	}
+	if s.ends("eed") {
+		if s.m() > 0 {
+			s.k--
+		}
+		return
+	}
+	if !((s.ends("ed") || s.ends("ing")) && s.vowelInStem()) {
+		return
+	}
+	s.k = s.j
+	switch {
+	case s.ends("at"):
+		s.setTo("ate")
+	case s.ends("bl"):
+		s.setTo("ble")
+	case s.ends("iz"):
+		s.setTo("ize")
+	case s.doubleC(s.k):
+		switch s.b[s.k] {
+		case 'l', 's', 'z':
+		default:
+			s.k--
+		}
+	default:
+		s.j = s.k
+		if s.m() == 1 && s.cvc(s.k) {
+			s.setTo("e")
+		}
+	}
+}
+
+// step1c 词干含元音时末尾的 y 改为 i，如 happy -> happi
+func (s *stemmer) step1c() {
+	if s.ends("y") && s.vowelInStem() {
+		s.b[s.k] = 'i'
+	}
+}
+
+// step2 将双重后缀归并为单个后缀，如 -ization -> -ize、-ational -> -ate
+func (s *stemmer) step2() {
+	var pairs [][2]string
+	switch s.b[s.k-1] {
+	case 'a':
+		pairs = [][2]string{{"ational", "ate"}, {"tional", "tion"}}
+	case 'c':
+		pairs = [][2]string{{"enci", "ence"}, {"anci", "ance"}}
+	case 'e':
+		pairs = [][2]string{{"izer", "ize"}}
+	case 'l':
+		pairs = [][2]string{{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"}}
+	case 'o':
+		pairs = [][2]string{{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}}
+	case 's':
+		pairs = [][2]string{{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"}}
+	case 't':
+		pairs = [][2]string{{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"}}
+	case 'g':
+		pairs = [][2]string{{"logi", "log"}}
+	}
+	s.replaceFirst(pairs)
+}
+
+// step3 处理 -ic-、-full、-ness 等，如 -icate -> -ic、-ness -> ""
+func (s *stemmer) step3() {
+	var pairs [][2]string
+	switch s.b[s.k] {
+	case 'e':
+		pairs = [][2]string{{"icate", "ic"}, {"ative", ""}, {"alize", "al"}}
+	case 'i':
+		pairs = [][2]string{{"iciti", "ic"}}
+	case 'l':
+		pairs = [][2]string{{"ical", "ic"}, {"ful", ""}}
+	case 's':
+		pairs = [][2]string{{"ness", ""}}
+	}
+	s.replaceFirst(pairs)
+}
+
+// step4 在 m > 1 时去掉 -ant、-ence、-ment 等后缀
+func (s *stemmer) step4() {
+	var suffixes []string
+	switch s.b[s.k-1] {
+	case 'a':
+		suffixes = []string{"al"}
+	case 'c':
+		suffixes = []string{"ance", "ence"}
+	case 'e':
+		suffixes = []string{"er"}
+	case 'i':
+		suffixes = []string{"ic"}
+	case 'l':
+		suffixes = []string{"able", "ible"}
+	case 'n':
+		suffixes = []string{"ant", "ement", "ment", "ent"}
+	case 'o':
+		if s.ends("ion") && s.j >= 0 && (s.b[s.j] == 's' || s.b[s.j] == 't') {
+			break
+		}
+		suffixes = []string{"ou"}
+	case 's':
+		suffixes = []string{"ism"}
+	case 't':
+		suffixes = []string{"ate", "iti"}
+	case 'u':
+		suffixes = []string{"ous"}
+	case 'v':
+		suffixes = []string{"ive"}
+	case 'z':
+		suffixes = []string{"ize"}
+	default:
+		return
+	}
+	if suffixes != nil {
+		matched := false
+		for _, suffix := range suffixes {
+			if s.ends(suffix) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return
+		}
+	}
+	if s.m() > 1 {
+		s.k = s.j
+	}
+}
+
+// step5 m > 1 时去掉末尾的 e，并把 -ll 缩为 -l，如 probate -> probat、controll -> control
+func (s *stemmer) step5() {
+	s.j = s.k
+	if s.b[s.k] == 'e' {
+		if a := s.m(); a > 1 || (a == 1 && !s.cvc(s.k-1)) {
+			s.k--
+		}
+	}
+	if s.b[s.k] == 'l' && s.doubleC(s.k) && s.m() > 1 {
+		s.k--
+	}
+}
@@ -0,0 +1,32 @@
+package ir
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	// 期望值取自 Porter 算法的参考实现
+	cases := map[string]string{
+		"networks":       "network",
+		"network":        "network",
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"running":        "run",
+		"hopping":        "hop",
+		"agreed":         "agre",
+		"happy":          "happi",
+		"relational":     "relat",
+		"generalization": "gener",
+		"hopeful":        "hope",
+		"goodness":       "good",
+		"adjustment":     "adjust",
+		"adoption":       "adopt",
+		"controll":       "control",
+		"probate":        "probat",
+		"as":             "as",
+		"bert2":          "bert2",
+	}
+	for word, want := range cases {
+		if got := Stem(word); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
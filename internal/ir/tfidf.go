@@ -26,22 +26,16 @@ func NewTFIDFSearcher(index *InvertedIndex, tokenizer *Tokenizer) *TFIDFSearcher
 
 func (s *TFIDFSearcher) Search(query string, topK int) []*SearchResult {
 
-	queryTerms := s.tokenizer.Tokenize(query)
-	if len(queryTerms) == 0 {
+	parsed := ParseQuery(query, s.tokenizer)
+	if parsed.IsEmpty() {
 		return make([]*SearchResult, 0)
 	}
 
-	candidateDocs := make(map[int64]bool)
-	for _, term := range queryTerms {
-		postingList := s.index.GetPostingList(term)
-		for _, posting := range postingList {
-			candidateDocs[posting.DocID] = true
-		}
-	}
+	candidateDocs := s.index.MatchDocuments(parsed)
 
 	docScores := make(map[int64]float64)
 	for docID := range candidateDocs {
-		score := s.computeDocumentScore(queryTerms, docID)
+		score := s.computeQueryScore(parsed, docID)
 		docScores[docID] = score
 	}
 
@@ -123,26 +117,63 @@ func (s *TFIDFSearcher) computeDocumentScore(queryTerms []string, docID int64) f
 		titleWeight := 2.0
 		abstractWeight := 1.0
 
-		titleTFIDF := (1 + math.Log(float64(titleFreq))) * idf * titleWeight
-		abstractTFIDF := (1 + math.Log(float64(abstractFreq))) * idf * abstractWeight
+		// 词只出现在一个字段时，另一个字段不计分（避免 log(0)）
+		if titleFreq > 0 {
+			totalScore += (1 + math.Log(float64(titleFreq))) * idf * titleWeight
+		}
+		if abstractFreq > 0 {
+			totalScore += (1 + math.Log(float64(abstractFreq))) * idf * abstractWeight
+		}
+	}
+
+	return totalScore
+}
+
+// computeQueryScore 按子句累加分数，限定字段的子句只使用该字段的词频
+func (s *TFIDFSearcher) computeQueryScore(q *ParsedQuery, docID int64) float64 {
+	var totalScore float64
+	for _, clause := range q.Clauses {
+		if clause.Field == FieldAll {
+			totalScore += s.computeDocumentScore(clause.Terms, docID)
+		} else {
+			totalScore += s.computeFieldScore(clause.Terms, clause.Field, docID)
+		}
+	}
+	return totalScore
+}
 
-		totalScore += titleTFIDF + abstractTFIDF
+// computeFieldScore 计算查询词在单个字段上的 TF-IDF 分数
+func (s *TFIDFSearcher) computeFieldScore(queryTerms []string, field Field, docID int64) float64 {
+	weight := 1.0
+	if field == FieldTitle {
+		weight = 2.0
 	}
 
+	totalDocs := s.index.GetTotalDocs()
+	var totalScore float64
+	for _, term := range queryTerms {
+		posting, ok := s.index.GetPosting(term, docID)
+		if !ok {
+			continue
+		}
+		tf := fieldFreq(posting, field)
+		df := s.index.GetDocumentFrequency(term)
+		if tf == 0 || df == 0 || totalDocs == 0 {
+			continue
+		}
+
+		idf := math.Log(float64(totalDocs) / float64(df))
+		totalScore += (1 + math.Log(float64(tf))) * idf * weight
+	}
 	return totalScore
 }
 
 // SearchWithPapers 执行搜索并返回包含论文信息的完整结果
-func (s *TFIDFSearcher) SearchWithPapers(query string, topK int, papers []*models.Paper) []*SearchResult {
+func (s *TFIDFSearcher) SearchWithPapers(query string, topK int, papers map[int64]*models.Paper) []*SearchResult {
 	results := s.Search(query, topK)
 
-	paperMap := make(map[int64]*models.Paper)
-	for i, paper := range papers {
-		paperMap[int64(i+1)] = paper
-	}
-
 	for _, result := range results {
-		if paper, exists := paperMap[result.DocID]; exists {
+		if paper, exists := papers[result.DocID]; exists {
 			result.Paper = paper
 		}
 	}
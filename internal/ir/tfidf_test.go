@@ -178,7 +178,7 @@ func TestTFIDFSearcher_SearchWithPapers(t *testing.T) {
 
 	index.AddDocuments(papers)
 
-	results := searcher.SearchWithPapers("learning", 10, papers)
+	results := searcher.SearchWithPapers("learning", 10, PapersByID(papers))
 
 	if len(results) == 0 {
 		t.Error("Expected search results, got empty slice")
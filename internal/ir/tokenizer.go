@@ -1,24 +1,118 @@
 package ir
 
 import (
+	"crypto/sha256"
 	"embed"
+	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-//go:embed stopWords.txt
+//go:embed stopWords.txt stopWords_zh.txt
 var stopWordsFS embed.FS
 var stopWords map[string]bool
+var stopWordsZh map[string]bool
+
+// 停用词语言
+const (
+	StopwordLangEN = "en"
+	StopwordLangZH = "zh" // 中英文停用词，汉字逐字切分
+)
+
+var (
+	nonWordRe    = regexp.MustCompile(`[^a-z0-9\s-]`)
+	nonWordCJKRe = regexp.MustCompile(`[^a-z0-9\s\p{Han}-]`)
+)
+
+// DefaultMinTokenLength 默认的最短词长，单字母词不参与检索
+const DefaultMinTokenLength = 2
+
+// TokenizerConfig 分词配置；建立索引与解析查询必须使用同一配置，否则词项对不上
+type TokenizerConfig struct {
+	StopwordLang    string   // 停用词语言：en 或 zh，为空时为 en
+	CustomStopwords []string // 追加的停用词
+	Stem            bool     // 对英文词做 Porter 词干提取，使 networks 与 network 归为同一词项
+	MinTokenLength  int      // 最短词长（按字符计），<= 0 时为 DefaultMinTokenLength；逐字切分的汉字不受限制
+}
+
 type Tokenizer struct {
-	stopWords map[string]bool  // 维护一个停用词的集合
+	stopWords map[string]bool // 维护一个停用词的集合，每个分词器持有独立副本
+	cjk       bool            // 是否保留汉字并逐字切分
+	stem      bool            // 是否做词干提取
+	minLen    int             // 最短词长
 }
 
+// NewTokenizer 创建使用内置英文停用词的分词器
+func NewTokenizer() (*Tokenizer, error) {
+	return NewTokenizerWithStopwords(StopwordLangEN)
+}
 
+// NewTokenizerWithStopwords 按语言创建分词器：en 只保留英文与数字；zh 额外保留汉字并逐字切分，
+// 同时使用中英文停用词。lang 为空时按 en 处理
+func NewTokenizerWithStopwords(lang string) (*Tokenizer, error) {
+	switch lang {
+	case "", StopwordLangEN:
+		return &Tokenizer{stopWords: copyStopWords(stopWords), minLen: DefaultMinTokenLength}, nil
+	case StopwordLangZH:
+		t := &Tokenizer{stopWords: copyStopWords(stopWords), cjk: true, minLen: DefaultMinTokenLength}
+		for word := range stopWordsZh {
+			t.stopWords[word] = true
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("不支持的停用词语言: %s（可选 en、zh）", lang)
+}
 
-func NewTokenizer() (*Tokenizer, error) {
-	return &Tokenizer {
-		stopWords: stopWords,
-	}, nil
+// NewTokenizerWithConfig 按配置创建分词器
+func NewTokenizerWithConfig(cfg TokenizerConfig) (*Tokenizer, error) {
+	t, err := NewTokenizerWithStopwords(cfg.StopwordLang)
+	if err != nil {
+		return nil, err
+	}
+	t.AddStopwords(cfg.CustomStopwords)
+	t.stem = cfg.Stem
+	if cfg.MinTokenLength > 0 {
+		t.minLen = cfg.MinTokenLength
+	}
+	return t, nil
+}
+
+// AddStopwords 追加自定义停用词（不区分大小写），需在建立索引前调用
+func (t *Tokenizer) AddStopwords(words []string) {
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			t.stopWords[word] = true
+		}
+	}
+}
+
+// RemoveStopwords 将词从停用词中移除，使其重新参与检索，需在建立索引前调用
+func (t *Tokenizer) RemoveStopwords(words []string) {
+	for _, word := range words {
+		delete(t.stopWords, strings.ToLower(strings.TrimSpace(word)))
+	}
+}
+
+// signature 概括影响分词结果的配置，用于判断磁盘上的索引是否由相同配置的分词器建立
+func (t *Tokenizer) signature() string {
+	words := make([]string, 0, len(t.stopWords))
+	for word := range t.stopWords {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	sum := sha256.Sum256([]byte(strings.Join(words, "\n")))
+	return fmt.Sprintf("cjk=%v stem=%v min=%d stopwords=%x", t.cjk, t.stem, t.minLen, sum[:8])
+}
+
+func copyStopWords(words map[string]bool) map[string]bool {
+	out := make(map[string]bool, len(words))
+	for word := range words {
+		out[word] = true
+	}
+	return out
 }
 
 func (t *Tokenizer) Tokenize(text string) []string {
@@ -28,27 +122,69 @@ func (t *Tokenizer) Tokenize(text string) []string {
 
 	text = strings.ToLower(text)
 
-
-	reg := regexp.MustCompile(`[^a-z0-9\s-]`)
-	text = reg.ReplaceAllString(text, " ")
+	if t.cjk {
+		text = nonWordCJKRe.ReplaceAllString(text, " ")
+	} else {
+		text = nonWordRe.ReplaceAllString(text, " ")
+	}
 
 	text = strings.ReplaceAll(text, "-", " ")
 
 	words := strings.Fields(text)
+	if t.cjk {
+		words = splitHan(words)
+	}
 
 	tokens := make([]string, 0, len(words))
 
 	for _, word := range words {
 		word = strings.TrimSpace(word)
 
-		if word != "" && len(word) > 1 && !t.stopWords[word] {
-			tokens = append(tokens, word)
+		if word == "" || t.stopWords[word] || !t.longEnough(word) {
+			continue
 		}
+		// 先判断停用词再提取词干，停用词表中的词均为原形
+		if t.stem {
+			word = Stem(word)
+		}
+		tokens = append(tokens, word)
 	}
 
 	return tokens
 }
 
+// longEnough 词长是否达到最短词长，逐字切分出的单个汉字总是保留
+func (t *Tokenizer) longEnough(word string) bool {
+	n := utf8.RuneCountInString(word)
+	if n >= t.minLen {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(word)
+	return t.cjk && n == 1 && unicode.Is(unicode.Han, r)
+}
+
+// splitHan 把词中的每个汉字拆成单独的词，汉字两侧的英文与数字保持连续
+func splitHan(words []string) []string {
+	out := make([]string, 0, len(words))
+	for _, word := range words {
+		start := 0
+		for i, r := range word {
+			if !unicode.Is(unicode.Han, r) {
+				continue
+			}
+			if start < i {
+				out = append(out, word[start:i])
+			}
+			out = append(out, string(r))
+			start = i + len(string(r))
+		}
+		if start < len(word) {
+			out = append(out, word[start:])
+		}
+	}
+	return out
+}
+
 func (t *Tokenizer) TokenizeWithCount(text string) map[string]int {
 	result := make(map[string]int)
 
@@ -62,4 +198,4 @@ func (t *Tokenizer) TokenizeWithCount(text string) map[string]int {
 	}
 
 	return result
-}
\ No newline at end of file
+}
@@ -3,6 +3,8 @@ package ir
 import (
 	"reflect"
 	"testing"
+
+	"PaperHunter/internal/models"
 )
 
 func TestNewTokenizer(t *testing.T) {
@@ -108,4 +110,94 @@ func TestTokenizer_TokenizeWithCount(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestTokenizer_ExcludesStopwords(t *testing.T) {
+	tokenizer, _ := NewTokenizer()
+	for _, token := range tokenizer.Tokenize("The analysis of a graph is the goal of the study") {
+		switch token {
+		case "the", "a", "of", "is":
+			t.Errorf("Tokenize() kept stopword %q", token)
+		}
+	}
+}
+
+func TestTokenizer_CustomStopwords(t *testing.T) {
+	tokenizer, _ := NewTokenizer()
+	tokenizer.AddStopwords([]string{"Neural"})
+	index := NewInvertedIndex(tokenizer)
+	index.AddDocuments([]*models.Paper{
+		{ID: 1, Title: "Neural Networks", Abstract: "Graph neural networks for molecules."},
+	})
+
+	if df := index.GetDocumentFrequency("neural"); df != 0 {
+		t.Errorf("Expected custom stopword to be absent from index, got df=%d", df)
+	}
+	if df := index.GetDocumentFrequency("networks"); df != 1 {
+		t.Errorf("Expected df=1 for networks, got %d", df)
+	}
+
+	// 自定义停用词只影响当前分词器
+	other, _ := NewTokenizer()
+	if got := other.Tokenize("neural"); !reflect.DeepEqual(got, []string{"neural"}) {
+		t.Errorf("Tokenize() on a fresh tokenizer = %v, want [neural]", got)
+	}
+
+	tokenizer.RemoveStopwords([]string{"neural", "the"})
+	if got := tokenizer.Tokenize("the neural"); !reflect.DeepEqual(got, []string{"the", "neural"}) {
+		t.Errorf("Tokenize() after RemoveStopwords = %v, want [the neural]", got)
+	}
+}
+
+func TestNewTokenizerWithStopwords_Chinese(t *testing.T) {
+	tokenizer, err := NewTokenizerWithStopwords(StopwordLangZH)
+	if err != nil {
+		t.Fatalf("NewTokenizerWithStopwords() error: %v", err)
+	}
+	got := tokenizer.Tokenize("基于GNN的图学习 for the graph")
+	want := []string{"基", "gnn", "图", "学", "习", "graph"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+
+	if _, err := NewTokenizerWithStopwords("fr"); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+}
+
+func TestNewTokenizerWithConfig_Stemming(t *testing.T) {
+	plain, err := NewTokenizerWithConfig(TokenizerConfig{})
+	if err != nil {
+		t.Fatalf("NewTokenizerWithConfig() error: %v", err)
+	}
+	if a, b := plain.Tokenize("networks"), plain.Tokenize("network"); reflect.DeepEqual(a, b) {
+		t.Errorf("Expected networks and network to differ without stemming, both got %v", a)
+	}
+
+	stemmed, err := NewTokenizerWithConfig(TokenizerConfig{Stem: true, CustomStopwords: []string{"models"}})
+	if err != nil {
+		t.Fatalf("NewTokenizerWithConfig() error: %v", err)
+	}
+	if a, b := stemmed.Tokenize("networks"), stemmed.Tokenize("network"); !reflect.DeepEqual(a, b) || len(a) != 1 {
+		t.Errorf("Expected networks and network to collide with stemming, got %v and %v", a, b)
+	}
+	// 停用词按原形匹配，提取词干后不会漏掉
+	if got := stemmed.Tokenize("the models"); len(got) != 0 {
+		t.Errorf("Expected stopwords to be removed before stemming, got %v", got)
+	}
+
+	if _, err := NewTokenizerWithConfig(TokenizerConfig{StopwordLang: "fr"}); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+}
+
+func TestNewTokenizerWithConfig_MinTokenLength(t *testing.T) {
+	tokenizer, err := NewTokenizerWithConfig(TokenizerConfig{StopwordLang: StopwordLangZH, MinTokenLength: 4})
+	if err != nil {
+		t.Fatalf("NewTokenizerWithConfig() error: %v", err)
+	}
+	got := tokenizer.Tokenize("GNN for graph 图")
+	want := []string{"graph", "图"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %v, want %v", got, want)
+	}
+}
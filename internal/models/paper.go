@@ -1,13 +1,25 @@
 package models
 
 import (
+	"regexp"
 	"strings"
 	"time"
 )
 
+// reDOI 备注中的 DOI（如 ACL / DBLP 写入的 "DOI: 10.18653/..."）或 doi.org 链接
+var reDOI = regexp.MustCompile(`(?i)(?:\bdoi:\s*|doi\.org/)(10\.\d+/[^\s|,;]+)`)
+
 type SimilarPaper struct {
 	Paper      Paper
 	Similarity float32 //与关键词的匹配相似度，这里主要是定义相似度多少就可以存储
+	Snippet    string  // 摘要片段：关键词/IR 搜索为命中词附近的窗口（**term** 标出查询词），语义搜索为摘要首句
+}
+
+// NotedPaper 带私人笔记的论文
+type NotedPaper struct {
+	Paper         Paper
+	Note          string
+	NoteUpdatedAt time.Time `ts_type:"string"`
 }
 
 type SearchCondition struct {
@@ -15,10 +27,30 @@ type SearchCondition struct {
 	Keywords []string   // 走 embedding。可以考虑调用时拼接成向量
 	DateFrom *time.Time `ts_type:"string|null"`
 	DateTo   *time.Time `ts_type:"string|null"`
+	Status   string     // 阅读状态过滤，为空表示不限
+	Starred  bool       // 只保留已收藏的论文
+	Authors  []string   // 作者过滤（子串匹配），多个作者之间为 OR
 	Limit    int
 	Offset   int
 }
 
+// 论文阅读状态，没有记录的论文视为 unread
+const (
+	StatusUnread   = "unread"
+	StatusReading  = "reading"
+	StatusRead     = "read"
+	StatusArchived = "archived"
+)
+
+// ValidPaperStatus 判断是否为合法的阅读状态
+func ValidPaperStatus(status string) bool {
+	switch status {
+	case StatusUnread, StatusReading, StatusRead, StatusArchived:
+		return true
+	}
+	return false
+}
+
 /*
    "deep learning" → [0.21, 0.15, -0.08, ..., 0.33]  (1536维)
 
@@ -47,20 +79,36 @@ SearchByEmbedding(
 // Paper 统一的论文数据模型，独立于具体平台（arXiv/ACL 等）
 
 type Paper struct {
-	ID                 int64     `db:"id"`
-	Source             string    `db:"source"`    // 平台标识，如: "arxiv", "acl", "dblp", "semantic"
-	SourceID           string    `db:"source_id"` // 平台内唯一ID，如: arXivID
-	URL                string    `db:"url"`
-	Title              string    `db:"title"`
-	TitleTranslated    string    `db:"title_translated"`
-	Authors            []string  `db:"-"`
-	Abstract           string    `db:"abstract"`
-	AbstractTranslated string    `db:"abstract_translated"`
-	Categories         []string  `db:"-"`
-	Comments           string    `db:"comments"`
-	FirstSubmittedAt   time.Time `db:"first_submitted_date" ts_type:"string"`
-	FirstAnnouncedAt   time.Time `db:"first_announced_date" ts_type:"string"`
-	UpdatedAt          time.Time `db:"update_time" ts_type:"string"`
+	ID                 int64          `db:"id"`
+	Source             string         `db:"source"`    // 平台标识，如: "arxiv", "acl", "dblp", "semantic"
+	SourceID           string         `db:"source_id"` // 平台内唯一ID，如: arXivID
+	URL                string         `db:"url"`
+	Title              string         `db:"title"`
+	TitleTranslated    string         `db:"title_translated"`
+	Authors            []string       `db:"-"`
+	Abstract           string         `db:"abstract"`
+	AbstractTranslated string         `db:"abstract_translated"`
+	Categories         []string       `db:"-"`
+	Comments           string         `db:"comments"`
+	Citations          int            `db:"citations"`       // 被引用次数，平台未提供时为 0
+	Decision           string         `db:"decision"`        // 录用决定，如 OpenReview 的 "Accept (Oral)"，未知时为空
+	References         []string       `db:"-"`               // 引用的同平台论文 SourceID，爬取时填充后写入 paper_citations
+	AltSources         []string       `db:"-"`               // 跨平台合并进来的其他来源，如 "acl:2024.acl-long.1"
+	OpenAccessURL      string         `db:"open_access_url"` // 可免费获取的 PDF 链接，未知时为空
+	FirstSubmittedAt   time.Time      `db:"first_submitted_date" ts_type:"string"`
+	FirstAnnouncedAt   time.Time      `db:"first_announced_date" ts_type:"string"`
+	UpdatedAt          time.Time      `db:"update_time" ts_type:"string"`
+	Status             string         `db:"-"` // 阅读状态，仅在导出等需要时由 LoadPaperStatus 填充
+	Starred            bool           `db:"-"` // 是否已收藏，同上
+	LatestVersion      string         `db:"-"` // 最新版本号，如 arXiv 的 "v2"，平台不区分版本时为空
+	VersionHistory     []PaperVersion `db:"-"` // 抓取时得到的版本，保存论文后写入 paper_versions
+}
+
+// PaperVersion 论文的一个版本，如 arXiv 的 v1、v2
+type PaperVersion struct {
+	Version     string
+	SubmittedAt time.Time `ts_type:"string"`
+	ChangeNote  string    // 版本说明，arXiv 取自该版本的 comment
 }
 
 func (p *Paper) AuthorsCSV() string {
@@ -70,3 +118,47 @@ func (p *Paper) AuthorsCSV() string {
 func (p *Paper) CategoriesCSV() string {
 	return strings.Join(p.Categories, ", ")
 }
+
+// DOI 从备注或 doi.org 链接中提取小写 DOI，没有时返回空字符串；数据库 doi 列即按此填充
+func (p *Paper) DOI() string {
+	for _, s := range []string{p.Comments, p.URL} {
+		if m := reDOI.FindStringSubmatch(s); m != nil {
+			return strings.ToLower(strings.TrimRight(m[1], "."))
+		}
+	}
+	return ""
+}
+
+// EmbeddingStat 库中已存向量按模型与维度分组的数量
+type EmbeddingStat struct {
+	Model string `json:"model"`
+	Dim   int    `json:"dim"`
+	Count int    `json:"count"`
+}
+
+// CrawlHistory 一次爬取任务的统计记录，持久化在 crawl_stats 表中
+type CrawlHistory struct {
+	TaskID      string                 `json:"task_id"`
+	Platform    string                 `json:"platform"`
+	Params      map[string]interface{} `json:"params,omitempty"`
+	Total       int                    `json:"total"`
+	StartTime   time.Time              `json:"start_time"`
+	EndTime     time.Time              `json:"end_time"`
+	Status      string                 `json:"status"`                 // completed, cancelled, failed
+	InsertedIDs []int64                `json:"inserted_ids,omitempty"` // 本次任务入库的论文 ID
+}
+
+// SavedSearch 命名保存的搜索，再次检查时与上次的结果数比较得出新增论文数
+type SavedSearch struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	QueryJSON       string    `json:"query_json"` // SavedSearchQuery 的 JSON
+	LastChecked     time.Time `json:"last_checked"`
+	LastResultCount int       `json:"last_result_count"`
+}
+
+// SavedSearchQuery 保存的检索条件：Query 为标题或摘要中的子串（为空表示不限），Condition 为平台、日期、作者等过滤
+type SavedSearchQuery struct {
+	Query     string          `json:"query"`
+	Condition SearchCondition `json:"condition"`
+}
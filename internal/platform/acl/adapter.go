@@ -6,14 +6,18 @@ import (
 	"io"
 	"net/http"
 
-	"PaperHunter/internal/core"
 	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/httpclient"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/proxy"
+	"PaperHunter/pkg/ratelimit"
 )
 
 type Adapter struct {
 	config     *Config
 	httpClient *http.Client
+	limiter    ratelimit.Limiter
+	proxies    *proxy.Rotator // 当前代理被封禁（403/429）时切换到下一个
 }
 
 func NewAdapter(config *Config) (*Adapter, error) {
@@ -24,10 +28,16 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	client := core.NewHTTPClient(int(config.Timeout.Seconds()), config.Proxy)
+	proxies := proxy.NewRotator(append([]string{config.Proxy}, config.ProxyPool...))
+	client := httpclient.New(config.Timeout, proxies,
+		httpclient.WithInsecureSkipVerify(config.InsecureSkipVerify),
+		httpclient.WithDialTimeout(config.DialTimeout),
+	)
 	return &Adapter{
 		config:     config,
 		httpClient: client,
+		limiter:    ratelimit.For("acl", config.RateLimit()),
+		proxies:    proxies,
 	}, nil
 }
 
@@ -35,7 +45,12 @@ func (a *Adapter) Name() string { return "acl" }
 
 func (a *Adapter) GetConfig() platform.Config { return a.config }
 
+// SetLimiter 替换请求限速器
+func (a *Adapter) SetLimiter(l ratelimit.Limiter) { a.limiter = l }
+
 func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	// 增量爬取时按 Since 收窄起始日期，早于该日期的论文由 matchesQuery 过滤
+	q.DateFrom = q.EffectiveDateFrom()
 	if a.config.UseRSS {
 		logger.Info("[ACL] 使用 RSS 模式获取最新论文")
 		return a.searchViaRSS(ctx, q)
@@ -47,6 +62,7 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 	return platform.Result{}, fmt.Errorf("错误的配置，请检查 config.yaml 中的配置")
 }
 
+// request 发送 GET 请求；配置了 proxy_pool 时，403/429 切换到下一个代理立即重试，最多 len(proxy_pool) 次
 func (a *Adapter) request(ctx context.Context, url string) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -56,9 +72,22 @@ func (a *Adapter) request(ctx context.Context, url string) (string, error) {
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/142.0.0.0 Safari/537.36")
 	req.Header.Set("Accept", "application/xml,application/rss+xml,text/plain")
 
-	resp, err := a.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+	var resp *http.Response
+	for rotations := 0; ; rotations++ {
+		if err := a.limiter.Wait(ctx); err != nil {
+			return "", err
+		}
+		resp, err = a.httpClient.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("request failed: %w", err)
+		}
+		blocked := resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests
+		if !blocked || rotations >= len(a.config.ProxyPool) || a.proxies.Len() <= 1 {
+			break
+		}
+		resp.Body.Close()
+		a.proxies.Next()
+		logger.Warn("[ACL] 收到 HTTP %d，切换代理后重试（%d/%d）", resp.StatusCode, rotations+1, len(a.config.ProxyPool))
 	}
 	defer resp.Body.Close()
 
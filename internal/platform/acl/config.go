@@ -9,9 +9,15 @@ type Config struct {
 	BaseURL   string        `mapstructure:"base_url" yaml:"base_url"`
 	Timeout   time.Duration `mapstructure:"timeout" yaml:"timeout"`
 	Proxy     string        `mapstructure:"proxy" yaml:"proxy"`
+	ProxyPool []string      `mapstructure:"proxy_pool" yaml:"proxy_pool"` // 备用代理，当前代理返回 403/429 时依次切换
 	Step      int           `mapstructure:"step" yaml:"step"`
 	UseRSS    bool          `mapstructure:"use_rss" yaml:"use_rss"`       // true: 使用 RSS 获取最新 1000 篇, false: 使用 BibTeX 全量
 	UseBibTeX bool          `mapstructure:"use_bibtex" yaml:"use_bibtex"` // 是否使用带摘要的 BibTeX 文件
+
+	RateLimitRPS float64 `mapstructure:"rate_limit_rps" yaml:"rate_limit_rps"` // 每秒请求数上限，0 表示不限速
+
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify"` // 跳过 TLS 证书校验，仅用于自签证书的代理
+	DialTimeout        time.Duration `mapstructure:"dial_timeout" yaml:"dial_timeout"`                 // 建立连接超时，如 10s
 }
 
 func DefaultConfig() *Config {
@@ -21,6 +27,9 @@ func DefaultConfig() *Config {
 		Step:      100,
 		UseRSS:    true,  // 默认使用 RSS 模式
 		UseBibTeX: false, // 默认不使用 BibTeX 全量模式
+
+		RateLimitRPS: 2,
+		DialTimeout:  30 * time.Second,
 	}
 }
 
@@ -34,5 +43,13 @@ func (c *Config) Validate() error {
 	if c.Step <= 0 {
 		return fmt.Errorf("step must be positive")
 	}
+	if c.DialTimeout < 0 {
+		return fmt.Errorf("dial_timeout must not be negative")
+	}
+	if c.RateLimitRPS < 0 {
+		return fmt.Errorf("rate_limit_rps must not be negative")
+	}
 	return nil
 }
+
+func (c *Config) RateLimit() float64 { return c.RateLimitRPS }
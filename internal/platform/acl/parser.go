@@ -1,8 +1,11 @@
 package acl
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -10,9 +13,11 @@ import (
 	"regexp"
 	"strings"
 	"time"
+	"unicode"
 
 	"PaperHunter/internal/models"
 	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/bibtex"
 	"PaperHunter/pkg/logger"
 )
 
@@ -108,8 +113,8 @@ func (a *Adapter) convertRSSItemToPaper(item RSSItem) *models.Paper {
 	paper := &models.Paper{
 		Source:    "acl",
 		URL:       item.Link,
-		Title:     cleanText(item.Title),
-		Abstract:  cleanText(item.Description),
+		Title:     bibtex.CleanText(item.Title),
+		Abstract:  bibtex.CleanText(item.Description),
 		UpdatedAt: time.Now(),
 	}
 
@@ -119,8 +124,8 @@ func (a *Adapter) convertRSSItemToPaper(item RSSItem) *models.Paper {
 		titleHash := a.generateTitleHash(paper.Title)
 		paper.SourceID = fmt.Sprintf("acl_rss_%s", titleHash)
 	} else {
-
-		paper.SourceID = fmt.Sprintf("acl_rss_%d", time.Now().UnixNano())
+		// 标题为空时用条目自身的链接、GUID 与描述生成，同一条目每次得到相同结果
+		paper.SourceID = fmt.Sprintf("acl_rss_%s", FallbackHash(item.GUID, item.Link, item.Description, item.PubDate))
 	}
 
 	if paper.URL == "" {
@@ -132,7 +137,7 @@ func (a *Adapter) convertRSSItemToPaper(item RSSItem) *models.Paper {
 		parts := strings.Split(item.Description, " in ")
 		if len(parts) > 0 {
 			authorsStr := strings.TrimSpace(parts[0])
-			paper.Authors = parseAuthors(authorsStr)
+			paper.Authors = bibtex.ParseAuthors(authorsStr)
 		}
 	}
 
@@ -161,7 +166,7 @@ func (a *Adapter) searchViaBibTeX(ctx context.Context, q platform.Query) (platfo
 	bibURL := a.config.BaseURL + "/anthology+abstracts.bib.gz"
 	logger.Debug("[ACL] 请求 BibTeX 文件: %s", bibURL)
 
-	// 直接使用 HTTP 客户端下载 gzip 文件
+	// 直接使用 HTTP 客户端下载 BibTeX 文件（通常为 gzip）
 	req, err := http.NewRequestWithContext(ctx, "GET", bibURL, nil)
 	if err != nil {
 		return platform.Result{}, fmt.Errorf("failed to create request: %w", err)
@@ -170,6 +175,9 @@ func (a *Adapter) searchViaBibTeX(ctx context.Context, q platform.Query) (platfo
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/142.0.0.0 Safari/537.36")
 	req.Header.Set("Accept", "application/gzip,application/octet-stream")
 
+	if err := a.limiter.Wait(ctx); err != nil {
+		return platform.Result{}, err
+	}
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
 		return platform.Result{}, fmt.Errorf("BibTeX request failed: %w", err)
@@ -180,18 +188,12 @@ func (a *Adapter) searchViaBibTeX(ctx context.Context, q platform.Query) (platfo
 		return platform.Result{}, fmt.Errorf("HTTP error fetching BibTeX: %d", resp.StatusCode)
 	}
 
-	reader, err := gzip.NewReader(resp.Body)
-	if err != nil {
-		return platform.Result{}, fmt.Errorf("failed to create gzip reader: %w", err)
-	}
-	defer reader.Close()
-
-	body, err := io.ReadAll(reader)
+	body, err := readBibTeXBody(resp)
 	if err != nil {
-		return platform.Result{}, fmt.Errorf("failed to read BibTeX response: %w", err)
+		return platform.Result{}, err
 	}
 
-	papers, err := a.parseBibTeX(string(body))
+	papers, err := a.parseBibTeX(body)
 	if err != nil {
 		return platform.Result{}, fmt.Errorf("failed to parse BibTeX: %w", err)
 	}
@@ -216,23 +218,67 @@ func (a *Adapter) searchViaBibTeX(ctx context.Context, q platform.Query) (platfo
 	}, nil
 }
 
-func (a *Adapter) parseBibTeX(content string) ([]*models.Paper, error) {
-	var papers []*models.Paper
+// readBibTeXBody 读取 BibTeX 响应：以 gzip 魔数开头时解压，否则按纯文本读取
+// （服务端可能直接返回未压缩内容，或由 Transport 按 Content-Encoding 自动解压）
+// 返回 HTML 页面时给出明确的错误，而不是交给 BibTeX 解析器
+func readBibTeXBody(resp *http.Response) (string, error) {
+	br := bufio.NewReader(resp.Body)
+
+	var reader io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return "", fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
 
-	// 按 @ 分割条目
-	entries := strings.Split(content, "@")
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read BibTeX response: %w", err)
+	}
 
-	for _, entry := range entries {
-		entry = strings.TrimSpace(entry)
-		if entry == "" {
-			continue
+	content := string(body)
+	if isHTMLResponse(resp.Header.Get("Content-Type"), content) {
+		msg := "ACL BibTeX 地址返回了 HTML 页面而不是 BibTeX，可能是错误页或地址已变更"
+		if title := htmlTitle(content); title != "" {
+			msg += fmt.Sprintf(" (title: %s)", title)
 		}
+		return "", fmt.Errorf("%s", msg)
+	}
+	return content, nil
+}
 
+// isHTMLResponse 根据 Content-Type 或内容开头判断是否为 HTML 页面
+func isHTMLResponse(contentType, content string) bool {
+	if strings.Contains(strings.ToLower(contentType), "text/html") {
+		return true
+	}
+	head := strings.ToLower(strings.TrimSpace(content))
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	return strings.HasPrefix(head, "<!doctype html") || strings.HasPrefix(head, "<html")
+}
+
+var htmlTitleRe = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// htmlTitle 提取 HTML 页面的 <title>，便于在错误信息中展示
+func htmlTitle(content string) string {
+	if m := htmlTitleRe.FindStringSubmatch(content); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
+
+func (a *Adapter) parseBibTeX(content string) ([]*models.Paper, error) {
+	var papers []*models.Paper
+
+	for _, entry := range bibtex.Parse(content) {
 		// 优先处理包含摘要的条目类型
-		if strings.HasPrefix(entry, "inproceedings") ||
-			strings.HasPrefix(entry, "article") ||
-			strings.HasPrefix(entry, "incollection") ||
-			strings.HasPrefix(entry, "inbook") {
+		switch entry.Type {
+		case "inproceedings", "article", "incollection", "inbook":
 			paper := a.parseBibTeXEntry(entry)
 			if paper != nil {
 				papers = append(papers, paper)
@@ -243,24 +289,24 @@ func (a *Adapter) parseBibTeX(content string) ([]*models.Paper, error) {
 	return papers, nil
 }
 
-func (a *Adapter) parseBibTeXEntry(entry string) *models.Paper {
+func (a *Adapter) parseBibTeXEntry(entry *bibtex.Entry) *models.Paper {
 	paper := &models.Paper{
 		Source:    "acl",
 		UpdatedAt: time.Now(),
 	}
 
-	paper.Title = a.extractBibTeXField(entry, "title")
-	paper.Abstract = a.extractBibTeXField(entry, "abstract")
-	paper.URL = a.extractBibTeXField(entry, "url")
+	paper.Title = entry.Field("title")
+	paper.Abstract = entry.Field("abstract")
+	paper.URL = entry.Field("url")
 
 	// 解析作者
-	authorsStr := a.extractBibTeXField(entry, "author")
+	authorsStr := entry.Field("author")
 	if authorsStr != "" {
-		paper.Authors = parseAuthors(authorsStr)
+		paper.Authors = bibtex.ParseAuthors(authorsStr)
 	}
 
 	// 解析年份
-	yearStr := a.extractBibTeXField(entry, "year")
+	yearStr := entry.Field("year")
 	if yearStr != "" {
 		if year, err := time.Parse("2006", yearStr); err == nil {
 			paper.FirstSubmittedAt = year
@@ -269,15 +315,15 @@ func (a *Adapter) parseBibTeXEntry(entry string) *models.Paper {
 	}
 
 	// 解析 DOI
-	doi := a.extractBibTeXField(entry, "doi")
+	doi := entry.Field("doi")
 	if doi != "" {
 		paper.Comments = "DOI: " + doi
 	}
 
 	// 解析会议/期刊
-	venue := a.extractBibTeXField(entry, "booktitle")
+	venue := entry.Field("booktitle")
 	if venue == "" {
-		venue = a.extractBibTeXField(entry, "journal")
+		venue = entry.Field("journal")
 	}
 	if venue != "" {
 		paper.Categories = []string{venue}
@@ -289,8 +335,8 @@ func (a *Adapter) parseBibTeXEntry(entry string) *models.Paper {
 		titleHash := a.generateTitleHash(paper.Title)
 		paper.SourceID = fmt.Sprintf("acl_%s", titleHash)
 	} else {
-		// 如果标题为空，使用时间戳
-		paper.SourceID = fmt.Sprintf("acl_%d", time.Now().UnixNano())
+		// 标题为空时用引用键、作者与年份生成，同一条目每次得到相同结果
+		paper.SourceID = fmt.Sprintf("acl_%s", FallbackHash(entry.Key, authorsStr, yearStr))
 	}
 
 	// 如果 URL 为空，生成唯一的 URL
@@ -306,113 +352,54 @@ func (a *Adapter) parseBibTeXEntry(entry string) *models.Paper {
 	return paper
 }
 
-func (a *Adapter) extractBibTeXField(entry, fieldName string) string {
-	// 查找字段开始位置 - 支持双引号和大括号两种格式
-	fieldPattern := fmt.Sprintf(`(?i)%s\s*=\s*["\{]`, fieldName)
-	re := regexp.MustCompile(fieldPattern)
-	lines := strings.Split(entry, "\n")
-
-	for i, line := range lines {
-		if re.MatchString(line) {
-			// 找到字段，提取值（可能跨多行）
-			value := a.extractMultiLineBibTeXValue(lines, i)
-			cleaned := cleanText(value)
-			return cleaned
-		}
-	}
-	// logger.Debug("[ACL] 未找到字段 %s", fieldName)
-	return ""
+func (a *Adapter) generateTitleHash(title string) string {
+	return TitleHash(title)
 }
 
-func (a *Adapter) extractMultiLineBibTeXValue(lines []string, startLine int) string {
-	// 从指定行开始提取多行 BibTeX 值
-	var value strings.Builder
-	braceCount := 0
-	quoteCount := 0
-	inValue := false
-	inQuotes := false
+// TitleHash 由标题生成稳定的 SourceID 片段：规范化标题的可读前缀 + SHA-1 摘要，
+// 同一标题在每次爬取中得到相同结果，保证 ON CONFLICT(source, source_id) 能更新已有记录
+func TitleHash(title string) string {
+	normalized := normalizeTitle(title)
+	sum := sha1.Sum([]byte(normalized))
+	digest := hex.EncodeToString(sum[:])[:10]
 
-	for i := startLine; i < len(lines); i++ {
-		line := lines[i]
-
-		// 查找第一个 { 或 " 开始位置
-		if !inValue {
-			braceIdx := strings.Index(line, "{")
-			quoteIdx := strings.Index(line, "\"")
-
-			if braceIdx == -1 && quoteIdx == -1 {
-				continue
-			}
-
-			if quoteIdx != -1 && (braceIdx == -1 || quoteIdx < braceIdx) {
-
-				line = line[quoteIdx+1:]
-				inValue = true
-				inQuotes = true
-				quoteCount = 1
-			} else {
-
-				line = line[braceIdx+1:]
-				inValue = true
-				braceCount = 1
-			}
-		}
-
-		if inQuotes {
-
-			quoteCount += strings.Count(line, "\"")
-			if quoteCount%2 == 0 {
-
-				value.WriteString(strings.TrimSuffix(line, "\""))
-				break
-			} else {
-				value.WriteString(line)
-			}
-		} else {
-
-			braceCount += strings.Count(line, "{")
-			braceCount -= strings.Count(line, "}")
-
-			if braceCount <= 0 {
-				// 值结束
-				value.WriteString(strings.TrimSuffix(line, "}"))
-				break
-			} else {
-				value.WriteString(line)
-			}
-		}
+	slug := []rune(strings.ReplaceAll(normalized, " ", "_"))
+	if len(slug) > 30 {
+		slug = slug[:30]
 	}
+	return fmt.Sprintf("%s_%s", string(slug), digest)
+}
 
-	return value.String()
+// FallbackHash 标题为空时由条目的其他字段生成稳定的 SourceID 片段（规范化后取 SHA-1 摘要）
+func FallbackHash(parts ...string) string {
+	normalized := make([]string, len(parts))
+	for i, part := range parts {
+		normalized[i] = normalizeTitle(part)
+	}
+	sum := sha1.Sum([]byte(strings.Join(normalized, "|")))
+	return "untitled_" + hex.EncodeToString(sum[:])[:10]
 }
 
-func (a *Adapter) generateTitleHash(title string) string {
+// normalizeTitle 小写并只保留字母数字，单词之间用单个空格分隔，
+// 使大小写、标点与空白不同的同一标题得到相同的摘要
+func normalizeTitle(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
 
-	cleanTitle := strings.ToLower(title)
-	cleanTitle = strings.ReplaceAll(cleanTitle, " ", "_")
-	cleanTitle = strings.ReplaceAll(cleanTitle, ",", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, ".", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, ":", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, ";", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "?", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "!", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "(", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, ")", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "[", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "]", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "{", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "}", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "\"", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "'", "")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "/", "_")
-	cleanTitle = strings.ReplaceAll(cleanTitle, "\\", "_")
-
-	if len(cleanTitle) > 30 {
-		cleanTitle = cleanTitle[:30]
-	}
-
-	timestamp := time.Now().UnixNano() % 1000000 // 取后6位
-	return fmt.Sprintf("%s_%d", cleanTitle, timestamp)
+// StableSourceID 按当前规则重新计算已入库 ACL 论文的 SourceID，保留 acl_ / acl_rss_ 前缀；
+// 标题为空的论文无法重新计算，返回原值
+func StableSourceID(p *models.Paper) string {
+	if strings.TrimSpace(p.Title) == "" {
+		return p.SourceID
+	}
+	prefix := "acl_"
+	if strings.HasPrefix(p.SourceID, "acl_rss_") {
+		prefix = "acl_rss_"
+	}
+	return prefix + TitleHash(p.Title)
 }
 
 // 查询匹配
@@ -447,55 +434,3 @@ func (a *Adapter) matchesQuery(paper *models.Paper, q platform.Query) bool {
 
 	return true
 }
-
-// 辅助函数
-func cleanText(text string) string {
-	text = strings.TrimSpace(text)
-	text = strings.ReplaceAll(text, "\n", " ")
-	text = strings.ReplaceAll(text, "\t", " ")
-
-	// 处理 LaTeX 格式的特殊字符
-	// 移除 LaTeX 强调标记 {text} -> text
-	latexEmphasis := regexp.MustCompile(`\{([^}]+)\}`)
-	text = latexEmphasis.ReplaceAllString(text, "$1")
-
-	// 移除其他 LaTeX 命令
-	latexCommands := regexp.MustCompile(`\\[a-zA-Z]+\{[^}]*\}`)
-	text = latexCommands.ReplaceAllString(text, "")
-
-	// 移除单独的 LaTeX 命令（无参数）
-	latexSimpleCommands := regexp.MustCompile(`\\[a-zA-Z]+`)
-	text = latexSimpleCommands.ReplaceAllString(text, "")
-
-	// 处理特殊字符
-	text = strings.ReplaceAll(text, "\\&", "&")
-	text = strings.ReplaceAll(text, "\\%", "%")
-	text = strings.ReplaceAll(text, "\\$", "$")
-	text = strings.ReplaceAll(text, "\\#", "#")
-	text = strings.ReplaceAll(text, "\\_", "_")
-	text = strings.ReplaceAll(text, "\\{", "{")
-	text = strings.ReplaceAll(text, "\\}", "}")
-
-	// 压缩多个空格
-	re := regexp.MustCompile(`\s+`)
-	text = re.ReplaceAllString(text, " ")
-
-	return text
-}
-
-func parseAuthors(authorsStr string) []string {
-	if authorsStr == "" {
-		return nil
-	}
-
-	// 处理 " and " 分隔符
-	authors := strings.Split(authorsStr, " and ")
-	var result []string
-	for _, author := range authors {
-		author = strings.TrimSpace(author)
-		if author != "" {
-			result = append(result, author)
-		}
-	}
-	return result
-}
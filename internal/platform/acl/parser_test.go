@@ -0,0 +1,169 @@
+package acl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+)
+
+const sampleBibTeX = `@inproceedings{smith-2023-parsing,
+    title = "Robust Parsing of Noisy Text",
+    author = "Smith, Alice and Doe, Bob",
+    booktitle = "Proceedings of ACL 2023",
+    year = "2023",
+    url = "https://aclanthology.org/2023.acl-long.1",
+    abstract = "We study parsing.",
+}
+`
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	w.Close()
+	return buf.Bytes()
+}
+
+func newBibTeXAdapter(t *testing.T, handler http.HandlerFunc) *Adapter {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	a, err := NewAdapter(&Config{BaseURL: srv.URL, Timeout: 5 * time.Second, Step: 100, UseBibTeX: true})
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	return a
+}
+
+func TestSearchViaBibTeX_ResponseEncodings(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"gzip file", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/gzip")
+			w.Write(gzipBytes(t, sampleBibTeX))
+		}},
+		{"content-encoding gzip", func(w http.ResponseWriter, r *http.Request) {
+			// Transport 会自动解压，读取到的已是纯文本
+			w.Header().Set("Content-Type", "application/x-bibtex")
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gzipBytes(t, sampleBibTeX))
+		}},
+		{"plain", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte(sampleBibTeX))
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newBibTeXAdapter(t, tc.handler)
+			result, err := a.Search(context.Background(), platform.Query{})
+			if err != nil {
+				t.Fatalf("Search() error: %v", err)
+			}
+			if len(result.Papers) != 1 || result.Papers[0].Title != "Robust Parsing of Noisy Text" {
+				t.Errorf("Unexpected papers: %+v", result.Papers)
+			}
+		})
+	}
+}
+
+func TestSearchViaBibTeX_HTMLErrorPage(t *testing.T) {
+	a := newBibTeXAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		// 部分 CDN 出错时仍返回 200 与 HTML 页面
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("<!DOCTYPE html>\n<html><head><title>Service Unavailable</title></head><body>maintenance</body></html>"))
+	})
+
+	_, err := a.Search(context.Background(), platform.Query{})
+	if err == nil {
+		t.Fatal("Expected error for HTML response")
+	}
+	if !strings.Contains(err.Error(), "HTML") || !strings.Contains(err.Error(), "Service Unavailable") {
+		t.Errorf("Expected clear HTML error message, got: %v", err)
+	}
+}
+
+func TestTitleHash_Deterministic(t *testing.T) {
+	a := TitleHash("Robust Parsing of Noisy Text")
+	if b := TitleHash("Robust Parsing of Noisy Text"); a != b {
+		t.Errorf("Expected identical hashes, got %q and %q", a, b)
+	}
+	// 大小写、标点与空白不同的同一标题得到相同结果
+	if b := TitleHash("  robust parsing of noisy text. "); a != b {
+		t.Errorf("Expected normalized titles to hash equally, got %q and %q", a, b)
+	}
+	if !strings.HasPrefix(a, "robust_parsing_of_noisy_text_") {
+		t.Errorf("Expected readable title prefix, got %q", a)
+	}
+	if TitleHash("Another Title") == a {
+		t.Error("Expected different titles to produce different hashes")
+	}
+}
+
+func TestParseBibTeX_StableSourceID(t *testing.T) {
+	const untitled = `@inproceedings{doe-2022-untitled,
+    author = "Doe, Bob",
+    booktitle = "Proceedings of ACL 2022",
+    year = "2022",
+}
+@inproceedings{doe-2022-other,
+    author = "Doe, Bob",
+    year = "2022",
+}
+`
+	a := &Adapter{}
+	parse := func() []*models.Paper {
+		papers, err := a.parseBibTeX(sampleBibTeX + untitled)
+		if err != nil {
+			t.Fatalf("parseBibTeX() error: %v", err)
+		}
+		if len(papers) != 3 {
+			t.Fatalf("Expected 3 papers, got %d", len(papers))
+		}
+		return papers
+	}
+
+	first, second := parse(), parse()
+	for i := range first {
+		if first[i].SourceID != second[i].SourceID {
+			t.Errorf("Entry %d: SourceID changed between parses: %q vs %q", i, first[i].SourceID, second[i].SourceID)
+		}
+	}
+	if first[0].SourceID != "acl_"+TitleHash("Robust Parsing of Noisy Text") {
+		t.Errorf("Unexpected titled SourceID %q", first[0].SourceID)
+	}
+	if !strings.HasPrefix(first[1].SourceID, "acl_untitled_") || first[1].SourceID == first[2].SourceID {
+		t.Errorf("Expected distinct untitled fallback IDs, got %q and %q", first[1].SourceID, first[2].SourceID)
+	}
+}
+
+func TestStableSourceID(t *testing.T) {
+	cases := []struct {
+		paper *models.Paper
+		want  string
+	}{
+		{&models.Paper{SourceID: "acl_old_123456", Title: "Robust Parsing"}, "acl_" + TitleHash("Robust Parsing")},
+		{&models.Paper{SourceID: "acl_rss_old_42", Title: "Robust Parsing"}, "acl_rss_" + TitleHash("Robust Parsing")},
+		{&models.Paper{SourceID: "acl_1700000000", Title: ""}, "acl_1700000000"},
+	}
+	for _, tc := range cases {
+		if got := StableSourceID(tc.paper); got != tc.want {
+			t.Errorf("StableSourceID(%q) = %q, want %q", tc.paper.SourceID, got, tc.want)
+		}
+	}
+}
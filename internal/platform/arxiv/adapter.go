@@ -8,17 +8,22 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
-	"PaperHunter/internal/core"
 	"PaperHunter/internal/models"
 	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/httpclient"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/proxy"
+	"PaperHunter/pkg/ratelimit"
 )
 
 type Adapter struct {
 	config     *Config
 	httpClient *http.Client
+	limiter    ratelimit.Limiter
+	proxies    *proxy.Rotator // 当前代理被封禁（403/429）时切换到下一个
 }
 
 func NewAdapter(config *Config) (*Adapter, error) {
@@ -29,11 +34,17 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	client := core.NewHTTPClient(config.Timeout, config.Proxy)
+	proxies := proxy.NewRotator(append([]string{config.Proxy}, config.ProxyPool...))
+	client := httpclient.New(time.Duration(config.Timeout)*time.Second, proxies,
+		httpclient.WithInsecureSkipVerify(config.InsecureSkipVerify),
+		httpclient.WithDialTimeout(config.DialTimeout),
+	)
 
 	return &Adapter{
 		config:     config,
 		httpClient: client,
+		limiter:    ratelimit.For("arxiv", config.RateLimit()),
+		proxies:    proxies,
 	}, nil
 }
 
@@ -41,8 +52,150 @@ func (a *Adapter) Name() string { return "arxiv" }
 
 func (a *Adapter) GetConfig() platform.Config { return a.config }
 
+// SetLimiter 替换请求限速器，arXiv 与 Semantic Scholar 的请求共用
+func (a *Adapter) SetLimiter(l ratelimit.Limiter) { a.limiter = l }
 
-func (a *Adapter) FetchNewSubmissions(ctx context.Context, category string) (platform.Result, error) {
+
+// newSubmissionsConcurrency 同时请求的 archive 数上限，实际请求间隔仍由限速器控制
+const newSubmissionsConcurrency = 3
+
+// FetchNewSubmissions 获取多个 archive（或分类，如 math.ST、stat.ML）的今日新论文：
+// 同一 archive 下的分类合并为一次 /list/<cat1>+<cat2>/new 请求，不同 archive 并发请求，
+// 按 archives 顺序合并，交叉列出的论文按 arXiv ID 去重
+// archives 为空时使用配置中的 new_submissions_categories；单个请求失败时跳过，全部失败才返回错误
+func (a *Adapter) FetchNewSubmissions(ctx context.Context, archives []string) (platform.Result, error) {
+	archives = normalizeArchives(archives)
+	if len(archives) == 0 {
+		archives = normalizeArchives(a.config.DailyCategories())
+	}
+	if len(archives) == 0 {
+		archives = []string{"cs"}
+	}
+	archives = groupListings(archives)
+
+	type archiveResult struct {
+		papers []*models.Paper
+		err    error
+	}
+	results := make([]archiveResult, len(archives))
+	sem := make(chan struct{}, newSubmissionsConcurrency)
+	var wg sync.WaitGroup
+	for i, archive := range archives {
+		wg.Add(1)
+		go func(i int, archive string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i].err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			results[i].papers, _, results[i].err = a.fetchNewSubmissionsPage(ctx, archive)
+		}(i, archive)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return platform.Result{}, err
+	}
+
+	var papers []*models.Paper
+	seen := make(map[string]bool)
+	var lastErr error
+	succeeded := 0
+
+	for i, archive := range archives {
+		archivePapers, err := results[i].papers, results[i].err
+		if err != nil {
+			logger.Warn("[arXiv] 获取 %s 今日新论文失败: %v", archive, err)
+			lastErr = err
+			continue
+		}
+		succeeded++
+
+		added := 0
+		for _, p := range archivePapers {
+			key := p.SourceID
+			if key == "" {
+				key = p.URL
+			}
+			if key != "" && seen[key] {
+				continue
+			}
+			seen[key] = true
+			papers = append(papers, p)
+			added++
+		}
+		logger.Info("[arXiv] %s: %d 篇，去重后新增 %d 篇", archive, len(archivePapers), added)
+	}
+
+	if succeeded == 0 && lastErr != nil {
+		return platform.Result{}, lastErr
+	}
+
+	fillOpenAccessURLs(papers)
+	a.fillCitations(ctx, papers)
+	return platform.Result{Total: len(papers), Papers: papers}, nil
+}
+
+// fillOpenAccessURLs 为论文填充 arXiv 的 PDF 链接，arXiv 论文均可免费获取
+func fillOpenAccessURLs(papers []*models.Paper) {
+	for _, p := range papers {
+		if p.OpenAccessURL == "" && p.SourceID != "" {
+			p.OpenAccessURL = PDFUrl(p.SourceID)
+		}
+	}
+}
+
+// normalizeArchives 去除空白与重复的 archive
+func normalizeArchives(archives []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, archive := range archives {
+		archive = strings.TrimSpace(archive)
+		if archive == "" || seen[archive] {
+			continue
+		}
+		seen[archive] = true
+		result = append(result, archive)
+	}
+	return result
+}
+
+// groupListings 将分类按所属 archive 分组，同一 archive 的分类以 + 连接为一个列表页；
+// 已请求整个 archive 时其下的分类不再单独请求。结果按各 archive 首次出现的顺序排列
+func groupListings(categories []string) []string {
+	var order []string
+	groups := make(map[string][]string)
+	whole := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, c := range categories {
+		archive, _, isCategory := strings.Cut(c, ".")
+		if !seen[archive] {
+			seen[archive] = true
+			order = append(order, archive)
+		}
+		if !isCategory {
+			whole[archive] = true
+			continue
+		}
+		groups[archive] = append(groups[archive], c)
+	}
+
+	listings := make([]string, 0, len(order))
+	for _, archive := range order {
+		if whole[archive] {
+			listings = append(listings, archive)
+			continue
+		}
+		listings = append(listings, strings.Join(groups[archive], "+"))
+	}
+	return listings
+}
+
+// fetchNewSubmissionsPage 获取并解析单个 archive（或以 + 连接的同一 archive 下多个分类）的 New Submissions 页面
+func (a *Adapter) fetchNewSubmissionsPage(ctx context.Context, category string) ([]*models.Paper, int, error) {
 	if category == "" {
 		category = "cs" // 默认 CS 全部
 	}
@@ -57,19 +210,24 @@ func (a *Adapter) FetchNewSubmissions(ctx context.Context, category string) (pla
 
 	content, err := a.request(ctx, newURL)
 	if err != nil {
-		return platform.Result{}, fmt.Errorf("fetch new submissions failed: %w", err)
+		return nil, 0, fmt.Errorf("fetch new submissions failed: %w", err)
 	}
 
 	papers, total, err := ParseNewSubmissionsHTML(content)
 	if err != nil {
-		return platform.Result{}, fmt.Errorf("failed to parse new submissions: %w", err)
+		return nil, 0, fmt.Errorf("failed to parse new submissions: %w", err)
 	}
 
 	logger.Info("[arXiv] 今日新论文: %d 篇", len(papers))
-	return platform.Result{Total: total, Papers: papers}, nil
+	return papers, total, nil
 }
 
 func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	if err := q.ValidateSort(); err != nil {
+		return platform.Result{}, err
+	}
+	// 增量爬取时起始日期取 DateFrom 与 Since 中较晚的一个；API 按日期降序分页，遇到更早的论文即停止
+	q.DateFrom = q.EffectiveDateFrom()
 	if a.config.UseAPI {
 		return a.searchViaAPI(ctx, q)
 	}
@@ -127,18 +285,17 @@ func (a *Adapter) searchViaAPI(ctx context.Context, q platform.Query) (platform.
 			currentPageSize = remaining
 		}
 
-		params := url.Values{}
-		params.Add("search_query", searchQuery)
-		params.Add("start", fmt.Sprintf("%d", start))
-		params.Add("max_results", fmt.Sprintf("%d", currentPageSize))
-		params.Add("sortBy", "submittedDate")
-		params.Add("sortOrder", "descending")
-
-		apiURL := a.config.APIBase + "?" + params.Encode()
+		apiURL := a.buildAPIURL(q, searchQuery, start, currentPageSize)
 		logger.Debug("[arXiv] API 请求: start=%d, max=%d", start, currentPageSize)
 
+		if ctx.Err() != nil {
+			return cancelledResult(ctx, allPapers)
+		}
 		content, err := a.request(ctx, apiURL)
 		if err != nil {
+			if ctx.Err() != nil {
+				return cancelledResult(ctx, allPapers)
+			}
 			return platform.Result{}, fmt.Errorf("API request failed: %w", err)
 		}
 
@@ -155,6 +312,8 @@ func (a *Adapter) searchViaAPI(ctx context.Context, q platform.Query) (platform.
 		// 日期过滤
 		filteredPapers := make([]*models.Paper, 0, len(papers))
 		tooOld := false
+		// 只有按提交日期降序时，遇到早于起始日期的论文才能断定后面的也都更早
+		dateDesc := apiSortBy(q) == platform.SortBySubmittedDate && !q.Ascending()
 		for _, p := range papers {
 			if hasDateFilter {
 				paperDate := p.FirstSubmittedAt
@@ -164,7 +323,7 @@ func (a *Adapter) searchViaAPI(ctx context.Context, q platform.Query) (platform.
 
 				// 检查是否在日期范围内
 				if !dateFrom.IsZero() && paperDate.Before(dateFrom) {
-					tooOld = true // 论文太旧了，由于按日期降序，后面的也会太旧
+					tooOld = dateDesc // 论文太旧了，由于按日期降序，后面的也会太旧
 					continue
 				}
 				if !dateTo.IsZero() && paperDate.After(dateTo) {
@@ -194,10 +353,15 @@ func (a *Adapter) searchViaAPI(ctx context.Context, q platform.Query) (platform.
 		}
 
 		start += len(papers)
-		time.Sleep(1000 * time.Millisecond) // 防止触发 429
+		// 防止触发 429
+		if err := sleepCtx(ctx, 1000*time.Millisecond); err != nil {
+			return cancelledResult(ctx, allPapers)
+		}
 	}
 
 	logger.Info("[arXiv] API 抓取完成，共 %d 篇论文", len(allPapers))
+	fillOpenAccessURLs(allPapers)
+	a.fillCitations(ctx, allPapers)
 	return platform.Result{Total: len(allPapers), Papers: allPapers}, nil
 }
 
@@ -235,8 +399,14 @@ func (a *Adapter) searchViaWeb(ctx context.Context, q platform.Query) (platform.
 		webURL := a.buildWebQuery(q)
 		logger.Debug("[arXiv] Web 请求第 %d 页 (offset=%d)", offset/pageSize+1, offset)
 
+		if ctx.Err() != nil {
+			return cancelledResult(ctx, papers)
+		}
 		content, err := a.request(ctx, webURL)
 		if err != nil {
+			if ctx.Err() != nil {
+				return cancelledResult(ctx, papers)
+			}
 			logger.Warn("[arXiv] 抓取第 %d 页失败: %v", offset/pageSize+1, err)
 			break
 		}
@@ -254,7 +424,10 @@ func (a *Adapter) searchViaWeb(ctx context.Context, q platform.Query) (platform.
 		papers = append(papers, pagePapers...)
 		logger.Info("[arXiv] 已抓取 %d/%d 篇", len(papers), totalFound)
 
-		time.Sleep(500 * time.Millisecond) // 限流保护
+		// 限流保护
+		if err := sleepCtx(ctx, 500*time.Millisecond); err != nil {
+			return cancelledResult(ctx, papers)
+		}
 	}
 
 	if q.Limit > 0 && len(papers) > q.Limit {
@@ -263,6 +436,8 @@ func (a *Adapter) searchViaWeb(ctx context.Context, q platform.Query) (platform.
 	}
 
 	logger.Info("[arXiv] Web 抓取完成，共 %d 篇论文", len(papers))
+	fillOpenAccessURLs(papers)
+	a.fillCitations(ctx, papers)
 	return platform.Result{Total: totalFound, Papers: papers}, nil
 }
 
@@ -302,6 +477,47 @@ func (a *Adapter) buildAPIQuery(q platform.Query) string {
 	return query
 }
 
+// buildAPIURL 构建 API 分页请求 URL，SortBy/SortOrder 映射到 API 的 sortBy/sortOrder，默认按提交日期降序
+func (a *Adapter) buildAPIURL(q platform.Query, searchQuery string, start, maxResults int) string {
+	sortOrder := "descending"
+	if q.Ascending() {
+		sortOrder = "ascending"
+	}
+
+	params := url.Values{}
+	params.Add("search_query", searchQuery)
+	params.Add("start", fmt.Sprintf("%d", start))
+	params.Add("max_results", fmt.Sprintf("%d", maxResults))
+	params.Add("sortBy", apiSortBy(q))
+	params.Add("sortOrder", sortOrder)
+	return a.config.APIBase + "?" + params.Encode()
+}
+
+// apiSortBy API 的 sortBy 取值（submittedDate、lastUpdatedDate、relevance），未设置时为 submittedDate
+func apiSortBy(q platform.Query) string {
+	if q.SortBy == "" {
+		return platform.SortBySubmittedDate
+	}
+	return q.SortBy
+}
+
+// webOrder 网页搜索的 order 参数：relevance 为空值；网页不支持按更新时间排序，lastUpdatedDate 与默认一样按公布日期
+func webOrder(q platform.Query) string {
+	var order string
+	switch q.SortBy {
+	case platform.SortByRelevance:
+		return ""
+	case platform.SortBySubmittedDate:
+		order = "submitted_date"
+	default:
+		order = "announced_date_first"
+	}
+	if !q.Ascending() {
+		order = "-" + order
+	}
+	return order
+}
+
 func (a *Adapter) buildWebQuery(q platform.Query) string {
 	params := url.Values{}
 	params.Add("advanced", "1")
@@ -351,7 +567,7 @@ func (a *Adapter) buildWebQuery(q platform.Query) string {
 		pageSize = 50
 	}
 	params.Add("size", fmt.Sprintf("%d", pageSize))
-	params.Add("order", "-announced_date_first")
+	params.Add("order", webOrder(q))
 	if q.Offset > 0 {
 		params.Add("start", fmt.Sprintf("%d", q.Offset))
 	}
@@ -380,9 +596,16 @@ func (a *Adapter) buildWebQuery(q platform.Query) string {
 	return webURL
 }
 
+// request 发送 GET 请求，失败时最多重试 2 次；每次请求前经过限速器，
+// 429 时不做固定退避，按 Retry-After（若有）等待后由限速器控制重试间隔。
+// 配置了 proxy_pool 时，403/429 先切换到下一个代理立即重试（最多 len(proxy_pool) 次），不计入重试次数
 func (a *Adapter) request(ctx context.Context, url string) (string, error) {
 	var lastErr error
+	rotations := 0
 	for attempt := 0; attempt < 3; attempt++ {
+		if err := a.limiter.Wait(ctx); err != nil {
+			return "", err
+		}
 		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 		if err != nil {
 			return "", fmt.Errorf("failed to create request: %w", err)
@@ -395,23 +618,40 @@ func (a *Adapter) request(ctx context.Context, url string) (string, error) {
 		if err != nil {
 			lastErr = err
 			if attempt < 2 {
-				time.Sleep(time.Duration(1<<attempt) * time.Second)
+				if err := sleepCtx(ctx, time.Duration(1<<attempt)*time.Second); err != nil {
+					return "", err
+				}
 				continue
 			}
 			break
 		}
-		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
 			lastErr = fmt.Errorf("HTTP error: %d", resp.StatusCode)
+			if blocked(resp.StatusCode) && rotations < len(a.config.ProxyPool) && a.proxies.Len() > 1 {
+				rotations++
+				a.proxies.Next()
+				logger.Warn("[arXiv] 收到 HTTP %d，切换代理后重试（%d/%d）", resp.StatusCode, rotations, len(a.config.ProxyPool))
+				attempt--
+				continue
+			}
 			if attempt < 2 {
-				time.Sleep(time.Duration(1<<attempt) * time.Second)
+				wait := time.Duration(1<<attempt) * time.Second
+				if resp.StatusCode == http.StatusTooManyRequests {
+					wait = ratelimit.RetryAfter(resp)
+					logger.Warn("[arXiv] 收到 429 频率限制，%v 后重试", wait)
+				}
+				if err := sleepCtx(ctx, wait); err != nil {
+					return "", err
+				}
 				continue
 			}
 			break
 		}
 
 		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
 			return "", fmt.Errorf("failed to read response: %w", err)
 		}
@@ -419,3 +659,26 @@ func (a *Adapter) request(ctx context.Context, url string) (string, error) {
 	}
 	return "", lastErr
 }
+
+// blocked 判断响应是否表示当前出口 IP 被拒绝或限流，此时换代理比等待更有效
+func blocked(status int) bool {
+	return status == http.StatusForbidden || status == http.StatusTooManyRequests
+}
+
+// sleepCtx 等待 d，context 取消时提前返回其错误
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cancelledResult 爬取被取消时返回已抓取的部分结果和 context 错误，不再补充引用信息
+func cancelledResult(ctx context.Context, papers []*models.Paper) (platform.Result, error) {
+	logger.Warn("[arXiv] 抓取已取消，返回已获取的 %d 篇论文: %v", len(papers), ctx.Err())
+	return platform.Result{Total: len(papers), Papers: papers}, ctx.Err()
+}
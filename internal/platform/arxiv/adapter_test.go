@@ -0,0 +1,502 @@
+package arxiv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/ratelimit"
+)
+
+func newSubmissionsHTML(ids ...string) string {
+	var b strings.Builder
+	b.WriteString("<html><body><dl id=\"articles\">")
+	for _, id := range ids {
+		fmt.Fprintf(&b, `<dt><a href="/abs/%s" title="Abstract">arXiv:%s</a></dt>
+<dd><div class="list-title mathjax">Title: Paper %s</div>
+<div class="list-authors">Authors: Alice, Bob</div>
+<p class="mathjax">Abstract of %s.</p></dd>`, id, id, id, id)
+	}
+	b.WriteString("</dl></body></html>")
+	return b.String()
+}
+
+func TestFetchNewSubmissions(t *testing.T) {
+	pages := map[string]string{
+		"/list/cs/new":   newSubmissionsHTML("2501.00001", "2501.00002"),
+		"/list/stat/new": newSubmissionsHTML("2501.00002", "2501.00003"), // 2501.00002 交叉列出
+		"/list/math/new": newSubmissionsHTML("2501.00004"),
+	}
+
+	var mu sync.Mutex
+	var requested []string
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		times = append(times, time.Now())
+		mu.Unlock()
+
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.NewBase = srv.URL + "/list"
+	cfg.NewSubmissionCategories = []string{"cs", "stat", "math", "stat"}
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	// 参数为空时使用配置中的 new_submissions_categories（重复项只请求一次）
+	result, err := a.FetchNewSubmissions(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("FetchNewSubmissions() error: %v", err)
+	}
+
+	if len(requested) != 3 {
+		t.Fatalf("Expected 3 archive requests, got %v", requested)
+	}
+	if result.Total != 4 || len(result.Papers) != 4 {
+		t.Fatalf("Expected 4 deduplicated papers, got total=%d papers=%d", result.Total, len(result.Papers))
+	}
+	want := []string{"2501.00001", "2501.00002", "2501.00003", "2501.00004"}
+	for i, p := range result.Papers {
+		if p.SourceID != want[i] {
+			t.Errorf("papers[%d].SourceID = %s, want %s", i, p.SourceID, want[i])
+		}
+	}
+
+	// 并发请求仍经过限速器（默认 1 rps），相邻请求保持间隔
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < 900*time.Millisecond {
+			t.Errorf("Request %d came %v after the previous one, expected about 1s", i, gap)
+		}
+	}
+}
+
+func TestFetchNewSubmissions_Concurrent(t *testing.T) {
+	pages := map[string]string{
+		"/list/math.ST/new":  newSubmissionsHTML("2501.00001", "2501.00002"),
+		"/list/stat.ML/new":  newSubmissionsHTML("2501.00002", "2501.00003"), // 2501.00002 交叉列出
+		"/list/eess.SP/new":  newSubmissionsHTML("2501.00004"),
+		"/list/q-bio.NC/new": newSubmissionsHTML("2501.00005"),
+	}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.NewBase = srv.URL + "/list"
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	a.SetLimiter(ratelimit.Unlimited())
+
+	result, err := a.FetchNewSubmissions(context.Background(), []string{"math.ST", "stat.ML", "eess.SP", "q-bio.NC"})
+	if err != nil {
+		t.Fatalf("FetchNewSubmissions() error: %v", err)
+	}
+	if maxInFlight < 2 || maxInFlight > newSubmissionsConcurrency {
+		t.Errorf("Expected between 2 and %d concurrent requests, got %d", newSubmissionsConcurrency, maxInFlight)
+	}
+
+	// 合并结果按参数顺序排列，与完成顺序无关
+	want := []string{"2501.00001", "2501.00002", "2501.00003", "2501.00004", "2501.00005"}
+	if len(result.Papers) != len(want) {
+		t.Fatalf("Expected %d deduplicated papers, got %d", len(want), len(result.Papers))
+	}
+	for i, p := range result.Papers {
+		if p.SourceID != want[i] {
+			t.Errorf("papers[%d].SourceID = %s, want %s", i, p.SourceID, want[i])
+		}
+	}
+}
+
+func TestFetchNewSubmissions_SkipsFailedArchive(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/list/cs/new" {
+			w.Write([]byte(newSubmissionsHTML("2501.00001")))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.NewBase = srv.URL + "/list"
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	// 显式参数覆盖配置
+	result, err := a.FetchNewSubmissions(context.Background(), []string{"nope", "cs"})
+	if err != nil {
+		t.Fatalf("FetchNewSubmissions() error: %v", err)
+	}
+	if len(result.Papers) != 1 {
+		t.Errorf("Expected 1 paper from the healthy archive, got %d", len(result.Papers))
+	}
+}
+
+func TestFetchNewSubmissions_CombinesCategories(t *testing.T) {
+	pages := map[string]string{
+		"/list/cs.AI+cs.LG/new": newSubmissionsHTML("2501.00001", "2501.00002"),
+		"/list/stat.ML/new":     newSubmissionsHTML("2501.00002", "2501.00003"), // 2501.00002 交叉列出
+	}
+	var mu sync.Mutex
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.NewBase = srv.URL + "/list"
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	a.SetLimiter(ratelimit.Unlimited())
+
+	// 同一 archive 的分类合并为一次请求，不同 archive 分别请求
+	result, err := a.FetchNewSubmissions(context.Background(), []string{"cs.AI", " cs.LG", "stat.ML", "cs.AI"})
+	if err != nil {
+		t.Fatalf("FetchNewSubmissions() error: %v", err)
+	}
+	sort.Strings(requested)
+	if len(requested) != 2 || requested[0] != "/list/cs.AI+cs.LG/new" || requested[1] != "/list/stat.ML/new" {
+		t.Errorf("Expected one combined request per archive, got %v", requested)
+	}
+	if len(result.Papers) != 3 {
+		t.Errorf("Expected 3 deduplicated papers, got %d", len(result.Papers))
+	}
+}
+
+func TestConfig_DailyCategories(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := strings.Join(cfg.DailyCategories(), ","); got != "cs,math.ST,stat.ML,eess.SP" {
+		t.Errorf("Default DailyCategories() = %s", got)
+	}
+	// 旧名 daily_archives 设置时优先
+	cfg.DailyArchives = []string{"stat"}
+	if got := strings.Join(cfg.DailyCategories(), ","); got != "stat" {
+		t.Errorf("DailyCategories() with daily_archives = %s, want stat", got)
+	}
+	if got := strings.Join((&Config{}).DailyCategories(), ","); got != "cs" {
+		t.Errorf("Empty DailyCategories() = %s, want cs", got)
+	}
+}
+
+func TestGroupListings(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want []string
+	}{
+		{[]string{"cs"}, []string{"cs"}},
+		{[]string{"cs.AI", "stat.ML", "cs.LG"}, []string{"cs.AI+cs.LG", "stat.ML"}},
+		{[]string{"math.ST", "math", "eess.SP"}, []string{"math", "eess.SP"}},
+		{[]string{"q-bio.NC", "q-bio.GN"}, []string{"q-bio.NC+q-bio.GN"}},
+	}
+	for _, c := range cases {
+		if got := groupListings(c.in); strings.Join(got, ",") != strings.Join(c.want, ",") {
+			t.Errorf("groupListings(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestFetchNewSubmissions_FillsOpenAccessURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// Semantic Scholar 批量接口：第一篇带 openAccessPdf，第二篇未收录
+			w.Write([]byte(`[{"citationCount": 3, "openAccessPdf": {"url": "https://example.org/s2.pdf"}}, null]`))
+			return
+		}
+		w.Write([]byte(newSubmissionsHTML("2501.00001", "2501.00002")))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.NewBase = srv.URL + "/list"
+	cfg.CitationAPI = srv.URL + "/batch"
+	cfg.FetchCitations = true
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	a.SetLimiter(ratelimit.Unlimited())
+
+	result, err := a.FetchNewSubmissions(context.Background(), []string{"cs.AI"})
+	if err != nil {
+		t.Fatalf("FetchNewSubmissions() error: %v", err)
+	}
+	if len(result.Papers) != 2 {
+		t.Fatalf("Expected 2 papers, got %d", len(result.Papers))
+	}
+	// arXiv 自身的 PDF 链接优先于 Semantic Scholar 返回的链接
+	for _, p := range result.Papers {
+		if want := "https://arxiv.org/pdf/" + p.SourceID; p.OpenAccessURL != want {
+			t.Errorf("OpenAccessURL = %q, want %q", p.OpenAccessURL, want)
+		}
+	}
+	if result.Papers[0].Citations != 3 {
+		t.Errorf("Citations = %d, want 3", result.Papers[0].Citations)
+	}
+}
+
+func TestRequest_RetriesAfterRateLimitInterval(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		first := len(times) == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.RateLimitRPS = 4 // 间隔 250ms
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	body, err := a.request(context.Background(), srv.URL)
+	if err != nil || body != "ok" {
+		t.Fatalf("request() = %q, %v", body, err)
+	}
+	if len(times) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(times))
+	}
+	// 429 后由限速器决定重试时间：不会立即重试，也不走 1s 的固定退避
+	gap := times[1].Sub(times[0])
+	if gap < 200*time.Millisecond || gap >= time.Second {
+		t.Errorf("Expected retry after ~250ms, got %v", gap)
+	}
+}
+
+func TestRequest_RotatesProxyOnBlock(t *testing.T) {
+	var mu sync.Mutex
+	var hits []string
+	newProxy := func(name string, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			hits = append(hits, name)
+			mu.Unlock()
+			w.WriteHeader(status)
+			w.Write([]byte(name))
+		}))
+	}
+	blockedProxy := newProxy("p1", http.StatusForbidden)
+	defer blockedProxy.Close()
+	okProxy := newProxy("p2", http.StatusOK)
+	defer okProxy.Close()
+
+	cfg := DefaultConfig()
+	cfg.Proxy = blockedProxy.URL
+	cfg.ProxyPool = []string{okProxy.URL}
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	a.SetLimiter(ratelimit.Unlimited())
+
+	start := time.Now()
+	body, err := a.request(context.Background(), "http://export.arxiv.example/api/query")
+	if err != nil || body != "p2" {
+		t.Fatalf("request() = %q, %v", body, err)
+	}
+	// 403 后立即换代理重试，不走固定退避
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Expected immediate retry via next proxy, took %v", elapsed)
+	}
+	if strings.Join(hits, ",") != "p1,p2" {
+		t.Errorf("Expected p1 then p2, got %v", hits)
+	}
+
+	// 后续请求继续使用切换后的代理
+	if body, err := a.request(context.Background(), "http://export.arxiv.example/api/query"); err != nil || body != "p2" {
+		t.Errorf("Second request() = %q, %v, want p2", body, err)
+	}
+}
+
+func atomFeedXML(total int, ids ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/"><opensearch:totalResults>%d</opensearch:totalResults>`, total)
+	for _, id := range ids {
+		fmt.Fprintf(&b, `<entry><id>http://arxiv.org/abs/%s</id><title>Paper %s</title><summary>Abstract.</summary><published>2025-01-01T00:00:00Z</published></entry>`, id, id)
+	}
+	b.WriteString("</feed>")
+	return b.String()
+}
+
+func TestSearchViaAPI_StopsOnContextCancel(t *testing.T) {
+	var mu sync.Mutex
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		n := hits
+		mu.Unlock()
+		w.Write([]byte(atomFeedXML(100, fmt.Sprintf("2501.%05d", 2*n-1), fmt.Sprintf("2501.%05d", 2*n))))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.UseAPI = true
+	cfg.APIBase = srv.URL
+	cfg.Step = 2
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	// 第一页返回后进入 1s 的翻页间隔，期间 context 超时
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err := a.Search(ctx, platform.Query{Keywords: []string{"graph"}, Limit: 10})
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Expected Search to return promptly after cancel, took %v", elapsed)
+	}
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if len(result.Papers) != 2 {
+		t.Errorf("Expected 2 partial papers, got %d", len(result.Papers))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if hits != 1 {
+		t.Errorf("Expected 1 request before cancel, got %d", hits)
+	}
+}
+
+func TestSearchViaAPI_CancelledBeforeRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request with a cancelled context")
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.UseAPI = true
+	cfg.APIBase = srv.URL
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := a.Search(ctx, platform.Query{Keywords: []string{"graph"}, Limit: 10})
+	if err != context.Canceled || len(result.Papers) != 0 {
+		t.Errorf("Search() = %d papers, %v; want 0, context.Canceled", len(result.Papers), err)
+	}
+}
+
+func TestBuildAPIURL_Sort(t *testing.T) {
+	a, err := NewAdapter(DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	tests := []struct {
+		query     platform.Query
+		sortBy    string
+		sortOrder string
+	}{
+		{platform.Query{}, "submittedDate", "descending"},
+		{platform.Query{SortBy: platform.SortByRelevance}, "relevance", "descending"},
+		{platform.Query{SortBy: platform.SortByLastUpdatedDate, SortOrder: platform.SortAsc}, "lastUpdatedDate", "ascending"},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(a.buildAPIURL(tt.query, "all:graph", 0, 50))
+		if err != nil {
+			t.Fatalf("buildAPIURL() returned invalid URL: %v", err)
+		}
+		params := u.Query()
+		if params.Get("sortBy") != tt.sortBy || params.Get("sortOrder") != tt.sortOrder {
+			t.Errorf("Query %+v: sortBy=%q sortOrder=%q, want %q %q", tt.query, params.Get("sortBy"), params.Get("sortOrder"), tt.sortBy, tt.sortOrder)
+		}
+	}
+}
+
+func TestBuildWebQuery_SortOrder(t *testing.T) {
+	a, err := NewAdapter(DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	tests := []struct {
+		query platform.Query
+		order string
+	}{
+		{platform.Query{}, "-announced_date_first"},
+		{platform.Query{SortOrder: platform.SortAsc}, "announced_date_first"},
+		{platform.Query{SortBy: platform.SortBySubmittedDate}, "-submitted_date"},
+		{platform.Query{SortBy: platform.SortBySubmittedDate, SortOrder: platform.SortAsc}, "submitted_date"},
+		{platform.Query{SortBy: platform.SortByRelevance}, ""},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(a.buildWebQuery(tt.query))
+		if err != nil {
+			t.Fatalf("buildWebQuery() returned invalid URL: %v", err)
+		}
+		if got := u.Query().Get("order"); got != tt.order {
+			t.Errorf("Query %+v: order=%q, want %q", tt.query, got, tt.order)
+		}
+	}
+}
+
+func TestSearch_RejectsUnknownSort(t *testing.T) {
+	a, err := NewAdapter(DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	if _, err := a.Search(context.Background(), platform.Query{SortBy: "citations"}); err == nil {
+		t.Error("Expected error for unsupported sort_by")
+	}
+	if _, err := a.Search(context.Background(), platform.Query{SortOrder: "up"}); err == nil {
+		t.Error("Expected error for unsupported sort_order")
+	}
+}
@@ -0,0 +1,29 @@
+package arxiv
+
+import (
+	"strings"
+	"time"
+)
+
+// AnnouncementDay 返回 now 当天或之前最近的 arXiv 公布日（当天零点，时区与 now 相同）：
+// arXiv 周六、周日不公布新论文，holidays（YYYY-MM-DD）中的日期同样跳过，
+// 此时 /list/<archive>/new 页面仍是上一个公布日的列表
+func AnnouncementDay(now time.Time, holidays []string) time.Time {
+	skip := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		skip[strings.TrimSpace(h)] = true
+	}
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for {
+		if wd := day.Weekday(); wd != time.Saturday && wd != time.Sunday && !skip[day.Format("2006-01-02")] {
+			return day
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+}
+
+// AnnouncementDay 按配置的节假日返回 now 当天或之前最近的公布日
+func (c *Config) AnnouncementDay(now time.Time) time.Time {
+	return AnnouncementDay(now, c.Holidays)
+}
@@ -0,0 +1,48 @@
+package arxiv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnnouncementDay(t *testing.T) {
+	day := func(s string) time.Time {
+		d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			t.Fatalf("parse %s: %v", s, err)
+		}
+		return d
+	}
+
+	cases := []struct {
+		name     string
+		now      time.Time
+		holidays []string
+		want     string
+	}{
+		{"saturday uses friday", day("2025-10-18").Add(15 * time.Hour), nil, "2025-10-17"},
+		{"sunday uses friday", day("2025-10-19").Add(9 * time.Hour), nil, "2025-10-17"},
+		{"monday is an announcement day", day("2025-10-20").Add(8 * time.Hour), nil, "2025-10-20"},
+		{"holiday monday falls back past the weekend", day("2025-10-20"), []string{"2025-10-20"}, "2025-10-17"},
+		{"consecutive holidays", day("2025-12-26"), []string{"2025-12-25", " 2025-12-26 "}, "2025-12-24"},
+	}
+	for _, tc := range cases {
+		got := AnnouncementDay(tc.now, tc.holidays)
+		if !got.Equal(day(tc.want)) {
+			t.Errorf("%s: AnnouncementDay(%s) = %s, want %s", tc.name, tc.now.Format(time.RFC3339), got.Format("2006-01-02 15:04"), tc.want)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.Holidays = []string{"2025-10-17"}
+	if got := cfg.AnnouncementDay(day("2025-10-18")); !got.Equal(day("2025-10-16")) {
+		t.Errorf("Config.AnnouncementDay() = %s, want 2025-10-16", got.Format("2006-01-02"))
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error: %v", err)
+	}
+	cfg.Holidays = []string{"12/25"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for invalid holiday")
+	}
+}
@@ -24,13 +24,13 @@ func init() {
 	})
 }
 
-//如果添加下载功能可以使用
-/*
+// PDFUrl 返回 arXiv 论文的 PDF 链接，不带版本号时指向最新版本
 func PDFUrl(arxivID string) string {
 	return "https://arxiv.org/pdf/" + arxivID
 }
 
-
+//如果添加下载功能可以使用
+/*
 func PapersCoolUrl(arxivID string) string {
 	return "https://papers.cool/arxiv/" + arxivID
 }*/
@@ -0,0 +1,103 @@
+package arxiv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// Semantic Scholar 批量接口单次最多 500 个 ID
+const citationBatchSize = 500
+
+// fillCitations 通过 Semantic Scholar 批量查询 arXiv 论文的被引用次数、参考文献与开放获取 PDF 链接
+// 查询失败只记录日志，不影响抓取结果
+func (a *Adapter) fillCitations(ctx context.Context, papers []*models.Paper) {
+	if !a.config.FetchCitations || len(papers) == 0 {
+		return
+	}
+
+	for start := 0; start < len(papers); start += citationBatchSize {
+		end := start + citationBatchSize
+		if end > len(papers) {
+			end = len(papers)
+		}
+		if err := a.fillCitationsBatch(ctx, papers[start:end]); err != nil {
+			logger.Warn("[arXiv] 获取引用数失败: %v", err)
+			return
+		}
+	}
+}
+
+func (a *Adapter) fillCitationsBatch(ctx context.Context, papers []*models.Paper) error {
+	ids := make([]string, 0, len(papers))
+	for _, p := range papers {
+		ids = append(ids, "arXiv:"+p.SourceID)
+	}
+
+	payload, err := json.Marshal(map[string][]string{"ids": ids})
+	if err != nil {
+		return err
+	}
+
+	apiURL := a.config.CitationAPI + "?" + url.Values{"fields": {"citationCount,references.externalIds,openAccessPdf"}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err := a.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP error: %d, %s", resp.StatusCode, string(body))
+	}
+
+	// 返回结果与请求 ID 一一对应，未收录的论文为 null
+	var results []*struct {
+		CitationCount int `json:"citationCount"`
+		References    []struct {
+			ExternalIDs map[string]interface{} `json:"externalIds"`
+		} `json:"references"`
+		OpenAccessPDF *struct {
+			URL string `json:"url"`
+		} `json:"openAccessPdf"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for i, r := range results {
+		if i >= len(papers) || r == nil {
+			continue
+		}
+		papers[i].Citations = r.CitationCount
+		if papers[i].OpenAccessURL == "" && r.OpenAccessPDF != nil {
+			papers[i].OpenAccessURL = r.OpenAccessPDF.URL
+		}
+
+		// 只保留同样来自 arXiv 的参考文献，便于在本地库中关联
+		refs := make([]string, 0, len(r.References))
+		for _, ref := range r.References {
+			if id, ok := ref.ExternalIDs["ArXiv"].(string); ok && id != "" {
+				refs = append(refs, id)
+			}
+		}
+		papers[i].References = refs
+	}
+	return nil
+}
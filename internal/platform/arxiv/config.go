@@ -2,6 +2,8 @@ package arxiv
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 )
 
@@ -14,6 +16,20 @@ type Config struct {
 	APIBase string `mapstructure:"api_base" yaml:"api_base"` // API 基础 URL
 	WebBase string `mapstructure:"web_base" yaml:"web_base"` // 网页搜索基础 URL
 	NewBase string `mapstructure:"new_base" yaml:"new_base"` // New Submissions 页面基础 URL
+
+	NewSubmissionCategories []string `mapstructure:"new_submissions_categories" yaml:"new_submissions_categories"` // 每日推荐从 New Submissions 页面获取的 archive 或分类，如 cs、stat、math.ST、stat.ML、eess.SP
+	DailyArchives           []string `mapstructure:"daily_archives" yaml:"daily_archives,omitempty"`               // new_submissions_categories 的旧名，设置时优先
+	Holidays                []string `mapstructure:"holidays" yaml:"holidays"`                                     // arXiv 不公布新论文的节假日（YYYY-MM-DD），与周末一样在每日推荐时跳过
+
+	FetchCitations bool   `mapstructure:"fetch_citations" yaml:"fetch_citations"` // 是否通过 Semantic Scholar 补充引用数
+	CitationAPI    string `mapstructure:"citation_api" yaml:"citation_api"`       // Semantic Scholar 批量查询接口
+
+	RateLimitRPS float64 `mapstructure:"rate_limit_rps" yaml:"rate_limit_rps"` // 每秒请求数上限，0 表示不限速
+
+	ProxyPool []string `mapstructure:"proxy_pool" yaml:"proxy_pool"` // 备用代理，当前代理返回 403/429 时依次切换并立即重试
+
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify"` // 跳过 TLS 证书校验，仅用于自签证书的代理
+	DialTimeout        time.Duration `mapstructure:"dial_timeout" yaml:"dial_timeout"`                 // 建立连接超时，如 10s
 }
 
 
@@ -25,9 +41,32 @@ func DefaultConfig() *Config {
 		APIBase: "https://export.arxiv.org/api/query",
 		WebBase: "https://arxiv.org/search/advanced",
 		NewBase: "https://arxiv.org/list",
+
+		NewSubmissionCategories: DefaultNewSubmissionCategories(),
+
+		CitationAPI: "https://api.semanticscholar.org/graph/v1/paper/batch",
+
+		RateLimitRPS: 1,
+		DialTimeout:  30 * time.Second,
 	}
 }
 
+// DefaultNewSubmissionCategories 默认的每日新论文 archive 与分类
+func DefaultNewSubmissionCategories() []string {
+	return []string{"cs", "math.ST", "stat.ML", "eess.SP"}
+}
+
+// DailyCategories 每日推荐要获取的 archive 或分类：旧配置 daily_archives 优先，
+// 其次为 new_submissions_categories，都未设置时为 cs
+func (c *Config) DailyCategories() []string {
+	if len(c.DailyArchives) > 0 {
+		return c.DailyArchives
+	}
+	if len(c.NewSubmissionCategories) > 0 {
+		return c.NewSubmissionCategories
+	}
+	return []string{"cs"}
+}
 
 func (c *Config) Validate() error {
 	if c.Step <= 0 || c.Step > 200 {
@@ -42,5 +81,21 @@ func (c *Config) Validate() error {
 	if c.WebBase == "" {
 		return fmt.Errorf("web_base cannot be empty")
 	}
+	if c.FetchCitations && c.CitationAPI == "" {
+		return fmt.Errorf("citation_api cannot be empty when fetch_citations is enabled")
+	}
+	if c.DialTimeout < 0 {
+		return fmt.Errorf("dial_timeout must not be negative, got %v", c.DialTimeout)
+	}
+	if c.RateLimitRPS < 0 {
+		return fmt.Errorf("rate_limit_rps must not be negative, got %v", c.RateLimitRPS)
+	}
+	for _, h := range c.Holidays {
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(h)); err != nil {
+			return fmt.Errorf("invalid holiday %q, expected YYYY-MM-DD", h)
+		}
+	}
 	return nil
 }
+
+func (c *Config) RateLimit() float64 { return c.RateLimitRPS }
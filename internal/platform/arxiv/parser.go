@@ -166,6 +166,16 @@ func parseArxivIDFromURL(url string) string {
 	return ""
 }
 
+var arxivVersionRe = regexp.MustCompile(`^(.+?)(v\d+)$`)
+
+// splitArxivVersion 将 2408.12345v2 拆为 2408.12345 与 v2，没有版本号时 version 为空
+func splitArxivVersion(id string) (base, version string) {
+	if m := arxivVersionRe.FindStringSubmatch(id); m != nil {
+		return m[1], m[2]
+	}
+	return id, ""
+}
+
 func parseAuthorsToSlice(authorsStr string) []string {
 	if authorsStr == "" {
 		return nil
@@ -196,6 +206,7 @@ type AtomEntry struct {
 	Authors    []AtomAuthor   `xml:"author"`
 	Links      []AtomLink     `xml:"link"`
 	Categories []AtomCategory `xml:"category"`
+	Comment    string         `xml:"http://arxiv.org/schemas/atom comment"` // 作者对当前版本的说明
 }
 
 type AtomAuthor struct {
@@ -212,15 +223,35 @@ type AtomCategory struct {
 	Term string `xml:"term,attr"`
 }
 
-// ParseNewSubmissionsHTML 解析 arXiv New Submissions 页面
+// SubmissionResult New Submissions 页面中的一篇论文及其分类
+type SubmissionResult struct {
+	Paper               *models.Paper
+	PrimaryCategory     string   // 主分类，如 cs.CL
+	CrossListCategories []string // 交叉列出的其他分类（不含主分类）
+}
+
+// ParseNewSubmissionsHTML 解析 arXiv New Submissions 页面，Paper.Categories 为主分类与交叉分类的并集
 // URL 格式: https://arxiv.org/list/cs/new
 func ParseNewSubmissionsHTML(htmlContent string) ([]*models.Paper, int, error) {
+	results, err := ParseNewSubmissions(htmlContent)
+	if err != nil {
+		return nil, 0, err
+	}
+	papers := make([]*models.Paper, len(results))
+	for i, r := range results {
+		papers[i] = r.Paper
+	}
+	return papers, len(papers), nil
+}
+
+// ParseNewSubmissions 解析 arXiv New Submissions 页面，分别返回每篇论文的主分类与交叉分类
+func ParseNewSubmissions(htmlContent string) ([]*SubmissionResult, error) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
-	var papers []*models.Paper
+	var results []*SubmissionResult
 
 	// New submissions 页面的结构：
 	// <dl id="articles"> 或 <dl>
@@ -290,28 +321,35 @@ func ParseNewSubmissionsHTML(htmlContent string) ([]*models.Paper, int, error) {
 		}
 
 		// 从 dd 中提取分类
-		// 格式: <span class="primary-subject">cs.AI</span>
-		var categories []string
-		dd.Find("span.primary-subject").Each(func(_ int, span *goquery.Selection) {
-			cat := strings.TrimSpace(span.Text())
-			if cat != "" {
-				categories = append(categories, cat)
-			}
-		})
-		// 也尝试从 list-subjects 中提取
+		// 格式: <div class="list-subjects"><span class="primary-subject">Computation and Language (cs.CL)</span>; Artificial Intelligence (cs.AI)</div>
+		result := &SubmissionResult{Paper: paper}
+		if primary := dd.Find("span.primary-subject").First(); primary.Length() > 0 {
+			result.PrimaryCategory = subjectCode(primary.Text())
+		}
 		if subjects := dd.Find("div.list-subjects"); subjects.Length() > 0 {
-			subjectsText := subjects.Text()
+			subjectsText := strings.TrimSpace(subjects.Text())
 			subjectsText = strings.TrimPrefix(subjectsText, "Subjects:")
 			subjectsText = strings.TrimPrefix(subjectsText, "Subjects :")
-			// 分类通常用分号或逗号分隔
-			for _, cat := range strings.Split(subjectsText, ";") {
-				cat = strings.TrimSpace(cat)
-				if cat != "" && !containsString(categories, cat) {
-					categories = append(categories, cat)
+			for _, subject := range strings.Split(subjectsText, ";") {
+				cat := subjectCode(subject)
+				if cat == "" {
+					continue
+				}
+				// 没有 primary-subject 标记时第一个分类即主分类
+				if result.PrimaryCategory == "" {
+					result.PrimaryCategory = cat
+					continue
+				}
+				if cat != result.PrimaryCategory && !containsString(result.CrossListCategories, cat) {
+					result.CrossListCategories = append(result.CrossListCategories, cat)
 				}
 			}
 		}
-		paper.Categories = categories
+		var categories []string
+		if result.PrimaryCategory != "" {
+			categories = append(categories, result.PrimaryCategory)
+		}
+		paper.Categories = append(categories, result.CrossListCategories...)
 
 		// 设置今天的日期作为发布日期（New Submissions 页面的论文都是今天公布的）
 		paper.FirstSubmittedAt = time.Now()
@@ -320,11 +358,23 @@ func ParseNewSubmissionsHTML(htmlContent string) ([]*models.Paper, int, error) {
 
 		// 只添加有效的论文
 		if paper.Title != "" && paper.SourceID != "" {
-			papers = append(papers, paper)
+			results = append(results, result)
 		}
 	})
 
-	return papers, len(papers), nil
+	return results, nil
+}
+
+// subjectCodeRe 匹配学科名末尾括号中的分类代码，如 "Machine Learning (stat.ML)"
+var subjectCodeRe = regexp.MustCompile(`\(([A-Za-z-]+(?:\.[A-Za-z-]+)?)\)\s*$`)
+
+// subjectCode 从 "Computation and Language (cs.CL)" 中取出 cs.CL；没有括号时返回去除空白的原文
+func subjectCode(subject string) string {
+	subject = cleanText(subject)
+	if m := subjectCodeRe.FindStringSubmatch(subject); m != nil {
+		return m[1]
+	}
+	return subject
 }
 
 // containsString 检查字符串切片是否包含指定字符串
@@ -349,11 +399,12 @@ func ParseAtomFeed(xmlContent string) ([]*models.Paper, int, error) {
 			Source: "arxiv", // 设置平台标识
 		}
 
-		// e.ID 类似 http://arxiv.org/abs/XXXX
-		p.URL = e.ID
-		p.SourceID = parseArxivIDFromURL(e.ID)
+		// e.ID 类似 http://arxiv.org/abs/XXXXvN，去掉版本号使各版本对应同一篇论文
+		p.SourceID, p.LatestVersion = splitArxivVersion(parseArxivIDFromURL(e.ID))
+		p.URL = strings.TrimSuffix(e.ID, p.LatestVersion)
 		p.Title = cleanText(e.Title)
 		p.Abstract = cleanText(e.Summary)
+		p.Comments = cleanText(e.Comment)
 
 		var authorNames []string
 		for _, a := range e.Authors {
@@ -379,6 +430,15 @@ func ParseAtomFeed(xmlContent string) ([]*models.Paper, int, error) {
 		}
 		p.UpdatedAt = time.Now()
 
+		// <updated> 为最新版本的提交时间
+		if p.LatestVersion != "" {
+			v := models.PaperVersion{Version: p.LatestVersion, ChangeNote: p.Comments}
+			if t, err := time.Parse(time.RFC3339, e.Updated); err == nil {
+				v.SubmittedAt = t
+			}
+			p.VersionHistory = []models.PaperVersion{v}
+		}
+
 		papers = append(papers, p)
 	}
 
@@ -0,0 +1,138 @@
+package arxiv
+
+import (
+	"reflect"
+	"testing"
+)
+
+// submissionHTML 生成 New Submissions 页面中的一篇论文，subjects 为 list-subjects 中主分类之后的部分
+func submissionHTML(id, primary, subjects string) string {
+	return `<html><body><dl id="articles">
+<dt><a href="/abs/` + id + `" title="Abstract">arXiv:` + id + `</a></dt>
+<dd><div class="list-title mathjax"><span class="descriptor">Title:</span> Paper ` + id + `</div>
+<div class="list-authors"><span class="descriptor">Authors:</span> Alice, Bob</div>
+<div class="list-subjects"><span class="descriptor">Subjects:</span>
+    <span class="primary-subject">` + primary + `</span>` + subjects + `</div>
+<p class="mathjax">Abstract of ` + id + `.</p></dd>
+</dl></body></html>`
+}
+
+func TestParseNewSubmissions_CrossLists(t *testing.T) {
+	tests := []struct {
+		name       string
+		html       string
+		primary    string
+		crossLists []string
+		categories []string
+	}{
+		{
+			name:       "no cross-lists",
+			html:       submissionHTML("2501.00001", "Computation and Language (cs.CL)", ""),
+			primary:    "cs.CL",
+			crossLists: nil,
+			categories: []string{"cs.CL"},
+		},
+		{
+			name:       "one cross-list",
+			html:       submissionHTML("2501.00002", "Machine Learning (cs.LG)", "; Machine Learning (stat.ML)"),
+			primary:    "cs.LG",
+			crossLists: []string{"stat.ML"},
+			categories: []string{"cs.LG", "stat.ML"},
+		},
+		{
+			name: "five cross-lists with duplicates",
+			html: submissionHTML("2501.00003", "Artificial Intelligence (cs.AI)",
+				"; Computation and Language (cs.CL); Machine Learning (cs.LG); Artificial Intelligence (cs.AI);\n"+
+					" Information Retrieval (cs.IR); Machine Learning (stat.ML); Optimization and Control (math.OC); Machine Learning (cs.LG)"),
+			primary:    "cs.AI",
+			crossLists: []string{"cs.CL", "cs.LG", "cs.IR", "stat.ML", "math.OC"},
+			categories: []string{"cs.AI", "cs.CL", "cs.LG", "cs.IR", "stat.ML", "math.OC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := ParseNewSubmissions(tt.html)
+			if err != nil {
+				t.Fatalf("ParseNewSubmissions() error: %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("Expected 1 result, got %d", len(results))
+			}
+			r := results[0]
+			if r.PrimaryCategory != tt.primary {
+				t.Errorf("PrimaryCategory = %q, want %q", r.PrimaryCategory, tt.primary)
+			}
+			if !reflect.DeepEqual(r.CrossListCategories, tt.crossLists) {
+				t.Errorf("CrossListCategories = %v, want %v", r.CrossListCategories, tt.crossLists)
+			}
+			if !reflect.DeepEqual(r.Paper.Categories, tt.categories) {
+				t.Errorf("Paper.Categories = %v, want %v", r.Paper.Categories, tt.categories)
+			}
+			if r.Paper.Title != "Paper "+r.Paper.SourceID {
+				t.Errorf("Unexpected title %q for %s", r.Paper.Title, r.Paper.SourceID)
+			}
+		})
+	}
+}
+
+func TestParseNewSubmissionsHTML_MissingSubjects(t *testing.T) {
+	papers, total, err := ParseNewSubmissionsHTML(newSubmissionsHTML("2501.00001", "2501.00002"))
+	if err != nil {
+		t.Fatalf("ParseNewSubmissionsHTML() error: %v", err)
+	}
+	if total != 2 || len(papers) != 2 {
+		t.Fatalf("Expected 2 papers, got total=%d papers=%d", total, len(papers))
+	}
+	for _, p := range papers {
+		if len(p.Categories) != 0 {
+			t.Errorf("Expected no categories for %s, got %v", p.SourceID, p.Categories)
+		}
+	}
+}
+
+func TestParseAtomFeed_Versions(t *testing.T) {
+	feed := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:arxiv="http://arxiv.org/schemas/atom">
+<entry>
+  <id>http://arxiv.org/abs/2401.01234v3</id>
+  <updated>2024-03-05T12:00:00Z</updated>
+  <published>2024-01-02T09:30:00Z</published>
+  <title>Versioned Paper</title>
+  <summary>Latest abstract.</summary>
+  <arxiv:comment>Camera-ready; fixed typos in Table 2</arxiv:comment>
+</entry>
+<entry>
+  <id>http://arxiv.org/abs/2401.05678</id>
+  <published>2024-01-03T09:30:00Z</published>
+  <title>Unversioned Paper</title>
+</entry>
+</feed>`
+
+	papers, _, err := ParseAtomFeed(feed)
+	if err != nil {
+		t.Fatalf("ParseAtomFeed() error: %v", err)
+	}
+	if len(papers) != 2 {
+		t.Fatalf("Expected 2 papers, got %d", len(papers))
+	}
+
+	p := papers[0]
+	if p.SourceID != "2401.01234" || p.URL != "http://arxiv.org/abs/2401.01234" || p.LatestVersion != "v3" {
+		t.Errorf("Expected version stripped from id/url, got %q %q %q", p.SourceID, p.URL, p.LatestVersion)
+	}
+	if p.Comments != "Camera-ready; fixed typos in Table 2" {
+		t.Errorf("Comments = %q", p.Comments)
+	}
+	if len(p.VersionHistory) != 1 {
+		t.Fatalf("Expected 1 version entry, got %+v", p.VersionHistory)
+	}
+	v := p.VersionHistory[0]
+	if v.Version != "v3" || v.SubmittedAt.Format("2006-01-02") != "2024-03-05" || v.ChangeNote != p.Comments {
+		t.Errorf("Unexpected version entry: %+v", v)
+	}
+
+	if papers[1].SourceID != "2401.05678" || papers[1].LatestVersion != "" || len(papers[1].VersionHistory) != 0 {
+		t.Errorf("Expected no version info for unversioned id, got %+v", papers[1])
+	}
+}
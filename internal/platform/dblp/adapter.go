@@ -0,0 +1,201 @@
+package dblp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/httpclient"
+	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/proxy"
+	"PaperHunter/pkg/ratelimit"
+)
+
+// maxOffset DBLP 检索接口最多返回前 10000 条结果
+const maxOffset = 10000
+
+type Adapter struct {
+	config     *Config
+	httpClient *http.Client
+	limiter    ratelimit.Limiter
+}
+
+func NewAdapter(config *Config) (*Adapter, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	client := httpclient.New(time.Duration(config.Timeout)*time.Second, proxy.NewRotator([]string{config.Proxy}),
+		httpclient.WithInsecureSkipVerify(config.InsecureSkipVerify),
+		httpclient.WithDialTimeout(config.DialTimeout),
+	)
+	return &Adapter{config: config, httpClient: client, limiter: ratelimit.For("dblp", config.RateLimit())}, nil
+}
+
+func (a *Adapter) Name() string { return "dblp" }
+
+func (a *Adapter) GetConfig() platform.Config { return a.config }
+
+// SetLimiter 替换请求限速器
+func (a *Adapter) SetLimiter(l ratelimit.Limiter) { a.limiter = l }
+
+// Search 按关键词检索 DBLP，Categories 作为会议/期刊名（venue）逐个检索；
+// DBLP 只有年份信息，DateFrom/DateTo 按年份在本地过滤
+func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	keywords := strings.TrimSpace(strings.Join(q.Keywords, " "))
+	if keywords == "" && len(q.Categories) == 0 {
+		return platform.Result{}, fmt.Errorf("dblp 需要提供关键词或 venue")
+	}
+
+	fromYear, toYear := yearOf(q.DateFrom), yearOf(q.DateTo)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	venues := q.Categories
+	if len(venues) == 0 {
+		venues = []string{""}
+	}
+
+	var papers []*models.Paper
+	seen := make(map[string]bool)
+	for _, venue := range venues {
+		query := buildQuery(keywords, venue, fromYear, toYear)
+		logger.Info("[DBLP] 检索: %s", query)
+
+		for offset := q.Offset; len(papers) < limit && offset < maxOffset; offset += a.config.PageSize {
+			page, total, err := a.fetchPage(ctx, query, offset)
+			if err != nil {
+				return platform.Result{}, err
+			}
+			for _, p := range page {
+				if seen[p.SourceID] || !inYearRange(p, fromYear, toYear) {
+					continue
+				}
+				seen[p.SourceID] = true
+				papers = append(papers, p)
+				if len(papers) >= limit {
+					break
+				}
+			}
+			logger.Debug("[DBLP] offset=%d 返回 %d 条，共 %d 条，已收集 %d 篇", offset, len(page), total, len(papers))
+			if len(page) < a.config.PageSize || offset+a.config.PageSize >= total {
+				break
+			}
+		}
+		if len(papers) >= limit {
+			break
+		}
+	}
+
+	logger.Info("[DBLP] 共获取 %d 篇论文", len(papers))
+	return platform.Result{Total: len(papers), Papers: papers}, nil
+}
+
+// buildQuery 组合关键词、venue 与年份，起止年份相同时交给服务端按年份过滤
+func buildQuery(keywords, venue string, fromYear, toYear int) string {
+	parts := make([]string, 0, 3)
+	if keywords != "" {
+		parts = append(parts, keywords)
+	}
+	if venue = strings.TrimSpace(venue); venue != "" {
+		parts = append(parts, "venue:"+venue+":")
+	}
+	if fromYear > 0 && fromYear == toYear {
+		parts = append(parts, fmt.Sprintf("year:%d:", fromYear))
+	}
+	return strings.Join(parts, " ")
+}
+
+// yearOf 取 YYYY-MM-DD 的年份，为空或无法解析时返回 0
+func yearOf(date string) int {
+	if len(date) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// inYearRange 论文年份是否在 [fromYear, toYear] 内，0 表示不限；没有年份的论文在有范围时被排除
+func inYearRange(p *models.Paper, fromYear, toYear int) bool {
+	if fromYear == 0 && toYear == 0 {
+		return true
+	}
+	if p.FirstSubmittedAt.IsZero() {
+		return false
+	}
+	year := p.FirstSubmittedAt.Year()
+	return (fromYear == 0 || year >= fromYear) && (toYear == 0 || year <= toYear)
+}
+
+func (a *Adapter) fetchPage(ctx context.Context, query string, offset int) ([]*models.Paper, int, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("format", "json")
+	params.Set("h", strconv.Itoa(a.config.PageSize))
+	params.Set("f", strconv.Itoa(offset))
+
+	body, err := a.request(ctx, a.config.APIBase+"?"+params.Encode())
+	if err != nil {
+		return nil, 0, err
+	}
+	return parseResponse(body)
+}
+
+func (a *Adapter) request(ctx context.Context, apiURL string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt < 3; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		if err := a.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := a.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := ratelimit.RetryAfter(resp)
+			resp.Body.Close()
+			lastErr = fmt.Errorf("rate limited (429)")
+			logger.Warn("[DBLP] 收到 429 频率限制，%v 后重试", wait)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		}
+		return body, nil
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,147 @@
+package dblp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"PaperHunter/internal/core"
+	"PaperHunter/internal/platform"
+)
+
+// hitJSON 构造一条 DBLP 命中记录；单作者、单 venue 时 DBLP 返回对象/字符串而非数组
+func hitJSON(key, title, year string, authors []string, venues []string, doi string) string {
+	var authorJSON string
+	if len(authors) == 1 {
+		authorJSON = fmt.Sprintf(`{"@pid":"1","text":%q}`, authors[0])
+	} else {
+		parts := make([]string, len(authors))
+		for i, a := range authors {
+			parts[i] = fmt.Sprintf(`{"@pid":"%d","text":%q}`, i, a)
+		}
+		authorJSON = "[" + strings.Join(parts, ",") + "]"
+	}
+	venueJSON := fmt.Sprintf("%q", venues[0])
+	if len(venues) > 1 {
+		venueJSON = fmt.Sprintf(`[%q,%q]`, venues[0], venues[1])
+	}
+	return fmt.Sprintf(`{"@score":"1","info":{"authors":{"author":%s},"title":%q,"venue":%s,"year":%q,
+		"type":"Conference and Workshop Papers","key":%q,"doi":%q,"ee":"https://doi.org/%s","url":"https://dblp.org/rec/%s"}}`,
+		authorJSON, title, venueJSON, year, key, doi, doi, key)
+}
+
+func pageJSON(total int, hits ...string) string {
+	return fmt.Sprintf(`{"result":{"hits":{"@total":"%d","@sent":"%d","hit":[%s]}}}`, total, len(hits), strings.Join(hits, ","))
+}
+
+func TestSearch_PagingAndYearFilter(t *testing.T) {
+	pages := map[int]string{
+		0: pageJSON(4,
+			hitJSON("conf/nips/A23", "Graph Transformers.", "2023", []string{"Alice 0001", "Bob"}, []string{"NeurIPS"}, "10.1/a"),
+			hitJSON("conf/iclr/B21", "Old Graph Work.", "2021", []string{"Carol"}, []string{"ICLR"}, "10.1/b"),
+		),
+		2: pageJSON(4,
+			hitJSON("journals/tmlr/C24", "Graph Survey.", "2024", []string{"Dan"}, []string{"TMLR", "CoRR"}, ""),
+			hitJSON("conf/nips/A23", "Graph Transformers.", "2023", []string{"Alice 0001", "Bob"}, []string{"NeurIPS"}, "10.1/a"),
+		),
+	}
+
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("q"))
+		mu.Unlock()
+		if r.URL.Query().Get("format") != "json" || r.URL.Query().Get("h") != "2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("f"))
+		body, ok := pages[offset]
+		if !ok {
+			body = pageJSON(4)
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.APIBase = srv.URL
+	cfg.PageSize = 2
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	res, err := a.Search(context.Background(), platform.Query{
+		Keywords: []string{"graph"},
+		DateFrom: "2022-01-01",
+		DateTo:   "2024-12-31",
+	})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(queries) != 2 || queries[0] != "graph" {
+		t.Errorf("Expected 2 page requests for query %q, got %q", "graph", queries)
+	}
+	if res.Total != 2 || len(res.Papers) != 2 {
+		t.Fatalf("Expected 2 papers within 2022-2024, got %d", len(res.Papers))
+	}
+
+	p := res.Papers[0]
+	if p.Source != "dblp" || p.SourceID != "conf/nips/A23" || p.Title != "Graph Transformers" {
+		t.Errorf("Unexpected paper: %+v", p)
+	}
+	if strings.Join(p.Authors, ",") != "Alice,Bob" {
+		t.Errorf("Authors = %v, want [Alice Bob]", p.Authors)
+	}
+	if strings.Join(p.Categories, ",") != "NeurIPS" || p.Comments != "DOI: 10.1/a" || p.URL != "https://doi.org/10.1/a" {
+		t.Errorf("Unexpected venue/DOI/URL: %v %q %q", p.Categories, p.Comments, p.URL)
+	}
+	if p.FirstSubmittedAt.Year() != 2023 {
+		t.Errorf("FirstSubmittedAt = %v, want year 2023", p.FirstSubmittedAt)
+	}
+	if got := res.Papers[1]; strings.Join(got.Categories, ",") != "TMLR,CoRR" || got.Comments != "" {
+		t.Errorf("Unexpected second paper: %v %q", got.Categories, got.Comments)
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	cases := []struct {
+		keywords, venue  string
+		fromYear, toYear int
+		want             string
+	}{
+		{"graph neural", "", 0, 0, "graph neural"},
+		{"graph", "NeurIPS", 2023, 2023, "graph venue:NeurIPS: year:2023:"},
+		{"", "ICLR", 2022, 2024, "venue:ICLR:"},
+	}
+	for _, c := range cases {
+		if got := buildQuery(c.keywords, c.venue, c.fromYear, c.toYear); got != c.want {
+			t.Errorf("buildQuery(%q, %q, %d, %d) = %q, want %q", c.keywords, c.venue, c.fromYear, c.toYear, got, c.want)
+		}
+	}
+}
+
+func TestRegisteredProvider(t *testing.T) {
+	prov, ok := core.Get("dblp")
+	if !ok {
+		t.Fatal("Expected dblp provider to be registered")
+	}
+	plat, err := prov.New(prov.DefaultConfig())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if plat.Name() != "dblp" {
+		t.Errorf("Name() = %q, want dblp", plat.Name())
+	}
+	if _, err := plat.Search(context.Background(), platform.Query{}); err == nil {
+		t.Error("Expected error for empty query")
+	}
+}
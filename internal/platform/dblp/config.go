@@ -0,0 +1,51 @@
+package dblp
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config DBLP 平台配置
+type Config struct {
+	APIBase  string `mapstructure:"api_base" yaml:"api_base"`   // 论文检索 API 地址
+	Proxy    string `mapstructure:"proxy" yaml:"proxy"`         // 代理地址
+	Timeout  int    `mapstructure:"timeout" yaml:"timeout"`     // 超时时间（秒）
+	PageSize int    `mapstructure:"page_size" yaml:"page_size"` // 每页数量（1-1000）
+
+	RateLimitRPS float64 `mapstructure:"rate_limit_rps" yaml:"rate_limit_rps"` // 每秒请求数上限，0 表示不限速
+
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify"` // 跳过 TLS 证书校验，仅用于自签证书的代理
+	DialTimeout        time.Duration `mapstructure:"dial_timeout" yaml:"dial_timeout"`                 // 建立连接超时，如 10s
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		APIBase:  "https://dblp.org/search/publ/api",
+		Timeout:  30,
+		PageSize: 100,
+
+		RateLimitRPS: 1,
+		DialTimeout:  30 * time.Second,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.APIBase == "" {
+		return fmt.Errorf("api_base 不能为空")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout 必须大于 0")
+	}
+	if c.PageSize <= 0 || c.PageSize > 1000 {
+		return fmt.Errorf("page_size 必须在 1-1000 之间")
+	}
+	if c.DialTimeout < 0 {
+		return fmt.Errorf("dial_timeout 不能为负")
+	}
+	if c.RateLimitRPS < 0 {
+		return fmt.Errorf("rate_limit_rps 不能为负")
+	}
+	return nil
+}
+
+func (c *Config) RateLimit() float64 { return c.RateLimitRPS }
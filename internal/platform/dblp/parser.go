@@ -0,0 +1,123 @@
+package dblp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+// apiResponse DBLP publ API 的 JSON 响应，只保留用到的字段
+type apiResponse struct {
+	Result struct {
+		Hits struct {
+			Total string `json:"@total"`
+			Hit   []struct {
+				Info hitInfo `json:"info"`
+			} `json:"hit"`
+		} `json:"hits"`
+	} `json:"result"`
+}
+
+type hitInfo struct {
+	Authors struct {
+		Author oneOrMany[author] `json:"author"`
+	} `json:"authors"`
+	Title string            `json:"title"`
+	Venue oneOrMany[string] `json:"venue"`
+	Year  string            `json:"year"`
+	Type  string            `json:"type"`
+	Key   string            `json:"key"`
+	DOI   string            `json:"doi"`
+	EE    oneOrMany[string] `json:"ee"`
+	URL   string            `json:"url"`
+}
+
+type author struct {
+	Text string `json:"text"`
+}
+
+// oneOrMany DBLP 在只有一个值时返回对象/字符串，多个值时返回数组
+type oneOrMany[T any] []T
+
+func (o *oneOrMany[T]) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		var many []T
+		if err := json.Unmarshal(data, &many); err != nil {
+			return err
+		}
+		*o = many
+		return nil
+	}
+	var one T
+	if err := json.Unmarshal(data, &one); err != nil {
+		return err
+	}
+	*o = []T{one}
+	return nil
+}
+
+// parseResponse 解析一页结果，返回论文和命中总数
+func parseResponse(body []byte) ([]*models.Paper, int, error) {
+	var raw apiResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, 0, fmt.Errorf("json unmarshal: %w", err)
+	}
+	total, _ := strconv.Atoi(raw.Result.Hits.Total)
+
+	papers := make([]*models.Paper, 0, len(raw.Result.Hits.Hit))
+	for _, hit := range raw.Result.Hits.Hit {
+		if p := toPaper(hit.Info); p != nil {
+			papers = append(papers, p)
+		}
+	}
+	return papers, total, nil
+}
+
+func toPaper(info hitInfo) *models.Paper {
+	title := strings.TrimSuffix(strings.TrimSpace(info.Title), ".")
+	if info.Key == "" || title == "" {
+		return nil
+	}
+
+	authors := make([]string, 0, len(info.Authors.Author))
+	for _, a := range info.Authors.Author {
+		// 同名作者带有 "0001" 之类的消歧编号
+		name := strings.TrimSpace(strings.TrimRight(a.Text, " 0123456789"))
+		if name != "" {
+			authors = append(authors, name)
+		}
+	}
+
+	url := info.URL
+	if len(info.EE) > 0 && info.EE[0] != "" {
+		url = info.EE[0]
+	}
+
+	var comments string
+	if info.DOI != "" {
+		comments = "DOI: " + info.DOI
+	}
+
+	// DBLP 只提供年份，用当年 1 月 1 日表示
+	var published time.Time
+	if year, err := strconv.Atoi(info.Year); err == nil {
+		published = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return &models.Paper{
+		Source:           "dblp",
+		SourceID:         info.Key,
+		URL:              url,
+		Title:            title,
+		Authors:          authors,
+		Categories:       []string(info.Venue),
+		Comments:         comments,
+		FirstSubmittedAt: published,
+		FirstAnnouncedAt: published,
+		UpdatedAt:        time.Now(),
+	}
+}
@@ -0,0 +1,207 @@
+package openreview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// AcceptedQuery 已录用论文查询条件
+type AcceptedQuery struct {
+	VenueID   string   // 会议 ID，如 "NeurIPS.cc/2024/Conference"
+	Decisions []string // 录用类型关键字（匹配 venue 字段，如 oral、spotlight、poster），为空表示不限
+	MinScore  float64  // 评审平均分下限，0 表示不过滤
+	Limit     int      // 最多返回数量
+}
+
+// AcceptedPaper 带录用类型与评审平均分的论文
+type AcceptedPaper struct {
+	Paper    *models.Paper
+	Decision string  // venue 字段，如 "NeurIPS 2024 oral"
+	AvgScore float64 // 评审平均分，无评审时为 0
+}
+
+type acceptedResponse struct {
+	Notes []struct {
+		ID      string `json:"id"`
+		Content struct {
+			Title struct {
+				Value string `json:"value"`
+			} `json:"title"`
+			Authors struct {
+				Value []string `json:"value"`
+			} `json:"authors"`
+			Abstract struct {
+				Value string `json:"value"`
+			} `json:"abstract"`
+			Keywords struct {
+				Value []string `json:"value"`
+			} `json:"keywords"`
+			Venue struct {
+				Value string `json:"value"`
+			} `json:"venue"`
+		} `json:"content"`
+		Details struct {
+			DirectReplies []struct {
+				Content map[string]struct {
+					Value json.RawMessage `json:"value"`
+				} `json:"content"`
+			} `json:"directReplies"`
+		} `json:"details"`
+	} `json:"notes"`
+}
+
+// ratingFields 不同会议评审意见中表示总体评分的字段
+var ratingFields = []string{"rating", "recommendation", "overall_assessment", "overall_rating"}
+
+// FetchAccepted 获取某会议已录用的论文，按录用类型与评审平均分过滤，结果按平均分降序
+// OpenReview 中录用论文的 content.venueid 即会议 ID，被拒稿件为 .../Rejected_Submission
+func (a *Adapter) FetchAccepted(ctx context.Context, q AcceptedQuery) ([]*AcceptedPaper, error) {
+	venueID := strings.TrimSuffix(strings.TrimSpace(q.VenueID), "/Submission")
+	if venueID == "" {
+		return nil, fmt.Errorf("venue_id 不能为空")
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	const pageSize = 100
+	var accepted []*AcceptedPaper
+	offset := 0
+	for {
+		params := url.Values{}
+		params.Add("content.venueid", venueID)
+		params.Add("details", "directReplies")
+		params.Add("limit", strconv.Itoa(pageSize))
+		params.Add("offset", strconv.Itoa(offset))
+
+		logger.Debug("[OpenReview] 获取录用论文: venue=%s, offset=%d", venueID, offset)
+		body, err := a.request(ctx, a.config.APIBase+"/notes?"+params.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		papers, err := parseAccepted(body)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range papers {
+			if matchDecision(p.Decision, q.Decisions) && (q.MinScore <= 0 || p.AvgScore >= q.MinScore) {
+				accepted = append(accepted, p)
+			}
+		}
+
+		if len(papers) < pageSize {
+			break
+		}
+		offset += len(papers)
+
+		select {
+		case <-time.After(1 * time.Second):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].AvgScore > accepted[j].AvgScore
+	})
+	if len(accepted) > limit {
+		accepted = accepted[:limit]
+	}
+
+	logger.Info("[OpenReview] %s 符合条件的录用论文 %d 篇", venueID, len(accepted))
+	return accepted, nil
+}
+
+func parseAccepted(body string) ([]*AcceptedPaper, error) {
+	var raw acceptedResponse
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+
+	papers := make([]*AcceptedPaper, 0, len(raw.Notes))
+	for _, note := range raw.Notes {
+		var sum float64
+		var count int
+		for _, reply := range note.Details.DirectReplies {
+			for _, field := range ratingFields {
+				if v, ok := reply.Content[field]; ok {
+					if score, ok := parseScore(v.Value); ok {
+						sum += score
+						count++
+					}
+					break
+				}
+			}
+		}
+
+		avg := 0.0
+		if count > 0 {
+			avg = sum / float64(count)
+		}
+
+		papers = append(papers, &AcceptedPaper{
+			Paper: &models.Paper{
+				Source:     "openreview",
+				SourceID:   note.ID,
+				URL:        fmt.Sprintf("https://openreview.net/forum?id=%s", note.ID),
+				Title:      note.Content.Title.Value,
+				Authors:    note.Content.Authors.Value,
+				Abstract:   note.Content.Abstract.Value,
+				Categories: note.Content.Keywords.Value,
+				Comments:   note.Content.Venue.Value,
+			},
+			Decision: note.Content.Venue.Value,
+			AvgScore: avg,
+		})
+	}
+	return papers, nil
+}
+
+// parseScore 解析评分，兼容数字与 "8: accept, good paper" 形式的字符串；null 视为没有评分
+func parseScore(raw json.RawMessage) (float64, bool) {
+	var num *float64
+	if err := json.Unmarshal(raw, &num); err == nil {
+		if num == nil {
+			return 0, false
+		}
+		return *num, true
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return 0, false
+	}
+	text = strings.TrimSpace(text)
+	if i := strings.IndexAny(text, ": "); i > 0 {
+		text = text[:i]
+	}
+	score, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, false
+	}
+	return score, true
+}
+
+// matchDecision 录用类型关键字不区分大小写匹配 venue 字段
+func matchDecision(venue string, decisions []string) bool {
+	if len(decisions) == 0 {
+		return true
+	}
+	venue = strings.ToLower(venue)
+	for _, d := range decisions {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" && strings.Contains(venue, d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package openreview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// acceptedNotes 录用论文列表，省略了与解析无关的字段；评分兼容数字与 "8: accept" 形式
+const acceptedNotes = `{"notes": [
+  {
+    "id": "poster1",
+    "content": {
+      "title": {"value": "Poster Paper"},
+      "authors": {"value": ["Bob"]},
+      "venue": {"value": "ICLR 2024 poster"}
+    },
+    "details": {"directReplies": [
+      {"content": {"recommendation": {"value": 5}}},
+      {"content": {"rating": {"value": "not a score"}}},
+      {"content": {"comment": {"value": "Thanks for the feedback."}}}
+    ]}
+  },
+  {
+    "id": "oral1",
+    "content": {
+      "title": {"value": "Oral Paper"},
+      "authors": {"value": ["Alice", "Carol"]},
+      "abstract": {"value": "An oral paper."},
+      "keywords": {"value": ["graphs"]},
+      "venue": {"value": "ICLR 2024 oral"}
+    },
+    "details": {"directReplies": [
+      {"content": {"rating": {"value": "8: accept, good paper"}, "confidence": {"value": 4}}},
+      {"content": {"rating": {"value": 6}}},
+      {"content": {"overall_rating": {"value": null}}}
+    ]}
+  },
+  {
+    "id": "spot1",
+    "content": {
+      "title": {"value": "Spotlight Paper"},
+      "venue": {"value": "ICLR 2024 Spotlight"}
+    },
+    "details": {"directReplies": []}
+  }
+]}`
+
+func newAcceptedServer(t *testing.T) (*Adapter, *url.Values) {
+	t.Helper()
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if r.URL.Path != "/notes" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(acceptedNotes))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.APIBase = srv.URL
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	return a, &query
+}
+
+func TestFetchAccepted(t *testing.T) {
+	a, query := newAcceptedServer(t)
+
+	papers, err := a.FetchAccepted(context.Background(), AcceptedQuery{VenueID: " ICLR.cc/2024/Conference/Submission "})
+	if err != nil {
+		t.Fatalf("FetchAccepted() error: %v", err)
+	}
+	if got := query.Get("content.venueid"); got != "ICLR.cc/2024/Conference" {
+		t.Errorf("content.venueid = %q, want the venue without /Submission", got)
+	}
+	if got := query.Get("details"); got != "directReplies" {
+		t.Errorf("details = %q, want directReplies", got)
+	}
+
+	// 按平均分降序：oral (8+6)/2，poster 只有一个可解析的评分，spotlight 无评审
+	want := []struct {
+		id    string
+		score float64
+	}{{"oral1", 7}, {"poster1", 5}, {"spot1", 0}}
+	if len(papers) != len(want) {
+		t.Fatalf("Expected %d papers, got %d", len(want), len(papers))
+	}
+	for i, w := range want {
+		if papers[i].Paper.SourceID != w.id || papers[i].AvgScore != w.score {
+			t.Errorf("papers[%d] = %s (%.2f), want %s (%.2f)", i, papers[i].Paper.SourceID, papers[i].AvgScore, w.id, w.score)
+		}
+	}
+
+	oral := papers[0]
+	if oral.Decision != "ICLR 2024 oral" || oral.Paper.Comments != oral.Decision {
+		t.Errorf("Expected decision from venue, got %q / %q", oral.Decision, oral.Paper.Comments)
+	}
+	p := oral.Paper
+	if p.Source != "openreview" || p.URL != "https://openreview.net/forum?id=oral1" || p.Title != "Oral Paper" ||
+		strings.Join(p.Authors, ",") != "Alice,Carol" || p.Abstract != "An oral paper." || strings.Join(p.Categories, ",") != "graphs" {
+		t.Errorf("Unexpected paper: %+v", p)
+	}
+}
+
+func TestFetchAccepted_Filters(t *testing.T) {
+	a, _ := newAcceptedServer(t)
+
+	tests := []struct {
+		query AcceptedQuery
+		want  string
+	}{
+		{AcceptedQuery{Decisions: []string{"Oral", " spotlight "}}, "oral1,spot1"},
+		{AcceptedQuery{MinScore: 5}, "oral1,poster1"},
+		{AcceptedQuery{Decisions: []string{"poster"}, MinScore: 6}, ""},
+		{AcceptedQuery{Limit: 1}, "oral1"},
+	}
+	for _, tt := range tests {
+		q := tt.query
+		q.VenueID = "ICLR.cc/2024/Conference"
+		papers, err := a.FetchAccepted(context.Background(), q)
+		if err != nil {
+			t.Fatalf("FetchAccepted(%+v) error: %v", tt.query, err)
+		}
+		var got []string
+		for _, p := range papers {
+			got = append(got, p.Paper.SourceID)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("FetchAccepted(%+v) = %v, want %s", tt.query, got, tt.want)
+		}
+	}
+
+	if _, err := a.FetchAccepted(context.Background(), AcceptedQuery{VenueID: " /Submission"}); err == nil {
+		t.Error("Expected error for an empty venue ID")
+	}
+}
+
+func TestParseAccepted_InvalidJSON(t *testing.T) {
+	if _, err := parseAccepted("not json"); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestParseScore(t *testing.T) {
+	tests := []struct {
+		raw   string
+		want  float64
+		valid bool
+	}{
+		{`8`, 8, true},
+		{`7.5`, 7.5, true},
+		{`"8: accept, good paper"`, 8, true},
+		{`"6 marginally above the acceptance threshold"`, 6, true},
+		{`" 3 "`, 3, true},
+		{`"5"`, 5, true},
+		{`"accept"`, 0, false},
+		{`""`, 0, false},
+		{`null`, 0, false},
+		{`{"score": 8}`, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseScore(json.RawMessage(tt.raw))
+		if ok != tt.valid || got != tt.want {
+			t.Errorf("parseScore(%s) = %v, %v; want %v, %v", tt.raw, got, ok, tt.want, tt.valid)
+		}
+	}
+}
+
+func TestMatchDecision(t *testing.T) {
+	tests := []struct {
+		venue     string
+		decisions []string
+		want      bool
+	}{
+		{"ICLR 2024 oral", nil, true},
+		{"ICLR 2024 oral", []string{"ORAL"}, true},
+		{"ICLR 2024 Spotlight", []string{"poster", "spotlight"}, true},
+		{"ICLR 2024 poster", []string{"oral"}, false},
+		{"ICLR 2024 poster", []string{" ", ""}, false},
+		{"", []string{"oral"}, false},
+	}
+	for _, tt := range tests {
+		if got := matchDecision(tt.venue, tt.decisions); got != tt.want {
+			t.Errorf("matchDecision(%q, %v) = %v, want %v", tt.venue, tt.decisions, got, tt.want)
+		}
+	}
+}
@@ -6,17 +6,21 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
-	"PaperHunter/internal/core"
 	"PaperHunter/internal/models"
 	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/httpclient"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/proxy"
+	"PaperHunter/pkg/ratelimit"
 )
 
 type Adapter struct {
 	config     *Config
 	httpClient *http.Client
+	limiter    ratelimit.Limiter
 }
 
 func NewAdapter(config *Config) (*Adapter, error) {
@@ -27,14 +31,20 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	client := core.NewHTTPClient(config.Timeout, config.Proxy)
-	return &Adapter{config: config, httpClient: client}, nil
+	client := httpclient.New(time.Duration(config.Timeout)*time.Second, proxy.NewRotator([]string{config.Proxy}),
+		httpclient.WithInsecureSkipVerify(config.InsecureSkipVerify),
+		httpclient.WithDialTimeout(config.DialTimeout),
+	)
+	return &Adapter{config: config, httpClient: client, limiter: ratelimit.For("openreview", config.RateLimit())}, nil
 }
 
 func (a *Adapter) Name() string { return "openreview" }
 
 func (a *Adapter) GetConfig() platform.Config { return a.config }
 
+// SetLimiter 替换请求限速器
+func (a *Adapter) SetLimiter(l ratelimit.Limiter) { a.limiter = l }
+
 // Search 实现 Platform 接口
 func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
 	// OpenReview 使用 venue_id 而非通用 categories
@@ -43,6 +53,9 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 		return platform.Result{}, fmt.Errorf("openreview requires venue_id in categories")
 	}
 	venueID := q.Categories[0] // 如 "ICLR.cc/2026/Conference/Submission"
+	if err := q.ValidateSort(); err != nil {
+		return platform.Result{}, err
+	}
 
 	var allPapers []*models.Paper
 	offset := q.Offset
@@ -51,9 +64,27 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 		userLimit = 1000 // 默认最多获取 1000 篇
 	}
 
+	// 设置了评审过滤时需要带上直接回复（评审与决定），并按整页请求以弥补被过滤掉的论文
+	filterReviews := q.MinRating > 0 || q.Decision != ""
+	details := "replyCount,invitation"
+	if filterReviews {
+		details += ",directReplies"
+		logger.Info("[OpenReview] 按评审过滤: 平均分 >= %.2f, 决定包含 %q", q.MinRating, q.Decision)
+	}
+
+	// 增量爬取：早于起始日期的论文丢弃；按投稿时间或编号降序时遇到这样的论文即可停止分页
+	var dateFrom time.Time
+	if from := q.EffectiveDateFrom(); from != "" {
+		if t, err := time.Parse("2006-01-02", from); err == nil {
+			dateFrom = t
+			logger.Info("[OpenReview] 只获取 %s 及之后投稿的论文", from)
+		}
+	}
+	newestFirst := !q.Ascending() && q.SortBy != platform.SortByLastUpdatedDate
+
 	// 每次分页请求的数量（API 限制）
 	pageSize := 100
-	if userLimit < pageSize {
+	if userLimit < pageSize && !filterReviews {
 		pageSize = userLimit
 	}
 
@@ -61,16 +92,16 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 
 		remaining := userLimit - len(allPapers)
 		currentLimit := pageSize
-		if remaining < currentLimit {
+		if remaining < currentLimit && !filterReviews {
 			currentLimit = remaining
 		}
 
 		params := url.Values{}
 		params.Add("content.venueid", venueID)
-		params.Add("details", "replyCount,invitation")
+		params.Add("details", details)
 		params.Add("limit", fmt.Sprintf("%d", currentLimit))
 		params.Add("offset", fmt.Sprintf("%d", offset))
-		params.Add("sort", "number:desc")
+		params.Add("sort", apiSort(q))
 
 		apiURL := a.config.APIBase + "/notes?" + params.Encode()
 		logger.Debug("[OpenReview] 请求 API: offset=%d, limit=%d", offset, currentLimit)
@@ -79,22 +110,36 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 			return platform.Result{}, err
 		}
 
-		resp, err := parseResponse(body)
+		notes, summaries, err := parseNotes(body)
 		if err != nil {
 			return platform.Result{}, err
 		}
 
-		if len(resp.Notes) == 0 {
+		if len(notes) == 0 {
 			logger.Debug("[OpenReview] 无更多论文，停止分页")
 			break
 		}
 
-		logger.Debug("[OpenReview] 本次获取 %d 篇论文", len(resp.Notes))
-		allPapers = append(allPapers, resp.Notes...)
-		offset += len(resp.Notes)
+		logger.Debug("[OpenReview] 本次获取 %d 篇论文", len(notes))
+		tooOld := false
+		for i, p := range notes {
+			if !dateFrom.IsZero() && p.FirstSubmittedAt.Before(dateFrom) {
+				tooOld = newestFirst
+				continue
+			}
+			if !filterReviews || matchReviewFilter(summaries[i], q) {
+				allPapers = append(allPapers, p)
+			}
+		}
+		offset += len(notes)
+
+		if tooOld {
+			logger.Debug("[OpenReview] 论文已早于起始日期，停止分页")
+			break
+		}
 
 		// 如果返回数量少于请求数量，说明已无更多
-		if len(resp.Notes) < currentLimit {
+		if len(notes) < currentLimit {
 			logger.Debug("[OpenReview] 已到最后一页")
 			break
 		}
@@ -118,6 +163,7 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 	if len(allPapers) > userLimit {
 		allPapers = allPapers[:userLimit]
 	}
+	sortPapers(allPapers, q)
 
 	return platform.Result{
 		Total:  len(allPapers),
@@ -145,6 +191,9 @@ func (a *Adapter) request(ctx context.Context, apiURL string) (string, error) {
 		}
 		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/142.0.0.0 Safari/537.36")
 
+		if err := a.limiter.Wait(ctx); err != nil {
+			return "", err
+		}
 		resp, err := a.httpClient.Do(req)
 		if err != nil {
 			lastErr = err
@@ -157,6 +206,7 @@ func (a *Adapter) request(ctx context.Context, apiURL string) (string, error) {
 
 		// 429 Too Many Requests - 重试
 		if resp.StatusCode == 429 {
+			resp.Body.Close()
 			logger.Debug("[OpenReview] 收到 429 频率限制，尝试=%d", attempt+1)
 			lastErr = fmt.Errorf("rate limited (429)")
 			if attempt < 4 {
@@ -178,3 +228,37 @@ func (a *Adapter) request(ctx context.Context, apiURL string) (string, error) {
 	}
 	return "", lastErr
 }
+
+// apiSort API 的 sort 参数：按日期排序时用 cdate/tmdate，relevance 与默认一样按投稿编号（最新在前）
+func apiSort(q platform.Query) string {
+	field := "number"
+	switch q.SortBy {
+	case platform.SortBySubmittedDate:
+		field = "cdate"
+	case platform.SortByLastUpdatedDate:
+		field = "tmdate"
+	}
+	if q.Ascending() {
+		return field + ":asc"
+	}
+	return field + ":desc"
+}
+
+// sortPapers 按日期排序时在本地再排一次，兼容忽略 sort 参数的 API（如 v1）；其余情况保持 API 返回顺序
+func sortPapers(papers []*models.Paper, q platform.Query) {
+	var key func(p *models.Paper) time.Time
+	switch q.SortBy {
+	case platform.SortBySubmittedDate:
+		key = func(p *models.Paper) time.Time { return p.FirstSubmittedAt }
+	case platform.SortByLastUpdatedDate:
+		key = func(p *models.Paper) time.Time { return p.UpdatedAt }
+	default:
+		return
+	}
+	sort.SliceStable(papers, func(i, j int) bool {
+		if q.Ascending() {
+			return key(papers[i]).Before(key(papers[j]))
+		}
+		return key(papers[i]).After(key(papers[j]))
+	})
+}
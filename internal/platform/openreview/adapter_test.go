@@ -0,0 +1,220 @@
+package openreview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/platform"
+)
+
+// submissionsWithReplies api2.openreview.net/notes?content.venueid=&details=directReplies 的精简样例
+const submissionsWithReplies = `{"notes": [
+  {
+    "id": "strong1",
+    "content": {
+      "title": {"value": "Strong Paper"},
+      "authors": {"value": ["Alice Smith"]},
+      "abstract": {"value": "Strong results."},
+      "keywords": {"value": ["graphs"]}
+    },
+    "details": {"directReplies": [
+      {"invitations": ["ICLR.cc/2024/Conference/Submission1/-/Official_Review"], "content": {"rating": {"value": "8: accept, good paper"}, "confidence": {"value": 4}}},
+      {"invitations": ["ICLR.cc/2024/Conference/Submission1/-/Official_Review"], "content": {"rating": {"value": 6}}},
+      {"invitations": ["ICLR.cc/2024/Conference/Submission1/-/Meta_Review"], "content": {"recommendation": {"value": 1}}},
+      {"invitations": ["ICLR.cc/2024/Conference/Submission1/-/Decision"], "content": {"decision": {"value": "Accept (oral)"}}}
+    ]}
+  },
+  {
+    "id": "weak2",
+    "content": {"title": {"value": "Weak Paper"}},
+    "details": {"directReplies": [
+      {"invitations": ["ICLR.cc/2024/Conference/Submission2/-/Official_Review"], "content": {"rating": {"value": "3: reject"}}},
+      {"invitations": ["ICLR.cc/2024/Conference/Submission2/-/Decision"], "content": {"decision": {"value": "Reject"}}}
+    ]}
+  },
+  {
+    "id": "pending3",
+    "content": {"title": {"value": "Pending Paper"}},
+    "details": {"directReplies": []}
+  }
+]}`
+
+func newSubmissionsServer(t *testing.T) (*Adapter, *string) {
+	t.Helper()
+	var details string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		details = r.URL.Query().Get("details")
+		w.Write([]byte(submissionsWithReplies))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.APIBase = srv.URL
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	return a, &details
+}
+
+func TestParseNotes_ReviewSummary(t *testing.T) {
+	papers, summaries, err := parseNotes(submissionsWithReplies)
+	if err != nil {
+		t.Fatalf("parseNotes() error: %v", err)
+	}
+	if len(papers) != 3 || len(summaries) != 3 {
+		t.Fatalf("Expected 3 papers, got %d", len(papers))
+	}
+	// 元评审不计入平均分
+	if s := summaries[0]; s.Reviews != 2 || s.AvgRating != 7 || s.Decision != "Accept (oral)" {
+		t.Errorf("Unexpected summary: %+v", s)
+	}
+	if papers[0].Comments != "Avg rating: 7.00 (2 reviews)" || papers[0].Decision != "Accept (oral)" {
+		t.Errorf("Expected rating in Comments and decision stored, got %q / %q", papers[0].Comments, papers[0].Decision)
+	}
+	if papers[2].Comments != "" || papers[2].Decision != "" {
+		t.Errorf("Expected no rating for pending paper, got %+v", papers[2])
+	}
+}
+
+func TestSearch_ReviewFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query platform.Query
+		want  []string
+	}{
+		{"no filter", platform.Query{}, []string{"strong1", "weak2", "pending3"}},
+		{"min rating", platform.Query{MinRating: 6}, []string{"strong1"}},
+		{"decision", platform.Query{Decision: "reject"}, []string{"weak2"}},
+		{"rating and decision", platform.Query{MinRating: 2, Decision: "accept"}, []string{"strong1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, details := newSubmissionsServer(t)
+			q := tt.query
+			q.Categories = []string{"ICLR.cc/2024/Conference"}
+			q.Limit = 3
+
+			res, err := a.Search(context.Background(), q)
+			if err != nil {
+				t.Fatalf("Search() error: %v", err)
+			}
+			var got []string
+			for _, p := range res.Papers {
+				got = append(got, p.SourceID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Papers = %v, want %v", got, tt.want)
+			}
+			filtered := q.MinRating > 0 || q.Decision != ""
+			if strings.Contains(*details, "directReplies") != filtered {
+				t.Errorf("details = %q, directReplies expected only when filtering", *details)
+			}
+		})
+	}
+}
+
+func TestSearch_SortByDate(t *testing.T) {
+	var sortParam string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sortParam = r.URL.Query().Get("sort")
+		// 模拟忽略 sort 参数的 API，按投稿编号返回
+		w.Write([]byte(`{"notes": [
+  {"id": "b", "number": 3, "cdate": 1700000200000, "tmdate": 1700000900000, "content": {"title": {"value": "B"}}},
+  {"id": "c", "number": 2, "cdate": 1700000300000, "tmdate": 1700000800000, "content": {"title": {"value": "C"}}},
+  {"id": "a", "number": 1, "cdate": 1700000100000, "tmdate": 1700000700000, "content": {"title": {"value": "A"}}}
+]}`))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.APIBase = srv.URL
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	tests := []struct {
+		query platform.Query
+		sort  string
+		want  string
+	}{
+		{platform.Query{}, "number:desc", "b,c,a"},
+		{platform.Query{SortBy: platform.SortBySubmittedDate, SortOrder: platform.SortAsc}, "cdate:asc", "a,b,c"},
+		{platform.Query{SortBy: platform.SortBySubmittedDate}, "cdate:desc", "c,b,a"},
+		{platform.Query{SortBy: platform.SortByLastUpdatedDate}, "tmdate:desc", "b,c,a"},
+	}
+	for _, tt := range tests {
+		q := tt.query
+		q.Categories = []string{"ICLR.cc/2024/Conference"}
+		q.Limit = 3
+		res, err := a.Search(context.Background(), q)
+		if err != nil {
+			t.Fatalf("Search() error: %v", err)
+		}
+		var got []string
+		for _, p := range res.Papers {
+			got = append(got, p.SourceID)
+		}
+		if sortParam != tt.sort || strings.Join(got, ",") != tt.want {
+			t.Errorf("Query %+v: sort=%q papers=%v, want %q %s", tt.query, sortParam, got, tt.sort, tt.want)
+		}
+	}
+
+	// 提交与更新时间取自 cdate/tmdate
+	res, err := a.Search(context.Background(), platform.Query{Categories: []string{"ICLR.cc/2024/Conference"}, Limit: 1})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if p := res.Papers[0]; !p.FirstSubmittedAt.Equal(time.UnixMilli(1700000200000)) || !p.UpdatedAt.Equal(time.UnixMilli(1700000900000)) {
+		t.Errorf("Expected dates from cdate/tmdate, got %v / %v", p.FirstSubmittedAt, p.UpdatedAt)
+	}
+}
+
+func TestSearch_SinceStopsPagination(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// 按投稿编号降序，第一页已出现早于起始日期的论文
+		w.Write([]byte(`{"notes": [
+  {"id": "new", "number": 2, "cdate": 1710028800000, "content": {"title": {"value": "New"}}},
+  {"id": "old", "number": 1, "cdate": 1704067200000, "content": {"title": {"value": "Old"}}}
+]}`))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.APIBase = srv.URL
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+
+	q := platform.Query{Categories: []string{"ICLR.cc/2024/Conference"}, Limit: 2, Since: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+	if got := q.EffectiveDateFrom(); got != "2024-03-01" {
+		t.Errorf("EffectiveDateFrom() = %q, want 2024-03-01", got)
+	}
+	res, err := a.Search(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(res.Papers) != 1 || res.Papers[0].SourceID != "new" {
+		t.Errorf("Expected only the paper after Since, got %+v", res.Papers)
+	}
+	if requests != 1 {
+		t.Errorf("Expected pagination to stop after the first page, got %d requests", requests)
+	}
+
+	// DateFrom 晚于 Since 时以 DateFrom 为准
+	q.DateFrom = "2024-03-15"
+	if got := q.EffectiveDateFrom(); got != "2024-03-15" {
+		t.Errorf("EffectiveDateFrom() = %q, want 2024-03-15", got)
+	}
+}
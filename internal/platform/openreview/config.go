@@ -1,18 +1,29 @@
 package openreview
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Config OpenReview 平台配置
 type Config struct {
 	APIBase string `mapstructure:"api_base" yaml:"api_base"` // API 地址
 	Proxy   string `mapstructure:"proxy" yaml:"proxy"`
 	Timeout int    `mapstructure:"timeout" yaml:"timeout"`
+
+	RateLimitRPS float64 `mapstructure:"rate_limit_rps" yaml:"rate_limit_rps"` // 每秒请求数上限，0 表示不限速
+
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify"` // 跳过 TLS 证书校验，仅用于自签证书的代理
+	DialTimeout        time.Duration `mapstructure:"dial_timeout" yaml:"dial_timeout"`                 // 建立连接超时，如 10s
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		APIBase: "https://api2.openreview.net",
 		Timeout: 30,
+
+		RateLimitRPS: 2,
+		DialTimeout:  30 * time.Second,
 	}
 }
 
@@ -25,5 +36,13 @@ func (c *Config) Validate() error {
 	if c.Timeout <= 0 {
 		return fmt.Errorf("timeout 不能为负")
 	}
+	if c.DialTimeout < 0 {
+		return fmt.Errorf("dial_timeout 不能为负")
+	}
+	if c.RateLimitRPS < 0 {
+		return fmt.Errorf("rate_limit_rps 不能为负")
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (c *Config) RateLimit() float64 { return c.RateLimitRPS }
@@ -6,12 +6,15 @@ import (
 	"time"
 
 	"PaperHunter/internal/models"
+	"PaperHunter/internal/platform"
 )
 
 type APIResponse struct {
 	Notes []struct {
 		ID      string `json:"id"`
 		Number  int    `json:"number"`
+		Cdate   int64  `json:"cdate"`  // 创建时间（毫秒时间戳）
+		Tmdate  int64  `json:"tmdate"` // 最后修改时间（毫秒时间戳）
 		Content struct {
 			Title struct {
 				Value string `json:"value"`
@@ -29,16 +32,37 @@ type APIResponse struct {
 				Value string `json:"value"`
 			} `json:"primary_area"`
 		} `json:"content"`
+		Details struct {
+			DirectReplies []forumNote `json:"directReplies"` // 仅在请求 details=directReplies 时返回
+		} `json:"details"`
 	} `json:"notes"`
 }
 
+// reviewSummary 由论文的直接回复汇总出的评审平均分与录用决定
+type reviewSummary struct {
+	AvgRating float64
+	Reviews   int
+	Decision  string
+}
+
 func parseResponse(body string) (*struct{ Notes []*models.Paper }, error) {
+	papers, _, err := parseNotes(body)
+	if err != nil {
+		return nil, err
+	}
+	return &struct{ Notes []*models.Paper }{Notes: papers}, nil
+}
+
+// parseNotes 解析投稿列表，同时汇总每篇论文直接回复中的评审分与录用决定；
+// 有评分时平均分写入 Comments，决定写入 Decision
+func parseNotes(body string) ([]*models.Paper, []reviewSummary, error) {
 	var raw APIResponse
 	if err := json.Unmarshal([]byte(body), &raw); err != nil {
-		return nil, fmt.Errorf("json unmarshal: %w", err)
+		return nil, nil, fmt.Errorf("json unmarshal: %w", err)
 	}
 
 	papers := make([]*models.Paper, 0, len(raw.Notes))
+	summaries := make([]reviewSummary, 0, len(raw.Notes))
 	for _, note := range raw.Notes {
 		paper := &models.Paper{
 			Source:           "openreview",
@@ -48,12 +72,66 @@ func parseResponse(body string) (*struct{ Notes []*models.Paper }, error) {
 			Authors:          note.Content.Authors.Value,
 			Abstract:         note.Content.Abstract.Value,
 			Categories:       append(note.Content.Keywords.Value, note.Content.PrimaryArea.Value),
-			FirstSubmittedAt: time.Now(), // OpenReview 未提供，用当前时间
-			FirstAnnouncedAt: time.Now(),
-			UpdatedAt:        time.Now(),
+			FirstSubmittedAt: millisOrNow(note.Cdate),
+			FirstAnnouncedAt: millisOrNow(note.Cdate),
+			UpdatedAt:        millisOrNow(note.Tmdate),
 		}
+		summary := summarizeReplies(note.Details.DirectReplies)
+		if summary.Reviews > 0 {
+			paper.Comments = fmt.Sprintf("Avg rating: %.2f (%d reviews)", summary.AvgRating, summary.Reviews)
+		}
+		paper.Decision = summary.Decision
 		papers = append(papers, paper)
+		summaries = append(summaries, summary)
 	}
 
-	return &struct{ Notes []*models.Paper }{Notes: papers}, nil
+	return papers, summaries, nil
+}
+
+// millisOrNow 将毫秒时间戳转为时间，响应中没有时间字段时用当前时间
+func millisOrNow(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Now()
+	}
+	return time.UnixMilli(ms)
+}
+
+// summarizeReplies 官方评审取总体评分求平均，决定取 decision 字段；
+// 回复不带 invitation 信息时（部分 API v1 响应）按是否有评分字段判断
+func summarizeReplies(replies []forumNote) reviewSummary {
+	var s reviewSummary
+	var sum float64
+	for _, reply := range replies {
+		if decision := reply.text("decision"); decision != "" {
+			s.Decision = decision
+			continue
+		}
+		if (reply.Invitation != "" || len(reply.Invitations) > 0) && !reply.hasInvitation("", reviewInvitationSuffix) {
+			continue
+		}
+		for _, field := range ratingFields {
+			if v, ok := reply.Content[field]; ok {
+				if score, ok := parseScore(v.Value); ok {
+					sum += score
+					s.Reviews++
+				}
+				break
+			}
+		}
+	}
+	if s.Reviews > 0 {
+		s.AvgRating = sum / float64(s.Reviews)
+	}
+	return s
+}
+
+// matchReviewFilter 判断论文是否满足查询中的评审分与录用决定条件
+func matchReviewFilter(s reviewSummary, q platform.Query) bool {
+	if q.MinRating > 0 && (s.Reviews == 0 || s.AvgRating < q.MinRating) {
+		return false
+	}
+	if q.Decision != "" && (s.Decision == "" || !matchDecision(s.Decision, []string{q.Decision})) {
+		return false
+	}
+	return true
 }
@@ -0,0 +1,163 @@
+package openreview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	"PaperHunter/pkg/logger"
+)
+
+const (
+	reviewInvitationSuffix   = "/-/Official_Review"
+	decisionInvitationSuffix = "/-/Decision"
+)
+
+// Review OpenReview 评审意见
+type Review struct {
+	Reviewer   string         // 评审签名，如 "Reviewer_AbCd"
+	Scores     map[string]int // 所有评分字段，如 rating、confidence、soundness
+	Summary    string
+	Strengths  string
+	Weaknesses string
+	Confidence int
+	Rating     int
+}
+
+// forumResponse GET /notes?forum= 的响应，同时兼容 API v1 的 invitation 与 v2 的 invitations
+type forumResponse struct {
+	Notes []forumNote `json:"notes"`
+}
+
+type forumNote struct {
+	ID          string   `json:"id"`
+	Invitation  string   `json:"invitation"`
+	Invitations []string `json:"invitations"`
+	Signatures  []string `json:"signatures"`
+	Content     map[string]struct {
+		Value json.RawMessage `json:"value"`
+	} `json:"content"`
+}
+
+// reviewTextFields 评审中的长文本字段，不参与评分解析（如 "3 issues ..." 会被误判为分数）
+var reviewTextFields = map[string]bool{
+	"title": true, "summary": true, "strengths": true, "weaknesses": true,
+	"questions": true, "limitations": true, "comment": true, "review": true,
+	"strengths_and_weaknesses": true, "summary_of_the_paper": true, "main_review": true,
+}
+
+// FetchReviews 获取论文（forum ID）下的全部官方评审意见
+func (a *Adapter) FetchReviews(ctx context.Context, paperID string) ([]Review, error) {
+	notes, err := a.fetchForumNotes(ctx, paperID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []Review
+	for _, note := range notes {
+		if note.hasInvitation("", reviewInvitationSuffix) {
+			reviews = append(reviews, parseReview(note))
+		}
+	}
+	logger.Debug("[OpenReview] %s 共 %d 条评审意见", paperID, len(reviews))
+	return reviews, nil
+}
+
+// FetchDecision 获取论文的录用决定（如 "Accept (Oral)"），尚未公布时返回空字符串；
+// venue 非空时只接受该会议下发布的决定
+func (a *Adapter) FetchDecision(ctx context.Context, venue, paperID string) (string, error) {
+	notes, err := a.fetchForumNotes(ctx, paperID)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(venue), "/"), "/Submission")
+	for _, note := range notes {
+		if !note.hasInvitation(prefix, decisionInvitationSuffix) {
+			continue
+		}
+		if decision := note.text("decision"); decision != "" {
+			return decision, nil
+		}
+	}
+	return "", nil
+}
+
+// fetchForumNotes 读取论文讨论区的全部 note；
+// API v2 不支持 invitation 通配，评审与决定均按 invitation 后缀在本地筛选
+func (a *Adapter) fetchForumNotes(ctx context.Context, paperID string) ([]forumNote, error) {
+	paperID = strings.TrimSpace(paperID)
+	if paperID == "" {
+		return nil, fmt.Errorf("论文 ID 不能为空")
+	}
+
+	params := url.Values{}
+	params.Add("forum", paperID)
+	body, err := a.request(ctx, a.config.APIBase+"/notes?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp forumResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+	return resp.Notes, nil
+}
+
+// hasInvitation 判断 note 的 invitation 是否以 prefix 开头、suffix 结尾
+func (n forumNote) hasInvitation(prefix, suffix string) bool {
+	for _, inv := range append([]string{n.Invitation}, n.Invitations...) {
+		if strings.HasSuffix(inv, suffix) && strings.HasPrefix(inv, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// text 返回字符串类型字段的值，字段不存在或不是字符串时为空
+func (n forumNote) text(field string) string {
+	v, ok := n.Content[field]
+	if !ok {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(v.Value, &s); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+func parseReview(note forumNote) Review {
+	r := Review{
+		Summary:    note.text("summary"),
+		Strengths:  note.text("strengths"),
+		Weaknesses: note.text("weaknesses"),
+		Scores:     make(map[string]int),
+	}
+	if len(note.Signatures) > 0 {
+		sig := note.Signatures[0]
+		r.Reviewer = sig[strings.LastIndex(sig, "/")+1:]
+	}
+
+	for field, v := range note.Content {
+		if reviewTextFields[field] {
+			continue
+		}
+		if score, ok := parseScore(v.Value); ok {
+			r.Scores[field] = int(math.Round(score))
+		}
+	}
+
+	r.Confidence = r.Scores["confidence"]
+	for _, field := range ratingFields {
+		if score, ok := r.Scores[field]; ok {
+			r.Rating = score
+			break
+		}
+	}
+	return r
+}
@@ -0,0 +1,156 @@
+package openreview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// forumWithDecision 录制自 api2.openreview.net/notes?forum=，省略了与解析无关的字段
+const forumWithDecision = `{"notes": [
+  {
+    "id": "dec1",
+    "forum": "abc123",
+    "invitations": ["ICLR.cc/2024/Conference/Submission42/-/Decision", "ICLR.cc/2024/Conference/-/Edit"],
+    "signatures": ["ICLR.cc/2024/Conference/Program_Chairs"],
+    "content": {
+      "title": {"value": "Paper Decision"},
+      "decision": {"value": "Accept (oral)"},
+      "comment": {"value": "The reviewers agree this is a strong paper."}
+    }
+  },
+  {
+    "id": "rev1",
+    "forum": "abc123",
+    "invitations": ["ICLR.cc/2024/Conference/Submission42/-/Official_Review", "ICLR.cc/2024/Conference/-/Edit"],
+    "signatures": ["ICLR.cc/2024/Conference/Submission42/Reviewer_AbCd"],
+    "content": {
+      "summary": {"value": "3 new benchmarks are proposed."},
+      "soundness": {"value": "3 good"},
+      "presentation": {"value": "4 excellent"},
+      "strengths": {"value": "Clear writing."},
+      "weaknesses": {"value": "Limited ablations."},
+      "rating": {"value": "8: accept, good paper"},
+      "confidence": {"value": "4: You are confident in your assessment, but not absolutely certain."},
+      "code_of_conduct": {"value": "Yes"}
+    }
+  },
+  {
+    "id": "rev2",
+    "forum": "abc123",
+    "invitations": ["ICLR.cc/2024/Conference/Submission42/-/Official_Review"],
+    "signatures": ["ICLR.cc/2024/Conference/Submission42/Reviewer_XyZw"],
+    "content": {
+      "summary": {"value": "An incremental method."},
+      "rating": {"value": 6},
+      "confidence": {"value": 3}
+    }
+  },
+  {
+    "id": "cmt1",
+    "forum": "abc123",
+    "invitations": ["ICLR.cc/2024/Conference/Submission42/-/Official_Comment"],
+    "signatures": ["ICLR.cc/2024/Conference/Submission42/Authors"],
+    "content": {"comment": {"value": "Thanks for the feedback."}}
+  }
+]}`
+
+// forumWithoutDecision 决定尚未公布的论文，只有投稿本身
+const forumWithoutDecision = `{"notes": [
+  {
+    "id": "pending1",
+    "forum": "pending1",
+    "invitations": ["ICLR.cc/2025/Conference/-/Submission"],
+    "content": {"title": {"value": "Pending Paper"}}
+  }
+]}`
+
+func newForumServer(t *testing.T, forums map[string]string) *Adapter {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := forums[r.URL.Query().Get("forum")]
+		if r.URL.Path != "/notes" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.APIBase = srv.URL
+	cfg.RateLimitRPS = 0
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	return a
+}
+
+func TestFetchReviews(t *testing.T) {
+	a := newForumServer(t, map[string]string{"abc123": forumWithDecision})
+
+	reviews, err := a.FetchReviews(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("FetchReviews() error: %v", err)
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("Expected 2 official reviews, got %d", len(reviews))
+	}
+
+	r := reviews[0]
+	if r.Reviewer != "Reviewer_AbCd" || r.Rating != 8 || r.Confidence != 4 {
+		t.Errorf("Unexpected review header: %+v", r)
+	}
+	if r.Summary != "3 new benchmarks are proposed." || r.Strengths != "Clear writing." || r.Weaknesses != "Limited ablations." {
+		t.Errorf("Unexpected review text: %+v", r)
+	}
+	want := map[string]int{"soundness": 3, "presentation": 4, "rating": 8, "confidence": 4}
+	if len(r.Scores) != len(want) {
+		t.Errorf("Scores = %v, want %v", r.Scores, want)
+	}
+	for k, v := range want {
+		if r.Scores[k] != v {
+			t.Errorf("Scores[%q] = %d, want %d", k, r.Scores[k], v)
+		}
+	}
+
+	if reviews[1].Rating != 6 || reviews[1].Confidence != 3 {
+		t.Errorf("Expected numeric scores to be parsed, got %+v", reviews[1])
+	}
+}
+
+func TestFetchDecision(t *testing.T) {
+	a := newForumServer(t, map[string]string{
+		"abc123":   forumWithDecision,
+		"pending1": forumWithoutDecision,
+	})
+
+	tests := []struct {
+		name    string
+		venue   string
+		paperID string
+		want    string
+	}{
+		{"accepted", "", "abc123", "Accept (oral)"},
+		{"matching venue", "ICLR.cc/2024/Conference/Submission", "abc123", "Accept (oral)"},
+		{"other venue", "NeurIPS.cc/2024/Conference", "abc123", ""},
+		{"no decision yet", "ICLR.cc/2025/Conference", "pending1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.FetchDecision(context.Background(), tt.venue, tt.paperID)
+			if err != nil {
+				t.Fatalf("FetchDecision() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FetchDecision() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := a.FetchDecision(context.Background(), "", " "); err == nil {
+		t.Error("Expected error for empty paper ID")
+	}
+}
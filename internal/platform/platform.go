@@ -2,8 +2,11 @@ package platform
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"PaperHunter/internal/models"
+	"PaperHunter/pkg/ratelimit"
 )
 
 // Query 平台查询参数（统一接口）, 这里是针对爬虫的逻辑, 也是 cli 对应配置
@@ -14,6 +17,61 @@ type Query struct {
 	DateTo     string // YYYY-MM-DD
 	Limit      int
 	Offset     int
+
+	// 以下评审过滤条件仅 OpenReview 生效，其它平台忽略
+	MinRating float64 // 评审平均分下限，0 表示不过滤；没有评分的论文会被过滤掉
+	Decision  string  // 录用决定关键字（不区分大小写匹配，如 "accept"、"oral"），为空表示不过滤
+
+	// 排序方式，为空时使用各平台默认顺序（最新在前）
+	SortBy    string // submittedDate、lastUpdatedDate、relevance
+	SortOrder string // asc、desc，为空表示 desc
+
+	// Since 增量爬取：只获取该时间（按天）及之后公布的论文，零值表示不限；
+	// 由 arXiv/ACL/OpenReview 换算为实际的起始日期，见 EffectiveDateFrom
+	Since time.Time
+	// SinceLast 本次为增量爬取（since_last），只有这类爬取会推进平台的爬取进度；
+	// 首次增量爬取时还没有进度，Since 为零值
+	SinceLast bool
+}
+
+// Query.SortBy 与 Query.SortOrder 的取值
+const (
+	SortBySubmittedDate   = "submittedDate"
+	SortByLastUpdatedDate = "lastUpdatedDate"
+	SortByRelevance       = "relevance"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// ValidateSort 检查排序参数，空值表示使用默认值
+func (q Query) ValidateSort() error {
+	switch q.SortBy {
+	case "", SortBySubmittedDate, SortByLastUpdatedDate, SortByRelevance:
+	default:
+		return fmt.Errorf("unsupported sort_by: %s", q.SortBy)
+	}
+	switch q.SortOrder {
+	case "", SortAsc, SortDesc:
+	default:
+		return fmt.Errorf("unsupported sort_order: %s", q.SortOrder)
+	}
+	return nil
+}
+
+// Ascending 是否按升序排列
+func (q Query) Ascending() bool { return q.SortOrder == SortAsc }
+
+// EffectiveDateFrom 实际的起始日期（YYYY-MM-DD）：DateFrom 与 Since 中较晚的一个，都未设置时为空
+func (q Query) EffectiveDateFrom() string {
+	if q.Since.IsZero() {
+		return q.DateFrom
+	}
+	since := q.Since.Format("2006-01-02")
+	if q.DateFrom > since {
+		return q.DateFrom
+	}
+	return since
 }
 
 // Result 查询结果
@@ -34,4 +92,11 @@ type Platform interface {
 
 type Config interface {
 	Validate() error
+	// RateLimit 每秒允许的请求数，<= 0 表示不限速
+	RateLimit() float64
+}
+
+// RateLimited 支持注入限速器的平台，由 core 在爬取前传入该平台的限速器
+type RateLimited interface {
+	SetLimiter(l ratelimit.Limiter)
 }
@@ -9,15 +9,18 @@ import (
 	"strings"
 	"time"
 
-	"PaperHunter/internal/core"
 	"PaperHunter/internal/models"
 	"PaperHunter/internal/platform"
+	"PaperHunter/pkg/httpclient"
 	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/proxy"
+	"PaperHunter/pkg/ratelimit"
 )
 
 type Adapter struct {
 	config     *Config
 	httpClient *http.Client
+	limiter    ratelimit.Limiter
 }
 
 func NewAdapter(config *Config) (*Adapter, error) {
@@ -28,17 +31,38 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	client := core.NewHTTPClient(int(config.Timeout.Seconds()), config.Proxy)
-	return &Adapter{config: config, httpClient: client}, nil
+	client := httpclient.New(config.Timeout, proxy.NewRotator([]string{config.Proxy}),
+		httpclient.WithInsecureSkipVerify(config.InsecureSkipVerify),
+		httpclient.WithDialTimeout(config.DialTimeout),
+	)
+	return &Adapter{config: config, httpClient: client, limiter: ratelimit.For("ssrn", config.RateLimit())}, nil
 }
 
 func (a *Adapter) Name() string { return "ssrn" }
 
 func (a *Adapter) GetConfig() platform.Config { return a.config }
 
+// SetLimiter 替换请求限速器
+func (a *Adapter) SetLimiter(l ratelimit.Limiter) { a.limiter = l }
+
 //需要添加代理池等配置方案来为抓取提供效率，目前太慢了
 
 func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result, error) {
+	// 未指定起始日期时从增量断点继续；断点当天的论文仍会抓取，避免漏掉上次爬取后同日发布的论文，重复论文入库时去重
+	if q.DateFrom == "" && !a.config.ForceFullCrawl {
+		if cp := a.config.loadCheckpoint(q.Keywords); cp != nil {
+			q.DateFrom = cp.Latest.Format("2006-01-02")
+			platform.ReportStatus(ctx, "[SSRN] 增量爬取：只抓取 %s 及之后发布的论文", q.DateFrom)
+		}
+	}
+	from, err := parseQueryDate(q.DateFrom)
+	if err != nil {
+		return platform.Result{}, err
+	}
+	to, err := parseQueryDate(q.DateTo)
+	if err != nil {
+		return platform.Result{}, err
+	}
 
 	want := q.Limit
 	if want <= 0 {
@@ -53,14 +77,14 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 		startPage = 1
 	}
 
-	ids := make([]string, 0, want)
+	entries := make([]SearchEntry, 0, want)
 	seen := map[string]struct{}{}
 	maxPages := a.config.MaxPages
 	if maxPages <= 0 {
 		maxPages = 1
 	}
 
-	for p := 0; p < maxPages && len(ids) < want; p++ {
+	for p := 0; p < maxPages && len(entries) < want; p++ {
 		npage := startPage + p
 		searchURL := a.buildSearchURL(npage, q)
 		logger.Debug("[SSRN] 搜索 URL(page=%d): %s", npage, searchURL)
@@ -72,83 +96,173 @@ func (a *Adapter) Search(ctx context.Context, q platform.Query) (platform.Result
 			logger.Warn("[SSRN] 第 %d 页请求失败: %v", npage, err)
 			break
 		}
-		pageIDs := ExtractIDsFromSearchHTML(html)
-		if len(pageIDs) == 0 {
+		pageEntries := ParseSearchEntries(html)
+		if len(pageEntries) == 0 {
+			// 列表页结构无法识别时只取 ID，标题等由详情页补全
+			for _, id := range ExtractIDsFromSearchHTML(html) {
+				pageEntries = append(pageEntries, SearchEntry{ID: id})
+			}
+		}
+		if len(pageEntries) == 0 {
 			if p == 0 {
 				logger.Info("[SSRN] 未找到论文条目")
 			}
 			break
 		}
-		for _, id := range pageIDs {
-			if _, ok := seen[id]; ok {
+		reachedOld := false
+		for _, e := range pageEntries {
+			if _, ok := seen[e.ID]; ok {
+				continue
+			}
+			seen[e.ID] = struct{}{}
+			if !from.IsZero() && !e.Posted.IsZero() && e.Posted.Before(from) {
+				reachedOld = true
 				continue
 			}
-			seen[id] = struct{}{}
-			ids = append(ids, id)
-			if len(ids) >= want {
+			if !to.IsZero() && e.Posted.After(to) {
+				continue
+			}
+			entries = append(entries, e)
+			if len(entries) >= want {
 				break
 			}
 		}
+		// 按时间降序排列，出现早于起始日期的论文后不必再翻页
+		if reachedOld {
+			logger.Debug("[SSRN] 第 %d 页已出现早于 %s 的论文，停止翻页", npage, q.DateFrom)
+			break
+		}
 	}
 
-	if len(ids) == 0 {
+	if len(entries) == 0 {
 		return platform.Result{Total: 0, Papers: nil}, nil
 	}
+	if q.Limit > 0 && q.Limit < len(entries) {
+		entries = entries[:q.Limit]
+	}
 
-	target := len(ids)
-	if q.Limit > 0 && q.Limit < target {
-		target = q.Limit
+	papers := make([]*models.Paper, 0, len(entries))
+	for _, e := range entries {
+		papers = append(papers, &models.Paper{
+			Source:           "ssrn",
+			SourceID:         e.ID,
+			URL:              a.detailURL(e.ID),
+			Title:            e.Title,
+			Authors:          e.Authors,
+			Abstract:         e.Abstract,
+			FirstSubmittedAt: e.Posted,
+		})
 	}
 
-	papers := make([]*models.Paper, 0, target)
-	delay := time.Duration(float64(time.Second) / a.config.RateLimitPerSecond)
-	if delay < 300*time.Millisecond {
-		delay = 300 * time.Millisecond
+	if err := a.enrichFromDetails(ctx, papers); err != nil {
+		return platform.Result{}, err
 	}
 
-	for i, id := range ids[:target] {
-		select {
-		case <-ctx.Done():
-			return platform.Result{}, ctx.Err()
-		default:
+	// 列表与详情页都没有标题的论文无法入库；日期由详情页补全的论文再按时间范围过滤一次
+	result := papers[:0]
+	for _, p := range papers {
+		d := p.FirstSubmittedAt
+		if p.Title == "" || (!from.IsZero() && !d.IsZero() && d.Before(from)) || (!to.IsZero() && d.After(to)) {
+			continue
 		}
-		detailURL := a.config.BaseURL + "/sol3/papers.cfm?abstract_id=" + id
-		logger.Debug("[SSRN] 抓取详情 %d/%d: %s", i+1, target, detailURL)
-		dhtml, err := a.request(ctx, detailURL)
+		result = append(result, p)
+	}
+
+	if err := a.config.saveCheckpoint(q.Keywords, result); err != nil {
+		logger.Warn("[SSRN] 保存增量断点失败: %v", err)
+	}
+	return platform.Result{Total: len(result), Papers: result}, nil
+}
+
+// parseQueryDate 解析 YYYY-MM-DD，空字符串返回零值；DateTo 当天的论文包含在内
+func parseQueryDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD): %w", s, err)
+	}
+	return t, nil
+}
+
+func (a *Adapter) detailURL(id string) string {
+	return a.config.BaseURL + "/sol3/papers.cfm?abstract_id=" + id
+}
+
+// enrichFromDetails 第二轮请求详情页：列表中缺标题的论文总是补抓，开启 fetch_abstracts 时还补抓缺摘要的论文；
+// 请求间隔由 request 中的限速器控制，详情页请求或解析失败时保留列表数据
+func (a *Adapter) enrichFromDetails(ctx context.Context, papers []*models.Paper) error {
+	var pending []*models.Paper
+	for _, p := range papers {
+		if p.Title == "" || (a.config.FetchAbstracts && p.Abstract == "") {
+			pending = append(pending, p)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	platform.ReportStatus(ctx, "[SSRN] 从详情页补全 %d 篇论文的摘要与作者...", len(pending))
+	enriched := 0
+	for i, p := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		logger.Debug("[SSRN] 抓取详情 %d/%d: %s", i+1, len(pending), p.URL)
+		html, err := a.request(ctx, p.URL)
 		if err != nil {
-			logger.Warn("[SSRN] 详情抓取失败 id=%s: %v", id, err)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warn("[SSRN] 详情抓取失败 id=%s: %v", p.SourceID, err)
 			continue
 		}
-		title, abs := ParseDetailTitleAbstract(dhtml)
-		canonical, pdf := ParseDetailLinks(dhtml)
-		if title == "" && abs == "" {
-			logger.Warn("[SSRN] 解析失败 id=%s", id)
-			continue
+		if applyDetail(p, html) {
+			enriched++
+		} else {
+			logger.Warn("[SSRN] 详情解析失败 id=%s，保留列表数据", p.SourceID)
 		}
-		p := &models.Paper{
-			Source:   "ssrn",
-			SourceID: id,
-			URL: func() string {
-				if canonical != "" {
-					return canonical
-				}
-				return detailURL
-			}(),
-			Title:    title,
-			Abstract: abs,
-		}
-		if pdf != "" {
-			if p.Comments == "" {
-				p.Comments = "PDF: " + pdf
-			} else {
-				p.Comments += " | PDF: " + pdf
-			}
+		if (i+1)%10 == 0 && i+1 < len(pending) {
+			platform.ReportStatus(ctx, "[SSRN] 详情页进度 %d/%d", i+1, len(pending))
 		}
-		papers = append(papers, p)
-		time.Sleep(delay)
 	}
+	platform.ReportStatus(ctx, "[SSRN] 详情页补全完成：%d/%d 篇", enriched, len(pending))
+	return nil
+}
 
-	return platform.Result{Total: len(papers), Papers: papers}, nil
+// applyDetail 用详情页数据补全论文缺失的字段，详情页没有可用内容时返回 false
+func applyDetail(p *models.Paper, html string) bool {
+	title, abs := ParseDetailTitleAbstract(html)
+	if title == "" && abs == "" {
+		return false
+	}
+	if p.Title == "" {
+		p.Title = title
+	}
+	if p.Abstract == "" {
+		p.Abstract = abs
+	}
+	if len(p.Authors) == 0 {
+		p.Authors = ParseDetailAuthors(html)
+	}
+	if p.FirstSubmittedAt.IsZero() {
+		p.FirstSubmittedAt = ParseDetailDate(html)
+	}
+
+	canonical, pdf := ParseDetailLinks(html)
+	if canonical != "" {
+		p.URL = canonical
+	}
+	if pdf != "" {
+		if p.Comments == "" {
+			p.Comments = "PDF: " + pdf
+		} else {
+			p.Comments += " | PDF: " + pdf
+		}
+	}
+	return true
 }
 
 func (a *Adapter) buildSearchURL(npage int, q platform.Query) string {
@@ -161,7 +275,12 @@ func (a *Adapter) buildSearchURL(npage int, q platform.Query) string {
 		npage = 1
 	}
 	params.Set("npage", fmt.Sprintf("%d", npage))
-	params.Set("sort", a.config.Sort)
+	// 指定起始日期（含增量断点）时按时间降序，以便遇到较早论文后停止翻页
+	sort := a.config.Sort
+	if q.DateFrom != "" {
+		sort = "AB_Date_D"
+	}
+	params.Set("sort", sort)
 	params.Set("stype", "abs")
 	if a.config.PageSize > 0 {
 		params.Set("lim", fmt.Sprintf("%d", a.config.PageSize))
@@ -188,6 +307,9 @@ func (a *Adapter) request(ctx context.Context, u string) (string, error) {
 		}
 		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/142.0.0.0 Safari/537.36")
 
+		if err := a.limiter.Wait(ctx); err != nil {
+			return "", err
+		}
 		resp, err := a.httpClient.Do(req)
 		if err != nil {
 			lastErr = err
@@ -196,6 +318,7 @@ func (a *Adapter) request(ctx context.Context, u string) (string, error) {
 		defer resp.Body.Close()
 
 		if resp.StatusCode == http.StatusTooManyRequests { // 429
+			resp.Body.Close()
 			lastErr = fmt.Errorf("HTTP error: 429")
 			continue
 		}
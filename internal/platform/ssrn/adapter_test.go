@@ -0,0 +1,143 @@
+package ssrn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/platform"
+)
+
+// listingHTML 仿照 SSRN 搜索结果页：标题链接带 title class，作者在 authors-list 中
+const listingHTML = `<html><body>
+<div class="description">
+  <a href="https://papers.ssrn.com/sol3/papers.cfm?abstract_id=1001" target="_blank" class="title optClickTitle"><span>Climate Risk and Bank Lending</span></a>
+  <div class="authors-list"><a href="/sol3/cf_dev/AbsByAuth.cfm?per_id=1">Alice Smith</a>, <a href="/sol3/cf_dev/AbsByAuth.cfm?per_id=2">Bob Lee</a></div>
+  <div class="abstract-text"><p>Abstract We study climate risk.</p></div>
+</div>
+<div class="description">
+  <a class="title optClickTitle" href="https://papers.ssrn.com/sol3/papers.cfm?abstract_id=1002"><span>Central Bank Digital Currency</span></a>
+  <a href="https://papers.ssrn.com/sol3/papers.cfm?abstract_id=1002" class="download">Download</a>
+</div>
+<div class="description">
+  <a href="https://papers.ssrn.com/sol3/papers.cfm?abstract_id=1003" class="title"><span>Private Credit Markets</span></a>
+  <div class="authors-list">Carol White</div>
+</div>
+</body></html>`
+
+const detail1002 = `<html><head><title>Central Bank Digital Currency :: SSRN</title>
+<meta name="citation_author" content="Doe, John">
+<meta name="citation_author" content="Roe, Jane">
+<link rel="canonical" href="https://papers.ssrn.com/sol3/papers.cfm?abstract_id=1002">
+</head><body><div class="abstract-text"><h3>Abstract</h3><p>CBDC adoption.</p></div></body></html>`
+
+func TestParseSearchEntries(t *testing.T) {
+	entries := ParseSearchEntries(listingHTML)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d: %+v", len(entries), entries)
+	}
+	first := entries[0]
+	if first.ID != "1001" || first.Title != "Climate Risk and Bank Lending" || first.Abstract != "We study climate risk." {
+		t.Errorf("Unexpected first entry: %+v", first)
+	}
+	if strings.Join(first.Authors, "|") != "Alice Smith|Bob Lee" {
+		t.Errorf("Authors = %v, want [Alice Smith Bob Lee]", first.Authors)
+	}
+	if entries[1].ID != "1002" || len(entries[1].Authors) != 0 || entries[1].Abstract != "" {
+		t.Errorf("Unexpected second entry: %+v", entries[1])
+	}
+	if strings.Join(entries[2].Authors, "|") != "Carol White" {
+		t.Errorf("Authors = %v, want [Carol White]", entries[2].Authors)
+	}
+
+	if got := ParseDetailAuthors(detail1002); strings.Join(got, "|") != "John Doe|Jane Roe" {
+		t.Errorf("ParseDetailAuthors() = %v", got)
+	}
+}
+
+func newTestAdapter(t *testing.T, fetchAbstracts bool) (*Adapter, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path+"?"+r.URL.Query().Get("abstract_id"))
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/sol3/results.cfm":
+			w.Write([]byte(listingHTML))
+		case r.URL.Query().Get("abstract_id") == "1002":
+			w.Write([]byte(detail1002))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = srv.URL
+	cfg.MaxPages = 1
+	cfg.Timeout = 5 * time.Second
+	cfg.RateLimitPerSecond = 1000
+	cfg.FetchAbstracts = fetchAbstracts
+	cfg.CheckpointPath = t.TempDir()
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	return a, &requested
+}
+
+func TestSearch_ListingOnlyByDefault(t *testing.T) {
+	a, requested := newTestAdapter(t, false)
+
+	res, err := a.Search(context.Background(), platform.Query{Keywords: []string{"bank"}})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(*requested) != 1 {
+		t.Errorf("Expected only the listing request, got %v", *requested)
+	}
+	if len(res.Papers) != 3 || res.Papers[1].Title != "Central Bank Digital Currency" || res.Papers[1].Abstract != "" {
+		t.Fatalf("Unexpected listing papers: %+v", res.Papers)
+	}
+}
+
+func TestSearch_FetchAbstracts(t *testing.T) {
+	a, requested := newTestAdapter(t, true)
+
+	var statuses []string
+	ctx := platform.WithStatus(context.Background(), func(msg string) { statuses = append(statuses, msg) })
+	res, err := a.Search(ctx, platform.Query{Keywords: []string{"bank"}})
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+
+	// 1001 列表已有摘要，不请求详情页
+	want := []string{"/sol3/results.cfm?", "/sol3/papers.cfm?1002", "/sol3/papers.cfm?1003"}
+	if strings.Join(*requested, ",") != strings.Join(want, ",") {
+		t.Errorf("Requests = %v, want %v", *requested, want)
+	}
+	if len(res.Papers) != 3 {
+		t.Fatalf("Expected 3 papers, got %d", len(res.Papers))
+	}
+
+	enriched := res.Papers[1]
+	if enriched.Abstract != "CBDC adoption." || strings.Join(enriched.Authors, "|") != "John Doe|Jane Roe" {
+		t.Errorf("Expected detail abstract and authors, got %+v", enriched)
+	}
+	// 1003 详情页失败，保留列表数据
+	failed := res.Papers[2]
+	if failed.Title != "Private Credit Markets" || failed.Abstract != "" || strings.Join(failed.Authors, "|") != "Carol White" {
+		t.Errorf("Expected listing data to be kept, got %+v", failed)
+	}
+
+	if len(statuses) != 2 || statuses[len(statuses)-1] != fmt.Sprintf("[SSRN] 详情页补全完成：%d/%d 篇", 1, 2) {
+		t.Errorf("Unexpected progress messages: %v", statuses)
+	}
+}
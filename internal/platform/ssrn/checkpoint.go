@@ -0,0 +1,113 @@
+package ssrn
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+const checkpointPrefix = "ssrn_checkpoint_"
+
+// Checkpoint 增量爬取断点：同一组关键词上次爬到的最新论文提交时间
+type Checkpoint struct {
+	Keywords  []string  `json:"keywords"`
+	Latest    time.Time `json:"latest"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// checkpointDir 断点文件目录，未配置 checkpoint_path 时为 ~/.quicksearch/data
+func (c *Config) checkpointDir() string {
+	if dir := strings.TrimSpace(c.CheckpointPath); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".quicksearch", "data")
+	}
+	return filepath.Join(home, ".quicksearch", "data")
+}
+
+// checkpointFile 按关键词（忽略大小写与首尾空白）计算断点文件路径，不同关键词的断点互不影响
+func (c *Config) checkpointFile(keywords []string) string {
+	key := strings.ToLower(joinNonEmpty(keywords, "\x00"))
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.checkpointDir(), checkpointPrefix+hex.EncodeToString(sum[:6])+".json")
+}
+
+// loadCheckpoint 读取关键词对应的断点，文件不存在或损坏时返回 nil
+func (c *Config) loadCheckpoint(keywords []string) *Checkpoint {
+	data, err := os.ReadFile(c.checkpointFile(keywords))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warn("[SSRN] 读取增量断点失败: %v", err)
+		}
+		return nil
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil || cp.Latest.IsZero() {
+		logger.Warn("[SSRN] 增量断点文件无效，忽略: %v", err)
+		return nil
+	}
+	return &cp
+}
+
+// saveCheckpoint 记录本次结果中最新论文的提交时间，早于已有断点时不覆盖
+func (c *Config) saveCheckpoint(keywords []string, papers []*models.Paper) error {
+	var latest time.Time
+	for _, p := range papers {
+		if p.FirstSubmittedAt.After(latest) {
+			latest = p.FirstSubmittedAt
+		}
+	}
+	if latest.IsZero() {
+		return nil
+	}
+	if old := c.loadCheckpoint(keywords); old != nil && !latest.After(old.Latest) {
+		return nil
+	}
+
+	path := c.checkpointFile(keywords)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建断点目录失败: %w", err)
+	}
+	data, err := json.MarshalIndent(Checkpoint{
+		Keywords:  keywords,
+		Latest:    latest.UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("写入断点文件失败: %w", err)
+	}
+	logger.Debug("[SSRN] 更新增量断点: %s -> %s", path, latest.Format("2006-01-02"))
+	return nil
+}
+
+// ResetCheckpoints 删除断点目录下的全部 SSRN 增量断点，下次爬取回到全量
+func ResetCheckpoints(c *Config) error {
+	if c == nil {
+		c = DefaultConfig()
+	}
+	files, err := filepath.Glob(filepath.Join(c.checkpointDir(), checkpointPrefix+"*.json"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("删除断点文件失败: %w", err)
+		}
+	}
+	logger.Info("[SSRN] 已清除 %d 个增量断点", len(files))
+	return nil
+}
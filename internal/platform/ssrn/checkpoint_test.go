@@ -0,0 +1,171 @@
+package ssrn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/platform"
+)
+
+// datedListing 生成按时间降序排列的列表页，每项为 abstract_id 与发布日期
+func datedListing(items ...[2]string) string {
+	var sb strings.Builder
+	sb.WriteString("<html><body>")
+	for _, it := range items {
+		fmt.Fprintf(&sb, `<div class="description">
+  <a href="https://papers.ssrn.com/sol3/papers.cfm?abstract_id=%s" class="title"><span>Paper %s</span></a>
+  <div class="note-list"><span>Posted: %s</span></div>
+</div>`, it[0], it[0], it[1])
+	}
+	sb.WriteString("</body></html>")
+	return sb.String()
+}
+
+// newCheckpointAdapter 返回一个列表页内容可替换的测试适配器，记录每次请求的 sort 参数
+func newCheckpointAdapter(t *testing.T, dir string, listing *string) (*Adapter, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var sorts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		sorts = append(sorts, r.URL.Query().Get("sort"))
+		w.Write([]byte(*listing))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = srv.URL
+	cfg.MaxPages = 1
+	cfg.RateLimitPerSecond = 1000
+	cfg.Sort = "relevance"
+	cfg.CheckpointPath = dir
+	a, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error: %v", err)
+	}
+	return a, &sorts
+}
+
+func paperIDs(res platform.Result) string {
+	ids := make([]string, 0, len(res.Papers))
+	for _, p := range res.Papers {
+		ids = append(ids, p.SourceID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSearch_WritesCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	listing := datedListing([2]string{"2001", "10 Mar 2024"}, [2]string{"2002", "5 Mar 2024"})
+	a, _ := newCheckpointAdapter(t, dir, &listing)
+
+	q := platform.Query{Keywords: []string{"Climate Risk"}}
+	res, err := a.Search(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if want := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC); !res.Papers[1].FirstSubmittedAt.Equal(want) {
+		t.Errorf("FirstSubmittedAt = %v, want %v", res.Papers[1].FirstSubmittedAt, want)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "ssrn_checkpoint_*.json"))
+	if len(files) != 1 || files[0] != a.config.checkpointFile([]string{" climate risk "}) {
+		t.Fatalf("Expected one checkpoint file keyed by keywords, got %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if want := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC); !cp.Latest.Equal(want) {
+		t.Errorf("Checkpoint latest = %v, want %v", cp.Latest, want)
+	}
+
+	// 结果中没有更新的论文时不回退断点
+	listing = datedListing([2]string{"2002", "5 Mar 2024"})
+	if _, err := a.Search(context.Background(), platform.Query{Keywords: []string{"climate risk"}, DateFrom: "2024-01-01"}); err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if cp := a.config.loadCheckpoint(q.Keywords); cp == nil || cp.Latest.Day() != 10 {
+		t.Errorf("Expected checkpoint to stay at 2024-03-10, got %+v", cp)
+	}
+}
+
+func TestSearch_IncrementalFromCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	listing := datedListing([2]string{"2001", "10 Mar 2024"}, [2]string{"2002", "5 Mar 2024"})
+	a, sorts := newCheckpointAdapter(t, dir, &listing)
+	q := platform.Query{Keywords: []string{"bank"}}
+	if _, err := a.Search(context.Background(), q); err != nil {
+		t.Fatalf("first Search() error: %v", err)
+	}
+
+	listing = datedListing(
+		[2]string{"2004", "15 Mar 2024"},
+		[2]string{"2003", "12 Mar 2024"},
+		[2]string{"2001", "10 Mar 2024"},
+		[2]string{"2002", "5 Mar 2024"},
+		[2]string{"1999", "1 Feb 2024"},
+	)
+	res, err := a.Search(context.Background(), q)
+	if err != nil {
+		t.Fatalf("second Search() error: %v", err)
+	}
+	// 断点当天（2024-03-10）的论文仍会抓取，更早的论文跳过
+	if got := paperIDs(res); got != "2004,2003,2001" {
+		t.Errorf("Incremental crawl returned %s, want 2004,2003,2001", got)
+	}
+	if got := (*sorts)[len(*sorts)-1]; got != "AB_Date_D" {
+		t.Errorf("Expected incremental crawl sorted by newest, got sort=%q", got)
+	}
+	if cp := a.config.loadCheckpoint(q.Keywords); cp == nil || cp.Latest.Day() != 15 {
+		t.Errorf("Expected checkpoint advanced to 2024-03-15, got %+v", cp)
+	}
+
+	// 其他关键词不受该断点影响
+	if res, _ := a.Search(context.Background(), platform.Query{Keywords: []string{"credit"}}); len(res.Papers) != 5 {
+		t.Errorf("Expected full crawl for new keywords, got %s", paperIDs(res))
+	}
+}
+
+func TestSearch_ForceFullCrawlIgnoresCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	listing := datedListing([2]string{"2001", "10 Mar 2024"}, [2]string{"2002", "5 Mar 2024"})
+	a, sorts := newCheckpointAdapter(t, dir, &listing)
+	q := platform.Query{Keywords: []string{"bank"}}
+	if _, err := a.Search(context.Background(), q); err != nil {
+		t.Fatalf("first Search() error: %v", err)
+	}
+
+	a.config.ForceFullCrawl = true
+	res, err := a.Search(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if got := paperIDs(res); got != "2001,2002" {
+		t.Errorf("ForceFullCrawl returned %s, want 2001,2002", got)
+	}
+	if got := (*sorts)[len(*sorts)-1]; got != "relevance" {
+		t.Errorf("Expected configured sort, got %q", got)
+	}
+
+	if err := ResetCheckpoints(a.config); err != nil {
+		t.Fatalf("ResetCheckpoints() error: %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "ssrn_checkpoint_*.json")); len(files) != 0 {
+		t.Errorf("Expected checkpoints removed, got %v", files)
+	}
+}
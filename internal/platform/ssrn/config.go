@@ -19,8 +19,19 @@ type Config struct {
 	MaxPages           int     `mapstructure:"max_pages" yaml:"max_pages"`
 	RateLimitPerSecond float64 `mapstructure:"rate_limit_per_second" yaml:"rate_limit_per_second"`
 
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify"` // 跳过 TLS 证书校验，仅用于自签证书的代理
+	DialTimeout        time.Duration `mapstructure:"dial_timeout" yaml:"dial_timeout"`                 // 建立连接超时，如 10s
+
 	// 排序: AB_Date_D(按时间降序) / AB_Date_A / relevance 等
 	Sort string `mapstructure:"sort" yaml:"sort"`
+
+	// FetchAbstracts 列表抓取后逐篇请求详情页补全摘要与作者，请求数随论文数成倍增加，默认关闭
+	FetchAbstracts bool `mapstructure:"fetch_abstracts" yaml:"fetch_abstracts"`
+
+	// CheckpointPath 增量爬取断点文件 ssrn_checkpoint_<hash>.json 所在目录，留空为 ~/.quicksearch/data
+	CheckpointPath string `mapstructure:"checkpoint_path" yaml:"checkpoint_path"`
+	// ForceFullCrawl 忽略增量断点，每次按关键词全量爬取
+	ForceFullCrawl bool `mapstructure:"force_full_crawl" yaml:"force_full_crawl"`
 }
 
 // DefaultConfig 返回 SSRN 的默认配置
@@ -31,6 +42,7 @@ func DefaultConfig() *Config {
 		PageSize:           20,
 		MaxPages:           3,
 		RateLimitPerSecond: 0.2,
+		DialTimeout:        30 * time.Second,
 		Sort:               "AB_Date_D",
 	}
 }
@@ -49,6 +61,9 @@ func (c *Config) Validate() error {
 	if c.MaxPages < 0 || c.MaxPages > 50 {
 		return fmt.Errorf("invalid max_pages: %d", c.MaxPages)
 	}
+	if c.DialTimeout < 0 {
+		return fmt.Errorf("invalid dial_timeout: %v", c.DialTimeout)
+	}
 	if c.RateLimitPerSecond <= 0 {
 		return fmt.Errorf("invalid rate_limit_per_second: %v", c.RateLimitPerSecond)
 	}
@@ -58,5 +73,8 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// RateLimit SSRN 沿用 rate_limit_per_second 配置
+func (c *Config) RateLimit() float64 { return c.RateLimitPerSecond }
+
 // 确保实现 platform.Config 接口
 var _ platform.Config = (*Config)(nil)
@@ -3,6 +3,7 @@ package ssrn
 import (
 	"regexp"
 	"strings"
+	"time"
 )
 
 // 提取搜索结果中的 abstract_id 列表
@@ -14,8 +15,125 @@ var (
 	reCanonical   = regexp.MustCompile(`(?is)<link[^>]*rel=\"canonical\"[^>]*href=\"([^\"]+)\"`)                          // canonical 链接
 	reCitationPDF = regexp.MustCompile(`(?is)<meta[^>]*name=\"citation_pdf_url\"[^>]*content=\"([^\"]+)\"`)               // meta pdf 链接
 	reDeliveryPDF = regexp.MustCompile(`(?is)<a[^>]*href=\"([^\"]*Delivery\\.cfm[^\"]+)\"`)                               // 备选 pdf 链接
+
+	reAnchor         = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)                                       // 列表页链接
+	reAnchorID       = regexp.MustCompile(`abstract_id=([0-9]+)`)                                             // 链接中的 abstract_id
+	reTitleClass     = regexp.MustCompile(`(?i)class=["'][^"']*\btitle\b[^"']*["']`)                          // 列表页标题链接
+	reAuthorsBox     = regexp.MustCompile(`(?is)<div[^>]*class=["'][^"']*authors[^"']*["'][^>]*>(.*?)</div>`) // 列表页作者
+	reCitationAuthor = regexp.MustCompile(`(?is)<meta[^>]*name=\"citation_author\"[^>]*content=\"([^\"]+)\"`) // 详情页作者
+
+	rePosted     = regexp.MustCompile(`(?i)\bPosted:?\s*(\d{1,2} [A-Za-z]{3,9},? \d{4})`)                                      // 列表页发布日期，如 Posted: 12 Mar 2024
+	reOnlineDate = regexp.MustCompile(`(?is)<meta[^>]*name=\"citation_(?:online|publication)_date\"[^>]*content=\"([^\"]+)\"`) // 详情页日期
 )
 
+// dateLayouts 列表页与详情页中出现过的日期格式
+var dateLayouts = []string{"2 Jan 2006", "2 January 2006", "2 Jan, 2006", "2006/01/02", "2006-01-02", "January 2, 2006"}
+
+// SearchEntry 搜索列表页中的一条论文，字段可能不完整
+type SearchEntry struct {
+	ID       string
+	Title    string
+	Authors  []string
+	Abstract string
+	Posted   time.Time // 发布日期，列表页未显示时为零值
+}
+
+// ParseSearchEntries 解析搜索列表页的标题、作者与摘要片段；
+// 每个带 title class 的论文链接开始一条记录，到下一条记录前的内容属于该论文
+func ParseSearchEntries(html string) []SearchEntry {
+	var entries []SearchEntry
+	var starts []int
+	seen := map[string]struct{}{}
+	for _, m := range reAnchor.FindAllStringSubmatchIndex(html, -1) {
+		attrs := html[m[2]:m[3]]
+		idm := reAnchorID.FindStringSubmatch(attrs)
+		if idm == nil || !reTitleClass.MatchString(attrs) {
+			continue
+		}
+		if _, ok := seen[idm[1]]; ok {
+			continue
+		}
+		seen[idm[1]] = struct{}{}
+		entries = append(entries, SearchEntry{ID: idm[1], Title: cleanText(html[m[4]:m[5]])})
+		starts = append(starts, m[1])
+	}
+
+	for i := range entries {
+		end := len(html)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		segment := html[starts[i]:end]
+		if m := reAuthorsBox.FindStringSubmatch(segment); len(m) > 1 {
+			entries[i].Authors = splitAuthors(m[1])
+		}
+		if m := reAbsBox.FindStringSubmatch(segment); len(m) > 1 {
+			entries[i].Abstract = strings.TrimPrefix(cleanText(m[1]), "Abstract ")
+		}
+		if m := rePosted.FindStringSubmatch(cleanText(segment)); len(m) > 1 {
+			entries[i].Posted = parseDate(m[1])
+		}
+	}
+	return entries
+}
+
+// ParseDetailAuthors 解析详情页 citation_author，"Last, First" 转为 "First Last"（作者以逗号拼接存储）
+func ParseDetailAuthors(html string) []string {
+	var authors []string
+	for _, m := range reCitationAuthor.FindAllStringSubmatch(html, -1) {
+		name := strings.TrimSpace(m[1])
+		if last, first, ok := strings.Cut(name, ","); ok {
+			name = strings.TrimSpace(first) + " " + strings.TrimSpace(last)
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	return authors
+}
+
+// ParseDetailDate 解析详情页 citation_online_date（缺失时用 citation_publication_date），无法解析时返回零值
+func ParseDetailDate(html string) time.Time {
+	if m := reOnlineDate.FindStringSubmatch(html); len(m) > 1 {
+		return parseDate(m[1])
+	}
+	return time.Time{}
+}
+
+func parseDate(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// splitAuthors 优先取作者链接文本，没有链接时按逗号拆分
+func splitAuthors(fragment string) []string {
+	var authors []string
+	for _, m := range reAnchor.FindAllStringSubmatch(fragment, -1) {
+		if name := cleanText(m[2]); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	if len(authors) > 0 {
+		return authors
+	}
+	for _, name := range strings.Split(cleanText(fragment), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			authors = append(authors, name)
+		}
+	}
+	return authors
+}
+
+// cleanText 去掉标签并合并空白
+func cleanText(fragment string) string {
+	return strings.Join(strings.Fields(reTags.ReplaceAllString(fragment, " ")), " ")
+}
+
 func ExtractIDsFromSearchHTML(html string) []string {
 	ids := []string{}
 	if html == "" {
@@ -0,0 +1,27 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+
+	"PaperHunter/pkg/logger"
+)
+
+// StatusFunc 接收平台在耗时阶段上报的进度说明，如桌面端写入爬取日志
+type StatusFunc func(message string)
+
+type statusKey struct{}
+
+// WithStatus 返回携带进度回调的 ctx，Search 过程中通过 ReportStatus 上报
+func WithStatus(ctx context.Context, fn StatusFunc) context.Context {
+	return context.WithValue(ctx, statusKey{}, fn)
+}
+
+// ReportStatus 上报进度：写入日志，ctx 带有回调时同时通知调用方
+func ReportStatus(ctx context.Context, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logger.Info("%s", msg)
+	if fn, ok := ctx.Value(statusKey{}).(StatusFunc); ok && fn != nil {
+		fn(msg)
+	}
+}
@@ -0,0 +1,172 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/httplimit"
+	"PaperHunter/pkg/logger"
+
+	"github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Config 翻译服务配置，复用 LLM 的 API 地址和 Key
+type Config struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+}
+
+// Item 待翻译的标题和摘要，Translate 返回相同顺序的译文
+type Item struct {
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+}
+
+type Service interface {
+	Translate(ctx context.Context, items []Item, targetLang string) ([]Item, error)
+	// TranslatePaper 翻译单篇论文，译文写入 TitleTranslated / AbstractTranslated
+	TranslatePaper(ctx context.Context, p *models.Paper, targetLang string) error
+}
+
+type llmService struct {
+	model *openai.ChatModel
+}
+
+// New 创建基于 OpenAI 兼容接口的翻译服务，未配置 API Key 时返回 nil
+func New(cfg Config) (Service, error) {
+	if cfg.APIKey == "" {
+		logger.Warn("LLM API Key 未配置，翻译功能不可用")
+		return nil, nil
+	}
+
+	temp := float32(0)
+	model, err := openai.NewChatModel(context.Background(), &openai.ChatModelConfig{
+		APIKey:      cfg.APIKey,
+		Model:       cfg.Model,
+		BaseURL:     cfg.BaseURL,
+		Temperature: &temp,
+		HTTPClient:  httplimit.Client(0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建 LLM 客户端失败: %w", err)
+	}
+	return &llmService{model: model}, nil
+}
+
+// languageNames 常用语言代码对应的提示词名称，其他值原样写入提示词
+var languageNames = map[string]string{
+	"zh": "Simplified Chinese",
+	"en": "English",
+	"ja": "Japanese",
+	"ko": "Korean",
+}
+
+type translationEntry struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+}
+
+// Translate 一次调用翻译多篇论文，按 id 对应译文；模型漏掉的条目返回空字符串
+func (s *llmService) Translate(ctx context.Context, items []Item, targetLang string) ([]Item, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	lang := languageNames[strings.ToLower(targetLang)]
+	if lang == "" {
+		lang = targetLang
+	}
+
+	entries := make([]translationEntry, len(items))
+	for i, item := range items {
+		entries[i] = translationEntry{ID: i, Title: item.Title, Abstract: item.Abstract}
+	}
+	payload, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("序列化翻译请求失败: %w", err)
+	}
+
+	messages := []*schema.Message{
+		{Role: schema.System, Content: systemPrompt(lang)},
+		{Role: schema.User, Content: string(payload)},
+	}
+	resp, err := s.model.Generate(ctx, messages)
+	if err != nil {
+		return nil, fmt.Errorf("LLM 翻译失败: %w", err)
+	}
+	if resp == nil || resp.Content == "" {
+		return nil, fmt.Errorf("LLM 返回空响应")
+	}
+
+	translated, err := parseResponse(resp.Content)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Item, len(items))
+	for _, e := range translated {
+		if e.ID >= 0 && e.ID < len(out) {
+			out[e.ID] = Item{Title: e.Title, Abstract: e.Abstract}
+		}
+	}
+	return out, nil
+}
+
+func (s *llmService) TranslatePaper(ctx context.Context, p *models.Paper, targetLang string) error {
+	translated, err := s.Translate(ctx, []Item{{Title: p.Title, Abstract: p.Abstract}}, targetLang)
+	if err != nil {
+		return err
+	}
+	if len(translated) == 0 || !Apply(p, translated[0]) {
+		return fmt.Errorf("未返回译文")
+	}
+	return nil
+}
+
+// Apply 将译文写入论文，模型未返回的字段保持不变；两个字段都为空时返回 false
+func Apply(p *models.Paper, t Item) bool {
+	if t.Title == "" && t.Abstract == "" {
+		return false
+	}
+	if t.Title != "" {
+		p.TitleTranslated = t.Title
+	}
+	if t.Abstract != "" {
+		p.AbstractTranslated = t.Abstract
+	}
+	return true
+}
+
+func systemPrompt(lang string) string {
+	return fmt.Sprintf(`You are a professional translator of academic papers. Translate the title and abstract of each paper in the JSON array into %s.
+
+Rules:
+- Keep technical terms, model names, math and citations accurate
+- Keep the "id" of each entry unchanged
+- Leave a field empty if the original is empty
+- Respond ONLY with a JSON array of objects with "id", "title" and "abstract" fields`, lang)
+}
+
+// parseResponse 解析模型返回的 JSON 数组，兼容 markdown 代码块
+func parseResponse(content string) ([]translationEntry, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	start := strings.Index(content, "[")
+	end := strings.LastIndex(content, "]")
+	if start == -1 || end <= start {
+		return nil, fmt.Errorf("翻译结果不是 JSON 数组")
+	}
+
+	var entries []translationEntry
+	if err := json.Unmarshal([]byte(content[start:end+1]), &entries); err != nil {
+		return nil, fmt.Errorf("JSON 解析失败: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,318 @@
+package bibtex
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Entry 一条 BibTeX 记录，如 @inproceedings{key, title = {...}, ...}
+type Entry struct {
+	Type   string            // 条目类型（小写），如 article、inproceedings、misc
+	Key    string            // 引用键
+	Fields map[string]string // 原始字段值（字段名小写，未清理 LaTeX）
+}
+
+// Field 返回清理 LaTeX 标记后的字段值，不存在时返回空串
+func (e *Entry) Field(name string) string {
+	return CleanText(e.Fields[strings.ToLower(name)])
+}
+
+// RawField 返回未经清理的字段值
+func (e *Entry) RawField(name string) string {
+	return strings.TrimSpace(e.Fields[strings.ToLower(name)])
+}
+
+// Parse 解析 BibTeX 文本，跳过 @comment/@string/@preamble 以及格式损坏的条目
+func Parse(content string) []*Entry {
+	p := &parser{src: content}
+	var entries []*Entry
+	for {
+		at := strings.IndexByte(p.src[p.pos:], '@')
+		if at < 0 {
+			break
+		}
+		p.pos += at + 1
+		if e := p.parseEntry(); e != nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) eof() bool { return p.pos >= len(p.src) }
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) readIdent() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.src[p.pos]
+		if c == ',' || c == '=' || c == '{' || c == '}' || c == '(' || c == ')' || c == '"' || c == '#' ||
+			c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// parseEntry 从 @ 之后开始解析一个条目
+func (p *parser) parseEntry() *Entry {
+	typ := strings.ToLower(p.readIdent())
+	p.skipSpace()
+	open := p.peek()
+	if typ == "" || (open != '{' && open != '(') {
+		return nil
+	}
+	closeCh := byte('}')
+	if open == '(' {
+		closeCh = ')'
+	}
+	p.pos++
+
+	switch typ {
+	case "comment", "string", "preamble":
+		p.skipBalanced(open, closeCh)
+		return nil
+	}
+
+	p.skipSpace()
+	key := strings.TrimSpace(p.readIdent())
+	entry := &Entry{Type: typ, Key: key, Fields: make(map[string]string)}
+
+	for {
+		p.skipSpace()
+		for p.peek() == ',' {
+			p.pos++
+			p.skipSpace()
+		}
+		if p.eof() {
+			return entry
+		}
+		if p.peek() == closeCh {
+			p.pos++
+			return entry
+		}
+		if p.peek() == '@' {
+			// 条目未闭合，交给下一轮解析
+			return entry
+		}
+
+		name := strings.ToLower(p.readIdent())
+		p.skipSpace()
+		if name == "" || p.peek() != '=' {
+			// 无法识别的内容，跳过一个字符避免死循环
+			p.pos++
+			continue
+		}
+		p.pos++
+		entry.Fields[name] = p.readValue(closeCh)
+	}
+}
+
+// readValue 读取字段值，支持 {...}、"..."、裸词以及 # 拼接
+func (p *parser) readValue(closeCh byte) string {
+	var sb strings.Builder
+	for {
+		p.skipSpace()
+		switch p.peek() {
+		case '{':
+			p.pos++
+			sb.WriteString(p.readUntilBalanced('{', '}'))
+		case '"':
+			p.pos++
+			sb.WriteString(p.readQuoted())
+		default:
+			start := p.pos
+			for !p.eof() {
+				c := p.src[p.pos]
+				if c == ',' || c == closeCh || c == '#' || c == '\n' {
+					break
+				}
+				p.pos++
+			}
+			sb.WriteString(strings.TrimSpace(p.src[start:p.pos]))
+		}
+		p.skipSpace()
+		if p.peek() != '#' {
+			return sb.String()
+		}
+		p.pos++
+	}
+}
+
+// readUntilBalanced 读取到与已消费的左括号匹配的右括号为止（不含外层括号）
+func (p *parser) readUntilBalanced(open, closeCh byte) string {
+	start := p.pos
+	depth := 1
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\':
+			p.pos++ // 跳过转义字符
+		case c == open:
+			depth++
+		case c == closeCh:
+			depth--
+			if depth == 0 {
+				value := p.src[start:p.pos]
+				p.pos++
+				return value
+			}
+		}
+		p.pos++
+	}
+	return p.src[start:]
+}
+
+func (p *parser) readQuoted() string {
+	start := p.pos
+	depth := 0
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch {
+		case c == '\\':
+			p.pos++
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case c == '"' && depth <= 0:
+			value := p.src[start:p.pos]
+			p.pos++
+			return value
+		}
+		p.pos++
+	}
+	return p.src[start:]
+}
+
+func (p *parser) skipBalanced(open, closeCh byte) {
+	p.readUntilBalanced(open, closeCh)
+}
+
+var (
+	reAccentBraced = regexp.MustCompile(`\\([` + "`" + `'"^~=.uvHc])\s*\{\s*([a-zA-Z])\s*\}`)
+	reAccentBare   = regexp.MustCompile(`\\([` + "`" + `'"^~=.])([a-zA-Z])`)
+	reCommandArg   = regexp.MustCompile(`\\[a-zA-Z]+\*?\s*\{([^{}]*)\}`)
+	reCommand      = regexp.MustCompile(`\\([a-zA-Z]+)`)
+	reSpaces       = regexp.MustCompile(`\s+`)
+	reAuthorSep    = regexp.MustCompile(`\s+and\s+`)
+)
+
+// 常见 LaTeX 重音到 Unicode 组合字符的映射
+var accentMarks = map[string]string{
+	"`": "\u0300", "'": "\u0301", "^": "\u0302", "~": "\u0303", "=": "\u0304",
+	"u": "\u0306", ".": "\u0307", "\"": "\u0308", "H": "\u030B", "v": "\u030C", "c": "\u0327",
+}
+
+// 无参数命令中表示特殊字母的部分
+var letterCommands = map[string]string{
+	"ss": "ß", "o": "ø", "O": "Ø", "ae": "æ", "AE": "Æ", "aa": "å", "AA": "Å", "l": "ł", "L": "Ł", "i": "ı",
+}
+
+// accent 将字母与重音组合并规范化为预组合字符
+func accent(mark, letter string) string {
+	return norm.NFC.String(letter + accentMarks[mark])
+}
+
+// CleanText 去除 LaTeX 标记，保留可读文本：
+// 重音转为 Unicode，\emph{x}/\mathcal{O} 等保留参数，数学模式去掉 $，其余命令保留名称
+func CleanText(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.ReplaceAll(text, "\n", " ")
+	text = strings.ReplaceAll(text, "\t", " ")
+
+	// 转义字符先换成占位符，避免被后续规则误处理
+	escapes := []struct{ from, placeholder, to string }{
+		{`\&`, "\x00amp\x00", "&"},
+		{`\%`, "\x00pct\x00", "%"},
+		{`\$`, "\x00dollar\x00", "$"},
+		{`\#`, "\x00hash\x00", "#"},
+		{`\_`, "\x00us\x00", "_"},
+		{`\{`, "\x00lb\x00", "{"},
+		{`\}`, "\x00rb\x00", "}"},
+	}
+	for _, e := range escapes {
+		text = strings.ReplaceAll(text, e.from, e.placeholder)
+	}
+
+	text = reAccentBraced.ReplaceAllStringFunc(text, func(m string) string {
+		sub := reAccentBraced.FindStringSubmatch(m)
+		return accent(sub[1], sub[2])
+	})
+	text = reAccentBare.ReplaceAllStringFunc(text, func(m string) string {
+		sub := reAccentBare.FindStringSubmatch(m)
+		return accent(sub[1], sub[2])
+	})
+
+	// 数学模式定界符
+	text = strings.ReplaceAll(text, "$", "")
+
+	// 带参数的命令保留参数内容，嵌套时反复处理
+	for {
+		next := reCommandArg.ReplaceAllString(text, "$1")
+		if next == text {
+			break
+		}
+		text = next
+	}
+
+	text = reCommand.ReplaceAllStringFunc(text, func(m string) string {
+		name := m[1:]
+		if letter, ok := letterCommands[name]; ok {
+			return letter
+		}
+		return name
+	})
+
+	text = strings.ReplaceAll(text, "{", "")
+	text = strings.ReplaceAll(text, "}", "")
+	text = strings.ReplaceAll(text, "~", " ")
+
+	for _, e := range escapes {
+		text = strings.ReplaceAll(text, e.placeholder, e.to)
+	}
+
+	text = reSpaces.ReplaceAllString(text, " ")
+	return strings.TrimSpace(text)
+}
+
+// ParseAuthors 按 " and " 拆分作者，并将 "Last, First" 统一为 "First Last"
+func ParseAuthors(authorsStr string) []string {
+	if authorsStr == "" {
+		return nil
+	}
+
+	authors := reAuthorSep.Split(authorsStr, -1)
+	var result []string
+	for _, author := range authors {
+		author = strings.TrimSpace(author)
+		if author == "" {
+			continue
+		}
+		if parts := strings.SplitN(author, ",", 2); len(parts) == 2 && strings.TrimSpace(parts[1]) != "" {
+			author = strings.TrimSpace(parts[1]) + " " + strings.TrimSpace(parts[0])
+		}
+		result = append(result, author)
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package bibtex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	entries := Parse(`
+@String{acl = "ACL"}
+@comment{ignored @article{fake, title = {Fake}}}
+@InProceedings{smith2023,
+  Title     = {Graph {Neural} Networks},
+  booktitle = acl # " 2023",
+  year      = 2023,
+}
+@article(doe2021,
+  title = "Quoted {Title}, with comma",
+  pages = {1--10}
+)
+@misc{broken,
+  title = {Unclosed entry}
+@misc{next, title = {After broken}}
+`)
+
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d: %+v", len(entries), entries)
+	}
+	first := entries[0]
+	if first.Type != "inproceedings" || first.Key != "smith2023" {
+		t.Errorf("Unexpected first entry: type=%q key=%q", first.Type, first.Key)
+	}
+	if got := first.RawField("title"); got != "Graph {Neural} Networks" {
+		t.Errorf("RawField(title) = %q", got)
+	}
+	if got := first.Field("TITLE"); got != "Graph Neural Networks" {
+		t.Errorf("Field(TITLE) = %q", got)
+	}
+	// 字符串宏不展开，# 拼接保留两侧内容
+	if got := first.Field("booktitle"); got != "acl 2023" {
+		t.Errorf("Field(booktitle) = %q", got)
+	}
+	if got := first.Field("year"); got != "2023" {
+		t.Errorf("Field(year) = %q", got)
+	}
+
+	second := entries[1]
+	if second.Type != "article" || second.Key != "doe2021" {
+		t.Errorf("Unexpected parenthesized entry: type=%q key=%q", second.Type, second.Key)
+	}
+	if got := second.Field("title"); got != "Quoted Title, with comma" {
+		t.Errorf("Field(title) = %q", got)
+	}
+	if got := second.Field("pages"); got != "1--10" {
+		t.Errorf("Field(pages) = %q", got)
+	}
+
+	// 未闭合的条目不影响后续条目
+	if entries[2].Key != "broken" || entries[3].Key != "next" || entries[3].Field("title") != "After broken" {
+		t.Errorf("Expected parsing to recover after an unclosed entry, got %+v %+v", entries[2], entries[3])
+	}
+	if got := entries[0].Field("missing"); got != "" {
+		t.Errorf("Field(missing) = %q, want empty", got)
+	}
+}
+
+func TestCleanText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`M{\"u}ller`, "Müller"},
+		{`Garc\'{i}a and Mar\'ia`, "García and María"},
+		{`\emph{Deep} Learning`, "Deep Learning"},
+		{`$\mathcal{O}(n)$ Memory`, "O(n) Memory"},
+		{`Research \& Development, 50\% off`, "Research & Development, 50% off"},
+		{`Stra{\ss}e~und {\O}resund`, "Straße und Øresund"},
+		{"  multi\n\tline   text ", "multi line text"},
+		{`\alpha-Divergence`, "alpha-Divergence"},
+	}
+	for _, tt := range tests {
+		if got := CleanText(tt.in); got != tt.want {
+			t.Errorf("CleanText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAuthors(t *testing.T) {
+	got := ParseAuthors("Smith, John and Jane Doe\n  and Knuth, Donald E.")
+	want := []string{"John Smith", "Jane Doe", "Donald E. Knuth"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAuthors() = %v, want %v", got, want)
+	}
+	if got := ParseAuthors(""); got != nil {
+		t.Errorf("ParseAuthors(\"\") = %v, want nil", got)
+	}
+}
@@ -0,0 +1,78 @@
+package embcache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache 按 paper_id 缓存论文向量的定长 LRU，容量满时淘汰最久未使用的条目，并发安全
+type Cache struct {
+	capacity int
+
+	mu    sync.Mutex
+	ll    *list.List // 表头为最近使用
+	items map[int64]*list.Element
+}
+
+type entry struct {
+	id  int64
+	vec []float32
+}
+
+// New 创建容量为 capacity 的缓存，capacity <= 0 时返回 nil（调用方视为不缓存）
+func New(capacity int) *Cache {
+	if capacity <= 0 {
+		return nil
+	}
+	return &Cache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[int64]*list.Element, capacity),
+	}
+}
+
+// Get 读取向量并标记为最近使用
+func (c *Cache) Get(paperID int64) ([]float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[paperID]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*entry).vec, true
+}
+
+// Put 写入或更新向量，超出容量时淘汰最久未使用的条目
+func (c *Cache) Put(paperID int64, vec []float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[paperID]; ok {
+		el.Value.(*entry).vec = vec
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[paperID] = c.ll.PushFront(&entry{id: paperID, vec: vec})
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry).id)
+	}
+}
+
+// Invalidate 移除论文的缓存向量，向量更新后调用
+func (c *Cache) Invalidate(paperID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[paperID]; ok {
+		c.ll.Remove(el)
+		delete(c.items, paperID)
+	}
+}
+
+// Len 当前缓存的条目数
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
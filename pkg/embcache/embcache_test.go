@@ -0,0 +1,84 @@
+package embcache
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(3)
+	c.Put(1, []float32{1})
+	c.Put(2, []float32{2})
+	c.Put(3, []float32{3})
+
+	// 访问 1 后，最久未使用的是 2
+	if _, ok := c.Get(1); !ok {
+		t.Fatal("Expected 1 to be cached")
+	}
+	c.Put(4, []float32{4})
+	if _, ok := c.Get(2); ok {
+		t.Error("Expected 2 to be evicted")
+	}
+	for _, id := range []int64{1, 3, 4} {
+		if _, ok := c.Get(id); !ok {
+			t.Errorf("Expected %d to be cached", id)
+		}
+	}
+
+	// 更新已有条目同样刷新使用顺序：此时顺序为 1,3,4（旧→新），更新 1 后淘汰 3
+	c.Put(1, []float32{10})
+	c.Put(5, []float32{5})
+	if _, ok := c.Get(3); ok {
+		t.Error("Expected 3 to be evicted")
+	}
+	if vec, _ := c.Get(1); len(vec) != 1 || vec[0] != 10 {
+		t.Errorf("Expected updated vector for 1, got %v", vec)
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	c := New(2)
+	c.Put(1, []float32{1})
+	c.Invalidate(1)
+	c.Invalidate(42) // 不存在的条目忽略
+	if _, ok := c.Get(1); ok {
+		t.Error("Expected 1 to be invalidated")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", c.Len())
+	}
+}
+
+func TestNew_DisabledWithoutCapacity(t *testing.T) {
+	if New(0) != nil || New(-1) != nil {
+		t.Error("Expected nil cache for non-positive capacity")
+	}
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	c := New(64)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				id := int64((g*1000 + i) % 100)
+				c.Put(id, []float32{float32(id)})
+				if vec, ok := c.Get(id); ok && vec[0] != float32(id) {
+					t.Errorf("Get(%d) = %v", id, vec)
+				}
+				if i%10 == 0 {
+					c.Invalidate(id)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if c.Len() > 64 {
+		t.Errorf("Len() = %d exceeds capacity", c.Len())
+	}
+}
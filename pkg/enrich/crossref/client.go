@@ -0,0 +1,305 @@
+package crossref
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"PaperHunter/pkg/httplimit"
+	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/ratelimit"
+)
+
+const (
+	defaultBaseURL = "https://api.crossref.org"
+	// DefaultMinSimilarity 标题相似度低于该值的候选视为不同论文
+	DefaultMinSimilarity = 0.9
+	// DefaultRateLimit CrossRef 公共池建议的请求速率
+	DefaultRateLimit = 2.0
+	// candidateRows 每次检索取回的候选数
+	candidateRows = 5
+)
+
+// selectFields 检索时只取回用到的字段
+const selectFields = "DOI,title,container-title,author,published,published-print,published-online,issued,publisher,is-referenced-by-count,license"
+
+// errNotFound CrossRef 返回 404，即 DOI 未在 CrossRef 注册
+var errNotFound = errors.New("CrossRef 未收录")
+
+// Work CrossRef 中的出版物
+type Work struct {
+	DOI        string
+	Title      string
+	Venue      string    // container-title，如会议论文集或期刊名
+	Publisher  string    // 出版方，如 "Association for Computational Linguistics"
+	Published  time.Time // 最早的出版日期，未知时为零值
+	Authors    []string  // "Given Family" 形式
+	Citations  int       // CrossRef 统计的被引次数（is-referenced-by-count）
+	OpenAccess bool      // 带有知识共享（Creative Commons）许可
+	Similarity float64   // 与查询标题的相似度，范围 [0, 1]；按 DOI 查询时为 1
+}
+
+// Client CrossRef REST API 客户端，按标题检索或按 DOI 查询出版信息
+type Client struct {
+	BaseURL string
+	// Mailto 填写后请求进入 CrossRef 的 polite 池，响应更稳定
+	Mailto string
+	// MinSimilarity 标题相似度阈值，<= 0 时使用 DefaultMinSimilarity
+	MinSimilarity float64
+
+	httpClient *http.Client
+	limiter    ratelimit.Limiter
+}
+
+// NewClient 创建 CrossRef 客户端，rps <= 0 时使用 DefaultRateLimit；所有客户端共享同一个限速器
+func NewClient(mailto string, rps float64) *Client {
+	if rps <= 0 {
+		rps = DefaultRateLimit
+	}
+	return &Client{
+		BaseURL:    defaultBaseURL,
+		Mailto:     mailto,
+		httpClient: httplimit.Client(30 * time.Second),
+		limiter:    ratelimit.For("crossref", rps),
+	}
+}
+
+type worksResponse struct {
+	Message struct {
+		Items []workItem `json:"items"`
+	} `json:"message"`
+}
+
+type workResponse struct {
+	Message workItem `json:"message"`
+}
+
+type workItem struct {
+	DOI            string   `json:"DOI"`
+	Title          []string `json:"title"`
+	ContainerTitle []string `json:"container-title"`
+	Author         []struct {
+		Given  string `json:"given"`
+		Family string `json:"family"`
+		Name   string `json:"name"`
+	} `json:"author"`
+	Published       dateParts `json:"published"`
+	PublishedPrint  dateParts `json:"published-print"`
+	PublishedOnline dateParts `json:"published-online"`
+	Issued          dateParts `json:"issued"`
+	Publisher       string    `json:"publisher"`
+	ReferencedBy    int       `json:"is-referenced-by-count"`
+	License         []struct {
+		URL string `json:"URL"`
+	} `json:"license"`
+}
+
+type dateParts struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// Lookup 按标题检索，返回相似度达到阈值的最佳匹配；没有足够相似的结果时返回 nil, nil
+func (c *Client) Lookup(ctx context.Context, title string) (*Work, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	params.Set("query.bibliographic", title)
+	params.Set("rows", fmt.Sprintf("%d", candidateRows))
+	params.Set("select", selectFields)
+	if c.Mailto != "" {
+		params.Set("mailto", c.Mailto)
+	}
+
+	body, err := c.get(ctx, strings.TrimSuffix(c.BaseURL, "/")+"/works?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	var resp worksResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析 CrossRef 响应失败: %w", err)
+	}
+
+	threshold := c.MinSimilarity
+	if threshold <= 0 {
+		threshold = DefaultMinSimilarity
+	}
+	var best *Work
+	for _, item := range resp.Message.Items {
+		if len(item.Title) == 0 || item.DOI == "" {
+			continue
+		}
+		sim := TitleSimilarity(title, item.Title[0])
+		if sim < threshold || (best != nil && sim <= best.Similarity) {
+			continue
+		}
+		best = item.toWork(sim)
+	}
+	if best == nil {
+		logger.Debug("[CrossRef] 未找到匹配: %s", title)
+	}
+	return best, nil
+}
+
+// LookupDOI 按 DOI 查询出版信息；DOI 未在 CrossRef 注册时返回 nil, nil
+func (c *Client) LookupDOI(ctx context.Context, doi string) (*Work, error) {
+	doi = strings.TrimSpace(doi)
+	if doi == "" {
+		return nil, nil
+	}
+
+	u := strings.TrimSuffix(c.BaseURL, "/") + "/works/" + (&url.URL{Path: doi}).EscapedPath()
+	if c.Mailto != "" {
+		u += "?" + url.Values{"mailto": {c.Mailto}}.Encode()
+	}
+	body, err := c.get(ctx, u)
+	if errors.Is(err, errNotFound) {
+		logger.Debug("[CrossRef] DOI 未收录: %s", doi)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var resp workResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析 CrossRef 响应失败: %w", err)
+	}
+	if resp.Message.DOI == "" {
+		return nil, nil
+	}
+	return resp.Message.toWork(1), nil
+}
+
+func (item workItem) toWork(similarity float64) *Work {
+	w := &Work{
+		DOI:        strings.ToLower(item.DOI),
+		Publisher:  strings.TrimSpace(item.Publisher),
+		Citations:  item.ReferencedBy,
+		Similarity: similarity,
+	}
+	if len(item.Title) > 0 {
+		w.Title = strings.Join(strings.Fields(item.Title[0]), " ")
+	}
+	for _, l := range item.License {
+		if strings.Contains(strings.ToLower(l.URL), "creativecommons.org") {
+			w.OpenAccess = true
+			break
+		}
+	}
+	if len(item.ContainerTitle) > 0 {
+		w.Venue = strings.TrimSpace(item.ContainerTitle[0])
+	}
+	for _, a := range item.Author {
+		name := strings.TrimSpace(strings.TrimSpace(a.Given) + " " + strings.TrimSpace(a.Family))
+		if name == "" {
+			name = strings.TrimSpace(a.Name)
+		}
+		if name != "" {
+			w.Authors = append(w.Authors, name)
+		}
+	}
+	// 取各类出版日期中最早的一个
+	for _, d := range []dateParts{item.Published, item.PublishedOnline, item.PublishedPrint, item.Issued} {
+		if t := d.time(); !t.IsZero() && (w.Published.IsZero() || t.Before(w.Published)) {
+			w.Published = t
+		}
+	}
+	return w
+}
+
+// time 将 [[year, month, day]] 转为日期，缺少的月、日按 1 处理
+func (d dateParts) time() time.Time {
+	if len(d.DateParts) == 0 || len(d.DateParts[0]) == 0 || d.DateParts[0][0] <= 0 {
+		return time.Time{}
+	}
+	parts := append(append([]int{}, d.DateParts[0]...), 1, 1)
+	month, day := parts[1], parts[2]
+	if month < 1 || month > 12 {
+		month = 1
+	}
+	if day < 1 || day > 31 {
+		day = 1
+	}
+	return time.Date(parts[0], time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func (c *Client) get(ctx context.Context, u string) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	ua := "PaperHunter/1.0"
+	if c.Mailto != "" {
+		ua += " (mailto:" + c.Mailto + ")"
+	}
+	req.Header.Set("User-Agent", ua)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("CrossRef 请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CrossRef HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// TitleSimilarity 归一化标题（小写、只保留字母数字）后的编辑距离相似度，范围 [0, 1]
+func TitleSimilarity(a, b string) float64 {
+	ra, rb := []rune(normalize(a)), []rune(normalize(b))
+	longer := max(len(ra), len(rb))
+	if longer == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longer)
+}
+
+func normalize(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,133 @@
+package crossref
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const worksJSON = `{"status":"ok","message":{"items":[
+ {"DOI":"10.1000/OTHER","title":["Attention Is Not All You Need"],"container-title":["Other Venue"]},
+ {"DOI":"10.18653/V1/2024.ACL-LONG.1","title":["Graph Transformers: A Survey."],
+  "container-title":["Proceedings of ACL 2024"],
+  "author":[{"given":"Alice","family":"Smith"},{"name":"ACL Consortium"}],
+  "published-print":{"date-parts":[[2024,8]]},
+  "published-online":{"date-parts":[[2024,7,20]]}}
+]}}`
+
+// workByDOI 录制自 api.crossref.org/works/10.18653/v1/2023.acl-long.1，省略了与解析无关的字段
+const workByDOI = `{"status":"ok","message-type":"work","message":{
+ "DOI":"10.18653/v1/2023.acl-long.1","title":["Robust Parsing of Noisy Text"],
+ "container-title":["Proceedings of the 61st Annual Meeting of the Association for Computational Linguistics"],
+ "publisher":"Association for Computational Linguistics",
+ "is-referenced-by-count":12,
+ "license":[{"URL":"https://creativecommons.org/licenses/by/4.0/"}],
+ "author":[{"given":"Alice","family":"Smith"},{"given":"Bob","family":"Doe"}],
+ "published":{"date-parts":[[2023,7]]},
+ "issued":{"date-parts":[[2023]]}
+}}`
+
+func newTestClient(t *testing.T, body string) (*Client, *string) {
+	t.Helper()
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		switch r.URL.Path {
+		case "/works":
+			w.Write([]byte(body))
+		case "/works/10.18653/v1/2023.acl-long.1":
+			w.Write([]byte(workByDOI))
+		default:
+			http.Error(w, "Resource not found.", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient("me@example.com", 1000)
+	c.BaseURL = srv.URL
+	return c, &query
+}
+
+func TestLookup_BestMatch(t *testing.T) {
+	c, query := newTestClient(t, worksJSON)
+
+	w, err := c.Lookup(context.Background(), "graph transformers - a survey")
+	if err != nil {
+		t.Fatalf("Lookup() error: %v", err)
+	}
+	if w == nil {
+		t.Fatal("Expected a match")
+	}
+	if w.DOI != "10.18653/v1/2024.acl-long.1" || w.Venue != "Proceedings of ACL 2024" {
+		t.Errorf("Unexpected work: %+v", w)
+	}
+	if want := time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC); !w.Published.Equal(want) {
+		t.Errorf("Published = %v, want earliest date %v", w.Published, want)
+	}
+	if len(w.Authors) != 2 || w.Authors[0] != "Alice Smith" || w.Authors[1] != "ACL Consortium" {
+		t.Errorf("Authors = %v", w.Authors)
+	}
+	if !strings.Contains(*query, "mailto=me%40example.com") {
+		t.Errorf("Expected mailto in query, got %q", *query)
+	}
+}
+
+func TestLookup_BelowThreshold(t *testing.T) {
+	c, _ := newTestClient(t, worksJSON)
+
+	w, err := c.Lookup(context.Background(), "Graph Neural Networks for Molecules")
+	if err != nil {
+		t.Fatalf("Lookup() error: %v", err)
+	}
+	if w != nil {
+		t.Errorf("Expected no match for dissimilar title, got %+v", w)
+	}
+
+	// 放宽阈值后接受相近标题
+	c.MinSimilarity = 0.5
+	if w, _ := c.Lookup(context.Background(), "Graph Transformer Surveys"); w == nil {
+		t.Error("Expected match with relaxed threshold")
+	}
+}
+
+func TestLookupDOI(t *testing.T) {
+	c, query := newTestClient(t, worksJSON)
+
+	w, err := c.LookupDOI(context.Background(), "10.18653/v1/2023.acl-long.1")
+	if err != nil {
+		t.Fatalf("LookupDOI() error: %v", err)
+	}
+	if w == nil {
+		t.Fatal("Expected a work")
+	}
+	if w.Title != "Robust Parsing of Noisy Text" || w.Publisher != "Association for Computational Linguistics" {
+		t.Errorf("Unexpected work: %+v", w)
+	}
+	if w.Citations != 12 || !w.OpenAccess || len(w.Authors) != 2 || w.Similarity != 1 {
+		t.Errorf("Unexpected citations/open access/authors: %+v", w)
+	}
+	if want := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC); !w.Published.Equal(want) {
+		t.Errorf("Published = %v, want %v", w.Published, want)
+	}
+	if !strings.Contains(*query, "mailto=me%40example.com") {
+		t.Errorf("Expected mailto in query, got %q", *query)
+	}
+
+	// 未注册的 DOI 不视为错误
+	w, err = c.LookupDOI(context.Background(), "10.9999/missing")
+	if err != nil || w != nil {
+		t.Errorf("LookupDOI(missing) = %+v, %v; want nil, nil", w, err)
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	if s := TitleSimilarity("BERT: Pre-training", "bert pre training"); s != 1 {
+		t.Errorf("Expected identical normalized titles, got %v", s)
+	}
+	if s := TitleSimilarity("", ""); s != 0 {
+		t.Errorf("Expected 0 for empty titles, got %v", s)
+	}
+}
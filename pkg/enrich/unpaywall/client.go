@@ -0,0 +1,129 @@
+package unpaywall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"PaperHunter/pkg/httplimit"
+	"PaperHunter/pkg/logger"
+	"PaperHunter/pkg/ratelimit"
+)
+
+const (
+	defaultBaseURL = "https://api.unpaywall.org/v2"
+	// DefaultRateLimit Unpaywall 限制每天 10 万次请求，这里保守地限速
+	DefaultRateLimit = 5.0
+)
+
+// Client Unpaywall REST API 客户端，按 DOI 查询论文的开放获取链接
+type Client struct {
+	BaseURL string
+	// Email Unpaywall 要求每个请求带上联系邮箱
+	Email string
+
+	httpClient *http.Client
+	limiter    ratelimit.Limiter
+}
+
+// NewClient 创建 Unpaywall 客户端，所有客户端共享同一个限速器
+func NewClient(email string) *Client {
+	return &Client{
+		BaseURL:    defaultBaseURL,
+		Email:      email,
+		httpClient: httplimit.Client(30 * time.Second),
+		limiter:    ratelimit.For("unpaywall", DefaultRateLimit),
+	}
+}
+
+type oaLocation struct {
+	URL       string `json:"url"`
+	URLForPDF string `json:"url_for_pdf"`
+}
+
+type doiResponse struct {
+	DOI            string       `json:"doi"`
+	IsOA           bool         `json:"is_oa"`
+	BestOALocation *oaLocation  `json:"best_oa_location"`
+	OALocations    []oaLocation `json:"oa_locations"`
+}
+
+// LookupPDF 按 DOI 查询开放获取链接，优先返回 PDF 链接，没有时返回落地页；
+// 论文不是开放获取或 DOI 未被 Unpaywall 收录时返回空字符串
+func (c *Client) LookupPDF(ctx context.Context, doi string) (string, error) {
+	doi = strings.TrimSpace(doi)
+	if doi == "" {
+		return "", nil
+	}
+	if c.Email == "" {
+		return "", fmt.Errorf("未配置 Unpaywall 联系邮箱")
+	}
+
+	u := strings.TrimSuffix(c.BaseURL, "/") + "/" + (&url.URL{Path: doi}).EscapedPath() +
+		"?" + url.Values{"email": {c.Email}}.Encode()
+	body, status, err := c.get(ctx, u)
+	if err != nil {
+		return "", err
+	}
+	if status == http.StatusNotFound {
+		logger.Debug("[Unpaywall] DOI 未收录: %s", doi)
+		return "", nil
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("Unpaywall HTTP %d: %s", status, strings.TrimSpace(string(body)))
+	}
+	return parsePDFURL(body)
+}
+
+// parsePDFURL 从 Unpaywall 响应中取出最佳开放获取位置的链接
+func parsePDFURL(body []byte) (string, error) {
+	var resp doiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("解析 Unpaywall 响应失败: %w", err)
+	}
+	if !resp.IsOA {
+		return "", nil
+	}
+	locations := resp.OALocations
+	if resp.BestOALocation != nil {
+		locations = append([]oaLocation{*resp.BestOALocation}, locations...)
+	}
+	for _, loc := range locations {
+		if loc.URLForPDF != "" {
+			return loc.URLForPDF, nil
+		}
+	}
+	for _, loc := range locations {
+		if loc.URL != "" {
+			return loc.URL, nil
+		}
+	}
+	return "", nil
+}
+
+func (c *Client) get(ctx context.Context, u string) ([]byte, int, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("User-Agent", "PaperHunter/1.0 (mailto:"+c.Email+")")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Unpaywall 请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, resp.StatusCode, nil
+}
@@ -0,0 +1,77 @@
+package unpaywall
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"PaperHunter/pkg/ratelimit"
+)
+
+// doiJSON 录制自 api.unpaywall.org/v2/10.18653/v1/2023.acl-long.1，省略了与解析无关的字段
+const doiJSON = `{"doi":"10.18653/v1/2023.acl-long.1","is_oa":true,
+ "best_oa_location":{"url":"https://aclanthology.org/2023.acl-long.1","url_for_pdf":"https://aclanthology.org/2023.acl-long.1.pdf"},
+ "oa_locations":[{"url":"https://arxiv.org/abs/2305.00001","url_for_pdf":null}]}`
+
+func TestParsePDFURL(t *testing.T) {
+	cases := []struct {
+		name, body, want string
+	}{
+		{"best location pdf", doiJSON, "https://aclanthology.org/2023.acl-long.1.pdf"},
+		{"falls back to other locations", `{"is_oa":true,"best_oa_location":{"url":"https://example.org/landing"},
+			"oa_locations":[{"url":"https://example.org/landing"},{"url":"https://repo.org/x","url_for_pdf":"https://repo.org/x.pdf"}]}`, "https://repo.org/x.pdf"},
+		{"landing page only", `{"is_oa":true,"best_oa_location":{"url":"https://example.org/landing"}}`, "https://example.org/landing"},
+		{"closed access", `{"is_oa":false,"best_oa_location":null,"oa_locations":[]}`, ""},
+	}
+	for _, tc := range cases {
+		got, err := parsePDFURL([]byte(tc.body))
+		if err != nil {
+			t.Errorf("%s: parsePDFURL() error: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: parsePDFURL() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+	if _, err := parsePDFURL([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestLookupPDF(t *testing.T) {
+	var email string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email = r.URL.Query().Get("email")
+		if r.URL.Path == "/10.18653/v1/2023.acl-long.1" {
+			w.Write([]byte(doiJSON))
+			return
+		}
+		http.Error(w, `{"HTTP_status_code":404,"error":true}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient("me@example.com")
+	c.BaseURL = srv.URL
+	c.limiter = ratelimit.Unlimited()
+
+	got, err := c.LookupPDF(context.Background(), "10.18653/v1/2023.acl-long.1")
+	if err != nil {
+		t.Fatalf("LookupPDF() error: %v", err)
+	}
+	if got != "https://aclanthology.org/2023.acl-long.1.pdf" {
+		t.Errorf("LookupPDF() = %q", got)
+	}
+	if email != "me@example.com" {
+		t.Errorf("Expected email query parameter, got %q", email)
+	}
+
+	if got, err := c.LookupPDF(context.Background(), "10.1000/missing"); err != nil || got != "" {
+		t.Errorf("LookupPDF() for unknown DOI = %q, %v; want empty", got, err)
+	}
+
+	c.Email = ""
+	if _, err := c.LookupPDF(context.Background(), "10.1000/x"); err == nil {
+		t.Error("Expected error without email")
+	}
+}
@@ -0,0 +1,131 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"PaperHunter/pkg/httplimit"
+	"PaperHunter/pkg/logger"
+)
+
+// DefaultTimeout timeout <= 0 时使用的整体请求超时
+const DefaultTimeout = 30 * time.Second
+
+type options struct {
+	maxIdleConns       int
+	disableCompression bool
+	userAgent          string
+	insecureSkipVerify bool
+	dialTimeout        time.Duration
+}
+
+// Option 定制 New 创建的客户端
+type Option func(*options)
+
+// WithMaxIdleConns 设置所有主机合计的空闲连接上限，<= 0 时使用 net/http 默认值
+func WithMaxIdleConns(n int) Option {
+	return func(o *options) { o.maxIdleConns = n }
+}
+
+// WithDisableCompression 禁止自动请求 gzip 压缩
+func WithDisableCompression(disable bool) Option {
+	return func(o *options) { o.disableCompression = disable }
+}
+
+// WithUserAgent 为未设置 User-Agent 的请求补充该值
+func WithUserAgent(ua string) Option {
+	return func(o *options) { o.userAgent = ua }
+}
+
+// WithInsecureSkipVerify 跳过 TLS 证书校验，仅用于自签证书的代理等场景
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(o *options) { o.insecureSkipVerify = skip }
+}
+
+// WithDialTimeout 设置建立 TCP 连接的超时，<= 0 时使用 30s
+func WithDialTimeout(d time.Duration) Option {
+	return func(o *options) { o.dialTimeout = d }
+}
+
+// ProxyRotator 提供每次请求使用的代理地址，返回空字符串表示直连；
+// 地址可以在请求之间变化（如 proxy.Rotator 在被封禁后切换到下一个代理）
+type ProxyRotator interface {
+	Current() string
+}
+
+// New 创建 HTTP 客户端，每个平台按自己的配置单独创建
+// - timeout: 整体请求超时，<= 0 时为 DefaultTimeout
+// - proxies: 代理来源，每次请求取其当前地址，例如 "http://127.0.0.1:7890"；为 nil 则不设置代理
+// 所有请求都受全局并发预算 httplimit 限制
+func New(timeout time.Duration, proxies ProxyRotator, opts ...Option) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	o := options{dialTimeout: 30 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.dialTimeout <= 0 {
+		o.dialTimeout = 30 * time.Second
+	}
+
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   o.dialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: o.insecureSkipVerify,
+			MinVersion:         tls.VersionTLS12,
+		},
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		MaxIdleConns:          o.maxIdleConns,
+		DisableCompression:    o.disableCompression,
+	}
+
+	if proxies != nil {
+		transport.Proxy = func(*http.Request) (*url.URL, error) {
+			proxy := proxies.Current()
+			if proxy == "" {
+				return nil, nil
+			}
+			proxyURL, err := url.Parse(proxy)
+			if err != nil || proxyURL.Host == "" {
+				logger.Warn("代理地址无效，已忽略: %s", proxy)
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+	}
+
+	var rt http.RoundTripper = transport
+	if o.userAgent != "" {
+		rt = userAgentTransport{base: rt, userAgent: o.userAgent}
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: httplimit.Transport(rt),
+	}
+}
+
+// userAgentTransport 为未设置 User-Agent 的请求补充默认值
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.base.RoundTrip(req)
+	}
+	// RoundTripper 不应修改传入的请求
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,141 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNew_UsesProxy(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 经代理的明文请求使用绝对 URL 作为请求行
+		mu.Lock()
+		seen = append(seen, r.URL.String())
+		mu.Unlock()
+		w.Write([]byte("via proxy"))
+	}))
+	defer proxy.Close()
+
+	client := New(5*time.Second, staticProxy(proxy.URL))
+	resp, err := client.Get("http://papers.example.com/list?page=2")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != "via proxy" {
+		t.Errorf("body = %q, want response from proxy", body)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 1 || seen[0] != "http://papers.example.com/list?page=2" {
+		t.Errorf("Proxy saw %v, want the target URL", seen)
+	}
+}
+
+// staticProxy 固定返回同一个代理地址
+type staticProxy string
+
+func (p staticProxy) Current() string { return string(p) }
+
+// switchableProxy 可在请求之间切换的代理地址
+type switchableProxy struct {
+	mu  sync.Mutex
+	url string
+}
+
+func (p *switchableProxy) Current() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.url
+}
+
+func (p *switchableProxy) set(url string) {
+	p.mu.Lock()
+	p.url = url
+	p.mu.Unlock()
+}
+
+func TestNew_ProxyFollowsRotator(t *testing.T) {
+	newProxy := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name))
+		}))
+	}
+	p1, p2 := newProxy("p1"), newProxy("p2")
+	defer p1.Close()
+	defer p2.Close()
+
+	rotator := &switchableProxy{url: p1.URL}
+	client := New(5*time.Second, rotator)
+	get := func() string {
+		resp, err := client.Get("http://papers.example.com/")
+		if err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	if got := get(); got != "p1" {
+		t.Errorf("First request via %q, want p1", got)
+	}
+	// 切换代理后同一个 client 的后续请求立即走新代理
+	rotator.set(p2.URL)
+	if got := get(); got != "p2" {
+		t.Errorf("Request after rotation via %q, want p2", got)
+	}
+}
+
+func TestNew_Options(t *testing.T) {
+	var gotUA, gotEncoding string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		gotEncoding = r.Header.Get("Accept-Encoding")
+	}))
+	defer srv.Close()
+
+	// 自签证书默认校验失败
+	if resp, err := New(5*time.Second, nil).Get(srv.URL); err == nil {
+		resp.Body.Close()
+		t.Fatal("Expected TLS verification error for self-signed certificate")
+	}
+
+	client := New(5*time.Second, nil,
+		WithInsecureSkipVerify(true),
+		WithUserAgent("PaperHunter-test"),
+		WithDisableCompression(true),
+		WithMaxIdleConns(4),
+		WithDialTimeout(time.Second),
+	)
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() with InsecureSkipVerify error: %v", err)
+	}
+	resp.Body.Close()
+	if gotUA != "PaperHunter-test" {
+		t.Errorf("User-Agent = %q, want PaperHunter-test", gotUA)
+	}
+	if gotEncoding != "" {
+		t.Errorf("Accept-Encoding = %q, want none with compression disabled", gotEncoding)
+	}
+
+	// 请求自带的 User-Agent 不被覆盖
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("User-Agent", "custom")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	resp.Body.Close()
+	if gotUA != "custom" {
+		t.Errorf("User-Agent = %q, want custom", gotUA)
+	}
+}
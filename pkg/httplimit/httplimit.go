@@ -0,0 +1,178 @@
+package httplimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultMaxConcurrent 全局同时进行的出站请求数上限
+const DefaultMaxConcurrent = 16
+
+// Budget 带权重的信号量，限制同时进行的请求总数；等待者按先来先得获取
+type Budget struct {
+	mu       sync.Mutex
+	max      int64
+	inFlight int64
+	waiters  []*waiter
+}
+
+type waiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// Stats 预算的当前使用情况
+type Stats struct {
+	Max      int64 `json:"max"`
+	InFlight int64 `json:"inFlight"`
+	Waiting  int   `json:"waiting"`
+}
+
+// New 创建上限为 max 的预算，max <= 0 时使用 DefaultMaxConcurrent
+func New(max int64) *Budget {
+	if max <= 0 {
+		max = DefaultMaxConcurrent
+	}
+	return &Budget{max: max}
+}
+
+// Acquire 占用 n 个名额，名额不足时阻塞直到可用或 ctx 结束；n 超过上限时按上限计
+func (b *Budget) Acquire(ctx context.Context, n int64) error {
+	b.mu.Lock()
+	n = min(n, b.max)
+	if len(b.waiters) == 0 && b.inFlight+n <= b.max {
+		b.inFlight += n
+		b.mu.Unlock()
+		return nil
+	}
+	w := &waiter{n: n, ready: make(chan struct{})}
+	b.waiters = append(b.waiters, w)
+	b.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		select {
+		case <-w.ready:
+			// 取消的同时已获得名额，归还
+			b.inFlight -= w.n
+			b.notifyLocked()
+		default:
+			for i, other := range b.waiters {
+				if other == w {
+					b.waiters = append(b.waiters[:i], b.waiters[i+1:]...)
+					break
+				}
+			}
+			b.notifyLocked()
+		}
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Release 归还 n 个名额，n 应与 Acquire 时一致
+func (b *Budget) Release(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inFlight -= min(n, b.max)
+	if b.inFlight < 0 {
+		b.inFlight = 0
+	}
+	b.notifyLocked()
+}
+
+// SetMax 调整上限，已占用的名额不受影响，max <= 0 时使用 DefaultMaxConcurrent
+func (b *Budget) SetMax(max int64) {
+	if max <= 0 {
+		max = DefaultMaxConcurrent
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.max = max
+	b.notifyLocked()
+}
+
+// Stats 返回当前上限、进行中的请求数和等待数
+func (b *Budget) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return Stats{Max: b.max, InFlight: b.inFlight, Waiting: len(b.waiters)}
+}
+
+// notifyLocked 按顺序唤醒名额足够的等待者，队首不满足时后面的也继续等待，避免大请求饿死
+func (b *Budget) notifyLocked() {
+	for len(b.waiters) > 0 {
+		w := b.waiters[0]
+		w.n = min(w.n, b.max)
+		if b.inFlight+w.n > b.max {
+			return
+		}
+		b.inFlight += w.n
+		b.waiters = b.waiters[1:]
+		close(w.ready)
+	}
+}
+
+var global = New(DefaultMaxConcurrent)
+
+// Global 所有出站请求共享的预算
+func Global() *Budget { return global }
+
+// SetMaxConcurrent 设置全局预算的上限，对应配置 http.max_concurrent
+func SetMaxConcurrent(max int) { global.SetMax(int64(max)) }
+
+// CurrentStats 返回全局预算的使用情况
+func CurrentStats() Stats { return global.Stats() }
+
+// Transport 包装 base，每个请求在发出前占用全局预算的一个名额，响应体关闭后归还；
+// base 为 nil 时使用 http.DefaultTransport
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return budgetTransport{base: base, budget: global}
+}
+
+// Client 返回使用全局预算的 HTTP 客户端，timeout 为 0 表示不超时
+func Client(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: Transport(nil)}
+}
+
+type budgetTransport struct {
+	base   http.RoundTripper
+	budget *Budget
+}
+
+func (t budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if err := t.budget.Acquire(req.Context(), 1); err != nil {
+		return nil, err
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		t.budget.Release(1)
+		return resp, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: func() { t.budget.Release(1) }}
+	return resp, nil
+}
+
+// releaseBody 关闭时归还名额，多次 Close 只归还一次
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
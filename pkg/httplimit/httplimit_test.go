@@ -0,0 +1,106 @@
+package httplimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransport_RespectsCeiling(t *testing.T) {
+	const limit, requests = 3, 20
+
+	var active, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	budget := New(limit)
+	client := &http.Client{Transport: budgetTransport{base: srv.Client().Transport, budget: budget}}
+
+	var wg sync.WaitGroup
+	var maxInFlight int64
+	var mu sync.Mutex
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Errorf("Get() error: %v", err)
+				return
+			}
+			mu.Lock()
+			maxInFlight = max(maxInFlight, budget.Stats().InFlight)
+			mu.Unlock()
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("server saw %d concurrent requests, want <= %d", peak, limit)
+	}
+	if maxInFlight > limit {
+		t.Errorf("Stats().InFlight reached %d, want <= %d", maxInFlight, limit)
+	}
+	if s := budget.Stats(); s.InFlight != 0 || s.Waiting != 0 || s.Max != limit {
+		t.Errorf("Expected budget to be fully released, got %+v", s)
+	}
+}
+
+func TestBudget_AcquireCanceled(t *testing.T) {
+	b := New(2)
+	if err := b.Acquire(context.Background(), 2); err != nil {
+		t.Fatalf("Acquire() error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Acquire(ctx, 1); err == nil {
+		t.Fatal("Expected Acquire() to fail when budget is exhausted")
+	}
+	if s := b.Stats(); s.InFlight != 2 || s.Waiting != 0 {
+		t.Errorf("Expected canceled waiter to be removed, got %+v", s)
+	}
+
+	// 大请求排在队首时，后来的小请求不能插队
+	done := make(chan struct{})
+	go func() {
+		b.Acquire(context.Background(), 2)
+		close(done)
+	}()
+	for b.Stats().Waiting == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	b.Release(1)
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	if err := b.Acquire(ctx2, 1); err == nil {
+		t.Error("Expected small request to wait behind the queued large request")
+	}
+	b.Release(1)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("queued weighted Acquire() was never granted")
+	}
+	if s := b.Stats(); s.InFlight != 2 {
+		t.Errorf("Stats().InFlight = %d, want 2", s.InFlight)
+	}
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"PaperHunter/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultPort metrics.port 未配置时的监听端口
+const DefaultPort = 9090
+
+// 进程内累计的计数器，由 core 和 embedding 在对应操作完成时递增
+var (
+	PapersCrawled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "paperhunter_papers_crawled_total",
+		Help: "Number of papers saved by crawls, by platform.",
+	}, []string{"platform"})
+
+	SearchRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "paperhunter_search_requests_total",
+		Help: "Number of local search requests, by search type.",
+	}, []string{"type"})
+
+	EmbedRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "paperhunter_embed_requests_total",
+		Help: "Number of requests sent to the embedding API.",
+	})
+
+	Exports = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "paperhunter_export_total",
+		Help: "Number of successful exports, by format.",
+	}, []string{"format"})
+)
+
+// PaperCounter 统计论文数量，core.App 满足该接口
+type PaperCounter interface {
+	CountPapers(ctx context.Context, conditions []string, params []interface{}) (int, error)
+}
+
+var (
+	papersTotalDesc = prometheus.NewDesc("paperhunter_papers_total",
+		"Number of papers in the database, excluding deleted ones.", nil, nil)
+	papersWithEmbeddingDesc = prometheus.NewDesc("paperhunter_papers_with_embedding",
+		"Number of papers in the database that have an embedding.", nil, nil)
+)
+
+// paperCollector 每次抓取时从数据库实时读取论文数量
+type paperCollector struct {
+	counter PaperCounter
+}
+
+// NewPaperCollector 返回导出 paperhunter_papers_total 和 paperhunter_papers_with_embedding 的 Collector
+func NewPaperCollector(counter PaperCounter) prometheus.Collector {
+	return &paperCollector{counter: counter}
+}
+
+func (c *paperCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- papersTotalDesc
+	ch <- papersWithEmbeddingDesc
+}
+
+func (c *paperCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gauges := []struct {
+		desc       *prometheus.Desc
+		conditions []string
+	}{
+		{papersTotalDesc, nil},
+		{papersWithEmbeddingDesc, []string{"embedding IS NOT NULL"}},
+	}
+	for _, g := range gauges {
+		n, err := c.counter.CountPapers(ctx, g.conditions, nil)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(g.desc, err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, float64(n))
+	}
+}
+
+// Handler 返回 Prometheus 文本格式的 /metrics 处理器，包含全部计数器和论文数量
+func Handler(counter PaperCounter) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(PapersCrawled, SearchRequests, EmbedRequests, Exports, NewPaperCollector(counter))
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+// Server 暴露 /metrics 的 HTTP 服务
+type Server struct {
+	srv *http.Server
+}
+
+// NewServer 创建监听 port 的 metrics 服务，port <= 0 时使用 DefaultPort
+func NewServer(port int, counter PaperCounter) *Server {
+	if port <= 0 {
+		port = DefaultPort
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(counter))
+	return &Server{srv: &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}}
+}
+
+// Start 监听端口并在后台提供服务，端口被占用等错误直接返回
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return fmt.Errorf("监听 %s 失败: %w", s.srv.Addr, err)
+	}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("metrics 服务异常退出: %v", err)
+		}
+	}()
+	logger.Info("metrics 服务已启动: http://localhost%s/metrics", s.srv.Addr)
+	return nil
+}
+
+// Shutdown 等待进行中的请求结束后关闭服务
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
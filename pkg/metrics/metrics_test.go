@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+type fakeCounter struct {
+	total, withEmbedding int
+	err                  error
+}
+
+func (c fakeCounter) CountPapers(ctx context.Context, conditions []string, params []interface{}) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	if len(conditions) > 0 {
+		return c.withEmbedding, nil
+	}
+	return c.total, nil
+}
+
+func scrape(t *testing.T, counter PaperCounter) map[string]*dto.MetricFamily {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler(counter).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != 200 {
+		t.Fatalf("GET /metrics status = %d, body: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(rec.Body)
+	if err != nil {
+		t.Fatalf("Invalid Prometheus text format: %v", err)
+	}
+	return families
+}
+
+func value(t *testing.T, families map[string]*dto.MetricFamily, name string, labels map[string]string) float64 {
+	t.Helper()
+	mf, ok := families[name]
+	if !ok {
+		t.Fatalf("Metric %s not found", name)
+	}
+	for _, m := range mf.GetMetric() {
+		match := true
+		for _, lp := range m.GetLabel() {
+			if labels[lp.GetName()] != lp.GetValue() {
+				match = false
+			}
+		}
+		if !match || len(m.GetLabel()) != len(labels) {
+			continue
+		}
+		switch {
+		case m.GetCounter() != nil:
+			return m.GetCounter().GetValue()
+		case m.GetGauge() != nil:
+			return m.GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("Metric %s%v not found", name, labels)
+	return 0
+}
+
+func TestHandler_ExposesCountersAndGauges(t *testing.T) {
+	before := scrape(t, fakeCounter{total: 1})
+	crawledBefore := 0.0
+	if _, ok := before["paperhunter_papers_crawled_total"]; ok {
+		crawledBefore = value(t, before, "paperhunter_papers_crawled_total", map[string]string{"platform": "test"})
+	}
+
+	PapersCrawled.WithLabelValues("test").Add(3)
+	SearchRequests.WithLabelValues("semantic").Inc()
+	EmbedRequests.Inc()
+	Exports.WithLabelValues("csv").Inc()
+
+	families := scrape(t, fakeCounter{total: 42, withEmbedding: 40})
+	if got := value(t, families, "paperhunter_papers_crawled_total", map[string]string{"platform": "test"}); got != crawledBefore+3 {
+		t.Errorf("papers_crawled_total = %v, want %v", got, crawledBefore+3)
+	}
+	if got := value(t, families, "paperhunter_search_requests_total", map[string]string{"type": "semantic"}); got < 1 {
+		t.Errorf("search_requests_total = %v, want >= 1", got)
+	}
+	if got := value(t, families, "paperhunter_embed_requests_total", nil); got < 1 {
+		t.Errorf("embed_requests_total = %v, want >= 1", got)
+	}
+	if got := value(t, families, "paperhunter_export_total", map[string]string{"format": "csv"}); got < 1 {
+		t.Errorf("export_total = %v, want >= 1", got)
+	}
+	if got := value(t, families, "paperhunter_papers_total", nil); got != 42 {
+		t.Errorf("papers_total = %v, want 42", got)
+	}
+	if got := value(t, families, "paperhunter_papers_with_embedding", nil); got != 40 {
+		t.Errorf("papers_with_embedding = %v, want 40", got)
+	}
+}
+
+func TestHandler_CountError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler(fakeCounter{err: fmt.Errorf("db closed")}).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != 500 {
+		t.Errorf("Expected status 500 when counting fails, got %d", rec.Code)
+	}
+}
+
+func TestServer_StartShutdown(t *testing.T) {
+	srv := NewServer(0, fakeCounter{})
+	srv.srv.Addr = "127.0.0.1:0"
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	if err := srv.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() error: %v", err)
+	}
+}
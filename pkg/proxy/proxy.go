@@ -1,8 +1,71 @@
 package proxy
 
-//考虑代理池的负载均衡，但配置可能会过于麻烦
+import (
+	"net/url"
+	"strings"
+	"sync"
 
-//"PaperHunter/config"
+	"PaperHunter/pkg/logger"
+)
 
-type Proxy struct {
+// Rotator 在多个代理之间轮询切换，单个代理被目标站点封禁（403/429）时由调用方切到下一个；并发安全
+type Rotator struct {
+	mu      sync.Mutex
+	proxies []string
+	current int
+}
+
+// NewRotator 以 proxies 创建轮询器，忽略空白、重复与无效的地址；
+// 没有可用代理时 Current 与 Next 都返回空字符串，即直连
+func NewRotator(proxies []string) *Rotator {
+	r := &Rotator{}
+	seen := make(map[string]bool)
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" || seen[p] {
+			continue
+		}
+		if u, err := url.Parse(p); err != nil || u.Host == "" {
+			logger.Warn("代理地址无效，已忽略: %s", p)
+			continue
+		}
+		seen[p] = true
+		r.proxies = append(r.proxies, p)
+	}
+	return r
+}
+
+// Current 返回当前使用的代理地址
+func (r *Rotator) Current() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.proxies) == 0 {
+		return ""
+	}
+	return r.proxies[r.current]
+}
+
+// Next 切换到下一个代理并返回其地址，到末尾后回到第一个
+func (r *Rotator) Next() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.proxies) == 0 {
+		return ""
+	}
+	r.current = (r.current + 1) % len(r.proxies)
+	return r.proxies[r.current]
+}
+
+// Len 返回可用代理数
+func (r *Rotator) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.proxies)
 }
@@ -0,0 +1,30 @@
+package proxy
+
+import "testing"
+
+func TestRotator_RoundRobin(t *testing.T) {
+	r := NewRotator([]string{" http://p1:8080 ", "", "http://p2:8080", "http://p1:8080", "://bad", "http://p3:8080"})
+	if r.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3 after dropping blank, duplicate and invalid entries", r.Len())
+	}
+	if got := r.Current(); got != "http://p1:8080" {
+		t.Errorf("Current() = %q, want http://p1:8080", got)
+	}
+	want := []string{"http://p2:8080", "http://p3:8080", "http://p1:8080"}
+	for i, w := range want {
+		if got := r.Next(); got != w {
+			t.Errorf("Next() #%d = %q, want %q", i+1, got, w)
+		}
+	}
+	if got := r.Current(); got != "http://p1:8080" {
+		t.Errorf("Current() after full cycle = %q, want http://p1:8080", got)
+	}
+}
+
+func TestRotator_Empty(t *testing.T) {
+	for _, r := range []*Rotator{nil, NewRotator(nil), NewRotator([]string{"", "  "})} {
+		if r.Len() != 0 || r.Current() != "" || r.Next() != "" {
+			t.Errorf("Expected empty rotator to connect directly, got Len=%d Current=%q", r.Len(), r.Current())
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package quantization
+
+import "math"
+
+// 向量量化：float32 向量按每个向量的取值范围缩放为 int8，存储空间降为原来的 1/4。
+// 采用以绝对值最大值为界的对称缩放（[-max, max] 映射到 [-127, 127]），不需要零点偏移，
+// 反量化后向量方向基本不变，余弦相似度误差通常在 1e-3 量级
+
+// maxInt8 量化后的最大绝对值，不使用 -128 以保持正负对称
+const maxInt8 = 127
+
+// QuantizeFloat32ToInt8 将向量量化为 int8 字节序列，缩放系数写入 scale（可为 nil）；
+// 全零向量的缩放系数为 0
+func QuantizeFloat32ToInt8(vec []float32, scale *float32) []byte {
+	var absMax float32
+	for _, v := range vec {
+		if a := float32(math.Abs(float64(v))); a > absMax {
+			absMax = a
+		}
+	}
+
+	s := absMax / maxInt8
+	out := make([]byte, len(vec))
+	if s > 0 {
+		for i, v := range vec {
+			q := math.Round(float64(v / s))
+			q = math.Max(-maxInt8, math.Min(maxInt8, q))
+			out[i] = byte(int8(q))
+		}
+	}
+	if scale != nil {
+		*scale = s
+	}
+	return out
+}
+
+// DequantizeInt8ToFloat32 按缩放系数还原 QuantizeFloat32ToInt8 的结果
+func DequantizeInt8ToFloat32(data []byte, scale float32) []float32 {
+	vec := make([]float32, len(data))
+	for i, b := range data {
+		vec[i] = float32(int8(b)) * scale
+	}
+	return vec
+}
@@ -0,0 +1,138 @@
+package quantization
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"PaperHunter/pkg/similarity"
+)
+
+const (
+	fixturePapers = 100
+	fixtureDim    = 2560
+)
+
+// fixture 固定种子生成 100 篇论文的向量与一个查询向量；论文向量围绕几个主题中心分布，
+// 每篇论文与主题中心的距离不同，查询靠近其中一个主题，接近真实检索时相似度的分布
+func fixture() (query []float32, papers [][]float32) {
+	r := rand.New(rand.NewSource(42))
+	centers := make([][]float32, 8)
+	for i := range centers {
+		centers[i] = randomVec(r, 1)
+	}
+	papers = make([][]float32, fixturePapers)
+	for i := range papers {
+		c := centers[i%len(centers)]
+		noise := randomVec(r, 0.3+float64(i)/fixturePapers)
+		vec := make([]float32, fixtureDim)
+		for j := range vec {
+			vec[j] = c[j] + noise[j]
+		}
+		papers[i] = vec
+	}
+	query = randomVec(r, 0.5)
+	for j := range query {
+		query[j] += centers[0][j]
+	}
+	return query, papers
+}
+
+func randomVec(r *rand.Rand, sigma float64) []float32 {
+	vec := make([]float32, fixtureDim)
+	for i := range vec {
+		vec[i] = float32(r.NormFloat64() * sigma)
+	}
+	return vec
+}
+
+// discordantPairs 两组相似度中相对顺序相反的论文对数（Kendall tau 距离）
+func discordantPairs(a, b []float32) int {
+	n := 0
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			if (a[i] > a[j]) != (b[i] > b[j]) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func roundTrip(vec []float32) []float32 {
+	var scale float32
+	return DequantizeInt8ToFloat32(QuantizeFloat32ToInt8(vec, &scale), scale)
+}
+
+func TestQuantize_RoundTrip(t *testing.T) {
+	vec := []float32{0.5, -1, 0.25, 0, 0.999}
+	var scale float32
+	data := QuantizeFloat32ToInt8(vec, &scale)
+	if len(data) != len(vec) {
+		t.Fatalf("Expected %d bytes, got %d", len(vec), len(data))
+	}
+	if want := float32(1) / 127; math.Abs(float64(scale-want)) > 1e-7 {
+		t.Errorf("scale = %v, want %v", scale, want)
+	}
+	got := DequantizeInt8ToFloat32(data, scale)
+	for i := range vec {
+		if math.Abs(float64(got[i]-vec[i])) > float64(scale)/2+1e-6 {
+			t.Errorf("component %d: got %v, want %v", i, got[i], vec[i])
+		}
+	}
+
+	if data := QuantizeFloat32ToInt8(make([]float32, 4), &scale); scale != 0 || len(data) != 4 {
+		t.Errorf("Expected zero scale for zero vector, got %v", scale)
+	}
+}
+
+// TestQuantize_PreservesRanking 量化前后按余弦相似度排序，相对顺序改变的论文对不超过 5%
+func TestQuantize_PreservesRanking(t *testing.T) {
+	query, papers := fixture()
+	before := make([]float32, len(papers))
+	after := make([]float32, len(papers))
+	var maxErr float64
+	for i, p := range papers {
+		before[i] = similarity.CosineSimilarity(query, p)
+		after[i] = similarity.CosineSimilarity(query, roundTrip(p))
+		maxErr = math.Max(maxErr, math.Abs(float64(before[i]-after[i])))
+	}
+
+	pairs := len(papers) * (len(papers) - 1) / 2
+	if n := discordantPairs(before, after); n*100 > 5*pairs {
+		t.Errorf("Rank order changed for %d/%d pairs, want at most 5%%", n, pairs)
+	}
+	if maxErr > 0.01 {
+		t.Errorf("Max cosine similarity error %v exceeds 0.01", maxErr)
+	}
+}
+
+func BenchmarkQuantize(b *testing.B) {
+	_, papers := fixture()
+	var scale float32
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		QuantizeFloat32ToInt8(papers[i%len(papers)], &scale)
+	}
+}
+
+// BenchmarkStorageAndError 报告 100 篇论文量化前后的存储字节数与余弦相似度的平均误差
+func BenchmarkStorageAndError(b *testing.B) {
+	query, papers := fixture()
+	for i := 0; i < b.N; i++ {
+		var floatBytes, int8Bytes int
+		var sumErr float64
+		for _, p := range papers {
+			var scale float32
+			data := QuantizeFloat32ToInt8(p, &scale)
+			floatBytes += len(p) * 4
+			int8Bytes += len(data) + 4 // 缩放系数单独存为一列
+			q := DequantizeInt8ToFloat32(data, scale)
+			sumErr += math.Abs(float64(similarity.CosineSimilarity(query, p) - similarity.CosineSimilarity(query, q)))
+		}
+		b.ReportMetric(float64(floatBytes), "float32-bytes")
+		b.ReportMetric(float64(int8Bytes), "int8-bytes")
+		b.ReportMetric(sumErr/float64(len(papers)), "cos-err/paper")
+	}
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter 请求限速器，每次发出请求前调用 Wait
+type Limiter interface {
+	// Wait 阻塞直到允许发出下一个请求，ctx 结束时返回错误
+	Wait(ctx context.Context) error
+}
+
+// New 创建每秒 rps 个请求的令牌桶限速器，桶容量为 1，请求均匀分布；rps <= 0 表示不限速
+func New(rps float64) Limiter {
+	if rps <= 0 {
+		return Unlimited()
+	}
+	return rate.NewLimiter(rate.Limit(rps), 1)
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = make(map[string]*rate.Limiter)
+)
+
+// For 返回按 key（平台名）共享的进程级限速器，同一平台的并发爬取共用令牌，避免多任务叠加请求速率；
+// rps 与已有限速器不同时更新其速率（如配置重载），rps <= 0 表示不限速
+func For(key string, rps float64) Limiter {
+	limit := rate.Inf
+	if rps > 0 {
+		limit = rate.Limit(rps)
+	}
+
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	l, ok := shared[key]
+	if !ok {
+		l = rate.NewLimiter(limit, 1)
+		shared[key] = l
+	} else if l.Limit() != limit {
+		l.SetLimit(limit)
+	}
+	return l
+}
+
+type unlimited struct{}
+
+func (unlimited) Wait(ctx context.Context) error { return ctx.Err() }
+
+// Unlimited 不限速的 Limiter
+func Unlimited() Limiter { return unlimited{} }
+
+// RetryAfter 解析 429/503 响应的 Retry-After 头（秒数或 HTTP 日期），缺失或无法解析时返回 0
+func RetryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNew_SpacesRequests(t *testing.T) {
+	l := New(20) // 50ms 一个令牌
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error: %v", err)
+		}
+	}
+	// 桶容量为 1，第一个请求立即通过，之后每 50ms 一个
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("5 requests at 20 rps took %v, want >= 200ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := New(0).Wait(ctx); err == nil {
+		t.Error("Expected unlimited Wait() to honor canceled context")
+	}
+}
+
+func TestFor_SharedAcrossGoroutines(t *testing.T) {
+	const perWorker = 3
+	start := time.Now()
+	var wg sync.WaitGroup
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 每个任务各自取限速器，模拟同一平台的两个并发爬取
+			l := For("test-shared", 20)
+			for i := 0; i < perWorker; i++ {
+				if err := l.Wait(context.Background()); err != nil {
+					t.Errorf("Wait() error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 共享 20 rps：6 个请求至少需要 5 个 50ms 间隔；若各自独立限速只需约 100ms
+	if elapsed := time.Since(start); elapsed < 230*time.Millisecond {
+		t.Errorf("6 requests sharing 20 rps took %v, want >= 250ms", elapsed)
+	}
+	if For("test-shared", 20) != For("test-shared", 20) {
+		t.Error("Expected the same limiter for the same key")
+	}
+	if For("test-shared", 20) == For("test-other", 20) {
+		t.Error("Expected different limiters for different keys")
+	}
+}
+
+func TestFor_UpdatesRate(t *testing.T) {
+	For("test-update", 1)
+	l := For("test-update", 0)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected unlimited rate after update, 5 requests took %v", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if d := RetryAfter(resp); d != 0 {
+		t.Errorf("RetryAfter() without header = %v, want 0", d)
+	}
+	resp.Header.Set("Retry-After", "3")
+	if d := RetryAfter(resp); d != 3*time.Second {
+		t.Errorf("RetryAfter() = %v, want 3s", d)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if d := RetryAfter(resp); d < 58*time.Second || d > time.Minute {
+		t.Errorf("RetryAfter() with date = %v, want ~1m", d)
+	}
+}
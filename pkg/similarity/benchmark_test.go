@@ -0,0 +1,172 @@
+package similarity
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// scalarCosine 优化前的逐元素实现，作为基准对照
+func scalarCosine(a, b []float32) float32 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float32
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (float32(math.Sqrt(float64(normA))) * float32(math.Sqrt(float64(normB))))
+}
+
+// referenceCosine 以 float64 累加的参考实现，用于校验数值精度
+func referenceCosine(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func randomVecs(rng *rand.Rand, n, dim int) [][]float32 {
+	vecs := make([][]float32, n)
+	for i := range vecs {
+		v := make([]float32, dim)
+		for j := range v {
+			v[j] = rng.Float32()*2 - 1
+		}
+		vecs[i] = v
+	}
+	return vecs
+}
+
+var (
+	benchDims = []int{128, 768, 1536, 2560}
+	benchDocs = []int{1, 100, 1000, 10000}
+	sink      float32
+)
+
+func TestCosineSimilarity_MatchesReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	// 覆盖不足一组 8 个元素与有余数的长度
+	for _, dim := range []int{1, 3, 8, 13, 128, 768, 1536, 2560} {
+		query := randomVecs(rng, 1, dim)[0]
+		docs := randomVecs(rng, 50, dim)
+		batch := CosineSimilarityBatch(query, docs)
+		for i, doc := range docs {
+			want := referenceCosine(query, doc)
+			if got := CosineSimilarity(query, doc); math.Abs(float64(got)-want) > 1e-6 {
+				t.Errorf("dim=%d doc=%d: CosineSimilarity() = %v, want %v", dim, i, got, want)
+			}
+			if math.Abs(float64(batch[i])-want) > 1e-6 {
+				t.Errorf("dim=%d doc=%d: CosineSimilarityBatch() = %v, want %v", dim, i, batch[i], want)
+			}
+		}
+	}
+}
+
+func TestCosineSimilarity_EdgeCases(t *testing.T) {
+	if got := CosineSimilarity(nil, nil); got != 0 {
+		t.Errorf("CosineSimilarity(nil, nil) = %v, want 0", got)
+	}
+	if got := CosineSimilarity([]float32{1, 2}, []float32{1}); got != 0 {
+		t.Errorf("Expected 0 for mismatched lengths, got %v", got)
+	}
+	if got := CosineSimilarity(make([]float32, 16), []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}); got != 0 {
+		t.Errorf("Expected 0 for zero vector, got %v", got)
+	}
+
+	query := []float32{1, 0, 0}
+	scores := CosineSimilarityBatch(query, [][]float32{{2, 0, 0}, {0, 1, 0}, {1, 2}, {0, 0, 0}, nil})
+	want := []float32{1, 0, 0, 0, 0}
+	for i := range want {
+		if scores[i] != want[i] {
+			t.Errorf("CosineSimilarityBatch()[%d] = %v, want %v", i, scores[i], want[i])
+		}
+	}
+	if scores := CosineSimilarityBatch(make([]float32, 3), [][]float32{{1, 2, 3}}); scores[0] != 0 {
+		t.Errorf("Expected 0 for zero query, got %v", scores[0])
+	}
+}
+
+// TestCosineSimilarity_Speedup 维度不低于 768 时，批量计算至少比逐元素实现快 3 倍；
+// 计时受机器负载影响，-short 或开启 race 检测时跳过
+func TestCosineSimilarity_Speedup(t *testing.T) {
+	if testing.Short() || raceEnabled {
+		t.Skip("skipping timing-sensitive test")
+	}
+	if !accelerated {
+		t.Skip("no accelerated kernel on this CPU")
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, dim := range []int{768, 1536, 2560} {
+		query := randomVecs(rng, 1, dim)[0]
+		docs := randomVecs(rng, 1000, dim)
+
+		scalar := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, doc := range docs {
+					sink = scalarCosine(query, doc)
+				}
+			}
+		})
+		batch := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sink = CosineSimilarityBatch(query, docs)[0]
+			}
+		})
+		speedup := float64(scalar.NsPerOp()) / float64(batch.NsPerOp())
+		t.Logf("dim=%d: scalar %v, batch %v, speedup %.1fx", dim, scalar.NsPerOp(), batch.NsPerOp(), speedup)
+		if speedup < 3 {
+			t.Errorf("dim=%d: expected at least 3x speedup over scalar, got %.2fx", dim, speedup)
+		}
+	}
+}
+
+func benchmarkCosine(b *testing.B, score func(query []float32, docs [][]float32)) {
+	rng := rand.New(rand.NewSource(1))
+	for _, dim := range benchDims {
+		query := randomVecs(rng, 1, dim)[0]
+		for _, n := range benchDocs {
+			docs := randomVecs(rng, n, dim)
+			b.Run(fmt.Sprintf("dim=%d/docs=%d", dim, n), func(b *testing.B) {
+				b.SetBytes(int64(n * dim * 4))
+				for i := 0; i < b.N; i++ {
+					score(query, docs)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkCosineScalar(b *testing.B) {
+	benchmarkCosine(b, func(query []float32, docs [][]float32) {
+		for _, doc := range docs {
+			sink = scalarCosine(query, doc)
+		}
+	})
+}
+
+func BenchmarkCosineSimilarity(b *testing.B) {
+	benchmarkCosine(b, func(query []float32, docs [][]float32) {
+		for _, doc := range docs {
+			sink = CosineSimilarity(query, doc)
+		}
+	})
+}
+
+func BenchmarkCosineSimilarityBatch(b *testing.B) {
+	benchmarkCosine(b, func(query []float32, docs [][]float32) {
+		sink = CosineSimilarityBatch(query, docs)[0]
+	})
+}
@@ -0,0 +1,26 @@
+package similarity
+
+import "golang.org/x/sys/cpu"
+
+// accelerated CPU 支持 AVX2 与 FMA 时使用汇编实现，每条指令处理 8 个 float32
+var accelerated = cpu.X86.HasAVX2 && cpu.X86.HasFMA
+
+// dotNormAVX2 计算 q、d 前 n 个元素的 q·d 与 |d|²，n 须为 8 的倍数
+//
+//go:noescape
+func dotNormAVX2(q, d *float32, n int) (dot, normD float32)
+
+// dotNorm 计算 q·d 与 |d|²，要求 len(q) == len(d)
+func dotNorm(q, d []float32) (dot, normD float32) {
+	n := len(q)
+	if !accelerated || n < block {
+		return dotNormGeneric(q, d)
+	}
+	m := n &^ (block - 1)
+	dot, normD = dotNormAVX2(&q[0], &d[0], m)
+	for i := m; i < n; i++ {
+		dot += q[i] * d[i]
+		normD += d[i] * d[i]
+	}
+	return dot, normD
+}
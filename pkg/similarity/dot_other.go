@@ -0,0 +1,11 @@
+//go:build !amd64
+
+package similarity
+
+// accelerated 当前平台没有汇编实现
+const accelerated = false
+
+// dotNorm 计算 q·d 与 |d|²，要求 len(q) == len(d)
+func dotNorm(q, d []float32) (dot, normD float32) {
+	return dotNormGeneric(q, d)
+}
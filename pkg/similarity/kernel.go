@@ -0,0 +1,59 @@
+package similarity
+
+import "unsafe"
+
+// Go 编译器不会自动向量化，纯 Go 实现按 8 个元素一组手动展开循环、用 4 个独立的累加器打断加法依赖链，
+// 并通过 unsafe 指针运算去掉逐元素的边界检查，使 CPU 能够流水线并行执行乘加
+
+// block 一次展开处理的元素个数
+const block = 8
+
+// at 返回 p 之后第 i 个 float32 开始的 8 个元素，调用方保证不越界
+func at(p unsafe.Pointer, i int) *[block]float32 {
+	return (*[block]float32)(unsafe.Add(p, uintptr(i)*4))
+}
+
+// dotNormGeneric 计算 q·d 与 |d|²，要求 len(q) == len(d)
+func dotNormGeneric(q, d []float32) (dot, normD float32) {
+	n := len(q)
+	pq, pd := unsafe.Pointer(unsafe.SliceData(q)), unsafe.Pointer(unsafe.SliceData(d))
+
+	var d0, d1, d2, d3, n0, n1, n2, n3 float32
+	i := 0
+	for ; i+block <= n; i += block {
+		x, y := at(pq, i), at(pd, i)
+		d0 += x[0]*y[0] + x[4]*y[4]
+		d1 += x[1]*y[1] + x[5]*y[5]
+		d2 += x[2]*y[2] + x[6]*y[6]
+		d3 += x[3]*y[3] + x[7]*y[7]
+		n0 += y[0]*y[0] + y[4]*y[4]
+		n1 += y[1]*y[1] + y[5]*y[5]
+		n2 += y[2]*y[2] + y[6]*y[6]
+		n3 += y[3]*y[3] + y[7]*y[7]
+	}
+	for ; i < n; i++ {
+		d0 += q[i] * d[i]
+		n0 += d[i] * d[i]
+	}
+	return (d0 + d1) + (d2 + d3), (n0 + n1) + (n2 + n3)
+}
+
+// sumSquares 计算 |v|²
+func sumSquares(v []float32) float32 {
+	n := len(v)
+	p := unsafe.Pointer(unsafe.SliceData(v))
+
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+block <= n; i += block {
+		x := at(p, i)
+		s0 += x[0]*x[0] + x[4]*x[4]
+		s1 += x[1]*x[1] + x[5]*x[5]
+		s2 += x[2]*x[2] + x[6]*x[6]
+		s3 += x[3]*x[3] + x[7]*x[7]
+	}
+	for ; i < n; i++ {
+		s0 += v[i] * v[i]
+	}
+	return (s0 + s1) + (s2 + s3)
+}
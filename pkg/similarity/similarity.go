@@ -1,29 +1,47 @@
 package similarity
 
-
 import (
 	"math"
 )
 
+// 检索时对库中每个向量都要计算一次余弦相似度，是全表扫描的热点。
+// 内层的点积与平方和由 dotNorm / sumSquares 计算：amd64 且支持 AVX2、FMA 时走汇编实现（dot_amd64.s），
+// 其余平台走按 8 个元素展开的纯 Go 实现（kernel.go）
 
-
-
-// cosineSimilarity 计算余弦相似度, search 时调用
+// CosineSimilarity 计算余弦相似度，长度不同、为空或任一向量为零向量时返回 0
 func CosineSimilarity(a, b []float32) float32 {
 	if len(a) != len(b) || len(a) == 0 {
 		return 0
 	}
-
-	var dot, normA, normB float32
-	for i := range a {
-		dot += a[i] * b[i]
-		normA += a[i] * a[i]
-		normB += b[i] * b[i]
+	dot, normB := dotNorm(a, b)
+	return cosine(dot, sumSquares(a), normB)
+}
+
+// CosineSimilarityBatch 计算 query 与 docs 中每个向量的余弦相似度，结果与 docs 一一对应；
+// query 的范数只计算一次，且 query 在整个批次中常驻缓存，比逐篇调用 CosineSimilarity 更快。
+// 与 query 长度不同或为零向量的文档得分为 0
+func CosineSimilarityBatch(query []float32, docs [][]float32) []float32 {
+	scores := make([]float32, len(docs))
+	if len(query) == 0 {
+		return scores
 	}
+	normQ := sumSquares(query)
+	if normQ == 0 {
+		return scores
+	}
+	for i, doc := range docs {
+		if len(doc) != len(query) {
+			continue
+		}
+		dot, normD := dotNorm(query, doc)
+		scores[i] = cosine(dot, normQ, normD)
+	}
+	return scores
+}
 
+func cosine(dot, normA, normB float32) float32 {
 	if normA == 0 || normB == 0 {
 		return 0
 	}
-
 	return dot / (float32(math.Sqrt(float64(normA))) * float32(math.Sqrt(float64(normB))))
-}
\ No newline at end of file
+}
@@ -8,15 +8,47 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	lark "github.com/larksuite/oapi-sdk-go/v3"
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
 	//larkdrive "github.com/larksuite/oapi-sdk-go/v3/service/drive/v1"
+
+	"PaperHunter/pkg/httplimit"
 )
 
 //const rootFolderEndpoint = "https://open.feishu.cn/open-apis/drive/explorer/v2/root_folder/meta"
 
+// bitableBaseURL 追加到已有多维表格时接口不返回链接，按 app_token 拼接
+const bitableBaseURL = "https://feishu.cn/base/"
+
+// 多维表格字段类型
+const (
+	fieldTypeText   = 1
+	fieldTypeNumber = 2
+	fieldTypeDate   = 5
+	fieldTypeURL    = 15
+)
+
+// columnTypes 按 CSV 导出器的表头决定字段类型，未列出的列均为文本
+var columnTypes = map[string]int{
+	"URL":    fieldTypeURL,
+	"引用数":    fieldTypeNumber,
+	"首次提交日期": fieldTypeDate,
+	"首次发布日期": fieldTypeDate,
+}
+
+// columnType 返回新建列时使用的字段类型
+func columnType(header string) int {
+	if t, ok := columnTypes[header]; ok {
+		return t
+	}
+	return fieldTypeText
+}
+
 // 上传 file 的时候可以使用 wails 的 runtime 来管理？
 
 // 可以指定名字，选取对应的文件，所以 feishu 的 config 需要 appid
@@ -36,8 +68,8 @@ func NewClient(appID, appSecret, fileName, folderName string) *Client {
 		AppSecret:    appSecret,
 		FileName:     fileName,
 		FolderName:   folderName, //飞书上多维表格的名字
-		httpClient:   http.DefaultClient,
-		feishuClient: lark.NewClient(appID, appSecret),
+		httpClient:   httplimit.Client(0),
+		feishuClient: lark.NewClient(appID, appSecret, lark.WithHttpClient(httplimit.Client(0))),
 	}
 }
 
@@ -88,6 +120,12 @@ func (c *Client) getTenantAccessToken() (string, error) {
 	return result.Data.TenantAccessToken, nil
 }
 
+// CheckCredentials 获取一次 Tenant Access Token，验证 app_id 与 app_secret 是否有效
+func (c *Client) CheckCredentials() error {
+	_, err := c.getTenantAccessToken()
+	return err
+}
+
 /*
 // getRootFolderToken 获取根文件夹 Token
 func (c *Client) getRootFolderToken(tenantAccessToken string) (string, error) {
@@ -278,8 +316,8 @@ func (c *Client) parseCSVFile(filePath string) ([]string, [][]string, error) {
 	return headers, records, nil
 }
 
-// convertCSVToBitableRecords 将 CSV 记录转换为飞书记录格式
-func (c *Client) convertCSVToBitableRecords(headers []string, csvRecords [][]string) ([]*larkbitable.AppTableRecord, error) {
+// convertCSVToBitableRecords 将 CSV 记录转换为飞书记录格式，按 types 中的字段类型编码值（缺省为文本）
+func (c *Client) convertCSVToBitableRecords(headers []string, csvRecords [][]string, types map[string]int) ([]*larkbitable.AppTableRecord, error) {
 	records := make([]*larkbitable.AppTableRecord, len(csvRecords))
 
 	for i, csvRow := range csvRecords {
@@ -287,9 +325,15 @@ func (c *Client) convertCSVToBitableRecords(headers []string, csvRecords [][]str
 		fields := make(map[string]interface{})
 
 		for j, header := range headers {
-			if j < len(csvRow) {
-				// 字段值为字符串
-				fields[header] = csvRow[j]
+			if j >= len(csvRow) {
+				continue
+			}
+			t, ok := types[header]
+			if !ok {
+				t = fieldTypeText
+			}
+			if value, ok := encodeFieldValue(t, csvRow[j]); ok {
+				fields[header] = value
 			}
 		}
 
@@ -301,6 +345,33 @@ func (c *Client) convertCSVToBitableRecords(headers []string, csvRecords [][]str
 	return records, nil
 }
 
+// encodeFieldValue 按字段类型编码单元格：数字为 float64，日期为毫秒时间戳，超链接为 {link, text}；
+// 非文本字段的值为空或无法解析时返回 false，该字段留空
+func encodeFieldValue(fieldType int, value string) (interface{}, bool) {
+	switch fieldType {
+	case fieldTypeNumber:
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, false
+		}
+		return n, true
+	case fieldTypeDate:
+		t, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(value), time.Local)
+		if err != nil {
+			return nil, false
+		}
+		return t.UnixMilli(), true
+	case fieldTypeURL:
+		link := strings.TrimSpace(value)
+		if link == "" {
+			return nil, false
+		}
+		return map[string]interface{}{"link": link, "text": link}, true
+	default:
+		return value, true
+	}
+}
+
 // UploadCSVToBitable 将 CSV 上传为多维表格
 func (c *Client) UploadCSVToBitable(csvFilePath string) (string, error) {
 
@@ -324,10 +395,12 @@ func (c *Client) UploadCSVToBitable(csvFilePath string) (string, error) {
 	//fmt.Printf("创建多维表格成功，token: %s\n", bitableToken)
 
 	fields := make([]*larkbitable.AppTableCreateHeader, len(headers)+1)
+	types := make(map[string]int, len(headers))
 	for i, header := range headers {
+		types[header] = columnType(header)
 		fields[i] = larkbitable.NewAppTableCreateHeaderBuilder().
 			FieldName(header).
-			Type(1).
+			Type(types[header]).
 			Build()
 	}
 
@@ -341,7 +414,7 @@ func (c *Client) UploadCSVToBitable(csvFilePath string) (string, error) {
 		return "", fmt.Errorf("创建数据表失败: %w", err)
 	}
 
-	bitableRecords, err := c.convertCSVToBitableRecords(headers, records)
+	bitableRecords, err := c.convertCSVToBitableRecords(headers, records, types)
 	if err != nil {
 		return "", fmt.Errorf("转换记录失败: %w", err)
 	}
@@ -350,5 +423,269 @@ func (c *Client) UploadCSVToBitable(csvFilePath string) (string, error) {
 		return "", fmt.Errorf("添加记录失败: %w", err)
 	}
 
-	return bitableURL, nil
+	return tableURL(bitableURL, bitableToken, tableId), nil
+}
+
+// AppendResult 追加到已有数据表的结果
+type AppendResult struct {
+	URL      string
+	Inserted int
+	Skipped  int // 数据表中已存在（按去重列判断）而跳过的行数
+}
+
+// AppendCSVToBitable 将 CSV 追加到已有多维表格的数据表，表中缺少的列会先按 columnTypes 创建，
+// 已有列按表中的实际字段类型编码；
+// dedupColumns 非空时先读取表中已有记录，跳过这些列的值与已有记录相同的行
+func (c *Client) AppendCSVToBitable(csvFilePath, appToken, tableID string, dedupColumns []string) (*AppendResult, error) {
+	if appToken == "" || tableID == "" {
+		return nil, fmt.Errorf("app_token 和 table_id 不能为空")
+	}
+
+	headers, records, err := c.parseCSVFile(csvFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("解析 CSV 失败: %w", err)
+	}
+
+	tenantAccessToken, err := c.getTenantAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("获取 tenant access token 失败: %w", err)
+	}
+
+	types, err := c.ensureFields(appToken, tableID, headers, tenantAccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("同步数据表字段失败: %w", err)
+	}
+
+	result := &AppendResult{URL: tableURL("", appToken, tableID)}
+	if len(dedupColumns) > 0 {
+		existing, err := c.listRecordKeys(appToken, tableID, dedupColumns, tenantAccessToken)
+		if err != nil {
+			return nil, fmt.Errorf("读取已有记录失败: %w", err)
+		}
+		var skipped int
+		records, skipped, err = filterExistingRows(headers, records, dedupColumns, existing)
+		if err != nil {
+			return nil, err
+		}
+		result.Skipped = skipped
+	}
+
+	bitableRecords, err := c.convertCSVToBitableRecords(headers, records, types)
+	if err != nil {
+		return nil, fmt.Errorf("转换记录失败: %w", err)
+	}
+
+	if err := c.addRecordsToBitable(appToken, tableID, bitableRecords, tenantAccessToken); err != nil {
+		return nil, fmt.Errorf("添加记录失败: %w", err)
+	}
+	result.Inserted = len(bitableRecords)
+
+	return result, nil
+}
+
+// listRecordKeys 分页读取数据表全部记录，返回去重列组成的键集合；去重列全为空的记录不计入
+func (c *Client) listRecordKeys(appToken, tableID string, dedupColumns []string, tenantAccessToken string) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	pageToken := ""
+	for {
+		builder := larkbitable.NewListAppTableRecordReqBuilder().
+			AppToken(appToken).
+			TableId(tableID).
+			FieldNames(fieldNamesParam(dedupColumns)).
+			PageSize(500)
+		if pageToken != "" {
+			builder = builder.PageToken(pageToken)
+		}
+
+		resp, err := c.feishuClient.Bitable.V1.AppTableRecord.List(context.Background(), builder.Build(), larkcore.WithTenantAccessToken(tenantAccessToken))
+		if err != nil {
+			return nil, fmt.Errorf("list records error: %w", err)
+		}
+		if !resp.Success() {
+			return nil, fmt.Errorf("list records failed: logId=%s, error=%s",
+				resp.RequestId(), larkcore.Prettify(resp.CodeError))
+		}
+
+		for _, item := range resp.Data.Items {
+			values := make([]string, len(dedupColumns))
+			for i, col := range dedupColumns {
+				values[i] = cellText(item.Fields[col])
+			}
+			if key := dedupKey(values); key != "" {
+				keys[key] = true
+			}
+		}
+		if resp.Data.HasMore == nil || !*resp.Data.HasMore || resp.Data.PageToken == nil {
+			return keys, nil
+		}
+		pageToken = *resp.Data.PageToken
+	}
+}
+
+// filterExistingRows 去掉键已在 existing 中的行（同一文件内的重复行也只保留第一行），返回保留的行和跳过数
+func filterExistingRows(headers []string, rows [][]string, dedupColumns []string, existing map[string]bool) ([][]string, int, error) {
+	index := make([]int, len(dedupColumns))
+	for i, col := range dedupColumns {
+		index[i] = -1
+		for j, header := range headers {
+			if header == col {
+				index[i] = j
+				break
+			}
+		}
+		if index[i] < 0 {
+			return nil, 0, fmt.Errorf("去重列不存在: %s", col)
+		}
+	}
+
+	kept := make([][]string, 0, len(rows))
+	skipped := 0
+	for _, row := range rows {
+		values := make([]string, len(index))
+		for i, j := range index {
+			if j < len(row) {
+				values[i] = row[j]
+			}
+		}
+		key := dedupKey(values)
+		if key != "" && existing[key] {
+			skipped++
+			continue
+		}
+		if key != "" {
+			existing[key] = true
+		}
+		kept = append(kept, row)
+	}
+	return kept, skipped, nil
+}
+
+// dedupKey 拼接去重列的值；全部为空时返回空串，表示该行不参与去重
+func dedupKey(values []string) string {
+	empty := true
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+		if values[i] != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return ""
+	}
+	return strings.Join(values, "\x00")
+}
+
+// cellText 将列表接口返回的单元格值还原为文本：文本字段为 [{"type":"text","text":...}]，超链接字段为 {"link":...,"text":...}
+func cellText(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []interface{}:
+		var sb strings.Builder
+		for _, item := range val {
+			sb.WriteString(cellText(item))
+		}
+		return sb.String()
+	case map[string]interface{}:
+		if link, ok := val["link"].(string); ok {
+			return link
+		}
+		if text, ok := val["text"].(string); ok {
+			return text
+		}
+		return ""
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// fieldNamesParam 列表接口的 field_names 参数为 JSON 数组字符串
+func fieldNamesParam(names []string) string {
+	data, _ := json.Marshal(names)
+	return string(data)
+}
+
+// ensureFields 为数据表补齐 headers 中不存在的列，返回字段名 -> 字段类型
+func (c *Client) ensureFields(appToken, tableID string, headers []string, tenantAccessToken string) (map[string]int, error) {
+	existing, err := c.listFieldTypes(appToken, tableID, tenantAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, header := range headers {
+		if _, ok := existing[header]; ok {
+			continue
+		}
+		req := larkbitable.NewCreateAppTableFieldReqBuilder().
+			AppToken(appToken).
+			TableId(tableID).
+			AppTableField(larkbitable.NewAppTableFieldBuilder().
+				FieldName(header).
+				Type(columnType(header)).
+				Build()).
+			Build()
+
+		resp, err := c.feishuClient.Bitable.V1.AppTableField.Create(context.Background(), req, larkcore.WithTenantAccessToken(tenantAccessToken))
+		if err != nil {
+			return nil, fmt.Errorf("create field error: %w", err)
+		}
+		if !resp.Success() {
+			return nil, fmt.Errorf("create field %s failed: logId=%s, error=%s",
+				header, resp.RequestId(), larkcore.Prettify(resp.CodeError))
+		}
+		existing[header] = columnType(header)
+	}
+	return existing, nil
+}
+
+// listFieldTypes 分页读取数据表的全部字段，返回字段名 -> 字段类型
+func (c *Client) listFieldTypes(appToken, tableID, tenantAccessToken string) (map[string]int, error) {
+	types := make(map[string]int)
+	pageToken := ""
+	for {
+		builder := larkbitable.NewListAppTableFieldReqBuilder().
+			AppToken(appToken).
+			TableId(tableID).
+			PageSize(100)
+		if pageToken != "" {
+			builder = builder.PageToken(pageToken)
+		}
+
+		resp, err := c.feishuClient.Bitable.V1.AppTableField.List(context.Background(), builder.Build(), larkcore.WithTenantAccessToken(tenantAccessToken))
+		if err != nil {
+			return nil, fmt.Errorf("list fields error: %w", err)
+		}
+		if !resp.Success() {
+			return nil, fmt.Errorf("list fields failed: logId=%s, error=%s",
+				resp.RequestId(), larkcore.Prettify(resp.CodeError))
+		}
+
+		for _, item := range resp.Data.Items {
+			if item.FieldName == nil {
+				continue
+			}
+			t := fieldTypeText
+			if item.Type != nil {
+				t = *item.Type
+			}
+			types[*item.FieldName] = t
+		}
+		if resp.Data.HasMore == nil || !*resp.Data.HasMore || resp.Data.PageToken == nil {
+			return types, nil
+		}
+		pageToken = *resp.Data.PageToken
+	}
+}
+
+// tableURL 返回直接打开指定数据表的链接；appURL 为空时按 app_token 拼接
+func tableURL(appURL, appToken, tableID string) string {
+	if appURL == "" {
+		appURL = bitableBaseURL + appToken
+	}
+	if i := strings.IndexAny(appURL, "?#"); i >= 0 {
+		appURL = appURL[:i]
+	}
+	return appURL + "?table=" + tableID
 }
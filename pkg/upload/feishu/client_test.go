@@ -0,0 +1,121 @@
+package feishu
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTableURL(t *testing.T) {
+	cases := []struct {
+		appURL, appToken, tableID, want string
+	}{
+		{"https://example.feishu.cn/base/bascn123?from=api", "bascn123", "tbl1", "https://example.feishu.cn/base/bascn123?table=tbl1"},
+		{"https://example.feishu.cn/base/bascn123#view", "bascn123", "tbl1", "https://example.feishu.cn/base/bascn123?table=tbl1"},
+		{"", "bascn123", "tbl2", "https://feishu.cn/base/bascn123?table=tbl2"},
+	}
+	for _, c := range cases {
+		if got := tableURL(c.appURL, c.appToken, c.tableID); got != c.want {
+			t.Errorf("tableURL(%q, %q, %q) = %q, want %q", c.appURL, c.appToken, c.tableID, got, c.want)
+		}
+	}
+}
+
+func TestFilterExistingRows(t *testing.T) {
+	headers := []string{"ID", "数据源", "平台ID", "URL"}
+	rows := [][]string{
+		{"1", "arxiv", "2401.00001", "https://arxiv.org/abs/2401.00001"},
+		{"2", "arxiv", "2401.00002", "https://arxiv.org/abs/2401.00002"},
+		{"3", "arxiv", "2401.00002", "https://arxiv.org/abs/2401.00002"}, // 同一文件内的重复行
+		{"4", "acl", "2024.acl-1", ""},
+	}
+	existing := map[string]bool{dedupKey([]string{"https://arxiv.org/abs/2401.00001"}): true}
+
+	kept, skipped, err := filterExistingRows(headers, rows, []string{"URL"}, existing)
+	if err != nil {
+		t.Fatalf("filterExistingRows() error: %v", err)
+	}
+	if skipped != 2 || len(kept) != 2 || kept[0][0] != "2" || kept[1][0] != "4" {
+		t.Errorf("expected rows 2 and 4 kept with 2 skipped, got %v (skipped %d)", kept, skipped)
+	}
+
+	existing = map[string]bool{dedupKey([]string{"acl", "2024.acl-1"}): true}
+	kept, skipped, err = filterExistingRows(headers, rows, []string{"数据源", "平台ID"}, existing)
+	if err != nil || skipped != 2 || len(kept) != 2 {
+		t.Errorf("expected 2 rows kept by source+source_id, got %d kept, %d skipped, %v", len(kept), skipped, err)
+	}
+
+	if _, _, err := filterExistingRows(headers, rows, []string{"DOI"}, map[string]bool{}); err == nil {
+		t.Error("expected error for unknown dedup column")
+	}
+}
+
+func TestCellText(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, ""},
+		{"plain", "plain"},
+		{[]interface{}{map[string]interface{}{"type": "text", "text": "Graph "}, map[string]interface{}{"type": "text", "text": "Transformers"}}, "Graph Transformers"},
+		{map[string]interface{}{"link": "https://arxiv.org/abs/2401.00001", "text": "arXiv"}, "https://arxiv.org/abs/2401.00001"},
+		{float64(42), "42"},
+	}
+	for _, c := range cases {
+		if got := cellText(c.value); got != c.want {
+			t.Errorf("cellText(%v) = %q, want %q", c.value, got, c.want)
+		}
+	}
+}
+
+func TestEncodeFieldValue(t *testing.T) {
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local).UnixMilli()
+	cases := []struct {
+		fieldType int
+		value     string
+		want      interface{}
+		ok        bool
+	}{
+		{fieldTypeText, "Graph Transformers", "Graph Transformers", true},
+		{fieldTypeText, "", "", true},
+		{fieldTypeNumber, "42", float64(42), true},
+		{fieldTypeNumber, "n/a", nil, false},
+		{fieldTypeDate, "2024-03-05", date, true},
+		{fieldTypeDate, "", nil, false},
+		{fieldTypeURL, "https://arxiv.org/abs/2401.00001", map[string]interface{}{"link": "https://arxiv.org/abs/2401.00001", "text": "https://arxiv.org/abs/2401.00001"}, true},
+		{fieldTypeURL, " ", nil, false},
+	}
+	for _, c := range cases {
+		got, ok := encodeFieldValue(c.fieldType, c.value)
+		if ok != c.ok || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("encodeFieldValue(%d, %q) = %v, %v; want %v, %v", c.fieldType, c.value, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestConvertCSVToBitableRecords_UsesFieldTypes(t *testing.T) {
+	headers := []string{"标题", "引用数", "URL", "首次提交日期"}
+	rows := [][]string{{"Paper", "7", "https://example.com/p", ""}}
+	types := map[string]int{"引用数": fieldTypeNumber, "URL": fieldTypeURL, "首次提交日期": fieldTypeDate}
+
+	records, err := (&Client{}).convertCSVToBitableRecords(headers, rows, types)
+	if err != nil {
+		t.Fatalf("convertCSVToBitableRecords() error: %v", err)
+	}
+	fields := records[0].Fields
+	if fields["标题"] != "Paper" || fields["引用数"] != float64(7) {
+		t.Errorf("unexpected text/number fields: %v", fields)
+	}
+	if link, _ := fields["URL"].(map[string]interface{}); link["link"] != "https://example.com/p" {
+		t.Errorf("expected URL encoded as hyperlink, got %v", fields["URL"])
+	}
+	if _, ok := fields["首次提交日期"]; ok {
+		t.Errorf("expected empty date to be omitted, got %v", fields["首次提交日期"])
+	}
+
+	// 已有数据表中未知类型的列按文本写入
+	records, _ = (&Client{}).convertCSVToBitableRecords(headers, rows, nil)
+	if records[0].Fields["引用数"] != "7" {
+		t.Errorf("expected text value without field types, got %v", records[0].Fields["引用数"])
+	}
+}
@@ -0,0 +1,209 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/httplimit"
+	"PaperHunter/pkg/logger"
+)
+
+const (
+	defaultBaseURL = "https://api.notion.com"
+	notionVersion  = "2022-06-28"
+
+	// batchSize 每批上传的论文数量
+	batchSize = 100
+	// requestInterval Notion 平均限速 3 req/s
+	requestInterval = time.Second / 3
+	// maxRetries 429 限流时的最大重试次数
+	maxRetries = 3
+	// maxTextLength 单个 rich_text 对象的最大长度
+	maxTextLength = 2000
+	// maxOptionLength select/multi_select 选项名的最大长度
+	maxOptionLength = 100
+	// maxArrayLength rich_text 与 multi_select 数组的最大元素数
+	maxArrayLength = 100
+)
+
+// Client Notion 数据库客户端，每篇论文对应数据库中的一页
+type Client struct {
+	IntegrationToken string
+	DatabaseID       string
+	baseURL          string
+	httpClient       *http.Client
+	sleep            func(time.Duration) // 便于测试替换
+}
+
+// NewClient 创建 Notion 客户端
+func NewClient(integrationToken, databaseID string) *Client {
+	return &Client{
+		IntegrationToken: integrationToken,
+		DatabaseID:       databaseID,
+		baseURL:          defaultBaseURL,
+		httpClient:       httplimit.Client(30 * time.Second),
+		sleep:            time.Sleep,
+	}
+}
+
+// AddPapers 将论文逐页写入数据库，按 100 篇分批，请求间隔控制在 3 req/s 以内
+func (c *Client) AddPapers(papers []*models.Paper) error {
+	if c.IntegrationToken == "" || c.DatabaseID == "" {
+		return fmt.Errorf("notion 配置不完整，需要 integration_token 和 database_id")
+	}
+
+	requests := 0
+	for i := 0; i < len(papers); i += batchSize {
+		end := i + batchSize
+		if end > len(papers) {
+			end = len(papers)
+		}
+
+		for _, paper := range papers[i:end] {
+			if paper == nil {
+				continue
+			}
+			if requests > 0 {
+				c.sleep(requestInterval)
+			}
+			requests++
+			if err := c.createPage(paper); err != nil {
+				return fmt.Errorf("failed to add paper %q: %w", paper.Title, err)
+			}
+		}
+		logger.Info("已添加论文 %d-%d 到 Notion", i+1, end)
+	}
+	return nil
+}
+
+// createPage 创建一页，遇到 429 按 Retry-After 等待后重试
+func (c *Client) createPage(paper *models.Paper) error {
+	body, err := json.Marshal(pageRequest{
+		Parent:     parent{DatabaseID: c.DatabaseID},
+		Properties: paperProperties(paper),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal page: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest("POST", c.baseURL+"/v1/pages", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.IntegrationToken)
+		req.Header.Set("Notion-Version", notionVersion)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return nil
+		case resp.StatusCode == http.StatusTooManyRequests && attempt < maxRetries:
+			wait := time.Second
+			if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+				wait = time.Duration(secs) * time.Second
+			}
+			logger.Warn("Notion 限流，%v 后重试（第 %d 次）", wait, attempt+1)
+			c.sleep(wait)
+		default:
+			return fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(respBody))
+		}
+	}
+}
+
+// paperProperties 论文字段到数据库属性的映射
+func paperProperties(p *models.Paper) map[string]interface{} {
+	props := map[string]interface{}{
+		"Title":      map[string]interface{}{"title": richText(p.Title)},
+		"Authors":    map[string]interface{}{"multi_select": options(p.Authors)},
+		"Abstract":   map[string]interface{}{"rich_text": richText(p.Abstract)},
+		"Categories": map[string]interface{}{"multi_select": options(p.Categories)},
+	}
+	if p.URL != "" {
+		props["URL"] = map[string]interface{}{"url": p.URL}
+	}
+	if p.Source != "" {
+		props["Source"] = map[string]interface{}{"select": map[string]string{"name": optionName(p.Source)}}
+	}
+	if !p.FirstSubmittedAt.IsZero() {
+		props["PublishedDate"] = map[string]interface{}{"date": map[string]string{"start": p.FirstSubmittedAt.Format("2006-01-02")}}
+	}
+	return props
+}
+
+// richText 按 2000 字符切分为多个 text 对象，最多 100 个，超出部分截断
+func richText(s string) []textObject {
+	runes := []rune(strings.TrimSpace(s))
+	var parts []textObject
+	for len(runes) > 0 && len(parts) < maxArrayLength {
+		n := len(runes)
+		if n > maxTextLength {
+			n = maxTextLength
+		}
+		parts = append(parts, textObject{Type: "text", Text: textContent{Content: string(runes[:n])}})
+		runes = runes[n:]
+	}
+	if parts == nil {
+		parts = []textObject{}
+	}
+	return parts
+}
+
+// options 转换为 multi_select 选项并去重，最多保留前 100 个
+func options(values []string) []map[string]string {
+	opts := make([]map[string]string, 0, len(values))
+	seen := make(map[string]bool)
+	for _, v := range values {
+		if len(opts) == maxArrayLength {
+			break
+		}
+		name := optionName(v)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		opts = append(opts, map[string]string{"name": name})
+	}
+	return opts
+}
+
+// optionName 选项名不允许包含逗号，且长度不超过 100
+func optionName(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, ",", " "))
+	if runes := []rune(s); len(runes) > maxOptionLength {
+		s = string(runes[:maxOptionLength])
+	}
+	return s
+}
+
+type pageRequest struct {
+	Parent     parent                 `json:"parent"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type parent struct {
+	DatabaseID string `json:"database_id"`
+}
+
+type textObject struct {
+	Type string      `json:"type"`
+	Text textContent `json:"text"`
+}
+
+type textContent struct {
+	Content string `json:"content"`
+}
@@ -0,0 +1,170 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"PaperHunter/internal/models"
+)
+
+type recordedPage struct {
+	Parent     parent                     `json:"parent"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	var sleeps []time.Duration
+	c := NewClient("secret_token", "db123")
+	c.baseURL = srv.URL
+	c.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return c, &sleeps
+}
+
+func samplePapers(n int) []*models.Paper {
+	papers := make([]*models.Paper, n)
+	for i := range papers {
+		papers[i] = &models.Paper{
+			Source:           "arxiv",
+			SourceID:         fmt.Sprintf("2401.%05d", i),
+			URL:              fmt.Sprintf("https://arxiv.org/abs/2401.%05d", i),
+			Title:            fmt.Sprintf("Paper %d", i),
+			Authors:          []string{"Alice Smith", "Bob, Jr."},
+			Abstract:         "An abstract.",
+			Categories:       []string{"cs.CL", "cs.LG", "cs.CL"},
+			FirstSubmittedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	return papers
+}
+
+func TestAddPapers_BatchesAndRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var pages []recordedPage
+	c, sleeps := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/pages" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer secret_token" || r.Header.Get("Notion-Version") == "" {
+			t.Errorf("missing auth or version headers")
+		}
+		var page recordedPage
+		if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		mu.Lock()
+		pages = append(pages, page)
+		mu.Unlock()
+		w.Write([]byte(`{"object":"page","id":"p"}`))
+	})
+
+	// 250 篇论文分 3 批（100/100/50），每篇一个请求
+	if err := c.AddPapers(samplePapers(250)); err != nil {
+		t.Fatalf("AddPapers() error: %v", err)
+	}
+	if len(pages) != 250 {
+		t.Fatalf("Expected 250 page requests, got %d", len(pages))
+	}
+
+	// 相邻请求之间都按 3 req/s 间隔休眠，跨批次也不例外
+	if len(*sleeps) != 249 {
+		t.Errorf("Expected 249 sleeps between requests, got %d", len(*sleeps))
+	}
+	for i, d := range *sleeps {
+		if d < time.Second/3 {
+			t.Errorf("sleep[%d] = %v, expected at least %v", i, d, time.Second/3)
+			break
+		}
+	}
+
+	first := pages[0]
+	if first.Parent.DatabaseID != "db123" {
+		t.Errorf("Expected database_id db123, got %q", first.Parent.DatabaseID)
+	}
+	for _, prop := range []string{"Title", "Authors", "Abstract", "URL", "Source", "PublishedDate", "Categories"} {
+		if _, ok := first.Properties[prop]; !ok {
+			t.Errorf("Missing property %s", prop)
+		}
+	}
+	authors := string(first.Properties["Authors"])
+	if strings.Contains(authors, "Bob,") || !strings.Contains(authors, "Alice Smith") {
+		t.Errorf("Unexpected Authors multi-select: %s", authors)
+	}
+	if n := strings.Count(string(first.Properties["Categories"]), "cs.CL"); n != 1 {
+		t.Errorf("Expected deduplicated categories, got %s", first.Properties["Categories"])
+	}
+	if !strings.Contains(string(first.Properties["PublishedDate"]), "2024-01-02") {
+		t.Errorf("Unexpected PublishedDate: %s", first.Properties["PublishedDate"])
+	}
+}
+
+func TestAddPapers_RetriesOn429(t *testing.T) {
+	calls := 0
+	c, sleeps := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"object":"error","code":"rate_limited"}`))
+			return
+		}
+		w.Write([]byte(`{"object":"page"}`))
+	})
+
+	if err := c.AddPapers(samplePapers(1)); err != nil {
+		t.Fatalf("AddPapers() error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls (429 then success), got %d", calls)
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != 2*time.Second {
+		t.Errorf("Expected a single Retry-After sleep of 2s, got %v", *sleeps)
+	}
+}
+
+func TestAddPapers_ErrorsAndConfig(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"object":"error","message":"Title is not a property that exists."}`))
+	})
+	if err := c.AddPapers(samplePapers(2)); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("Expected 400 error, got %v", err)
+	}
+
+	if err := NewClient("", "db").AddPapers(samplePapers(1)); err == nil {
+		t.Error("Expected error for missing integration token")
+	}
+}
+
+func TestRichTextSplitsLongAbstract(t *testing.T) {
+	parts := richText(strings.Repeat("a", 4500))
+	if len(parts) != 3 || len(parts[0].Text.Content) != maxTextLength || len(parts[2].Text.Content) != 500 {
+		t.Errorf("Unexpected rich text split: %d parts", len(parts))
+	}
+	if len(richText("")) != 0 {
+		t.Error("Expected empty rich text for empty string")
+	}
+}
+
+func TestArrayLimits(t *testing.T) {
+	if parts := richText(strings.Repeat("a", maxTextLength*(maxArrayLength+5))); len(parts) != maxArrayLength {
+		t.Errorf("Expected rich text capped at %d parts, got %d", maxArrayLength, len(parts))
+	}
+
+	authors := make([]string, 150)
+	for i := range authors {
+		authors[i] = fmt.Sprintf("Author %d", i)
+	}
+	if opts := options(authors); len(opts) != maxArrayLength || opts[0]["name"] != "Author 0" {
+		t.Errorf("Expected first %d authors as options, got %d", maxArrayLength, len(opts))
+	}
+}
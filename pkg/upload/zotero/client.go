@@ -3,34 +3,67 @@ package zotero
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"PaperHunter/internal/models"
+	"PaperHunter/pkg/httplimit"
 	"PaperHunter/pkg/logger"
 )
 
+// ErrRequestTooLarge Zotero 返回 413 Request Entity Too Large，提交的条目过多或过大
+var ErrRequestTooLarge = errors.New("zotero request entity too large")
+
+// 库类型
+const (
+	LibraryUser  = "user"  // 个人库 /users/<userID>
+	LibraryGroup = "group" // 群组库 /groups/<groupID>
+)
+
 type Client struct {
-	userID     string
-	apiKey     string
-	httpClient *http.Client
-	baseURL    string
+	userID        string
+	apiKey        string
+	libraryPath   string // 条目与 collection 所在库的路径，如 /users/123 或 /groups/456
+	httpClient    *http.Client
+	baseURL       string
+	syncStatePath string // 增量同步状态文件，为空时使用 DefaultSyncStatePath
 }
 
+// NewClient 创建操作个人库的客户端
 func NewClient(userID, apiKey string) *Client {
+	return NewLibraryClient(userID, apiKey, LibraryUser, "")
+}
+
+// NewLibraryClient 创建操作指定库的客户端：libraryType 为 group 时操作 groupID 对应的群组库，
+// groupID 为空时退回个人库
+func NewLibraryClient(userID, apiKey, libraryType, groupID string) *Client {
+	libraryPath := "/users/" + userID
+	if libraryType == LibraryGroup {
+		if groupID != "" {
+			libraryPath = "/groups/" + groupID
+		} else {
+			logger.Warn("zotero.library_type 为 group 但未设置 group_id，使用个人库")
+		}
+	}
 	return &Client{
-		userID:  userID,
-		apiKey:  apiKey,
-		baseURL: "https://api.zotero.org",
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		userID:      userID,
+		apiKey:      apiKey,
+		libraryPath: libraryPath,
+		baseURL:     "https://api.zotero.org",
+		httpClient:  httplimit.Client(30 * time.Second),
 	}
 }
 
+// libraryURL 拼接当前库下的接口地址，path 以 / 开头
+func (c *Client) libraryURL(path string) string {
+	return c.baseURL + c.libraryPath + path
+}
+
 // AddPaper 添加论文到 Zotero（使用统一的 models.Paper）
 func (c *Client) AddPaper(paper *models.Paper, collectionKey string) error {
 	item := c.paperToZoteroItem(paper, collectionKey)
@@ -41,7 +74,7 @@ func (c *Client) AddPaper(paper *models.Paper, collectionKey string) error {
 		return fmt.Errorf("解析失败: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/users/%s/items", c.baseURL, c.userID)
+	url := c.libraryURL("/items")
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -76,71 +109,108 @@ func (c *Client) AddPaper(paper *models.Paper, collectionKey string) error {
 	return nil
 }
 
-// AddPapers 批量添加论文
+// SkippedError 单篇提交仍被 Zotero 以 413 拒绝而跳过的论文，其余论文已上传
+type SkippedError struct {
+	Papers []*models.Paper
+}
+
+func (e *SkippedError) Error() string {
+	titles := make([]string, len(e.Papers))
+	for i, p := range e.Papers {
+		titles[i] = strconv.Quote(p.Title)
+	}
+	return fmt.Sprintf("%d papers too large for zotero were skipped: %s", len(e.Papers), strings.Join(titles, ", "))
+}
+
+// AddPapers 批量添加论文；有论文因过大被跳过时返回 *SkippedError
 func (c *Client) AddPapers(papers []*models.Paper, collectionKey string) error {
 	batchSize := 50
+	var skipped []*models.Paper
 	for i := 0; i < len(papers); i += batchSize {
 		end := i + batchSize
 		if end > len(papers) {
 			end = len(papers)
 		}
 		batch := papers[i:end]
-		if err := c.addPapersBatch(batch, collectionKey); err != nil {
+		res, err := c.postItemsSplitting(batch, collectionKey)
+		skipped = append(skipped, res.skipped...)
+		if err != nil {
 			return fmt.Errorf("failed to add batch %d-%d: %w", i, end, err)
 		}
 		fmt.Printf("Added papers %d-%d to Zotero\n", i+1, end)
 		// 429 避免触发速率限制， 请自行阅读 zotero 文档
 		time.Sleep(1 * time.Second)
 	}
+	if len(skipped) > 0 {
+		return &SkippedError{Papers: skipped}
+	}
 	return nil
 }
 
-// addPapersBatch 批量添加论文（单次请求）
-func (c *Client) addPapersBatch(papers []*models.Paper, collectionKey string) error {
-	items := make([]ItemData, len(papers))
-	for i, paper := range papers {
-		items[i] = c.paperToZoteroItem(paper, collectionKey)
-	}
+// postResult 一次（可能被拆分的）上传的结果
+type postResult struct {
+	added   []*models.Paper // Zotero 接受的论文
+	skipped []*models.Paper // 单篇提交仍然过大而跳过的论文
+	version int             // 响应中最大的库版本
+}
 
-	jsonData, err := json.Marshal(items)
-	if err != nil {
-		return fmt.Errorf("failed to marshal items: %w", err)
+// postItemsSplitting 上传一批论文，遇到 413 时对半拆分后分别重试，直到单篇提交；
+// 单篇仍然过大时跳过该论文并记录警告，不影响其余论文。出错时返回出错前已上传的结果
+func (c *Client) postItemsSplitting(papers []*models.Paper, collectionKey string) (postResult, error) {
+	failed, version, err := c.postItems(papers, collectionKey)
+	if err == nil {
+		res := postResult{version: version}
+		for i, p := range papers {
+			if !failed[strconv.Itoa(i)] {
+				res.added = append(res.added, p)
+			}
+		}
+		return res, nil
 	}
-
-	url := fmt.Sprintf("%s/users/%s/items", c.baseURL, c.userID)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	if !errors.Is(err, ErrRequestTooLarge) {
+		return postResult{}, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
-	req.Header.Set("Zotero-API-Version", "3")
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+	if len(papers) == 1 {
+		logger.Warn("论文过大，Zotero 拒绝单独提交，已跳过: %q (%d 字节)", papers[0].Title, c.itemSize(papers[0], collectionKey))
+		return postResult{skipped: papers}, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(body))
-	}
+	largest, size := c.largestPaper(papers, collectionKey)
+	mid := len(papers) / 2
+	logger.Warn("Zotero 返回 413，%d 篇论文拆分为 %d + %d 重试，最大条目: %q (%d 字节)",
+		len(papers), mid, len(papers)-mid, largest.Title, size)
 
-	var result CreateResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	res, err := c.postItemsSplitting(papers[:mid], collectionKey)
+	if err != nil {
+		return res, err
 	}
+	right, err := c.postItemsSplitting(papers[mid:], collectionKey)
+	res.added = append(res.added, right.added...)
+	res.skipped = append(res.skipped, right.skipped...)
+	res.version = max(res.version, right.version)
+	return res, err
+}
 
-	if len(result.Failed) > 0 {
-		fmt.Printf("Warning: %d papers failed to add\n", len(result.Failed))
-		for key, failed := range result.Failed {
-			fmt.Printf("  - Paper %s: %s\n", key, failed.Message)
+// largestPaper 找出序列化后体积最大的论文，用于定位导致 413 的条目
+func (c *Client) largestPaper(papers []*models.Paper, collectionKey string) (*models.Paper, int) {
+	var largest *models.Paper
+	maxSize := -1
+	for _, paper := range papers {
+		if size := c.itemSize(paper, collectionKey); size > maxSize {
+			largest, maxSize = paper, size
 		}
 	}
+	return largest, maxSize
+}
 
-	return nil
+// itemSize 论文转换为 Zotero 条目后的 JSON 字节数
+func (c *Client) itemSize(paper *models.Paper, collectionKey string) int {
+	data, err := json.Marshal(c.paperToZoteroItem(paper, collectionKey))
+	if err != nil {
+		return 0
+	}
+	return len(data)
 }
 
 // paperToZoteroItem 将统一 Paper 转换为 Zotero ItemData
@@ -186,8 +256,11 @@ func (c *Client) paperToZoteroItem(paper *models.Paper, collectionKey string) It
 	if paper.Abstract != "" {
 		item.AbstractNote = &paper.Abstract
 	}
+	// 没有论文页链接时退而使用开放获取的 PDF 链接
 	if paper.URL != "" {
 		item.URL = &paper.URL
+	} else if paper.OpenAccessURL != "" {
+		item.URL = &paper.OpenAccessURL
 	}
 	if repo != "" {
 		item.Repository = &repo
@@ -202,7 +275,7 @@ func (c *Client) paperToZoteroItem(paper *models.Paper, collectionKey string) It
 		date := paper.FirstSubmittedAt.Format("2006-01-02")
 		item.Date = &date
 	}
-	// 如果指定了集合，且是有效的 key 格式
+	// 如果指定了集合，且是有效的 key 格式（调用方应先通过 ResolveCollection 解析，此处仅做格式兜底）
 	if collectionKey != "" {
 		if c.isValidCollectionKey(collectionKey) {
 			item.Collections = []string{collectionKey}
@@ -214,6 +287,131 @@ func (c *Client) paperToZoteroItem(paper *models.Paper, collectionKey string) It
 	return item
 }
 
+// 集合无效时的处理方式
+const (
+	CollectionModeFail   = "fail"   // 直接返回错误
+	CollectionModeWarn   = "warn"   // 记录警告并添加到默认位置
+	CollectionModeCreate = "create" // 按名称查找，不存在则创建
+)
+
+// ResolveCollection 将用户输入的 collection key 或名称解析为有效的 key
+// 返回空串表示添加到默认位置
+func (c *Client) ResolveCollection(keyOrName string, mode string) (string, error) {
+	keyOrName = strings.TrimSpace(keyOrName)
+	if keyOrName == "" {
+		return "", nil
+	}
+	if mode == "" {
+		mode = CollectionModeWarn
+	}
+
+	collections, err := c.GetCollections()
+	if err != nil {
+		if mode == CollectionModeFail {
+			return "", fmt.Errorf("获取 collection 列表失败: %w", err)
+		}
+		// 无法校验时沿用旧逻辑：格式合法的 key 直接使用
+		logger.Warn("获取 Zotero collection 列表失败，跳过校验: %v", err)
+		if c.isValidCollectionKey(keyOrName) {
+			return keyOrName, nil
+		}
+		return "", nil
+	}
+
+	if key := findCollection(collections, keyOrName); key != "" {
+		return key, nil
+	}
+
+	switch mode {
+	case CollectionModeFail:
+		return "", fmt.Errorf("zotero collection '%s' 不存在", keyOrName)
+	case CollectionModeCreate:
+		// 列表中已按 key 与名称查找过，直接创建
+		return c.createCollection(keyOrName)
+	default:
+		logger.Warn("'%s' 不是有效的 Zotero collection，将添加到默认位置", keyOrName)
+		return "", nil
+	}
+}
+
+// EnsureCollection 按名称查找 collection，不存在时创建，返回其 key
+func (c *Client) EnsureCollection(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("collection name cannot be empty")
+	}
+
+	collections, err := c.GetCollections()
+	if err != nil {
+		return "", fmt.Errorf("获取 collection 列表失败: %w", err)
+	}
+	for _, col := range collections {
+		if strings.EqualFold(col.Data.Name, name) {
+			return col.Key, nil
+		}
+	}
+	return c.createCollection(name)
+}
+
+// createCollection 新建名为 name 的 collection，返回其 key
+func (c *Client) createCollection(name string) (string, error) {
+	jsonData, err := json.Marshal([]map[string]string{{"name": name}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal collection: %w", err)
+	}
+
+	url := c.libraryURL("/collections")
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Set("Zotero-API-Version", "3")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result CreateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	for _, failed := range result.Failed {
+		return "", fmt.Errorf("failed to create collection: %s", failed.Message)
+	}
+	key := result.Success["0"]
+	if key == "" {
+		return "", fmt.Errorf("failed to create collection: empty key in response")
+	}
+
+	logger.Info("已创建 Zotero collection: %s (%s)", name, key)
+	return key, nil
+}
+
+// findCollection 按 key 或名称（忽略大小写）查找 collection
+func findCollection(collections []Collection, keyOrName string) string {
+	for _, col := range collections {
+		if col.Key == keyOrName {
+			return col.Key
+		}
+	}
+	for _, col := range collections {
+		if strings.EqualFold(col.Data.Name, keyOrName) {
+			return col.Key
+		}
+	}
+	return ""
+}
+
 func (c *Client) isValidCollectionKey(key string) bool {
 	if len(key) < 6 || len(key) > 10 {
 		return false
@@ -271,7 +469,7 @@ func (c *Client) createTags(paper *models.Paper) []Tag {
 // CheckPaperExists 检查论文是否已存在（按平台与平台内ID）
 func (c *Client) CheckPaperExists(source string, sourceID string) (bool, error) {
 	key := fmt.Sprintf("%s:%s", strings.ToLower(source), sourceID)
-	url := fmt.Sprintf("%s/users/%s/items?q=%s", c.baseURL, c.userID, sourceID)
+	url := c.libraryURL("/items?q=" + sourceID)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -308,9 +506,9 @@ func (c *Client) CheckPaperExists(source string, sourceID string) (bool, error)
 	return false, nil
 }
 
-// GetCollections 获取用户的 collection 列表
+// GetCollections 获取当前库的 collection 列表
 func (c *Client) GetCollections() ([]Collection, error) {
-	url := fmt.Sprintf("%s/users/%s/collections", c.baseURL, c.userID)
+	url := c.libraryURL("/collections")
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -339,6 +537,37 @@ func (c *Client) GetCollections() ([]Collection, error) {
 	return collections, nil
 }
 
+// ListGroups 获取当前用户所属的群组库，其 ID 可作为 group_id 配置
+func (c *Client) ListGroups() ([]Group, error) {
+	url := fmt.Sprintf("%s/users/%s/groups", c.baseURL, c.userID)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Set("Zotero-API-Version", "3")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var groups []Group
+	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return groups, nil
+}
+
 // SelectCollection 交互式选择 collection
 func (c *Client) SelectCollection() (string, error) {
 	collections, err := c.GetCollections()
@@ -390,9 +619,9 @@ func (c *Client) GetPapers(collectionKey string, limit int) ([]*models.Paper, er
 	useCollection := collectionKey != ""
 
 	if useCollection {
-		url = fmt.Sprintf("%s/users/%s/collections/%s/items", c.baseURL, c.userID, collectionKey)
+		url = c.libraryURL("/collections/" + collectionKey + "/items")
 	} else {
-		url = fmt.Sprintf("%s/users/%s/items", c.baseURL, c.userID)
+		url = c.libraryURL("/items")
 	}
 
 	// 添加查询参数
@@ -420,7 +649,7 @@ func (c *Client) GetPapers(collectionKey string, limit int) ([]*models.Paper, er
 		logger.Warn("指定的 Zotero collection '%s' 不存在，将获取所有论文", collectionKey)
 
 		// 重新请求所有论文
-		url = fmt.Sprintf("%s/users/%s/items", c.baseURL, c.userID)
+		url = c.libraryURL("/items")
 		if limit > 0 {
 			url += fmt.Sprintf("?limit=%d", limit)
 		}
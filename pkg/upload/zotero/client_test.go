@@ -0,0 +1,183 @@
+package zotero
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+// oversizedServer 模拟 Zotero 的 413：包含大摘要的多条目请求被拒绝，
+// 摘要超过 rejectAlone 的条目即使单独提交也会被拒绝
+type oversizedServer struct {
+	mu          sync.Mutex
+	batchSizes  []int
+	posted      []string
+	largeAbs    int
+	rejectAlone int
+}
+
+func (s *oversizedServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// 增量同步先拉取已有条目，库为空
+		if r.Method == http.MethodGet {
+			w.Header().Set("Last-Modified-Version", "1")
+			w.Write([]byte("[]"))
+			return
+		}
+
+		var items []ItemData
+		if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+			t.Errorf("decode POST body: %v", err)
+		}
+		s.batchSizes = append(s.batchSizes, len(items))
+
+		for _, item := range items {
+			if item.AbstractNote == nil {
+				continue
+			}
+			size := len(*item.AbstractNote)
+			if (len(items) > 1 && size > s.largeAbs) || (s.rejectAlone > 0 && size > s.rejectAlone) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				w.Write([]byte("Request Entity Too Large"))
+				return
+			}
+		}
+
+		resp := CreateResponse{Success: map[string]string{}, Failed: map[string]FailedItem{}}
+		for i, item := range items {
+			s.posted = append(s.posted, item.Title)
+			resp.Success[strconv.Itoa(i)] = "KEY" + strconv.Itoa(len(s.posted))
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func oversizedPapers(n int, huge map[int]int) []*models.Paper {
+	papers := make([]*models.Paper, n)
+	for i := range papers {
+		abstract := "A short abstract."
+		if size, ok := huge[i]; ok {
+			abstract = strings.Repeat("x", size)
+		}
+		papers[i] = &models.Paper{
+			Source:   "arxiv",
+			SourceID: fmt.Sprintf("2401.%05d", i),
+			Title:    fmt.Sprintf("Paper %d", i),
+			Abstract: abstract,
+		}
+	}
+	return papers
+}
+
+func newOversizedClient(t *testing.T, s *oversizedServer) *Client {
+	t.Helper()
+	srv := httptest.NewServer(s.handler(t))
+	t.Cleanup(srv.Close)
+
+	c := NewClient("u1", "key")
+	c.baseURL = srv.URL
+	return c
+}
+
+func TestAddPapers_SplitsOn413(t *testing.T) {
+	s := &oversizedServer{largeAbs: 1000}
+	c := newOversizedClient(t, s)
+
+	papers := oversizedPapers(8, map[int]int{5: 5000})
+	if err := c.AddPapers(papers, ""); err != nil {
+		t.Fatalf("AddPapers() error: %v", err)
+	}
+
+	if len(s.posted) != 8 {
+		t.Fatalf("Expected all 8 papers posted after splitting, got %d: %v", len(s.posted), s.posted)
+	}
+	// 深度优先拆分：8(413) -> 4 + 4(413) -> 2(413) + 2，含大摘要的一对再拆为 1 + 1
+	expected := []int{8, 4, 4, 2, 1, 1, 2}
+	if fmt.Sprint(s.batchSizes) != fmt.Sprint(expected) {
+		t.Errorf("Expected batch sizes %v, got %v", expected, s.batchSizes)
+	}
+}
+
+func TestAddPapers_SkipsItemTooLargeAlone(t *testing.T) {
+	s := &oversizedServer{largeAbs: 1000, rejectAlone: 8000}
+	c := newOversizedClient(t, s)
+
+	papers := oversizedPapers(4, map[int]int{2: 10000})
+	var skipped *SkippedError
+	if err := c.AddPapers(papers, ""); !errors.As(err, &skipped) {
+		t.Fatalf("Expected SkippedError from AddPapers, got %v", err)
+	}
+	if len(skipped.Papers) != 1 || skipped.Papers[0] != papers[2] || !strings.Contains(skipped.Error(), `"Paper 2"`) {
+		t.Errorf("Expected Paper 2 to be reported as skipped, got %v", skipped)
+	}
+
+	// 单独提交仍被拒绝的论文被跳过，其余论文正常上传
+	if len(s.posted) != 3 {
+		t.Fatalf("Expected 3 papers posted, got %d: %v", len(s.posted), s.posted)
+	}
+	for _, title := range s.posted {
+		if title == "Paper 2" {
+			t.Errorf("Oversized paper should have been skipped")
+		}
+	}
+}
+
+func TestSyncPapers_SplitsOn413(t *testing.T) {
+	s := &oversizedServer{largeAbs: 1000, rejectAlone: 8000}
+	c := newOversizedClient(t, s)
+	c.SetSyncStatePath(filepath.Join(t.TempDir(), "zotero_sync_state.json"))
+
+	papers := oversizedPapers(6, map[int]int{1: 5000, 4: 10000})
+	added, _, err := c.SyncPapers(papers, "")
+	var skipped *SkippedError
+	if !errors.As(err, &skipped) || len(skipped.Papers) != 1 || skipped.Papers[0] != papers[4] {
+		t.Fatalf("Expected Paper 4 to be reported as skipped, got %v", err)
+	}
+	if added != 5 || len(s.posted) != 5 {
+		t.Errorf("Expected 5 papers added after splitting, got added=%d posted=%v", added, s.posted)
+	}
+
+	// 已上传的论文记入同步状态，再次同步时只重试被跳过的论文
+	added, known, err := c.SyncPapers(papers, "")
+	if !errors.As(err, &skipped) || added != 0 || known != 5 {
+		t.Errorf("Expected second sync to skip 5 known papers, got added=%d skipped=%d err=%v", added, known, err)
+	}
+}
+
+func TestPostItems_Returns413Error(t *testing.T) {
+	s := &oversizedServer{largeAbs: 1000}
+	c := newOversizedClient(t, s)
+
+	_, _, err := c.postItems(oversizedPapers(2, map[int]int{0: 2000}), "")
+	if !errors.Is(err, ErrRequestTooLarge) {
+		t.Errorf("Expected ErrRequestTooLarge, got %v", err)
+	}
+}
+
+func TestPaperToZoteroItem_FallsBackToOpenAccessURL(t *testing.T) {
+	c := NewClient("u1", "key")
+	pdf := "https://example.org/paper.pdf"
+
+	item := c.paperToZoteroItem(&models.Paper{Source: "acl", SourceID: "2024.acl-long.1", Title: "T", OpenAccessURL: pdf}, "")
+	if item.URL == nil || *item.URL != pdf {
+		t.Errorf("Expected URL to fall back to OpenAccessURL, got %v", item.URL)
+	}
+
+	page := "https://aclanthology.org/2024.acl-long.1"
+	item = c.paperToZoteroItem(&models.Paper{Source: "acl", SourceID: "2024.acl-long.1", Title: "T", URL: page, OpenAccessURL: pdf}, "")
+	if item.URL == nil || *item.URL != page {
+		t.Errorf("Expected paper URL to be kept, got %v", item.URL)
+	}
+}
@@ -0,0 +1,161 @@
+package zotero
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// collectionServer 返回固定的 collection 列表，记录请求并按需让列表请求失败
+type collectionServer struct {
+	mu       sync.Mutex
+	requests []string
+	created  []string
+	listFail bool
+}
+
+func (s *collectionServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	switch {
+	case r.URL.Path != "/users/u1/collections":
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet && s.listFail:
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode([]Collection{
+			{Key: "ABCD1234", Data: CollectionData{Name: "Reading List"}},
+			{Key: "EFGH5678", Data: CollectionData{Name: "Graphs"}},
+		})
+	case r.Method == http.MethodPost:
+		var body []map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) == 1 {
+			s.created = append(s.created, body[0]["name"])
+		}
+		json.NewEncoder(w).Encode(CreateResponse{Success: map[string]string{"0": "NEWKEY01"}})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *collectionServer) counts() (gets, posts int, created []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.requests {
+		switch r {
+		case "GET /users/u1/collections":
+			gets++
+		case "POST /users/u1/collections":
+			posts++
+		}
+	}
+	return gets, posts, append([]string(nil), s.created...)
+}
+
+func newCollectionClient(t *testing.T, s *collectionServer) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(s.handler))
+	t.Cleanup(srv.Close)
+
+	c := NewClient("u1", "key")
+	c.baseURL = srv.URL
+	return c
+}
+
+func TestResolveCollection_Existing(t *testing.T) {
+	for _, mode := range []string{CollectionModeFail, CollectionModeWarn, CollectionModeCreate} {
+		s := &collectionServer{}
+		c := newCollectionClient(t, s)
+
+		for input, want := range map[string]string{"EFGH5678": "EFGH5678", " reading list ": "ABCD1234", "": ""} {
+			got, err := c.ResolveCollection(input, mode)
+			if err != nil || got != want {
+				t.Errorf("mode %s: ResolveCollection(%q) = %q, %v; want %q", mode, input, got, err, want)
+			}
+		}
+		if _, posts, _ := s.counts(); posts != 0 {
+			t.Errorf("mode %s: expected no collection to be created, got %d POSTs", mode, posts)
+		}
+	}
+}
+
+func TestResolveCollection_Missing(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    string
+		wantErr bool
+		created []string
+	}{
+		{CollectionModeFail, "", true, nil},
+		{CollectionModeWarn, "", false, nil},
+		{"", "", false, nil},
+		{CollectionModeCreate, "NEWKEY01", false, []string{"New Papers"}},
+	}
+	for _, tt := range tests {
+		s := &collectionServer{}
+		c := newCollectionClient(t, s)
+
+		got, err := c.ResolveCollection("New Papers", tt.mode)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("mode %q: ResolveCollection() = %q, %v; want %q, error %v", tt.mode, got, err, tt.want, tt.wantErr)
+		}
+		// 创建时复用已获取的列表，不再重复请求
+		gets, _, created := s.counts()
+		if gets != 1 {
+			t.Errorf("mode %q: expected 1 collection list request, got %d", tt.mode, gets)
+		}
+		if len(created) != len(tt.created) || (len(created) > 0 && created[0] != tt.created[0]) {
+			t.Errorf("mode %q: created %v, want %v", tt.mode, created, tt.created)
+		}
+	}
+}
+
+func TestResolveCollection_ListFails(t *testing.T) {
+	tests := []struct {
+		mode    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{CollectionModeFail, "ABCD1234", "", true},
+		// 无法校验时格式合法的 key 直接使用，名称添加到默认位置
+		{CollectionModeWarn, "ABCD1234", "ABCD1234", false},
+		{CollectionModeWarn, "Reading List", "", false},
+		{CollectionModeCreate, "Reading List", "", false},
+	}
+	for _, tt := range tests {
+		s := &collectionServer{listFail: true}
+		c := newCollectionClient(t, s)
+
+		got, err := c.ResolveCollection(tt.input, tt.mode)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("mode %s: ResolveCollection(%q) = %q, %v; want %q, error %v", tt.mode, tt.input, got, err, tt.want, tt.wantErr)
+		}
+		if _, posts, _ := s.counts(); posts != 0 {
+			t.Errorf("mode %s: expected no collection to be created, got %d POSTs", tt.mode, posts)
+		}
+	}
+}
+
+func TestEnsureCollection(t *testing.T) {
+	s := &collectionServer{}
+	c := newCollectionClient(t, s)
+
+	if key, err := c.EnsureCollection("graphs"); err != nil || key != "EFGH5678" {
+		t.Errorf("EnsureCollection(graphs) = %q, %v; want EFGH5678", key, err)
+	}
+	if key, err := c.EnsureCollection("Fresh"); err != nil || key != "NEWKEY01" {
+		t.Errorf("EnsureCollection(Fresh) = %q, %v; want NEWKEY01", key, err)
+	}
+	if _, err := c.EnsureCollection("  "); err == nil {
+		t.Error("Expected error for an empty collection name")
+	}
+	if gets, posts, created := s.counts(); gets != 2 || posts != 1 || len(created) != 1 || created[0] != "Fresh" {
+		t.Errorf("Expected 2 list requests and one create for Fresh, got %d GETs, %d POSTs, created %v", gets, posts, created)
+	}
+}
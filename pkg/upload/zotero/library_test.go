@@ -0,0 +1,132 @@
+package zotero
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// libraryServer 记录请求路径，只在 /groups/g42 下返回条目
+type libraryServer struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (s *libraryServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.paths = append(s.paths, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/groups/g42/items":
+			id := "2401.00001"
+			json.NewEncoder(w).Encode([]Item{
+				{Key: "A1", Data: ItemData{ItemType: "preprint", Title: "Group Paper", ArchiveID: &id}},
+				{Key: "A2", Data: ItemData{ItemType: "note", Title: "Meeting notes"}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/users/u1/groups":
+			json.NewEncoder(w).Encode([]Group{{ID: 42, Data: GroupData{ID: 42, Name: "Lab"}}})
+		case r.Method == http.MethodGet:
+			w.Write([]byte("[]"))
+		default:
+			json.NewEncoder(w).Encode(CreateResponse{Success: map[string]string{"0": "KEY1"}})
+		}
+	}
+}
+
+func (s *libraryServer) requested() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.paths...)
+}
+
+func newLibraryClient(t *testing.T, s *libraryServer, libraryType, groupID string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(s.handler(t))
+	t.Cleanup(srv.Close)
+
+	c := NewLibraryClient("u1", "key", libraryType, groupID)
+	c.baseURL = srv.URL
+	return c
+}
+
+func TestLibraryClient_GroupPaths(t *testing.T) {
+	s := &libraryServer{}
+	c := newLibraryClient(t, s, LibraryGroup, "g42")
+
+	if _, err := c.GetCollections(); err != nil {
+		t.Fatalf("GetCollections() error: %v", err)
+	}
+	if err := c.AddPaper(oversizedPapers(1, nil)[0], ""); err != nil {
+		t.Fatalf("AddPaper() error: %v", err)
+	}
+	if _, err := c.CheckPaperExists("arxiv", "2401.00000"); err != nil {
+		t.Fatalf("CheckPaperExists() error: %v", err)
+	}
+	if _, err := c.GetPapers("COLL1234", 0); err != nil {
+		t.Fatalf("GetPapers() error: %v", err)
+	}
+
+	want := []string{
+		"GET /groups/g42/collections",
+		"POST /groups/g42/items",
+		"GET /groups/g42/items",
+		"GET /groups/g42/collections/COLL1234/items",
+	}
+	got := s.requested()
+	if len(got) != len(want) {
+		t.Fatalf("Requested %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Request %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLibraryClient_FallsBackToUserLibrary(t *testing.T) {
+	for _, tc := range []struct{ libraryType, groupID string }{
+		{LibraryUser, ""},
+		{LibraryGroup, ""},
+		{"", "g42"},
+	} {
+		s := &libraryServer{}
+		c := newLibraryClient(t, s, tc.libraryType, tc.groupID)
+		if _, err := c.GetCollections(); err != nil {
+			t.Fatalf("GetCollections() error: %v", err)
+		}
+		if got := s.requested(); len(got) != 1 || got[0] != "GET /users/u1/collections" {
+			t.Errorf("library_type=%q group_id=%q requested %v, want user library", tc.libraryType, tc.groupID, got)
+		}
+	}
+}
+
+func TestLibraryClient_GetPapersFromGroup(t *testing.T) {
+	s := &libraryServer{}
+	c := newLibraryClient(t, s, LibraryGroup, "g42")
+
+	papers, err := c.GetPapers("", 0)
+	if err != nil {
+		t.Fatalf("GetPapers() error: %v", err)
+	}
+	if len(papers) != 1 || papers[0].Title != "Group Paper" || papers[0].SourceID != "2401.00001" {
+		t.Errorf("GetPapers() = %+v, want the single group preprint", papers)
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	s := &libraryServer{}
+	// 群组列表始终按用户查询，与当前操作的库无关
+	c := newLibraryClient(t, s, LibraryGroup, "g42")
+
+	groups, err := c.ListGroups()
+	if err != nil {
+		t.Fatalf("ListGroups() error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].ID != 42 || groups[0].Data.Name != "Lab" {
+		t.Errorf("ListGroups() = %+v, want group 42 Lab", groups)
+	}
+}
@@ -0,0 +1,297 @@
+package zotero
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"PaperHunter/internal/models"
+	"PaperHunter/pkg/logger"
+)
+
+// SyncState 增量同步状态：记录已同步到的库版本以及库中已存在的 source:source_id
+type SyncState struct {
+	UserID         string    `json:"user_id"`
+	Library        string    `json:"library,omitempty"` // 库路径，如 /groups/456；旧状态文件为空，视为个人库
+	LibraryVersion int       `json:"library_version"`
+	Keys           []string  `json:"keys"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// syncPageSize 拉取条目时的分页大小（API 上限 100）
+const syncPageSize = 100
+
+// DefaultSyncStatePath 默认同步状态文件 ~/.quicksearch/data/zotero_sync_state.json
+func DefaultSyncStatePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".quicksearch", "data", "zotero_sync_state.json")
+	}
+	return filepath.Join(home, ".quicksearch", "data", "zotero_sync_state.json")
+}
+
+// SetSyncStatePath 设置同步状态文件路径
+func (c *Client) SetSyncStatePath(path string) {
+	c.syncStatePath = path
+}
+
+// SyncPapers 增量同步论文：Extra 中已存在相同 source:source_id 的论文跳过，仅上传新论文
+// 首次同步拉取整个库，之后通过 since=<libraryVersion> 只拉取变更的条目；
+// 与 AddPapers 一样遇到 413 时拆分重试，有论文因过大被跳过时返回 *SkippedError
+func (c *Client) SyncPapers(papers []*models.Paper, collectionKey string) (added int, skipped int, err error) {
+	state := c.loadSyncState()
+
+	known, version, err := c.fetchItemKeys(state.LibraryVersion)
+	if err != nil {
+		return 0, 0, fmt.Errorf("获取 Zotero 库条目失败: %w", err)
+	}
+	keySet := make(map[string]bool, len(state.Keys)+len(known))
+	for _, k := range state.Keys {
+		keySet[k] = true
+	}
+	for _, k := range known {
+		keySet[k] = true
+	}
+	if version > state.LibraryVersion {
+		state.LibraryVersion = version
+	}
+
+	var pending []*models.Paper
+	queued := make(map[string]bool)
+	for _, p := range papers {
+		if p == nil {
+			continue
+		}
+		key := paperSyncKey(p)
+		if key != "" && (keySet[key] || queued[key]) {
+			skipped++
+			continue
+		}
+		if key != "" {
+			// 同一批次内重复的论文只上传一次
+			queued[key] = true
+		}
+		pending = append(pending, p)
+	}
+	logger.Info("Zotero 增量同步: 待上传 %d 篇，已存在跳过 %d 篇", len(pending), skipped)
+
+	batchSize := 50
+	var tooLarge []*models.Paper
+	for i := 0; i < len(pending); i += batchSize {
+		end := i + batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if i > 0 {
+			// 429 避免触发速率限制
+			time.Sleep(1 * time.Second)
+		}
+
+		batch := pending[i:end]
+		res, postErr := c.postItemsSplitting(batch, collectionKey)
+		if res.version > state.LibraryVersion {
+			state.LibraryVersion = res.version
+		}
+		for _, p := range res.added {
+			added++
+			if key := paperSyncKey(p); key != "" {
+				keySet[key] = true
+			}
+		}
+		tooLarge = append(tooLarge, res.skipped...)
+		if postErr != nil {
+			// 已上传的论文仍需记录，避免下次重复上传
+			c.saveSyncState(state, keySet)
+			return added, skipped, fmt.Errorf("failed to add batch %d-%d: %w", i, end, postErr)
+		}
+	}
+
+	c.saveSyncState(state, keySet)
+	if len(tooLarge) > 0 {
+		return added, skipped, &SkippedError{Papers: tooLarge}
+	}
+	return added, skipped, nil
+}
+
+// paperSyncKey 论文在 Extra 中的标识，与 paperToZoteroItem 写入的格式一致
+func paperSyncKey(p *models.Paper) string {
+	if p.Source == "" || p.SourceID == "" {
+		return ""
+	}
+	return strings.ToLower(fmt.Sprintf("%s:%s", p.Source, p.SourceID))
+}
+
+// extraKeys 从 Extra 字段提取每一行作为候选标识
+func extraKeys(extra string) []string {
+	var keys []string
+	for _, line := range strings.Split(extra, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if strings.Contains(line, ":") {
+			keys = append(keys, line)
+		}
+	}
+	return keys
+}
+
+// fetchItemKeys 拉取自 since 版本之后变更的条目，返回其中的标识与当前库版本
+func (c *Client) fetchItemKeys(since int) ([]string, int, error) {
+	var keys []string
+	version := 0
+	for start := 0; ; start += syncPageSize {
+		url := c.libraryURL(fmt.Sprintf("/items?format=json&itemType=-attachment&limit=%d&start=%d", syncPageSize, start))
+		if since > 0 {
+			url += "&since=" + strconv.Itoa(since)
+		}
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+		req.Header.Set("Zotero-API-Version", "3")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, 0, fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(body))
+		}
+		if v, err := strconv.Atoi(resp.Header.Get("Last-Modified-Version")); err == nil && v > version {
+			version = v
+		}
+
+		var items []Item
+		err = json.NewDecoder(resp.Body).Decode(&items)
+		resp.Body.Close()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		for _, item := range items {
+			if item.Data.Extra != nil {
+				keys = append(keys, extraKeys(*item.Data.Extra)...)
+			}
+		}
+
+		if len(items) < syncPageSize {
+			break
+		}
+	}
+	return keys, version, nil
+}
+
+// postItems 上传一批论文（单次请求），返回失败条目的序号集合与响应中的库版本；
+// Zotero 返回 413 时返回包装了 ErrRequestTooLarge 的错误
+func (c *Client) postItems(papers []*models.Paper, collectionKey string) (map[string]bool, int, error) {
+	items := make([]ItemData, len(papers))
+	for i, paper := range papers {
+		items[i] = c.paperToZoteroItem(paper, collectionKey)
+	}
+
+	jsonData, err := json.Marshal(items)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal items: %w", err)
+	}
+
+	url := c.libraryURL("/items")
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Set("Zotero-API-Version", "3")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, fmt.Errorf("%w: %d items, %s", ErrRequestTooLarge, len(items), strings.TrimSpace(string(body)))
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, fmt.Errorf("API returned error %d: %s", resp.StatusCode, string(body))
+	}
+	version, _ := strconv.Atoi(resp.Header.Get("Last-Modified-Version"))
+
+	var result CreateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, version, fmt.Errorf("failed to decode response: %w", err)
+	}
+	failed := make(map[string]bool, len(result.Failed))
+	for idx, item := range result.Failed {
+		logger.Warn("Zotero 条目 %s 上传失败: %s", idx, item.Message)
+		failed[idx] = true
+	}
+
+	return failed, version, nil
+}
+
+func (c *Client) statePath() string {
+	if c.syncStatePath != "" {
+		return c.syncStatePath
+	}
+	return DefaultSyncStatePath()
+}
+
+// loadSyncState 读取同步状态，文件不存在或属于其他用户、其他库时从头开始
+func (c *Client) loadSyncState() *SyncState {
+	state := &SyncState{UserID: c.userID, Library: c.libraryPath}
+	data, err := os.ReadFile(c.statePath())
+	if err != nil {
+		return state
+	}
+	var saved SyncState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		logger.Warn("解析 Zotero 同步状态失败，将执行全量检查: %v", err)
+		return state
+	}
+	if saved.Library == "" {
+		saved.Library = "/users/" + saved.UserID
+	}
+	if saved.UserID != c.userID || saved.Library != c.libraryPath {
+		return state
+	}
+	return &saved
+}
+
+func (c *Client) saveSyncState(state *SyncState, keySet map[string]bool) {
+	state.UserID = c.userID
+	state.Library = c.libraryPath
+	state.UpdatedAt = time.Now()
+	state.Keys = make([]string, 0, len(keySet))
+	for k := range keySet {
+		state.Keys = append(state.Keys, k)
+	}
+	sort.Strings(state.Keys)
+
+	path := c.statePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logger.Warn("创建 Zotero 同步状态目录失败: %v", err)
+		return
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		logger.Warn("序列化 Zotero 同步状态失败: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		logger.Warn("保存 Zotero 同步状态失败: %v", err)
+	}
+}
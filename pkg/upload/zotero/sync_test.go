@@ -0,0 +1,159 @@
+package zotero
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"PaperHunter/internal/models"
+)
+
+// mockZotero 模拟 Zotero API：GET 返回已有条目，POST 记录上传的条目
+type mockZotero struct {
+	mu        sync.Mutex
+	existing  []Item
+	version   int
+	sinceSeen []string
+	posted    []ItemData
+	posts     int
+	failTitle string
+}
+
+func (m *mockZotero) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if r.URL.Path != "/users/u1/items" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			m.sinceSeen = append(m.sinceSeen, r.URL.Query().Get("since"))
+			items := m.existing
+			if r.URL.Query().Get("since") != "" {
+				// 库自上次同步后无变化
+				items = []Item{}
+			}
+			w.Header().Set("Last-Modified-Version", strconv.Itoa(m.version))
+			json.NewEncoder(w).Encode(items)
+		case http.MethodPost:
+			var items []ItemData
+			if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+				t.Errorf("decode POST body: %v", err)
+			}
+			m.posts++
+			resp := CreateResponse{Success: map[string]string{}, Failed: map[string]FailedItem{}}
+			for i, item := range items {
+				if m.failTitle != "" && item.Title == m.failTitle {
+					resp.Failed[strconv.Itoa(i)] = FailedItem{Code: 400, Message: "invalid"}
+					continue
+				}
+				m.posted = append(m.posted, item)
+				resp.Success[strconv.Itoa(i)] = "KEY" + strconv.Itoa(len(m.posted))
+			}
+			m.version += 5
+			w.Header().Set("Last-Modified-Version", strconv.Itoa(m.version))
+			json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func newTestClient(t *testing.T, m *mockZotero) *Client {
+	t.Helper()
+	srv := httptest.NewServer(m.handler(t))
+	t.Cleanup(srv.Close)
+
+	c := NewClient("u1", "key")
+	c.baseURL = srv.URL
+	c.SetSyncStatePath(filepath.Join(t.TempDir(), "zotero_sync_state.json"))
+	return c
+}
+
+func extraItem(extra string) Item {
+	return Item{Data: ItemData{Title: "existing", Extra: &extra}}
+}
+
+func TestSyncPapers_SkipsKnownExtraKeys(t *testing.T) {
+	m := &mockZotero{
+		version:  100,
+		existing: []Item{extraItem("arXiv:2401.00001\n标题：已有论文"), extraItem("acl:2023.acl-long.5")},
+	}
+	c := newTestClient(t, m)
+
+	papers := []*models.Paper{
+		{Source: "arxiv", SourceID: "2401.00001", Title: "Known arXiv"},
+		{Source: "arxiv", SourceID: "2401.00002", Title: "Novel arXiv"},
+		{Source: "acl", SourceID: "2023.acl-long.5", Title: "Known ACL"},
+		{Source: "openreview", SourceID: "abc", Title: "Novel OpenReview"},
+		{Source: "arxiv", SourceID: "2401.00002", Title: "Novel arXiv duplicate"},
+	}
+
+	added, skipped, err := c.SyncPapers(papers, "")
+	if err != nil {
+		t.Fatalf("SyncPapers() error: %v", err)
+	}
+	if added != 2 || skipped != 3 {
+		t.Errorf("Expected added=2 skipped=3, got added=%d skipped=%d", added, skipped)
+	}
+	if len(m.posted) != 2 || m.posted[0].Title != "Novel arXiv" || m.posted[1].Title != "Novel OpenReview" {
+		t.Errorf("Unexpected posted items: %+v", m.posted)
+	}
+	if m.sinceSeen[0] != "" {
+		t.Errorf("First sync should fetch the full library, got since=%q", m.sinceSeen[0])
+	}
+
+	// 第二次同步：使用缓存的库版本只拉取增量，已上传的论文不再重复上传
+	added, skipped, err = c.SyncPapers(papers, "")
+	if err != nil {
+		t.Fatalf("second SyncPapers() error: %v", err)
+	}
+	if added != 0 || skipped != 5 {
+		t.Errorf("Expected added=0 skipped=5 on resync, got added=%d skipped=%d", added, skipped)
+	}
+	if m.posts != 1 {
+		t.Errorf("Expected no additional POST, got %d POSTs", m.posts)
+	}
+	if got := m.sinceSeen[len(m.sinceSeen)-1]; got != "105" {
+		t.Errorf("Expected incremental fetch since=105, got %q", got)
+	}
+}
+
+func TestSyncPapers_FailedItemsRetried(t *testing.T) {
+	m := &mockZotero{version: 10, failTitle: "Broken"}
+	c := newTestClient(t, m)
+
+	papers := []*models.Paper{
+		{Source: "arxiv", SourceID: "1", Title: "Broken"},
+		{Source: "arxiv", SourceID: "2", Title: "Fine"},
+	}
+	added, skipped, err := c.SyncPapers(papers, "")
+	if err != nil {
+		t.Fatalf("SyncPapers() error: %v", err)
+	}
+	if added != 1 || skipped != 0 {
+		t.Errorf("Expected added=1 skipped=0, got added=%d skipped=%d", added, skipped)
+	}
+
+	// 上传失败的论文不记入同步状态，下次同步会重新上传
+	m.failTitle = ""
+	added, skipped, err = c.SyncPapers(papers, "")
+	if err != nil {
+		t.Fatalf("second SyncPapers() error: %v", err)
+	}
+	if added != 1 || skipped != 1 {
+		t.Errorf("Expected added=1 skipped=1 on retry, got added=%d skipped=%d", added, skipped)
+	}
+	if len(m.posted) != 2 || m.posted[1].Title != "Broken" {
+		t.Errorf("Expected failed paper to be retried, posted: %+v", m.posted)
+	}
+}
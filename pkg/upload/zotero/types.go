@@ -341,6 +341,7 @@ POST /users/{userID}/items
 413 Request Entity Too Large 	提交项目过多
 */
 type CreateResponse struct {
+	Success    map[string]string     `json:"success"` // 序号 -> 新建对象的 key
 	Successful map[string]Item       `json:"successful"`
 	Unchanged  map[string]Item       `json:"unchanged"`
 	Failed     map[string]FailedItem `json:"failed"`
@@ -376,3 +377,25 @@ type CollectionMeta struct {
 	NumCollections IntOrBool `json:"numCollections"`
 	NumItems       IntOrBool `json:"numItems"`
 }
+
+// Group 群组库，GET /users/<userID>/groups 返回
+type Group struct {
+	ID      int       `json:"id"`
+	Version int       `json:"version"`
+	Meta    GroupMeta `json:"meta"`
+	Data    GroupData `json:"data"`
+}
+
+// GroupData 群组信息
+type GroupData struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"` // Private / PublicOpen / PublicClosed
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+// GroupMeta 群组元数据
+type GroupMeta struct {
+	NumItems int `json:"numItems"`
+}